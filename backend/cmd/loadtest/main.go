@@ -0,0 +1,86 @@
+// Command loadtest replays a request scenario against a running server. The
+// graph queries behind the main reads and the JSON encoders are benchmarked on
+// their own with `make bench-queries` and `make bench-encoders`.
+//
+//	go run ./cmd/loadtest                                   # bundled demo-day scenario against localhost
+//	go run ./cmd/loadtest -scenario smoke -base https://...  # another bundled scenario and server
+//	go run ./cmd/loadtest -file scenario.json -json          # a scenario on disk, JSON report
+//
+// The process exits with status 1 when a scenario exceeds its thresholds, so it
+// can gate a deployment.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/loadtest"
+	"github.com/mayura-andrew/fastfinder/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	name := flag.String("scenario", "", "bundled scenario (default "+loadtest.DefaultScenario+")")
+	file := flag.String("file", "", "load the scenario from a JSON file")
+	base := flag.String("base", "http://localhost:8080", "base URL of the server under test")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	list := flag.Bool("list", false, "list the bundled scenarios and exit")
+	flag.Parse()
+
+	if *list {
+		fmt.Println(strings.Join(loadtest.Scenarios(), "\n"))
+		return
+	}
+
+	if err := logger.Initialize(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+	log := logger.MustGetLogger()
+
+	var scenario *loadtest.Scenario
+	var err error
+	if *file != "" {
+		scenario, err = loadtest.ReadFile(*file)
+	} else {
+		scenario, err = loadtest.Load(*name)
+	}
+	if err != nil {
+		log.Fatal("Failed to load scenario", zap.Error(err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Info("Running load test scenario",
+		zap.String("scenario", scenario.Name),
+		zap.String("base", *base),
+		zap.Int("rate", scenario.Rate),
+		zap.Duration("duration", time.Duration(scenario.Duration)))
+
+	report, err := loadtest.Run(ctx, &http.Client{}, *base, scenario)
+	if err != nil {
+		log.Fatal("Load test failed", zap.Error(err))
+	}
+	if *asJSON {
+		printJSON(report)
+	} else {
+		report.Format(os.Stdout)
+	}
+	if !report.Passed() {
+		os.Exit(1)
+	}
+}
+
+func printJSON(report any) {
+	encoded, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(encoded))
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newCacheCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and manage the learning roadmap cache",
+	}
+
+	stats := &cobra.Command{
+		Use:   "stats",
+		Short: "Show roadmap cache statistics",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodGet,
+				"/api/v1/pathway/cache/stats", nil, nil, ""))
+		},
+	}
+
+	clear := &cobra.Command{
+		Use:   "clear [PROGRAM]",
+		Short: "Remove one program's cached roadmap, or every cached roadmap",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "/api/v1/pathway/cache"
+			if len(args) == 1 {
+				path += "/" + pathSegment(args[0])
+			}
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodDelete, path, nil, nil, ""))
+		},
+	}
+
+	refresh := &cobra.Command{
+		Use:   "refresh PROGRAM",
+		Short: "Regenerate a program's cached roadmap",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodPost,
+				"/api/v1/pathway/cache/"+pathSegment(args[0])+"/refresh", nil, nil, ""))
+		},
+	}
+
+	var file string
+	warm := &cobra.Command{
+		Use:   "warm [PROGRAM...]",
+		Short: "Generate and cache roadmaps for programs that are not cached yet",
+		Long: "Requests the learning roadmap of each program, so it is generated and cached\n" +
+			"before students ask for it. Programs come from the arguments and from --file,\n" +
+			"one per line. Programs that fail are reported and the rest are still warmed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			programs := args
+			if file != "" {
+				listed, err := readLines(file)
+				if err != nil {
+					return err
+				}
+				programs = append(programs, listed...)
+			}
+			if len(programs) == 0 {
+				return fmt.Errorf("no programs to warm: pass program names or --file")
+			}
+
+			client := newAPIClient(opts)
+			failed := 0
+			for _, program := range programs {
+				started := time.Now()
+				err := warmRoadmap(cmd.Context(), client, program)
+				elapsed := time.Since(started).Round(time.Millisecond)
+				if err != nil {
+					failed++
+					printf(cmd, "FAIL  %s: %s\n", program, err)
+					continue
+				}
+				printf(cmd, "OK    %s (%s)\n", program, elapsed)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d programs failed", failed, len(programs))
+			}
+			return nil
+		},
+	}
+	warm.Flags().StringVar(&file, "file", "", "file listing programs to warm, one per line (- reads stdin)")
+
+	cmd.AddCommand(stats, clear, refresh, warm)
+	return cmd
+}
+
+// warmRoadmap requests a program's full roadmap, which the server caches
+func warmRoadmap(ctx context.Context, client *apiClient, program string) error {
+	_, err := client.do(ctx, http.MethodGet,
+		"/api/v1/pathway/programs/"+pathSegment(program)+"/learning-roadmap", nil, nil, "")
+	return err
+}
+
+// readLines reads the non-empty lines of a file, or of stdin for "-"
+func readLines(name string) ([]string, error) {
+	input := os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// selfCheck is a request the server must answer successfully to be healthy
+type selfCheck struct {
+	name  string
+	path  string
+	admin bool // only run with an admin key
+}
+
+var selfChecks = []selfCheck{
+	{name: "liveness", path: "/health"},
+	{name: "readiness", path: "/ready"},
+	{name: "institute listing", path: "/api/v1/pathway/institutes"},
+	{name: "career listing", path: "/api/v1/pathway/careers"},
+	{name: "search", path: "/api/v1/search?q=engineering"},
+	{name: "roadmap cache", path: "/api/v1/pathway/cache/stats"},
+	{name: "admin access", path: "/api/v1/admin/changes?limit=1", admin: true},
+}
+
+func newCheckCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Run self-checks against the server",
+		Long: "Sends the requests a healthy server must answer: liveness, readiness, the main\n" +
+			"listings, search and the roadmap cache, and admin access when a key is set.\n" +
+			"Exits with status 1 if any check fails.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client := newAPIClient(opts)
+			failed := 0
+			for _, check := range selfChecks {
+				if check.admin && opts.adminKey == "" {
+					printf(cmd, "SKIP  %-18s no admin key\n", check.name)
+					continue
+				}
+
+				ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+				started := time.Now()
+				_, err := client.do(ctx, http.MethodGet, check.path, nil, nil, "")
+				cancel()
+
+				elapsed := time.Since(started).Round(time.Millisecond)
+				if err != nil {
+					failed++
+					printf(cmd, "FAIL  %-18s %s (%s)\n", check.name, err, elapsed)
+					continue
+				}
+				printf(cmd, "PASS  %-18s %s\n", check.name, elapsed)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d checks failed", failed, len(selfChecks))
+			}
+			return nil
+		},
+	}
+}
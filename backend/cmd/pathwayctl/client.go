@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// apiClient sends requests to the API server and unwraps its response envelope
+type apiClient struct {
+	base     string
+	adminKey string
+	http     *http.Client
+}
+
+func newAPIClient(opts *options) *apiClient {
+	return &apiClient{
+		base:     strings.TrimRight(opts.server, "/"),
+		adminKey: opts.adminKey,
+		http:     &http.Client{Timeout: opts.timeout},
+	}
+}
+
+// apiError is a non-2xx response, carrying the envelope's error message
+type apiError struct {
+	Status  int
+	Message string
+}
+
+func (e *apiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("server answered %d %s", e.Status, http.StatusText(e.Status))
+	}
+	return fmt.Sprintf("server answered %d: %s", e.Status, e.Message)
+}
+
+// do sends a request and returns the response body. Query values that are
+// empty are left out; body, when not nil, is sent with contentType.
+func (a *apiClient) do(ctx context.Context, method, path string, query url.Values, body io.Reader, contentType string) ([]byte, error) {
+	target := a.base + path
+	if encoded := query.Encode(); encoded != "" {
+		target += "?" + encoded
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if a.adminKey != "" {
+		req.Header.Set("X-Admin-Key", a.adminKey)
+	}
+
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var envelope struct {
+			Error string `json:"error"`
+		}
+		_ = json.Unmarshal(data, &envelope)
+		return data, &apiError{Status: resp.StatusCode, Message: envelope.Error}
+	}
+	return data, nil
+}
+
+// indent pretty-prints a JSON response for the terminal, returning other
+// bodies unchanged
+func indent(data []byte) string {
+	var b bytes.Buffer
+	if err := json.Indent(&b, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return b.String()
+}
+
+// queryValues builds query values from name/value pairs, skipping empty values
+func queryValues(pairs ...string) url.Values {
+	query := url.Values{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			query.Set(pairs[i], pairs[i+1])
+		}
+	}
+	return query
+}
+
+// pathSegment escapes a name for use as one segment of a request path
+func pathSegment(name string) string {
+	return url.PathEscape(name)
+}
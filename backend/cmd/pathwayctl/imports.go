@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func newImportCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import data into the graph through the admin API",
+	}
+
+	var dryRun, allowDuplicates bool
+	var sourceURL string
+	programs := &cobra.Command{
+		Use:   "programs FILE",
+		Short: "Bulk import programs from a JSON file of rows (- reads stdin)",
+		Long: "Sends the rows in FILE to the bulk program import. FILE holds either a\n" +
+			"{\"rows\": [...]} object or a bare array of rows. Every row is validated and\n" +
+			"nothing is written unless all of them are valid.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := readInput(args[0])
+			if err != nil {
+				return err
+			}
+			if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+				data, err = json.Marshal(map[string]json.RawMessage{"rows": trimmed})
+				if err != nil {
+					return err
+				}
+			}
+
+			query := queryValues(
+				"dry_run", strconv.FormatBool(dryRun),
+				"allow_duplicates", strconv.FormatBool(allowDuplicates),
+				"source_url", sourceURL)
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodPost,
+				"/api/v1/admin/import/programs", query, bytes.NewReader(data), "application/json"))
+		},
+	}
+	programs.Flags().BoolVar(&dryRun, "dry-run", false, "validate the rows without writing them")
+	programs.Flags().BoolVar(&allowDuplicates, "allow-duplicates", false, "accept names that closely match existing ones")
+	programs.Flags().StringVar(&sourceURL, "source-url", "", "where the rows came from, recorded as their provenance")
+
+	cmd.AddCommand(programs)
+	return cmd
+}
+
+func newIngestCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingest",
+		Short: "Run the external dataset pipelines",
+	}
+
+	var dryRun bool
+	var format string
+	var year int
+	ugc := &cobra.Command{
+		Use:   "ugc FILE",
+		Short: "Ingest a UGC handbook extract (- reads stdin)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := readInput(args[0])
+			if err != nil {
+				return err
+			}
+			query := queryValues("format", format, "dry_run", strconv.FormatBool(dryRun))
+			if year > 0 {
+				query.Set("year", strconv.Itoa(year))
+			}
+			contentType := "text/csv"
+			if format == "text" {
+				contentType = "text/plain"
+			}
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodPost,
+				"/api/v1/admin/ingest/ugc-handbook", query, bytes.NewReader(data), contentType))
+		},
+	}
+	ugc.Flags().StringVar(&format, "format", "csv", "extract format: csv or text")
+	ugc.Flags().IntVar(&year, "year", 0, "handbook year")
+	ugc.Flags().BoolVar(&dryRun, "dry-run", false, "report what would change without writing")
+
+	tvec := &cobra.Command{
+		Use:   "tvec",
+		Short: "Sync the TVEC course registry",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodPost,
+				"/api/v1/admin/ingest/tvec-sync", queryValues("dry_run", strconv.FormatBool(dryRun)), nil, ""))
+		},
+	}
+	tvec.Flags().BoolVar(&dryRun, "dry-run", false, "report what would change without writing")
+
+	salaries := &cobra.Command{
+		Use:   "salaries",
+		Short: "Sync career salaries from the job boards",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodPost,
+				"/api/v1/admin/ingest/job-board-salaries", nil, nil, ""))
+		},
+	}
+
+	vacancies := &cobra.Command{
+		Use:   "vacancies",
+		Short: "Sync open vacancies from the job boards",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodPost,
+				"/api/v1/admin/ingest/vacancies", nil, nil, ""))
+		},
+	}
+
+	var allowDuplicates bool
+	sheet := &cobra.Command{
+		Use:   "sheet NAME",
+		Short: "Sync a mapped Google Sheet",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := queryValues(
+				"dry_run", strconv.FormatBool(dryRun),
+				"allow_duplicates", strconv.FormatBool(allowDuplicates))
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodPost,
+				"/api/v1/admin/sheets/"+pathSegment(args[0])+"/sync", query, nil, ""))
+		},
+	}
+	sheet.Flags().BoolVar(&dryRun, "dry-run", false, "report what would change without writing")
+	sheet.Flags().BoolVar(&allowDuplicates, "allow-duplicates", false, "accept names that closely match existing ones")
+
+	cmd.AddCommand(ugc, tvec, salaries, vacancies, sheet)
+	return cmd
+}
+
+// readInput reads a file, or stdin for "-"
+func readInput(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// printResponse returns a function printing a response body, including the
+// body of a failed request, which often holds a report worth reading
+func printResponse(cmd *cobra.Command) func([]byte, error) error {
+	return func(data []byte, err error) error {
+		if len(data) > 0 {
+			printf(cmd, "%s\n", indent(data))
+		}
+		return err
+	}
+}
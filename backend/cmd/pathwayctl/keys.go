@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+// adminKeyBytes is the length of generated admin keys before hex encoding
+const adminKeyBytes = 32
+
+func newKeysCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage partner API keys and generate admin keys",
+	}
+
+	create := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create a partner API key with the default quotas",
+		Long: "Creates an API key for the named partner. The key's secret is only shown in\n" +
+			"this response, so pass it on before it is lost.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := json.Marshal(map[string]string{"name": args[0]})
+			if err != nil {
+				return err
+			}
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodPost,
+				"/api/v1/admin/api-keys", nil, bytes.NewReader(data), "application/json"))
+		},
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List partner API keys, including revoked ones",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodGet,
+				"/api/v1/admin/api-keys", nil, nil, ""))
+		},
+	}
+
+	revoke := &cobra.Command{
+		Use:   "revoke ID",
+		Short: "Revoke a partner API key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printResponse(cmd)(newAPIClient(opts).do(cmd.Context(), http.MethodDelete,
+				"/api/v1/admin/api-keys/"+pathSegment(args[0]), nil, nil, ""))
+		},
+	}
+
+	issue := &cobra.Command{
+		Use:   "issue",
+		Short: "Generate a new admin API key, locally",
+		Long: "Generates a random admin API key. The server accepts a single admin key, read\n" +
+			"from ADMIN_API_KEY at startup, so set it there and restart the server to\n" +
+			"issue the key; the previous key stops working at the same time.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			key := make([]byte, adminKeyBytes)
+			if _, err := rand.Read(key); err != nil {
+				return err
+			}
+			encoded := hex.EncodeToString(key)
+			printf(cmd, "%s\n", encoded)
+			cmd.PrintErrf("Set ADMIN_API_KEY=%s on the server and restart it to use this key\n", encoded)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(create, list, revoke, issue)
+	return cmd
+}
@@ -0,0 +1,71 @@
+// Command pathwayctl runs common operations against a PathwayLK API server, so
+// they do not need hand-written curl commands.
+//
+//	pathwayctl check                                   # self-checks against the server
+//	pathwayctl import programs rows.json --dry-run     # validate a bulk import
+//	pathwayctl ingest tvec                             # trigger the TVEC registry sync
+//	pathwayctl cache warm "ICT Technician (NVQ Level 3)"
+//	pathwayctl keys create "Example Partner"           # create a partner API key
+//	pathwayctl keys issue                              # generate an admin key
+//	pathwayctl seed                                    # seed an empty graph (uses NEO4J_* settings)
+//
+// The server and admin key come from --server and --admin-key, or the
+// PATHWAYCTL_SERVER and ADMIN_API_KEY environment variables.
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// options are the flags shared by every command
+type options struct {
+	server   string
+	adminKey string
+	timeout  time.Duration
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+	root := &cobra.Command{
+		Use:          "pathwayctl",
+		Short:        "Operate a PathwayLK API server",
+		SilenceUsage: true,
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.server, "server", envOr("PATHWAYCTL_SERVER", "http://localhost:8080"), "base URL of the API server")
+	flags.StringVar(&opts.adminKey, "admin-key", os.Getenv("ADMIN_API_KEY"), "admin API key, sent as X-Admin-Key")
+	flags.DurationVar(&opts.timeout, "timeout", 10*time.Minute, "how long to wait for each request")
+
+	root.AddCommand(
+		newCheckCommand(opts),
+		newImportCommand(opts),
+		newIngestCommand(opts),
+		newCacheCommand(opts),
+		newKeysCommand(opts),
+		newSeedCommand(),
+	)
+	return root
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// printf writes command output to stdout, leaving stderr to cobra's errors
+func printf(cmd *cobra.Command, format string, args ...any) {
+	fmt.Fprintf(cmd.OutOrStdout(), format, args...)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/seed"
+	"github.com/mayura-andrew/fastfinder/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+func newSeedCommand() *cobra.Command {
+	var version, file, url string
+	var force, list bool
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Load a seed dataset into an empty graph",
+		Long: "Loads a versioned seed dataset straight into Neo4j, connecting with the same\n" +
+			"NEO4J_* settings as the server. The graph must be empty unless --force is set.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if list {
+				printf(cmd, "%s\n", strings.Join(seed.Versions(), "\n"))
+				return nil
+			}
+
+			if err := logger.Initialize(); err != nil {
+				return fmt.Errorf("failed to initialize logger: %w", err)
+			}
+			defer logger.Sync()
+			log := logger.MustGetLogger()
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			ctx := cmd.Context()
+			var dataset *seed.Dataset
+			switch {
+			case file != "":
+				dataset, err = seed.ReadFile(file)
+			case url != "":
+				dataset, err = seed.Fetch(ctx, url)
+			default:
+				dataset, err = seed.Load(version)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to load seed dataset: %w", err)
+			}
+
+			client, err := neo4j.NewClient(cfg.Neo4j)
+			if err != nil {
+				return fmt.Errorf("failed to connect to Neo4j: %w", err)
+			}
+			defer client.Close(context.Background())
+
+			report, err := seed.NewSeeder(client, log).Apply(ctx, dataset, force)
+			if err != nil {
+				return fmt.Errorf("failed to seed graph: %w", err)
+			}
+			if !report.Applied {
+				encoded, _ := json.MarshalIndent(report, "", "  ")
+				cmd.PrintErrln(string(encoded))
+				return fmt.Errorf("seed dataset has %d invalid rows; nothing was written", report.Summary.Invalid)
+			}
+
+			printf(cmd, "Seeded dataset %s: %d programs created, %d updated\n",
+				dataset.Version, report.Summary.Created, report.Summary.Updated)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&version, "version", "", "bundled dataset version (default "+seed.LatestVersion+")")
+	cmd.Flags().StringVar(&file, "file", "", "load the dataset from a JSON or CSV file")
+	cmd.Flags().StringVar(&url, "url", "", "download the dataset from a URL")
+	cmd.Flags().BoolVar(&force, "force", false, "seed even if the graph already has data")
+	cmd.Flags().BoolVar(&list, "list", false, "list the bundled dataset versions")
+	return cmd
+}
@@ -9,14 +9,20 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/mayura-andrew/fastfinder/internal/api/jsoncodec"
 	"github.com/mayura-andrew/fastfinder/internal/api/routes"
 	"github.com/mayura-andrew/fastfinder/internal/containers"
 	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/jobs"
 	"github.com/mayura-andrew/fastfinder/pkg/logger"
 	"go.uber.org/zap"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate-config" {
+		os.Exit(validateConfigCommand(os.Args[2:]))
+	}
+
 	// Initialize logger
 	if err := logger.Initialize(); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
@@ -33,9 +39,15 @@ func main() {
 		log.Fatal("Failed to load configuration", zap.Error(err))
 	}
 
+	if err := jsoncodec.Use(cfg.Server.JSONEncoder); err != nil {
+		log.Fatal("Invalid JSON encoder", zap.Error(err))
+	}
+
 	log.Info("Configuration loaded",
 		zap.String("environment", cfg.Server.Environment),
-		zap.Int("port", cfg.Server.Port))
+		zap.Int("port", cfg.Server.Port),
+		zap.String("json_encoder", jsoncodec.Name()),
+		zap.Bool("demo", cfg.Server.Demo))
 
 	// Initialize container with all dependencies
 	container, err := containers.NewContainer(cfg)
@@ -46,6 +58,16 @@ func main() {
 	// Setup routes
 	router := routes.SetupRoutes(container, cfg, log)
 
+	// Start background jobs
+	scheduler := jobs.NewScheduler(container.Leases(), log)
+	if cfg.Server.Demo {
+		// The demo graph never changes and there is no database to sync into
+		log.Info("Demo mode: background jobs disabled")
+	} else {
+		registerJobs(scheduler, container, cfg)
+	}
+	scheduler.Start()
+
 	// Create HTTP server
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 	server := &http.Server{
@@ -85,5 +107,94 @@ func main() {
 		log.Error("Server forced to shutdown", zap.Error(err))
 	}
 
+	scheduler.Stop()
+
+	// Keep the API key usage counted since the last flush
+	if keys := container.APIKeyService(); keys != nil {
+		if err := keys.Flush(ctx); err != nil {
+			log.Error("Failed to store API key usage", zap.Error(err))
+		}
+	}
+
+	// Keep the experiment counts since the last flush
+	if experiments := container.ExperimentService(); experiments != nil {
+		if err := experiments.Flush(ctx); err != nil {
+			log.Error("Failed to store experiment counts", zap.Error(err))
+		}
+	}
+
 	log.Info("Server exited gracefully")
 }
+
+// registerJobs adds the enabled recurring jobs to the scheduler. Jobs writing
+// the graph, the database or the discovery index run on one instance at a time;
+// jobs rebuilding an instance's own indexes and snapshots run on every instance.
+func registerJobs(scheduler *jobs.Scheduler, container containers.Container, cfg *config.Config) {
+	if cfg.TVEC.SyncEnabled && cfg.TVEC.RegistryURL != "" {
+		scheduler.RegisterExclusive("tvec-registry-sync", cfg.TVEC.SyncInterval, 10*time.Minute, func(ctx context.Context) error {
+			_, err := container.IngestionService().SyncTVECRegistry(ctx, false)
+			return err
+		})
+	}
+
+	if cfg.JobBoard.SyncEnabled && len(cfg.JobBoard.SearchURLs) > 0 {
+		scheduler.RegisterExclusive("job-board-salary-sync", cfg.JobBoard.SyncInterval, 2*time.Hour, func(ctx context.Context) error {
+			_, err := container.IngestionService().SyncJobBoardSalaries(ctx)
+			return err
+		})
+	}
+
+	if cfg.JobBoard.VacancySyncEnabled && len(cfg.JobBoard.SearchURLs) > 0 {
+		scheduler.RegisterExclusive("vacancy-sync", cfg.JobBoard.VacancySyncInterval, 2*time.Hour, func(ctx context.Context) error {
+			_, err := container.VacancyService().Sync(ctx)
+			return err
+		})
+	}
+
+	scheduler.RegisterExclusive("career-demand", cfg.Demand.Interval, 30*time.Minute, func(ctx context.Context) error {
+		_, err := container.DemandService().Recompute(ctx)
+		return err
+	})
+
+	if cfg.Sheets.SyncEnabled {
+		scheduler.RegisterExclusive("sheets-sync", cfg.Sheets.SyncInterval, 30*time.Minute, container.SheetsService().SyncAll)
+	}
+
+	scheduler.Register("typeahead-refresh", cfg.Neo4j.TypeaheadRefreshInterval, 5*time.Minute, func(ctx context.Context) error {
+		_, err := container.TypeaheadService().Refresh(ctx)
+		return err
+	})
+
+	// Each instance tracks whether the access edges are current, so each rebuilds
+	// them; rebuilds are safe to run concurrently
+	scheduler.Register("pathway-accessibility", cfg.Neo4j.MaterializeInterval, time.Hour, func(ctx context.Context) error {
+		_, err := container.PathwayService().RebuildAccessibility(ctx)
+		return err
+	})
+
+	scheduler.RegisterExclusive("pathway-materialize", cfg.Neo4j.MaterializeInterval, time.Hour, func(ctx context.Context) error {
+		_, err := container.PathwayService().MaterializeViews(ctx)
+		return err
+	})
+
+	scheduler.Register("listing-snapshot", cfg.Neo4j.SnapshotInterval, 10*time.Minute, func(ctx context.Context) error {
+		_, err := container.PathwayService().RefreshSnapshot(ctx)
+		return err
+	})
+
+	if container.DiscoveryService().Available() {
+		scheduler.RegisterExclusive("discovery-reindex", cfg.Weaviate.ReindexInterval, 10*time.Minute, func(ctx context.Context) error {
+			_, err := container.DiscoveryService().Reindex(ctx)
+			return err
+		})
+	}
+
+	// Each instance counts its own API key usage and experiment outcomes and adds
+	// them to the stored totals
+	scheduler.Register("api-usage-flush", cfg.APIKeys.UsageFlushInterval, time.Minute, container.APIKeyService().Flush)
+	scheduler.Register("experiment-flush", cfg.Experiments.FlushInterval, time.Minute, container.ExperimentService().Flush)
+
+	if cfg.Backup.Enabled {
+		scheduler.RegisterExclusive("graph-backup", cfg.Backup.Interval, time.Hour, container.BackupService().Run)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/api/jsoncodec"
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/data/sqlstore"
+	"go.uber.org/zap"
+)
+
+// validateConfigCommand implements "server validate-config": it checks the
+// configuration the server would start with and prints every problem found,
+// optionally probing Neo4j, MongoDB and a SQL cache store, so a bad deployment fails here instead
+// of crash-looping. It returns the process exit status.
+//
+//	server validate-config            # settings only
+//	server validate-config -probe     # also connect to Neo4j and MongoDB
+func validateConfigCommand(args []string) int {
+	flags := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	probe := flags.Bool("probe", false, "also connect to Neo4j and MongoDB")
+	timeout := flags.Duration("timeout", 10*time.Second, "how long each connectivity probe may take")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg := config.FromEnv()
+	problems := config.Validate(cfg)
+	if err := jsoncodec.Use(cfg.Server.JSONEncoder); err != nil {
+		problems = append(problems, config.Problem{Severity: config.SeverityError, Env: "JSON_ENCODER", Message: err.Error()})
+	}
+
+	out := os.Stdout
+	fmt.Fprintf(out, "Configuration (environment %s)\n", cfg.Server.Environment)
+	errorCount, warningCount := 0, 0
+	for _, problem := range problems {
+		label := "WARN "
+		if problem.Severity == config.SeverityError {
+			label = "ERROR"
+			errorCount++
+		} else {
+			warningCount++
+		}
+		fmt.Fprintf(out, "  %s  %-24s %s\n", label, problem.Env, problem.Message)
+	}
+	if len(problems) == 0 {
+		fmt.Fprintln(out, "  OK     no problems found")
+	}
+
+	failed := 0
+	if *probe {
+		fmt.Fprintln(out, "\nConnectivity")
+		probes := []connectivityProbe{
+			{"Neo4j", func(ctx context.Context) error { return neo4j.VerifyConnectivity(ctx, cfg.Neo4j) }},
+			{"MongoDB", func(ctx context.Context) error {
+				return mongodb.Ping(ctx, mongodb.Config{
+					URI:            cfg.MongoDB.URI,
+					Username:       cfg.MongoDB.Username,
+					Password:       cfg.MongoDB.Password,
+					ConnectTimeout: min(cfg.MongoDB.ConnectTimeout, *timeout),
+				})
+			}},
+		}
+		if cfg.Cache.Store != "mongodb" {
+			probes = append(probes, connectivityProbe{"Cache (" + cfg.Cache.Store + ")", func(ctx context.Context) error {
+				client, err := sqlstore.NewClient(cfg.Cache, zap.NewNop())
+				if err != nil {
+					return err
+				}
+				return client.Close()
+			}})
+		}
+		for _, p := range probes {
+			if !runProbe(out, p.name, *timeout, p.run) {
+				failed++
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "\n%d %s, %d %s", errorCount, plural(errorCount, "error"), warningCount, plural(warningCount, "warning"))
+	if *probe {
+		fmt.Fprintf(out, ", %d failed %s", failed, plural(failed, "probe"))
+	}
+	fmt.Fprintln(out)
+
+	if errorCount > 0 || failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// connectivityProbe is a named connectivity check
+type connectivityProbe struct {
+	name string
+	run  func(context.Context) error
+}
+
+// runProbe runs one connectivity probe and reports whether it succeeded
+func runProbe(out io.Writer, name string, timeout time.Duration, run func(context.Context) error) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := run(ctx)
+	elapsed := time.Since(started).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(out, "  FAIL   %-24s %s (%s)\n", name, strings.TrimSpace(err.Error()), elapsed)
+		return false
+	}
+	fmt.Fprintf(out, "  OK     %-24s reachable (%s)\n", name, elapsed)
+	return true
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/abuse"
+	"go.uber.org/zap"
+)
+
+// AbuseHandler handles reviewing and lifting blocks on abusive clients
+type AbuseHandler struct {
+	service *abuse.Service
+	logger  *zap.Logger
+}
+
+// NewAbuseHandler creates a new abuse handler
+func NewAbuseHandler(service *abuse.Service, logger *zap.Logger) *AbuseHandler {
+	return &AbuseHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListBlocks handles GET /api/v1/admin/abuse/blocks
+func (h *AbuseHandler) ListBlocks(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	blocks := h.service.Blocks()
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"data":           blocks,
+		"count":          len(blocks),
+		"enabled":        h.service.Enabled(),
+		"honeypot_paths": h.service.HoneypotPaths(),
+		"request_id":     requestID,
+		"timestamp":      time.Now().UTC(),
+	})
+}
+
+// Unblock handles DELETE /api/v1/admin/abuse/blocks/*key, e.g.
+// /api/v1/admin/abuse/blocks/subnet:203.0.113.0/24
+func (h *AbuseHandler) Unblock(c *gin.Context) {
+	requestID := c.GetString("request_id")
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	if err := h.service.Unblock(key, reviewer(c)); err != nil {
+		if errors.Is(err, abuse.ErrBlockNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success":    false,
+				"error":      err.Error(),
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		h.logger.Error("Failed to lift block",
+			zap.String("request_id", requestID),
+			zap.String("key", key),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to lift block",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       gin.H{"key": key, "unblocked": true},
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,652 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/admin"
+	"go.uber.org/zap"
+)
+
+// AdminHandler handles administrative graph maintenance requests
+type AdminHandler struct {
+	service *admin.Service
+	logger  *zap.Logger
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(service *admin.Service, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// entityKinds maps the plural path segment of admin routes to graph entity kinds
+var entityKinds = map[string]string{
+	"institutes":     neo4j.KindInstitute,
+	"faculties":      neo4j.KindFaculty,
+	"departments":    neo4j.KindDepartment,
+	"programs":       neo4j.KindProgram,
+	"qualifications": neo4j.KindQualification,
+	"careers":        neo4j.KindCareer,
+	"scholarships":   neo4j.KindScholarship,
+}
+
+// CreateEntity handles POST /api/v1/admin/:entity
+func (h *AdminHandler) CreateEntity(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	kind, ok := h.entityKind(c, requestID)
+	if !ok {
+		return
+	}
+
+	var entity neo4j.GraphEntity
+	if err := c.ShouldBindJSON(&entity); err != nil {
+		h.respondBadBody(c, requestID, err)
+		return
+	}
+
+	if entity.Provenance == nil {
+		provenance := neo4j.NewProvenance(admin.SourceAdmin, "")
+		provenance.VerifiedBy = reviewer(c)
+		entity.Provenance = &provenance
+	}
+
+	h.logger.Info("Admin creating graph entity",
+		zap.String("request_id", requestID),
+		zap.String("kind", kind),
+		zap.String("name", entity.Name))
+
+	if err := h.service.CreateEntity(ctx, kind, entity, reviewer(c)); err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success":    true,
+		"kind":       kind,
+		"data":       entity,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// UpdateEntity handles PUT /api/v1/admin/:entity/:name
+func (h *AdminHandler) UpdateEntity(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	kind, ok := h.entityKind(c, requestID)
+	if !ok {
+		return
+	}
+
+	var entity neo4j.GraphEntity
+	if err := c.ShouldBindJSON(&entity); err != nil {
+		h.respondBadBody(c, requestID, err)
+		return
+	}
+
+	h.logger.Info("Admin updating graph entity",
+		zap.String("request_id", requestID),
+		zap.String("kind", kind),
+		zap.String("name", name))
+
+	if err := h.service.UpdateEntity(ctx, kind, name, entity, reviewer(c)); err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"kind":       kind,
+		"name":       name,
+		"data":       entity,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetProvenance handles GET /api/v1/admin/:entity/:name/provenance
+func (h *AdminHandler) GetProvenance(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	kind, ok := h.entityKind(c, requestID)
+	if !ok {
+		return
+	}
+
+	provenance, err := h.service.GetProvenance(ctx, kind, name)
+	if err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"kind":       kind,
+		"name":       name,
+		"data":       provenance,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SaveProgramIntakes handles PUT /api/v1/admin/programs/:name/intakes
+// Body: {"intakes": [{"year": 2024, "intake": 120, "applications": 850, "source": "..."}]}
+func (h *AdminHandler) SaveProgramIntakes(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	kind, ok := h.entityKind(c, requestID)
+	if !ok {
+		return
+	}
+	if kind != neo4j.KindProgram {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success":    false,
+			"error":      "Only programs have intake data",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	var request struct {
+		Intakes []mongodb.ProgramIntake `json:"intakes" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.respondBadBody(c, requestID, err)
+		return
+	}
+
+	h.logger.Info("Admin saving program intakes",
+		zap.String("request_id", requestID),
+		zap.String("program", name),
+		zap.Int("years", len(request.Intakes)))
+
+	intakes, err := h.service.SaveProgramIntakes(ctx, name, request.Intakes)
+	if err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"name":       name,
+		"data":       intakes,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SaveAptitudeTests handles PUT /api/v1/admin/programs/:name/aptitude-tests
+// Body: {"tests": [{"name": "...", "kind": "aptitude", "dates": [{"label": "Test", "date": "2025-03-15T09:00:00+05:30"}], "past_papers": [{"title": "...", "year": 2024, "url": "..."}]}]}
+// An empty list removes the program's tests.
+func (h *AdminHandler) SaveAptitudeTests(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	kind, ok := h.entityKind(c, requestID)
+	if !ok {
+		return
+	}
+	if kind != neo4j.KindProgram {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success":    false,
+			"error":      "Only programs have aptitude tests",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	var request struct {
+		Tests []mongodb.AptitudeTest `json:"tests" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.respondBadBody(c, requestID, err)
+		return
+	}
+
+	h.logger.Info("Admin saving aptitude tests",
+		zap.String("request_id", requestID),
+		zap.String("program", name),
+		zap.Int("tests", len(request.Tests)))
+
+	tests, err := h.service.SaveAptitudeTests(ctx, name, request.Tests)
+	if err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"name":       name,
+		"data":       tests,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SaveLivingCost handles PUT /api/v1/admin/living-costs/:district
+// Body: {"boarding": 15000, "transport": 4000, "food": 18000, "year": 2025, "source": "..."}
+func (h *AdminHandler) SaveLivingCost(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	district := c.Param("district")
+
+	var cost mongodb.LivingCost
+	if err := c.ShouldBindJSON(&cost); err != nil {
+		h.respondBadBody(c, requestID, err)
+		return
+	}
+
+	h.logger.Info("Admin saving living cost",
+		zap.String("request_id", requestID),
+		zap.String("district", district))
+
+	saved, err := h.service.SaveLivingCost(ctx, district, cost)
+	if err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       saved,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetQualificationAliases handles GET /api/v1/admin/qualification-aliases
+func (h *AdminHandler) GetQualificationAliases(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	aliases, err := h.service.ListQualificationAliases(ctx)
+	if err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       aliases,
+		"count":      len(aliases),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SaveQualificationAlias handles PUT /api/v1/admin/qualification-aliases
+// Body: {"alias": "A/L", "name": "G.C.E. (A/L) Examination Pass"}
+// The alias is sent in the body as aliases often contain slashes.
+func (h *AdminHandler) SaveQualificationAlias(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var request struct {
+		Alias string `json:"alias" binding:"required"`
+		Name  string `json:"name" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.respondBadBody(c, requestID, err)
+		return
+	}
+
+	h.logger.Info("Admin saving qualification alias",
+		zap.String("request_id", requestID),
+		zap.String("alias", request.Alias))
+
+	saved, err := h.service.SaveQualificationAlias(ctx, request.Alias, request.Name)
+	if err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       saved,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// DeleteQualificationAlias handles DELETE /api/v1/admin/qualification-aliases/:key
+// The key is the alias as listed, or any spelling of it without slashes.
+func (h *AdminHandler) DeleteQualificationAlias(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	alias := c.Param("key")
+
+	h.logger.Info("Admin deleting qualification alias",
+		zap.String("request_id", requestID),
+		zap.String("alias", alias))
+
+	if err := h.service.DeleteQualificationAlias(ctx, alias); err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"alias":      alias,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// DeleteEntity handles DELETE /api/v1/admin/:entity/:name
+// The entity is kept, hidden from all but admin reads, until it is restored
+func (h *AdminHandler) DeleteEntity(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	kind, ok := h.entityKind(c, requestID)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Admin deleting graph entity",
+		zap.String("request_id", requestID),
+		zap.String("kind", kind),
+		zap.String("name", name))
+
+	if err := h.service.DeleteEntity(ctx, kind, name, reviewer(c)); err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"kind":       kind,
+		"name":       name,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// RestoreEntity handles POST /api/v1/admin/:entity/:name/restore
+// Brings back a deleted entity as it was. What it belongs to and, for a program,
+// what it links to must not be deleted themselves.
+func (h *AdminHandler) RestoreEntity(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	kind, ok := h.entityKind(c, requestID)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Admin restoring graph entity",
+		zap.String("request_id", requestID),
+		zap.String("kind", kind),
+		zap.String("name", name))
+
+	if err := h.service.RestoreEntity(ctx, kind, name, reviewer(c)); err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"kind":       kind,
+		"name":       name,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// LinkProgram handles POST /api/v1/admin/graph/programs/:name/:relation/:target
+// relation is requirements, prerequisites or careers, e.g.
+// POST /api/v1/admin/graph/programs/BSc%20in%20IT/careers/Software%20Engineer
+func (h *AdminHandler) LinkProgram(c *gin.Context) {
+	h.changeProgramLink(c, true)
+}
+
+// UnlinkProgram handles DELETE /api/v1/admin/graph/programs/:name/:relation/:target
+func (h *AdminHandler) UnlinkProgram(c *gin.Context) {
+	h.changeProgramLink(c, false)
+}
+
+func (h *AdminHandler) changeProgramLink(c *gin.Context, link bool) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+	relation := c.Param("relation")
+	target := c.Param("target")
+
+	if !neo4j.IsProgramRelation(relation) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success":    false,
+			"error":      "Unknown program relationship",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Admin changing program relationship",
+		zap.String("request_id", requestID),
+		zap.String("program", name),
+		zap.String("relation", relation),
+		zap.String("target", target),
+		zap.Bool("link", link))
+
+	var changed bool
+	var err error
+	if link {
+		changed, err = h.service.LinkProgram(ctx, name, relation, target, reviewer(c))
+	} else {
+		changed, err = h.service.UnlinkProgram(ctx, name, relation, target, reviewer(c))
+	}
+	if err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"kind":       neo4j.KindProgram,
+		"name":       name,
+		"relation":   relation,
+		"target":     target,
+		"changed":    changed,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SearchEntities handles GET /api/v1/admin/search?q=&type=programs,careers&status=unverified,missing_careers&source=&offset=0&limit=50
+// Searches entities of all (or the given) types by name, provenance source and
+// data quality status. An entity must have every listed status to match.
+func (h *AdminHandler) SearchEntities(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	search := neo4j.EntitySearch{
+		Query:  c.Query("q"),
+		Source: c.Query("source"),
+		Issues: splitQuery(c.Query("status")),
+		Offset: queryInt(c, "offset"),
+		Limit:  queryInt(c, "limit"),
+	}
+	for _, value := range splitQuery(c.Query("type")) {
+		kind, ok := entityKinds[value]
+		if !ok {
+			kind = value
+		}
+		search.Kinds = append(search.Kinds, kind)
+	}
+
+	h.logger.Info("Admin searching entities",
+		zap.String("request_id", requestID),
+		zap.String("query", search.Query),
+		zap.Strings("types", search.Kinds),
+		zap.Strings("statuses", search.Issues))
+
+	result, err := h.service.SearchEntities(ctx, search)
+	if err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       result.Matches,
+		"count":      len(result.Matches),
+		"total":      result.Total,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetHistory handles GET /api/v1/admin/entities/:entity/:name/history?limit=50
+// Returns the admin changes made to an entity, newest first, each with the
+// entity's state before and after and who made it.
+func (h *AdminHandler) GetHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	kind, ok := h.entityKind(c, requestID)
+	if !ok {
+		return
+	}
+
+	changes, err := h.service.GetHistory(ctx, kind, name, queryInt(c, "limit"))
+	if err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"kind":       kind,
+		"name":       name,
+		"data":       changes,
+		"count":      len(changes),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// RevertChange handles POST /api/v1/admin/entities/:entity/:name/history/:id/revert
+// Restores the entity to its state before the given change.
+func (h *AdminHandler) RevertChange(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+	changeID := c.Param("id")
+
+	kind, ok := h.entityKind(c, requestID)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("Admin reverting entity change",
+		zap.String("request_id", requestID),
+		zap.String("kind", kind),
+		zap.String("name", name),
+		zap.String("change_id", changeID))
+
+	if err := h.service.RevertChange(ctx, kind, name, changeID, reviewer(c)); err != nil {
+		h.respondAdminError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"kind":       kind,
+		"name":       name,
+		"reverted":   changeID,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// splitQuery splits a comma-separated query parameter, dropping empty values
+func splitQuery(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// entityKind resolves the :entity path parameter, responding 404 for unknown kinds
+func (h *AdminHandler) entityKind(c *gin.Context, requestID string) (string, bool) {
+	kind, ok := entityKinds[c.Param("entity")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success":    false,
+			"error":      "Unknown entity type",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return "", false
+	}
+	return kind, true
+}
+
+func (h *AdminHandler) respondBadBody(c *gin.Context, requestID string, err error) {
+	h.logger.Warn("Invalid request body",
+		zap.String("request_id", requestID),
+		zap.Error(err))
+	c.JSON(http.StatusBadRequest, gin.H{
+		"success":    false,
+		"error":      "Invalid request body",
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// respondAdminError maps graph write errors to HTTP responses
+func (h *AdminHandler) respondAdminError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, neo4j.ErrEntityNotFound), errors.Is(err, admin.ErrChangeNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, admin.ErrInvalidFilter):
+		status = http.StatusBadRequest
+	case errors.Is(err, neo4j.ErrEntityExists), errors.Is(err, neo4j.ErrHasDependents),
+		errors.Is(err, neo4j.ErrEntityDeleted), errors.Is(err, neo4j.ErrNotDeleted):
+		status = http.StatusConflict
+	case errors.Is(err, neo4j.ErrReferenceNotFound), errors.Is(err, neo4j.ErrInvalidEntity):
+		status = http.StatusUnprocessableEntity
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Admin graph write failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Failed to write to the graph"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
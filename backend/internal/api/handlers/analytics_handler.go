@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/analytics"
+	"go.uber.org/zap"
+)
+
+// AnalyticsHandler serves aggregate usage trends for ministries and NGOs
+type AnalyticsHandler struct {
+	service *analytics.Service
+	logger  *zap.Logger
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(service *analytics.Service, logger *zap.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetTrends handles GET /api/v1/analytics/trends?event=...&district=...&days=...&limit=...
+// Returns the most searched qualifications or targeted careers and daily volume
+func (h *AnalyticsHandler) GetTrends(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	query := analytics.TrendQuery{
+		EventType: c.Query("event"),
+		District:  c.Query("district"),
+		Days:      queryInt(c, "days"),
+		Limit:     queryInt(c, "limit"),
+	}
+
+	h.logger.Info("Fetching analytics trends",
+		zap.String("request_id", requestID),
+		zap.String("event_type", query.EventType))
+
+	report, err := h.service.GetTrends(ctx, query)
+	if err != nil {
+		h.respondAnalyticsError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetDistricts handles GET /api/v1/analytics/districts?event=...&days=...
+// Returns event volume per district
+func (h *AnalyticsHandler) GetDistricts(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	eventType := c.Query("event")
+
+	h.logger.Info("Fetching analytics district breakdown",
+		zap.String("request_id", requestID),
+		zap.String("event_type", eventType))
+
+	districts, err := h.service.GetDistrictBreakdown(ctx, eventType, queryInt(c, "days"))
+	if err != nil {
+		h.respondAnalyticsError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":              true,
+		"data":                 districts,
+		"count":                len(districts),
+		"min_reportable_count": analytics.MinReportableCount,
+		"request_id":           requestID,
+		"timestamp":            time.Now().UTC(),
+	})
+}
+
+// GetOpportunityMap handles GET /api/v1/analytics/districts/opportunities
+// Returns the institutes, programs and open job postings in every district, for
+// mapping where access to education and work is thin
+func (h *AnalyticsHandler) GetOpportunityMap(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Fetching district opportunity map", zap.String("request_id", requestID))
+
+	opportunities, err := h.service.GetOpportunityMap(ctx)
+	if err != nil {
+		h.respondAnalyticsError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       opportunities,
+		"count":      len(opportunities.Districts),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetZeroResults handles GET /api/v1/admin/analytics/zero-results?days=...&limit=...
+// Returns the searches and eligibility checks that most often found nothing, so
+// missing programs and qualification aliases can be added first
+func (h *AnalyticsHandler) GetZeroResults(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Fetching zero-result report", zap.String("request_id", requestID))
+
+	report, err := h.service.GetGapReport(ctx, queryInt(c, "days"), queryInt(c, "limit"))
+	if err != nil {
+		h.respondAnalyticsError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *AnalyticsHandler) respondAnalyticsError(c *gin.Context, requestID string, err error) {
+	if errors.Is(err, analytics.ErrUnknownEventType) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      err.Error(),
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Error("Failed to fetch analytics",
+		zap.String("request_id", requestID),
+		zap.Error(err))
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"success":    false,
+		"error":      "Failed to fetch analytics",
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// queryInt parses an integer query parameter, returning 0 when absent or invalid
+func queryInt(c *gin.Context, key string) int {
+	value, err := strconv.Atoi(c.Query(key))
+	if err != nil {
+		return 0
+	}
+	return value
+}
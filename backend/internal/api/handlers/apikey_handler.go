@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/apikeys"
+	"go.uber.org/zap"
+)
+
+// APIKeyHandler handles partner API keys and their usage reports
+type APIKeyHandler struct {
+	service *apikeys.Service
+	logger  *zap.Logger
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(service *apikeys.Service, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// CreateAPIKeyRequest names the partner a key is for. Without a quota the
+// configured default quotas apply.
+type CreateAPIKeyRequest struct {
+	Name  string               `json:"name" binding:"required"`
+	Quota *mongodb.APIKeyQuota `json:"quota"`
+}
+
+// CreateKey handles POST /api/v1/admin/api-keys. The key's secret is only
+// returned here.
+func (h *APIKeyHandler) CreateKey(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must name the partner",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Creating API key",
+		zap.String("request_id", requestID),
+		zap.String("name", req.Name))
+
+	key, err := h.service.Create(ctx, req.Name, req.Quota)
+	if err != nil {
+		h.respondAPIKeyError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success":    true,
+		"data":       key,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// ListKeys handles GET /api/v1/admin/api-keys
+func (h *APIKeyHandler) ListKeys(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	keys, err := h.service.List(ctx)
+	if err != nil {
+		h.respondAPIKeyError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       keys,
+		"count":      len(keys),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SetQuota handles PUT /api/v1/admin/api-keys/:id/quota
+func (h *APIKeyHandler) SetQuota(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	id := c.Param("id")
+
+	var quota mongodb.APIKeyQuota
+	if err := c.ShouldBindJSON(&quota); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Invalid request body",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Updating API key quota",
+		zap.String("request_id", requestID),
+		zap.String("key_id", id))
+
+	key, err := h.service.SetQuota(ctx, id, quota)
+	if err != nil {
+		h.respondAPIKeyError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       key,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// RevokeKey handles DELETE /api/v1/admin/api-keys/:id. The key is kept, revoked,
+// so its usage can still be reported.
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	id := c.Param("id")
+
+	h.logger.Info("Revoking API key",
+		zap.String("request_id", requestID),
+		zap.String("key_id", id))
+
+	key, err := h.service.Revoke(ctx, id)
+	if err != nil {
+		h.respondAPIKeyError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       key,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetUsage handles GET /api/v1/admin/api-keys/:id/usage?month=YYYY-MM, the
+// current month by default
+func (h *APIKeyHandler) GetUsage(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	id := c.Param("id")
+
+	report, err := h.service.Usage(ctx, id, c.Query("month"))
+	if err != nil {
+		h.respondAPIKeyError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *APIKeyHandler) respondAPIKeyError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, apikeys.ErrKeyNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, apikeys.ErrInvalidKeyRequest), errors.Is(err, apikeys.ErrInvalidMonth):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("API key operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "API key operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
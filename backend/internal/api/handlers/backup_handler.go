@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/backup"
+	"go.uber.org/zap"
+)
+
+// BackupHandler handles graph and cache backups
+type BackupHandler struct {
+	service *backup.Service
+	logger  *zap.Logger
+}
+
+// NewBackupHandler creates a new backup handler
+func NewBackupHandler(service *backup.Service, logger *zap.Logger) *BackupHandler {
+	return &BackupHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListBackups handles GET /api/v1/admin/backups
+func (h *BackupHandler) ListBackups(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Listing backups", zap.String("request_id", requestID))
+
+	backups, err := h.service.List(ctx)
+	if err != nil {
+		h.respondBackupError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       backups,
+		"count":      len(backups),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// CreateBackup handles POST /api/v1/admin/backups
+func (h *BackupHandler) CreateBackup(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Creating backup", zap.String("request_id", requestID))
+
+	info, err := h.service.Create(ctx, "manual by "+reviewer(c))
+	if err != nil {
+		h.respondBackupError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success":    true,
+		"data":       info,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// RestoreBackup handles POST /api/v1/admin/backups/:name/restore?confirm=true
+// Replaces the current graph and cache; a safety backup is taken first
+func (h *BackupHandler) RestoreBackup(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	if c.Query("confirm") != "true" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Restoring replaces the current graph; repeat with ?confirm=true",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Warn("Restoring backup",
+		zap.String("request_id", requestID),
+		zap.String("name", name),
+		zap.String("by", reviewer(c)))
+
+	result, err := h.service.Restore(ctx, name)
+	if err != nil {
+		h.respondBackupError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       result,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// maxPromotionSize caps the size of an uploaded promotion snapshot
+const maxPromotionSize = 512 << 20
+
+// ExportPromotion handles GET /api/v1/admin/promotion/export
+// Returns the graph and curated collections as a gzipped snapshot. The SHA-256
+// of the file is sent in the X-Snapshot-Checksum header and must be passed back
+// when importing it.
+func (h *BackupHandler) ExportPromotion(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Exporting snapshot for promotion",
+		zap.String("request_id", requestID),
+		zap.String("by", reviewer(c)))
+
+	export, err := h.service.ExportForPromotion(ctx)
+	if err != nil {
+		h.respondBackupError(c, requestID, err)
+		return
+	}
+
+	filename := fmt.Sprintf("promotion-%s.json.gz", export.CreatedAt.Format("20060102T150405Z"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("X-Snapshot-Checksum", "sha256:"+export.Checksum)
+	c.Data(http.StatusOK, "application/gzip", export.Data)
+}
+
+// ImportPromotion handles POST /api/v1/admin/promotion/import?checksum=sha256:...&confirm=true
+// The body is a snapshot from ExportPromotion. It replaces the current graph and
+// curated collections; the previous state is kept as a backup (the rollback
+// point) and restored automatically if the import fails.
+func (h *BackupHandler) ImportPromotion(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	checksum := c.Query("checksum")
+
+	if c.Query("confirm") != "true" || checksum == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Importing replaces the current graph; repeat with the export's ?checksum= and ?confirm=true",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPromotionSize))
+	if err != nil || len(data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      fmt.Sprintf("Request body must be a snapshot of at most %d MB", maxPromotionSize>>20),
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Warn("Importing promoted snapshot",
+		zap.String("request_id", requestID),
+		zap.Int("size", len(data)),
+		zap.String("by", reviewer(c)))
+
+	result, err := h.service.ImportPromotion(ctx, data, checksum)
+	if err != nil {
+		h.respondBackupError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       result,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *BackupHandler) respondBackupError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, backup.ErrStorageNotConfigured):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, backup.ErrBackupNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, backup.ErrInvalidBackupName):
+		status = http.StatusBadRequest
+	case errors.Is(err, backup.ErrBusy):
+		status = http.StatusConflict
+	case errors.Is(err, backup.ErrChecksumMismatch), errors.Is(err, backup.ErrInvalidSnapshot):
+		status = http.StatusUnprocessableEntity
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Backup operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Backup operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/badges"
+	"go.uber.org/zap"
+)
+
+// BadgeHandler handles Open Badges for completed roadmaps. The issuer, key,
+// badge class and assertion documents are served bare, as badge verifiers
+// expect them, rather than in the API's response envelope.
+type BadgeHandler struct {
+	service *badges.Service
+	logger  *zap.Logger
+}
+
+// NewBadgeHandler creates a new badge handler
+func NewBadgeHandler(service *badges.Service, logger *zap.Logger) *BadgeHandler {
+	return &BadgeHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ClaimBadgeRequest names the completed roadmap and the email to issue its
+// badge to. The badge is emailed in the language given (en, si or ta).
+type ClaimBadgeRequest struct {
+	Program  string `json:"program" binding:"required"`
+	Email    string `json:"email" binding:"required"`
+	Language string `json:"language"`
+}
+
+// ClaimBadge handles POST /api/v1/badges
+func (h *BadgeHandler) ClaimBadge(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	var req ClaimBadgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must name a program and an email",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Claiming roadmap badge",
+		zap.String("request_id", requestID),
+		zap.String("program", req.Program))
+
+	badge, err := h.service.Claim(ctx, userID, strings.TrimSpace(req.Program), req.Email, req.Language)
+	if err != nil {
+		h.respondBadgeError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       badge,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// ListBadges handles GET /api/v1/badges
+func (h *BadgeHandler) ListBadges(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	issued, err := h.service.List(ctx, userID)
+	if err != nil {
+		h.respondBadgeError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       issued,
+		"count":      len(issued),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetIssuer handles GET /api/v1/badges/issuer
+func (h *BadgeHandler) GetIssuer(c *gin.Context) {
+	issuer, err := h.service.Issuer()
+	if err != nil {
+		h.respondBadgeError(c, c.GetString("request_id"), err)
+		return
+	}
+	c.JSON(http.StatusOK, issuer)
+}
+
+// GetKey handles GET /api/v1/badges/issuer/key
+func (h *BadgeHandler) GetKey(c *gin.Context) {
+	key, err := h.service.Key()
+	if err != nil {
+		h.respondBadgeError(c, c.GetString("request_id"), err)
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
+
+// GetBadgeClass handles GET /api/v1/badges/classes/:program
+func (h *BadgeHandler) GetBadgeClass(c *gin.Context) {
+	class, err := h.service.BadgeClass(c.Request.Context(), c.Param("program"))
+	if err != nil {
+		h.respondBadgeError(c, c.GetString("request_id"), err)
+		return
+	}
+	c.JSON(http.StatusOK, class)
+}
+
+// GetAssertion handles GET /api/v1/badges/assertions/:id
+func (h *BadgeHandler) GetAssertion(c *gin.Context) {
+	badge, err := h.service.Assertion(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondBadgeError(c, c.GetString("request_id"), err)
+		return
+	}
+	c.JSON(http.StatusOK, badge.Assertion)
+}
+
+// GetSignedAssertion handles GET /api/v1/badges/assertions/:id/signed, the
+// assertion as a JWS ready to paste into a badge backpack
+func (h *BadgeHandler) GetSignedAssertion(c *gin.Context) {
+	badge, err := h.service.Assertion(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondBadgeError(c, c.GetString("request_id"), err)
+		return
+	}
+	c.String(http.StatusOK, badge.Signed)
+}
+
+func (h *BadgeHandler) respondBadgeError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, badges.ErrBadgesDisabled):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, badges.ErrBadgeNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, badges.ErrRoadmapIncomplete), errors.Is(err, badges.ErrAlreadyIssued):
+		status = http.StatusConflict
+	case errors.Is(err, badges.ErrInvalidRecipient):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Badge operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Badge operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
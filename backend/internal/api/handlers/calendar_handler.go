@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/calendar"
+	"github.com/mayura-andrew/fastfinder/internal/services/plans"
+	"go.uber.org/zap"
+)
+
+// CalendarHandler handles Google Calendar connections and study schedule pushes
+type CalendarHandler struct {
+	service   *calendar.Service
+	returnURL string
+	logger    *zap.Logger
+}
+
+// NewCalendarHandler creates a new calendar handler. Students are redirected to
+// returnURL after connecting their calendar; without one the callback answers
+// with JSON.
+func NewCalendarHandler(service *calendar.Service, returnURL string, logger *zap.Logger) *CalendarHandler {
+	return &CalendarHandler{
+		service:   service,
+		returnURL: returnURL,
+		logger:    logger,
+	}
+}
+
+// GetStatus handles GET /api/v1/calendar/google
+func (h *CalendarHandler) GetStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	status, err := h.service.Status(ctx, userID)
+	if err != nil {
+		h.respondCalendarError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       status,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// Connect handles POST /api/v1/calendar/google/connect
+// Returns the Google consent page the frontend should send the student to
+func (h *CalendarHandler) Connect(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	authURL, err := h.service.ConnectURL(ctx, userID)
+	if err != nil {
+		h.respondCalendarError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       gin.H{"auth_url": authURL},
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// Callback handles GET /api/v1/calendar/google/callback
+// Google redirects the student's browser here after the consent page, so the
+// user is identified by the OAuth state rather than their user token
+func (h *CalendarHandler) Callback(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var err error
+	if denied := c.Query("error"); denied != "" {
+		err = fmt.Errorf("%w: %s", calendar.ErrConsentDenied, denied)
+	} else {
+		err = h.service.CompleteConnect(ctx, c.Query("state"), c.Query("code"))
+	}
+	if err != nil {
+		h.logger.Warn("Google Calendar connection failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+	}
+
+	if h.returnURL != "" {
+		result := "connected"
+		if err != nil {
+			result = "error"
+		}
+		c.Redirect(http.StatusFound, withQuery(h.returnURL, "calendar", result))
+		return
+	}
+
+	if err != nil {
+		h.respondCalendarError(c, requestID, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Google Calendar connected",
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// Disconnect handles DELETE /api/v1/calendar/google
+func (h *CalendarHandler) Disconnect(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	if err := h.service.Disconnect(ctx, userID); err != nil {
+		h.respondCalendarError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Google Calendar disconnected",
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// PushSchedule handles POST /api/v1/plans/:id/schedule/google
+// Takes the same options as GET /api/v1/plans/:id/schedule, as query parameters
+func (h *CalendarHandler) PushSchedule(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+	planID := c.Param("id")
+
+	var opts plans.ScheduleOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Invalid schedule options",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Pushing study schedule to Google Calendar",
+		zap.String("request_id", requestID),
+		zap.String("plan_id", planID))
+
+	report, err := h.service.PushSchedule(ctx, userID, planID, opts)
+	if err != nil {
+		h.respondCalendarError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *CalendarHandler) respondCalendarError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, calendar.ErrCalendarDisabled):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, calendar.ErrNotConnected):
+		status = http.StatusConflict
+	case errors.Is(err, calendar.ErrInvalidState), errors.Is(err, plans.ErrInvalidSchedule):
+		status = http.StatusBadRequest
+	case errors.Is(err, calendar.ErrConsentDenied):
+		status = http.StatusForbidden
+	case errors.Is(err, plans.ErrPlanNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, calendar.ErrGoogleUnavailable):
+		status = http.StatusBadGateway
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Calendar operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Calendar operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// withQuery adds a query parameter to a URL
+func withQuery(rawURL, key, value string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := parsed.Query()
+	query.Set(key, value)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"go.uber.org/zap"
+)
+
+// ChangelogHandler handles graph change log requests
+type ChangelogHandler struct {
+	service *changelog.Service
+	logger  *zap.Logger
+}
+
+// NewChangelogHandler creates a new change log handler
+func NewChangelogHandler(service *changelog.Service, logger *zap.Logger) *ChangelogHandler {
+	return &ChangelogHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListChanges handles GET /api/v1/admin/changes?after=<id>&since=<RFC 3339 time>&limit=100
+// Returns graph changes oldest first. Consumers page through the log by passing
+// the ID of the last change they processed as after.
+func (h *ChangelogHandler) ListChanges(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	after := c.Query("after")
+
+	var since time.Time
+	if value := c.Query("since"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      "since must be an RFC 3339 time",
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		since = parsed
+	}
+
+	h.logger.Info("Listing graph changes",
+		zap.String("request_id", requestID),
+		zap.String("after", after),
+		zap.Time("since", since))
+
+	changes, err := h.service.List(ctx, after, since, queryInt(c, "limit"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to list graph changes"
+		if errors.Is(err, changelog.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+			message = err.Error()
+		} else {
+			h.logger.Error("Failed to list graph changes",
+				zap.String("request_id", requestID),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       changes,
+		"count":      len(changes),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
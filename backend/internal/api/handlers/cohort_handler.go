@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/cohorts"
+	"go.uber.org/zap"
+)
+
+// CohortHandler handles classroom cohorts
+type CohortHandler struct {
+	service *cohorts.Service
+	logger  *zap.Logger
+}
+
+// NewCohortHandler creates a new cohort handler
+func NewCohortHandler(service *cohorts.Service, logger *zap.Logger) *CohortHandler {
+	return &CohortHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// CreateCohort handles POST /api/v1/cohorts, a teacher starting a cohort
+// Body: {"name": "Grade 13 Science, 2026"}
+func (h *CohortHandler) CreateCohort(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	var request struct {
+		Name string `json:"name" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must give the cohort's name",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	cohort, err := h.service.Create(ctx, userID, request.Name)
+	if err != nil {
+		h.respondCohortError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success":    true,
+		"data":       cohort,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// ListCohorts handles GET /api/v1/cohorts, the cohorts the user teaches
+func (h *CohortHandler) ListCohorts(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	taught, err := h.service.ListTaught(ctx, userID)
+	if err != nil {
+		h.respondCohortError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       taught,
+		"count":      len(taught),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// JoinCohort handles POST /api/v1/cohorts/join, a student joining with a code
+// Body: {"code": "K7QM2XPA"}
+func (h *CohortHandler) JoinCohort(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	var request struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must give the cohort code",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	cohort, err := h.service.Join(ctx, userID, request.Code)
+	if err != nil {
+		h.respondCohortError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       cohort,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// LeaveCohort handles DELETE /api/v1/cohorts/:code/membership
+func (h *CohortHandler) LeaveCohort(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	if err := h.service.Leave(ctx, userID, c.Param("code")); err != nil {
+		h.respondCohortError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetCohortSummary handles GET /api/v1/cohorts/:code/summary
+// Returns the cohort's most targeted careers and average roadmap progress to its
+// teacher, once enough students have joined.
+func (h *CohortHandler) GetCohortSummary(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	summary, err := h.service.Summary(ctx, userID, c.Param("code"))
+	if err != nil {
+		h.respondCohortError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       summary,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *CohortHandler) respondCohortError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, cohorts.ErrCohortNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, cohorts.ErrInvalidCohort):
+		status = http.StatusBadRequest
+	case errors.Is(err, cohorts.ErrCohortFull):
+		status = http.StatusConflict
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Cohort operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Cohort operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
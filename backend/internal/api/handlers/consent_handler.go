@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/consent"
+	"go.uber.org/zap"
+)
+
+// ConsentHandler handles privacy policy acceptance and parental consent
+type ConsentHandler struct {
+	service  *consent.Service
+	policyID string
+	logger   *zap.Logger
+}
+
+// NewConsentHandler creates a new consent handler. The policy ID is the current
+// privacy policy version, served even when consent is not tracked (demo mode).
+func NewConsentHandler(service *consent.Service, policyID string, logger *zap.Logger) *ConsentHandler {
+	return &ConsentHandler{
+		service:  service,
+		policyID: policyID,
+		logger:   logger,
+	}
+}
+
+// AcceptPolicyRequest accepts a privacy policy version. Minors may include their
+// guardian's consent, or have it given later.
+type AcceptPolicyRequest struct {
+	PolicyID        string            `json:"policy_id" binding:"required"`
+	Minor           bool              `json:"minor"`
+	ParentalConsent *consent.Guardian `json:"parental_consent"`
+}
+
+// ParentalConsentRequest is a guardian's consent for a minor
+type ParentalConsentRequest struct {
+	PolicyID string `json:"policy_id" binding:"required"`
+	consent.Guardian
+}
+
+// GetPolicy handles GET /api/v1/consent/policy
+func (h *ConsentHandler) GetPolicy(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       gin.H{"policy_id": h.policyID},
+		"request_id": c.GetString("request_id"),
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetStatus handles GET /api/v1/consent
+func (h *ConsentHandler) GetStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	status, err := h.service.Status(ctx, userID)
+	if err != nil {
+		h.respondConsentError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       status,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// AcceptPolicy handles POST /api/v1/consent
+func (h *ConsentHandler) AcceptPolicy(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	var req AcceptPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must name the accepted policy_id",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	status, err := h.service.Accept(ctx, userID, req.PolicyID, req.Minor, req.ParentalConsent)
+	if err != nil {
+		h.respondConsentError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       status,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GrantParentalConsent handles POST /api/v1/consent/parental
+func (h *ConsentHandler) GrantParentalConsent(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	var req ParentalConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must name the policy_id consented to",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	status, err := h.service.GrantParental(ctx, userID, req.PolicyID, req.Guardian)
+	if err != nil {
+		h.respondConsentError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       status,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// WithdrawConsent handles DELETE /api/v1/consent
+func (h *ConsentHandler) WithdrawConsent(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	status, err := h.service.Withdraw(ctx, userID)
+	if err != nil {
+		h.respondConsentError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       status,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetHistory handles GET /api/v1/consent/history
+func (h *ConsentHandler) GetHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	events, err := h.service.History(ctx, userID)
+	if err != nil {
+		h.respondConsentError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       events,
+		"count":      len(events),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *ConsentHandler) respondConsentError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, consent.ErrPolicyOutdated), errors.Is(err, consent.ErrNotAccepted):
+		status = http.StatusConflict
+	case errors.Is(err, consent.ErrInvalidConsent):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Consent operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Consent operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/demand"
+	"go.uber.org/zap"
+)
+
+// DemandHandler handles careers' demand indexes
+type DemandHandler struct {
+	service *demand.Service
+	logger  *zap.Logger
+}
+
+// NewDemandHandler creates a new demand handler
+func NewDemandHandler(service *demand.Service, logger *zap.Logger) *DemandHandler {
+	return &DemandHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListDemand handles GET /api/v1/admin/career-demand
+// Lists every scored or overridden career, highest index first, with the
+// signals behind each index
+func (h *DemandHandler) ListDemand(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	list, err := h.service.List(ctx)
+	if err != nil {
+		h.respondDemandError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       list,
+		"count":      len(list),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// RecomputeDemand handles POST /api/v1/admin/career-demand/recompute
+// Recomputes the demand indexes immediately instead of waiting for the schedule
+func (h *DemandHandler) RecomputeDemand(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Recomputing career demand", zap.String("request_id", requestID))
+
+	report, err := h.service.Recompute(ctx)
+	if err != nil {
+		h.respondDemandError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SetDemandOverride handles PUT /api/v1/admin/career-demand/overrides
+// Body: {"career": "Civil Engineer", "index": 85, "reason": "Infrastructure projects hiring"}
+func (h *DemandHandler) SetDemandOverride(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var request struct {
+		Career string   `json:"career" binding:"required"`
+		Index  *float64 `json:"index" binding:"required"`
+		Reason string   `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must give the career and index",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Admin setting demand override",
+		zap.String("request_id", requestID),
+		zap.String("career", request.Career),
+		zap.Float64("index", *request.Index))
+
+	override, err := h.service.SetOverride(ctx, request.Career, *request.Index, request.Reason)
+	if err != nil {
+		h.respondDemandError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       override,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// DeleteDemandOverride handles DELETE /api/v1/admin/career-demand/overrides/:career
+// The career's computed index applies again
+func (h *DemandHandler) DeleteDemandOverride(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	career := c.Param("career")
+
+	h.logger.Info("Admin removing demand override",
+		zap.String("request_id", requestID),
+		zap.String("career", career))
+
+	if err := h.service.DeleteOverride(ctx, career); err != nil {
+		h.respondDemandError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"career":     career,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *DemandHandler) respondDemandError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, demand.ErrCareerNotFound), errors.Is(err, demand.ErrOverrideNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, demand.ErrInvalidOverride):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Demand operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Demand operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/diagnostics"
+	"go.uber.org/zap"
+)
+
+// DiagnosticsHandler serves admin diagnostics of the running instance
+type DiagnosticsHandler struct {
+	service *diagnostics.Service
+	logger  *zap.Logger
+}
+
+// NewDiagnosticsHandler creates a new diagnostics handler
+func NewDiagnosticsHandler(service *diagnostics.Service, logger *zap.Logger) *DiagnosticsHandler {
+	return &DiagnosticsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetConfig handles GET /api/v1/admin/diagnostics/config
+// Returns the configuration in effect, with secrets masked
+func (h *DiagnosticsHandler) GetConfig(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       h.service.Config(),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetHealth handles GET /api/v1/admin/diagnostics/health
+// Checks every dependency; responds 503 while any is unhealthy or the instance
+// is not ready
+func (h *DiagnosticsHandler) GetHealth(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	health := h.service.Health(ctx)
+
+	status := http.StatusOK
+	if health.Status != diagnostics.StatusHealthy {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{
+		"success":    health.Status == diagnostics.StatusHealthy,
+		"data":       health,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// RunSmokeTest handles POST /api/v1/admin/diagnostics/smoke-test
+// Reads through the pathway graph as a student would; responds 503 when a step
+// fails
+func (h *DiagnosticsHandler) RunSmokeTest(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Admin running pathway smoke test", zap.String("request_id", requestID))
+
+	test := h.service.RunSmokeTest(ctx)
+
+	status := http.StatusOK
+	if !test.Passed {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{
+		"success":    test.Passed,
+		"data":       test,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/discovery"
+	"github.com/mayura-andrew/fastfinder/internal/services/drafts"
+	"go.uber.org/zap"
+)
+
+// DiscoveryHandler handles "describe your interests" searches
+type DiscoveryHandler struct {
+	service *discovery.Service
+	logger  *zap.Logger
+}
+
+// NewDiscoveryHandler creates a new discovery handler
+func NewDiscoveryHandler(service *discovery.Service, logger *zap.Logger) *DiscoveryHandler {
+	return &DiscoveryHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Discover handles POST /api/v1/pathway/discover
+// Body: {"interests": "I want to work with animals but failed maths", "limit": 8}
+// Returns programs and careers matching the description, each with an explanation.
+// The interests may come from the draft profile in X-Draft-Profile instead.
+func (h *DiscoveryHandler) Discover(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var request struct {
+		Interests string `json:"interests"`
+		Limit     int    `json:"limit"`
+	}
+	err := bindDraftable(c, &request)
+	if draft := drafts.FromContext(ctx); draft != nil && request.Interests == "" {
+		request.Interests = draft.Interests
+	}
+	if err != nil || strings.TrimSpace(request.Interests) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Invalid request body",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	// The description itself is not logged, as students may share personal details
+	h.logger.Info("Discovering pathways from interests",
+		zap.String("request_id", requestID),
+		zap.Int("length", len(request.Interests)))
+
+	result, err := h.service.Discover(ctx, request.Interests, request.Limit)
+	if err != nil {
+		h.respondDiscoveryError(c, requestID, err, "Failed to search by interests")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       result,
+		"count":      len(result.Suggestions),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// Reindex handles POST /api/v1/admin/discovery/reindex
+// Rebuilds the discovery index from the programs and careers in the graph.
+func (h *DiscoveryHandler) Reindex(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Reindexing discovery documents", zap.String("request_id", requestID))
+
+	documents, err := h.service.Reindex(ctx)
+	if err != nil {
+		h.respondDiscoveryError(c, requestID, err, "Failed to rebuild the discovery index")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"documents":  documents,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *DiscoveryHandler) respondDiscoveryError(c *gin.Context, requestID string, err error, message string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, discovery.ErrUnavailable):
+		status = http.StatusServiceUnavailable
+		message = err.Error()
+	case errors.Is(err, discovery.ErrInvalidInterests):
+		status = http.StatusUnprocessableEntity
+		message = err.Error()
+	default:
+		h.logger.Error(message,
+			zap.String("request_id", requestID),
+			zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/drafts"
+	"go.uber.org/zap"
+)
+
+// DraftHandler issues the draft profiles wizards carry between steps
+type DraftHandler struct {
+	service *drafts.Service
+	logger  *zap.Logger
+}
+
+// NewDraftHandler creates a new draft profile handler
+func NewDraftHandler(service *drafts.Service, logger *zap.Logger) *DraftHandler {
+	return &DraftHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// IssueDraft handles POST /api/v1/drafts/profile
+// Body: {"qualifications": ["G.C.E. (O/L) Examination Pass"], "household_income_band": "25000_50000"}
+// Signs the answers given, on top of those in the X-Draft-Profile header when
+// sent, into a new draft. Send it back in X-Draft-Profile to eligibility and
+// recommendation requests to fill in what they leave out.
+func (h *DraftHandler) IssueDraft(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var update drafts.Profile
+	if err := bindDraftable(c, &update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Invalid request body",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	draft, err := h.service.Issue(ctx, c.GetHeader(drafts.Header), update)
+	if err != nil {
+		h.respondDraftError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       draft,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetDraft handles GET /api/v1/drafts/profile
+// Returns the answers in the X-Draft-Profile header, to resume a wizard
+func (h *DraftHandler) GetDraft(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	profile := drafts.FromContext(c.Request.Context())
+	if profile == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "X-Draft-Profile header is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       profile,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *DraftHandler) respondDraftError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, drafts.ErrInvalidDraft), errors.Is(err, drafts.ErrDraftExpired), errors.Is(err, drafts.ErrInvalidProfile):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Draft profile operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Draft profile operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// bindDraftable binds a request body that a draft profile may complete. With a
+// draft, the body may be left empty to rely on the draft's answers alone.
+func bindDraftable(c *gin.Context, request any) error {
+	if c.Request.ContentLength == 0 && drafts.FromContext(c.Request.Context()) != nil {
+		return nil
+	}
+	return c.ShouldBindJSON(request)
+}
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/events"
+	"go.uber.org/zap"
+)
+
+// EventHandler handles institute open days, aptitude tests and career fairs
+type EventHandler struct {
+	service *events.Service
+	logger  *zap.Logger
+}
+
+// NewEventHandler creates a new institute event handler
+func NewEventHandler(service *events.Service, logger *zap.Logger) *EventHandler {
+	return &EventHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// EventRequest is the body of creating or updating an institute event
+type EventRequest struct {
+	Institute   string     `json:"institute" binding:"required"`
+	Kind        string     `json:"kind" binding:"required"`
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description"`
+	StartsAt    time.Time  `json:"starts_at" binding:"required"`
+	EndsAt      *time.Time `json:"ends_at"`
+	Venue       string     `json:"venue"`
+	District    string     `json:"district"`
+	URL         string     `json:"url"`
+	Programs    []string   `json:"programs"`
+}
+
+func (r EventRequest) event() mongodb.InstituteEvent {
+	return mongodb.InstituteEvent{
+		Institute:   r.Institute,
+		Kind:        r.Kind,
+		Title:       r.Title,
+		Description: r.Description,
+		StartsAt:    r.StartsAt,
+		EndsAt:      r.EndsAt,
+		Venue:       r.Venue,
+		District:    r.District,
+		URL:         r.URL,
+		Programs:    r.Programs,
+	}
+}
+
+// GetInstituteEvents handles GET /api/v1/pathway/institutes/:name/events?include_past=false
+func (h *EventHandler) GetInstituteEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	institute := c.Param("name")
+	includePast, _ := strconv.ParseBool(c.DefaultQuery("include_past", "false"))
+
+	list, err := h.service.ForInstitute(ctx, institute, includePast)
+	if err != nil {
+		h.respondEventError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"institute":  institute,
+		"data":       list,
+		"count":      len(list),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetUpcomingEvents handles GET /api/v1/events/upcoming?days=30&kind=open_day,career_fair&district=...&updated_since=RFC3339
+// Notifiers poll it with updated_since set to their previous poll.
+func (h *EventHandler) GetUpcomingEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	days, _ := strconv.Atoi(c.Query("days"))
+
+	query := events.UpcomingQuery{
+		Days:     days,
+		Kinds:    splitQuery(c.Query("kind")),
+		District: c.Query("district"),
+	}
+	if since := c.Query("updated_since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      "updated_since must be an RFC 3339 time",
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		query.UpdatedSince = parsed
+	}
+
+	feed, err := h.service.Upcoming(ctx, query)
+	if err != nil {
+		h.respondEventError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       feed,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// CreateEvent handles POST /api/v1/admin/events
+func (h *EventHandler) CreateEvent(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req EventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadEvent(c, requestID)
+		return
+	}
+
+	event, err := h.service.Create(ctx, req.event())
+	if err != nil {
+		h.respondEventError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success":    true,
+		"data":       event,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetEvent handles GET /api/v1/admin/events/:id
+func (h *EventHandler) GetEvent(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	event, err := h.service.Get(ctx, c.Param("id"))
+	if err != nil {
+		h.respondEventError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       event,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// UpdateEvent handles PUT /api/v1/admin/events/:id
+func (h *EventHandler) UpdateEvent(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req EventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadEvent(c, requestID)
+		return
+	}
+
+	event, err := h.service.Update(ctx, c.Param("id"), req.event())
+	if err != nil {
+		h.respondEventError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       event,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// DeleteEvent handles DELETE /api/v1/admin/events/:id
+func (h *EventHandler) DeleteEvent(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	if err := h.service.Delete(ctx, c.Param("id")); err != nil {
+		h.respondEventError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *EventHandler) respondBadEvent(c *gin.Context, requestID string) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		"success":    false,
+		"error":      "Request body must name the institute, kind, title and starts_at (RFC 3339)",
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *EventHandler) respondEventError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, events.ErrInstituteNotFound),
+		errors.Is(err, events.ErrEventNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, events.ErrInvalidEvent):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Institute event operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Institute event operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
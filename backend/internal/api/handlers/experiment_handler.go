@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/api/middleware"
+	"github.com/mayura-andrew/fastfinder/internal/services/experiments"
+	"go.uber.org/zap"
+)
+
+// ExperimentHandler handles A/B experiment assignments, feedback and reports
+type ExperimentHandler struct {
+	service *experiments.Service
+	logger  *zap.Logger
+}
+
+// NewExperimentHandler creates a new experiment handler
+func NewExperimentHandler(service *experiments.Service, logger *zap.Logger) *ExperimentHandler {
+	return &ExperimentHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ExperimentFeedbackRequest is feedback on a response shaped by an experiment. The
+// variant is the one named in the response's X-Experiment header; signed-in users
+// may leave it out.
+type ExperimentFeedbackRequest struct {
+	Experiment string `json:"experiment" binding:"required"`
+	Variant    string `json:"variant"`
+	Action     string `json:"action" binding:"required"`
+}
+
+// ListExperiments handles GET /api/v1/experiments, the running experiments and
+// the caller's variants
+func (h *ExperimentHandler) ListExperiments(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"experiments": h.service.Running(),
+			"variants":    middleware.ExperimentVariants(c),
+		},
+		"request_id": c.GetString("request_id"),
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// RecordFeedback handles POST /api/v1/experiments/feedback
+func (h *ExperimentHandler) RecordFeedback(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	var req ExperimentFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must name the experiment and action",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	variant := req.Variant
+	if variant == "" && c.GetString("user_id") != "" {
+		variant = middleware.ExperimentVariants(c)[req.Experiment]
+	}
+
+	if err := h.service.RecordFeedback(req.Experiment, variant, req.Action); err != nil {
+		h.respondExperimentError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success":    true,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetReport handles GET /api/v1/admin/experiments?from=YYYY-MM-DD&to=YYYY-MM-DD,
+// the last 30 days by default
+func (h *ExperimentHandler) GetReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	report, err := h.service.Report(ctx, c.Query("from"), c.Query("to"))
+	if err != nil {
+		h.respondExperimentError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *ExperimentHandler) respondExperimentError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, experiments.ErrUnknownExperiment),
+		errors.Is(err, experiments.ErrInvalidFeedback),
+		errors.Is(err, experiments.ErrInvalidRange):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Experiment operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Experiment operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -66,3 +67,29 @@ func (h *Handler) HealthCheck(c *gin.Context) {
 		"uptime":    time.Since(h.startTime).String(),
 	})
 }
+
+// Readiness handles GET /ready
+// Returns 503 while the service cannot safely take traffic, e.g. when the graph's
+// uniqueness constraints could not be created. The endpoint is public, so the
+// cause is logged and only its kind is returned; admins see it in diagnostics.
+func (h *Handler) Readiness(c *gin.Context) {
+	if err := h.container.Readiness(c.Request.Context()); err != nil {
+		h.logger.Warn("Service is not ready", zap.Error(err))
+
+		reason := "dependencies unreachable"
+		if errors.Is(err, containers.ErrMigrationsPending) {
+			reason = "migrations pending"
+		}
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":    "not_ready",
+			"reason":    reason,
+			"timestamp": time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "ready",
+		"timestamp": time.Now().UTC(),
+	})
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/handout"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"go.uber.org/zap"
+)
+
+// HandoutHandler handles printable program handouts
+type HandoutHandler struct {
+	service *handout.Service
+	logger  *zap.Logger
+}
+
+// NewHandoutHandler creates a new handout handler
+func NewHandoutHandler(service *handout.Service, logger *zap.Logger) *HandoutHandler {
+	return &HandoutHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetHandout handles GET /api/v1/pathway/programs/:name/handout, a one-page
+// summary of the program for print
+// Query: format (pdf or png, default pdf), home_district (adds living costs)
+func (h *HandoutHandler) GetHandout(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+	format := c.DefaultQuery("format", handout.FormatPDF)
+
+	document, contentType, err := h.service.Render(ctx, name, c.Query("home_district"), format)
+	if err != nil {
+		h.respondHandoutError(c, requestID, err)
+		return
+	}
+
+	filename := fmt.Sprintf("%s.%s", slugify(name), format)
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	c.Data(http.StatusOK, contentType, document)
+}
+
+func (h *HandoutHandler) respondHandoutError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, handout.ErrProgramNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, handout.ErrUnknownFormat), errors.Is(err, pathway.ErrUnknownDistrict):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Failed to generate handout",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Failed to generate handout"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/importer"
+	"go.uber.org/zap"
+)
+
+// ImportHandler handles bulk data imports
+type ImportHandler struct {
+	service *importer.Service
+	logger  *zap.Logger
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(service *importer.Service, logger *zap.Logger) *ImportHandler {
+	return &ImportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Content types of spreadsheet uploads sent as the raw request body
+const (
+	contentTypeCSV  = "text/csv"
+	contentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// ImportPrograms handles POST /api/v1/admin/import/programs?dry_run=true&allow_duplicates=false&source_url=...
+// Validates every row and returns a per-row report. With dry_run the graph is never
+// touched; otherwise the rows are applied only if all of them are valid. New names
+// that closely match an existing one (e.g. "Univ. of Moratuwa") are rejected as
+// probable duplicates unless allow_duplicates is set. The optional source_url is
+// recorded as the provenance of the imported programs.
+//
+// Rows are sent as JSON ({"rows": [...]}) or as a CSV or XLSX spreadsheet, either
+// in a multipart "file" field or as the raw body with a text/csv or XLSX content
+// type; format=csv|xlsx overrides the file extension and content type. The
+// report of a spreadsheet import numbers rows as the spreadsheet does.
+func (h *ImportHandler) ImportPrograms(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	allowDuplicates, _ := strconv.ParseBool(c.DefaultQuery("allow_duplicates", "false"))
+
+	contentType := c.ContentType()
+	if c.Query("format") != "" || strings.HasPrefix(contentType, "multipart/") ||
+		contentType == contentTypeCSV || contentType == contentTypeXLSX {
+		h.importSpreadsheet(c, requestID, dryRun, allowDuplicates)
+		return
+	}
+
+	var request struct {
+		Rows []importer.Row `json:"rows" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Invalid request: rows array is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Importing programs",
+		zap.String("request_id", requestID),
+		zap.Int("rows", len(request.Rows)),
+		zap.Bool("dry_run", dryRun),
+		zap.Bool("allow_duplicates", allowDuplicates))
+
+	provenance := neo4j.NewProvenance(importer.SourceBulkImport, c.Query("source_url"))
+	report, err := h.service.ImportPrograms(ctx, request.Rows, dryRun, allowDuplicates, provenance)
+	h.respondImport(c, requestID, report, err)
+}
+
+// importSpreadsheet imports the rows of an uploaded spreadsheet
+func (h *ImportHandler) importSpreadsheet(c *gin.Context, requestID string, dryRun, allowDuplicates bool) {
+	ctx := c.Request.Context()
+
+	body, err := readUpload(c)
+	if err != nil {
+		h.respondBadSpreadsheet(c, requestID, err)
+		return
+	}
+	defer body.Close()
+
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		format = spreadsheetFormat(c)
+	}
+
+	rows, lines, err := importer.ParseSpreadsheet(format, body)
+	if err != nil {
+		h.logger.Warn("Failed to parse import spreadsheet",
+			zap.String("request_id", requestID),
+			zap.String("format", format),
+			zap.Error(err))
+		h.respondBadSpreadsheet(c, requestID, err)
+		return
+	}
+
+	h.logger.Info("Importing programs from spreadsheet",
+		zap.String("request_id", requestID),
+		zap.String("format", format),
+		zap.Int("rows", len(rows)),
+		zap.Bool("dry_run", dryRun),
+		zap.Bool("allow_duplicates", allowDuplicates))
+
+	provenance := neo4j.NewProvenance(importer.SourceBulkImport, c.Query("source_url"))
+	report, err := h.service.ImportPrograms(ctx, rows, dryRun, allowDuplicates, provenance)
+	if err == nil {
+		report.Renumber(lines)
+	}
+	h.respondImport(c, requestID, report, err)
+}
+
+// spreadsheetFormat tells the format of an upload from the extension of the
+// uploaded file or the content type of the body
+func spreadsheetFormat(c *gin.Context) string {
+	if header, err := c.FormFile("file"); err == nil {
+		return strings.ToLower(strings.TrimPrefix(path.Ext(header.Filename), "."))
+	}
+	switch c.ContentType() {
+	case contentTypeCSV:
+		return importer.FormatCSV
+	case contentTypeXLSX:
+		return importer.FormatXLSX
+	}
+	return ""
+}
+
+func (h *ImportHandler) respondBadSpreadsheet(c *gin.Context, requestID string, err error) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		"success":    false,
+		"error":      err.Error(),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// respondImport writes the outcome of an import. A rejected (non dry-run) import
+// with invalid rows is reported as 422 along with the full report.
+func (h *ImportHandler) respondImport(c *gin.Context, requestID string, report *importer.Report, err error) {
+	if err != nil {
+		if errors.Is(err, importer.ErrEmptyImport) || errors.Is(err, importer.ErrTooManyRows) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      err.Error(),
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		h.logger.Error("Failed to import programs",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to import programs",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	status := http.StatusOK
+	if !report.DryRun && !report.Applied {
+		status = http.StatusUnprocessableEntity
+	}
+
+	c.JSON(status, gin.H{
+		"success":    status == http.StatusOK,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
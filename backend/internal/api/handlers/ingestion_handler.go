@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/ingestion"
+	"go.uber.org/zap"
+)
+
+// maxUploadSize caps the size of uploaded dataset files
+const maxUploadSize = 20 << 20 // 20MB
+
+// IngestionHandler handles runs of the external dataset pipelines
+type IngestionHandler struct {
+	service *ingestion.Service
+	logger  *zap.Logger
+}
+
+// NewIngestionHandler creates a new ingestion handler
+func NewIngestionHandler(service *ingestion.Service, logger *zap.Logger) *IngestionHandler {
+	return &IngestionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// IngestUGCHandbook handles POST /api/v1/admin/ingest/ugc-handbook?format=csv|text&year=...&dry_run=true
+// Accepts the extract as a multipart "file" field or as the raw request body
+func (h *IngestionHandler) IngestUGCHandbook(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	format := strings.ToLower(c.DefaultQuery("format", ingestion.FormatCSV))
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	year, _ := strconv.Atoi(c.Query("year"))
+
+	h.logger.Info("Ingesting UGC handbook",
+		zap.String("request_id", requestID),
+		zap.String("format", format),
+		zap.Int("year", year),
+		zap.Bool("dry_run", dryRun))
+
+	body, err := readUpload(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      err.Error(),
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+	defer body.Close()
+
+	entries, problems, err := ingestion.ParseUGCHandbook(format, body, year)
+	if err != nil {
+		h.logger.Warn("Failed to parse UGC handbook",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      err.Error(),
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	report, err := h.service.IngestUGCHandbook(ctx, entries, problems, dryRun)
+	if err != nil {
+		h.logger.Error("Failed to ingest UGC handbook",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to ingest handbook",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SyncTVECRegistry handles POST /api/v1/admin/ingest/tvec-sync?dry_run=true
+// Runs the registered-course sync immediately instead of waiting for the schedule
+func (h *IngestionHandler) SyncTVECRegistry(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+
+	h.logger.Info("Syncing TVEC registry",
+		zap.String("request_id", requestID),
+		zap.Bool("dry_run", dryRun))
+
+	report, err := h.service.SyncTVECRegistry(ctx, dryRun)
+	if err != nil {
+		status := http.StatusBadGateway
+		message := "Failed to sync TVEC registry"
+		if errors.Is(err, ingestion.ErrRegistryNotConfigured) {
+			status = http.StatusServiceUnavailable
+			message = err.Error()
+		}
+		h.logger.Error("Failed to sync TVEC registry",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SyncJobBoardSalaries handles POST /api/v1/admin/ingest/job-board-salaries
+// Runs the salary sync immediately instead of waiting for the schedule. This
+// searches every board for every career and can take several minutes.
+func (h *IngestionHandler) SyncJobBoardSalaries(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Syncing job board salaries", zap.String("request_id", requestID))
+
+	report, err := h.service.SyncJobBoardSalaries(ctx)
+	if err != nil {
+		status := http.StatusBadGateway
+		message := "Failed to sync job board salaries"
+		if errors.Is(err, ingestion.ErrJobBoardsNotConfigured) {
+			status = http.StatusServiceUnavailable
+			message = err.Error()
+		}
+		h.logger.Error("Failed to sync job board salaries",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// readUpload returns the uploaded file from a multipart "file" field, falling back
+// to the raw request body
+func readUpload(c *gin.Context) (io.ReadCloser, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadSize)
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, errors.New("multipart upload must include a \"file\" field")
+		}
+		return header.Open()
+	}
+
+	if c.Request.ContentLength == 0 {
+		return nil, errors.New("request body is empty")
+	}
+	return c.Request.Body, nil
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/core/mail"
+	"go.uber.org/zap"
+)
+
+// MailHandler lets admins check outgoing email
+type MailHandler struct {
+	mailer *mail.Mailer
+	logger *zap.Logger
+}
+
+// NewMailHandler creates a new mail handler
+func NewMailHandler(mailer *mail.Mailer, logger *zap.Logger) *MailHandler {
+	return &MailHandler{
+		mailer: mailer,
+		logger: logger,
+	}
+}
+
+// SendTestMailRequest names the recipient of a test email and its language
+type SendTestMailRequest struct {
+	To       string `json:"to" binding:"required"`
+	Language string `json:"language"`
+}
+
+// SendTestMail handles POST /api/v1/admin/mail/test
+func (h *MailHandler) SendTestMail(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var req SendTestMailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must name a recipient",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Sending test email",
+		zap.String("request_id", requestID),
+		zap.String("provider", h.mailer.Provider()))
+
+	err := h.mailer.Send(ctx, req.To, "test", req.Language, map[string]string{"Provider": h.mailer.Provider()})
+	if err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, mail.ErrInvalidRecipient) {
+			status = http.StatusBadRequest
+		}
+		h.logger.Warn("Test email failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      err.Error(),
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Test email sent",
+		"provider":   h.mailer.Provider(),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetOutbox handles GET /api/v1/admin/mail/outbox
+// Email captured while sending is disabled or the sink provider is in use
+func (h *MailHandler) GetOutbox(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	messages, ok := h.mailer.Outbox()
+	if !ok {
+		c.JSON(http.StatusConflict, gin.H{
+			"success":    false,
+			"error":      "Email is sent through " + h.mailer.Provider() + "; there is no outbox",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       messages,
+		"count":      len(messages),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/moodle"
+	"go.uber.org/zap"
+)
+
+// MoodleHandler handles Moodle partners and roadmap exports
+type MoodleHandler struct {
+	service *moodle.Service
+	logger  *zap.Logger
+}
+
+// NewMoodleHandler creates a new Moodle handler
+func NewMoodleHandler(service *moodle.Service, logger *zap.Logger) *MoodleHandler {
+	return &MoodleHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ExportRoadmapRequest names the program whose roadmap is exported
+type ExportRoadmapRequest struct {
+	Program string `json:"program" binding:"required"`
+}
+
+// ListPartners handles GET /api/v1/admin/moodle/partners
+func (h *MoodleHandler) ListPartners(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Listing Moodle partners", zap.String("request_id", requestID))
+
+	partners, err := h.service.ListPartners(ctx)
+	if err != nil {
+		h.respondMoodleError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       partners,
+		"count":      len(partners),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SavePartner handles PUT /api/v1/admin/moodle/partners/:name
+func (h *MoodleHandler) SavePartner(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var partner mongodb.MoodlePartner
+	if err := c.ShouldBindJSON(&partner); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Invalid request body",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+	partner.Name = c.Param("name")
+
+	h.logger.Info("Saving Moodle partner",
+		zap.String("request_id", requestID),
+		zap.String("name", partner.Name))
+
+	if err := h.service.SavePartner(ctx, &partner); err != nil {
+		h.respondMoodleError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       partner,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// ExportRoadmap handles POST /api/v1/admin/moodle/partners/:name/export
+func (h *MoodleHandler) ExportRoadmap(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	var req ExportRoadmapRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Program) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must name a program",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Exporting roadmap to Moodle",
+		zap.String("request_id", requestID),
+		zap.String("partner", name),
+		zap.String("program", req.Program))
+
+	report, err := h.service.Export(ctx, name, strings.TrimSpace(req.Program))
+	if err != nil {
+		h.respondMoodleError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *MoodleHandler) respondMoodleError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, moodle.ErrPartnerNotFound), errors.Is(err, moodle.ErrProgramNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, moodle.ErrInvalidPartner):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, moodle.ErrMoodleUnavailable), errors.Is(err, moodle.ErrMoodleRejected):
+		status = http.StatusBadGateway
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Moodle operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Moodle operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
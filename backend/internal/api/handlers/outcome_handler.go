@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/outcomes"
+	"go.uber.org/zap"
+)
+
+// OutcomeHandler handles graduate outcomes of programs
+type OutcomeHandler struct {
+	service *outcomes.Service
+	logger  *zap.Logger
+}
+
+// NewOutcomeHandler creates a new outcome handler
+func NewOutcomeHandler(service *outcomes.Service, logger *zap.Logger) *OutcomeHandler {
+	return &OutcomeHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetProgramOutcomes handles GET /api/v1/pathway/programs/:name/outcomes
+func (h *OutcomeHandler) GetProgramOutcomes(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	report, err := h.service.Report(ctx, c.Param("name"))
+	if err != nil {
+		h.respondOutcomeError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// ReportOutcome handles POST /api/v1/pathway/programs/:name/outcomes/reports, a
+// graduate's own outcome
+// Body: {"graduation_year": 2023, "employed": true, "months_to_employment": 4, "first_job": "Software Engineer"}
+func (h *OutcomeHandler) ReportOutcome(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	var req outcomes.Report
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must give the graduation_year",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	if err := h.service.SaveReport(ctx, userID, c.Param("name"), req); err != nil {
+		h.respondOutcomeError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success":    true,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SaveSurveys handles PUT /api/v1/admin/programs/:name/outcomes
+// Body: {"surveys": [{"year": 2023, "respondents": 140, "employment_rate": 0.82, "months_to_employment": 5, "first_jobs": ["..."], "source": "..."}]}
+func (h *OutcomeHandler) SaveSurveys(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	if c.Param("entity") != "programs" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success":    false,
+			"error":      "Only programs have graduate outcomes",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	var request struct {
+		Surveys []mongodb.ProgramOutcome `json:"surveys" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must list the surveys",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Admin saving program outcomes",
+		zap.String("request_id", requestID),
+		zap.String("program", name),
+		zap.Int("years", len(request.Surveys)))
+
+	surveys, err := h.service.SaveSurveys(ctx, name, request.Surveys)
+	if err != nil {
+		h.respondOutcomeError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"name":       name,
+		"data":       surveys,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *OutcomeHandler) respondOutcomeError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, outcomes.ErrProgramNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, outcomes.ErrInvalidOutcome):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Outcome operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Outcome operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -1,12 +1,26 @@
 package handlers
 
 import (
+	"bytes"
+	"cmp"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/api/middleware"
+	"github.com/mayura-andrew/fastfinder/internal/core/district"
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/drafts"
 	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
 	"github.com/mayura-andrew/fastfinder/internal/services/scraper"
 	"go.uber.org/zap"
@@ -28,11 +42,23 @@ func NewPathwayHandler(service *pathway.Service, youtubeService *scraper.YouTube
 	}
 }
 
-// GetInstitutes handles GET /api/v1/pathway/institutes
+// GetInstitutes handles GET /api/v1/pathway/institutes?type=university&district=Kandy&q=&limit=50&cursor=
+// Without a filter, limit or cursor the whole listing is returned at once.
 func (h *PathwayHandler) GetInstitutes(c *gin.Context) {
-	ctx := c.Request.Context()
+	ctx, stale := pathway.WithStaleFlag(c.Request.Context())
 	requestID := c.GetString("request_id")
 
+	filter := neo4j.InstituteFilter{
+		Query:    c.Query("q"),
+		Type:     c.Query("type"),
+		District: c.Query("district"),
+	}
+	if filter != (neo4j.InstituteFilter{}) || pageRequested(c) {
+		page, err := h.service.ListInstitutes(ctx, filter, c.Query("cursor"), queryInt(c, "limit"))
+		writeListPage(c, h.logger, page, err, "Failed to fetch institutes", nil)
+		return
+	}
+
 	h.logger.Info("Fetching all institutes", zap.String("request_id", requestID))
 
 	institutes, err := h.service.GetAllInstitutes(ctx)
@@ -49,18 +75,23 @@ func (h *PathwayHandler) GetInstitutes(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	writeJSON(c, http.StatusOK, staleListing(c, stale, gin.H{
 		"success":    true,
 		"data":       institutes,
 		"count":      len(institutes),
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
-	})
+	}))
 }
 
-// GetProgramsByInstitute handles GET /api/v1/pathway/institutes/:name/programs
+// GetProgramsByInstitute handles GET /api/v1/pathway/institutes/:name/programs?department=&faculty=&q=&limit=50&cursor=
+// Without a filter, limit or cursor the whole listing is returned at once.
 func (h *PathwayHandler) GetProgramsByInstitute(c *gin.Context) {
-	ctx := c.Request.Context()
+	ctx, stale := pathway.WithStaleFlag(c.Request.Context())
+	ctx, ok := withDistrict(c, ctx)
+	if !ok {
+		return
+	}
 	requestID := c.GetString("request_id")
 	instituteName := c.Param("name")
 
@@ -78,6 +109,17 @@ func (h *PathwayHandler) GetProgramsByInstitute(c *gin.Context) {
 		return
 	}
 
+	filter := neo4j.ProgramFilter{
+		Query:      c.Query("q"),
+		Faculty:    c.Query("faculty"),
+		Department: c.Query("department"),
+	}
+	if filter != (neo4j.ProgramFilter{}) || pageRequested(c) {
+		page, err := h.service.ListProgramsByInstitute(ctx, instituteName, filter, c.Query("cursor"), queryInt(c, "limit"))
+		writeListPage(c, h.logger, page, err, "Failed to fetch programs", gin.H{"institute": instituteName})
+		return
+	}
+
 	programs, err := h.service.GetProgramsByInstitute(ctx, instituteName)
 	if err != nil {
 		h.logger.Error("Failed to fetch programs",
@@ -93,17 +135,40 @@ func (h *PathwayHandler) GetProgramsByInstitute(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	writeJSON(c, http.StatusOK, staleListing(c, stale, gin.H{
 		"success":    true,
 		"data":       programs,
 		"count":      len(programs),
 		"institute":  instituteName,
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
-	})
+	}))
 }
 
-// GetProgramDetails handles GET /api/v1/pathway/programs/:name
+// GetProgramsByDistrict handles GET /api/v1/pathway/districts/:name/programs?faculty=&department=&q=&limit=50&cursor=
+// Returns the programs of the institutes located in a district, a page at a time.
+func (h *PathwayHandler) GetProgramsByDistrict(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+
+	filter := neo4j.ProgramFilter{
+		Query:      c.Query("q"),
+		Faculty:    c.Query("faculty"),
+		Department: c.Query("department"),
+	}
+	page, err := h.service.ListProgramsByDistrict(ctx, name, filter, c.Query("cursor"), queryInt(c, "limit"))
+	fields := gin.H{}
+	if canonical, ok := district.Canonical(name); ok {
+		fields["district"] = canonical
+		fields["province"] = district.ProvinceOf(canonical)
+	}
+	writeListPage(c, h.logger, page, err, "Failed to fetch programs", fields)
+}
+
+// GetProgramDetails handles GET /api/v1/pathway/programs/:name?home_district=Badulla
+// A misspelt name is corrected when one program is clearly meant, and reported
+// as "corrected_from"; otherwise the 404 lists the closest programs as
+// "did_you_mean".
 func (h *PathwayHandler) GetProgramDetails(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
@@ -123,94 +188,144 @@ func (h *PathwayHandler) GetProgramDetails(c *gin.Context) {
 		return
 	}
 
-	details, err := h.service.GetProgramDetails(ctx, programName)
+	details, err := h.service.GetProgramDetails(ctx, h.service.CorrectName(ctx, neo4j.KindProgram, programName))
 	if err != nil {
 		h.logger.Error("Failed to fetch program details",
 			zap.String("request_id", requestID),
 			zap.String("program", programName),
 			zap.Error(err))
 		c.JSON(http.StatusNotFound, gin.H{
-			"success":    false,
-			"error":      "Program not found",
-			"request_id": requestID,
-			"timestamp":  time.Now().UTC(),
+			"success":      false,
+			"error":        "Program not found",
+			"did_you_mean": h.service.SuggestNames(ctx, neo4j.KindProgram, programName),
+			"request_id":   requestID,
+			"timestamp":    time.Now().UTC(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	// Students give their home district to learn what moving to the institute costs
+	if homeDistrict := c.Query("home_district"); homeDistrict != "" {
+		if err := h.service.AttachLivingCost(ctx, details, homeDistrict); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      err.Error(),
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+	}
+
+	h.service.RecordView(historyUser(c), mongodb.EntityTypeProgram, details.Name)
+
+	response := gin.H{
 		"success":    true,
 		"data":       details,
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
-	})
+	}
+	if details.Name != programName {
+		response["corrected_from"] = programName
+	}
+	c.JSON(http.StatusOK, response)
 }
 
-// GetCareerPaths handles POST /api/v1/pathway/career-paths
-func (h *PathwayHandler) GetCareerPaths(c *gin.Context) {
+// GetLivingCosts handles GET /api/v1/pathway/living-costs, the monthly living
+// costs of each district recorded
+func (h *PathwayHandler) GetLivingCosts(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
 
-	var request struct {
-		Qualifications []string `json:"qualifications" binding:"required,min=1"`
-	}
-
-	if err := c.ShouldBindJSON(&request); err != nil {
-		h.logger.Warn("Invalid request body",
+	costs, err := h.service.ListLivingCosts(ctx)
+	if err != nil {
+		h.logger.Error("Failed to fetch living costs",
 			zap.String("request_id", requestID),
 			zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
+		c.JSON(http.StatusInternalServerError, gin.H{
 			"success":    false,
-			"error":      "Invalid request: qualifications array is required",
+			"error":      "Failed to fetch living costs",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	h.logger.Info("Finding career paths",
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       costs,
+		"count":      len(costs),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetProgramOverview handles GET /api/v1/pathway/programs/:name/overview
+// Returns the program details and its cached learning roadmap (null if none has
+// been generated yet) in one response.
+func (h *PathwayHandler) GetProgramOverview(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("name")
+
+	h.logger.Info("Fetching program overview",
 		zap.String("request_id", requestID),
-		zap.Strings("qualifications", request.Qualifications))
+		zap.String("program", programName))
 
-	paths, err := h.service.GetCareerPaths(ctx, request.Qualifications)
+	overview, err := h.service.GetProgramOverview(ctx, programName)
 	if err != nil {
-		h.logger.Error("Failed to find career paths",
+		h.logger.Error("Failed to fetch program overview",
 			zap.String("request_id", requestID),
+			zap.String("program", programName),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(http.StatusNotFound, gin.H{
 			"success":    false,
-			"error":      "Failed to find career paths",
+			"error":      "Program not found",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
+	h.service.RecordView(historyUser(c), mongodb.EntityTypeProgram, overview.Program.Name)
+
 	c.JSON(http.StatusOK, gin.H{
 		"success":        true,
-		"data":           paths,
-		"count":          len(paths),
-		"qualifications": request.Qualifications,
+		"data":           overview,
+		"roadmap_cached": overview.Roadmap != nil,
 		"request_id":     requestID,
 		"timestamp":      time.Now().UTC(),
 	})
 }
 
-// GetAllCareers handles GET /api/v1/pathway/careers
-func (h *PathwayHandler) GetAllCareers(c *gin.Context) {
+// GetZScoreCutoffs handles GET /api/v1/pathway/programs/:name/zscore-cutoffs?district=Colombo
+func (h *PathwayHandler) GetZScoreCutoffs(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
+	programName := c.Param("name")
+	district := c.Query("district")
 
-	h.logger.Info("Fetching all careers", zap.String("request_id", requestID))
+	h.logger.Info("Fetching z-score cutoffs",
+		zap.String("request_id", requestID),
+		zap.String("program", programName),
+		zap.String("district", district))
 
-	careers, err := h.service.GetAllCareers(ctx)
+	cutoffs, err := h.service.GetZScoreCutoffs(ctx, programName, district)
 	if err != nil {
-		h.logger.Error("Failed to fetch careers",
-			zap.String("request_id", requestID),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		message := "Failed to fetch z-score cutoffs"
+		if errors.Is(err, pathway.ErrProgramNotFound) {
+			status = http.StatusNotFound
+			message = err.Error()
+		} else {
+			h.logger.Error("Failed to fetch z-score cutoffs",
+				zap.String("request_id", requestID),
+				zap.String("program", programName),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
 			"success":    false,
-			"error":      "Failed to fetch careers",
+			"error":      message,
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
@@ -219,42 +334,55 @@ func (h *PathwayHandler) GetAllCareers(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success":    true,
-		"data":       careers,
-		"count":      len(careers),
+		"data":       cutoffs,
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
 	})
 }
 
-// GetPathwayToCareer handles GET /api/v1/pathway/careers/:title/pathways
-func (h *PathwayHandler) GetPathwayToCareer(c *gin.Context) {
+// GetFundingOptions handles POST /api/v1/pathway/funding-options
+// Body: {"program_name": "...", "household_income_band": "25000_50000"}
+// The income band may come from the draft profile in X-Draft-Profile instead.
+func (h *PathwayHandler) GetFundingOptions(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
-	careerTitle := c.Param("title")
-
-	h.logger.Info("Finding pathways to career",
-		zap.String("request_id", requestID),
-		zap.String("career", careerTitle))
 
-	if careerTitle == "" {
+	var req pathway.FundingRequest
+	err := c.ShouldBindJSON(&req)
+	if draft := drafts.FromContext(ctx); draft != nil && req.HouseholdIncomeBand == "" {
+		req.HouseholdIncomeBand = draft.HouseholdIncomeBand
+	}
+	if err != nil || req.HouseholdIncomeBand == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success":    false,
-			"error":      "Career title is required",
+			"error":      "Request body must give the program_name and household_income_band",
+			"bands":      pathway.IncomeBands,
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	paths, err := h.service.GetPathwayToCareer(ctx, careerTitle)
+	options, err := h.service.GetFundingOptions(ctx, req)
 	if err != nil {
-		h.logger.Error("Failed to find career pathways",
-			zap.String("request_id", requestID),
-			zap.String("career", careerTitle),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		message := "Failed to check funding options"
+		switch {
+		case errors.Is(err, pathway.ErrProgramNotFound):
+			status = http.StatusNotFound
+			message = err.Error()
+		case errors.Is(err, pathway.ErrInvalidFundingRequest):
+			status = http.StatusBadRequest
+			message = err.Error()
+		default:
+			h.logger.Error("Failed to check funding options",
+				zap.String("request_id", requestID),
+				zap.String("program", req.ProgramName),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
 			"success":    false,
-			"error":      "Failed to find career pathways",
+			"error":      message,
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
@@ -263,43 +391,92 @@ func (h *PathwayHandler) GetPathwayToCareer(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success":    true,
-		"data":       paths,
-		"count":      len(paths),
-		"career":     careerTitle,
+		"data":       options,
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
 	})
 }
 
-// GetCompletePathway handles GET /api/v1/pathway/departments/:name/complete
-func (h *PathwayHandler) GetCompletePathway(c *gin.Context) {
+// GetScholarships handles GET /api/v1/pathway/scholarships?q=&district=Badulla&monthly_income=30000
+// Returns the scholarships in the graph, narrowed to those open to a household's
+// monthly income (in rupees) and district when given.
+func (h *PathwayHandler) GetScholarships(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
-	department := c.Param("name")
 
-	h.logger.Info("Fetching complete pathway",
-		zap.String("request_id", requestID),
-		zap.String("department", department))
+	filter := neo4j.ScholarshipFilter{
+		Query:    c.Query("q"),
+		District: c.Query("district"),
+	}
+	if income := c.Query("monthly_income"); income != "" {
+		value, err := strconv.ParseInt(income, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      "monthly_income must be a whole number of rupees",
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		filter.MonthlyIncomeLKR = value
+	}
 
-	if department == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
+	scholarships, err := h.service.ListScholarships(ctx, filter)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to fetch scholarships"
+		switch {
+		case errors.Is(err, pathway.ErrInvalidFilter):
+			status = http.StatusBadRequest
+			message = err.Error()
+		default:
+			h.logger.Error("Failed to fetch scholarships",
+				zap.String("request_id", requestID),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
 			"success":    false,
-			"error":      "Department name is required",
+			"error":      message,
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	programs, err := h.service.GetCompletePathway(ctx, department)
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       scholarships,
+		"count":      len(scholarships),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetProgramScholarships handles GET /api/v1/pathway/programs/:name/scholarships
+// Returns the scholarships funding the program or its institute.
+func (h *PathwayHandler) GetProgramScholarships(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("name")
+
+	scholarships, err := h.service.GetProgramScholarships(ctx, programName)
 	if err != nil {
-		h.logger.Error("Failed to fetch complete pathway",
-			zap.String("request_id", requestID),
-			zap.String("department", department),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		message := "Failed to fetch program scholarships"
+		switch {
+		case errors.Is(err, pathway.ErrProgramNotFound):
+			status = http.StatusNotFound
+			message = err.Error()
+		default:
+			h.logger.Error("Failed to fetch program scholarships",
+				zap.String("request_id", requestID),
+				zap.String("program", programName),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
 			"success":    false,
-			"error":      "Failed to fetch complete pathway",
+			"error":      message,
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
@@ -308,57 +485,69 @@ func (h *PathwayHandler) GetCompletePathway(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success":    true,
-		"data":       programs,
-		"count":      len(programs),
-		"department": department,
+		"data":       scholarships,
+		"count":      len(scholarships),
+		"program":    programName,
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
 	})
 }
 
-// GetPathwayByQualification handles GET /api/v1/pathway/departments/:name/by-qualification
-// Query params: qualification (string)
-func (h *PathwayHandler) GetPathwayByQualification(c *gin.Context) {
+// GetReadinessAssessment handles POST /api/v1/pathway/programs/:name/readiness
+// Body: {"qualifications": ["G.C.E. (O/L) Examination Pass"], "subjects": [{"subject": "Mathematics", "grade": "C"}], "study_hours_per_week": 10}
+// Returns strong and weak areas and the weeks to prepare per subject. Answers
+// left out are taken from the draft profile in X-Draft-Profile.
+func (h *PathwayHandler) GetReadinessAssessment(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
-	department := c.Param("name")
-	qualification := c.Query("qualification")
-
-	h.logger.Info("Fetching pathway by qualification",
-		zap.String("request_id", requestID),
-		zap.String("department", department),
-		zap.String("qualification", qualification))
+	programName := c.Param("name")
 
-	if department == "" {
+	var req pathway.ReadinessRequest
+	if err := bindDraftable(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success":    false,
-			"error":      "Department name is required",
+			"error":      "Invalid request body",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	if qualification == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success":    false,
-			"error":      "Qualification parameter is required",
-			"request_id": requestID,
-			"timestamp":  time.Now().UTC(),
-		})
-		return
+	if draft := drafts.FromContext(ctx); draft != nil {
+		if len(req.Qualifications) == 0 {
+			req.Qualifications = draft.Qualifications
+		}
+		if len(req.Subjects) == 0 {
+			req.Subjects = draft.Subjects
+		}
+		if req.StudyHoursPerWeek == 0 {
+			req.StudyHoursPerWeek = draft.StudyHoursPerWeek
+		}
 	}
 
-	programs, err := h.service.GetPathwayByQualification(ctx, department, qualification)
+	readiness, err := h.service.GetReadinessAssessment(ctx, programName, req)
 	if err != nil {
-		h.logger.Error("Failed to fetch pathway by qualification",
-			zap.String("request_id", requestID),
-			zap.String("department", department),
-			zap.String("qualification", qualification),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		message := "Failed to assess readiness"
+		switch {
+		case errors.Is(err, pathway.ErrProgramNotFound):
+			status = http.StatusNotFound
+			message = err.Error()
+		case errors.Is(err, pathway.ErrInvalidProfile):
+			status = http.StatusBadRequest
+			message = err.Error()
+		case errors.Is(err, pathway.ErrReadinessUnavailable):
+			status = http.StatusServiceUnavailable
+			message = "Readiness assessments are temporarily unavailable"
+		default:
+			h.logger.Error("Failed to assess readiness",
+				zap.String("request_id", requestID),
+				zap.String("program", programName),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
 			"success":    false,
-			"error":      "Failed to fetch pathway",
+			"error":      message,
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
@@ -366,144 +555,1303 @@ func (h *PathwayHandler) GetPathwayByQualification(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"success":       true,
-		"data":          programs,
-		"count":         len(programs),
-		"department":    department,
-		"qualification": qualification,
-		"request_id":    requestID,
-		"timestamp":     time.Now().UTC(),
+		"success":    true,
+		"data":       readiness,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
 	})
 }
 
-// GetLearningRoadmap handles GET /api/v1/pathway/programs/:name/learning-roadmap
-func (h *PathwayHandler) GetLearningRoadmap(c *gin.Context) {
+// GetCareerPaths handles POST /api/v1/pathway/career-paths
+// Body: {"qualifications": ["..."]}, or the qualifications of the draft profile
+// in X-Draft-Profile when left out
+func (h *PathwayHandler) GetCareerPaths(c *gin.Context) {
 	ctx := c.Request.Context()
+	ctx, ok := withDistrict(c, ctx)
+	if !ok {
+		return
+	}
 	requestID := c.GetString("request_id")
-	programName := c.Param("name")
 
-	h.logger.Info("Fetching learning roadmap",
-		zap.String("request_id", requestID),
-		zap.String("program", programName))
+	var request struct {
+		Qualifications []string `json:"qualifications"`
+	}
 
-	if programName == "" {
-		h.logger.Warn("Program name is required",
-			zap.String("request_id", requestID))
+	err := bindDraftable(c, &request)
+	if draft := drafts.FromContext(ctx); draft != nil && len(request.Qualifications) == 0 {
+		request.Qualifications = draft.Qualifications
+	}
+	if err != nil || len(request.Qualifications) == 0 {
+		h.logger.Warn("Invalid request body",
+			zap.String("request_id", requestID),
+			zap.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success":    false,
-			"error":      "Program name is required",
+			"error":      "Invalid request: qualifications array is required",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	roadmap, err := h.service.GetLearningRoadmap(ctx, programName)
+	h.logger.Info("Finding career paths",
+		zap.String("request_id", requestID),
+		zap.Strings("qualifications", request.Qualifications))
+
+	stream := newJSONArrayStream(c)
+	err = h.service.StreamCareerPaths(ctx, request.Qualifications, func(path neo4j.EducationPath) error {
+		return stream.Write(path)
+	})
 	if err != nil {
-		h.logger.Error("Failed to generate learning roadmap",
+		h.logger.Error("Failed to find career paths",
 			zap.String("request_id", requestID),
-			zap.String("program", programName),
+			zap.Bool("partial", stream.Started()),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success":    false,
-			"error":      "Failed to generate learning roadmap",
-			"request_id": requestID,
-			"timestamp":  time.Now().UTC(),
-		})
+		h.failStream(c, stream, "Failed to find career paths")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success":    true,
-		"data":       roadmap,
-		"program":    programName,
-		"request_id": requestID,
-		"timestamp":  time.Now().UTC(),
+	for _, qualification := range request.Qualifications {
+		middleware.TrackEvent(c, mongodb.EventQualificationSearched, qualification)
+	}
+	if stream.Count() == 0 {
+		middleware.TrackEvent(c, mongodb.EventEligibilityNoResults, qualificationSet(request.Qualifications))
+	}
+
+	_ = stream.Close(gin.H{
+		"success":        true,
+		"qualifications": request.Qualifications,
+		"request_id":     requestID,
+		"timestamp":      time.Now().UTC(),
 	})
 }
 
-// GetCachedLearningRoadmap handles GET /api/v1/pathway/programs/:name/learning-roadmap/cached
-// Returns ONLY cached roadmap data, does NOT call LLM - used as fallback when LLM is slow/unavailable
-func (h *PathwayHandler) GetCachedLearningRoadmap(c *gin.Context) {
+// RecognizePriorLearning handles POST /api/v1/pathway/prior-learning
+// Body: {"experience": [{"occupation": "electrician", "years": 4}], "qualifications": ["..."]}
+// Declared work experience is recognized as NVQ qualifications, then the career
+// paths open with those and the declared qualifications are streamed. Either
+// may come from the draft profile in X-Draft-Profile instead.
+func (h *PathwayHandler) RecognizePriorLearning(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
-	programName := c.Param("name")
-
-	h.logger.Info("Fetching cached learning roadmap only",
-		zap.String("request_id", requestID),
-		zap.String("program", programName))
 
-	if programName == "" {
-		h.logger.Warn("Program name is required",
-			zap.String("request_id", requestID))
+	var request struct {
+		Experience     []pathway.WorkExperience `json:"experience"`
+		Qualifications []string                 `json:"qualifications"`
+	}
+	err := bindDraftable(c, &request)
+	if draft := drafts.FromContext(ctx); draft != nil {
+		if len(request.Experience) == 0 {
+			request.Experience = draft.Experience
+		}
+		if len(request.Qualifications) == 0 {
+			request.Qualifications = draft.Qualifications
+		}
+	}
+	if err != nil || len(request.Experience) == 0 {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success":    false,
-			"error":      "Program name is required",
+			"error":      "Invalid request: experience array is required",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	roadmap, err := h.service.GetCachedLearningRoadmap(ctx, programName)
+	recognition, err := h.service.RecognizePriorLearning(ctx, request.Experience, request.Qualifications)
 	if err != nil {
-		h.logger.Warn("No cached roadmap found",
-			zap.String("request_id", requestID),
-			zap.String("program", programName),
-			zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
+		status := http.StatusInternalServerError
+		message := "Failed to recognize prior learning"
+		switch {
+		case errors.Is(err, pathway.ErrInvalidExperience):
+			status = http.StatusBadRequest
+			message = err.Error()
+		default:
+			h.logger.Error("Failed to recognize prior learning",
+				zap.String("request_id", requestID),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
 			"success":    false,
-			"error":      "No cached roadmap found for this program",
-			"message":    "Try generating a new roadmap first using the /learning-roadmap endpoint",
+			"error":      message,
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success":    true,
-		"data":       roadmap,
-		"program":    programName,
-		"source":     "cache",
+	h.logger.Info("Finding career paths with prior learning",
+		zap.String("request_id", requestID),
+		zap.Strings("qualifications", recognition.Qualifications))
+
+	stream := newJSONArrayStream(c)
+	if len(recognition.Qualifications) > 0 {
+		err = h.service.StreamCareerPaths(ctx, recognition.Qualifications, func(path neo4j.EducationPath) error {
+			return stream.Write(path)
+		})
+		if err != nil {
+			h.logger.Error("Failed to find career paths",
+				zap.String("request_id", requestID),
+				zap.Bool("partial", stream.Started()),
+				zap.Error(err))
+			h.failStream(c, stream, "Failed to find career paths")
+			return
+		}
+	}
+
+	if stream.Count() == 0 && len(recognition.Qualifications) > 0 {
+		middleware.TrackEvent(c, mongodb.EventEligibilityNoResults, qualificationSet(recognition.Qualifications))
+	}
+
+	_ = stream.Close(gin.H{
+		"success":        true,
+		"recognized":     recognition.Recognized,
+		"unrecognized":   recognition.Unrecognized,
+		"qualifications": recognition.Qualifications,
+		"disclaimer":     recognition.Disclaimer,
+		"request_id":     requestID,
+		"timestamp":      time.Now().UTC(),
+	})
+}
+
+// GetAllCareers handles GET /api/v1/pathway/careers?department=&institute_type=vocational&q=&sort=demand&limit=50&cursor=
+// Without a filter, sort, limit or cursor the whole listing is returned at once.
+// Careers carry their demand index; sort=demand lists the highest first.
+func (h *PathwayHandler) GetAllCareers(c *gin.Context) {
+	ctx, stale := pathway.WithStaleFlag(c.Request.Context())
+	requestID := c.GetString("request_id")
+
+	filter := neo4j.CareerFilter{
+		Query:         c.Query("q"),
+		Department:    c.Query("department"),
+		InstituteType: c.Query("institute_type"),
+	}
+	order := c.Query("sort")
+	if filter != (neo4j.CareerFilter{}) || order != "" || pageRequested(c) {
+		page, err := h.service.ListCareers(ctx, filter, order, c.Query("cursor"), queryInt(c, "limit"))
+		writeListPage(c, h.logger, page, err, "Failed to fetch careers", nil)
+		return
+	}
+
+	h.logger.Info("Fetching all careers", zap.String("request_id", requestID))
+
+	careers, err := h.service.GetAllCareers(ctx)
+	if err != nil {
+		h.logger.Error("Failed to fetch careers",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to fetch careers",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, staleListing(c, stale, gin.H{
+		"success":    true,
+		"data":       careers,
+		"count":      len(careers),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	}))
+}
+
+// GetPathwayToCareer handles GET /api/v1/pathway/careers/:title/pathways
+// A misspelt title is corrected as in GetProgramDetails; when no pathways are
+// found the closest careers are listed as "did_you_mean".
+func (h *PathwayHandler) GetPathwayToCareer(c *gin.Context) {
+	ctx := c.Request.Context()
+	ctx, ok := withDistrict(c, ctx)
+	if !ok {
+		return
+	}
+	requestID := c.GetString("request_id")
+	careerTitle := c.Param("title")
+
+	h.logger.Info("Finding pathways to career",
+		zap.String("request_id", requestID),
+		zap.String("career", careerTitle))
+
+	if careerTitle == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Career title is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	typed := careerTitle
+	careerTitle = h.service.CorrectName(ctx, neo4j.KindCareer, careerTitle)
+
+	stream := newJSONArrayStream(c)
+	err := h.service.StreamPathwayToCareer(ctx, careerTitle, func(path neo4j.EducationPath) error {
+		return stream.Write(path)
+	})
+	if err != nil {
+		h.logger.Error("Failed to find career pathways",
+			zap.String("request_id", requestID),
+			zap.String("career", careerTitle),
+			zap.Bool("partial", stream.Started()),
+			zap.Error(err))
+		h.failStream(c, stream, "Failed to find career pathways")
+		return
+	}
+
+	h.service.RecordView(historyUser(c), mongodb.EntityTypeCareer, careerTitle)
+	middleware.TrackEvent(c, mongodb.EventCareerTargeted, careerTitle)
+
+	fields := gin.H{
+		"success":    true,
+		"career":     careerTitle,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	}
+	if careerTitle != typed {
+		fields["corrected_from"] = typed
+	}
+	if stream.Count() == 0 {
+		fields["did_you_mean"] = h.service.SuggestNames(ctx, neo4j.KindCareer, typed)
+	}
+	_ = stream.Close(fields)
+}
+
+// GetCareerTransition handles GET /api/v1/pathway/careers/:title/transition-to/:to
+// Programs that help someone working as :title retrain for :to, best first.
+// Misspelt titles are corrected as in GetProgramDetails.
+func (h *PathwayHandler) GetCareerTransition(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	from := c.Param("title")
+	to := c.Param("to")
+
+	h.logger.Info("Finding career transition",
+		zap.String("request_id", requestID),
+		zap.String("from", from),
+		zap.String("to", to))
+
+	from = h.service.CorrectName(ctx, neo4j.KindCareer, from)
+	to = h.service.CorrectName(ctx, neo4j.KindCareer, to)
+	transition, err := h.service.GetCareerTransition(ctx, from, to)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to find career transition"
+		switch {
+		case errors.Is(err, neo4j.ErrEntityNotFound):
+			status = http.StatusNotFound
+			message = err.Error()
+		case errors.Is(err, pathway.ErrInvalidTransition):
+			status = http.StatusBadRequest
+			message = err.Error()
+		default:
+			h.logger.Error("Failed to find career transition",
+				zap.String("request_id", requestID),
+				zap.String("from", from),
+				zap.String("to", to),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	middleware.TrackEvent(c, mongodb.EventCareerTargeted, transition.To)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       transition,
+		"count":      len(transition.Options),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// staleListing flags a listing answered from the snapshot taken while the graph
+// was reachable, and keeps browsers and CDNs from caching it
+func staleListing(c *gin.Context, stale *pathway.StaleFlag, body gin.H) gin.H {
+	if takenAt, ok := stale.SnapshotTakenAt(); ok {
+		c.Header("Cache-Control", "no-store")
+		body["stale"] = true
+		body["snapshot_taken_at"] = takenAt
+	}
+	return body
+}
+
+// pageRequested reports whether a listing request asks for a page rather than
+// the whole listing
+func pageRequested(c *gin.Context) bool {
+	return c.Query("limit") != "" || c.Query("cursor") != ""
+}
+
+// qualificationSet names a set of qualifications the same whatever order they
+// were given in, as recorded for eligibility checks that found nothing
+func qualificationSet(qualifications []string) string {
+	sorted := slices.Clone(qualifications)
+	slices.Sort(sorted)
+	return strings.Join(slices.Compact(sorted), " + ")
+}
+
+// withDistrict narrows pathway reads to the programs offered in the district
+// named by ?district=, answering with a 400 when it is not a known district
+func withDistrict(c *gin.Context, ctx context.Context) (context.Context, bool) {
+	name := c.Query("district")
+	if name == "" {
+		return ctx, true
+	}
+	canonical, ok := district.Canonical(name)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      fmt.Sprintf("unknown district %q", name),
+			"request_id": c.GetString("request_id"),
+			"timestamp":  time.Now().UTC(),
+		})
+		return nil, false
+	}
+	return pathway.WithDistrict(ctx, canonical), true
+}
+
+// writeListPage answers a paginated listing with its page and the cursor of the
+// next, plus fields describing the listing
+func writeListPage[T any](c *gin.Context, logger *zap.Logger, page *pathway.ListPage[T], err error, message string, fields gin.H) {
+	requestID := c.GetString("request_id")
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, pathway.ErrInvalidCursor), errors.Is(err, pathway.ErrInvalidFilter):
+			status = http.StatusBadRequest
+			message = err.Error()
+		case errors.Is(err, pathway.ErrDistrictNotFound):
+			status = http.StatusNotFound
+			message = err.Error()
+		default:
+			logger.Error(message,
+				zap.String("request_id", requestID),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	body := gin.H{
+		"success":     true,
+		"data":        page.Items,
+		"count":       len(page.Items),
+		"next_cursor": page.NextCursor,
+		"request_id":  requestID,
+		"timestamp":   time.Now().UTC(),
+	}
+	for key, value := range fields {
+		body[key] = value
+	}
+	writeJSON(c, http.StatusOK, body)
+}
+
+// failStream answers a failed streamed listing: with a 500 when nothing has
+// been sent yet, otherwise by ending the partial array with success false
+func (h *PathwayHandler) failStream(c *gin.Context, stream *jsonArrayStream, message string) {
+	requestID := c.GetString("request_id")
+	if !stream.Started() {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+	_ = stream.Close(gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetCompletePathway handles GET /api/v1/pathway/departments/:name/complete
+func (h *PathwayHandler) GetCompletePathway(c *gin.Context) {
+	ctx, stale := pathway.WithStaleFlag(c.Request.Context())
+	ctx, ok := withDistrict(c, ctx)
+	if !ok {
+		return
+	}
+	requestID := c.GetString("request_id")
+	department := c.Param("name")
+
+	h.logger.Info("Fetching complete pathway",
+		zap.String("request_id", requestID),
+		zap.String("department", department))
+
+	if department == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Department name is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	programs, err := h.service.GetCompletePathway(ctx, department)
+	if err != nil {
+		h.logger.Error("Failed to fetch complete pathway",
+			zap.String("request_id", requestID),
+			zap.String("department", department),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to fetch complete pathway",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, staleListing(c, stale, gin.H{
+		"success":    true,
+		"data":       programs,
+		"count":      len(programs),
+		"department": department,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	}))
+}
+
+// GetPathwayByQualification handles GET /api/v1/pathway/departments/:name/by-qualification
+// Query params: qualification (string), max_hops (prerequisite steps beyond the
+// programs requiring the qualification, 0 to 6, default 6), rank_by (distance,
+// level or popularity, default distance). Each program carries its rank.
+func (h *PathwayHandler) GetPathwayByQualification(c *gin.Context) {
+	ctx := c.Request.Context()
+	ctx, ok := withDistrict(c, ctx)
+	if !ok {
+		return
+	}
+	requestID := c.GetString("request_id")
+	department := c.Param("name")
+	qualification := c.Query("qualification")
+	rankBy := c.Query("rank_by")
+
+	h.logger.Info("Fetching pathway by qualification",
+		zap.String("request_id", requestID),
+		zap.String("department", department),
+		zap.String("qualification", qualification),
+		zap.String("rank_by", rankBy))
+
+	if department == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Department name is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	if qualification == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Qualification parameter is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	maxHops := neo4j.MaxPrerequisiteDepth
+	if value, ok := c.GetQuery("max_hops"); ok {
+		hops, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      "max_hops must be a number",
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		maxHops = hops
+	}
+
+	programs, err := h.service.GetPathwayByQualification(ctx, department, qualification, maxHops, rankBy)
+	if errors.Is(err, pathway.ErrInvalidTraversal) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      err.Error(),
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to fetch pathway by qualification",
+			zap.String("request_id", requestID),
+			zap.String("department", department),
+			zap.String("qualification", qualification),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to fetch pathway",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	middleware.TrackEvent(c, mongodb.EventQualificationSearched, qualification)
+	if len(programs) == 0 {
+		middleware.TrackEvent(c, mongodb.EventEligibilityNoResults, qualification)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"data":          programs,
+		"count":         len(programs),
+		"department":    department,
+		"qualification": qualification,
+		"max_hops":      maxHops,
+		"rank_by":       cmp.Or(rankBy, pathway.RankByDistance),
+		"request_id":    requestID,
+		"timestamp":     time.Now().UTC(),
+	})
+}
+
+// GetLearningRoadmap handles GET /api/v1/pathway/programs/:name/learning-roadmap?steps=&free_only=
+func (h *PathwayHandler) GetLearningRoadmap(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("name")
+
+	h.logger.Info("Fetching learning roadmap",
+		zap.String("request_id", requestID),
+		zap.String("program", programName))
+
+	if programName == "" {
+		h.logger.Warn("Program name is required",
+			zap.String("request_id", requestID))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Program name is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	steps, ok := h.stepRange(c)
+	if !ok {
+		return
+	}
+
+	roadmap, err := h.service.GetLearningRoadmap(ctx, programName)
+	if err != nil {
+		h.logger.Error("Failed to generate learning roadmap",
+			zap.String("request_id", requestID),
+			zap.String("program", programName),
+			zap.Error(err))
+		h.failRoadmap(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, roadmapBody(c, roadmap, steps, gin.H{
+		"success":    true,
+		"program":    programName,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	}))
+}
+
+// stepRange reads the optional steps query, e.g. ?steps=1-3, answering 400 when
+// it cannot be parsed
+func (h *PathwayHandler) stepRange(c *gin.Context) (pathway.StepRange, bool) {
+	steps, err := pathway.ParseStepRange(c.Query("steps"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "steps must be a step number or range such as 1-3 or 4-",
+			"request_id": c.GetString("request_id"),
+			"timestamp":  time.Now().UTC(),
+		})
+		return pathway.StepRange{}, false
+	}
+	return steps, true
+}
+
+// roadmapBody adds the steps of the roadmap in the requested range, how many it
+// has in all and whether it was personalized by the LLM. Resources are tagged
+// free or paid with the cost of each step and of the whole roadmap, and with
+// ?free_only=true only free resources are suggested. Curated templates served
+// while the LLM is unavailable are kept out of shared caches, so the generated
+// roadmap replaces them once it recovers.
+func roadmapBody(c *gin.Context, roadmap *pathway.LearningRoadmapResponse, steps pathway.StepRange, body gin.H) gin.H {
+	freeOnly, _ := strconv.ParseBool(c.DefaultQuery("free_only", "false"))
+	sliced, more := steps.Apply(pathway.PriceResources(roadmap, freeOnly))
+	body["data"] = sliced
+	body["free_only"] = freeOnly
+	body["total_steps"] = len(roadmap.Steps)
+	body["has_more_steps"] = more
+	body["personalized"] = !roadmap.Template
+	if roadmap.Template {
+		c.Header("Cache-Control", "no-store")
+	}
+	return body
+}
+
+// failRoadmap answers a roadmap that could not be generated: with a 503 when
+// the LLM is unavailable and the program has no template, otherwise a 500
+func (h *PathwayHandler) failRoadmap(c *gin.Context, err error) {
+	if errors.Is(err, pathway.ErrRoadmapUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success":    false,
+			"error":      "Learning roadmaps are temporarily unavailable for this program",
+			"request_id": c.GetString("request_id"),
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"success":    false,
+		"error":      "Failed to generate learning roadmap",
+		"request_id": c.GetString("request_id"),
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetCachedLearningRoadmap handles GET /api/v1/pathway/programs/:name/learning-roadmap/cached?steps=&free_only=
+// Returns ONLY cached roadmap data, does NOT call LLM - used as fallback when LLM is slow/unavailable
+func (h *PathwayHandler) GetCachedLearningRoadmap(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("name")
+
+	h.logger.Info("Fetching cached learning roadmap only",
+		zap.String("request_id", requestID),
+		zap.String("program", programName))
+
+	if programName == "" {
+		h.logger.Warn("Program name is required",
+			zap.String("request_id", requestID))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Program name is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	steps, ok := h.stepRange(c)
+	if !ok {
+		return
+	}
+
+	roadmap, err := h.service.GetCachedLearningRoadmap(ctx, programName)
+	if err != nil {
+		h.logger.Warn("No cached roadmap found",
+			zap.String("request_id", requestID),
+			zap.String("program", programName),
+			zap.Error(err))
+		c.JSON(http.StatusNotFound, gin.H{
+			"success":    false,
+			"error":      "No cached roadmap found for this program",
+			"message":    "Try generating a new roadmap first using the /learning-roadmap endpoint",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	writeJSON(c, http.StatusOK, roadmapBody(c, roadmap, steps, gin.H{
+		"success":    true,
+		"program":    programName,
+		"source":     "cache",
 		"note":       "This is cached data. For fresh generation, use /learning-roadmap endpoint",
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
+	}))
+}
+
+// ListRoadmapVersions handles GET /api/v1/pathway/programs/:name/learning-roadmap/versions
+// Lists every stored version of the program's roadmap, newest first
+func (h *PathwayHandler) ListRoadmapVersions(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("name")
+
+	versions, err := h.service.ListRoadmapVersions(ctx, programName)
+	if err != nil {
+		h.logger.Error("Failed to list roadmap versions",
+			zap.String("request_id", requestID),
+			zap.String("program", programName),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to list roadmap versions",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       versions,
+		"count":      len(versions),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetRoadmapVersion handles GET /api/v1/pathway/programs/:name/learning-roadmap/versions/:version?steps=&free_only=
+// Returns a stored version of the roadmap, for users still following it
+func (h *PathwayHandler) GetRoadmapVersion(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("name")
+
+	version, ok := h.roadmapVersion(c, c.Param("version"), "version")
+	if !ok {
+		return
+	}
+	steps, ok := h.stepRange(c)
+	if !ok {
+		return
+	}
+
+	roadmap, err := h.service.GetRoadmapVersion(ctx, programName, version)
+	if err != nil {
+		h.failRoadmapVersion(c, programName, err)
+		return
+	}
+
+	// Stored versions never change, so they can be cached like generated roadmaps
+	writeJSON(c, http.StatusOK, roadmapBody(c, roadmap, steps, gin.H{
+		"success":    true,
+		"program":    programName,
+		"version":    version,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	}))
+}
+
+// DiffRoadmapVersions handles GET /api/v1/pathway/programs/:name/learning-roadmap/diff?from=1&to=2
+// Returns the steps added, removed and changed between two versions
+func (h *PathwayHandler) DiffRoadmapVersions(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("name")
+
+	from, ok := h.roadmapVersion(c, c.Query("from"), "from")
+	if !ok {
+		return
+	}
+	to, ok := h.roadmapVersion(c, c.Query("to"), "to")
+	if !ok {
+		return
+	}
+
+	diff, err := h.service.DiffRoadmapVersions(ctx, programName, from, to)
+	if err != nil {
+		h.failRoadmapVersion(c, programName, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       diff,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// roadmapVersion parses a roadmap version number, answering 400 when it is not
+// a positive number
+func (h *PathwayHandler) roadmapVersion(c *gin.Context, value, name string) (int, bool) {
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      name + " must be a roadmap version number",
+			"request_id": c.GetString("request_id"),
+			"timestamp":  time.Now().UTC(),
+		})
+		return 0, false
+	}
+	return version, true
+}
+
+// failRoadmapVersion answers a roadmap version that could not be read
+func (h *PathwayHandler) failRoadmapVersion(c *gin.Context, programName string, err error) {
+	status := http.StatusInternalServerError
+	message := "Failed to fetch roadmap version"
+	if errors.Is(err, pathway.ErrRoadmapVersionNotFound) {
+		status = http.StatusNotFound
+		message = err.Error()
+	} else {
+		h.logger.Error("Failed to fetch roadmap version",
+			zap.String("request_id", c.GetString("request_id")),
+			zap.String("program", programName),
+			zap.Error(err))
+	}
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": c.GetString("request_id"),
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetLearningRoadmapFast handles GET /api/v1/pathway/programs/:name/learning-roadmap-fast?steps=&free_only=
+// Returns roadmap WITHOUT videos for ultra-fast response (2-3 seconds vs 15-30 seconds)
+func (h *PathwayHandler) GetLearningRoadmapFast(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("name")
+
+	h.logger.Info("Fetching FAST learning roadmap (no videos)",
+		zap.String("request_id", requestID),
+		zap.String("program", programName))
+
+	if programName == "" {
+		h.logger.Warn("Program name is required",
+			zap.String("request_id", requestID))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Program name is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	steps, ok := h.stepRange(c)
+	if !ok {
+		return
+	}
+
+	roadmap, err := h.service.GetLearningRoadmapFast(ctx, programName)
+	if err != nil {
+		h.logger.Error("Failed to generate fast learning roadmap",
+			zap.String("request_id", requestID),
+			zap.String("program", programName),
+			zap.Error(err))
+		h.failRoadmap(c, err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, roadmapBody(c, roadmap, steps, gin.H{
+		"success":    true,
+		"program":    programName,
+		"mode":       "fast",
+		"note":       "Videos excluded for faster response. Use /videos/:stepNumber endpoint to fetch videos for specific steps.",
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	}))
+}
+
+// GetVideosForStep handles GET /api/v1/pathway/programs/:name/steps/:stepNumber/videos
+// Fetches videos for a specific learning step on-demand
+func (h *PathwayHandler) GetVideosForStep(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("name")
+	stepNumberStr := c.Param("stepNumber")
+
+	h.logger.Info("Fetching videos for specific step",
+		zap.String("request_id", requestID),
+		zap.String("program", programName),
+		zap.String("step", stepNumberStr))
+
+	if programName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Program name is required",
+		})
+		return
+	}
+
+	// Get topics from query params (comma-separated string or array)
+	topicsStr := c.Query("topics")
+	if topicsStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Topics query parameter is required (comma-separated string)",
+			"example": "/programs/Software%20Engineering/steps/1/videos?topics=Python,JavaScript,Git",
+		})
+		return
+	}
+
+	// Parse comma-separated topics
+	topics := strings.Split(topicsStr, ",")
+	cleanTopics := make([]string, 0)
+	for _, t := range topics {
+		trimmed := strings.TrimSpace(t)
+		if trimmed != "" {
+			cleanTopics = append(cleanTopics, trimmed)
+		}
+	}
+
+	if len(cleanTopics) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "At least one topic is required",
+		})
+		return
+	}
+
+	// Limit to 3 topics max for performance
+	if len(cleanTopics) > 3 {
+		cleanTopics = cleanTopics[:3]
+	}
+
+	// Fetch videos for topics with timeout
+	videoCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var allVideos []interface{}
+	for _, topic := range cleanTopics {
+		videos, err := h.youtubeService.SearchVideos(videoCtx, topic, 1)
+		if err != nil {
+			h.logger.Warn("Failed to fetch videos for topic",
+				zap.String("topic", topic),
+				zap.Error(err))
+			continue
+		}
+
+		for _, v := range videos {
+			allVideos = append(allVideos, v)
+		}
+	}
+
+	h.logger.Info("Video fetching for step completed",
+		zap.String("request_id", requestID),
+		zap.Int("topics_count", len(cleanTopics)),
+		zap.Int("video_count", len(allVideos)))
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"data":        allVideos,
+		"topics":      cleanTopics,
+		"program":     programName,
+		"step_number": stepNumberStr,
+		"request_id":  requestID,
+		"timestamp":   time.Now().UTC(),
+	})
+}
+
+// Cache Management Endpoints
+
+// GetCacheStats handles GET /api/v1/pathway/cache/stats
+func (h *PathwayHandler) GetCacheStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Fetching cache statistics", zap.String("request_id", requestID))
+
+	stats, err := h.service.GetCacheStats(ctx)
+	if err != nil {
+		h.logger.Error("Failed to fetch cache stats",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to fetch cache statistics",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       stats,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
 	})
 }
 
-// GetLearningRoadmapFast handles GET /api/v1/pathway/programs/:name/learning-roadmap-fast
-// Returns roadmap WITHOUT videos for ultra-fast response (2-3 seconds vs 15-30 seconds)
-func (h *PathwayHandler) GetLearningRoadmapFast(c *gin.Context) {
+// InvalidateCache handles DELETE /api/v1/pathway/cache/:program
+func (h *PathwayHandler) InvalidateCache(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
-	programName := c.Param("name")
+	programName := c.Param("program")
 
-	h.logger.Info("Fetching FAST learning roadmap (no videos)",
+	if programName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Program name is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Invalidating cache",
 		zap.String("request_id", requestID),
 		zap.String("program", programName))
 
+	if err := h.service.InvalidateCache(ctx, programName); err != nil {
+		if errors.Is(err, pathway.ErrRoadmapPinned) {
+			h.respondPinned(c, err)
+			return
+		}
+		h.logger.Error("Failed to invalidate cache",
+			zap.String("request_id", requestID),
+			zap.String("program", programName),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to invalidate cache",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Cache invalidated successfully",
+		"program":    programName,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// RefreshCache handles POST /api/v1/pathway/cache/:program/refresh
+func (h *PathwayHandler) RefreshCache(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("program")
+
 	if programName == "" {
-		h.logger.Warn("Program name is required",
-			zap.String("request_id", requestID))
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success":    false,
-			"error":      "Program name is required",
+			"error":      "Program name is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Refreshing cache",
+		zap.String("request_id", requestID),
+		zap.String("program", programName))
+
+	if err := h.service.RefreshCache(ctx, programName); err != nil {
+		if errors.Is(err, pathway.ErrRoadmapPinned) {
+			h.respondPinned(c, err)
+			return
+		}
+		h.logger.Error("Failed to refresh cache",
+			zap.String("request_id", requestID),
+			zap.String("program", programName),
+			zap.Error(err))
+		status := http.StatusInternalServerError
+		if errors.Is(err, pathway.ErrRoadmapUnavailable) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      "Failed to refresh cache",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Cache refreshed successfully",
+		"program":    programName,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// respondPinned answers a change refused because the roadmap is pinned
+func (h *PathwayHandler) respondPinned(c *gin.Context, err error) {
+	c.JSON(http.StatusConflict, gin.H{
+		"success":    false,
+		"error":      err.Error(),
+		"message":    "Unpin the roadmap first to replace it",
+		"request_id": c.GetString("request_id"),
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// PinRoadmap handles PUT /api/v1/admin/roadmaps/:program/pin
+// Pins the program's cached roadmap, e.g. once a counselor has reviewed it, so
+// it never expires and is not replaced by newly generated roadmaps
+func (h *PathwayHandler) PinRoadmap(c *gin.Context) {
+	h.setPinned(c, true)
+}
+
+// UnpinRoadmap handles DELETE /api/v1/admin/roadmaps/:program/pin
+// The roadmap then expires after the cache TTL like any other
+func (h *PathwayHandler) UnpinRoadmap(c *gin.Context) {
+	h.setPinned(c, false)
+}
+
+func (h *PathwayHandler) setPinned(c *gin.Context, pinned bool) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	programName := c.Param("program")
+
+	h.logger.Info("Changing roadmap pin",
+		zap.String("request_id", requestID),
+		zap.String("program", programName),
+		zap.Bool("pinned", pinned))
+
+	if err := h.service.PinRoadmap(ctx, programName, pinned); err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to change roadmap pin"
+		if errors.Is(err, pathway.ErrRoadmapNotCached) {
+			status = http.StatusNotFound
+			message = err.Error()
+		} else {
+			h.logger.Error("Failed to change roadmap pin",
+				zap.String("request_id", requestID),
+				zap.String("program", programName),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"program":    programName,
+		"pinned":     pinned,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// ClearAllCache handles DELETE /api/v1/pathway/cache (use with caution)
+func (h *PathwayHandler) ClearAllCache(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Warn("Clearing all cache", zap.String("request_id", requestID))
+
+	if err := h.service.ClearAllCache(ctx); err != nil {
+		h.logger.Error("Failed to clear cache",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to clear cache",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "All cache cleared successfully",
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetJobRoleDetails handles GET /api/v1/pathway/job-roles/:roleName
+func (h *PathwayHandler) GetJobRoleDetails(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	roleName := c.Param("roleName")
+	programContext := c.Query("program")
+
+	// URL decode the role name
+	roleName = strings.ReplaceAll(roleName, "%20", " ")
+	roleName = strings.ReplaceAll(roleName, "+", " ")
+
+	h.logger.Info("Fetching job role details",
+		zap.String("request_id", requestID),
+		zap.String("role", roleName),
+		zap.String("program", programContext))
+
+	if roleName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Role name is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	// If no program context provided, use generic context
+	if programContext == "" {
+		programContext = "General career path"
+	}
+
+	jobDetails, err := h.service.GetJobRoleDetails(ctx, roleName, programContext)
+	if errors.Is(err, llm.ErrGenerationLimit) {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"success":    false,
+			"error":      "Monthly generation quota of this API key reached; only cached job roles are available",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to fetch job role details",
+			zap.String("request_id", requestID),
+			zap.String("role", roleName),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to fetch job role details",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	roadmap, err := h.service.GetLearningRoadmapFast(ctx, programName)
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       jobDetails,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetRecentActivityRecommendations handles GET /api/v1/pathway/recommendations/recent-activity
+// Suggests programs and careers related to what the signed-in user viewed recently
+func (h *PathwayHandler) GetRecentActivityRecommendations(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	h.logger.Info("Fetching recent activity recommendations", zap.String("request_id", requestID))
+
+	recommendations, err := h.service.GetRecentActivityRecommendations(ctx, userID)
 	if err != nil {
-		h.logger.Error("Failed to generate fast learning roadmap",
+		h.logger.Error("Failed to build recent activity recommendations",
 			zap.String("request_id", requestID),
-			zap.String("program", programName),
 			zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success":    false,
-			"error":      "Failed to generate learning roadmap",
+			"error":      "Failed to fetch recommendations",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
@@ -512,236 +1860,318 @@ func (h *PathwayHandler) GetLearningRoadmapFast(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success":    true,
-		"data":       roadmap,
-		"program":    programName,
-		"mode":       "fast",
-		"note":       "Videos excluded for faster response. Use /videos/:stepNumber endpoint to fetch videos for specific steps.",
+		"data":       recommendations,
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
 	})
 }
 
-// GetVideosForStep handles GET /api/v1/pathway/programs/:name/steps/:stepNumber/videos
-// Fetches videos for a specific learning step on-demand
-func (h *PathwayHandler) GetVideosForStep(c *gin.Context) {
+// CompareCareers handles POST /api/v1/pathway/careers/compare
+// Returns a side-by-side matrix of salary, demand, education length and remote options
+func (h *PathwayHandler) CompareCareers(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
-	programName := c.Param("name")
-	stepNumberStr := c.Param("stepNumber")
 
-	h.logger.Info("Fetching videos for specific step",
-		zap.String("request_id", requestID),
-		zap.String("program", programName),
-		zap.String("step", stepNumberStr))
+	var request struct {
+		Careers []string `json:"careers" binding:"required,min=2,max=4"`
+	}
 
-	if programName == "" {
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("request_id", requestID),
+			zap.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Program name is required",
+			"success":    false,
+			"error":      "Invalid request: careers array with 2 to 4 titles is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	// Get topics from query params (comma-separated string or array)
-	topicsStr := c.Query("topics")
-	if topicsStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Topics query parameter is required (comma-separated string)",
-			"example": "/programs/Software%20Engineering/steps/1/videos?topics=Python,JavaScript,Git",
+	h.logger.Info("Comparing careers",
+		zap.String("request_id", requestID),
+		zap.Strings("careers", request.Careers))
+
+	comparison, err := h.service.CompareCareers(ctx, request.Careers)
+	if err != nil {
+		if errors.Is(err, pathway.ErrInvalidCareerComparison) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      err.Error(),
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		h.logger.Error("Failed to compare careers",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to compare careers",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	// Parse comma-separated topics
-	topics := strings.Split(topicsStr, ",")
-	cleanTopics := make([]string, 0)
-	for _, t := range topics {
-		trimmed := strings.TrimSpace(t)
-		if trimmed != "" {
-			cleanTopics = append(cleanTopics, trimmed)
-		}
+	for _, career := range comparison.Careers {
+		middleware.TrackEvent(c, mongodb.EventCareerTargeted, career.Title)
 	}
 
-	if len(cleanTopics) == 0 {
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       comparison,
+		"count":      len(comparison.Careers),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetOfflineBundle handles GET /api/v1/offline-bundle?department=...
+// Returns a gzip-compressed snapshot of a department for offline use in the mobile app.
+// Clients send the version they hold in If-None-Match and get 304 when it is still current.
+// An unknown department is 404 rather than an empty bundle.
+func (h *PathwayHandler) GetOfflineBundle(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	department := c.Query("department")
+
+	if department == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "At least one topic is required",
+			"success":    false,
+			"error":      "department query parameter is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	// Limit to 3 topics max for performance
-	if len(cleanTopics) > 3 {
-		cleanTopics = cleanTopics[:3]
-	}
-
-	// Fetch videos for topics with timeout
-	videoCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	h.logger.Info("Building offline bundle",
+		zap.String("request_id", requestID),
+		zap.String("department", department))
 
-	var allVideos []interface{}
-	for _, topic := range cleanTopics {
-		videos, err := h.youtubeService.SearchVideos(videoCtx, topic, 1)
-		if err != nil {
-			h.logger.Warn("Failed to fetch videos for topic",
-				zap.String("topic", topic),
+	bundle, err := h.service.GetOfflineBundle(ctx, department)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to build offline bundle"
+		if errors.Is(err, neo4j.ErrEntityNotFound) {
+			status = http.StatusNotFound
+			message = err.Error()
+		} else {
+			h.logger.Error("Failed to build offline bundle",
+				zap.String("request_id", requestID),
+				zap.String("department", department),
 				zap.Error(err))
-			continue
-		}
-
-		for _, v := range videos {
-			allVideos = append(allVideos, v)
 		}
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
 	}
 
-	h.logger.Info("Video fetching for step completed",
-		zap.String("request_id", requestID),
-		zap.Int("topics_count", len(cleanTopics)),
-		zap.Int("video_count", len(allVideos)))
-
-	c.JSON(http.StatusOK, gin.H{
-		"success":     true,
-		"data":        allVideos,
-		"topics":      cleanTopics,
-		"program":     programName,
-		"step_number": stepNumberStr,
-		"request_id":  requestID,
-		"timestamp":   time.Now().UTC(),
-	})
-}
-
-// Cache Management Endpoints
-
-// GetCacheStats handles GET /api/v1/pathway/cache/stats
-func (h *PathwayHandler) GetCacheStats(c *gin.Context) {
-	ctx := c.Request.Context()
-	requestID := c.GetString("request_id")
-
-	h.logger.Info("Fetching cache statistics", zap.String("request_id", requestID))
+	etag := fmt.Sprintf("%q", bundle.Version)
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
 
-	stats, err := h.service.GetCacheStats(ctx)
+	data, err := gzipJSON(bundle)
 	if err != nil {
-		h.logger.Error("Failed to fetch cache stats",
+		h.logger.Error("Failed to compress offline bundle",
 			zap.String("request_id", requestID),
 			zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success":    false,
-			"error":      "Failed to fetch cache statistics",
+			"error":      "Failed to build offline bundle",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success":    true,
-		"data":       stats,
-		"request_id": requestID,
-		"timestamp":  time.Now().UTC(),
-	})
+	filename := fmt.Sprintf("offline-bundle-%s.json.gz", slugify(department))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("X-Bundle-Version", bundle.Version)
+	c.Data(http.StatusOK, "application/gzip", data)
 }
 
-// InvalidateCache handles DELETE /api/v1/pathway/cache/:program
-func (h *PathwayHandler) InvalidateCache(c *gin.Context) {
+// gzipJSON encodes v as gzip-compressed JSON
+func gzipJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	var err error
+	if err = json.NewEncoder(gz).Encode(v); err == nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// slugify turns a name into a lowercase, dash-separated file name fragment
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// Search handles GET /api/v1/search?q=&limit=
+// Searches institutes, programs, careers, departments and qualifications at once,
+// returning results grouped by type with links to their details.
+func (h *PathwayHandler) Search(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
-	programName := c.Param("program")
 
-	if programName == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success":    false,
-			"error":      "Program name is required",
-			"request_id": requestID,
-			"timestamp":  time.Now().UTC(),
-		})
-		return
-	}
+	query := c.Query("q")
 
-	h.logger.Info("Invalidating cache",
+	h.logger.Info("Searching all entity types",
 		zap.String("request_id", requestID),
-		zap.String("program", programName))
+		zap.String("query", query))
 
-	if err := h.service.InvalidateCache(ctx, programName); err != nil {
-		h.logger.Error("Failed to invalidate cache",
+	results, err := h.service.Search(ctx, query, queryInt(c, "limit"))
+	if err != nil {
+		if errors.Is(err, pathway.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      err.Error(),
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		h.logger.Error("Failed to search",
 			zap.String("request_id", requestID),
-			zap.String("program", programName),
 			zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success":    false,
-			"error":      "Failed to invalidate cache",
+			"error":      "Failed to search",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
+	if results.Total == 0 {
+		middleware.TrackEvent(c, mongodb.EventSearchNoResults, query)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success":    true,
-		"message":    "Cache invalidated successfully",
-		"program":    programName,
+		"data":       results,
+		"count":      results.Total,
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
 	})
 }
 
-// RefreshCache handles POST /api/v1/pathway/cache/:program/refresh
-func (h *PathwayHandler) RefreshCache(c *gin.Context) {
+// SearchEntities handles GET /api/v1/pathway/search?q=&type=programs,careers&limit=
+// Fuzzy search forgiving misspellings, returning institutes, programs,
+// qualifications and careers (or only the given types) ranked together.
+func (h *PathwayHandler) SearchEntities(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
-	programName := c.Param("program")
 
-	if programName == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success":    false,
-			"error":      "Program name is required",
-			"request_id": requestID,
-			"timestamp":  time.Now().UTC(),
-		})
-		return
+	query := c.Query("q")
+	var types []string
+	for _, value := range splitQuery(c.Query("type")) {
+		kind, ok := entityKinds[value]
+		if !ok {
+			kind = value
+		}
+		types = append(types, kind)
 	}
 
-	h.logger.Info("Refreshing cache",
+	h.logger.Info("Fuzzy searching entities",
 		zap.String("request_id", requestID),
-		zap.String("program", programName))
+		zap.String("query", query),
+		zap.Strings("types", types))
 
-	if err := h.service.RefreshCache(ctx, programName); err != nil {
-		h.logger.Error("Failed to refresh cache",
+	results, err := h.service.SearchEntities(ctx, query, types, queryInt(c, "limit"))
+	if err != nil {
+		if errors.Is(err, pathway.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      err.Error(),
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		h.logger.Error("Failed to search entities",
 			zap.String("request_id", requestID),
-			zap.String("program", programName),
 			zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success":    false,
-			"error":      "Failed to refresh cache",
+			"error":      "Failed to search",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
+	if len(results) == 0 {
+		middleware.TrackEvent(c, mongodb.EventSearchNoResults, query)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success":    true,
-		"message":    "Cache refreshed successfully",
-		"program":    programName,
+		"data":       results,
+		"count":      len(results),
+		"query":      query,
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
 	})
 }
 
-// ClearAllCache handles DELETE /api/v1/pathway/cache (use with caution)
-func (h *PathwayHandler) ClearAllCache(c *gin.Context) {
+// GetSubgraph handles GET /api/v1/pathway/graph/subgraph?center=&depth=
+// Returns the entities around center (a node ID such as "program:Medicine", or
+// a name) as nodes and edges for D3 or Cytoscape. Node IDs can be passed back
+// as center to move through the map.
+func (h *PathwayHandler) GetSubgraph(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
+	center := c.Query("center")
 
-	h.logger.Warn("Clearing all cache", zap.String("request_id", requestID))
+	h.logger.Info("Fetching subgraph",
+		zap.String("request_id", requestID),
+		zap.String("center", center))
 
-	if err := h.service.ClearAllCache(ctx); err != nil {
-		h.logger.Error("Failed to clear cache",
-			zap.String("request_id", requestID),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+	subgraph, err := h.service.GetSubgraph(ctx, center, queryInt(c, "depth"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to fetch subgraph"
+		switch {
+		case errors.Is(err, neo4j.ErrEntityNotFound):
+			status = http.StatusNotFound
+			message = err.Error()
+		case errors.Is(err, pathway.ErrInvalidSubgraph), errors.Is(err, neo4j.ErrInvalidEntity):
+			status = http.StatusBadRequest
+			message = err.Error()
+		default:
+			h.logger.Error("Failed to fetch subgraph",
+				zap.String("request_id", requestID),
+				zap.String("center", center),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
 			"success":    false,
-			"error":      "Failed to clear cache",
+			"error":      message,
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
@@ -750,62 +2180,82 @@ func (h *PathwayHandler) ClearAllCache(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success":    true,
-		"message":    "All cache cleared successfully",
+		"data":       subgraph,
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
 	})
 }
 
-// GetJobRoleDetails handles GET /api/v1/pathway/job-roles/:roleName
-func (h *PathwayHandler) GetJobRoleDetails(c *gin.Context) {
+// ExportGraph handles GET /api/v1/admin/graph/export?department=&format=json|graphml
+// Streams the whole pathway graph, or the part of it around a department, as
+// nodes and edges with the same IDs as GetSubgraph, for frontends and analysts
+// to render without access to Neo4j. GraphML is sent as a download.
+func (h *PathwayHandler) ExportGraph(c *gin.Context) {
 	ctx := c.Request.Context()
 	requestID := c.GetString("request_id")
-	roleName := c.Param("roleName")
-	programContext := c.Query("program")
-
-	// URL decode the role name
-	roleName = strings.ReplaceAll(roleName, "%20", " ")
-	roleName = strings.ReplaceAll(roleName, "+", " ")
+	department := strings.TrimSpace(c.Query("department"))
+	format := c.DefaultQuery("format", "json")
 
-	h.logger.Info("Fetching job role details",
+	h.logger.Info("Exporting graph",
 		zap.String("request_id", requestID),
-		zap.String("role", roleName),
-		zap.String("program", programContext))
-
-	if roleName == "" {
+		zap.String("department", department),
+		zap.String("format", format))
+
+	var stream graphStream
+	switch format {
+	case "json":
+		stream = newJSONGraphStream(c)
+	case "graphml":
+		stream = newGraphMLStream(c, "pathway-graph.graphml")
+	default:
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success":    false,
-			"error":      "Role name is required",
+			"error":      "format must be json or graphml",
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
 		})
 		return
 	}
 
-	// If no program context provided, use generic context
-	if programContext == "" {
-		programContext = "General career path"
-	}
-
-	jobDetails, err := h.service.GetJobRoleDetails(ctx, roleName, programContext)
+	err := h.service.ExportGraph(ctx, department, stream.Node, stream.Edge)
 	if err != nil {
-		h.logger.Error("Failed to fetch job role details",
-			zap.String("request_id", requestID),
-			zap.String("role", roleName),
-			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		message := "Failed to export graph"
+		if errors.Is(err, neo4j.ErrEntityNotFound) {
+			status = http.StatusNotFound
+			message = err.Error()
+		} else {
+			h.logger.Error("Failed to export graph",
+				zap.String("request_id", requestID),
+				zap.String("department", department),
+				zap.Error(err))
+		}
+		fields := gin.H{
 			"success":    false,
-			"error":      "Failed to fetch job role details",
+			"error":      message,
 			"request_id": requestID,
 			"timestamp":  time.Now().UTC(),
-		})
+		}
+		if stream.Started() {
+			stream.Fail(fields)
+		} else {
+			c.JSON(status, fields)
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	_ = stream.Close(gin.H{
 		"success":    true,
-		"data":       jobDetails,
 		"request_id": requestID,
 		"timestamp":  time.Now().UTC(),
 	})
 }
+
+// historyUser is the user whose browsing history a view is recorded in, or empty
+// when they have not consented to profile data being stored
+func historyUser(c *gin.Context) string {
+	if !middleware.HasConsent(c) {
+		return ""
+	}
+	return c.GetString("user_id")
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+)
+
+func TestGzipJSON(t *testing.T) {
+	data, err := gzipJSON(map[string]string{"department": "Computing"})
+	if err != nil {
+		t.Fatalf("gzipJSON() error = %v", err)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("output is not gzip: %v", err)
+	}
+	var got map[string]string
+	if err := json.NewDecoder(gz).Decode(&got); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if got["department"] != "Computing" {
+		t.Errorf("department = %q, want %q", got["department"], "Computing")
+	}
+}
+
+func TestGzipJSONEncodeFailure(t *testing.T) {
+	// A channel cannot be encoded, so the error must reach the caller rather
+	// than an empty bundle being served
+	data, err := gzipJSON(map[string]any{"roadmap": make(chan int)})
+	if err == nil {
+		t.Fatal("gzipJSON() error = nil, want an encode error")
+	}
+	if data != nil {
+		t.Errorf("gzipJSON() data = %d bytes, want nil", len(data))
+	}
+}
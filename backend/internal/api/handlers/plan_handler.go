@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/plans"
+	"go.uber.org/zap"
+)
+
+// PlanHandler handles student plan and share link requests
+type PlanHandler struct {
+	service *plans.Service
+	logger  *zap.Logger
+}
+
+// NewPlanHandler creates a new plan handler
+func NewPlanHandler(service *plans.Service, logger *zap.Logger) *PlanHandler {
+	return &PlanHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// CreatePlan handles POST /api/v1/plans
+func (h *PlanHandler) CreatePlan(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	var request plans.CreatePlanRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Invalid request: program_name is required",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Creating student plan",
+		zap.String("request_id", requestID),
+		zap.String("program", request.ProgramName))
+
+	plan, err := h.service.CreatePlan(ctx, userID, request)
+	if err != nil {
+		h.logger.Error("Failed to create plan",
+			zap.String("request_id", requestID),
+			zap.String("program", request.ProgramName),
+			zap.Error(err))
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"success":    false,
+			"error":      "Failed to create plan",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success":    true,
+		"data":       plan,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// ListPlans handles GET /api/v1/plans
+func (h *PlanHandler) ListPlans(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	userPlans, err := h.service.ListPlans(ctx, userID)
+	if err != nil {
+		h.logger.Error("Failed to list plans",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      "Failed to list plans",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       userPlans,
+		"count":      len(userPlans),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetPlan handles GET /api/v1/plans/:id
+func (h *PlanHandler) GetPlan(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+	planID := c.Param("id")
+
+	plan, err := h.service.GetPlan(ctx, userID, planID)
+	if err != nil {
+		h.respondPlanError(c, err, "Failed to fetch plan")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       plan,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// CreateShareLink handles POST /api/v1/plans/:id/share-links
+func (h *PlanHandler) CreateShareLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+	planID := c.Param("id")
+
+	h.logger.Info("Creating share link",
+		zap.String("request_id", requestID),
+		zap.String("plan_id", planID))
+
+	link, err := h.service.CreateShareLink(ctx, userID, planID)
+	if err != nil {
+		h.respondPlanError(c, err, "Failed to create share link")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success":    true,
+		"data":       link,
+		"share_path": "/api/v1/shared/" + link.Token + "/guardian-summary",
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// RevokeShareLink handles DELETE /api/v1/plans/share-links/:token
+func (h *PlanHandler) RevokeShareLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+	token := c.Param("token")
+
+	if err := h.service.RevokeShareLink(ctx, userID, token); err != nil {
+		h.respondPlanError(c, err, "Failed to revoke share link")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Share link revoked successfully",
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetSchedule handles GET /api/v1/plans/:id/schedule
+// Spreads the plan's roadmap steps over weekly study sessions, shaped by the
+// start, days, time, minutes, reminder and tz query parameters
+func (h *PlanHandler) GetSchedule(c *gin.Context) {
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	schedule, ok := h.buildSchedule(c, userID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       schedule,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// ExportScheduleICal handles GET /api/v1/plans/:id/schedule/ical
+// The same schedule as an iCalendar file for any calendar app
+func (h *PlanHandler) ExportScheduleICal(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	schedule, ok := h.buildSchedule(c, userID)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="study-plan.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", schedule.ICalendar())
+}
+
+// buildSchedule builds the schedule requested by the query parameters,
+// responding with the error when it cannot
+func (h *PlanHandler) buildSchedule(c *gin.Context, userID string) (*plans.StudySchedule, bool) {
+	requestID := c.GetString("request_id")
+
+	var opts plans.ScheduleOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Invalid schedule options",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return nil, false
+	}
+
+	schedule, err := h.service.StudySchedule(c.Request.Context(), userID, c.Param("id"), opts)
+	if err != nil {
+		h.respondPlanError(c, err, "Failed to build study schedule")
+		return nil, false
+	}
+	return schedule, true
+}
+
+// GetGuardianSummary handles GET /api/v1/shared/:token/guardian-summary
+// Read-only view of a student's plan for parents; the share token is the permission
+func (h *PlanHandler) GetGuardianSummary(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	token := c.Param("token")
+
+	summary, err := h.service.GetGuardianSummary(ctx, token)
+	if err != nil {
+		h.respondPlanError(c, err, "Failed to build guardian summary")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       summary,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// respondPlanError maps plan service errors to HTTP responses
+func (h *PlanHandler) respondPlanError(c *gin.Context, err error, message string) {
+	requestID := c.GetString("request_id")
+
+	switch {
+	case errors.Is(err, plans.ErrPlanNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"success":    false,
+			"error":      "Plan not found",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+	case errors.Is(err, plans.ErrInvalidSchedule):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      err.Error(),
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+	case errors.Is(err, plans.ErrInvalidShareLink):
+		c.JSON(http.StatusNotFound, gin.H{
+			"success":    false,
+			"error":      "Share link is invalid or has expired",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+	default:
+		h.logger.Error(message,
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+	}
+}
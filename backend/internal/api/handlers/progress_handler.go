@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/progress"
+	"go.uber.org/zap"
+)
+
+// ProgressHandler handles learning roadmap progress requests
+type ProgressHandler struct {
+	service *progress.Service
+	logger  *zap.Logger
+}
+
+// NewProgressHandler creates a new progress handler
+func NewProgressHandler(service *progress.Service, logger *zap.Logger) *ProgressHandler {
+	return &ProgressHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListProgress handles GET /api/v1/progress
+func (h *ProgressHandler) ListProgress(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	progressList, err := h.service.List(ctx, userID)
+	if err != nil {
+		h.respondProgressError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       progressList,
+		"count":      len(progressList),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetProgress handles GET /api/v1/progress/:program
+func (h *ProgressHandler) GetProgress(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+
+	roadmapProgress, err := h.service.Get(ctx, userID, c.Param("program"))
+	if err != nil {
+		h.respondProgressError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       roadmapProgress,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// CompleteStep handles PUT /api/v1/progress/:program/steps/:step
+func (h *ProgressHandler) CompleteStep(c *gin.Context) {
+	h.setStep(c, true)
+}
+
+// UncompleteStep handles DELETE /api/v1/progress/:program/steps/:step
+func (h *ProgressHandler) UncompleteStep(c *gin.Context) {
+	h.setStep(c, false)
+}
+
+func (h *ProgressHandler) setStep(c *gin.Context, completed bool) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	userID := c.GetString("user_id")
+	program := c.Param("program")
+
+	step, err := strconv.Atoi(c.Param("step"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Step must be a number",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Updating roadmap progress",
+		zap.String("request_id", requestID),
+		zap.String("program", program),
+		zap.Int("step", step),
+		zap.Bool("completed", completed))
+
+	roadmapProgress, err := h.service.SetStep(ctx, userID, program, step, completed)
+	if err != nil {
+		h.respondProgressError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       roadmapProgress,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *ProgressHandler) respondProgressError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, progress.ErrProgressNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, progress.ErrRoadmapNotAvailable):
+		status = http.StatusConflict
+	case errors.Is(err, progress.ErrInvalidStep):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Progress operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Failed to update roadmap progress"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/promptreview"
+	"go.uber.org/zap"
+)
+
+// PromptReviewHandler handles browsing and labeling captured LLM exchanges
+type PromptReviewHandler struct {
+	service *promptreview.Service
+	logger  *zap.Logger
+}
+
+// NewPromptReviewHandler creates a new prompt review handler
+func NewPromptReviewHandler(service *promptreview.Service, logger *zap.Logger) *PromptReviewHandler {
+	return &PromptReviewHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListExchanges handles GET /api/v1/admin/llm-exchanges?task=...&label=...&unlabeled=true&before=...&limit=...
+// before is an RFC 3339 time, e.g. the created_at of the last exchange of the previous page
+func (h *PromptReviewHandler) ListExchanges(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	filter := mongodb.LLMExchangeFilter{
+		Task:      c.Query("task"),
+		Label:     c.Query("label"),
+		Unlabeled: c.Query("unlabeled") == "true",
+	}
+	if before := c.Query("before"); before != "" {
+		parsed, err := time.Parse(time.RFC3339, before)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      "before must be an RFC 3339 time",
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		filter.Before = parsed
+	}
+
+	exchanges, err := h.service.List(ctx, filter, queryInt(c, "limit"))
+	if err != nil {
+		h.respondPromptReviewError(c, requestID, err)
+		return
+	}
+
+	counts, err := h.service.Counts(ctx)
+	if err != nil {
+		h.respondPromptReviewError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       exchanges,
+		"count":      len(exchanges),
+		"totals":     counts,
+		"labels":     promptreview.Labels,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// GetExchange handles GET /api/v1/admin/llm-exchanges/:id
+func (h *PromptReviewHandler) GetExchange(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	exchange, err := h.service.Get(ctx, c.Param("id"))
+	if err != nil {
+		h.respondPromptReviewError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       exchange,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// LabelExchange handles PUT /api/v1/admin/llm-exchanges/:id/label
+// Body: {"label": "needs_work", "note": "Steps skip the A/L prerequisites"}
+func (h *PromptReviewHandler) LabelExchange(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	id := c.Param("id")
+
+	var request struct {
+		Label string `json:"label" binding:"required"`
+		Note  string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Request body must give a label",
+			"labels":     promptreview.Labels,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	h.logger.Info("Labeling LLM exchange",
+		zap.String("request_id", requestID),
+		zap.String("id", id),
+		zap.String("label", request.Label))
+
+	exchange, err := h.service.Label(ctx, id, request.Label, request.Note, reviewer(c))
+	if err != nil {
+		h.respondPromptReviewError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       exchange,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *PromptReviewHandler) respondPromptReviewError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, promptreview.ErrExchangeNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, promptreview.ErrInvalidLabel), errors.Is(err, promptreview.ErrInvalidFilter):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Prompt review operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Prompt review operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/review"
+	"go.uber.org/zap"
+)
+
+// ReviewHandler handles the admin approval queue
+type ReviewHandler struct {
+	service *review.Service
+	logger  *zap.Logger
+}
+
+// NewReviewHandler creates a new review handler
+func NewReviewHandler(service *review.Service, logger *zap.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListItems handles GET /api/v1/admin/review-queue?source=...&status=pending&limit=...
+func (h *ReviewHandler) ListItems(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	source := c.Query("source")
+	status := c.DefaultQuery("status", "pending")
+
+	h.logger.Info("Listing review queue",
+		zap.String("request_id", requestID),
+		zap.String("source", source),
+		zap.String("status", status))
+
+	items, err := h.service.List(ctx, source, status, queryInt(c, "limit"))
+	if err != nil {
+		h.respondReviewError(c, requestID, err)
+		return
+	}
+
+	pending, err := h.service.PendingCounts(ctx)
+	if err != nil {
+		h.respondReviewError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       items,
+		"count":      len(items),
+		"pending":    pending,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// ApproveItem handles POST /api/v1/admin/review-queue/:id/approve
+// The body is the resolution passed to the pipeline that produced the item,
+// e.g. {"program": "<existing program name>"}
+func (h *ReviewHandler) ApproveItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	id := c.Param("id")
+
+	resolution := map[string]interface{}{}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&resolution); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      "Invalid request: resolution must be a JSON object",
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+	}
+
+	h.logger.Info("Approving review item",
+		zap.String("request_id", requestID),
+		zap.String("id", id))
+
+	item, err := h.service.Approve(ctx, id, reviewer(c), resolution)
+	if err != nil {
+		h.respondReviewError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       item,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// RejectItem handles POST /api/v1/admin/review-queue/:id/reject
+func (h *ReviewHandler) RejectItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	id := c.Param("id")
+
+	var request struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      "Invalid request body",
+				"request_id": requestID,
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+	}
+
+	h.logger.Info("Rejecting review item",
+		zap.String("request_id", requestID),
+		zap.String("id", id))
+
+	item, err := h.service.Reject(ctx, id, reviewer(c), request.Reason)
+	if err != nil {
+		h.respondReviewError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       item,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *ReviewHandler) respondReviewError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, review.ErrItemNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, review.ErrAlreadyResolved):
+		status = http.StatusConflict
+	case errors.Is(err, review.ErrInvalidResolution):
+		status = http.StatusUnprocessableEntity
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Review queue operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Review queue operation failed"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// reviewer identifies who made an admin decision, defaulting to "admin"
+func reviewer(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+	return "admin"
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/seo"
+	"go.uber.org/zap"
+)
+
+// SEOHandler serves the sitemap, structured data and feed of the frontend's
+// public pages. All are served bare, as crawlers, feed readers and the frontend
+// consume them.
+type SEOHandler struct {
+	service *seo.Service
+	logger  *zap.Logger
+}
+
+// NewSEOHandler creates a new SEO handler
+func NewSEOHandler(service *seo.Service, logger *zap.Logger) *SEOHandler {
+	return &SEOHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetSitemap handles GET /sitemap.xml
+func (h *SEOHandler) GetSitemap(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	sitemap, err := h.service.Sitemap(c.Request.Context())
+	if err != nil {
+		h.respondSEOError(c, requestID, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", sitemap)
+}
+
+// GetNewProgramsFeed handles GET /api/v1/feed/new-programs.xml
+func (h *SEOHandler) GetNewProgramsFeed(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	feed, err := h.service.NewProgramsFeed(c.Request.Context())
+	if err != nil {
+		h.respondSEOError(c, requestID, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/atom+xml; charset=utf-8", feed)
+}
+
+// GetProgramStructuredData handles GET /api/v1/seo/programs/:name, the schema.org
+// JSON-LD the frontend embeds in the program's page
+func (h *SEOHandler) GetProgramStructuredData(c *gin.Context) {
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+
+	h.logger.Debug("Building program structured data",
+		zap.String("request_id", requestID),
+		zap.String("program", name))
+
+	program, err := h.service.ProgramStructuredData(c.Request.Context(), name)
+	if err != nil {
+		h.respondSEOError(c, requestID, err)
+		return
+	}
+
+	body, err := json.Marshal(program)
+	if err != nil {
+		h.respondSEOError(c, requestID, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/ld+json; charset=utf-8", body)
+}
+
+func (h *SEOHandler) respondSEOError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, seo.ErrSEODisabled):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, seo.ErrProgramNotFound):
+		status = http.StatusNotFound
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("SEO operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Failed to build page metadata"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/importer"
+	"github.com/mayura-andrew/fastfinder/internal/services/sheets"
+	"go.uber.org/zap"
+)
+
+// SheetsHandler handles Google Sheets mappings and syncs
+type SheetsHandler struct {
+	service *sheets.Service
+	logger  *zap.Logger
+}
+
+// NewSheetsHandler creates a new sheets handler
+func NewSheetsHandler(service *sheets.Service, logger *zap.Logger) *SheetsHandler {
+	return &SheetsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListMappings handles GET /api/v1/admin/sheets
+func (h *SheetsHandler) ListMappings(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Listing sheet mappings", zap.String("request_id", requestID))
+
+	mappings, err := h.service.ListMappings(ctx)
+	if err != nil {
+		h.respondSheetsError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       mappings,
+		"count":      len(mappings),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SaveMapping handles PUT /api/v1/admin/sheets/:name
+func (h *SheetsHandler) SaveMapping(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var mapping mongodb.SheetMapping
+	if err := c.ShouldBindJSON(&mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Invalid request body",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+	mapping.Name = c.Param("name")
+
+	h.logger.Info("Saving sheet mapping",
+		zap.String("request_id", requestID),
+		zap.String("name", mapping.Name))
+
+	if err := h.service.SaveMapping(ctx, &mapping); err != nil {
+		h.respondSheetsError(c, requestID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       mapping,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SyncSheet handles POST /api/v1/admin/sheets/:name/sync?dry_run=true&allow_duplicates=false
+func (h *SheetsHandler) SyncSheet(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	name := c.Param("name")
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	allowDuplicates, _ := strconv.ParseBool(c.DefaultQuery("allow_duplicates", "false"))
+
+	h.logger.Info("Syncing sheet",
+		zap.String("request_id", requestID),
+		zap.String("mapping", name),
+		zap.Bool("dry_run", dryRun),
+		zap.Bool("allow_duplicates", allowDuplicates))
+
+	report, err := h.service.Sync(ctx, name, dryRun, allowDuplicates)
+	if err != nil {
+		h.respondSheetsError(c, requestID, err)
+		return
+	}
+
+	status := http.StatusOK
+	if !dryRun && !report.Import.Applied {
+		status = http.StatusUnprocessableEntity
+	}
+
+	c.JSON(status, gin.H{
+		"success":    status == http.StatusOK,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *SheetsHandler) respondSheetsError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, sheets.ErrMappingNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, sheets.ErrInvalidMapping), errors.Is(err, importer.ErrEmptyImport),
+		errors.Is(err, importer.ErrTooManyRows):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, sheets.ErrSheetUnavailable):
+		status = http.StatusBadGateway
+	}
+
+	message := err.Error()
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error("Sheet operation failed",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Sheet operation failed"
+	case http.StatusBadGateway:
+		// Errors reaching Google describe the request made, which is no
+		// business of the client
+		h.logger.Warn("Sheet could not be read",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Sheet could not be read"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/core/sms"
+	"go.uber.org/zap"
+)
+
+// SMSHandler lets admins check outgoing text messages
+type SMSHandler struct {
+	sender *sms.Sender
+	logger *zap.Logger
+}
+
+// NewSMSHandler creates a new SMS handler
+func NewSMSHandler(sender *sms.Sender, logger *zap.Logger) *SMSHandler {
+	return &SMSHandler{
+		sender: sender,
+		logger: logger,
+	}
+}
+
+// GetOutbox handles GET /api/v1/admin/sms/outbox
+// Messages captured while sending is disabled or the sink provider is in use
+func (h *SMSHandler) GetOutbox(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	messages, ok := h.sender.Outbox()
+	if !ok {
+		c.JSON(http.StatusConflict, gin.H{
+			"success":    false,
+			"error":      "SMS is sent through " + h.sender.Provider() + "; there is no outbox",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       messages,
+		"count":      len(messages),
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/api/jsoncodec"
+	"github.com/mayura-andrew/fastfinder/internal/api/middleware"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+)
+
+// streamFlushEvery is how many array elements are written between flushes
+const streamFlushEvery = 50
+
+// streamTailReserve is the room kept under the response size limit for the
+// end of the envelope once elements stop being added
+const streamTailReserve = 4096
+
+// jsonArrayStream writes the usual response envelope with its "data" array
+// encoded one element at a time, so results read from a cursor reach the client
+// without being buffered first. Nothing is written until the first element, so
+// a failure before then can still be answered with an error status; after that
+// the status is committed and a failure is reported in the envelope instead.
+// Elements that would take the response past the route's size limit are left
+// out, and the envelope is marked "truncated".
+type jsonArrayStream struct {
+	c         *gin.Context
+	started   bool
+	truncated bool
+	count     int
+}
+
+func newJSONArrayStream(c *gin.Context) *jsonArrayStream {
+	return &jsonArrayStream{c: c}
+}
+
+// Started reports whether the response status and any elements have been sent
+func (s *jsonArrayStream) Started() bool {
+	return s.started
+}
+
+// Count returns the number of elements written so far
+func (s *jsonArrayStream) Count() int {
+	return s.count
+}
+
+// Write appends one element to the data array, or drops it once the array has
+// been truncated
+func (s *jsonArrayStream) Write(element any) error {
+	if s.truncated {
+		return nil
+	}
+	encoded, err := jsoncodec.Marshal(element)
+	if err != nil {
+		return err
+	}
+	if limit := middleware.ResponseLimit(s.c); limit > 0 {
+		if int64(max(s.c.Writer.Size(), 0)+len(encoded)+1)+streamTailReserve > limit {
+			s.truncated = true
+			return nil
+		}
+	}
+
+	if !s.started {
+		s.begin()
+	} else if _, err := s.c.Writer.WriteString(","); err != nil {
+		return err
+	}
+	if _, err := s.c.Writer.Write(encoded); err != nil {
+		return err
+	}
+
+	s.count++
+	if s.count%streamFlushEvery == 0 {
+		s.c.Writer.Flush()
+	}
+	return nil
+}
+
+// Close ends the data array and writes "count" followed by fields to complete
+// the envelope
+func (s *jsonArrayStream) Close(fields gin.H) error {
+	if !s.started {
+		s.begin()
+	}
+
+	encoded, err := jsoncodec.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	tail := `],"count":` + strconv.Itoa(s.count)
+	if s.truncated {
+		tail += `,"truncated":true`
+	}
+	if len(encoded) > 2 {
+		// Splice the fields object into the envelope without its braces
+		tail += "," + string(encoded[1:len(encoded)-1])
+	}
+	if _, err := s.c.Writer.WriteString(tail + "}"); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+func (s *jsonArrayStream) begin() {
+	s.started = true
+	s.c.Header("Content-Type", "application/json; charset=utf-8")
+	s.c.Status(http.StatusOK)
+	_, _ = s.c.Writer.WriteString(`{"data":[`)
+}
+
+// graphStream writes a graph as its nodes and then its edges are read, in one
+// of the export formats. As with jsonArrayStream, nothing is written until the
+// first node or edge, so a failure before then can still be answered with an
+// error status.
+type graphStream interface {
+	Node(node neo4j.GraphNode) error
+	Edge(edge neo4j.GraphEdge) error
+	Started() bool
+	// Close completes the document, adding fields where the format has room
+	Close(fields gin.H) error
+	// Fail ends a started document so that it reads as incomplete
+	Fail(fields gin.H)
+}
+
+// jsonGraphStream writes the usual response envelope with a "data" object of
+// "nodes" and "edges" arrays, as GET /pathway/graph/subgraph returns them
+type jsonGraphStream struct {
+	c       *gin.Context
+	started bool
+	inEdges bool
+	nodes   int
+	edges   int
+}
+
+func newJSONGraphStream(c *gin.Context) *jsonGraphStream {
+	return &jsonGraphStream{c: c}
+}
+
+func (s *jsonGraphStream) Started() bool {
+	return s.started
+}
+
+func (s *jsonGraphStream) Node(node neo4j.GraphNode) error {
+	if err := s.write(node, s.nodes == 0); err != nil {
+		return err
+	}
+	s.nodes++
+	return nil
+}
+
+func (s *jsonGraphStream) Edge(edge neo4j.GraphEdge) error {
+	s.startEdges()
+	if err := s.write(edge, s.edges == 0); err != nil {
+		return err
+	}
+	s.edges++
+	return nil
+}
+
+func (s *jsonGraphStream) Close(fields gin.H) error {
+	s.startEdges()
+
+	encoded, err := jsoncodec.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	tail := `]},"node_count":` + strconv.Itoa(s.nodes) + `,"edge_count":` + strconv.Itoa(s.edges)
+	if len(encoded) > 2 {
+		tail += "," + string(encoded[1:len(encoded)-1])
+	}
+	if _, err := s.c.Writer.WriteString(tail + "}"); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+// Fail completes the envelope with fields, which report the failure
+func (s *jsonGraphStream) Fail(fields gin.H) {
+	_ = s.Close(fields)
+}
+
+func (s *jsonGraphStream) write(element any, first bool) error {
+	encoded, err := jsoncodec.Marshal(element)
+	if err != nil {
+		return err
+	}
+	s.begin()
+	if !first {
+		if _, err := s.c.Writer.WriteString(","); err != nil {
+			return err
+		}
+	}
+	if _, err := s.c.Writer.Write(encoded); err != nil {
+		return err
+	}
+	if (s.nodes+s.edges+1)%streamFlushEvery == 0 {
+		s.c.Writer.Flush()
+	}
+	return nil
+}
+
+func (s *jsonGraphStream) begin() {
+	if s.started {
+		return
+	}
+	s.started = true
+	s.c.Header("Content-Type", "application/json; charset=utf-8")
+	s.c.Status(http.StatusOK)
+	_, _ = s.c.Writer.WriteString(`{"data":{"nodes":[`)
+}
+
+func (s *jsonGraphStream) startEdges() {
+	s.begin()
+	if !s.inEdges {
+		s.inEdges = true
+		_, _ = s.c.Writer.WriteString(`],"edges":[`)
+	}
+}
+
+// graphMLStream writes a GraphML document, as downloaded for graph tools such
+// as Gephi and yEd, with each node's label and group and each edge's type as
+// data. A failed export is left unterminated so that it does not parse as a
+// smaller graph.
+type graphMLStream struct {
+	c        *gin.Context
+	filename string
+	started  bool
+	count    int
+}
+
+func newGraphMLStream(c *gin.Context, filename string) *graphMLStream {
+	return &graphMLStream{c: c, filename: filename}
+}
+
+func (s *graphMLStream) Started() bool {
+	return s.started
+}
+
+func (s *graphMLStream) Node(node neo4j.GraphNode) error {
+	return s.write(`<node id="` + escapeXML(node.ID) + `"><data key="label">` + escapeXML(node.Label) +
+		`</data><data key="group">` + escapeXML(node.Group) + "</data></node>\n")
+}
+
+func (s *graphMLStream) Edge(edge neo4j.GraphEdge) error {
+	return s.write(`<edge id="` + escapeXML(edge.ID) + `" source="` + escapeXML(edge.Source) +
+		`" target="` + escapeXML(edge.Target) + `"><data key="type">` + escapeXML(edge.Type) + "</data></edge>\n")
+}
+
+// Close ends the document; GraphML has no room for the envelope fields
+func (s *graphMLStream) Close(gin.H) error {
+	s.begin()
+	if _, err := s.c.Writer.WriteString("</graph>\n</graphml>\n"); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+func (s *graphMLStream) Fail(gin.H) {
+	s.c.Writer.Flush()
+}
+
+func (s *graphMLStream) write(element string) error {
+	s.begin()
+	if _, err := s.c.Writer.WriteString(element); err != nil {
+		return err
+	}
+	s.count++
+	if s.count%streamFlushEvery == 0 {
+		s.c.Writer.Flush()
+	}
+	return nil
+}
+
+func (s *graphMLStream) begin() {
+	if s.started {
+		return
+	}
+	s.started = true
+	s.c.Header("Content-Type", "application/graphml+xml; charset=utf-8")
+	s.c.Header("Content-Disposition", `attachment; filename="`+s.filename+`"`)
+	s.c.Status(http.StatusOK)
+	_, _ = s.c.Writer.WriteString(xml.Header +
+		`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n" +
+		`<key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n" +
+		`<key id="group" for="node" attr.name="group" attr.type="string"/>` + "\n" +
+		`<key id="type" for="edge" attr.name="type" attr.type="string"/>` + "\n" +
+		`<graph id="pathways" edgedefault="directed">` + "\n")
+}
+
+// escapeXML escapes text for use in XML character data and attribute values
+func escapeXML(text string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
+
+// writeJSON writes a response body encoded with the configured JSON encoder, in
+// place of c.JSON on the hottest endpoints
+func writeJSON(c *gin.Context, status int, body any) {
+	encoded, err := jsoncodec.Marshal(body)
+	if err != nil {
+		_ = c.Error(err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", encoded)
+}
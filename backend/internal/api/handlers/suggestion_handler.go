@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/suggestions"
+	"go.uber.org/zap"
+)
+
+// SuggestionHandler handles crowd-sourced corrections to the graph
+type SuggestionHandler struct {
+	service *suggestions.Service
+	logger  *zap.Logger
+}
+
+// NewSuggestionHandler creates a new suggestion handler
+func NewSuggestionHandler(service *suggestions.Service, logger *zap.Logger) *SuggestionHandler {
+	return &SuggestionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// SubmitSuggestion handles POST /api/v1/pathway/suggestions
+// Suggestions are queued for moderation under the "community" source and applied
+// when approved through the admin review queue. Suggestions with flagged wording
+// are held under the "moderation" source until an admin releases them.
+func (h *SuggestionHandler) SubmitSuggestion(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	var suggestion suggestions.Suggestion
+	if err := c.ShouldBindJSON(&suggestion); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success":    false,
+			"error":      "Invalid request body",
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+	suggestion.SubmittedBy = c.GetString("user_id")
+
+	h.logger.Info("Submitting suggestion",
+		zap.String("request_id", requestID),
+		zap.String("entity", suggestion.Entity),
+		zap.String("name", suggestion.Name),
+		zap.String("field", suggestion.Field))
+
+	submission, err := h.service.Submit(ctx, suggestion)
+	if err != nil {
+		h.respondSuggestionError(c, requestID, err)
+		return
+	}
+
+	status := http.StatusAccepted
+	if !submission.Created {
+		status = http.StatusOK
+	}
+
+	c.JSON(status, gin.H{
+		"success":    true,
+		"data":       submission,
+		"message":    "Thanks! Your suggestion will be reviewed by a moderator.",
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+func (h *SuggestionHandler) respondSuggestionError(c *gin.Context, requestID string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, suggestions.ErrTargetNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, suggestions.ErrInvalidSuggestion):
+		status = http.StatusUnprocessableEntity
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error("Failed to submit suggestion",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		message = "Failed to submit suggestion"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    false,
+		"error":      message,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
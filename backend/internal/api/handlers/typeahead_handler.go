@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/typeahead"
+	"go.uber.org/zap"
+)
+
+// TypeaheadHandler handles search box suggestions
+type TypeaheadHandler struct {
+	service *typeahead.Service
+	logger  *zap.Logger
+}
+
+// NewTypeaheadHandler creates a new typeahead handler
+func NewTypeaheadHandler(service *typeahead.Service, logger *zap.Logger) *TypeaheadHandler {
+	return &TypeaheadHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Suggest handles GET /api/v1/pathway/suggest?q=&type=&limit=
+// type is one of institute, program or career (singular or plural) and may be
+// left out to match all three.
+func (h *TypeaheadHandler) Suggest(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	kind := strings.ToLower(strings.TrimSpace(c.Query("type")))
+	if singular, ok := entityKinds[kind]; ok {
+		kind = singular
+	}
+	limit := queryInt(c, "limit")
+
+	// Called on every keystroke, so only logged at debug level
+	h.logger.Debug("Suggesting names",
+		zap.String("request_id", requestID),
+		zap.String("query", c.Query("q")),
+		zap.String("type", kind))
+
+	matches, err := h.service.Suggest(c.Query("q"), kind, limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to suggest names"
+		if errors.Is(err, typeahead.ErrInvalidQuery) {
+			status = http.StatusBadRequest
+			message = err.Error()
+		} else {
+			h.logger.Error(message,
+				zap.String("request_id", requestID),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":      true,
+		"data":         matches,
+		"count":        len(matches),
+		"refreshed_at": h.service.RefreshedAt(),
+		"request_id":   requestID,
+		"timestamp":    time.Now().UTC(),
+	})
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/ussd"
+	"go.uber.org/zap"
+)
+
+// USSD gateways show screens starting with ussdContinue and wait for the next
+// choice; ussdEnd closes the session
+const (
+	ussdContinue = "CON "
+	ussdEnd      = "END "
+)
+
+// USSDHandler handles sessions relayed by USSD and IVR gateways
+type USSDHandler struct {
+	service *ussd.Service
+	logger  *zap.Logger
+}
+
+// NewUSSDHandler creates a new USSD handler
+func NewUSSDHandler(service *ussd.Service, logger *zap.Logger) *USSDHandler {
+	return &USSDHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Respond handles POST /api/v1/ussd, a gateway relaying a caller's input
+// Form: sessionId, serviceCode, phoneNumber, text (the choices so far joined by *)
+// The reply is plain text, as gateways show or read out the body as it is. They
+// also show any status but 200 as a generic network error, so failures are
+// explained in a closing screen instead.
+func (h *USSDHandler) Respond(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	screen, err := h.service.Respond(ctx, c.PostForm("text"))
+	if err != nil {
+		h.logger.Error("Failed to answer USSD session",
+			zap.String("request_id", requestID),
+			zap.String("session_id", c.PostForm("sessionId")),
+			zap.Error(err))
+		c.String(http.StatusOK, ussdEnd+"Sorry, the service is unavailable. Please try again later.")
+		return
+	}
+
+	c.String(http.StatusOK, ussdContinue+screen)
+}
+
+// RespondMatch handles POST /api/v1/ussd/match, a session asking the caller's
+// stream and interest and texting them matching programs
+// Form: sessionId, serviceCode, phoneNumber, text (the choices so far joined by *)
+// Only the configured gateway is admitted, and only Sri Lankan numbers are texted.
+func (h *USSDHandler) RespondMatch(c *gin.Context) {
+	screen, end := h.service.RespondMatch(ussd.Session{
+		ID:    c.PostForm("sessionId"),
+		Phone: c.PostForm("phoneNumber"),
+		Input: c.PostForm("text"),
+	})
+
+	if end {
+		c.String(http.StatusOK, ussdEnd+screen)
+		return
+	}
+	c.String(http.StatusOK, ussdContinue+screen)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/vacancies"
+	"go.uber.org/zap"
+)
+
+// VacancyHandler handles job vacancy requests
+type VacancyHandler struct {
+	service *vacancies.Service
+	logger  *zap.Logger
+}
+
+// NewVacancyHandler creates a new vacancy handler
+func NewVacancyHandler(service *vacancies.Service, logger *zap.Logger) *VacancyHandler {
+	return &VacancyHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetCareerVacancies handles GET /api/v1/pathway/careers/:title/vacancies?limit=10
+func (h *VacancyHandler) GetCareerVacancies(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+	careerTitle := c.Param("title")
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	h.logger.Info("Fetching career vacancies",
+		zap.String("request_id", requestID),
+		zap.String("career", careerTitle))
+
+	result, err := h.service.ForCareer(ctx, careerTitle, limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to fetch vacancies"
+		if errors.Is(err, vacancies.ErrCareerNotFound) {
+			status = http.StatusNotFound
+			message = err.Error()
+		} else {
+			h.logger.Error("Failed to fetch vacancies",
+				zap.String("request_id", requestID),
+				zap.String("career", careerTitle),
+				zap.Error(err))
+		}
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       result,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
+
+// SyncVacancies handles POST /api/v1/admin/ingest/vacancies
+// Runs the vacancy sync immediately instead of waiting for the schedule
+func (h *VacancyHandler) SyncVacancies(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Syncing vacancies", zap.String("request_id", requestID))
+
+	report, err := h.service.Sync(ctx)
+	if err != nil {
+		status := http.StatusBadGateway
+		message := "Failed to sync vacancies"
+		if errors.Is(err, vacancies.ErrNotConfigured) {
+			status = http.StatusServiceUnavailable
+			message = err.Error()
+		}
+		h.logger.Error("Failed to sync vacancies",
+			zap.String("request_id", requestID),
+			zap.Error(err))
+		c.JSON(status, gin.H{
+			"success":    false,
+			"error":      message,
+			"request_id": requestID,
+			"timestamp":  time.Now().UTC(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       report,
+		"request_id": requestID,
+		"timestamp":  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,83 @@
+// Package jsoncodec selects the JSON encoder used for the hottest API responses:
+// listings, learning roadmaps and streamed pathways. The standard library encoder
+// is the default; faster ones are chosen by name at startup.
+package jsoncodec
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// Std is the name of the standard library encoder
+const Std = "std"
+
+// Encoder marshals a response body
+type Encoder interface {
+	Marshal(v any) ([]byte, error)
+}
+
+// encoders holds the encoders built into this binary by name. Encoders behind
+// build tags add themselves when compiled in.
+var encoders = map[string]Encoder{
+	Std: stdEncoder{},
+}
+
+type selected struct {
+	name    string
+	encoder Encoder
+}
+
+var current atomic.Pointer[selected]
+
+func init() {
+	current.Store(&selected{name: Std, encoder: stdEncoder{}})
+}
+
+// Use selects the encoder used by Marshal. Call it once at startup.
+func Use(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = Std
+	}
+	encoder, ok := encoders[name]
+	if !ok {
+		return fmt.Errorf("unknown JSON encoder %q, available: %s", name, strings.Join(Names(), ", "))
+	}
+	current.Store(&selected{name: name, encoder: encoder})
+	return nil
+}
+
+// Name returns the name of the selected encoder
+func Name() string {
+	return current.Load().name
+}
+
+// Marshal encodes v with the selected encoder
+func Marshal(v any) ([]byte, error) {
+	return current.Load().encoder.Marshal(v)
+}
+
+// Names returns the names of the encoders built into this binary, sorted
+func Names() []string {
+	names := make([]string, 0, len(encoders))
+	for name := range encoders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Get returns the named encoder, for comparing encoders against each other
+func Get(name string) (Encoder, bool) {
+	encoder, ok := encoders[name]
+	return encoder, ok
+}
+
+type stdEncoder struct{}
+
+func (stdEncoder) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
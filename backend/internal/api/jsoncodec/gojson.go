@@ -0,0 +1,16 @@
+package jsoncodec
+
+import gojson "github.com/goccy/go-json"
+
+// GoJSON is the name of the goccy/go-json encoder
+const GoJSON = "go-json"
+
+type goJSONEncoder struct{}
+
+func (goJSONEncoder) Marshal(v any) ([]byte, error) {
+	return gojson.Marshal(v)
+}
+
+func init() {
+	encoders[GoJSON] = goJSONEncoder{}
+}
@@ -0,0 +1,11 @@
+package jsoncodec
+
+import jsoniter "github.com/json-iterator/go"
+
+// JSONIter is the name of the json-iterator encoder, configured to produce the
+// same output as the standard library
+const JSONIter = "jsoniter"
+
+func init() {
+	encoders[JSONIter] = jsoniter.ConfigCompatibleWithStandardLibrary
+}
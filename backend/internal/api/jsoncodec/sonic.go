@@ -0,0 +1,14 @@
+//go:build sonic && (linux || windows || darwin) && (amd64 || arm64)
+
+package jsoncodec
+
+import "github.com/bytedance/sonic"
+
+// Sonic is the name of the bytedance/sonic encoder. It generates code at
+// runtime, so it is only built in with the sonic build tag, on the platforms and
+// Go versions sonic supports; the same tag switches gin's own encoder to sonic.
+const Sonic = "sonic"
+
+func init() {
+	encoders[Sonic] = sonic.ConfigStd
+}
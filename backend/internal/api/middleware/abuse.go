@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/abuse"
+)
+
+// Honeypot blocks clients requesting a honeypot path from the expensive
+// endpoints, and answers them as if the path did not exist
+func Honeypot(service *abuse.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !service.IsHoneypot(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		service.Trap(abuseClient(c), c.Request.URL.Path)
+		c.AbortWithStatus(http.StatusNotFound)
+	}
+}
+
+// GuardAbuse counts requests to an endpoint that calls the LLM or scrapes
+// videos and turns away blocked clients with 429 until their block expires.
+// Partners calling with an API key are metered by their quota instead.
+func GuardAbuse(service *abuse.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("api_key_id") != "" {
+			c.Next()
+			return
+		}
+
+		block, ok := service.Admit(abuseClient(c))
+		if !ok {
+			c.Header("Retry-After", strconv.Itoa(int(time.Until(block.ExpiresAt).Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success":     false,
+				"error":       "Too many requests from this client; try again later",
+				"retry_after": block.ExpiresAt,
+				"request_id":  c.GetString("request_id"),
+				"timestamp":   time.Now().UTC(),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func abuseClient(c *gin.Context) abuse.Client {
+	return abuse.Client{
+		IP:        c.ClientIP(),
+		UserID:    c.GetString("user_id"),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/analytics"
+)
+
+// analyticsEventsKey is the gin context key holding events tracked during a request
+const analyticsEventsKey = "analytics_events"
+
+// TrackEvent marks an anonymized analytics event for the current request. The event
+// is only recorded if the request succeeds.
+func TrackEvent(c *gin.Context, eventType, value string) {
+	events, _ := c.Get(analyticsEventsKey)
+	tracked, _ := events.([]mongodb.AnalyticsEvent)
+	c.Set(analyticsEventsKey, append(tracked, mongodb.AnalyticsEvent{
+		EventType: eventType,
+		Value:     value,
+	}))
+}
+
+// Analytics records the events tracked by handlers once the request completes.
+// Only the event values and the caller's self-reported district (X-District header
+// or district query parameter) are stored - never user IDs, IPs or user agents.
+func Analytics(service *analytics.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if service == nil || c.Writer.Status() >= 400 {
+			return
+		}
+
+		events, exists := c.Get(analyticsEventsKey)
+		if !exists {
+			return
+		}
+		tracked, _ := events.([]mongodb.AnalyticsEvent)
+		if len(tracked) == 0 {
+			return
+		}
+
+		district := c.GetHeader("X-District")
+		if district == "" {
+			district = c.Query("district")
+		}
+		for i := range tracked {
+			tracked[i].District = district
+		}
+
+		service.Record(tracked)
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"github.com/mayura-andrew/fastfinder/internal/services/apikeys"
+)
+
+// MeterAPIKey identifies partners by their X-API-Key header, turns away requests
+// over the key's monthly quota with 429, and counts each request, the LLM
+// generations it triggers and the bytes it transfers. Requests without a key are
+// not metered, nor are any while key lookups fail or the service is nil (demo
+// mode), so metering never takes the API down.
+func MeterAPIKey(service *apikeys.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := strings.TrimSpace(c.GetHeader("X-API-Key"))
+		if secret == "" || service == nil {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		key, err := service.Authenticate(ctx, secret)
+		if errors.Is(err, apikeys.ErrInvalidKey) || errors.Is(err, apikeys.ErrKeyRevoked) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success":    false,
+				"error":      err.Error(),
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		if err := service.Admit(ctx, key); err != nil {
+			reset := apikeys.NextMonth(time.Now())
+			c.Header("Retry-After", strconv.Itoa(int(time.Until(reset).Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success":    false,
+				"error":      err.Error(),
+				"resets_at":  reset,
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+
+		c.Set("api_key_id", key.ID)
+		c.Request = c.Request.WithContext(llm.WithGenerationMeter(ctx, service.GenerationMeter(key)))
+
+		c.Next()
+
+		service.RecordBytes(key, max(c.Request.ContentLength, 0)+int64(max(c.Writer.Size(), 0)))
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublicCache lets browsers and CDNs keep successful responses of public reads
+// for maxAge. Error responses are marked no-store so outages are not cached.
+//
+// Conditional requests are not answered with 304 Not Modified: responses mix
+// graph data with intakes, outcomes and demand indexes kept in MongoDB, and no
+// instance knows when all of them last changed, so a 304 could keep stale data
+// in clients indefinitely. maxAge alone bounds how stale a kept response is.
+func PublicCache(maxAge time.Duration) gin.HandlerFunc {
+	cacheControl := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if maxAge <= 0 || (method != http.MethodGet && method != http.MethodHead) {
+			c.Next()
+			return
+		}
+
+		c.Writer = &cacheHeaderWriter{
+			ResponseWriter: c.Writer,
+			cacheControl:   cacheControl,
+		}
+		c.Next()
+	}
+}
+
+// cacheHeaderWriter sets cache headers once the response status is known
+type cacheHeaderWriter struct {
+	gin.ResponseWriter
+	cacheControl string
+}
+
+func (w *cacheHeaderWriter) WriteHeader(code int) {
+	// Handlers may opt a response out, e.g. one served from stale data
+	if code == http.StatusOK && w.Header().Get("Cache-Control") != "" {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if code == http.StatusOK {
+		w.Header().Set("Cache-Control", w.cacheControl)
+	} else {
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
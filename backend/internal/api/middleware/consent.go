@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/consent"
+	"go.uber.org/zap"
+)
+
+const profileConsentKey = "profile_consent"
+
+// Consent looks up whether profile data of the signed-in user may be stored, for
+// RequireConsent and HasConsent. Without a consent service (demo mode) nothing is
+// kept beyond the process, so consent is not asked for. When the lookup fails the
+// answer stays unknown.
+func Consent(service *consent.Service, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("user_id")
+		if userID == "" {
+			c.Next()
+			return
+		}
+		if service == nil {
+			c.Set(profileConsentKey, true)
+			c.Next()
+			return
+		}
+
+		allowed, err := service.Allowed(c.Request.Context(), userID)
+		if err != nil {
+			logger.Warn("Failed to look up consent",
+				zap.String("request_id", c.GetString("request_id")),
+				zap.Error(err))
+		} else {
+			c.Set(profileConsentKey, allowed)
+		}
+
+		c.Next()
+	}
+}
+
+// HasConsent reports whether profile data of the signed-in user may be stored;
+// false when it is unknown
+func HasConsent(c *gin.Context) bool {
+	return c.GetBool(profileConsentKey)
+}
+
+// RequireConsent rejects requests that would store profile data for a user who
+// has not accepted the current privacy policy, or whose guardian has not consented
+// for a minor. Reads and deletions always pass, so users can see and remove what
+// was stored before.
+func RequireConsent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodDelete:
+			c.Next()
+			return
+		}
+
+		allowed, known := c.Get(profileConsentKey)
+		if !known {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"success":    false,
+				"error":      "Consent could not be checked, try again later",
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+		if allowed != true {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"success":    false,
+				"error":      "Accept the current privacy policy before saving profile data",
+				"code":       "consent_required",
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/drafts"
+)
+
+// DraftProfile verifies the draft profile sent in the X-Draft-Profile header,
+// so handlers can fill in what the request leaves out with drafts.FromContext.
+// Drafts that were not issued here, or have expired, are rejected rather than
+// ignored, so the wizard can start again instead of answering a different
+// question.
+func DraftProfile(service *drafts.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(drafts.Header)
+		if token == "" {
+			c.Next()
+			return
+		}
+
+		profile, err := service.Verify(c.Request.Context(), token)
+		if err != nil {
+			message := "Draft profile is invalid"
+			if errors.Is(err, drafts.ErrDraftExpired) {
+				message = "Draft profile has expired"
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"success":    false,
+				"error":      message,
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+
+		c.Request = c.Request.WithContext(drafts.WithProfile(c.Request.Context(), profile))
+		c.Next()
+	}
+}
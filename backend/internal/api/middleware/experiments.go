@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/core/experiment"
+	"github.com/mayura-andrew/fastfinder/internal/services/experiments"
+)
+
+// Experiments assigns the caller to a variant of every running experiment: by
+// user, so signed-in users see the same variant on every request, and otherwise
+// per request. Each variant a response is shaped by is counted and named in an
+// X-Experiment header ("search-ranking=word-match"), which clients send back with
+// their feedback. Without a service (demo mode) no experiments run.
+func Experiments(service *experiments.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if service == nil || len(service.Running()) == 0 {
+			c.Next()
+			return
+		}
+
+		unit := c.GetString("user_id")
+		if unit == "" {
+			unit = c.GetString("request_id")
+		}
+
+		expose := func(name, variant string) {
+			service.Expose(name, variant)
+			c.Writer.Header().Add("X-Experiment", name+"="+variant)
+		}
+		variants := service.Assign(unit)
+		c.Set("experiments", variants)
+		c.Request = c.Request.WithContext(experiment.WithAssignments(c.Request.Context(), variants, expose))
+
+		c.Next()
+	}
+}
+
+// ExperimentVariants returns the variants the caller is assigned to, by
+// experiment name
+func ExperimentVariants(c *gin.Context) map[string]string {
+	variants, _ := c.Get("experiments")
+	assigned, _ := variants.(map[string]string)
+	return assigned
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireGateway admits only the USSD gateway: requests must carry secret in
+// the X-Gateway-Key header when one is set, and come from one of addresses
+// (IPs or CIDRs) when any are listed. With neither configured the routes are
+// disabled, as anyone could otherwise have callers texted at our expense.
+func RequireGateway(secret string, addresses []string) gin.HandlerFunc {
+	var allowed []netip.Prefix
+	for _, address := range addresses {
+		if prefix, err := netip.ParsePrefix(address); err == nil {
+			allowed = append(allowed, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(address); err == nil {
+			allowed = append(allowed, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+
+	return func(c *gin.Context) {
+		if secret == "" && len(allowed) == 0 {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"success":    false,
+				"error":      "USSD gateway is not configured",
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+
+		admitted := secret == "" || subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Gateway-Key")), []byte(secret)) == 1
+		if admitted && len(allowed) > 0 {
+			admitted = false
+			if ip, err := netip.ParseAddr(c.ClientIP()); err == nil {
+				ip = ip.Unmap()
+				for _, prefix := range allowed {
+					if prefix.Contains(ip) {
+						admitted = true
+						break
+					}
+				}
+			}
+		}
+		if !admitted {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"success":    false,
+				"error":      "Not a recognized USSD gateway",
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// responseLimitKey holds the response size limit of the route being served
+const responseLimitKey = "response_limit"
+
+// errResponseTooLarge is returned for writes past the response size limit
+var errResponseTooLarge = errors.New("response exceeds the size limit")
+
+// SizeLimit caps the request and response bodies of a route, in bytes. A zero
+// limit leaves that body unlimited.
+type SizeLimit struct {
+	Request  int64
+	Response int64
+}
+
+// SizeLimits enforces the size limit of each route, keyed by method and route
+// pattern (e.g. "POST /api/v1/pathway/discover"), or defaults for routes not
+// listed. Requests declaring a larger body are answered 413 before the handler
+// runs; bodies that turn out larger fail to read. A response too large to send
+// is replaced with a 500 error when nothing has been written yet, and cut short
+// otherwise; streamed listings check ResponseLimit to stop in time instead.
+func SizeLimits(defaults SizeLimit, routes map[string]SizeLimit, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, ok := routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			limit = defaults
+		}
+
+		if limit.Request > 0 && c.Request.Body != nil {
+			if c.Request.ContentLength > limit.Request {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+					"success":    false,
+					"error":      "Request body is too large",
+					"max_bytes":  limit.Request,
+					"request_id": c.GetString("request_id"),
+					"timestamp":  time.Now().UTC(),
+				})
+				return
+			}
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit.Request)
+		}
+
+		if limit.Response <= 0 {
+			c.Next()
+			return
+		}
+
+		writer := &limitedWriter{ResponseWriter: c.Writer, c: c, limit: limit.Response}
+		c.Writer = writer
+		c.Set(responseLimitKey, limit.Response)
+		c.Next()
+
+		if writer.exceeded {
+			logger.Warn("Response exceeded the size limit",
+				zap.String("request_id", c.GetString("request_id")),
+				zap.String("route", c.FullPath()),
+				zap.Int64("limit", limit.Response))
+		}
+	}
+}
+
+// ResponseLimit returns the response size limit of the route being served, or
+// zero when responses are unlimited
+func ResponseLimit(c *gin.Context) int64 {
+	return c.GetInt64(responseLimitKey)
+}
+
+// limitedWriter refuses writes past the response size limit
+type limitedWriter struct {
+	gin.ResponseWriter
+	c        *gin.Context
+	limit    int64
+	exceeded bool
+}
+
+func (w *limitedWriter) Write(data []byte) (int, error) {
+	if w.exceeded {
+		return 0, errResponseTooLarge
+	}
+	if int64(max(w.Size(), 0)+len(data)) <= w.limit {
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.exceeded = true
+	if w.Written() {
+		return 0, errResponseTooLarge
+	}
+
+	// Nothing has been sent, so the whole response can still be replaced. The
+	// write is reported as done so the handler does not fail in turn.
+	body, _ := json.Marshal(gin.H{
+		"success":    false,
+		"error":      "Response is too large; request fewer results with limit and offset",
+		"request_id": w.c.GetString("request_id"),
+		"timestamp":  time.Now().UTC(),
+	})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.ResponseWriter.WriteHeader(http.StatusInternalServerError)
+	if _, err := w.ResponseWriter.Write(body); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (w *limitedWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
@@ -2,14 +2,17 @@ package middleware
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"math/rand"
 	"net/http"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/mayura-andrew/fastfinder/internal/services/identity"
 	"go.uber.org/zap"
 )
 
@@ -61,6 +64,93 @@ func RequestLogger(logger *zap.Logger) gin.HandlerFunc {
 	})
 }
 
+// UserIdentity verifies the user token sent as "Authorization: Bearer" or in
+// the identity cookie and stores its user ID in the request context as
+// "user_id". Callers without a valid token are anonymous; user IDs sent
+// unsigned, such as in an X-User-ID header, are ignored.
+func UserIdentity(service *identity.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := ""
+		if scheme, bearer, ok := strings.Cut(c.GetHeader("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+			token = strings.TrimSpace(bearer)
+		} else if cookie, err := c.Cookie(identity.CookieName); err == nil {
+			token = cookie
+		}
+
+		if token != "" {
+			if userID, err := service.Verify(c.Request.Context(), token); err == nil {
+				c.Set("user_id", userID)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RequireUser rejects requests that do not carry a user identity
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("user_id") == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success":    false,
+				"error":      "Authentication required",
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireAdmin rejects requests without a valid X-Admin-Key header. When no admin
+// key is configured the admin API is disabled entirely.
+func RequireAdmin(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiKey == "" {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"success":    false,
+				"error":      "Admin API is disabled",
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+
+		provided := c.GetHeader("X-Admin-Key")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(apiKey)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success":    false,
+				"error":      "Invalid admin key",
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// UnavailableInDemo rejects requests to features that need MongoDB or write to
+// the graph when the server runs in demo mode, whose services for them are nil
+func UnavailableInDemo(demo bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if demo {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"success":    false,
+				"error":      "Not available in demo mode",
+				"request_id": c.GetString("request_id"),
+				"timestamp":  time.Now().UTC(),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
 func Timeout(duration time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx, cancel := context.WithTimeout(c.Request.Context(), duration)
@@ -136,7 +226,8 @@ func CORS() gin.HandlerFunc {
 		}
 
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID, X-District, X-Admin-Key, X-API-Key, X-Draft-Profile")
+		c.Header("Access-Control-Expose-Headers", "X-Experiment")
 		c.Header("Access-Control-Allow-Credentials", "true")
 		c.Header("Access-Control-Max-Age", "86400")
 
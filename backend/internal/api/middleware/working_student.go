@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+)
+
+// WorkingStudent turns on working student mode for requests with
+// ?working_student=true: pathway reads then only return programs, and paths
+// made of programs, that can be completed while employed
+func WorkingStudent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if on, _ := strconv.ParseBool(c.Query("working_student")); on {
+			c.Request = c.Request.WithContext(pathway.WithWorkingStudent(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
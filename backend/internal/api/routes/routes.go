@@ -1,8 +1,6 @@
 package routes
 
 import (
-	"time"
-
 	"github.com/gin-gonic/gin"
 	"github.com/mayura-andrew/fastfinder/internal/api/handlers"
 	"github.com/mayura-andrew/fastfinder/internal/api/middleware"
@@ -23,134 +21,536 @@ func SetupRoutes(
 	}
 
 	router := gin.New()
+	if len(cfg.Server.TrustedProxies) > 0 {
+		if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+			logger.Warn("Invalid trusted proxies, trusting none", zap.Error(err))
+			_ = router.SetTrustedProxies(nil)
+		}
+	}
 
 	// Global middleware
 	router.Use(middleware.RequestID())
 	router.Use(middleware.RequestLogger(logger))
 	router.Use(middleware.Recovery(logger))
+	router.Use(middleware.Honeypot(cont.AbuseService()))
+	sizeLimits := routeSizeLimits(cfg)
+	router.Use(middleware.SizeLimits(middleware.SizeLimit{
+		Request:  cfg.Server.MaxBodySize,
+		Response: cfg.Server.MaxResponseSize,
+	}, sizeLimits, logger))
 	router.Use(middleware.CORS())
 	router.Use(middleware.SecurityHeaders())
+	router.Use(middleware.UserIdentity(cont.IdentityService()))
+	router.Use(middleware.MeterAPIKey(cont.APIKeyService()))
+	router.Use(middleware.Consent(cont.ConsentService(), logger))
+	router.Use(middleware.Experiments(cont.ExperimentService()))
+	router.Use(middleware.Analytics(cont.AnalyticsService()))
 
 	// Initialize handlers
 	handler := handlers.NewHandler(cont, logger)
 	pathwayHandler := handlers.NewPathwayHandler(cont.PathwayService(), cont.YouTubeService(), logger)
+	planHandler := handlers.NewPlanHandler(cont.PlanService(), logger)
+	analyticsHandler := handlers.NewAnalyticsHandler(cont.AnalyticsService(), logger)
+	adminHandler := handlers.NewAdminHandler(cont.AdminService(), logger)
+	importHandler := handlers.NewImportHandler(cont.ImportService(), logger)
+	reviewHandler := handlers.NewReviewHandler(cont.ReviewService(), logger)
+	ingestionHandler := handlers.NewIngestionHandler(cont.IngestionService(), logger)
+	backupHandler := handlers.NewBackupHandler(cont.BackupService(), logger)
+	sheetsHandler := handlers.NewSheetsHandler(cont.SheetsService(), logger)
+	suggestionHandler := handlers.NewSuggestionHandler(cont.SuggestionService(), logger)
+	discoveryHandler := handlers.NewDiscoveryHandler(cont.DiscoveryService(), logger)
+	typeaheadHandler := handlers.NewTypeaheadHandler(cont.TypeaheadService(), logger)
+	vacancyHandler := handlers.NewVacancyHandler(cont.VacancyService(), logger)
+	changelogHandler := handlers.NewChangelogHandler(cont.ChangelogService(), logger)
+	moodleHandler := handlers.NewMoodleHandler(cont.MoodleService(), logger)
+	progressHandler := handlers.NewProgressHandler(cont.ProgressService(), logger)
+	badgeHandler := handlers.NewBadgeHandler(cont.BadgeService(), logger)
+	mailHandler := handlers.NewMailHandler(cont.Mailer(), logger)
+	smsHandler := handlers.NewSMSHandler(cont.SMSSender(), logger)
+	calendarHandler := handlers.NewCalendarHandler(cont.CalendarService(), cfg.Calendar.ReturnURL, logger)
+	seoHandler := handlers.NewSEOHandler(cont.SEOService(), logger)
+	apiKeyHandler := handlers.NewAPIKeyHandler(cont.APIKeyService(), logger)
+	consentHandler := handlers.NewConsentHandler(cont.ConsentService(), cfg.Consent.PolicyID, logger)
+	experimentHandler := handlers.NewExperimentHandler(cont.ExperimentService(), logger)
+	eventHandler := handlers.NewEventHandler(cont.EventService(), logger)
+	outcomeHandler := handlers.NewOutcomeHandler(cont.OutcomeService(), logger)
+	demandHandler := handlers.NewDemandHandler(cont.DemandService(), logger)
+	cohortHandler := handlers.NewCohortHandler(cont.CohortService(), logger)
+	handoutHandler := handlers.NewHandoutHandler(cont.HandoutService(), logger)
+	ussdHandler := handlers.NewUSSDHandler(cont.USSDService(), logger)
+	abuseHandler := handlers.NewAbuseHandler(cont.AbuseService(), logger)
+	promptReviewHandler := handlers.NewPromptReviewHandler(cont.PromptReviewService(), logger)
+	draftHandler := handlers.NewDraftHandler(cont.DraftService(), logger)
+	diagnosticsHandler := handlers.NewDiagnosticsHandler(cont.DiagnosticsService(), logger)
+
+	// Public reads may be cached by browsers and CDNs for a short while
+	listingCache := middleware.PublicCache(cfg.Server.PublicCacheMaxAge)
+	roadmapCache := middleware.PublicCache(cfg.Server.RoadmapCacheMaxAge)
+
+	// Features backed by MongoDB or graph writes are off in demo mode
+	needsDatabase := middleware.UnavailableInDemo(cfg.Server.Demo)
+
+	// Profile data is only stored for users who accepted the current privacy policy
+	needsConsent := middleware.RequireConsent()
+
+	// Endpoints that call the LLM or scrape videos cost money per request, so
+	// scripted clients hammering them are blocked for a while
+	expensive := middleware.GuardAbuse(cont.AbuseService())
+
+	// Eligibility and recommendation requests may be completed by a draft
+	// profile the client holds, so wizards work without an account
+	draftProfile := middleware.DraftProfile(cont.DraftService())
 
 	// Health checks (no timeout)
 	router.GET("/health", handler.HealthCheck)
 	router.GET("/api/v1/health", handler.HealthCheck)
 	router.GET("/api/v1/health-detailed", handler.HealthCheck)
+	router.GET("/ready", handler.Readiness)
+
+	// Sitemap of the frontend's public program and career pages
+	router.GET("/sitemap.xml", listingCache, seoHandler.GetSitemap)
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
-		// Pathway endpoints
-		pathway := v1.Group("/pathway")
+		// Pathway endpoints; ?working_student=true keeps only pathways that can be
+		// completed while employed, and ?district= on program listings and career
+		// pathways those offered by institutes in a district
+		pathway := v1.Group("/pathway", middleware.WorkingStudent())
 		{
 			// Get all institutes
-			pathway.GET("/institutes", pathwayHandler.GetInstitutes)
+			pathway.GET("/institutes", listingCache, pathwayHandler.GetInstitutes)
 
 			// Get programs by institute
-			pathway.GET("/institutes/:name/programs", pathwayHandler.GetProgramsByInstitute)
+			pathway.GET("/institutes/:name/programs", listingCache, pathwayHandler.GetProgramsByInstitute)
+
+			// Open days, aptitude tests and career fairs of an institute
+			pathway.GET("/institutes/:name/events", needsDatabase, eventHandler.GetInstituteEvents)
+
+			// Programs offered by institutes in a district
+			pathway.GET("/districts/:name/programs", listingCache, pathwayHandler.GetProgramsByDistrict)
 
 			// Get complete pathway by department
-			pathway.GET("/departments/:name/complete", pathwayHandler.GetCompletePathway)
+			pathway.GET("/departments/:name/complete", listingCache, pathwayHandler.GetCompletePathway)
 
 			// Get pathway by qualification (NEW)
-			pathway.GET("/departments/:name/by-qualification", pathwayHandler.GetPathwayByQualification)
+			pathway.GET("/departments/:name/by-qualification", listingCache, pathwayHandler.GetPathwayByQualification)
 
 			// Get program details
-			pathway.GET("/programs/:name", pathwayHandler.GetProgramDetails)
+			pathway.GET("/programs/:name", listingCache, pathwayHandler.GetProgramDetails)
+
+			// Program details and cached roadmap in one request (for mobile)
+			pathway.GET("/programs/:name/overview", pathwayHandler.GetProgramOverview)
 
 			// Get learning roadmap for a program (with videos - slower 15-30s)
-			pathway.GET("/programs/:name/learning-roadmap", pathwayHandler.GetLearningRoadmap)
+			pathway.GET("/programs/:name/learning-roadmap", expensive, roadmapCache, pathwayHandler.GetLearningRoadmap)
 
 			// Get CACHED learning roadmap ONLY (no LLM call - instant if cached)
-			pathway.GET("/programs/:name/learning-roadmap/cached", pathwayHandler.GetCachedLearningRoadmap)
+			pathway.GET("/programs/:name/learning-roadmap/cached", roadmapCache, pathwayHandler.GetCachedLearningRoadmap)
+
+			// Every stored version of a roadmap, one version, and what changed between two
+			pathway.GET("/programs/:name/learning-roadmap/versions", needsDatabase, pathwayHandler.ListRoadmapVersions)
+			pathway.GET("/programs/:name/learning-roadmap/versions/:version", needsDatabase, roadmapCache, pathwayHandler.GetRoadmapVersion)
+			pathway.GET("/programs/:name/learning-roadmap/diff", needsDatabase, pathwayHandler.DiffRoadmapVersions)
 
 			// Get learning roadmap FAST (without videos - ultra fast 2-3s)
-			pathway.GET("/programs/:name/learning-roadmap-fast", pathwayHandler.GetLearningRoadmapFast)
+			pathway.GET("/programs/:name/learning-roadmap-fast", expensive, pathwayHandler.GetLearningRoadmapFast)
 
 			// Get videos for a specific step on-demand
-			pathway.GET("/programs/:name/steps/:stepNumber/videos", pathwayHandler.GetVideosForStep)
+			pathway.GET("/programs/:name/steps/:stepNumber/videos", expensive, pathwayHandler.GetVideosForStep)
+
+			// Z-score cutoff history and trend of a program, optionally for one district
+			pathway.GET("/programs/:name/zscore-cutoffs", pathwayHandler.GetZScoreCutoffs)
+
+			// Scholarships funding a program or its institute
+			pathway.GET("/programs/:name/scholarships", pathwayHandler.GetProgramScholarships)
+
+			// Readiness of a student profile for a program, with preparation per subject
+			pathway.POST("/programs/:name/readiness", expensive, draftProfile, pathwayHandler.GetReadinessAssessment)
+
+			// Graduate outcomes of a program, and graduates reporting their own
+			pathway.GET("/programs/:name/outcomes", needsDatabase, outcomeHandler.GetProgramOutcomes)
+			pathway.POST("/programs/:name/outcomes/reports", needsDatabase, middleware.RequireUser(), needsConsent, outcomeHandler.ReportOutcome)
+
+			// One-page printable summary of a program, as PDF or PNG
+			pathway.GET("/programs/:name/handout", handoutHandler.GetHandout)
 
 			// Cache management endpoints
 			cache := pathway.Group("/cache")
 			{
 				cache.GET("/stats", pathwayHandler.GetCacheStats)
 				cache.DELETE("/:program", pathwayHandler.InvalidateCache)
-				cache.POST("/:program/refresh", pathwayHandler.RefreshCache)
+				cache.POST("/:program/refresh", expensive, pathwayHandler.RefreshCache)
 				cache.DELETE("", pathwayHandler.ClearAllCache) // Use with caution
 			}
 
 			// Job role details endpoint
-			pathway.GET("/job-roles/:roleName", pathwayHandler.GetJobRoleDetails)
+			pathway.GET("/job-roles/:roleName", expensive, pathwayHandler.GetJobRoleDetails)
+
+			// Scholarships, bursaries and loans a student is likely eligible for
+			pathway.POST("/funding-options", draftProfile, pathwayHandler.GetFundingOptions)
+
+			// Scholarships in the graph, by household income and district
+			pathway.GET("/scholarships", pathwayHandler.GetScholarships)
+
+			// Monthly living costs per district, for students who would relocate
+			pathway.GET("/living-costs", needsDatabase, pathwayHandler.GetLivingCosts)
 
 			// Get all careers
-			pathway.GET("/careers", pathwayHandler.GetAllCareers)
+			pathway.GET("/careers", listingCache, pathwayHandler.GetAllCareers)
 
 			// Get pathways to a specific career
-			pathway.GET("/careers/:title/pathways", pathwayHandler.GetPathwayToCareer)
+			pathway.GET("/careers/:title/pathways", listingCache, pathwayHandler.GetPathwayToCareer)
+
+			// Programs for retraining from one career into another
+			pathway.GET("/careers/:title/transition-to/:to", listingCache, pathwayHandler.GetCareerTransition)
+
+			// Open job postings for a career, pulled from job boards
+			pathway.GET("/careers/:title/vacancies", needsDatabase, vacancyHandler.GetCareerVacancies)
+
+			// Compare up to four careers side by side
+			pathway.POST("/careers/compare", expensive, pathwayHandler.CompareCareers)
 
 			// Find career paths based on qualifications
-			pathway.POST("/career-paths", pathwayHandler.GetCareerPaths)
+			pathway.POST("/career-paths", draftProfile, pathwayHandler.GetCareerPaths)
+
+			// Career paths counting work experience recognized as NVQ qualifications
+			pathway.POST("/prior-learning", draftProfile, pathwayHandler.RecognizePriorLearning)
+
+			// Suggestions based on the signed-in user's browsing history
+			pathway.GET("/recommendations/recent-activity", middleware.RequireUser(), pathwayHandler.GetRecentActivityRecommendations)
+
+			// Suggest a correction; applied once approved in the admin review queue
+			pathway.POST("/suggestions", needsDatabase, suggestionHandler.SubmitSuggestion)
+
+			// Programs and careers matching a free-text description of interests
+			pathway.POST("/discover", expensive, draftProfile, discoveryHandler.Discover)
+
+			// Search box suggestions for institutes, programs and careers
+			pathway.GET("/suggest", typeaheadHandler.Suggest)
+
+			// Fuzzy search across institutes, programs, qualifications and careers, forgiving typos
+			pathway.GET("/search", pathwayHandler.SearchEntities)
+
+			// Nodes and edges around an entity, for an interactive pathway map
+			pathway.GET("/graph/subgraph", listingCache, pathwayHandler.GetSubgraph)
+		}
+
+		// Search across institutes, programs, careers, departments and qualifications
+		v1.GET("/search", pathwayHandler.Search)
+
+		// Compressed department snapshot for offline use in the mobile app
+		v1.GET("/offline-bundle", pathwayHandler.GetOfflineBundle)
+
+		// Draft profiles for wizards: answers signed into a token the client holds
+		// and sends back in X-Draft-Profile; nothing is stored on the server
+		draftGroup := v1.Group("/drafts", draftProfile)
+		{
+			draftGroup.POST("/profile", draftHandler.IssueDraft)
+			draftGroup.GET("/profile", draftHandler.GetDraft)
 		}
-	}
 
-	// Debug routes (only in development)
-	if cfg.Server.Environment == "development" {
-		debug := router.Group("/debug")
+		// Privacy policy acceptance and parental consent for minors
+		consentGroup := v1.Group("/consent")
 		{
-			debug.GET("/config", func(c *gin.Context) {
-				// Return sanitized config (without sensitive info)
-				sanitizedCfg := *cfg
-				sanitizedCfg.MongoDB.URI = maskSensitive(cfg.MongoDB.URI)
-				sanitizedCfg.Neo4j.Password = "***"
-				sanitizedCfg.LLM.APIKey = "***"
-				sanitizedCfg.Weaviate.APIKey = "***"
-				c.JSON(200, sanitizedCfg)
-			})
-
-			debug.GET("/health-check", func(c *gin.Context) {
-				health := cont.HealthCheck(c.Request.Context())
-				c.JSON(200, gin.H{
-					"health_status": health,
-					"all_healthy":   allHealthy(health),
-				})
-			})
-
-			// Debug endpoint for pathway testing
-			debug.GET("/pathway/test", func(c *gin.Context) {
-				institutes, err := cont.PathwayService().GetAllInstitutes(c.Request.Context())
-				if err != nil {
-					c.JSON(500, gin.H{"error": err.Error()})
-					return
-				}
-
-				c.JSON(200, gin.H{
-					"institutes": institutes,
-					"count":      len(institutes),
-					"timestamp":  time.Now(),
-				})
-			})
+			consentGroup.GET("/policy", consentHandler.GetPolicy)
+			consentGroup.GET("", needsDatabase, middleware.RequireUser(), consentHandler.GetStatus)
+			consentGroup.POST("", needsDatabase, middleware.RequireUser(), consentHandler.AcceptPolicy)
+			consentGroup.POST("/parental", needsDatabase, middleware.RequireUser(), consentHandler.GrantParentalConsent)
+			consentGroup.DELETE("", needsDatabase, middleware.RequireUser(), consentHandler.WithdrawConsent)
+			consentGroup.GET("/history", needsDatabase, middleware.RequireUser(), consentHandler.GetHistory)
+		}
+
+		// Upcoming institute events, polled by notifiers
+		v1.GET("/events/upcoming", needsDatabase, eventHandler.GetUpcomingEvents)
+
+		// Running A/B experiments, and feedback on responses they shaped
+		experimentGroup := v1.Group("/experiments", needsDatabase)
+		{
+			experimentGroup.GET("", experimentHandler.ListExperiments)
+			experimentGroup.POST("/feedback", experimentHandler.RecordFeedback)
+		}
+
+		// Student plan endpoints (require a signed-in user)
+		plans := v1.Group("/plans", needsDatabase, middleware.RequireUser(), needsConsent)
+		{
+			plans.POST("", planHandler.CreatePlan)
+			plans.GET("", planHandler.ListPlans)
+			plans.GET("/:id", planHandler.GetPlan)
+
+			// Study schedule from the plan's roadmap, as JSON, an iCalendar file or
+			// pushed to the student's Google Calendar
+			plans.GET("/:id/schedule", planHandler.GetSchedule)
+			plans.GET("/:id/schedule/ical", planHandler.ExportScheduleICal)
+			plans.POST("/:id/schedule/google", calendarHandler.PushSchedule)
+
+			// Share a plan with parents/guardians
+			plans.POST("/:id/share-links", planHandler.CreateShareLink)
+			plans.DELETE("/share-links/:token", planHandler.RevokeShareLink)
+		}
+
+		// Google Calendar connection of the signed-in user
+		calendarGroup := v1.Group("/calendar/google", needsDatabase, middleware.RequireUser(), needsConsent)
+		{
+			calendarGroup.GET("", calendarHandler.GetStatus)
+			calendarGroup.POST("/connect", calendarHandler.Connect)
+			calendarGroup.DELETE("", calendarHandler.Disconnect)
+		}
+
+		// Google redirects the browser here, identifying the user by the OAuth state
+		v1.GET("/calendar/google/callback", needsDatabase, calendarHandler.Callback)
+
+		// Steps of learning roadmaps the user has completed
+		progressGroup := v1.Group("/progress", needsDatabase, middleware.RequireUser(), needsConsent)
+		{
+			progressGroup.GET("", progressHandler.ListProgress)
+			progressGroup.GET("/:program", progressHandler.GetProgress)
+			progressGroup.PUT("/:program/steps/:step", progressHandler.CompleteStep)
+			progressGroup.DELETE("/:program/steps/:step", progressHandler.UncompleteStep)
+		}
+
+		// Classroom cohorts: teachers follow their students' pathways in aggregate
+		cohortGroup := v1.Group("/cohorts", needsDatabase, middleware.RequireUser(), needsConsent)
+		{
+			cohortGroup.POST("", cohortHandler.CreateCohort)
+			cohortGroup.GET("", cohortHandler.ListCohorts)
+			cohortGroup.POST("/join", cohortHandler.JoinCohort)
+			cohortGroup.DELETE("/:code/membership", cohortHandler.LeaveCohort)
+			cohortGroup.GET("/:code/summary", cohortHandler.GetCohortSummary)
+		}
+
+		// schema.org markup for the frontend's program pages
+		seoGroup := v1.Group("/seo")
+		{
+			seoGroup.GET("/programs/:name", listingCache, seoHandler.GetProgramStructuredData)
+		}
+
+		// Plain-text menus for USSD and IVR gateways, for students on basic phones:
+		// browsing, and matching programs to a stream and interest sent by SMS.
+		// Matching texts the caller, so only the gateway may start it.
+		v1.POST("/ussd", ussdHandler.Respond)
+		v1.POST("/ussd/match", middleware.RequireGateway(cfg.USSD.GatewaySecret, cfg.USSD.GatewayIPs), expensive, ussdHandler.RespondMatch)
+
+		// Atom feed of newly added programs for schools and community centers
+		feedGroup := v1.Group("/feed")
+		{
+			feedGroup.GET("/new-programs.xml", listingCache, seoHandler.GetNewProgramsFeed)
+		}
+
+		// Open Badges for completed roadmaps; the issuer, key, badge classes and
+		// assertions are public so that anyone can verify a badge
+		badgeGroup := v1.Group("/badges", needsDatabase)
+		{
+			badgeGroup.GET("", middleware.RequireUser(), badgeHandler.ListBadges)
+			badgeGroup.POST("", middleware.RequireUser(), needsConsent, badgeHandler.ClaimBadge)
+			badgeGroup.GET("/issuer", badgeHandler.GetIssuer)
+			badgeGroup.GET("/issuer/key", badgeHandler.GetKey)
+			badgeGroup.GET("/classes/:program", badgeHandler.GetBadgeClass)
+			badgeGroup.GET("/assertions/:id", badgeHandler.GetAssertion)
+			badgeGroup.GET("/assertions/:id/signed", badgeHandler.GetSignedAssertion)
+		}
+
+		// Aggregate, anonymized usage trends for ministries and NGOs
+		analyticsGroup := v1.Group("/analytics", needsDatabase)
+		{
+			analyticsGroup.GET("/trends", analyticsHandler.GetTrends)
+			analyticsGroup.GET("/districts", analyticsHandler.GetDistricts)
+			analyticsGroup.GET("/districts/opportunities", analyticsHandler.GetOpportunityMap)
+		}
+
+		// Diagnostics of this instance (requires X-Admin-Key): sanitized config,
+		// dependency health and a pathway smoke test. Unlike the rest of the admin
+		// API they are available in demo mode and while databases are down.
+		diagnosticsGroup := v1.Group("/admin/diagnostics", middleware.RequireAdmin(cfg.Admin.APIKey))
+		{
+			diagnosticsGroup.GET("/config", diagnosticsHandler.GetConfig)
+			diagnosticsGroup.GET("/health", diagnosticsHandler.GetHealth)
+			diagnosticsGroup.POST("/smoke-test", diagnosticsHandler.RunSmokeTest)
+		}
+
+		// Graph administration (requires X-Admin-Key)
+		adminGroup := v1.Group("/admin", needsDatabase, middleware.RequireAdmin(cfg.Admin.APIKey))
+		{
+			adminGroup.POST("/:entity", adminHandler.CreateEntity)
+			adminGroup.PUT("/:entity/:name", adminHandler.UpdateEntity)
+			adminGroup.GET("/:entity/:name/provenance", adminHandler.GetProvenance)
+			adminGroup.PUT("/:entity/:name/intakes", adminHandler.SaveProgramIntakes)
+			adminGroup.PUT("/:entity/:name/aptitude-tests", adminHandler.SaveAptitudeTests)
+			adminGroup.PUT("/:entity/:name/outcomes", outcomeHandler.SaveSurveys)
+			adminGroup.DELETE("/:entity/:name", adminHandler.DeleteEntity)
+			adminGroup.POST("/:entity/:name/restore", adminHandler.RestoreEntity)
+
+			// The same entity writes grouped under /graph, plus linking and
+			// unlinking single program requirements, prerequisites and careers
+			graphGroup := adminGroup.Group("/graph")
+			{
+				graphGroup.POST("/:entity", adminHandler.CreateEntity)
+				graphGroup.PUT("/:entity/:name", adminHandler.UpdateEntity)
+				graphGroup.DELETE("/:entity/:name", adminHandler.DeleteEntity)
+				graphGroup.POST("/:entity/:name/restore", adminHandler.RestoreEntity)
+				graphGroup.POST("/programs/:name/:relation/:target", adminHandler.LinkProgram)
+				graphGroup.DELETE("/programs/:name/:relation/:target", adminHandler.UnlinkProgram)
+
+				// The whole pathway graph, or a department's part of it, as
+				// nodes and edges (?format=json) or GraphML (?format=graphml)
+				graphGroup.GET("/export", pathwayHandler.ExportGraph)
+			}
+
+			// Rebuild the semantic discovery index from the graph
+			adminGroup.POST("/discovery/reindex", discoveryHandler.Reindex)
+
+			// Data quality search across entity types
+			adminGroup.GET("/search", adminHandler.SearchEntities)
+
+			// Audit trail of admin edits, with before/after snapshots for reverting
+			adminGroup.GET("/entities/:entity/:name/history", adminHandler.GetHistory)
+			adminGroup.POST("/entities/:entity/:name/history/:id/revert", adminHandler.RevertChange)
+
+			// Bulk imports (?dry_run=true validates without writing)
+			adminGroup.POST("/import/programs", importHandler.ImportPrograms)
+
+			// Counselor-maintained Google Sheets, mapped onto import fields
+			adminGroup.GET("/sheets", sheetsHandler.ListMappings)
+			adminGroup.PUT("/sheets/:name", sheetsHandler.SaveMapping)
+			adminGroup.POST("/sheets/:name/sync", sheetsHandler.SyncSheet)
+
+			// Partner API keys, their quotas and monthly usage reports
+			adminGroup.GET("/api-keys", apiKeyHandler.ListKeys)
+			adminGroup.POST("/api-keys", apiKeyHandler.CreateKey)
+			adminGroup.PUT("/api-keys/:id/quota", apiKeyHandler.SetQuota)
+			adminGroup.DELETE("/api-keys/:id", apiKeyHandler.RevokeKey)
+			adminGroup.GET("/api-keys/:id/usage", apiKeyHandler.GetUsage)
+
+			// Living costs per district
+			adminGroup.PUT("/living-costs/:district", adminHandler.SaveLivingCost)
+
+			// Other names students give qualifications and subjects ("AL",
+			// "Maths"), resolved in eligibility, search and career path requests
+			adminGroup.GET("/qualification-aliases", adminHandler.GetQualificationAliases)
+			adminGroup.PUT("/qualification-aliases", adminHandler.SaveQualificationAlias)
+			adminGroup.DELETE("/qualification-aliases/:key", adminHandler.DeleteQualificationAlias)
+
+			// Institute events
+			adminGroup.POST("/events", eventHandler.CreateEvent)
+			adminGroup.GET("/events/:id", eventHandler.GetEvent)
+			adminGroup.PUT("/events/:id", eventHandler.UpdateEvent)
+			adminGroup.DELETE("/events/:id", eventHandler.DeleteEvent)
+
+			// Engagement and feedback per variant of the A/B experiments
+			adminGroup.GET("/experiments", experimentHandler.GetReport)
+
+			// Searches and eligibility checks that found nothing, to find data gaps
+			adminGroup.GET("/analytics/zero-results", analyticsHandler.GetZeroResults)
+
+			// Partner schools' Moodle sites and roadmap exports to them as courses
+			adminGroup.GET("/moodle/partners", moodleHandler.ListPartners)
+			adminGroup.PUT("/moodle/partners/:name", moodleHandler.SavePartner)
+			adminGroup.POST("/moodle/partners/:name/export", moodleHandler.ExportRoadmap)
+
+			// Outgoing email: send a test message, or read what the sink captured
+			adminGroup.POST("/mail/test", mailHandler.SendTestMail)
+			adminGroup.GET("/mail/outbox", mailHandler.GetOutbox)
+
+			// Outgoing SMS captured by the sink
+			adminGroup.GET("/sms/outbox", smsHandler.GetOutbox)
+
+			// Log of graph changes applied by admin edits, imports and syncs, for downstream consumers
+			adminGroup.GET("/changes", changelogHandler.ListChanges)
+
+			// External dataset pipelines
+			adminGroup.POST("/ingest/ugc-handbook", ingestionHandler.IngestUGCHandbook)
+			adminGroup.POST("/ingest/tvec-sync", ingestionHandler.SyncTVECRegistry)
+			adminGroup.POST("/ingest/job-board-salaries", ingestionHandler.SyncJobBoardSalaries)
+			adminGroup.POST("/ingest/vacancies", vacancyHandler.SyncVacancies)
+
+			// Career demand indexes, recomputed on a schedule, and admin overrides of them
+			adminGroup.GET("/career-demand", demandHandler.ListDemand)
+			adminGroup.POST("/career-demand/recompute", demandHandler.RecomputeDemand)
+			adminGroup.PUT("/career-demand/overrides", demandHandler.SetDemandOverride)
+			adminGroup.DELETE("/career-demand/overrides/:career", demandHandler.DeleteDemandOverride)
+
+			// Approval queue for entries the pipelines could not map confidently
+			adminGroup.GET("/review-queue", reviewHandler.ListItems)
+			adminGroup.POST("/review-queue/:id/approve", reviewHandler.ApproveItem)
+			adminGroup.POST("/review-queue/:id/reject", reviewHandler.RejectItem)
+
+			// Pinned roadmaps, e.g. reviewed by a counselor, never expire and are not regenerated
+			adminGroup.PUT("/roadmaps/:program/pin", pathwayHandler.PinRoadmap)
+			adminGroup.DELETE("/roadmaps/:program/pin", pathwayHandler.UnpinRoadmap)
+
+			// Clients blocked for scripted use of expensive endpoints, and lifting a block early
+			adminGroup.GET("/abuse/blocks", abuseHandler.ListBlocks)
+			adminGroup.DELETE("/abuse/blocks/*key", abuseHandler.Unblock)
+
+			// Captured LLM prompts and responses, labeled to improve the prompts
+			adminGroup.GET("/llm-exchanges", promptReviewHandler.ListExchanges)
+			adminGroup.GET("/llm-exchanges/:id", promptReviewHandler.GetExchange)
+			adminGroup.PUT("/llm-exchanges/:id/label", promptReviewHandler.LabelExchange)
+
+			adminGroup.GET("/backups", backupHandler.ListBackups)
+			adminGroup.POST("/backups", backupHandler.CreateBackup)
+			adminGroup.POST("/backups/:name/restore", backupHandler.RestoreBackup)
+
+			// Promote a curated graph between environments (export here, import there)
+			adminGroup.GET("/promotion/export", backupHandler.ExportPromotion)
+			adminGroup.POST("/promotion/import", backupHandler.ImportPromotion)
+		}
+
+		// Shared views (the share token is the permission)
+		shared := v1.Group("/shared", needsDatabase)
+		{
+			shared.GET("/:token/guardian-summary", planHandler.GetGuardianSummary)
 		}
 	}
 
+	warnUnknownRoutes(router, sizeLimits, logger)
+
 	return router
 }
 
-func maskSensitive(uri string) string {
-	// Simple masking for URIs containing credentials
-	if len(uri) > 20 {
-		return uri[:10] + "***" + uri[len(uri)-7:]
+// searchBodySize caps the bodies of search and USSD requests, which only carry
+// a few qualifications, preferences or a menu reply
+const searchBodySize = 16 << 10 // 16KB
+
+// routeSizeLimits lists the routes whose request or response size limits differ
+// from the defaults
+func routeSizeLimits(cfg *config.Config) map[string]middleware.SizeLimit {
+	search := middleware.SizeLimit{Request: searchBodySize, Response: cfg.Server.MaxResponseSize}
+	upload := middleware.SizeLimit{Request: cfg.Server.MaxUploadSize, Response: cfg.Server.MaxResponseSize}
+	download := middleware.SizeLimit{Request: cfg.Server.MaxBodySize}
+
+	return map[string]middleware.SizeLimit{
+		"POST /api/v1/pathway/discover":                 search,
+		"POST /api/v1/pathway/career-paths":             search,
+		"POST /api/v1/pathway/careers/compare":          search,
+		"POST /api/v1/pathway/funding-options":          search,
+		"POST /api/v1/pathway/programs/:name/readiness": search,
+		"POST /api/v1/pathway/suggestions":              search,
+		"POST /api/v1/experiments/feedback":             search,
+		"POST /api/v1/ussd":                             search,
+		"POST /api/v1/ussd/match":                       search,
+		"POST /api/v1/admin/import/programs":            upload,
+		"POST /api/v1/admin/ingest/ugc-handbook":        upload,
+		"GET /sitemap.xml":                              download,
+		"GET /api/v1/offline-bundle":                    download,
+		"GET /api/v1/pathway/programs/:name/handout":    download,
+		"GET /api/v1/admin/promotion/export":            download,
+		"GET /api/v1/admin/graph/export":                download,
+		// The promotion import applies its own, larger cap
+		"POST /api/v1/admin/promotion/import": {},
 	}
-	return "***"
 }
 
-func allHealthy(health map[string]bool) bool {
-	for _, healthy := range health {
-		if !healthy {
-			return false
+// warnUnknownRoutes logs size limits given for routes that are not registered,
+// so a renamed route does not silently fall back to the defaults
+func warnUnknownRoutes(router *gin.Engine, limits map[string]middleware.SizeLimit, logger *zap.Logger) {
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for route := range limits {
+		if !registered[route] {
+			logger.Warn("Size limit set for an unknown route", zap.String("route", route))
 		}
 	}
-	return true
 }
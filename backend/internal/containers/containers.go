@@ -2,24 +2,111 @@ package containers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/mayura-andrew/fastfinder/internal/core/config"
 	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"github.com/mayura-andrew/fastfinder/internal/core/mail"
+	"github.com/mayura-andrew/fastfinder/internal/core/sms"
+	"github.com/mayura-andrew/fastfinder/internal/data/memstore"
 	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
 	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/data/sqlstore"
+	"github.com/mayura-andrew/fastfinder/internal/data/weaviate"
+	"github.com/mayura-andrew/fastfinder/internal/services/abuse"
+	"github.com/mayura-andrew/fastfinder/internal/services/admin"
+	"github.com/mayura-andrew/fastfinder/internal/services/analytics"
+	"github.com/mayura-andrew/fastfinder/internal/services/apikeys"
+	"github.com/mayura-andrew/fastfinder/internal/services/backup"
+	"github.com/mayura-andrew/fastfinder/internal/services/badges"
+	"github.com/mayura-andrew/fastfinder/internal/services/calendar"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"github.com/mayura-andrew/fastfinder/internal/services/cohorts"
+	"github.com/mayura-andrew/fastfinder/internal/services/consent"
+	"github.com/mayura-andrew/fastfinder/internal/services/demand"
+	"github.com/mayura-andrew/fastfinder/internal/services/diagnostics"
+	"github.com/mayura-andrew/fastfinder/internal/services/discovery"
+	"github.com/mayura-andrew/fastfinder/internal/services/drafts"
+	"github.com/mayura-andrew/fastfinder/internal/services/events"
+	"github.com/mayura-andrew/fastfinder/internal/services/experiments"
+	"github.com/mayura-andrew/fastfinder/internal/services/handout"
+	"github.com/mayura-andrew/fastfinder/internal/services/identity"
+	"github.com/mayura-andrew/fastfinder/internal/services/importer"
+	"github.com/mayura-andrew/fastfinder/internal/services/ingestion"
+	"github.com/mayura-andrew/fastfinder/internal/services/moderation"
+	"github.com/mayura-andrew/fastfinder/internal/services/moodle"
+	"github.com/mayura-andrew/fastfinder/internal/services/outcomes"
 	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"github.com/mayura-andrew/fastfinder/internal/services/plans"
+	"github.com/mayura-andrew/fastfinder/internal/services/progress"
+	"github.com/mayura-andrew/fastfinder/internal/services/promptreview"
+	"github.com/mayura-andrew/fastfinder/internal/services/resources"
+	"github.com/mayura-andrew/fastfinder/internal/services/review"
 	"github.com/mayura-andrew/fastfinder/internal/services/scraper"
+	"github.com/mayura-andrew/fastfinder/internal/services/seo"
+	"github.com/mayura-andrew/fastfinder/internal/services/sheets"
+	"github.com/mayura-andrew/fastfinder/internal/services/suggestions"
+	"github.com/mayura-andrew/fastfinder/internal/services/typeahead"
+	"github.com/mayura-andrew/fastfinder/internal/services/ussd"
+	"github.com/mayura-andrew/fastfinder/internal/services/vacancies"
+	"github.com/mayura-andrew/fastfinder/internal/services/webhook"
 	"github.com/mayura-andrew/fastfinder/pkg/logger"
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrMigrationsPending is returned by Readiness while the graph schema has
+	// not been migrated
+	ErrMigrationsPending = errors.New("migrations pending")
+
+	// ErrDependencyUnreachable is returned by Readiness while a database cannot
+	// be reached
+	ErrDependencyUnreachable = errors.New("dependency unreachable")
+)
+
 type Container interface {
 	PathwayService() *pathway.Service
 	YouTubeService() *scraper.YouTubeService
+	PlanService() *plans.Service
+	AnalyticsService() *analytics.Service
+	AdminService() *admin.Service
+	ImportService() *importer.Service
+	ReviewService() *review.Service
+	IngestionService() *ingestion.Service
+	BackupService() *backup.Service
+	SheetsService() *sheets.Service
+	SuggestionService() *suggestions.Service
+	VacancyService() *vacancies.Service
+	ChangelogService() *changelog.Service
+	DiscoveryService() *discovery.Service
+	TypeaheadService() *typeahead.Service
+	MoodleService() *moodle.Service
+	ProgressService() *progress.Service
+	BadgeService() *badges.Service
+	CalendarService() *calendar.Service
+	SEOService() *seo.Service
+	APIKeyService() *apikeys.Service
+	ConsentService() *consent.Service
+	ExperimentService() *experiments.Service
+	EventService() *events.Service
+	OutcomeService() *outcomes.Service
+	DemandService() *demand.Service
+	CohortService() *cohorts.Service
+	HandoutService() *handout.Service
+	USSDService() *ussd.Service
+	PromptReviewService() *promptreview.Service
+	AbuseService() *abuse.Service
+	DraftService() *drafts.Service
+	IdentityService() *identity.Service
+	DiagnosticsService() *diagnostics.Service
+	Mailer() *mail.Mailer
+	SMSSender() *sms.Sender
+	Leases() *mongodb.LeaseStore
 	HealthCheck(ctx context.Context) map[string]bool
+	Readiness(ctx context.Context) error
 }
 
 type AppContainer struct {
@@ -27,13 +114,64 @@ type AppContainer struct {
 	logger *zap.Logger
 
 	// Database clients
-	mongoClient *mongodb.Client
-	neo4jClient *neo4j.Client
-	llmClient   *llm.Client
+	mongoClient    *mongodb.Client
+	neo4jClient    *neo4j.Client
+	llmClient      *llm.Client
+	weaviateClient *weaviate.Client
+
+	// cacheDB holds the roadmap and job role caches when they are kept in SQL
+	cacheDB *sqlstore.Client
+
+	// mailer sends notification email, or captures it while email is disabled
+	mailer *mail.Mailer
+
+	// smsSender sends text messages, or captures them while SMS is disabled
+	smsSender *sms.Sender
+
+	// graph replaces Neo4j in demo mode
+	graph *memstore.Graph
+
+	// leases keeps background work on shared state to one instance
+	leases *mongodb.LeaseStore
+
+	// schemaErr is why the graph schema could not be migrated at startup, if it failed
+	schemaErr error
 
 	// Services
-	pathwayService *pathway.Service
-	youtubeService *scraper.YouTubeService
+	pathwayService    *pathway.Service
+	youtubeService    *scraper.YouTubeService
+	planService       *plans.Service
+	analyticsService  *analytics.Service
+	adminService      *admin.Service
+	importService     *importer.Service
+	reviewService     *review.Service
+	ingestionService  *ingestion.Service
+	backupService     *backup.Service
+	sheetsService     *sheets.Service
+	suggestionService *suggestions.Service
+	vacancyService    *vacancies.Service
+	changelogService  *changelog.Service
+	discoveryService  *discovery.Service
+	typeaheadService  *typeahead.Service
+	moodleService     *moodle.Service
+	progressService   *progress.Service
+	badgeService      *badges.Service
+	calendarService   *calendar.Service
+	seoService        *seo.Service
+	apiKeyService     *apikeys.Service
+	consentService    *consent.Service
+	experimentService *experiments.Service
+	eventService      *events.Service
+	outcomeService    *outcomes.Service
+	demandService     *demand.Service
+	cohortService     *cohorts.Service
+	handoutService    *handout.Service
+	ussdService       *ussd.Service
+	promptReview      *promptreview.Service
+	abuseService      *abuse.Service
+	draftService      *drafts.Service
+	identityService   *identity.Service
+	diagnostics       *diagnostics.Service
 }
 
 func NewContainer(cfg *config.Config) (Container, error) {
@@ -44,6 +182,38 @@ func NewContainer(cfg *config.Config) (Container, error) {
 		logger: logger,
 	}
 
+	mailer, err := mail.New(cfg.Mailer, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize mailer: %w", err)
+	}
+	container.mailer = mailer
+
+	smsSender, err := sms.New(cfg.SMS, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize SMS sender: %w", err)
+	}
+	container.smsSender = smsSender
+
+	// Abuse detection keeps its counts in memory, so it also runs in demo mode
+	container.abuseService = abuse.NewService(cfg.Abuse, logger)
+
+	// Draft profiles are held by clients, so they also work in demo mode
+	container.draftService = drafts.NewService(cfg.Drafts, logger)
+
+	// User tokens are verified with a shared secret, so they need no database
+	container.identityService = identity.NewService(cfg.Identity, logger)
+
+	// Diagnostics matter most when databases are down, so they never need them
+	container.diagnostics = diagnostics.NewService(cfg, container, logger)
+
+	if cfg.Server.Demo {
+		if err := container.initializeDemo(); err != nil {
+			return nil, fmt.Errorf("failed to initialize demo mode: %w", err)
+		}
+		logger.Info("Dependency injection container initialized in demo mode")
+		return container, nil
+	}
+
 	if err := container.initializeClients(); err != nil {
 		return nil, fmt.Errorf("failed to initialize clients: %w", err)
 	}
@@ -82,6 +252,9 @@ func (c *AppContainer) initializeClientsEnhanced() error {
 
 	c.logger.Info("MongoDB client initialized successfully with verified write permissions")
 
+	c.leases = mongodb.NewLeaseStore(mongoClient, c.logger)
+	c.logger.Info("Lease store initialized", zap.String("holder", c.leases.Holder()))
+
 	// Initialize Neo4j client
 	c.logger.Info("Initializing Neo4j client", zap.String("uri", c.config.Neo4j.URI))
 	neo4jClient, err := neo4j.NewClient(c.config.Neo4j)
@@ -90,6 +263,15 @@ func (c *AppContainer) initializeClientsEnhanced() error {
 	}
 	c.neo4jClient = neo4jClient
 
+	// Bring the graph schema up to date. A failure leaves the service running for
+	// diagnosis but not ready, as writes could create duplicate nodes.
+	migrateCtx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	if err := neo4jClient.Migrate(migrateCtx); err != nil {
+		c.logger.Error("Graph schema migration failed, service will report not ready", zap.Error(err))
+		c.schemaErr = err
+	}
+	cancel()
+
 	c.logger.Info("Neo4j client initialized successfully")
 
 	// Initialize LLM client
@@ -105,6 +287,17 @@ func (c *AppContainer) initializeClientsEnhanced() error {
 	}
 	c.llmClient = llmClient
 
+	// Initialize Weaviate client (optional, for semantic discovery)
+	if c.config.Weaviate.Host != "" {
+		c.logger.Info("Initializing Weaviate client", zap.String("host", c.config.Weaviate.Host))
+		weaviateClient, err := weaviate.NewClient(c.config.Weaviate)
+		if err != nil {
+			c.logger.Warn("Failed to initialize Weaviate client, semantic discovery will be disabled", zap.Error(err))
+		} else {
+			c.weaviateClient = weaviateClient
+		}
+	}
+
 	// Initialize YouTube service
 	c.logger.Info("Initializing YouTube service")
 	youtubeAPIKey := c.config.LLM.APIKey // Reusing API key config, you may want to add a separate field
@@ -115,9 +308,163 @@ func (c *AppContainer) initializeClientsEnhanced() error {
 
 	// Initialize services
 	c.logger.Info("Initializing services")
-	c.pathwayService = pathway.NewService(c.neo4jClient, c.llmClient, c.youtubeService, c.mongoClient, c.logger)
+	c.pathwayService = pathway.NewService(c.neo4jClient, c.llmClient, c.youtubeService, c.mongoClient, c.config.Neo4j.ListCacheTTL, c.config.Neo4j.SnapshotPath, c.logger)
+	if c.config.Neo4j.ListCacheShared == "mongodb" {
+		c.pathwayService.UseSharedListCache(mongodb.NewListingCache(c.mongoClient, c.logger))
+	}
+	// Only the roadmap and job role caches can be kept in SQL; every other
+	// service above and below still stores its data in MongoDB
+	if c.config.Cache.Store != "mongodb" {
+		c.logger.Info("Initializing SQL cache store", zap.String("store", c.config.Cache.Store))
+		cacheDB, err := sqlstore.NewClient(c.config.Cache, c.logger)
+		if err != nil {
+			return fmt.Errorf("failed to initialize cache store: %w", err)
+		}
+		c.cacheDB = cacheDB
+		c.pathwayService.UseCaches(sqlstore.NewLearningRoadmapCache(cacheDB, c.logger), sqlstore.NewJobRoleCache(cacheDB, c.logger))
+	}
+	c.useResourceProviders()
+	// Access edges left by a previous run may be stale, so they are only used
+	// once rebuilt
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if _, err := c.pathwayService.RebuildAccessibility(ctx); err != nil {
+			c.logger.Warn("Initial program accessibility build failed", zap.Error(err))
+		}
+	}()
+	// Take a fresh listing snapshot rather than serving the one on disk until the
+	// first scheduled run
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		if _, err := c.pathwayService.RefreshSnapshot(ctx); err != nil {
+			c.logger.Warn("Initial listing snapshot failed", zap.Error(err))
+		}
+	}()
 	c.logger.Info("Pathway service initialized successfully")
 
+	c.planService = plans.NewService(c.mongoClient, c.pathwayService, c.logger)
+	c.logger.Info("Plan service initialized successfully")
+
+	c.analyticsService = analytics.NewService(c.mongoClient, c.neo4jClient, c.logger)
+	c.logger.Info("Analytics service initialized successfully")
+
+	c.typeaheadService = typeahead.NewService(c.neo4jClient, c.logger)
+	c.seoService = seo.NewService(c.neo4jClient, c.pathwayService, c.config.SEO, c.logger)
+	// Build the index now rather than waiting for the first scheduled run
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if _, err := c.typeaheadService.Refresh(ctx); err != nil {
+			c.logger.Warn("Initial typeahead index build failed", zap.Error(err))
+		}
+	}()
+	c.logger.Info("Typeahead service initialized successfully")
+
+	// Admin edits, imports, syncs and restores report their changes through the change log;
+	// cached roadmaps of changed programs are dropped as they arrive, the typeahead
+	// index is rebuilt and the configured webhooks are notified
+	c.changelogService = changelog.NewService(c.mongoClient, c.logger)
+	c.changelogService.Subscribe(c.pathwayService.InvalidateChangedPrograms)
+	c.changelogService.Subscribe(c.typeaheadService.RefreshOnChange)
+	if len(c.config.Webhooks.URLs) > 0 {
+		c.changelogService.Subscribe(webhook.NewNotifier(c.config.Webhooks, c.logger).Notify)
+	}
+	c.logger.Info("Change log initialized successfully",
+		zap.Int("webhooks", len(c.config.Webhooks.URLs)))
+
+	c.adminService = admin.NewService(c.neo4jClient, c.mongoClient, c.changelogService, c.logger)
+	c.logger.Info("Admin service initialized successfully")
+
+	c.importService = importer.NewService(c.neo4jClient, c.changelogService, c.logger)
+	c.logger.Info("Import service initialized successfully")
+
+	// Syncs record their own, more detailed changes, so their imports are not
+	// reported to the change log a second time
+	sheetsImporter := importer.NewService(c.neo4jClient, nil, c.logger)
+	c.sheetsService = sheets.NewService(c.neo4jClient, c.mongoClient, sheetsImporter, c.changelogService, c.config.Sheets, c.logger)
+	c.logger.Info("Sheets service initialized successfully")
+
+	c.reviewService = review.NewService(c.mongoClient, c.logger)
+	c.promptReview = promptreview.NewService(c.mongoClient, c.config.LLM, c.logger)
+	if c.config.LLM.Capture && c.llmClient != nil {
+		c.llmClient.UseCapture(c.promptReview.Capture)
+		c.logger.Info("Capturing LLM exchanges for review",
+			zap.Float64("sample_rate", c.config.LLM.CaptureSampleRate))
+	}
+	c.ingestionService = ingestion.NewService(c.neo4jClient, c.mongoClient, c.reviewService, c.changelogService, c.config.TVEC, c.config.JobBoard, c.logger)
+	c.logger.Info("Ingestion pipelines initialized successfully")
+
+	c.suggestionService = suggestions.NewService(c.neo4jClient, c.adminService, c.reviewService, c.logger)
+	c.suggestionService.UseModeration(moderation.NewService(c.config.Moderation, c.llmClient, c.reviewService, c.logger))
+	c.logger.Info("Suggestion service initialized successfully")
+
+	c.vacancyService = vacancies.NewService(c.neo4jClient, c.mongoClient, c.config.JobBoard, c.logger)
+	c.analyticsService.UseVacancies(c.vacancyService)
+	c.logger.Info("Vacancy service initialized successfully")
+
+	c.demandService = demand.NewService(c.neo4jClient, c.mongoClient, c.vacancyService, c.pathwayService, c.config.Demand, c.logger)
+	c.pathwayService.UseDemand(c.demandService)
+	c.logger.Info("Career demand service initialized successfully")
+
+	c.moodleService = moodle.NewService(c.neo4jClient, c.mongoClient, c.pathwayService, c.config.Moodle, c.logger)
+	c.logger.Info("Moodle export service initialized successfully")
+
+	c.progressService = progress.NewService(c.mongoClient, c.pathwayService, c.logger)
+	c.badgeService = badges.NewService(c.mongoClient, c.progressService, c.mailer, c.config.Badges, c.logger)
+	c.cohortService = cohorts.NewService(c.mongoClient, c.planService, c.progressService, c.logger)
+	c.logger.Info("Progress and badge services initialized successfully")
+
+	c.calendarService = calendar.NewService(c.mongoClient, c.planService, c.config.Calendar, c.logger)
+	c.logger.Info("Calendar service initialized successfully")
+
+	c.apiKeyService = apikeys.NewService(c.mongoClient, c.config.APIKeys, c.logger)
+	c.consentService = consent.NewService(c.mongoClient, c.config.Consent, c.logger)
+	c.experimentService = experiments.NewService(c.mongoClient, c.config.Experiments, c.logger)
+	c.eventService = events.NewService(c.neo4jClient, c.mongoClient, c.logger)
+	c.outcomeService = outcomes.NewService(c.neo4jClient, c.mongoClient, c.logger)
+	c.pathwayService.UseOutcomes(c.outcomeService)
+	c.handoutService = handout.NewService(c.neo4jClient, c.pathwayService, c.seoService, c.logger)
+	c.handoutService.UseEvents(c.eventService)
+	c.logger.Info("API key service initialized successfully")
+
+	c.discoveryService = discovery.NewService(c.neo4jClient, c.weaviateClient, c.llmClient, c.logger)
+	if c.discoveryService.Available() {
+		// Build the index now rather than waiting for the first scheduled run. The
+		// index is shared, so a replica starting while another holds the reindex
+		// job's lease leaves it to that one.
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+			held, err := c.leases.Acquire(ctx, "discovery-reindex", c.config.Weaviate.ReindexInterval)
+			if err != nil {
+				c.logger.Warn("Initial discovery index build skipped: lease unavailable", zap.Error(err))
+				return
+			}
+			if !held {
+				c.logger.Info("Initial discovery index build left to the instance holding its lease")
+				return
+			}
+			if _, err := c.discoveryService.Reindex(ctx); err != nil {
+				c.logger.Warn("Initial discovery index build failed", zap.Error(err))
+			}
+		}()
+	}
+	c.logger.Info("Discovery service initialized successfully",
+		zap.Bool("available", c.discoveryService.Available()))
+
+	c.ussdService = ussd.NewService(c.pathwayService, c.typeaheadService, c.discoveryService, c.smsSender, c.logger)
+
+	backupStore, err := backup.NewStore(c.config.Backup)
+	if err != nil {
+		c.logger.Warn("Backup storage unavailable, backups disabled", zap.Error(err))
+		backupStore = nil
+	}
+	c.backupService = backup.NewService(c.neo4jClient, c.mongoClient, c.changelogService, backupStore, c.config.Backup, c.logger)
+	c.logger.Info("Backup service initialized successfully",
+		zap.String("storage", c.config.Backup.Storage))
+
 	c.logger.Info("All data clients initialized successfully with enhanced authentication")
 	return nil
 }
@@ -132,10 +479,193 @@ func (c *AppContainer) YouTubeService() *scraper.YouTubeService {
 	return c.youtubeService
 }
 
+// PlanService returns the student plan service
+func (c *AppContainer) PlanService() *plans.Service {
+	return c.planService
+}
+
+// AnalyticsService returns the anonymized usage analytics service
+func (c *AppContainer) AnalyticsService() *analytics.Service {
+	return c.analyticsService
+}
+
+// AdminService returns the graph administration service
+func (c *AppContainer) AdminService() *admin.Service {
+	return c.adminService
+}
+
+// ImportService returns the bulk import service
+func (c *AppContainer) ImportService() *importer.Service {
+	return c.importService
+}
+
+// ReviewService returns the admin approval queue service
+func (c *AppContainer) ReviewService() *review.Service {
+	return c.reviewService
+}
+
+// IngestionService returns the external dataset ingestion service
+func (c *AppContainer) IngestionService() *ingestion.Service {
+	return c.ingestionService
+}
+
+// BackupService returns the graph and cache backup service
+func (c *AppContainer) BackupService() *backup.Service {
+	return c.backupService
+}
+
+// SheetsService returns the Google Sheets import service
+func (c *AppContainer) SheetsService() *sheets.Service {
+	return c.sheetsService
+}
+
+// SuggestionService returns the crowd-sourced suggestion service
+func (c *AppContainer) SuggestionService() *suggestions.Service {
+	return c.suggestionService
+}
+
+// VacancyService returns the job vacancy feed service
+func (c *AppContainer) VacancyService() *vacancies.Service {
+	return c.vacancyService
+}
+
+// ChangelogService returns the graph change log service
+func (c *AppContainer) ChangelogService() *changelog.Service {
+	return c.changelogService
+}
+
+// DiscoveryService returns the semantic interest discovery service
+func (c *AppContainer) DiscoveryService() *discovery.Service {
+	return c.discoveryService
+}
+
+// TypeaheadService returns the search box suggestion service
+func (c *AppContainer) TypeaheadService() *typeahead.Service {
+	return c.typeaheadService
+}
+
+// MoodleService returns the Moodle roadmap export service
+func (c *AppContainer) MoodleService() *moodle.Service {
+	return c.moodleService
+}
+
+// ProgressService returns the roadmap progress service
+func (c *AppContainer) ProgressService() *progress.Service {
+	return c.progressService
+}
+
+// BadgeService returns the Open Badges service
+func (c *AppContainer) BadgeService() *badges.Service {
+	return c.badgeService
+}
+
+// Mailer returns the mailer used for notification email
+func (c *AppContainer) Mailer() *mail.Mailer {
+	return c.mailer
+}
+
+// SMSSender returns the sender used for text messages
+func (c *AppContainer) SMSSender() *sms.Sender {
+	return c.smsSender
+}
+
+// CalendarService returns the Google Calendar sync service
+func (c *AppContainer) CalendarService() *calendar.Service {
+	return c.calendarService
+}
+
+// SEOService returns the sitemap and structured data service
+func (c *AppContainer) SEOService() *seo.Service {
+	return c.seoService
+}
+
+// APIKeyService returns the partner API key service, which is nil in demo mode
+func (c *AppContainer) APIKeyService() *apikeys.Service {
+	return c.apiKeyService
+}
+
+// ConsentService returns the privacy consent service, which is nil in demo mode
+func (c *AppContainer) ConsentService() *consent.Service {
+	return c.consentService
+}
+
+// ExperimentService returns the A/B experiments service, which is nil in demo mode
+func (c *AppContainer) ExperimentService() *experiments.Service {
+	return c.experimentService
+}
+
+// EventService returns the institute event service, which is nil in demo mode
+func (c *AppContainer) EventService() *events.Service {
+	return c.eventService
+}
+
+// OutcomeService returns the graduate outcomes service, which is nil in demo mode
+func (c *AppContainer) OutcomeService() *outcomes.Service {
+	return c.outcomeService
+}
+
+// DemandService returns the career demand index service, which is nil in demo mode
+func (c *AppContainer) DemandService() *demand.Service {
+	return c.demandService
+}
+
+// CohortService returns the classroom cohort service, which is nil in demo mode
+func (c *AppContainer) CohortService() *cohorts.Service {
+	return c.cohortService
+}
+
+// HandoutService returns the printable program handout service
+func (c *AppContainer) HandoutService() *handout.Service {
+	return c.handoutService
+}
+
+// USSDService returns the USSD and IVR menu service
+func (c *AppContainer) USSDService() *ussd.Service {
+	return c.ussdService
+}
+
+// PromptReviewService returns the service capturing LLM exchanges for review
+func (c *AppContainer) PromptReviewService() *promptreview.Service {
+	return c.promptReview
+}
+
+// AbuseService returns the service blocking scripted use of expensive endpoints
+func (c *AppContainer) AbuseService() *abuse.Service {
+	return c.abuseService
+}
+
+// DraftService returns the service signing the draft profiles clients hold
+func (c *AppContainer) DraftService() *drafts.Service {
+	return c.draftService
+}
+
+// IdentityService returns the service verifying the user tokens students sign
+// in with
+func (c *AppContainer) IdentityService() *identity.Service {
+	return c.identityService
+}
+
+// DiagnosticsService returns the service admins inspect the instance with
+func (c *AppContainer) DiagnosticsService() *diagnostics.Service {
+	return c.diagnostics
+}
+
+// Leases returns the store of leases shared by every instance of the server
+func (c *AppContainer) Leases() *mongodb.LeaseStore {
+	return c.leases
+}
+
 // HealthCheck checks the health of all services
 func (c *AppContainer) HealthCheck(ctx context.Context) map[string]bool {
 	health := make(map[string]bool)
 
+	// Demo mode has no databases to check
+	if c.graph != nil {
+		health["memory_graph"] = c.graph.IsHealthy(ctx)
+		health["llm"] = c.llmClient.IsHealthy(ctx)
+		return health
+	}
+
 	// Check MongoDB
 	if c.mongoClient != nil {
 		health["mongodb"] = c.mongoClient.Ping(ctx) == nil
@@ -150,6 +680,13 @@ func (c *AppContainer) HealthCheck(ctx context.Context) map[string]bool {
 		health["neo4j"] = false
 	}
 
+	health["neo4j_schema"] = c.neo4jClient != nil && c.schemaErr == nil
+
+	// The SQL cache store is only reported when configured
+	if c.cacheDB != nil {
+		health["cache_db"] = c.cacheDB.Ping(ctx) == nil
+	}
+
 	// Check LLM
 	if c.llmClient != nil {
 		health["llm"] = c.llmClient.IsHealthy(ctx)
@@ -157,9 +694,33 @@ func (c *AppContainer) HealthCheck(ctx context.Context) map[string]bool {
 		health["llm"] = false
 	}
 
+	// Weaviate is optional, so it is only reported when configured
+	if c.weaviateClient != nil {
+		health["weaviate"] = c.weaviateClient.IsHealthy(ctx)
+	}
+
 	return health
 }
 
+// Readiness returns why the service should not receive traffic yet, or nil when it
+// is ready: the databases must be reachable and the graph schema migrated. In demo
+// mode it is always ready.
+func (c *AppContainer) Readiness(ctx context.Context) error {
+	if c.graph != nil {
+		return nil
+	}
+	if c.schemaErr != nil {
+		return fmt.Errorf("%w: %w", ErrMigrationsPending, c.schemaErr)
+	}
+	if c.mongoClient == nil || c.mongoClient.Ping(ctx) != nil {
+		return fmt.Errorf("%w: mongodb", ErrDependencyUnreachable)
+	}
+	if c.neo4jClient == nil || !c.neo4jClient.IsHealthy(ctx) {
+		return fmt.Errorf("%w: neo4j", ErrDependencyUnreachable)
+	}
+	return nil
+}
+
 // maskMongoURI masks sensitive information in MongoDB URIs for logging
 func maskMongoURI(uri string) string {
 	if strings.Contains(uri, "@") {
@@ -175,3 +736,25 @@ func maskMongoURI(uri string) string {
 	}
 	return uri
 }
+
+// useResourceProviders loads the resource provider plugins and hands the enabled
+// providers to the pathway service. Roadmaps are still served with whatever
+// providers opened, so failures are only logged.
+func (c *AppContainer) useResourceProviders() {
+	if dir := c.config.Resources.PluginDir; dir != "" {
+		if err := resources.LoadPlugins(dir, c.logger); err != nil {
+			c.logger.Error("Failed to load resource provider plugins", zap.Error(err))
+		}
+	}
+
+	providers, err := resources.Open(c.config.Resources.Providers, c.config.Resources.Settings, c.logger)
+	if err != nil {
+		c.logger.Error("Failed to open resource providers",
+			zap.Strings("registered", resources.Registered()),
+			zap.Error(err))
+	}
+	if len(providers) > 0 {
+		c.logger.Info("Resource providers enabled", zap.Int("providers", len(providers)))
+	}
+	c.pathwayService.UseResourceProviders(providers)
+}
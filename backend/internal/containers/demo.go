@@ -0,0 +1,62 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"github.com/mayura-andrew/fastfinder/internal/data/memstore"
+	"github.com/mayura-andrew/fastfinder/internal/seed"
+	"github.com/mayura-andrew/fastfinder/internal/services/discovery"
+	"github.com/mayura-andrew/fastfinder/internal/services/handout"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"github.com/mayura-andrew/fastfinder/internal/services/scraper"
+	"github.com/mayura-andrew/fastfinder/internal/services/seo"
+	"github.com/mayura-andrew/fastfinder/internal/services/typeahead"
+	"github.com/mayura-andrew/fastfinder/internal/services/ussd"
+	"go.uber.org/zap"
+)
+
+// initializeDemo wires the read-only API onto the latest bundled seed dataset
+// held in memory and a mock LLM, connecting to nothing. Services that need
+// MongoDB or write to the graph are left nil; their routes answer 503.
+func (c *AppContainer) initializeDemo() error {
+	c.logger.Warn("Demo mode: serving the bundled sample graph from memory with a mock LLM")
+
+	dataset, err := seed.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load demo dataset: %w", err)
+	}
+	c.graph = memstore.NewGraph(dataset)
+	c.logger.Info("In-memory graph loaded",
+		zap.String("dataset", dataset.Version),
+		zap.Int("institutes", len(dataset.Institutes)),
+		zap.Int("programs", len(dataset.Programs)))
+
+	c.llmClient = llm.NewMockClient(c.config.LLM)
+	c.youtubeService = scraper.NewYouTubeService(c.config.LLM.APIKey, c.logger)
+
+	// Without MongoDB roadmaps, job roles and browsing history are not cached, and
+	// without a real graph there is no listing snapshot to keep
+	c.pathwayService = pathway.NewService(c.graph, c.llmClient, c.youtubeService, nil, c.config.Neo4j.ListCacheTTL, "", c.logger)
+	c.useResourceProviders()
+
+	c.typeaheadService = typeahead.NewService(c.graph, c.logger)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := c.typeaheadService.Refresh(ctx); err != nil {
+		return fmt.Errorf("failed to build demo typeahead index: %w", err)
+	}
+
+	c.seoService = seo.NewService(c.graph, c.pathwayService, c.config.SEO, c.logger)
+	c.handoutService = handout.NewService(c.graph, c.pathwayService, c.seoService, c.logger)
+
+	// Without a vector store discovery reports itself unavailable
+	c.discoveryService = discovery.NewService(nil, nil, c.llmClient, c.logger)
+
+	c.ussdService = ussd.NewService(c.pathwayService, c.typeaheadService, c.discoveryService, c.smsSender, c.logger)
+
+	c.logger.Info("Demo services initialized successfully")
+	return nil
+}
@@ -2,20 +2,44 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	MongoDB  MongoDBConfig  `mapstructure:"mongodb"`
-	Neo4j    Neo4jConfig    `mapstructure:"neo4j"`
-	Weaviate WeaviateConfig `mapstructure:"weaviate"`
-	LLM      LLMConfig      `mapstructure:"llm"`
-	Scraper  ScraperConfig  `mapstructure:"scraper"`
-	Mailer   MailerConfig   `mapstructure:"mailer"`
-	Logging  LoggingConfig  `mapstructure:"logging"`
+	Server      ServerConfig      `mapstructure:"server"`
+	MongoDB     MongoDBConfig     `mapstructure:"mongodb"`
+	Cache       CacheConfig       `mapstructure:"cache"`
+	Neo4j       Neo4jConfig       `mapstructure:"neo4j"`
+	Weaviate    WeaviateConfig    `mapstructure:"weaviate"`
+	LLM         LLMConfig         `mapstructure:"llm"`
+	Scraper     ScraperConfig     `mapstructure:"scraper"`
+	Mailer      MailerConfig      `mapstructure:"mailer"`
+	SMS         SMSConfig         `mapstructure:"sms"`
+	USSD        USSDConfig        `mapstructure:"ussd"`
+	Logging     LoggingConfig     `mapstructure:"logging"`
+	Admin       AdminConfig       `mapstructure:"admin"`
+	TVEC        TVECConfig        `mapstructure:"tvec"`
+	Backup      BackupConfig      `mapstructure:"backup"`
+	Sheets      SheetsConfig      `mapstructure:"sheets"`
+	Moodle      MoodleConfig      `mapstructure:"moodle"`
+	Badges      BadgeConfig       `mapstructure:"badges"`
+	Calendar    CalendarConfig    `mapstructure:"calendar"`
+	SEO         SEOConfig         `mapstructure:"seo"`
+	APIKeys     APIKeysConfig     `mapstructure:"api_keys"`
+	Consent     ConsentConfig     `mapstructure:"consent"`
+	Experiments ExperimentsConfig `mapstructure:"experiments"`
+	Resources   ResourcesConfig   `mapstructure:"resources"`
+	JobBoard    JobBoardConfig    `mapstructure:"job_board"`
+	Demand      DemandConfig      `mapstructure:"demand"`
+	Drafts      DraftsConfig      `mapstructure:"drafts"`
+	Identity    IdentityConfig    `mapstructure:"identity"`
+	Webhooks    WebhookConfig     `mapstructure:"webhooks"`
+	Moderation  ModerationConfig  `mapstructure:"moderation"`
+	Abuse       AbuseConfig       `mapstructure:"abuse"`
 }
 
 type ServerConfig struct {
@@ -27,6 +51,29 @@ type ServerConfig struct {
 	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`
 	MaxBodySize  int64         `mapstructure:"max_body_size"`
 	RateLimit    int           `mapstructure:"rate_limit"` // requests per minute
+
+	// Size limits in bytes: MaxBodySize applies to requests on routes without
+	// their own limit, MaxUploadSize to file uploads, and MaxResponseSize to
+	// responses other than exports and downloads; 0 disables the response limit
+	MaxUploadSize   int64 `mapstructure:"max_upload_size"`
+	MaxResponseSize int64 `mapstructure:"max_response_size"`
+
+	// TrustedProxies are the proxy addresses or CIDRs whose X-Forwarded-For is
+	// believed; when empty every proxy is trusted, as gin does by default
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+
+	// How long browsers and CDNs may keep public reads; 0 disables caching
+	PublicCacheMaxAge  time.Duration `mapstructure:"public_cache_max_age"`  // institute, program and career listings
+	RoadmapCacheMaxAge time.Duration `mapstructure:"roadmap_cache_max_age"` // learning roadmaps
+
+	// JSONEncoder encodes listing, roadmap and streamed pathway responses: std,
+	// jsoniter, go-json, or sonic in builds with the sonic tag
+	JSONEncoder string `mapstructure:"json_encoder"`
+
+	// Demo serves the bundled sample graph from memory with canned LLM answers,
+	// without MongoDB, Neo4j or an LLM API key. Features that need a database
+	// answer 503.
+	Demo bool `mapstructure:"demo"`
 }
 
 type MongoDBConfig struct {
@@ -41,18 +88,38 @@ type MongoDBConfig struct {
 }
 
 type Neo4jConfig struct {
-	URI      string `mapstructure:"uri"`
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
-	Database string `mapstructure:"database"`
+	URI                      string        `mapstructure:"uri"`
+	Username                 string        `mapstructure:"username"`
+	Password                 string        `mapstructure:"password"`
+	Database                 string        `mapstructure:"database"`
+	AuthScheme               string        `mapstructure:"auth_scheme"`     // basic, bearer, kerberos or none
+	AuthRealm                string        `mapstructure:"auth_realm"`      // basic auth realm, for enterprise LDAP setups
+	AuthToken                string        `mapstructure:"auth_token"`      // base64 bearer token or Kerberos ticket
+	AuthTokenFile            string        `mapstructure:"auth_token_file"` // file with the token, read again when the server rejects it
+	TLSCAFile                string        `mapstructure:"tls_ca_file"`     // PEM CA bundle for clusters with a private CA
+	TLSCertFile              string        `mapstructure:"tls_cert_file"`   // client certificate for mutual TLS
+	TLSKeyFile               string        `mapstructure:"tls_key_file"`
+	LivenessCheckTimeout     time.Duration `mapstructure:"liveness_check_timeout"` // check pooled connections idle longer than this (0: off, or 2m on Aura)
+	MaxPoolSize              int           `mapstructure:"max_pool_size"`
+	MaxConnectionLifetime    time.Duration `mapstructure:"max_connection_lifetime"`
+	AcquisitionTimeout       time.Duration `mapstructure:"acquisition_timeout"` // wait for a free pooled connection
+	ConnectTimeout           time.Duration `mapstructure:"connect_timeout"`
+	VerifyTimeout            time.Duration `mapstructure:"verify_timeout"`             // connectivity check at startup
+	TypeaheadRefreshInterval time.Duration `mapstructure:"typeahead_refresh_interval"` // rebuild the in-memory name index from the graph
+	ListCacheTTL             time.Duration `mapstructure:"list_cache_ttl"`             // keep institute, career and program listings in memory (30s-300s, 0 disables)
+	ListCacheShared          string        `mapstructure:"list_cache_shared"`          // also share those listings between instances: mongodb, or empty for none
+	MaterializeInterval      time.Duration `mapstructure:"materialize_interval"`       // precompute department pathways into MongoDB and program accessibility in the graph
+	SnapshotInterval         time.Duration `mapstructure:"snapshot_interval"`          // copy core listings to serve as stale data while Neo4j is down
+	SnapshotPath             string        `mapstructure:"snapshot_path"`              // file the listing snapshot is kept in, empty for memory only
 }
 
 type WeaviateConfig struct {
-	Host      string            `mapstructure:"host"`
-	Scheme    string            `mapstructure:"scheme"`
-	Headers   map[string]string `mapstructure:"headers"`
-	APIKey    string            `mapstructure:"api_key"`
-	ClassName string            `mapstructure:"class_name"`
+	Host            string            `mapstructure:"host"`
+	Scheme          string            `mapstructure:"scheme"`
+	Headers         map[string]string `mapstructure:"headers"`
+	APIKey          string            `mapstructure:"api_key"`
+	ClassName       string            `mapstructure:"class_name"`
+	ReindexInterval time.Duration     `mapstructure:"reindex_interval"` // rebuild the discovery index from the graph
 }
 
 type LLMConfig struct {
@@ -63,6 +130,12 @@ type LLMConfig struct {
 	MaxTokens   int               `mapstructure:"max_tokens"`
 	Temperature float64           `mapstructure:"temperature"`
 	Headers     map[string]string `mapstructure:"headers"`
+	// HedgeModel is a faster model raced against Model for fast roadmaps; empty disables the race
+	HedgeModel string `mapstructure:"hedge_model"`
+	// Capture stores a sample of prompts and responses, scrubbed of personal
+	// details, for admins to review and label
+	Capture           bool    `mapstructure:"capture"`
+	CaptureSampleRate float64 `mapstructure:"capture_sample_rate"` // 0 to 1
 }
 
 type ScraperConfig struct {
@@ -72,14 +145,44 @@ type ScraperConfig struct {
 	Timeout       int    `mapstructure:"timeout"` // seconds
 }
 
+// SMSConfig configures outgoing text messages. While disabled, or with the sink
+// provider, messages are kept in memory for the admin outbox instead of sent.
+type SMSConfig struct {
+	Provider string        `mapstructure:"provider"` // africastalking or sink
+	Enabled  bool          `mapstructure:"enabled"`
+	Username string        `mapstructure:"username"`
+	APIKey   string        `mapstructure:"api_key"`
+	SenderID string        `mapstructure:"sender_id"` // registered alphanumeric sender, or empty for the shared shortcode
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// USSDConfig admits the USSD gateway relaying match sessions, by a secret it
+// sends in the X-Gateway-Key header or by its addresses. Without either the
+// match route is disabled, as it texts callers at our expense.
+type USSDConfig struct {
+	GatewaySecret string   `mapstructure:"gateway_secret"`
+	GatewayIPs    []string `mapstructure:"gateway_ips"` // addresses or CIDRs
+}
+
+// MailerConfig configures outgoing email. While disabled, or with the sink
+// provider, messages are kept in memory for the admin outbox instead of sent.
 type MailerConfig struct {
-	Host      string `mapstructure:"host"`
-	Port      int    `mapstructure:"port"`
-	Username  string `mapstructure:"username"`
-	Password  string `mapstructure:"password"`
-	Sender    string `mapstructure:"sender"`
-	AdminMail string `mapstructure:"admin_mail"`
-	Enabled   bool   `mapstructure:"enabled"`
+	Provider   string        `mapstructure:"provider"` // smtp, sendgrid, ses or sink
+	Host       string        `mapstructure:"host"`
+	Port       int           `mapstructure:"port"`
+	Username   string        `mapstructure:"username"`
+	Password   string        `mapstructure:"password"`
+	Sender     string        `mapstructure:"sender"`
+	SenderName string        `mapstructure:"sender_name"`
+	AdminMail  string        `mapstructure:"admin_mail"`
+	Enabled    bool          `mapstructure:"enabled"`
+	Timeout    time.Duration `mapstructure:"timeout"`
+
+	SendGridAPIKey string `mapstructure:"sendgrid_api_key"`
+
+	SESRegion    string `mapstructure:"ses_region"`
+	SESAccessKey string `mapstructure:"ses_access_key"`
+	SESSecretKey string `mapstructure:"ses_secret_key"`
 }
 
 type LoggingConfig struct {
@@ -88,6 +191,190 @@ type LoggingConfig struct {
 	OutputPath string `mapstructure:"output_path"`
 }
 
+type AdminConfig struct {
+	APIKey string `mapstructure:"api_key"` // admin endpoints are disabled when empty
+}
+
+// APIKeysConfig meters partner API keys. The default quotas apply to keys created
+// without one; zero leaves a measure unlimited.
+type APIKeysConfig struct {
+	UsageFlushInterval        time.Duration `mapstructure:"usage_flush_interval"`
+	DefaultMonthlyRequests    int64         `mapstructure:"default_monthly_requests"`
+	DefaultMonthlyGenerations int64         `mapstructure:"default_monthly_generations"`
+	DefaultMonthlyBytes       int64         `mapstructure:"default_monthly_bytes"`
+}
+
+// ConsentConfig names the current privacy policy version. Users accept it, with
+// a guardian's consent for minors, before profile data is stored for them;
+// changing it asks everyone to accept the new version.
+type ConsentConfig struct {
+	PolicyID string `mapstructure:"policy_id"`
+}
+
+// ExperimentsConfig names the A/B experiments to run. Their counts are written to
+// MongoDB on the flush interval.
+type ExperimentsConfig struct {
+	Running       []string      `mapstructure:"running"`
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+}
+
+// ResourcesConfig enables learning-resource providers that enrich roadmap steps.
+// Providers are registered by name in the binary or by Go plugins in PluginDir;
+// each one's settings are its RESOURCE_<NAME>_<KEY> variables.
+type ResourcesConfig struct {
+	Providers []string                     `mapstructure:"providers"`
+	PluginDir string                       `mapstructure:"plugin_dir"`
+	Settings  map[string]map[string]string `mapstructure:"settings"` // by provider, then lowercase key
+}
+
+type TVECConfig struct {
+	RegistryURL  string        `mapstructure:"registry_url"` // CSV or JSON export of registered courses
+	SyncEnabled  bool          `mapstructure:"sync_enabled"`
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+}
+
+// CacheConfig selects where generated learning roadmaps and job role details are
+// cached. MongoDB is the default; small deployments can keep them in SQLite or
+// Postgres instead. Only these caches move: MONGODB_URI is required either way.
+type CacheConfig struct {
+	Store string `mapstructure:"store"` // mongodb, sqlite (builds with the sqlite tag) or postgres
+	DSN   string `mapstructure:"dsn"`   // database file for sqlite, connection URL for postgres
+}
+
+type BackupConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Interval  time.Duration `mapstructure:"interval"`
+	Retention time.Duration `mapstructure:"retention"` // backups older than this are pruned
+	KeepMin   int           `mapstructure:"keep_min"`  // never prune below this many backups
+	Storage   string        `mapstructure:"storage"`   // local or s3 (GCS via its S3-compatible endpoint)
+	LocalDir  string        `mapstructure:"local_dir"`
+	Prefix    string        `mapstructure:"prefix"`
+	Endpoint  string        `mapstructure:"endpoint"`
+	Region    string        `mapstructure:"region"`
+	Bucket    string        `mapstructure:"bucket"`
+	AccessKey string        `mapstructure:"access_key"`
+	SecretKey string        `mapstructure:"secret_key"`
+}
+
+type SheetsConfig struct {
+	APIKey       string        `mapstructure:"api_key"` // without a key, sheets must be shared by link and are read via CSV export
+	Timeout      time.Duration `mapstructure:"timeout"`
+	SyncEnabled  bool          `mapstructure:"sync_enabled"` // sync every mapping on a schedule
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
+}
+
+// MoodleConfig configures roadmap exports to partner schools' Moodle sites. The
+// sites themselves are configured per partner through the admin API.
+type MoodleConfig struct {
+	Timeout time.Duration `mapstructure:"timeout"` // per web service call
+}
+
+// BadgeConfig configures the Open Badges issued for completed roadmaps. Badges
+// are disabled until both a signing key and the public URL are set.
+type BadgeConfig struct {
+	SigningKeyFile string `mapstructure:"signing_key_file"` // RSA private key, PEM encoded
+	PublicURL      string `mapstructure:"public_url"`       // base URL the API is reached at, for hosted badge metadata
+	IssuerName     string `mapstructure:"issuer_name"`
+	IssuerURL      string `mapstructure:"issuer_url"`
+	IssuerEmail    string `mapstructure:"issuer_email"`
+	ImageURL       string `mapstructure:"image_url"` // badge image shown for every program
+}
+
+// CalendarConfig configures pushing study schedules to students' Google
+// Calendars. The integration is disabled while the OAuth client ID is empty.
+type CalendarConfig struct {
+	GoogleClientID     string        `mapstructure:"google_client_id"`
+	GoogleClientSecret string        `mapstructure:"google_client_secret"`
+	GoogleRedirectURL  string        `mapstructure:"google_redirect_url"` // this API's /api/v1/calendar/google/callback
+	ReturnURL          string        `mapstructure:"return_url"`          // frontend page students are sent back to after connecting
+	Timeout            time.Duration `mapstructure:"timeout"`
+}
+
+// SEOConfig locates the frontend's public pages for the sitemap and structured
+// data, and to link the new programs feed to. The sitemap and feed are disabled
+// while the site URL is empty.
+type SEOConfig struct {
+	SiteURL     string `mapstructure:"site_url"`     // frontend origin, e.g. https://mathprereq.com
+	ProgramPath string `mapstructure:"program_path"` // {name} is replaced by the program name
+	CareerPath  string `mapstructure:"career_path"`  // {title} is replaced by the career title
+}
+
+type JobBoardConfig struct {
+	SearchURLs   []string      `mapstructure:"search_urls"` // keyword search page templates; {query} is replaced by the career title
+	SyncEnabled  bool          `mapstructure:"sync_enabled"`
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+	RequestDelay time.Duration `mapstructure:"request_delay"` // pause between requests to stay polite to the boards
+	MinSamples   int           `mapstructure:"min_samples"`   // salaries seen in fewer ads are not stored
+
+	VacancySyncEnabled  bool          `mapstructure:"vacancy_sync_enabled"`
+	VacancySyncInterval time.Duration `mapstructure:"vacancy_sync_interval"`
+	VacancyOpenWindow   time.Duration `mapstructure:"vacancy_open_window"` // postings seen within this window count as open
+	VacancyRetention    time.Duration `mapstructure:"vacancy_retention"`   // postings not seen for this long are deleted
+}
+
+// DemandConfig weighs the signals combined into each career's demand index,
+// recomputed every Interval: open vacancies, scoring full marks at
+// VacancySaturation postings, and the LLM's assessment of local demand. Weights
+// are relative; a career missing a signal is scored on the others.
+type DemandConfig struct {
+	Interval          time.Duration `mapstructure:"interval"`
+	VacancyWeight     float64       `mapstructure:"vacancy_weight"`
+	AssessmentWeight  float64       `mapstructure:"assessment_weight"`
+	VacancySaturation int           `mapstructure:"vacancy_saturation"`
+}
+
+// DraftsConfig signs the draft profiles the server issues to clients, so
+// multi-step wizards can carry a student's answers without an account. Without
+// a secret, a random one is used, and drafts do not survive a restart or work
+// across instances.
+type DraftsConfig struct {
+	Secret string        `mapstructure:"secret"`
+	TTL    time.Duration `mapstructure:"ttl"`
+}
+
+// IdentityConfig verifies the user tokens students sign in with. Secret is
+// shared with the frontend's server, which issues the tokens; without it every
+// caller is anonymous.
+type IdentityConfig struct {
+	Secret string `mapstructure:"secret"`
+}
+
+type WebhookConfig struct {
+	URLs        []string      `mapstructure:"urls"`   // endpoints notified of every batch of graph changes
+	Secret      string        `mapstructure:"secret"` // signs payloads with HMAC-SHA256 when set
+	Timeout     time.Duration `mapstructure:"timeout"`
+	MaxAttempts int           `mapstructure:"max_attempts"`
+}
+
+// ModerationConfig screens text users submit before it is stored. Words from
+// the built-in list, the wordlist files (one word or phrase per line) and
+// BlockedWords are matched whole; UseLLM also asks the model about text the
+// wordlists pass.
+type ModerationConfig struct {
+	Enabled       bool     `mapstructure:"enabled"`
+	WordlistFiles []string `mapstructure:"wordlist_files"`
+	BlockedWords  []string `mapstructure:"blocked_words"`
+	UseLLM        bool     `mapstructure:"use_llm"`
+}
+
+// AbuseConfig detects scripted use of the endpoints that call the LLM or scrape
+// videos. Within each Window, a client IP making more than BurstLimit of those
+// requests, a subnet (/24 or /64) more than SubnetLimit, or one user ID or
+// non-browser user agent coming from more than RotationLimit IPs is blocked from
+// them for BlockDuration. Requesting a honeypot path, which no page links to,
+// blocks the client at once.
+type AbuseConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Window        time.Duration `mapstructure:"window"`
+	BurstLimit    int           `mapstructure:"burst_limit"`
+	SubnetLimit   int           `mapstructure:"subnet_limit"`
+	RotationLimit int           `mapstructure:"rotation_limit"`
+	BlockDuration time.Duration `mapstructure:"block_duration"`
+	HoneypotPaths []string      `mapstructure:"honeypot_paths"`
+}
+
 // buildMongoDBURI constructs MongoDB connection string with authentication
 func buildMongoDBURI() string {
 	host := getEnvString("MONGODB_HOST", "localhost")
@@ -111,16 +398,38 @@ func buildMongoDBURI() string {
 	return fmt.Sprintf("mongodb://%s:%s", host, port)
 }
 
+// LoadConfig reads the configuration from environment variables and checks the
+// settings the server cannot start without
 func LoadConfig() (*Config, error) {
+	config := FromEnv()
+	if err := validateConfig(config); err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", err)
+	}
+
+	return config, nil
+}
+
+// FromEnv reads the configuration from environment variables without checking
+// it; see Validate
+func FromEnv() *Config {
 	// Configuration loaded from environment variables
 
-	// weaviateHeaders := make(map[string]string)
-	// weaviateHost := getEnvString("WEAVIATE_HOST", "")
+	// Weaviate is optional; semantic discovery is disabled without a host
+	weaviateHeaders := make(map[string]string)
+	weaviateHost := getEnvString("WEAVIATE_HOST", "")
+
+	// Add cluster URL header for Weaviate Cloud
+	if weaviateHost != "" && getEnvString("WEAVIATE_SCHEME", "https") == "https" {
+		weaviateHeaders["X-Weaviate-Cluster-Url"] = fmt.Sprintf("https://%s", weaviateHost)
+	}
+	// Settings of each enabled resource provider, from RESOURCE_<NAME>_<KEY>
+	resourceProviders := getEnvList("RESOURCE_PROVIDERS")
+	resourceSettings := make(map[string]map[string]string, len(resourceProviders))
+	for _, name := range resourceProviders {
+		prefix := "RESOURCE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		resourceSettings[name] = getEnvPrefixed(prefix)
+	}
 
-	// // Add cluster URL header for Weaviate Cloud
-	// if weaviateHost != "localhost:8080" && weaviateHost != "localhost" {
-	// 	weaviateHeaders["X-Weaviate-Cluster-Url"] = fmt.Sprintf("https://%s", weaviateHost)
-	// }
 	config := &Config{
 		Server: ServerConfig{
 			Environment:  getEnvString("ENVIRONMENT", "development"),
@@ -129,8 +438,17 @@ func LoadConfig() (*Config, error) {
 			ReadTimeout:  getEnvDuration("READ_TIMEOUT", "30s"),
 			WriteTimeout: getEnvDuration("WRITE_TIMEOUT", "30s"),
 			IdleTimeout:  getEnvDuration("IDLE_TIMEOUT", "120s"),
-			MaxBodySize:  getEnvInt64("MAX_BODY_SIZE", 10*1024*1024), // 10MB
-			RateLimit:    getEnvInt("RATE_LIMIT", 100),               // 100 requests per minute
+			MaxBodySize:  getEnvInt64("MAX_BODY_SIZE", 1024*1024), // 1MB
+			RateLimit:    getEnvInt("RATE_LIMIT", 100),            // 100 requests per minute
+
+			MaxUploadSize:   getEnvInt64("MAX_UPLOAD_SIZE", 20*1024*1024),  // 20MB
+			MaxResponseSize: getEnvInt64("MAX_RESPONSE_SIZE", 8*1024*1024), // 8MB
+			TrustedProxies:  getEnvList("TRUSTED_PROXIES"),
+
+			PublicCacheMaxAge:  getEnvDuration("PUBLIC_CACHE_MAX_AGE", "5m"),
+			RoadmapCacheMaxAge: getEnvDuration("ROADMAP_CACHE_MAX_AGE", "24h"),
+			JSONEncoder:        getEnvString("JSON_ENCODER", "std"),
+			Demo:               getEnvBool("DEMO_MODE", false),
 		},
 		MongoDB: MongoDBConfig{
 			URI:            buildMongoDBURI(),
@@ -147,14 +465,44 @@ func LoadConfig() (*Config, error) {
 			Username: getEnvString("NEO4J_USERNAME", "neo4j"),
 			Password: getEnvString("NEO4J_PASSWORD", "password123"),
 			Database: getEnvString("NEO4J_DATABASE", "neo4j"),
+
+			AuthScheme:    strings.ToLower(getEnvString("NEO4J_AUTH_SCHEME", "basic")),
+			AuthRealm:     getEnvString("NEO4J_AUTH_REALM", ""),
+			AuthToken:     getEnvString("NEO4J_AUTH_TOKEN", ""),
+			AuthTokenFile: getEnvString("NEO4J_AUTH_TOKEN_FILE", ""),
+			TLSCAFile:     getEnvString("NEO4J_TLS_CA_FILE", ""),
+			TLSCertFile:   getEnvString("NEO4J_TLS_CERT_FILE", ""),
+			TLSKeyFile:    getEnvString("NEO4J_TLS_KEY_FILE", ""),
+
+			MaxPoolSize:           getEnvInt("NEO4J_MAX_POOL_SIZE", 50),
+			MaxConnectionLifetime: getEnvDuration("NEO4J_MAX_CONNECTION_LIFETIME", "1h"),
+			AcquisitionTimeout:    getEnvDuration("NEO4J_ACQUISITION_TIMEOUT", "5s"),
+			ConnectTimeout:        getEnvDuration("NEO4J_CONNECT_TIMEOUT", "5s"),
+			VerifyTimeout:         getEnvDuration("NEO4J_VERIFY_TIMEOUT", "10s"),
+			LivenessCheckTimeout:  getEnvDuration("NEO4J_LIVENESS_CHECK_TIMEOUT", "0"),
+
+			TypeaheadRefreshInterval: getEnvDuration("TYPEAHEAD_REFRESH_INTERVAL", "15m"),
+			ListCacheTTL:             getEnvDuration("LIST_CACHE_TTL", "60s"),
+			ListCacheShared:          getEnvString("LIST_CACHE_SHARED", ""),
+			MaterializeInterval:      getEnvDuration("PATHWAY_MATERIALIZE_INTERVAL", "24h"),
+			SnapshotInterval:         getEnvDuration("LISTING_SNAPSHOT_INTERVAL", "30m"),
+			SnapshotPath:             getEnvString("LISTING_SNAPSHOT_PATH", "./data/listing-snapshot.json"),
+		},
+		Weaviate: WeaviateConfig{
+			Host:      weaviateHost,
+			Scheme:    getEnvString("WEAVIATE_SCHEME", "https"),
+			APIKey:    getEnvString("WEAVIATE_API_KEY", ""),
+			ClassName: getEnvString("WEAVIATE_CLASS_NAME", "MathChunk"),
+			Headers:   weaviateHeaders,
+
+			ReindexInterval: getEnvDuration("WEAVIATE_REINDEX_INTERVAL", "24h"),
+		},
+		LLM: LLMConfig{
+			HedgeModel: getEnvString("LLM_HEDGE_MODEL", ""),
+
+			Capture:           getEnvBool("LLM_CAPTURE_ENABLED", false),
+			CaptureSampleRate: getEnvFloat64("LLM_CAPTURE_SAMPLE_RATE", 1),
 		},
-		// Weaviate: WeaviateConfig{
-		// 	Host:      weaviateHost,
-		// 	Scheme:    getEnvString("WEAVIATE_SCHEME", "https"),
-		// 	APIKey:    getEnvString("WEAVIATE_API_KEY", ""),
-		// 	ClassName: getEnvString("WEAVIATE_CLASS_NAME", "MathChunk"),
-		// 	Headers:   weaviateHeaders,
-		// },
 		// LLM: LLMConfig{
 		// 	Provider:    getEnvString("LLM_PROVIDER", "gemini"),
 		// 	APIKey:      getEnvString("LLM_API_KEY", ""),
@@ -178,28 +526,192 @@ func LoadConfig() (*Config, error) {
 			Sender:    getEnvString("MAILER_SENDER", "noreply@mathprereq.com"),
 			AdminMail: getEnvString("MAILER_ADMIN_MAIL", "admin@mathprereq.com"),
 			Enabled:   getEnvBool("MAILER_ENABLED", false),
+
+			Provider:   getEnvString("MAILER_PROVIDER", "smtp"),
+			SenderName: getEnvString("MAILER_SENDER_NAME", "PathwayLK"),
+			Timeout:    getEnvDuration("MAILER_TIMEOUT", "30s"),
+
+			SendGridAPIKey: getEnvString("MAILER_SENDGRID_API_KEY", ""),
+
+			SESRegion:    getEnvString("MAILER_SES_REGION", "us-east-1"),
+			SESAccessKey: getEnvString("MAILER_SES_ACCESS_KEY", ""),
+			SESSecretKey: getEnvString("MAILER_SES_SECRET_KEY", ""),
+		},
+		SMS: SMSConfig{
+			Provider: getEnvString("SMS_PROVIDER", "africastalking"),
+			Enabled:  getEnvBool("SMS_ENABLED", false),
+			Username: getEnvString("SMS_USERNAME", ""),
+			APIKey:   getEnvString("SMS_API_KEY", ""),
+			SenderID: getEnvString("SMS_SENDER_ID", ""),
+			Timeout:  getEnvDuration("SMS_TIMEOUT", "15s"),
+		},
+		USSD: USSDConfig{
+			GatewaySecret: getEnvString("USSD_GATEWAY_SECRET", ""),
+			GatewayIPs:    getEnvList("USSD_GATEWAY_IPS"),
 		},
 		Logging: LoggingConfig{
 			Level:      getEnvString("LOG_LEVEL", "info"),
 			Format:     getEnvString("LOG_FORMAT", "json"),
 			OutputPath: getEnvString("LOG_OUTPUT_PATH", "stdout"),
 		},
-	}
+		Admin: AdminConfig{
+			APIKey: getEnvString("ADMIN_API_KEY", ""),
+		},
+		APIKeys: APIKeysConfig{
+			UsageFlushInterval:        getEnvDuration("API_USAGE_FLUSH_INTERVAL", "30s"),
+			DefaultMonthlyRequests:    getEnvInt64("API_KEY_MONTHLY_REQUESTS", 0),
+			DefaultMonthlyGenerations: getEnvInt64("API_KEY_MONTHLY_GENERATIONS", 0),
+			DefaultMonthlyBytes:       getEnvInt64("API_KEY_MONTHLY_BYTES", 0),
+		},
+		Consent: ConsentConfig{
+			PolicyID: getEnvString("PRIVACY_POLICY_ID", "2025-01"),
+		},
+		Experiments: ExperimentsConfig{
+			Running:       getEnvList("EXPERIMENTS"),
+			FlushInterval: getEnvDuration("EXPERIMENT_FLUSH_INTERVAL", "30s"),
+		},
+		Resources: ResourcesConfig{
+			Providers: resourceProviders,
+			PluginDir: getEnvString("RESOURCE_PLUGIN_DIR", ""),
+			Settings:  resourceSettings,
+		},
+		TVEC: TVECConfig{
+			RegistryURL:  getEnvString("TVEC_REGISTRY_URL", ""),
+			SyncEnabled:  getEnvBool("TVEC_SYNC_ENABLED", false),
+			SyncInterval: getEnvDuration("TVEC_SYNC_INTERVAL", "24h"),
+			Timeout:      getEnvDuration("TVEC_TIMEOUT", "60s"),
+		},
+		Cache: CacheConfig{
+			Store: getEnvString("CACHE_STORE", "mongodb"),
+			DSN:   getEnvString("CACHE_DSN", ""),
+		},
+		Backup: BackupConfig{
+			Enabled:   getEnvBool("BACKUP_ENABLED", false),
+			Interval:  getEnvDuration("BACKUP_INTERVAL", "24h"),
+			Retention: getEnvDuration("BACKUP_RETENTION", "720h"), // 30 days
+			KeepMin:   getEnvInt("BACKUP_KEEP_MIN", 3),
+			Storage:   getEnvString("BACKUP_STORAGE", "local"),
+			LocalDir:  getEnvString("BACKUP_LOCAL_DIR", "./backups"),
+			Prefix:    getEnvString("BACKUP_PREFIX", "backups/"),
+			Endpoint:  getEnvString("BACKUP_S3_ENDPOINT", ""),
+			Region:    getEnvString("BACKUP_S3_REGION", "us-east-1"),
+			Bucket:    getEnvString("BACKUP_S3_BUCKET", ""),
+			AccessKey: getEnvString("BACKUP_S3_ACCESS_KEY", ""),
+			SecretKey: getEnvString("BACKUP_S3_SECRET_KEY", ""),
+		},
+		Sheets: SheetsConfig{
+			APIKey:       getEnvString("GOOGLE_SHEETS_API_KEY", getEnvString("GOOGLE_API_KEY", "")),
+			Timeout:      getEnvDuration("GOOGLE_SHEETS_TIMEOUT", "30s"),
+			SyncEnabled:  getEnvBool("GOOGLE_SHEETS_SYNC_ENABLED", false),
+			SyncInterval: getEnvDuration("GOOGLE_SHEETS_SYNC_INTERVAL", "24h"),
+		},
+		Moodle: MoodleConfig{
+			Timeout: getEnvDuration("MOODLE_TIMEOUT", "30s"),
+		},
+		SEO: SEOConfig{
+			SiteURL:     strings.TrimRight(getEnvString("SEO_SITE_URL", ""), "/"),
+			ProgramPath: getEnvString("SEO_PROGRAM_PATH", "/programs/{name}"),
+			CareerPath:  getEnvString("SEO_CAREER_PATH", "/careers/{title}"),
+		},
+		Calendar: CalendarConfig{
+			GoogleClientID:     getEnvString("GOOGLE_CALENDAR_CLIENT_ID", ""),
+			GoogleClientSecret: getEnvString("GOOGLE_CALENDAR_CLIENT_SECRET", ""),
+			GoogleRedirectURL:  getEnvString("GOOGLE_CALENDAR_REDIRECT_URL", ""),
+			ReturnURL:          getEnvString("GOOGLE_CALENDAR_RETURN_URL", ""),
+			Timeout:            getEnvDuration("GOOGLE_CALENDAR_TIMEOUT", "30s"),
+		},
+		Badges: BadgeConfig{
+			SigningKeyFile: getEnvString("BADGE_SIGNING_KEY_FILE", ""),
+			PublicURL:      strings.TrimRight(getEnvString("BADGE_PUBLIC_URL", ""), "/"),
+			IssuerName:     getEnvString("BADGE_ISSUER_NAME", "PathwayLK"),
+			IssuerURL:      getEnvString("BADGE_ISSUER_URL", ""),
+			IssuerEmail:    getEnvString("BADGE_ISSUER_EMAIL", ""),
+			ImageURL:       getEnvString("BADGE_IMAGE_URL", ""),
+		},
+		JobBoard: JobBoardConfig{
+			SearchURLs:   getEnvList("JOB_BOARD_SEARCH_URLS"),
+			SyncEnabled:  getEnvBool("JOB_BOARD_SYNC_ENABLED", false),
+			SyncInterval: getEnvDuration("JOB_BOARD_SYNC_INTERVAL", "168h"),
+			Timeout:      getEnvDuration("JOB_BOARD_TIMEOUT", "30s"),
+			RequestDelay: getEnvDuration("JOB_BOARD_REQUEST_DELAY", "2s"),
+			MinSamples:   getEnvInt("JOB_BOARD_MIN_SAMPLES", 3),
 
-	if err := validateConfig(config); err != nil {
-		return nil, fmt.Errorf("config validation failed: %w", err)
+			VacancySyncEnabled:  getEnvBool("VACANCY_SYNC_ENABLED", false),
+			VacancySyncInterval: getEnvDuration("VACANCY_SYNC_INTERVAL", "24h"),
+			VacancyOpenWindow:   getEnvDuration("VACANCY_OPEN_WINDOW", "72h"),
+			VacancyRetention:    getEnvDuration("VACANCY_RETENTION", "2160h"),
+		},
+		Demand: DemandConfig{
+			Interval:          getEnvDuration("DEMAND_INDEX_INTERVAL", "6h"),
+			VacancyWeight:     getEnvFloat64("DEMAND_VACANCY_WEIGHT", 0.6),
+			AssessmentWeight:  getEnvFloat64("DEMAND_ASSESSMENT_WEIGHT", 0.4),
+			VacancySaturation: getEnvInt("DEMAND_VACANCY_SATURATION", 50),
+		},
+		Drafts: DraftsConfig{
+			Secret: getEnvString("DRAFT_PROFILE_SECRET", ""),
+			TTL:    getEnvDuration("DRAFT_PROFILE_TTL", "24h"),
+		},
+		Identity: IdentityConfig{
+			Secret: getEnvString("USER_TOKEN_SECRET", ""),
+		},
+		Webhooks: WebhookConfig{
+			URLs:        getEnvList("CHANGE_WEBHOOK_URLS"),
+			Secret:      getEnvString("CHANGE_WEBHOOK_SECRET", ""),
+			Timeout:     getEnvDuration("CHANGE_WEBHOOK_TIMEOUT", "10s"),
+			MaxAttempts: getEnvInt("CHANGE_WEBHOOK_MAX_ATTEMPTS", 3),
+		},
+		Moderation: ModerationConfig{
+			Enabled:       getEnvBool("MODERATION_ENABLED", true),
+			WordlistFiles: getEnvList("MODERATION_WORDLIST_FILES"),
+			BlockedWords:  getEnvList("MODERATION_BLOCKED_WORDS"),
+			UseLLM:        getEnvBool("MODERATION_LLM_ENABLED", false),
+		},
+		Abuse: AbuseConfig{
+			Enabled:       getEnvBool("ABUSE_DETECTION_ENABLED", true),
+			Window:        getEnvDuration("ABUSE_WINDOW", "1m"),
+			BurstLimit:    getEnvInt("ABUSE_BURST_LIMIT", 20),
+			SubnetLimit:   getEnvInt("ABUSE_SUBNET_LIMIT", 60),
+			RotationLimit: getEnvInt("ABUSE_ROTATION_LIMIT", 5),
+			BlockDuration: getEnvDuration("ABUSE_BLOCK_DURATION", "1h"),
+			HoneypotPaths: getEnvList("ABUSE_HONEYPOT_PATHS"),
+		},
 	}
 
-	return config, nil
+	return config
 }
 
 func validateConfig(cfg *Config) error {
 	if cfg.MongoDB.URI == "" {
-		return fmt.Errorf("MONGODB_URI is required")
+		return fmt.Errorf("MONGODB_URI is required, whatever CACHE_STORE is")
 	}
 	if cfg.Neo4j.URI == "" {
 		return fmt.Errorf("NEO4J_URI is required")
 	}
+	if cfg.Neo4j.MaxPoolSize <= 0 {
+		return fmt.Errorf("invalid NEO4J_MAX_POOL_SIZE: %d", cfg.Neo4j.MaxPoolSize)
+	}
+	if cfg.Neo4j.VerifyTimeout <= 0 {
+		return fmt.Errorf("NEO4J_VERIFY_TIMEOUT must be positive")
+	}
+	if shared := cfg.Neo4j.ListCacheShared; shared != "" && shared != "mongodb" {
+		return fmt.Errorf("invalid LIST_CACHE_SHARED: %q (use mongodb, or leave empty)", shared)
+	}
+	if cfg.Demand.VacancyWeight < 0 || cfg.Demand.AssessmentWeight < 0 || cfg.Demand.VacancyWeight+cfg.Demand.AssessmentWeight <= 0 {
+		return fmt.Errorf("DEMAND_VACANCY_WEIGHT and DEMAND_ASSESSMENT_WEIGHT must not be negative, and one must be positive")
+	}
+	if cfg.Demand.VacancySaturation <= 0 {
+		return fmt.Errorf("invalid DEMAND_VACANCY_SATURATION: %d", cfg.Demand.VacancySaturation)
+	}
+	if cfg.Drafts.TTL <= 0 {
+		return fmt.Errorf("invalid DRAFT_PROFILE_TTL: %s", cfg.Drafts.TTL)
+	}
+	for _, address := range cfg.USSD.GatewayIPs {
+		if _, err := netip.ParsePrefix(address); err != nil {
+			if _, err := netip.ParseAddr(address); err != nil {
+				return fmt.Errorf("invalid USSD_GATEWAY_IPS entry: %q", address)
+			}
+		}
+	}
 	// if cfg.Weaviate.Host == "" {
 	// 	return fmt.Errorf("WEAVIATE_HOST is required")
 	// }
@@ -217,6 +729,30 @@ func getEnvString(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvList splits a comma-separated variable, dropping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvPrefixed returns the variables starting with prefix, keyed by the rest
+// of their name in lowercase
+func getEnvPrefixed(prefix string) map[string]string {
+	values := make(map[string]string)
+	for _, entry := range os.Environ() {
+		key, value, _ := strings.Cut(entry, "=")
+		if rest, ok := strings.CutPrefix(key, prefix); ok && rest != "" {
+			values[strings.ToLower(rest)] = value
+		}
+	}
+	return values
+}
+
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if parsed, err := strconv.Atoi(value); err == nil {
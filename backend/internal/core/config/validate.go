@@ -0,0 +1,351 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/experiment"
+)
+
+// Severity of a configuration problem
+const (
+	SeverityError   = "error"   // the server will not start or a feature cannot work
+	SeverityWarning = "warning" // the server runs, but probably not as intended
+)
+
+// Problem is one misconfigured setting, named by its environment variable
+type Problem struct {
+	Severity string `json:"severity"`
+	Env      string `json:"env"`
+	Message  string `json:"message"`
+}
+
+var (
+	mongoSchemes = []string{"mongodb", "mongodb+srv"}
+	neo4jSchemes = []string{"neo4j", "neo4j+s", "neo4j+ssc", "bolt", "bolt+s", "bolt+ssc"}
+	httpSchemes  = []string{"http", "https"}
+)
+
+// Validate checks the whole configuration and returns every problem found,
+// unlike LoadConfig, which stops at the first setting the server cannot start
+// with. It does not connect to anything.
+func Validate(cfg *Config) []Problem {
+	var problems []Problem
+	errorf := func(env, format string, args ...any) {
+		problems = append(problems, Problem{Severity: SeverityError, Env: env, Message: fmt.Sprintf(format, args...)})
+	}
+	warnf := func(env, format string, args ...any) {
+		problems = append(problems, Problem{Severity: SeverityWarning, Env: env, Message: fmt.Sprintf(format, args...)})
+	}
+	requireURI := func(env, value string, schemes []string, required bool) {
+		if value == "" {
+			if required {
+				errorf(env, "is required")
+			}
+			return
+		}
+		if err := validURI(value, schemes); err != nil {
+			errorf(env, "%v", err)
+		}
+	}
+
+	// Server
+	if cfg.Server.Port <= 0 || cfg.Server.Port > 65535 {
+		errorf("PORT", "must be between 1 and 65535, got %d", cfg.Server.Port)
+	}
+	if !slices.Contains([]string{"development", "staging", "production"}, cfg.Server.Environment) {
+		warnf("ENVIRONMENT", "unknown environment %q; expected development, staging or production", cfg.Server.Environment)
+	}
+	if cfg.Server.RateLimit <= 0 {
+		warnf("RATE_LIMIT", "must be positive, got %d", cfg.Server.RateLimit)
+	}
+	if cfg.Server.MaxBodySize <= 0 {
+		errorf("MAX_BODY_SIZE", "must be positive, got %d", cfg.Server.MaxBodySize)
+	}
+	if cfg.Server.MaxUploadSize <= 0 {
+		errorf("MAX_UPLOAD_SIZE", "must be positive, got %d", cfg.Server.MaxUploadSize)
+	}
+	if cfg.Server.MaxResponseSize < 0 {
+		errorf("MAX_RESPONSE_SIZE", "must not be negative, got %d", cfg.Server.MaxResponseSize)
+	}
+
+	// MongoDB
+	requireURI("MONGODB_URI", cfg.MongoDB.URI, mongoSchemes, true)
+	if cfg.MongoDB.Database == "" {
+		errorf("MONGODB_DATABASE", "is required")
+	}
+	if cfg.MongoDB.MinPoolSize > cfg.MongoDB.MaxPoolSize {
+		warnf("MONGODB_MIN_POOL_SIZE", "is larger than MONGODB_MAX_POOL_SIZE (%d > %d)", cfg.MongoDB.MinPoolSize, cfg.MongoDB.MaxPoolSize)
+	}
+
+	// Cache store
+	switch cfg.Cache.Store {
+	case "mongodb":
+	case "sqlite", "postgres":
+		if cfg.Cache.DSN == "" {
+			errorf("CACHE_DSN", "is required for the %s cache store", cfg.Cache.Store)
+		}
+	default:
+		errorf("CACHE_STORE", "must be mongodb, sqlite or postgres, got %q", cfg.Cache.Store)
+	}
+
+	// Neo4j
+	requireURI("NEO4J_URI", cfg.Neo4j.URI, neo4jSchemes, true)
+	switch cfg.Neo4j.AuthScheme {
+	case "basic":
+		if cfg.Neo4j.Username == "" {
+			errorf("NEO4J_USERNAME", "is required")
+		}
+	case "bearer", "kerberos":
+		if cfg.Neo4j.AuthToken == "" && cfg.Neo4j.AuthTokenFile == "" {
+			errorf("NEO4J_AUTH_TOKEN", "or NEO4J_AUTH_TOKEN_FILE is required for %s auth", cfg.Neo4j.AuthScheme)
+		}
+		if cfg.Neo4j.AuthTokenFile != "" {
+			if _, err := os.Stat(cfg.Neo4j.AuthTokenFile); err != nil {
+				errorf("NEO4J_AUTH_TOKEN_FILE", "cannot be read: %v", err)
+			}
+		}
+	case "none":
+	default:
+		errorf("NEO4J_AUTH_SCHEME", "must be basic, bearer, kerberos or none, got %q", cfg.Neo4j.AuthScheme)
+	}
+	if neo4jURI, err := url.Parse(cfg.Neo4j.URI); err == nil {
+		encrypted := strings.HasSuffix(neo4jURI.Scheme, "+s") || strings.HasSuffix(neo4jURI.Scheme, "+ssc")
+		if strings.HasSuffix(neo4jURI.Hostname(), ".databases.neo4j.io") && !encrypted {
+			errorf("NEO4J_URI", "must use the neo4j+s scheme for Aura, which only accepts encrypted connections")
+		}
+		if !encrypted && (cfg.Neo4j.TLSCAFile != "" || cfg.Neo4j.TLSCertFile != "") {
+			warnf("NEO4J_URI", "is not encrypted (+s or +ssc scheme); the TLS files are not used")
+		}
+	}
+	if cfg.Neo4j.TLSCAFile != "" {
+		if _, err := os.Stat(cfg.Neo4j.TLSCAFile); err != nil {
+			errorf("NEO4J_TLS_CA_FILE", "cannot be read: %v", err)
+		}
+	}
+	if (cfg.Neo4j.TLSCertFile == "") != (cfg.Neo4j.TLSKeyFile == "") {
+		errorf("NEO4J_TLS_CERT_FILE", "and NEO4J_TLS_KEY_FILE must be set together")
+	}
+	if cfg.Neo4j.LivenessCheckTimeout < 0 {
+		errorf("NEO4J_LIVENESS_CHECK_TIMEOUT", "must not be negative")
+	}
+	if cfg.Neo4j.MaxPoolSize <= 0 {
+		errorf("NEO4J_MAX_POOL_SIZE", "must be positive, got %d", cfg.Neo4j.MaxPoolSize)
+	}
+	if cfg.Neo4j.VerifyTimeout <= 0 {
+		errorf("NEO4J_VERIFY_TIMEOUT", "must be positive")
+	}
+	if cfg.Neo4j.ListCacheTTL != 0 && (cfg.Neo4j.ListCacheTTL < 30*time.Second || cfg.Neo4j.ListCacheTTL > 300*time.Second) {
+		warnf("LIST_CACHE_TTL", "should be between 30s and 300s, or 0 to disable, got %s", cfg.Neo4j.ListCacheTTL)
+	}
+
+	// Default credentials are fine locally but not in production
+	if cfg.Server.Environment == "production" {
+		if strings.Contains(cfg.MongoDB.URI, ":password123@") {
+			warnf("MONGODB_PASSWORD", "is the development default")
+		}
+		if cfg.Neo4j.Password == "password123" {
+			warnf("NEO4J_PASSWORD", "is the development default")
+		}
+		if cfg.Admin.APIKey == "" {
+			warnf("ADMIN_API_KEY", "is not set; admin endpoints are disabled")
+		}
+	}
+	if cfg.Admin.APIKey != "" && len(cfg.Admin.APIKey) < 16 {
+		warnf("ADMIN_API_KEY", "is shorter than 16 characters")
+	}
+
+	// Weaviate is optional
+	if cfg.Weaviate.Host != "" {
+		if strings.Contains(cfg.Weaviate.Host, "://") {
+			errorf("WEAVIATE_HOST", "must be a host name without a scheme; set WEAVIATE_SCHEME instead")
+		}
+		if !slices.Contains(httpSchemes, cfg.Weaviate.Scheme) {
+			errorf("WEAVIATE_SCHEME", "must be http or https, got %q", cfg.Weaviate.Scheme)
+		}
+	}
+
+	// Logging
+	if !slices.Contains([]string{"debug", "info", "warn", "warning", "error"}, strings.ToLower(cfg.Logging.Level)) {
+		warnf("LOG_LEVEL", "unknown level %q; info is used", cfg.Logging.Level)
+	}
+	if !slices.Contains([]string{"json", "console"}, strings.ToLower(cfg.Logging.Format)) {
+		warnf("LOG_FORMAT", "unknown format %q; json is used", cfg.Logging.Format)
+	}
+
+	// Mailer
+	if cfg.Mailer.Enabled {
+		switch cfg.Mailer.Provider {
+		case "smtp":
+			if cfg.Mailer.Host == "" {
+				errorf("MAILER_HOST", "is required when MAILER_ENABLED is set")
+			}
+		case "sendgrid":
+			if cfg.Mailer.SendGridAPIKey == "" {
+				errorf("MAILER_SENDGRID_API_KEY", "is required for the sendgrid mail provider")
+			}
+		case "ses":
+			if cfg.Mailer.SESAccessKey == "" || cfg.Mailer.SESSecretKey == "" {
+				errorf("MAILER_SES_ACCESS_KEY", "and MAILER_SES_SECRET_KEY are required for the ses mail provider")
+			}
+		case "sink":
+		default:
+			errorf("MAILER_PROVIDER", "must be smtp, sendgrid, ses or sink, got %q", cfg.Mailer.Provider)
+		}
+		if cfg.Mailer.Sender == "" {
+			errorf("MAILER_SENDER", "is required when MAILER_ENABLED is set")
+		}
+	}
+
+	// Partner API keys
+	if cfg.APIKeys.UsageFlushInterval <= 0 {
+		errorf("API_USAGE_FLUSH_INTERVAL", "must be positive")
+	}
+	if cfg.APIKeys.DefaultMonthlyRequests < 0 {
+		errorf("API_KEY_MONTHLY_REQUESTS", "must not be negative, got %d", cfg.APIKeys.DefaultMonthlyRequests)
+	}
+	if cfg.APIKeys.DefaultMonthlyGenerations < 0 {
+		errorf("API_KEY_MONTHLY_GENERATIONS", "must not be negative, got %d", cfg.APIKeys.DefaultMonthlyGenerations)
+	}
+	if cfg.APIKeys.DefaultMonthlyBytes < 0 {
+		errorf("API_KEY_MONTHLY_BYTES", "must not be negative, got %d", cfg.APIKeys.DefaultMonthlyBytes)
+	}
+
+	// Abuse detection
+	if cfg.Abuse.Enabled {
+		if cfg.Abuse.Window <= 0 {
+			errorf("ABUSE_WINDOW", "must be positive")
+		}
+		if cfg.Abuse.BlockDuration <= 0 {
+			errorf("ABUSE_BLOCK_DURATION", "must be positive")
+		}
+		if cfg.Abuse.BurstLimit <= 0 {
+			errorf("ABUSE_BURST_LIMIT", "must be positive, got %d", cfg.Abuse.BurstLimit)
+		}
+		if cfg.Abuse.SubnetLimit < cfg.Abuse.BurstLimit {
+			warnf("ABUSE_SUBNET_LIMIT", "is below ABUSE_BURST_LIMIT, so single clients are blocked by subnet first")
+		}
+		if cfg.Abuse.RotationLimit < 2 {
+			errorf("ABUSE_ROTATION_LIMIT", "must be at least 2, got %d", cfg.Abuse.RotationLimit)
+		}
+		for _, path := range cfg.Abuse.HoneypotPaths {
+			if !strings.HasPrefix(path, "/") {
+				errorf("ABUSE_HONEYPOT_PATHS", "path %q must start with /", path)
+			}
+		}
+	}
+
+	// Consent
+	if strings.TrimSpace(cfg.Consent.PolicyID) == "" {
+		errorf("PRIVACY_POLICY_ID", "is required")
+	}
+
+	// Experiments
+	for _, name := range cfg.Experiments.Running {
+		if _, ok := experiment.Lookup(name); !ok {
+			errorf("EXPERIMENTS", "unknown experiment %q", name)
+		}
+	}
+	if cfg.Experiments.FlushInterval <= 0 {
+		errorf("EXPERIMENT_FLUSH_INTERVAL", "must be positive")
+	}
+
+	// Learning-resource providers; unknown names are only found once plugins load
+	if cfg.Resources.PluginDir != "" {
+		if info, err := os.Stat(cfg.Resources.PluginDir); err != nil || !info.IsDir() {
+			errorf("RESOURCE_PLUGIN_DIR", "is not a readable directory")
+		}
+	}
+
+	// Data pipelines
+	requireURI("TVEC_REGISTRY_URL", cfg.TVEC.RegistryURL, httpSchemes, cfg.TVEC.SyncEnabled)
+	for _, search := range cfg.JobBoard.SearchURLs {
+		requireURI("JOB_BOARD_SEARCH_URLS", search, httpSchemes, false)
+		if !strings.Contains(search, "{query}") {
+			errorf("JOB_BOARD_SEARCH_URLS", "%s has no {query} placeholder", search)
+		}
+	}
+	if (cfg.JobBoard.SyncEnabled || cfg.JobBoard.VacancySyncEnabled) && len(cfg.JobBoard.SearchURLs) == 0 {
+		errorf("JOB_BOARD_SEARCH_URLS", "is required when job board or vacancy sync is enabled")
+	}
+	for _, hook := range cfg.Webhooks.URLs {
+		requireURI("CHANGE_WEBHOOK_URLS", hook, httpSchemes, false)
+	}
+
+	// Open Badges for completed roadmaps
+	if cfg.Badges.SigningKeyFile != "" || cfg.Badges.PublicURL != "" {
+		if cfg.Badges.SigningKeyFile == "" {
+			warnf("BADGE_SIGNING_KEY_FILE", "is required with BADGE_PUBLIC_URL; badges are disabled")
+		} else if _, err := os.Stat(cfg.Badges.SigningKeyFile); err != nil {
+			errorf("BADGE_SIGNING_KEY_FILE", "cannot be read: %v", err)
+		}
+		if cfg.Badges.PublicURL == "" {
+			warnf("BADGE_PUBLIC_URL", "is required with BADGE_SIGNING_KEY_FILE; badges are disabled")
+		}
+		requireURI("BADGE_PUBLIC_URL", cfg.Badges.PublicURL, httpSchemes, false)
+		requireURI("BADGE_ISSUER_URL", cfg.Badges.IssuerURL, httpSchemes, false)
+		requireURI("BADGE_IMAGE_URL", cfg.Badges.ImageURL, httpSchemes, false)
+	}
+
+	// SEO
+	requireURI("SEO_SITE_URL", cfg.SEO.SiteURL, httpSchemes, false)
+	if !strings.Contains(cfg.SEO.ProgramPath, "{name}") {
+		errorf("SEO_PROGRAM_PATH", "has no {name} placeholder")
+	}
+	if !strings.Contains(cfg.SEO.CareerPath, "{title}") {
+		errorf("SEO_CAREER_PATH", "has no {title} placeholder")
+	}
+
+	// Google Calendar sync
+	if cfg.Calendar.GoogleClientID != "" {
+		if cfg.Calendar.GoogleClientSecret == "" {
+			errorf("GOOGLE_CALENDAR_CLIENT_SECRET", "is required with GOOGLE_CALENDAR_CLIENT_ID")
+		}
+		requireURI("GOOGLE_CALENDAR_REDIRECT_URL", cfg.Calendar.GoogleRedirectURL, httpSchemes, true)
+		if cfg.Calendar.GoogleRedirectURL != "" && !strings.HasSuffix(cfg.Calendar.GoogleRedirectURL, "/api/v1/calendar/google/callback") {
+			warnf("GOOGLE_CALENDAR_REDIRECT_URL", "should end in /api/v1/calendar/google/callback")
+		}
+		requireURI("GOOGLE_CALENDAR_RETURN_URL", cfg.Calendar.ReturnURL, httpSchemes, false)
+	}
+
+	// Backups
+	if cfg.Backup.Enabled {
+		switch cfg.Backup.Storage {
+		case "local":
+			if cfg.Backup.LocalDir == "" {
+				errorf("BACKUP_LOCAL_DIR", "is required for local backups")
+			}
+		case "s3":
+			if cfg.Backup.Bucket == "" {
+				errorf("BACKUP_S3_BUCKET", "is required for s3 backups")
+			}
+			requireURI("BACKUP_S3_ENDPOINT", cfg.Backup.Endpoint, httpSchemes, false)
+		default:
+			errorf("BACKUP_STORAGE", "must be local or s3, got %q", cfg.Backup.Storage)
+		}
+	}
+
+	return problems
+}
+
+// validURI checks that value is an absolute URI with one of the schemes and a
+// host
+func validURI(value string, schemes []string) error {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		// The parse error repeats the URI, which may hold a password
+		return errors.New("is not a valid URI")
+	}
+	if !slices.Contains(schemes, parsed.Scheme) {
+		return fmt.Errorf("has scheme %q; expected %s", parsed.Scheme, strings.Join(schemes, ", "))
+	}
+	if parsed.Host == "" {
+		return errors.New("has no host")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package district names the administrative districts of Sri Lanka, which place
+// students, institutes and z-score cutoffs.
+package district
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Names are the 25 districts
+var Names = []string{
+	"Ampara", "Anuradhapura", "Badulla", "Batticaloa", "Colombo",
+	"Galle", "Gampaha", "Hambantota", "Jaffna", "Kalutara",
+	"Kandy", "Kegalle", "Kilinochchi", "Kurunegala", "Mannar",
+	"Matale", "Matara", "Monaragala", "Mullaitivu", "Nuwara Eliya",
+	"Polonnaruwa", "Puttalam", "Ratnapura", "Trincomalee", "Vavuniya",
+}
+
+// Provinces are the 9 provinces, each made up of districts
+var Provinces = []string{
+	"Central", "Eastern", "North Central", "North Western", "Northern",
+	"Sabaragamuwa", "Southern", "Uva", "Western",
+}
+
+// provinces maps each district to its province
+var provinces = map[string]string{
+	"Kandy": "Central", "Matale": "Central", "Nuwara Eliya": "Central",
+	"Ampara": "Eastern", "Batticaloa": "Eastern", "Trincomalee": "Eastern",
+	"Anuradhapura": "North Central", "Polonnaruwa": "North Central",
+	"Kurunegala": "North Western", "Puttalam": "North Western",
+	"Jaffna": "Northern", "Kilinochchi": "Northern", "Mannar": "Northern", "Mullaitivu": "Northern", "Vavuniya": "Northern",
+	"Kegalle": "Sabaragamuwa", "Ratnapura": "Sabaragamuwa",
+	"Galle": "Southern", "Hambantota": "Southern", "Matara": "Southern",
+	"Badulla": "Uva", "Monaragala": "Uva",
+	"Colombo": "Western", "Gampaha": "Western", "Kalutara": "Western",
+}
+
+// ProvinceOf returns the province of a district named as in Names
+func ProvinceOf(district string) string {
+	return provinces[district]
+}
+
+// aliases are other spellings in common use
+var aliases = map[string]string{
+	"moneragala":  "Monaragala",
+	"mulativu":    "Mullaitivu",
+	"nuwaraeliya": "Nuwara Eliya",
+	"rathnapura":  "Ratnapura",
+	"kegalla":     "Kegalle",
+}
+
+// Canonical returns the district's name as spelled in Names, ignoring case and
+// spacing and accepting common alternative spellings
+func Canonical(name string) (string, bool) {
+	key := strings.ToLower(strings.Join(strings.Fields(name), ""))
+	if key == "" {
+		return "", false
+	}
+	for _, district := range Names {
+		if strings.ToLower(strings.ReplaceAll(district, " ", "")) == key {
+			return district, true
+		}
+	}
+	district, ok := aliases[key]
+	return district, ok
+}
+
+// Find returns a district named in free text such as a job posting's location,
+// matching whole words and ignoring case and punctuation. When several are
+// named, the first of them in Names is returned.
+func Find(text string) (string, bool) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) == 0 {
+		return "", false
+	}
+	padded := " " + strings.Join(words, " ") + " "
+
+	for _, district := range Names {
+		if strings.Contains(padded, " "+strings.ToLower(district)+" ") {
+			return district, true
+		}
+	}
+	for alias, district := range aliases {
+		if strings.Contains(padded, " "+alias+" ") {
+			return district, true
+		}
+	}
+	return "", false
+}
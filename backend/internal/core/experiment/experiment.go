@@ -0,0 +1,112 @@
+// Package experiment defines the A/B experiments run on prompts and ranking and
+// carries a request's variant assignments to the code that varies. Assignment is
+// deterministic, so a user sees the same variant on every request.
+package experiment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Experiments
+const (
+	// DiscoveryPrompt varies the prompt explaining interest matches
+	DiscoveryPrompt = "discovery-prompt"
+
+	// SearchRanking varies the weights ranking global search matches
+	SearchRanking = "search-ranking"
+)
+
+// Variants. Every experiment has a control variant: the behavior without it.
+const (
+	Control = "control"
+
+	// NextStep explanations end with a concrete next step for the student
+	NextStep = "next-step"
+
+	// WordMatch ranks names starting a word with the query as high as names
+	// starting with it, and loose matches lower
+	WordMatch = "word-match"
+)
+
+// Definition is an experiment and its variants, which split traffic equally
+type Definition struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Variants    []string `json:"variants"`
+}
+
+// Definitions are the experiments that can be run
+var Definitions = []Definition{
+	{
+		Name:        DiscoveryPrompt,
+		Description: "Prompt explaining why discovered programs and careers suit a student",
+		Variants:    []string{Control, NextStep},
+	},
+	{
+		Name:        SearchRanking,
+		Description: "Weights ranking global search matches",
+		Variants:    []string{Control, WordMatch},
+	},
+}
+
+// Lookup returns the experiment with a name
+func Lookup(name string) (Definition, bool) {
+	for _, def := range Definitions {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
+
+// HasVariant reports whether the experiment has a variant
+func (d Definition) HasVariant(variant string) bool {
+	for _, v := range d.Variants {
+		if v == variant {
+			return true
+		}
+	}
+	return false
+}
+
+// Assign returns the variant of the experiment for a unit: a user, or a single
+// request for anonymous callers
+func (d Definition) Assign(unit string) string {
+	// A cryptographic hash keeps assignments to different experiments independent
+	sum := sha256.Sum256([]byte(d.Name + ":" + unit))
+	return d.Variants[binary.BigEndian.Uint32(sum[:4])%uint32(len(d.Variants))]
+}
+
+type assignmentsKey struct{}
+
+type assignments struct {
+	variants map[string]string
+	expose   func(name, variant string)
+}
+
+// WithAssignments returns a context carrying variants by experiment name. expose
+// is called whenever a variant is put to use, to count exposures and tag the
+// response.
+func WithAssignments(ctx context.Context, variants map[string]string, expose func(name, variant string)) context.Context {
+	return context.WithValue(ctx, assignmentsKey{}, assignments{variants: variants, expose: expose})
+}
+
+// Variant returns the variant of an experiment assigned in ctx, and Control when
+// the experiment is not running. Each call with a running experiment counts as an
+// exposure, so call it once per request.
+func Variant(ctx context.Context, name string) string {
+	a, ok := ctx.Value(assignmentsKey{}).(assignments)
+	if !ok {
+		return Control
+	}
+	variant, ok := a.variants[name]
+	if !ok {
+		return Control
+	}
+	if a.expose != nil {
+		a.expose(name, variant)
+	}
+	return variant
+}
@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling Gemini while recent calls keep
+// failing, so callers can fall back at once instead of waiting out a timeout
+var ErrCircuitOpen = errors.New("LLM circuit open")
+
+const (
+	// breakerThreshold is how many consecutive failed calls open the circuit
+	breakerThreshold = 3
+
+	// breakerCooldown is how long the circuit stays open before one call is let
+	// through to probe whether Gemini has recovered
+	breakerCooldown = time.Minute
+)
+
+// breaker counts consecutive Gemini failures and short-circuits calls for a
+// cooldown once there are too many
+type breaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// allow reports whether a call may be made. Once the cooldown has passed, a
+// single probe is let through and the circuit is held open for another cooldown
+// until it reports back.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < breakerThreshold {
+		return true
+	}
+	now := time.Now()
+	if now.Before(b.openUntil) {
+		return false
+	}
+	b.openUntil = now.Add(breakerCooldown)
+	return true
+}
+
+// open reports whether calls are currently being short-circuited
+func (b *breaker) open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures >= breakerThreshold && time.Now().Before(b.openUntil)
+}
+
+// record notes the outcome of a call. Calls abandoned because the caller's own
+// context ended say nothing about Gemini and are not counted.
+func (b *breaker) record(ctx context.Context, err error) {
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures == breakerThreshold {
+		b.openUntil = time.Now().Add(breakerCooldown)
+	}
+}
+
+// Available reports whether the client can be asked for generated content. It
+// is false for a nil client and while the circuit is open.
+func (c *Client) Available() bool {
+	return c != nil && !c.breaker.open()
+}
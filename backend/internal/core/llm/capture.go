@@ -0,0 +1,67 @@
+package llm
+
+import "time"
+
+// Tasks name what a model call was made for, so captured exchanges can be
+// reviewed per prompt
+const (
+	TaskLearningRoadmap     = "learning_roadmap"
+	TaskStepTopics          = "step_topics"
+	TaskJobRole             = "job_role"
+	TaskInterestExplanation = "interest_explanation"
+	TaskReadinessAssessment = "readiness_assessment"
+	TaskModeration          = "moderation"
+
+	// Health checks are never captured
+	taskHealthCheck = "health_check"
+)
+
+// Tasks lists every task whose exchanges can be captured
+var Tasks = []string{
+	TaskLearningRoadmap,
+	TaskStepTopics,
+	TaskJobRole,
+	TaskInterestExplanation,
+	TaskReadinessAssessment,
+	TaskModeration,
+}
+
+// Exchange is a prompt sent to a model and its response, or the error the call
+// failed with
+type Exchange struct {
+	Task         string
+	Model        string
+	SystemPrompt string
+	UserPrompt   string
+	Temperature  float32
+	Response     string
+	Error        string
+	Duration     time.Duration
+	At           time.Time
+}
+
+// UseCapture sets a function given every call made to a model, health checks
+// aside. It runs on the caller's goroutine before the response is returned, so
+// it should hand exchanges off rather than store them itself. Calls refused by
+// the generation meter or the circuit breaker are not made, so not captured.
+// Call it before the client is used.
+func (c *Client) UseCapture(capture func(Exchange)) {
+	c.capture = capture
+}
+
+func newExchange(task, model, systemPrompt, userPrompt string, temperature float32, response string, err error, start time.Time) Exchange {
+	exchange := Exchange{
+		Task:         task,
+		Model:        model,
+		SystemPrompt: systemPrompt,
+		UserPrompt:   userPrompt,
+		Temperature:  temperature,
+		Response:     response,
+		Duration:     time.Since(start),
+		At:           start,
+	}
+	if err != nil {
+		exchange.Error = err.Error()
+	}
+	return exchange
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/core/experiment"
 	"github.com/mayura-andrew/fastfinder/pkg/logger"
 	"go.uber.org/zap"
 	"google.golang.org/genai"
@@ -21,6 +22,9 @@ type Client struct {
 	ctx         context.Context
 	cancel      context.CancelFunc
 	logger      *zap.Logger
+	breaker     breaker
+	mock        bool           // answer with canned content instead of calling a model
+	capture     func(Exchange) // given every call made to a model, when set
 }
 
 // Default configuration constants
@@ -96,6 +100,9 @@ func NewClient(cfg config.LLMConfig) (*Client, error) {
 }
 
 func (c *Client) Provider() string {
+	if c.mock {
+		return MockProvider
+	}
 	return "gemini"
 }
 
@@ -108,10 +115,14 @@ func (c *Client) Model() string {
 }
 
 func (c *Client) IsHealthy(ctx context.Context) bool {
+	if c.mock {
+		return true
+	}
+
 	healthCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	_, err := c.callGemini(healthCtx, "You are a health check assistant.", HealthCheckPrompt, 0.1)
+	_, err := c.callGemini(healthCtx, taskHealthCheck, "You are a health check assistant.", HealthCheckPrompt, 0.1)
 	if err != nil {
 		c.logger.Warn("Gemini health check failed", zap.Error(err))
 		return false
@@ -119,11 +130,24 @@ func (c *Client) IsHealthy(ctx context.Context) bool {
 	return true
 }
 
-func (c *Client) callGemini(ctx context.Context, systemPrompt, userPrompt string, temperature float32) (string, error) {
-	// Use configured model or fallback
-	model := c.config.Model
-	if model == "" {
-		model = DefaultModel
+func (c *Client) callGemini(ctx context.Context, task, systemPrompt, userPrompt string, temperature float32) (string, error) {
+	return c.callModel(ctx, task, c.Model(), systemPrompt, userPrompt, temperature)
+}
+
+func (c *Client) callModel(ctx context.Context, task, model, systemPrompt, userPrompt string, temperature float32) (result string, err error) {
+	// Metered before the breaker, so a refused call leaves no probe unreported
+	if !admitGeneration(ctx) {
+		return "", ErrGenerationLimit
+	}
+	if !c.breaker.allow() {
+		return "", ErrCircuitOpen
+	}
+	defer func() { c.breaker.record(ctx, err) }()
+	if c.capture != nil && task != taskHealthCheck {
+		start := time.Now()
+		defer func() {
+			c.capture(newExchange(task, model, systemPrompt, userPrompt, temperature, result, err, start))
+		}()
 	}
 
 	// Create the full prompt combining system and user prompts
@@ -171,7 +195,7 @@ func (c *Client) callGemini(ctx context.Context, systemPrompt, userPrompt string
 		}
 	}
 
-	result := strings.TrimSpace(content.String())
+	result = strings.TrimSpace(content.String())
 	if result == "" {
 		return "", fmt.Errorf("no text content in Gemini response")
 	}
@@ -231,8 +255,17 @@ type LearningRoadmap struct {
 
 // GenerateLearningRoadmap generates a structured learning roadmap for a program
 func (c *Client) GenerateLearningRoadmap(ctx context.Context, programName string, prerequisites []string) (*LearningRoadmap, error) {
+	return c.generateLearningRoadmap(ctx, c.Model(), programName, prerequisites)
+}
+
+func (c *Client) generateLearningRoadmap(ctx context.Context, model, programName string, prerequisites []string) (*LearningRoadmap, error) {
+	if c.mock {
+		return mockLearningRoadmap(programName, prerequisites), nil
+	}
+
 	c.logger.Info("Generating learning roadmap",
 		zap.String("program", programName),
+		zap.String("model", model),
 		zap.Strings("prerequisites", prerequisites))
 
 	systemPrompt := `You are an expert education advisor specializing in creating comprehensive learning roadmaps for Sri Lankan students pursuing higher education.
@@ -287,7 +320,7 @@ Each step should:
 
 Return ONLY the JSON object, no additional text.`, programName, prerequisitesStr)
 
-	response, err := c.callGemini(ctx, systemPrompt, userPrompt, 0.7)
+	response, err := c.callModel(ctx, TaskLearningRoadmap, model, systemPrompt, userPrompt, 0.7)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate learning roadmap: %w", err)
 	}
@@ -309,6 +342,7 @@ Return ONLY the JSON object, no additional text.`, programName, prerequisitesStr
 
 	c.logger.Info("Successfully generated learning roadmap",
 		zap.String("program", programName),
+		zap.String("model", model),
 		zap.Int("steps", len(roadmap.LearningSteps)))
 
 	return &roadmap, nil
@@ -316,6 +350,10 @@ Return ONLY the JSON object, no additional text.`, programName, prerequisitesStr
 
 // GenerateTopicsForStep generates specific learning topics for a step
 func (c *Client) GenerateTopicsForStep(ctx context.Context, stepTitle string, programContext string) ([]string, error) {
+	if c.mock {
+		return mockTopics(stepTitle), nil
+	}
+
 	systemPrompt := `You are an educational content curator. Generate a list of 3-5 specific, searchable topics for learning.`
 
 	userPrompt := fmt.Sprintf(`For a student learning "%s" as part of "%s", what are the key topics they should search for and study?
@@ -328,7 +366,7 @@ Provide topics that:
 
 Return a JSON array of topic strings, like: ["Topic 1", "Topic 2", "Topic 3"]`, stepTitle, programContext)
 
-	response, err := c.callGemini(ctx, systemPrompt, userPrompt, 0.5)
+	response, err := c.callGemini(ctx, TaskStepTopics, systemPrompt, userPrompt, 0.5)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate topics: %w", err)
 	}
@@ -381,12 +419,16 @@ type CareerPathInfo struct {
 	YearsToAdvance string `json:"years_to_advance"`
 }
 
-// SalaryInfo represents salary expectations
+// SalaryInfo represents salary expectations. Source is "job_boards" when the
+// figures come from advertised salaries rather than the model's estimate.
 type SalaryInfo struct {
-	EntryLevel  string `json:"entry_level"`
-	MidLevel    string `json:"mid_level"`
-	SeniorLevel string `json:"senior_level"`
-	Currency    string `json:"currency"`
+	EntryLevel  string     `json:"entry_level"`
+	MidLevel    string     `json:"mid_level"`
+	SeniorLevel string     `json:"senior_level"`
+	Currency    string     `json:"currency"`
+	Source      string     `json:"source,omitempty"`
+	SampleSize  int        `json:"sample_size,omitempty"`
+	CollectedAt *time.Time `json:"collected_at,omitempty"`
 }
 
 // WorkEnvironmentInfo represents work environment details
@@ -407,6 +449,10 @@ type LocalMarketInfo struct {
 
 // GenerateJobRoleDetails generates comprehensive information about a specific job role
 func (c *Client) GenerateJobRoleDetails(ctx context.Context, roleName string, programContext string) (*JobRoleDetails, error) {
+	if c.mock {
+		return mockJobRoleDetails(roleName), nil
+	}
+
 	c.logger.Info("Generating job role details",
 		zap.String("role", roleName),
 		zap.String("context", programContext))
@@ -526,7 +572,7 @@ Important guidelines:
 
 Return ONLY the JSON object, no additional text or markdown formatting.`, roleName, programContext, roleName)
 
-	response, err := c.callGemini(ctx, systemPrompt, userPrompt, 0.6)
+	response, err := c.callGemini(ctx, TaskJobRole, systemPrompt, userPrompt, 0.6)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate job role details: %w", err)
 	}
@@ -553,6 +599,209 @@ Return ONLY the JSON object, no additional text or markdown formatting.`, roleNa
 	return &jobDetails, nil
 }
 
+// InterestMatch is a program or career proposed for the interests a student
+// described, with its graph context
+type InterestMatch struct {
+	Kind         string   `json:"kind"`
+	Name         string   `json:"name"`
+	Requirements []string `json:"requirements,omitempty"`
+	Related      []string `json:"related,omitempty"`
+}
+
+// ExplainInterestMatches explains briefly why each match suits the interests a
+// student described, returning the explanations keyed by match name
+func (c *Client) ExplainInterestMatches(ctx context.Context, interests string, matches []InterestMatch) (map[string]string, error) {
+	if c.mock {
+		return mockExplanations(interests, matches), nil
+	}
+
+	variant := experiment.Variant(ctx, experiment.DiscoveryPrompt)
+	c.logger.Info("Explaining interest matches",
+		zap.Int("matches", len(matches)),
+		zap.String("prompt_variant", variant))
+
+	instructions := `For each match, write one or two plain sentences addressed to the student explaining why it fits what they said.`
+	if variant == experiment.NextStep {
+		instructions = `For each match, write one plain sentence addressed to the student explaining why it fits what they said, then one sentence with a concrete next step: an entry requirement to check, a subject to strengthen or a related option to look at.`
+	}
+
+	systemPrompt := `You are a friendly career guidance counselor for Sri Lankan school leavers.
+
+A student described their interests and circumstances in their own words. You are given programs and careers that were matched to that description, with their entry requirements and related careers or programs.
+
+` + instructions + ` If the student mentions a weakness or a failed subject and a match has entry requirements touching on it, say so honestly and, where possible, point to a related option that avoids it. Do not invent requirements, institutes or salaries.
+
+Format your response as a JSON array with this exact structure:
+[{"name": "Match name exactly as given", "explanation": "Why it fits"}]`
+
+	matchesJSON, err := json.Marshal(matches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode matches: %w", err)
+	}
+
+	userPrompt := fmt.Sprintf(`Student's description: %q
+
+Matches:
+%s
+
+Return ONLY the JSON array, no additional text.`, interests, matchesJSON)
+
+	response, err := c.callGemini(ctx, TaskInterestExplanation, systemPrompt, userPrompt, 0.4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain interest matches: %w", err)
+	}
+
+	// Clean the response (remove markdown code blocks if present)
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+	response = strings.TrimSpace(response)
+
+	var explained []struct {
+		Name        string `json:"name"`
+		Explanation string `json:"explanation"`
+	}
+	if err := json.Unmarshal([]byte(response), &explained); err != nil {
+		c.logger.Error("Failed to parse interest match explanations JSON",
+			zap.Error(err),
+			zap.String("response", response[:min(500, len(response))]))
+		return nil, fmt.Errorf("failed to parse interest match explanations: %w", err)
+	}
+
+	explanations := make(map[string]string, len(explained))
+	for _, e := range explained {
+		if e.Name != "" && e.Explanation != "" {
+			explanations[e.Name] = strings.TrimSpace(e.Explanation)
+		}
+	}
+	return explanations, nil
+}
+
+// Readiness levels of a readiness assessment
+const (
+	ReadinessReady       = "ready"
+	ReadinessNearlyReady = "nearly_ready"
+	ReadinessNotReady    = "not_ready"
+)
+
+// SubjectGrade is a grade a student obtained in an examination subject
+type SubjectGrade struct {
+	Subject string `json:"subject"`
+	Grade   string `json:"grade"`
+}
+
+// ReadinessContext is what the graph says about a program and how a student's
+// qualifications compare with its entry requirements
+type ReadinessContext struct {
+	ProgramName       string   `json:"program_name"`
+	Institute         string   `json:"institute,omitempty"`
+	MetRequirements   []string `json:"met_requirements"`
+	UnmetRequirements []string `json:"unmet_requirements"`
+	Prerequisites     []string `json:"prerequisites,omitempty"`
+	AptitudeTests     []string `json:"aptitude_tests,omitempty"`
+	Careers           []string `json:"careers,omitempty"`
+}
+
+// ReadinessProfile is what a student told us about themselves
+type ReadinessProfile struct {
+	Qualifications    []string       `json:"qualifications"`
+	Subjects          []SubjectGrade `json:"subjects"`
+	StudyHoursPerWeek int            `json:"study_hours_per_week,omitempty"`
+}
+
+// SubjectPreparation is how long a student should prepare in a subject, and on what
+type SubjectPreparation struct {
+	Subject string `json:"subject"`
+	Weeks   int    `json:"weeks"`
+	Focus   string `json:"focus"`
+}
+
+// ReadinessAssessment is how ready a student is for a program and how to prepare
+type ReadinessAssessment struct {
+	Level       string               `json:"level"`
+	Summary     string               `json:"summary"`
+	StrongAreas []string             `json:"strong_areas"`
+	WeakAreas   []string             `json:"weak_areas"`
+	Preparation []SubjectPreparation `json:"preparation"`
+}
+
+// GenerateReadinessAssessment assesses how ready a student is for a program and
+// suggests how many weeks to prepare per subject, grounded in the program's
+// requirements from the graph
+func (c *Client) GenerateReadinessAssessment(ctx context.Context, program ReadinessContext, profile ReadinessProfile) (*ReadinessAssessment, error) {
+	if c.mock {
+		return mockReadinessAssessment(program, profile), nil
+	}
+
+	c.logger.Info("Generating readiness assessment",
+		zap.String("program", program.ProgramName),
+		zap.Int("subjects", len(profile.Subjects)))
+
+	systemPrompt := `You are an experienced academic advisor for Sri Lankan school leavers.
+
+You are given a program with its entry requirements, split into those the student already meets and those they do not, its prerequisite programs, any aptitude or practical tests and the careers it leads to. You are also given the student's qualifications, their examination grades per subject and how many hours a week they can study.
+
+Assess how ready the student is to start the program and how they should prepare:
+1. "level" is "ready" when no requirement is unmet and their grades suit the program, "nearly_ready" when a few weeks of preparation would close the gaps, and "not_ready" otherwise.
+2. Strong and weak areas name subjects or skills from the student's grades and the program's demands.
+3. Preparation lists the subjects to work on, with the weeks needed at the student's weekly study hours and what to focus on.
+
+Base the assessment only on the data given. Do not invent requirements, tests or grades, and never say an unmet requirement is met.
+
+Format your response as a JSON object with this exact structure:
+{
+  "level": "ready | nearly_ready | not_ready",
+  "summary": "Two or three plain sentences addressed to the student",
+  "strong_areas": ["Area"],
+  "weak_areas": ["Area"],
+  "preparation": [{"subject": "Subject", "weeks": 4, "focus": "What to practise"}]
+}`
+
+	programJSON, err := json.Marshal(program)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode program: %w", err)
+	}
+	profileJSON, err := json.Marshal(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode profile: %w", err)
+	}
+
+	userPrompt := fmt.Sprintf(`Program:
+%s
+
+Student:
+%s
+
+Return ONLY the JSON object, no additional text.`, programJSON, profileJSON)
+
+	response, err := c.callGemini(ctx, TaskReadinessAssessment, systemPrompt, userPrompt, 0.3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate readiness assessment: %w", err)
+	}
+
+	// Clean the response (remove markdown code blocks if present)
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+	response = strings.TrimSpace(response)
+
+	var assessment ReadinessAssessment
+	if err := json.Unmarshal([]byte(response), &assessment); err != nil {
+		c.logger.Error("Failed to parse readiness assessment JSON",
+			zap.Error(err),
+			zap.String("response", response[:min(500, len(response))]))
+		return nil, fmt.Errorf("failed to parse readiness assessment: %w", err)
+	}
+
+	c.logger.Info("Successfully generated readiness assessment",
+		zap.String("program", program.ProgramName),
+		zap.String("level", assessment.Level))
+
+	return &assessment, nil
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// roadmapResult is the outcome of one model's roadmap generation
+type roadmapResult struct {
+	roadmap *LearningRoadmap
+	err     error
+	primary bool
+}
+
+// HedgeModel returns the faster model raced against the primary one for
+// latency-critical generations, or "" when hedging is disabled
+func (c *Client) HedgeModel() string {
+	if c.config.HedgeModel == c.Model() {
+		return ""
+	}
+	return c.config.HedgeModel
+}
+
+// GenerateLearningRoadmapHedged races the hedge model against the primary model
+// and returns whichever roadmap is generated first. The primary model keeps
+// going when the hedge model wins, detached from ctx, and every roadmap it
+// generates is passed to onPrimary on its own goroutine, so the better roadmap
+// can still be cached. The hedge model is cancelled once the primary one wins.
+// Without a hedge model this is GenerateLearningRoadmap and onPrimary is not
+// called.
+func (c *Client) GenerateLearningRoadmapHedged(ctx context.Context, programName string, prerequisites []string, onPrimary func(*LearningRoadmap)) (*LearningRoadmap, error) {
+	hedgeModel := c.HedgeModel()
+	if hedgeModel == "" {
+		return c.GenerateLearningRoadmap(ctx, programName, prerequisites)
+	}
+
+	hedgeCtx, cancelHedge := context.WithCancel(ctx)
+	defer cancelHedge()
+
+	// Buffered so the loser can finish after the caller has returned
+	results := make(chan roadmapResult, 2)
+	go func() {
+		roadmap, err := c.generateLearningRoadmap(context.WithoutCancel(ctx), c.Model(), programName, prerequisites)
+		results <- roadmapResult{roadmap: roadmap, err: err, primary: true}
+		if err == nil && onPrimary != nil {
+			onPrimary(roadmap)
+		}
+	}()
+	go func() {
+		roadmap, err := c.generateLearningRoadmap(hedgeCtx, hedgeModel, programName, prerequisites)
+		results <- roadmapResult{roadmap: roadmap, err: err}
+	}()
+
+	var primaryErr error
+	for range 2 {
+		var result roadmapResult
+		select {
+		case result = <-results:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if result.err == nil {
+			c.logger.Info("Hedged learning roadmap generated",
+				zap.String("program", programName),
+				zap.Bool("primary_won", result.primary))
+			return result.roadmap, nil
+		}
+		if result.primary {
+			primaryErr = result.err
+		} else {
+			c.logger.Warn("Hedge model failed to generate learning roadmap",
+				zap.String("program", programName),
+				zap.String("model", hedgeModel),
+				zap.Error(result.err))
+		}
+	}
+	return nil, primaryErr
+}
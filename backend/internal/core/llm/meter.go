@@ -0,0 +1,26 @@
+package llm
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrGenerationLimit is returned without calling the model when the caller the
+// generation is made for has no generations left
+var ErrGenerationLimit = errors.New("LLM generation limit reached")
+
+type meterKey struct{}
+
+// WithGenerationMeter returns a context whose model calls are first passed to
+// admit, which counts them and reports whether they may be made. The meter stays
+// with work that outlives the request, such as hedged roadmaps finishing in the
+// background.
+func WithGenerationMeter(ctx context.Context, admit func() bool) context.Context {
+	return context.WithValue(ctx, meterKey{}, admit)
+}
+
+// admitGeneration reports whether the meter of ctx, if any, admits a model call
+func admitGeneration(ctx context.Context) bool {
+	admit, ok := ctx.Value(meterKey{}).(func() bool)
+	return !ok || admit()
+}
@@ -0,0 +1,168 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/pkg/logger"
+)
+
+// MockProvider is the provider name of clients created by NewMockClient
+const MockProvider = "mock"
+
+// NewMockClient creates a client that answers every request instantly with
+// plausible, deterministic content built from the request instead of calling a
+// model, so the API can run without an API key or network access in demo mode
+func NewMockClient(cfg config.LLMConfig) *Client {
+	cfg.Provider = MockProvider
+	cfg.HedgeModel = ""
+	return &Client{
+		config: cfg,
+		logger: logger.MustGetLogger(),
+		mock:   true,
+	}
+}
+
+// mockLearningRoadmap is a generic roadmap that leads from the prerequisites to
+// the program
+func mockLearningRoadmap(programName string, prerequisites []string) *LearningRoadmap {
+	if len(prerequisites) == 0 {
+		prerequisites = []string{"None specified"}
+	}
+	return &LearningRoadmap{
+		ProgramName:   programName,
+		Overview:      fmt.Sprintf("A sample roadmap preparing students for %s, generated in demo mode.", programName),
+		TotalDuration: "4-6 months",
+		Prerequisites: prerequisites,
+		LearningSteps: []LearningStep{
+			{
+				StepNumber:  1,
+				Title:       "Review the entry requirements",
+				Description: "Make sure the subjects and qualifications the program asks for are in place.",
+				Topics:      []string{"Entry requirements", "Study skills", "Time management"},
+				Duration:    "2-3 weeks",
+				Difficulty:  "beginner",
+			},
+			{
+				StepNumber:  2,
+				Title:       "Foundations of " + programName,
+				Description: "Learn the basic concepts and vocabulary the program builds on.",
+				Topics:      []string{"Core concepts", "Key terminology", "Introductory exercises"},
+				Duration:    "4-6 weeks",
+				Difficulty:  "beginner",
+			},
+			{
+				StepNumber:  3,
+				Title:       "Core skills",
+				Description: "Practise the main skills of the field on small, guided exercises.",
+				Topics:      []string{"Problem solving", "Hands-on practice", "Tools of the trade"},
+				Duration:    "6-8 weeks",
+				Difficulty:  "intermediate",
+			},
+			{
+				StepNumber:  4,
+				Title:       "Projects and next steps",
+				Description: "Apply what you learned in a small project and plan your applications.",
+				Topics:      []string{"Portfolio project", "Career options", "Applying to programs"},
+				Duration:    "3-4 weeks",
+				Difficulty:  "intermediate",
+			},
+		},
+		KeySkills:      []string{"Problem solving", "Communication", "Self-directed learning"},
+		RecommendedFor: "Students exploring " + programName,
+	}
+}
+
+// mockTopics are search topics for a roadmap step
+func mockTopics(stepTitle string) []string {
+	return []string{
+		stepTitle + " for beginners",
+		stepTitle + " explained",
+		stepTitle + " practice problems",
+	}
+}
+
+// mockJobRoleDetails describes a job role in general terms
+func mockJobRoleDetails(roleName string) *JobRoleDetails {
+	return &JobRoleDetails{
+		RoleName:            roleName,
+		Overview:            fmt.Sprintf("A sample description of the %s role, generated in demo mode.", roleName),
+		KeyResponsibilities: []string{"Plan and carry out day-to-day work", "Work with a team", "Keep skills up to date"},
+		RequiredSkills: SkillCategory{
+			Technical: []string{"Domain knowledge", "Problem solving"},
+			Soft:      []string{"Communication", "Teamwork"},
+			Tools:     []string{"Office software"},
+		},
+		CareerPath: CareerPathInfo{
+			EntryLevel:     "Junior " + roleName,
+			MidLevel:       roleName,
+			SeniorLevel:    "Senior " + roleName,
+			YearsToAdvance: "3-5 years",
+		},
+		SalaryInfo: SalaryInfo{
+			EntryLevel:  "60,000 - 90,000",
+			MidLevel:    "120,000 - 180,000",
+			SeniorLevel: "250,000+",
+			Currency:    "LKR",
+		},
+		WorkEnvironment: WorkEnvironmentInfo{
+			Type:         "Office",
+			Industries:   []string{"Private sector", "Public sector"},
+			CompanyTypes: []string{"Large companies", "Small and medium enterprises"},
+		},
+		GrowthOpportunities: []string{"Specialization", "Team leadership"},
+		Certifications:      []string{"Relevant NVQ or professional certificate"},
+		DayInLife:           []string{"Morning planning", "Project work", "Team meetings"},
+		LocalMarket: LocalMarketInfo{
+			Demand:           "Moderate",
+			GrowthProjection: "Stable",
+			KeyCities:        []string{"Colombo", "Kandy", "Galle"},
+		},
+	}
+}
+
+// mockExplanations explains every match with the same template
+func mockExplanations(interests string, matches []InterestMatch) map[string]string {
+	explanations := make(map[string]string, len(matches))
+	for _, match := range matches {
+		explanation := fmt.Sprintf("This %s matches what you described: %q.", match.Kind, interests)
+		if len(match.Related) > 0 {
+			explanation += " It is related to " + strings.Join(match.Related, ", ") + "."
+		}
+		explanations[match.Name] = explanation
+	}
+	return explanations
+}
+
+// mockReadinessAssessment rates a student by their unmet requirements and
+// suggests preparing in each subject without an A or B
+func mockReadinessAssessment(program ReadinessContext, profile ReadinessProfile) *ReadinessAssessment {
+	assessment := &ReadinessAssessment{
+		Level:       ReadinessReady,
+		Summary:     fmt.Sprintf("A sample readiness assessment for %s, generated in demo mode.", program.ProgramName),
+		StrongAreas: []string{},
+		WeakAreas:   []string{},
+		Preparation: []SubjectPreparation{},
+	}
+	for _, subject := range profile.Subjects {
+		switch strings.ToUpper(subject.Grade) {
+		case "A", "B":
+			assessment.StrongAreas = append(assessment.StrongAreas, subject.Subject)
+		default:
+			assessment.WeakAreas = append(assessment.WeakAreas, subject.Subject)
+			assessment.Preparation = append(assessment.Preparation, SubjectPreparation{
+				Subject: subject.Subject,
+				Weeks:   4,
+				Focus:   "Revise the basics and practise past papers",
+			})
+		}
+	}
+	switch {
+	case len(program.UnmetRequirements) > 0:
+		assessment.Level = ReadinessNotReady
+	case len(assessment.Preparation) > 0:
+		assessment.Level = ReadinessNearlyReady
+	}
+	return assessment
+}
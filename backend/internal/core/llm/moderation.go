@@ -0,0 +1,61 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Moderation is a model's verdict on user-written text
+type Moderation struct {
+	Flagged    bool     `json:"flagged"`
+	Categories []string `json:"categories,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+}
+
+// ModerateText asks the model whether text a user wrote is abusive, hateful,
+// sexual, threatening or otherwise unfit to show on a site used by school
+// leavers. Text may be in English, Sinhala or Tamil, in any script.
+func (c *Client) ModerateText(ctx context.Context, text string) (*Moderation, error) {
+	if c.mock {
+		return &Moderation{}, nil
+	}
+
+	c.logger.Debug("Moderating user text", zap.Int("length", len(text)))
+
+	systemPrompt := `You moderate text written by users of a career guidance site for Sri Lankan school leavers, many of them minors. The text may be in English, Sinhala or Tamil, in their own scripts or romanized.
+
+Flag the text if it contains profanity, insults, harassment, hate towards a group, sexual content, threats, self-harm encouragement, spam or advertising. Do not flag criticism of institutes or programs, frustration, or spelling mistakes.
+
+Format your response as JSON with this exact structure:
+{"flagged": true, "categories": ["profanity"], "reason": "One short sentence"}
+Categories are: profanity, harassment, hate, sexual, violence, self_harm, spam.`
+
+	userPrompt := fmt.Sprintf(`Text: %q
+
+Return ONLY the JSON object, no additional text.`, text)
+
+	response, err := c.callGemini(ctx, TaskModeration, systemPrompt, userPrompt, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to moderate text: %w", err)
+	}
+
+	// Clean the response (remove markdown code blocks if present)
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+	response = strings.TrimSpace(response)
+
+	var moderation Moderation
+	if err := json.Unmarshal([]byte(response), &moderation); err != nil {
+		c.logger.Error("Failed to parse moderation JSON",
+			zap.Error(err),
+			zap.String("response", response[:min(500, len(response))]))
+		return nil, fmt.Errorf("failed to parse moderation: %w", err)
+	}
+	return &moderation, nil
+}
@@ -0,0 +1,144 @@
+// Package mail sends email through interchangeable providers (SMTP, SendGrid,
+// Amazon SES) from HTML and plain text templates in English, Sinhala and Tamil.
+// Without a provider enabled, messages go to an in-memory sink that admins can
+// inspect, so features that send email can be exercised anywhere.
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"go.uber.org/zap"
+)
+
+// Languages templates are written in
+const (
+	LanguageEnglish = "en"
+	LanguageSinhala = "si"
+	LanguageTamil   = "ta"
+)
+
+// SinkProvider is the provider name of the in-memory sink
+const SinkProvider = "sink"
+
+// ErrInvalidRecipient is returned for recipient addresses that cannot be used
+var ErrInvalidRecipient = errors.New("invalid recipient address")
+
+// Message is a rendered email
+type Message struct {
+	From     string    `json:"from"`
+	To       []string  `json:"to"`
+	Subject  string    `json:"subject"`
+	Text     string    `json:"text"`
+	HTML     string    `json:"html"`
+	Language string    `json:"language"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// Provider delivers rendered messages
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Mailer renders templates and sends them through the configured provider
+type Mailer struct {
+	provider  Provider
+	sink      *Sink // set when messages are captured instead of sent
+	from      netmail.Address
+	templates *templates
+	logger    *zap.Logger
+}
+
+// New creates a mailer for the configured provider. A disabled mailer captures
+// messages in the sink.
+func New(cfg config.MailerConfig, logger *zap.Logger) (*Mailer, error) {
+	tmpl, err := loadTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mailer{
+		from:      netmail.Address{Name: cfg.SenderName, Address: cfg.Sender},
+		templates: tmpl,
+		logger:    logger,
+	}
+
+	provider := cfg.Provider
+	if !cfg.Enabled {
+		provider = SinkProvider
+	}
+	switch provider {
+	case "smtp":
+		m.provider = newSMTPProvider(cfg)
+	case "sendgrid":
+		m.provider = newSendGridProvider(cfg)
+	case "ses":
+		m.provider = newSESProvider(cfg)
+	case SinkProvider:
+		m.sink = newSink(logger)
+		m.provider = m.sink
+	default:
+		return nil, fmt.Errorf("unknown mail provider %q", cfg.Provider)
+	}
+
+	logger.Info("Mailer initialized", zap.String("provider", m.provider.Name()))
+	return m, nil
+}
+
+// Provider returns the name of the provider messages are sent through
+func (m *Mailer) Provider() string {
+	return m.provider.Name()
+}
+
+// Outbox returns the messages captured by the sink, newest first, and whether
+// the sink is in use
+func (m *Mailer) Outbox() ([]Message, bool) {
+	if m.sink == nil {
+		return nil, false
+	}
+	return m.sink.Messages(), true
+}
+
+// Send renders a template in the given language, falling back to English, and
+// sends it to one recipient
+func (m *Mailer) Send(ctx context.Context, to, template, language string, data any) error {
+	address, err := netmail.ParseAddress(strings.TrimSpace(to))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidRecipient, to)
+	}
+
+	msg, err := m.templates.render(template, NormalizeLanguage(language), data)
+	if err != nil {
+		return err
+	}
+	msg.From = m.from.String()
+	msg.To = []string{address.Address}
+	msg.SentAt = time.Now().UTC()
+
+	if err := m.provider.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send %s email via %s: %w", template, m.provider.Name(), err)
+	}
+
+	m.logger.Info("Email sent",
+		zap.String("template", template),
+		zap.String("language", msg.Language),
+		zap.String("provider", m.provider.Name()))
+	return nil
+}
+
+// NormalizeLanguage maps a language tag such as si-LK to a template language,
+// English for languages without templates
+func NormalizeLanguage(language string) string {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(language)), "-")
+	switch base {
+	case LanguageSinhala, LanguageTamil:
+		return base
+	}
+	return LanguageEnglish
+}
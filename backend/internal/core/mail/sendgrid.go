@@ -0,0 +1,95 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	netmail "net/mail"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+)
+
+const sendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+// sendGridProvider sends through SendGrid's v3 mail API
+type sendGridProvider struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newSendGridProvider(cfg config.MailerConfig) *sendGridProvider {
+	return &sendGridProvider{
+		apiKey:     cfg.SendGridAPIKey,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (p *sendGridProvider) Name() string { return "sendgrid" }
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridRequest struct {
+	Personalizations []struct {
+		To []sendGridAddress `json:"to"`
+	} `json:"personalizations"`
+	From    sendGridAddress   `json:"from"`
+	Subject string            `json:"subject"`
+	Content []sendGridContent `json:"content"`
+}
+
+func (p *sendGridProvider) Send(ctx context.Context, msg *Message) error {
+	from, err := netmail.ParseAddress(msg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+
+	var payload sendGridRequest
+	payload.Personalizations = make([]struct {
+		To []sendGridAddress `json:"to"`
+	}, 1)
+	for _, to := range msg.To {
+		payload.Personalizations[0].To = append(payload.Personalizations[0].To, sendGridAddress{Email: to})
+	}
+	payload.From = sendGridAddress{Email: from.Address, Name: from.Name}
+	payload.Subject = msg.Subject
+	payload.Content = []sendGridContent{
+		{Type: "text/plain", Value: msg.Text},
+		{Type: "text/html", Value: msg.HTML},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendGridURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// SendGrid accepts messages with 202
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/pkg/awsv4"
+)
+
+// sesProvider sends through the Amazon SES v2 API
+type sesProvider struct {
+	endpoint   string
+	creds      awsv4.Credentials
+	httpClient *http.Client
+}
+
+func newSESProvider(cfg config.MailerConfig) *sesProvider {
+	return &sesProvider{
+		endpoint: fmt.Sprintf("https://email.%s.amazonaws.com/v2/email/outbound-emails", cfg.SESRegion),
+		creds: awsv4.Credentials{
+			AccessKey: cfg.SESAccessKey,
+			SecretKey: cfg.SESSecretKey,
+			Region:    cfg.SESRegion,
+			Service:   "ses",
+		},
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (p *sesProvider) Name() string { return "ses" }
+
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+func (p *sesProvider) Send(ctx context.Context, msg *Message) error {
+	var payload struct {
+		FromEmailAddress string `json:"FromEmailAddress"`
+		Destination      struct {
+			ToAddresses []string `json:"ToAddresses"`
+		} `json:"Destination"`
+		Content struct {
+			Simple struct {
+				Subject sesContent `json:"Subject"`
+				Body    struct {
+					Text sesContent `json:"Text"`
+					Html sesContent `json:"Html"`
+				} `json:"Body"`
+			} `json:"Simple"`
+		} `json:"Content"`
+	}
+	payload.FromEmailAddress = msg.From
+	payload.Destination.ToAddresses = msg.To
+	payload.Content.Simple.Subject = sesContent{Data: msg.Subject, Charset: "UTF-8"}
+	payload.Content.Simple.Body.Text = sesContent{Data: msg.Text, Charset: "UTF-8"}
+	payload.Content.Simple.Body.Html = sesContent{Data: msg.HTML, Charset: "UTF-8"}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	awsv4.Sign(req, body, p.creds, time.Now())
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
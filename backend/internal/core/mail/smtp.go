@@ -0,0 +1,168 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+)
+
+// smtpProvider sends through an SMTP server, upgrading to TLS with STARTTLS
+// when the server offers it
+type smtpProvider struct {
+	addr     string
+	host     string
+	username string
+	password string
+	timeout  time.Duration
+}
+
+func newSMTPProvider(cfg config.MailerConfig) *smtpProvider {
+	return &smtpProvider{
+		addr:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		host:     cfg.Host,
+		username: cfg.Username,
+		password: cfg.Password,
+		timeout:  cfg.Timeout,
+	}
+}
+
+func (p *smtpProvider) Name() string { return "smtp" }
+
+func (p *smtpProvider) Send(ctx context.Context, msg *Message) error {
+	from, err := netmail.ParseAddress(msg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	data, err := buildMIME(msg)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: p.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", p.addr, err)
+	}
+	deadline := time.Now().Add(p.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, p.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: p.host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if p.username != "" {
+		// PlainAuth refuses to send credentials over unencrypted connections
+		// to anything but localhost
+		if err := client.Auth(smtp.PlainAuth("", p.username, p.password, p.host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("sender rejected: %w", err)
+	}
+	for _, to := range msg.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("recipient rejected: %w", err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMIME encodes a message as multipart/alternative with base64 UTF-8 parts,
+// which keeps Sinhala and Tamil text intact through any relay
+func buildMIME(msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+	body := multipart.NewWriter(&buf)
+
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate message ID: %w", err)
+	}
+	domain := "localhost"
+	if from, err := netmail.ParseAddress(msg.From); err == nil {
+		if _, host, ok := strings.Cut(from.Address, "@"); ok {
+			domain = host
+		}
+	}
+
+	headers := []string{
+		"From: " + msg.From,
+		"To: " + strings.Join(msg.To, ", "),
+		"Subject: " + mime.BEncoding.Encode("UTF-8", msg.Subject),
+		"Date: " + msg.SentAt.Format(time.RFC1123Z),
+		"Message-ID: <" + hex.EncodeToString(id) + "@" + domain + ">",
+		"Content-Language: " + msg.Language,
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + body.Boundary(),
+	}
+	var out bytes.Buffer
+	out.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", msg.Text},
+		{"text/html; charset=UTF-8", msg.HTML},
+	} {
+		w, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build message: %w", err)
+		}
+		writeBase64Lines(w, []byte(part.content))
+	}
+	if err := body.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build message: %w", err)
+	}
+
+	out.Write(buf.Bytes())
+	return out.Bytes(), nil
+}
+
+// writeBase64Lines writes base64 in 76 character lines, as MIME requires
+func writeBase64Lines(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
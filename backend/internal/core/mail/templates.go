@@ -0,0 +1,94 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Templates are named <template>.<language>.txt and .html. The text file defines
+// a "subject" block and its remaining content is the plain text body; the HTML
+// file defines the "content" block of layout.html.
+//
+//go:embed templates/*
+var templateFiles embed.FS
+
+type templates struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+func loadTemplates() (*templates, error) {
+	t := &templates{
+		text: map[string]*texttemplate.Template{},
+		html: map[string]*htmltemplate.Template{},
+	}
+
+	names, err := fs.Glob(templateFiles, "templates/*.*.txt")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		key := strings.TrimSuffix(strings.TrimPrefix(name, "templates/"), ".txt")
+
+		text, err := texttemplate.ParseFS(templateFiles, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse mail template %s: %w", name, err)
+		}
+		if text.Lookup("subject") == nil {
+			return nil, fmt.Errorf("mail template %s has no subject", name)
+		}
+		html, err := htmltemplate.ParseFS(templateFiles, "templates/layout.html", "templates/"+key+".html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse mail template %s.html: %w", key, err)
+		}
+
+		t.text[key] = text
+		t.html[key] = html
+	}
+	return t, nil
+}
+
+// render renders a template, in English when it has no translation
+func (t *templates) render(name, language string, data any) (*Message, error) {
+	key := name + "." + language
+	if _, ok := t.text[key]; !ok {
+		language = LanguageEnglish
+		key = name + "." + language
+	}
+	text, ok := t.text[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown mail template %q", name)
+	}
+
+	var subject, body, html bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %w", key, err)
+	}
+	if err := text.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s text: %w", key, err)
+	}
+	layout := layoutData{Language: language, Subject: strings.TrimSpace(subject.String()), Data: data}
+	if err := t.html[key].ExecuteTemplate(&html, "layout", layout); err != nil {
+		return nil, fmt.Errorf("failed to render %s html: %w", key, err)
+	}
+
+	return &Message{
+		Subject:  strings.TrimSpace(subject.String()),
+		Text:     strings.TrimSpace(body.String()) + "\n",
+		HTML:     html.String(),
+		Language: language,
+	}, nil
+}
+
+// layoutData is what layout.html is rendered with; it renders the content block
+// with the template's own data
+type layoutData struct {
+	Language string
+	Subject  string
+	Data     any
+}
@@ -0,0 +1,101 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+)
+
+const (
+	africasTalkingURL        = "https://api.africastalking.com/version1/messaging"
+	africasTalkingSandboxURL = "https://api.sandbox.africastalking.com/version1/messaging"
+
+	// africasTalkingSandbox is the username of every sandbox account
+	africasTalkingSandbox = "sandbox"
+)
+
+// africasTalkingProvider sends through Africa's Talking's bulk messaging API
+type africasTalkingProvider struct {
+	url        string
+	username   string
+	apiKey     string
+	senderID   string
+	httpClient *http.Client
+}
+
+func newAfricasTalkingProvider(cfg config.SMSConfig) *africasTalkingProvider {
+	endpoint := africasTalkingURL
+	if cfg.Username == africasTalkingSandbox {
+		endpoint = africasTalkingSandboxURL
+	}
+	return &africasTalkingProvider{
+		url:        endpoint,
+		username:   cfg.Username,
+		apiKey:     cfg.APIKey,
+		senderID:   cfg.SenderID,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (p *africasTalkingProvider) Name() string { return "africastalking" }
+
+type africasTalkingResponse struct {
+	SMSMessageData struct {
+		Message    string `json:"Message"`
+		Recipients []struct {
+			StatusCode int    `json:"statusCode"`
+			Status     string `json:"status"`
+		} `json:"Recipients"`
+	} `json:"SMSMessageData"`
+}
+
+func (p *africasTalkingProvider) Send(ctx context.Context, msg *Message) error {
+	form := url.Values{
+		"username": {p.username},
+		"to":       {msg.To},
+		"message":  {msg.Text},
+	}
+	if p.senderID != "" {
+		form.Set("from", p.senderID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("apiKey", p.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// A request can be accepted while the message to the recipient is not;
+	// codes 100 to 102 mean processed, sent and queued
+	var result africasTalkingResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	recipients := result.SMSMessageData.Recipients
+	if len(recipients) == 0 {
+		return fmt.Errorf("message not accepted: %s", result.SMSMessageData.Message)
+	}
+	if code := recipients[0].StatusCode; code < 100 || code > 102 {
+		return fmt.Errorf("message rejected: %s (%d)", recipients[0].Status, code)
+	}
+	return nil
+}
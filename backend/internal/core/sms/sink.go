@@ -0,0 +1,49 @@
+package sms
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// sinkCapacity is how many of the latest messages the sink keeps
+const sinkCapacity = 100
+
+// Sink captures messages in memory instead of sending them
+type Sink struct {
+	mu       sync.Mutex
+	messages []Message
+	logger   *zap.Logger
+}
+
+func newSink(logger *zap.Logger) *Sink {
+	return &Sink{logger: logger}
+}
+
+func (s *Sink) Name() string { return SinkProvider }
+
+func (s *Sink) Send(_ context.Context, msg *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, *msg)
+	if len(s.messages) > sinkCapacity {
+		s.messages = s.messages[len(s.messages)-sinkCapacity:]
+	}
+
+	s.logger.Debug("SMS captured by sink", zap.Int("length", len([]rune(msg.Text))))
+	return nil
+}
+
+// Messages returns the captured messages, newest first
+func (s *Sink) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]Message, len(s.messages))
+	for i, msg := range s.messages {
+		messages[len(s.messages)-1-i] = msg
+	}
+	return messages
+}
@@ -0,0 +1,129 @@
+// Package sms sends text messages through a gateway provider (Africa's
+// Talking). Without a provider enabled, messages go to an in-memory sink that
+// admins can inspect, so features that send SMS can be exercised anywhere.
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"go.uber.org/zap"
+)
+
+// SinkProvider is the provider name of the in-memory sink
+const SinkProvider = "sink"
+
+// defaultCountryCode is assumed for numbers given in the local 0XXXXXXXXX form
+const defaultCountryCode = "94"
+
+// ErrInvalidRecipient is returned for phone numbers that cannot be used
+var ErrInvalidRecipient = errors.New("invalid recipient number")
+
+// Message is a text message
+type Message struct {
+	To     string    `json:"to"`
+	Text   string    `json:"text"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+// Provider delivers messages
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Sender sends messages through the configured provider
+type Sender struct {
+	provider Provider
+	sink     *Sink // set when messages are captured instead of sent
+	logger   *zap.Logger
+}
+
+// New creates a sender for the configured provider. A disabled sender captures
+// messages in the sink.
+func New(cfg config.SMSConfig, logger *zap.Logger) (*Sender, error) {
+	s := &Sender{logger: logger}
+
+	provider := cfg.Provider
+	if !cfg.Enabled {
+		provider = SinkProvider
+	}
+	switch provider {
+	case "africastalking":
+		s.provider = newAfricasTalkingProvider(cfg)
+	case SinkProvider:
+		s.sink = newSink(logger)
+		s.provider = s.sink
+	default:
+		return nil, fmt.Errorf("unknown SMS provider %q", cfg.Provider)
+	}
+
+	logger.Info("SMS sender initialized", zap.String("provider", s.provider.Name()))
+	return s, nil
+}
+
+// Provider returns the name of the provider messages are sent through
+func (s *Sender) Provider() string {
+	return s.provider.Name()
+}
+
+// Outbox returns the messages captured by the sink, newest first, and whether
+// the sink is in use
+func (s *Sender) Outbox() ([]Message, bool) {
+	if s.sink == nil {
+		return nil, false
+	}
+	return s.sink.Messages(), true
+}
+
+// Send sends a message to one phone number
+func (s *Sender) Send(ctx context.Context, to, text string) error {
+	number, err := NormalizeNumber(to)
+	if err != nil {
+		return err
+	}
+
+	msg := &Message{To: number, Text: text, SentAt: time.Now().UTC()}
+	if err := s.provider.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send SMS via %s: %w", s.provider.Name(), err)
+	}
+
+	s.logger.Info("SMS sent",
+		zap.String("provider", s.provider.Name()),
+		zap.Int("length", len([]rune(text))))
+	return nil
+}
+
+// NormalizeNumber writes a phone number in international form, +94771234567,
+// reading numbers starting with 0 as Sri Lankan
+func NormalizeNumber(number string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9':
+			return r
+		case r == ' ', r == '-', r == '(', r == ')', r == '+':
+			return -1
+		}
+		return 'x'
+	}, number)
+	if strings.ContainsRune(digits, 'x') {
+		return "", fmt.Errorf("%w: %s", ErrInvalidRecipient, number)
+	}
+
+	trimmed := strings.TrimSpace(number)
+	switch {
+	case strings.HasPrefix(trimmed, "+"):
+	case strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+	case strings.HasPrefix(digits, "0"):
+		digits = defaultCountryCode + digits[1:]
+	}
+	if len(digits) < 10 || len(digits) > 15 {
+		return "", fmt.Errorf("%w: %s", ErrInvalidRecipient, number)
+	}
+	return "+" + digits, nil
+}
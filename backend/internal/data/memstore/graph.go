@@ -0,0 +1,534 @@
+// Package memstore holds the education graph in memory, answering the read
+// queries of the pathway and typeahead services without Neo4j. It backs demo
+// mode, where the API runs on a bundled seed dataset with no infrastructure.
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/seed"
+)
+
+// maxAccessiblePrograms caps the programs returned for one qualification
+const maxAccessiblePrograms = 500
+
+// Graph is a read-only education graph built from a seed dataset. Its methods
+// mirror those of neo4j.Client, returning the same types and ordering results
+// the same way.
+type Graph struct {
+	programs map[string]seed.Program
+	names    map[string][]string // sorted names by entity kind
+	// leadsTo lists the programs a program is a prerequisite for
+	leadsTo map[string][]string
+	// institutes maps faculties and departments to their institute
+	institutes map[string]string
+}
+
+// NewGraph builds a graph from a seed dataset
+func NewGraph(dataset *seed.Dataset) *Graph {
+	g := &Graph{
+		programs:   make(map[string]seed.Program, len(dataset.Programs)),
+		names:      make(map[string][]string),
+		leadsTo:    make(map[string][]string),
+		institutes: make(map[string]string),
+	}
+
+	kinds := map[string]map[string]bool{}
+	add := func(kind, name string) {
+		if name == "" {
+			return
+		}
+		if kinds[kind] == nil {
+			kinds[kind] = map[string]bool{}
+		}
+		kinds[kind][name] = true
+	}
+
+	for _, institute := range dataset.Institutes {
+		add(neo4j.KindInstitute, institute)
+	}
+	for _, program := range dataset.Programs {
+		g.programs[program.Program] = program
+		add(neo4j.KindInstitute, program.Institute)
+		add(neo4j.KindFaculty, program.Faculty)
+		add(neo4j.KindDepartment, program.Department)
+		add(neo4j.KindProgram, program.Program)
+		for _, requirement := range program.Requirements {
+			add(neo4j.KindQualification, requirement)
+		}
+		for _, career := range program.Careers {
+			add(neo4j.KindCareer, career)
+		}
+		for _, prerequisite := range program.Prerequisites {
+			g.leadsTo[prerequisite] = append(g.leadsTo[prerequisite], program.Program)
+		}
+		for _, unit := range []string{program.Faculty, program.Department} {
+			if unit != "" {
+				g.institutes[unit] = program.Institute
+			}
+		}
+	}
+
+	for kind, set := range kinds {
+		names := make([]string, 0, len(set))
+		for name := range set {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		g.names[kind] = names
+	}
+	return g
+}
+
+// IsHealthy always reports the in-memory graph as healthy
+func (g *Graph) IsHealthy(ctx context.Context) bool {
+	return true
+}
+
+// GetAllInstitutes lists the institutes by name
+func (g *Graph) GetAllInstitutes(ctx context.Context) ([]neo4j.Institute, error) {
+	var institutes []neo4j.Institute
+	for _, name := range g.names[neo4j.KindInstitute] {
+		institutes = append(institutes, neo4j.Institute{Name: name, Type: neo4j.InstituteTypeOf(name)})
+	}
+	return institutes, nil
+}
+
+// GetAllCareers lists the careers by title
+func (g *Graph) GetAllCareers(ctx context.Context) ([]neo4j.Career, error) {
+	return careersTitled(g.names[neo4j.KindCareer]), nil
+}
+
+// GetProgramsByInstitute lists the programs an institute offers by name
+func (g *Graph) GetProgramsByInstitute(ctx context.Context, instituteName string) ([]neo4j.ProgramDetails, error) {
+	return g.listPrograms(func(p seed.Program) bool { return p.Institute == instituteName }, cmp.Compare), nil
+}
+
+// GetCompletePathway lists the programs a department offers, entry level first
+func (g *Graph) GetCompletePathway(ctx context.Context, department string) ([]neo4j.ProgramDetails, error) {
+	byLevel := func(a, b string) int { return cmp.Compare(levelRank(a), levelRank(b)) }
+	return g.listPrograms(func(p seed.Program) bool { return p.Department == department }, byLevel), nil
+}
+
+// GetPathwayByQualification lists the programs accessible from a qualification
+// within maxHops prerequisite steps in departments whose name contains
+// department, nearest to it first and then by name
+func (g *Graph) GetPathwayByQualification(ctx context.Context, department string, qualification string, maxHops int) ([]neo4j.ProgramDetails, error) {
+	distances := g.accessibleFrom(qualification, max(0, min(maxHops, neo4j.MaxPrerequisiteDepth)))
+
+	var programs []neo4j.ProgramDetails
+	for name, hops := range distances {
+		program := g.programs[name]
+		if program.Department == "" || !strings.Contains(program.Department, department) {
+			continue
+		}
+		details := g.details(program)
+		details.Hops = &hops
+		details.Level = neo4j.ProgramLevel(name)
+		programs = append(programs, details)
+	}
+	slices.SortFunc(programs, func(a, b neo4j.ProgramDetails) int {
+		return cmp.Or(
+			cmp.Compare(distances[a.Name], distances[b.Name]),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	if len(programs) > maxAccessiblePrograms {
+		programs = programs[:maxAccessiblePrograms]
+	}
+	return programs, nil
+}
+
+// GetProgramDetails describes a program
+func (g *Graph) GetProgramDetails(ctx context.Context, programName string) (*neo4j.ProgramDetails, error) {
+	program, ok := g.programs[programName]
+	if !ok {
+		return nil, fmt.Errorf("program not found: %s", programName)
+	}
+	details := g.details(program)
+	return &details, nil
+}
+
+// StreamCareerPaths passes the paths open to any of the qualifications to fn,
+// by program name, stopping at the first error fn returns
+func (g *Graph) StreamCareerPaths(ctx context.Context, qualifications []string, fn func(neo4j.EducationPath) error) error {
+	for _, name := range g.names[neo4j.KindProgram] {
+		program := g.programs[name]
+		if !slices.ContainsFunc(program.Requirements, func(q string) bool { return slices.Contains(qualifications, q) }) {
+			continue
+		}
+		err := fn(neo4j.EducationPath{
+			Programs:       []neo4j.Program{{Name: name}},
+			Qualifications: qualificationsNamed(program.Requirements),
+			Careers:        careersTitled(program.Careers),
+			Institute:      program.Institute,
+			Faculty:        program.Faculty,
+			Department:     program.Department,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamPathwayToCareer passes the paths leading to a career to fn, by program
+// name, stopping at the first error fn returns
+func (g *Graph) StreamPathwayToCareer(ctx context.Context, careerTitle string, fn func(neo4j.EducationPath) error) error {
+	for _, name := range g.names[neo4j.KindProgram] {
+		program := g.programs[name]
+		if !slices.Contains(program.Careers, careerTitle) {
+			continue
+		}
+		// Prerequisites are listed as programs of the path too
+		err := fn(neo4j.EducationPath{
+			Programs:       append([]neo4j.Program{{Name: name}}, programsNamed(program.Prerequisites)...),
+			Qualifications: qualificationsNamed(program.Requirements),
+			Careers:        []neo4j.Career{{Title: careerTitle}},
+			Institute:      program.Institute,
+			Faculty:        program.Faculty,
+			Department:     program.Department,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCareerEducation lists the programs leading to each of the careers, leaving
+// out careers that are not in the graph
+func (g *Graph) GetCareerEducation(ctx context.Context, careerTitles []string) ([]neo4j.CareerEducation, error) {
+	var careers []neo4j.CareerEducation
+	for _, title := range careerTitles {
+		if _, found := slices.BinarySearch(g.names[neo4j.KindCareer], title); !found {
+			continue
+		}
+		career := neo4j.CareerEducation{Title: title}
+		for _, name := range g.names[neo4j.KindProgram] {
+			if slices.Contains(g.programs[name].Careers, title) {
+				career.Programs = append(career.Programs, neo4j.Program{Name: name})
+			}
+		}
+		careers = append(careers, career)
+	}
+	return careers, nil
+}
+
+// GetCareerTransition retrieves what connects the programs leading to one
+// career with those leading to another
+func (g *Graph) GetCareerTransition(ctx context.Context, from, to string) (*neo4j.CareerTransitionData, error) {
+	for _, title := range []string{from, to} {
+		if _, found := slices.BinarySearch(g.names[neo4j.KindCareer], title); !found {
+			return nil, fmt.Errorf("%w: career %q", neo4j.ErrEntityNotFound, title)
+		}
+	}
+
+	data := &neo4j.CareerTransitionData{
+		From:               from,
+		To:                 to,
+		FromPrograms:       []string{},
+		FromQualifications: []string{},
+		TargetPrograms:     []neo4j.TransitionProgram{},
+	}
+	for _, name := range g.names[neo4j.KindProgram] {
+		program := g.programs[name]
+		if !slices.Contains(program.Careers, from) {
+			continue
+		}
+		data.FromPrograms = append(data.FromPrograms, name)
+		for _, requirement := range program.Requirements {
+			if !slices.Contains(data.FromQualifications, requirement) {
+				data.FromQualifications = append(data.FromQualifications, requirement)
+			}
+		}
+	}
+	for _, name := range g.names[neo4j.KindProgram] {
+		program := g.programs[name]
+		if !slices.Contains(program.Careers, to) {
+			continue
+		}
+		target := neo4j.TransitionProgram{
+			Name:         name,
+			Institute:    program.Institute,
+			Requirements: append([]string{}, program.Requirements...),
+			BuildsOn:     []string{},
+			LeadsToBoth:  slices.Contains(data.FromPrograms, name),
+		}
+		for _, prerequisite := range program.Prerequisites {
+			if slices.Contains(data.FromPrograms, prerequisite) {
+				target.BuildsOn = append(target.BuildsOn, prerequisite)
+			}
+		}
+		data.TargetPrograms = append(data.TargetPrograms, target)
+	}
+	return data, nil
+}
+
+// GetRelatedPrograms suggests programs in the same department as the programs,
+// sharing a career with them, or leading to one of the careers
+func (g *Graph) GetRelatedPrograms(ctx context.Context, programs []string, careers []string, limit int) ([]neo4j.RelatedEntity, error) {
+	related := newRelated("program")
+	for _, viewed := range programs {
+		vp, ok := g.programs[viewed]
+		if !ok {
+			continue
+		}
+		for _, name := range g.names[neo4j.KindProgram] {
+			rp := g.programs[name]
+			if name == viewed || slices.Contains(programs, name) {
+				continue
+			}
+			if vp.Department != "" && rp.Department == vp.Department {
+				related.add(name, "same_department", vp.Department)
+			}
+			for _, career := range vp.Careers {
+				if slices.Contains(rp.Careers, career) {
+					related.add(name, "shared_career", career)
+				}
+			}
+		}
+	}
+	for _, viewed := range careers {
+		for _, name := range g.names[neo4j.KindProgram] {
+			if !slices.Contains(programs, name) && slices.Contains(g.programs[name].Careers, viewed) {
+				related.add(name, "leads_to_viewed_career", viewed)
+			}
+		}
+	}
+	return related.top(limit), nil
+}
+
+// GetRelatedCareers suggests careers sharing programs with the careers or that
+// the programs lead to
+func (g *Graph) GetRelatedCareers(ctx context.Context, programs []string, careers []string, limit int) ([]neo4j.RelatedEntity, error) {
+	related := newRelated("career")
+	for _, viewed := range careers {
+		for _, name := range g.names[neo4j.KindProgram] {
+			program := g.programs[name]
+			if !slices.Contains(program.Careers, viewed) {
+				continue
+			}
+			for _, career := range program.Careers {
+				if career != viewed && !slices.Contains(careers, career) {
+					related.add(career, "shared_program", name)
+				}
+			}
+		}
+	}
+	for _, viewed := range programs {
+		for _, career := range g.programs[viewed].Careers {
+			if !slices.Contains(careers, career) {
+				related.add(career, "from_viewed_program", viewed)
+			}
+		}
+	}
+	return related.top(limit), nil
+}
+
+// ListNames returns the names of all entities of a kind
+func (g *Graph) ListNames(ctx context.Context, kind string) ([]string, error) {
+	if !neo4j.IsEntityKind(kind) {
+		return nil, fmt.Errorf("%w: unknown kind %q", neo4j.ErrInvalidEntity, kind)
+	}
+	return slices.Clone(g.names[kind]), nil
+}
+
+// RecentlyAddedPrograms returns no programs: the bundled dataset carries no
+// provenance, so none of its programs has a date it was added
+func (g *Graph) RecentlyAddedPrograms(ctx context.Context, limit int) ([]neo4j.ProgramDetails, error) {
+	return []neo4j.ProgramDetails{}, nil
+}
+
+// ExistingNames returns which of the given names exist for an entity kind
+func (g *Graph) ExistingNames(ctx context.Context, kind string, names []string) (map[string]bool, error) {
+	if !neo4j.IsEntityKind(kind) {
+		return nil, fmt.Errorf("%w: unknown kind %q", neo4j.ErrInvalidEntity, kind)
+	}
+	existing := make(map[string]bool)
+	for _, name := range names {
+		if _, found := slices.BinarySearch(g.names[kind], name); found {
+			existing[name] = true
+		}
+	}
+	return existing, nil
+}
+
+// SearchNames returns up to limit entities of one kind whose names contain every
+// given word (lowercase), shortest names first
+func (g *Graph) SearchNames(ctx context.Context, kind string, words []string, limit int) ([]neo4j.NameMatch, error) {
+	if !neo4j.IsEntityKind(kind) {
+		return nil, fmt.Errorf("%w: unknown kind %q", neo4j.ErrInvalidEntity, kind)
+	}
+
+	var matches []neo4j.NameMatch
+	for _, name := range g.names[kind] {
+		lower := strings.ToLower(name)
+		if !allContained(lower, words) {
+			continue
+		}
+		match := neo4j.NameMatch{Name: name}
+		switch kind {
+		case neo4j.KindProgram:
+			match.Institute = g.programs[name].Institute
+		case neo4j.KindFaculty, neo4j.KindDepartment:
+			match.Institute = g.institutes[name]
+		}
+		matches = append(matches, match)
+	}
+	slices.SortFunc(matches, func(a, b neo4j.NameMatch) int {
+		return cmp.Or(cmp.Compare(len(a.Name), len(b.Name)), cmp.Compare(a.Name, b.Name))
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// PathwayProfiles returns the profiles of the programs or careers with the given
+// names, or of all of them when names is nil
+func (g *Graph) PathwayProfiles(ctx context.Context, kind string, names []string) ([]neo4j.PathwayProfile, error) {
+	if kind != neo4j.KindProgram && kind != neo4j.KindCareer {
+		return nil, fmt.Errorf("%w: profiles are kept for programs and careers, not %q", neo4j.ErrInvalidEntity, kind)
+	}
+
+	var profiles []neo4j.PathwayProfile
+	for _, name := range g.names[kind] {
+		if names != nil && !slices.Contains(names, name) {
+			continue
+		}
+		profile := neo4j.PathwayProfile{Kind: kind, Name: name, Related: []string{}}
+		if kind == neo4j.KindProgram {
+			program := g.programs[name]
+			profile.Institute = program.Institute
+			profile.Requirements = slices.Sorted(slices.Values(program.Requirements))
+			profile.Related = append(profile.Related, program.Careers...)
+		} else {
+			for _, programName := range g.names[neo4j.KindProgram] {
+				if slices.Contains(g.programs[programName].Careers, name) {
+					profile.Related = append(profile.Related, programName)
+				}
+			}
+		}
+		slices.Sort(profile.Related)
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+// MarkAccessibilityStale does nothing: the graph never changes
+func (g *Graph) MarkAccessibilityStale() {}
+
+// RebuildAccessibility returns the number of qualification to program access
+// pairs. Accessibility is computed on every query, so there is nothing to build.
+func (g *Graph) RebuildAccessibility(ctx context.Context) (int, error) {
+	pairs := 0
+	for _, qualification := range g.names[neo4j.KindQualification] {
+		pairs += len(g.accessibleFrom(qualification, neo4j.MaxPrerequisiteDepth))
+	}
+	return pairs, nil
+}
+
+// accessibleFrom returns the programs accessible from a qualification within
+// maxHops prerequisite steps with their distance: the number of prerequisite
+// steps after a program requiring it
+func (g *Graph) accessibleFrom(qualification string, maxHops int) map[string]int {
+	distances := make(map[string]int)
+	var frontier []string
+	for _, name := range g.names[neo4j.KindProgram] {
+		if slices.Contains(g.programs[name].Requirements, qualification) {
+			distances[name] = 0
+			frontier = append(frontier, name)
+		}
+	}
+	for depth := 1; depth <= maxHops && len(frontier) > 0; depth++ {
+		var next []string
+		for _, name := range frontier {
+			for _, dependent := range g.leadsTo[name] {
+				if _, seen := distances[dependent]; !seen {
+					distances[dependent] = depth
+					next = append(next, dependent)
+				}
+			}
+		}
+		frontier = next
+	}
+	return distances
+}
+
+// listPrograms lists the programs matching keep, ordered by compare on their
+// names
+func (g *Graph) listPrograms(keep func(seed.Program) bool, compare func(a, b string) int) []neo4j.ProgramDetails {
+	var programs []neo4j.ProgramDetails
+	for _, name := range g.names[neo4j.KindProgram] {
+		if program := g.programs[name]; keep(program) {
+			programs = append(programs, g.details(program))
+		}
+	}
+	slices.SortStableFunc(programs, func(a, b neo4j.ProgramDetails) int { return compare(a.Name, b.Name) })
+	return programs
+}
+
+func (g *Graph) details(program seed.Program) neo4j.ProgramDetails {
+	return neo4j.ProgramDetails{
+		Name:          program.Program,
+		Institute:     program.Institute,
+		Faculty:       program.Faculty,
+		Department:    program.Department,
+		Requirements:  qualificationsNamed(program.Requirements),
+		Prerequisites: programsNamed(program.Prerequisites),
+		CareerPaths:   careersTitled(program.Careers),
+	}
+}
+
+// levelRank orders programs entry level first, as the complete pathway query does
+func levelRank(name string) int {
+	switch {
+	case strings.Contains(name, "NVQ"):
+		return 1
+	case strings.Contains(name, "Certificate"):
+		return 2
+	case strings.Contains(name, "Bachelor"):
+		return 3
+	default:
+		return 4
+	}
+}
+
+func allContained(name string, words []string) bool {
+	for _, word := range words {
+		if !strings.Contains(name, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func qualificationsNamed(names []string) []neo4j.Qualification {
+	qualifications := make([]neo4j.Qualification, 0, len(names))
+	for _, name := range names {
+		qualifications = append(qualifications, neo4j.Qualification{Name: name})
+	}
+	return qualifications
+}
+
+func programsNamed(names []string) []neo4j.Program {
+	programs := make([]neo4j.Program, 0, len(names))
+	for _, name := range names {
+		programs = append(programs, neo4j.Program{Name: name})
+	}
+	return programs
+}
+
+func careersTitled(titles []string) []neo4j.Career {
+	careers := make([]neo4j.Career, 0, len(titles))
+	for _, title := range titles {
+		careers = append(careers, neo4j.Career{Title: title})
+	}
+	return careers
+}
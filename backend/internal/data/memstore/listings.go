@@ -0,0 +1,120 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/seed"
+)
+
+// ListInstitutes returns a page of the institutes matching a filter, by name,
+// skipping the first offset. Seeded institutes have no contact details, so a
+// district filter matches none.
+func (g *Graph) ListInstitutes(ctx context.Context, filter neo4j.InstituteFilter, offset, limit int) ([]neo4j.Institute, error) {
+	if strings.TrimSpace(filter.District) != "" {
+		return []neo4j.Institute{}, nil
+	}
+
+	institutes := []neo4j.Institute{}
+	for _, name := range g.names[neo4j.KindInstitute] {
+		institute := neo4j.Institute{Name: name, Type: neo4j.InstituteTypeOf(name)}
+		if containsFold(name, filter.Query) && matchesFold(institute.Type, filter.Type) {
+			institutes = append(institutes, institute)
+		}
+	}
+	return page(institutes, offset, limit), nil
+}
+
+// ListCareers returns a page of the careers matching a filter, by title,
+// skipping the first offset
+func (g *Graph) ListCareers(ctx context.Context, filter neo4j.CareerFilter, offset, limit int) ([]neo4j.Career, error) {
+	reachable := make(map[string]bool)
+	for _, program := range g.programs {
+		if !matchesFold(program.Department, filter.Department) ||
+			!matchesFold(neo4j.InstituteTypeOf(program.Institute), filter.InstituteType) {
+			continue
+		}
+		for _, career := range program.Careers {
+			reachable[career] = true
+		}
+	}
+
+	var titles []string
+	for _, title := range g.names[neo4j.KindCareer] {
+		if reachable[title] && containsFold(title, filter.Query) {
+			titles = append(titles, title)
+		}
+	}
+	return page(careersTitled(titles), offset, limit), nil
+}
+
+// ListProgramsByInstitute returns a page of the programs an institute offers
+// matching a filter, by name, skipping the first offset. As no seeded program
+// suits working students and no seeded institute has a district, the filters
+// asking for them match none.
+func (g *Graph) ListProgramsByInstitute(ctx context.Context, instituteName string, filter neo4j.ProgramFilter, offset, limit int) ([]neo4j.ProgramDetails, error) {
+	programs := g.listPrograms(func(p seed.Program) bool {
+		return p.Institute == instituteName && !filter.WorkingStudent && strings.TrimSpace(filter.District) == "" &&
+			containsFold(p.Program, filter.Query) &&
+			matchesFold(p.Faculty, filter.Faculty) &&
+			matchesFold(p.Department, filter.Department)
+	}, cmp.Compare)
+	if programs == nil {
+		programs = []neo4j.ProgramDetails{}
+	}
+	return page(programs, offset, limit), nil
+}
+
+// page returns up to limit items after the first offset
+func page[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// containsFold reports whether s contains substr, ignoring case
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(strings.TrimSpace(substr)))
+}
+
+// matchesFold reports whether s equals a filter value, ignoring case; an empty
+// filter matches everything
+func matchesFold(s, filter string) bool {
+	filter = strings.TrimSpace(filter)
+	return filter == "" || strings.EqualFold(s, filter)
+}
+
+// WorkingStudentPrograms returns the programs that can be completed while
+// employed. Seed datasets record no schedules or delivery modes, so none can.
+func (g *Graph) WorkingStudentPrograms(ctx context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+// ListScholarships returns no scholarships, as seed datasets record none
+func (g *Graph) ListScholarships(ctx context.Context, filter neo4j.ScholarshipFilter) ([]neo4j.Scholarship, error) {
+	return []neo4j.Scholarship{}, nil
+}
+
+// ProgramScholarships returns no scholarships, as seed datasets record none
+func (g *Graph) ProgramScholarships(ctx context.Context, programName string) ([]neo4j.Scholarship, error) {
+	return []neo4j.Scholarship{}, nil
+}
+
+// InstitutesInDistrict returns no institutes, as seeded institutes have no
+// district
+func (g *Graph) InstitutesInDistrict(ctx context.Context, districtName string) ([]string, error) {
+	return []string{}, nil
+}
+
+// ListProgramsByDistrict returns no programs, as seeded institutes have no
+// district
+func (g *Graph) ListProgramsByDistrict(ctx context.Context, districtName string, filter neo4j.ProgramFilter, offset, limit int) ([]neo4j.ProgramDetails, error) {
+	return []neo4j.ProgramDetails{}, nil
+}
@@ -0,0 +1,49 @@
+package memstore
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+)
+
+// related tallies suggestions the way the recommendation queries do: one point
+// per matching reason and connecting entity, with distinct reasons and vias
+type related struct {
+	entityType string
+	byName     map[string]*neo4j.RelatedEntity
+}
+
+func newRelated(entityType string) *related {
+	return &related{entityType: entityType, byName: make(map[string]*neo4j.RelatedEntity)}
+}
+
+func (r *related) add(name, reason, via string) {
+	entity, ok := r.byName[name]
+	if !ok {
+		entity = &neo4j.RelatedEntity{Name: name, Type: r.entityType}
+		r.byName[name] = entity
+	}
+	if !slices.Contains(entity.Reasons, reason) {
+		entity.Reasons = append(entity.Reasons, reason)
+	}
+	if !slices.Contains(entity.Via, via) {
+		entity.Via = append(entity.Via, via)
+	}
+	entity.Score++
+}
+
+// top returns up to limit suggestions, highest score first
+func (r *related) top(limit int) []neo4j.RelatedEntity {
+	var entities []neo4j.RelatedEntity
+	for _, entity := range r.byName {
+		entities = append(entities, *entity)
+	}
+	slices.SortFunc(entities, func(a, b neo4j.RelatedEntity) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Name, b.Name))
+	})
+	if len(entities) > limit {
+		entities = entities[:limit]
+	}
+	return entities
+}
@@ -0,0 +1,111 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+)
+
+// Scores of the ways a search word can match a word of a name, weighted like
+// the clauses of neo4j.FullTextQuery
+const (
+	exactWordScore  = 3.0
+	prefixWordScore = 1.0
+	fuzzyWordScore  = 0.5
+)
+
+// SearchEntities returns up to limit entities of the given kinds (all
+// neo4j.SearchableKinds when empty) whose names match the query, best match
+// first. Like the full-text index, words match exactly, as the start of a name
+// word, or within neo4j.FuzzyEdits typos.
+func (g *Graph) SearchEntities(ctx context.Context, query string, kinds []string, limit int) ([]neo4j.EntityHit, error) {
+	if len(kinds) == 0 {
+		kinds = neo4j.SearchableKinds
+	}
+	for _, kind := range kinds {
+		if !slices.Contains(neo4j.SearchableKinds, kind) {
+			return nil, fmt.Errorf("%w: %q is not searchable", neo4j.ErrInvalidEntity, kind)
+		}
+	}
+
+	words := searchWords(query)
+	hits := []neo4j.EntityHit{}
+	if len(words) == 0 {
+		return hits, nil
+	}
+
+	for _, kind := range kinds {
+		for _, name := range g.names[kind] {
+			score := nameScore(searchWords(name), words)
+			if score == 0 {
+				continue
+			}
+			hit := neo4j.EntityHit{Kind: kind, Name: name, Score: score}
+			if kind == neo4j.KindProgram {
+				hit.Institute = g.programs[name].Institute
+			}
+			hits = append(hits, hit)
+		}
+	}
+	slices.SortFunc(hits, func(a, b neo4j.EntityHit) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(len(a.Name), len(b.Name)), cmp.Compare(a.Name, b.Name))
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// nameScore adds up how well each search word matches its best word of a name
+func nameScore(nameWords, words []string) float64 {
+	total := 0.0
+	for _, word := range words {
+		best := 0.0
+		edits := neo4j.FuzzyEdits(word)
+		for _, nameWord := range nameWords {
+			switch {
+			case nameWord == word:
+				best = max(best, exactWordScore)
+			case strings.HasPrefix(nameWord, word):
+				best = max(best, prefixWordScore)
+			case edits > 0 && editDistance(nameWord, word) <= edits:
+				best = max(best, fuzzyWordScore)
+			}
+		}
+		total += best
+	}
+	return total
+}
+
+// editDistance is the Levenshtein distance between two words
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,217 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+)
+
+// GetSubgraph returns the entities within depth relationships of an entity,
+// nearest first and at most maxNodes besides the center, with the
+// relationships between them. Kind may be empty to look the name up among all
+// kinds, preferring programs, then careers, institutes and qualifications.
+func (g *Graph) GetSubgraph(ctx context.Context, kind, name string, depth, maxNodes int) (*neo4j.Subgraph, error) {
+	kinds := []string{neo4j.KindProgram, neo4j.KindCareer, neo4j.KindInstitute, neo4j.KindQualification, neo4j.KindDepartment, neo4j.KindFaculty}
+	if kind != "" {
+		if !neo4j.IsEntityKind(kind) {
+			return nil, fmt.Errorf("%w: unknown kind %q", neo4j.ErrInvalidEntity, kind)
+		}
+		kinds = []string{kind}
+	}
+
+	center := ""
+	for _, k := range kinds {
+		if _, found := slices.BinarySearch(g.names[k], name); found {
+			center = neo4j.NodeID(k, name)
+			break
+		}
+	}
+	if center == "" {
+		return nil, fmt.Errorf("%w: %q", neo4j.ErrEntityNotFound, name)
+	}
+
+	edges := g.edges()
+	neighbours := make(map[string][]string)
+	for _, edge := range edges {
+		neighbours[edge.Source] = append(neighbours[edge.Source], edge.Target)
+		neighbours[edge.Target] = append(neighbours[edge.Target], edge.Source)
+	}
+
+	// Breadth first, so each entity is reached at its shortest distance
+	distances := map[string]int{center: 0}
+	frontier := []string{center}
+	var found []neo4j.GraphNode
+	for distance := 1; distance <= depth && len(frontier) > 0; distance++ {
+		var next []string
+		for _, id := range frontier {
+			for _, neighbour := range neighbours[id] {
+				if _, seen := distances[neighbour]; seen {
+					continue
+				}
+				distances[neighbour] = distance
+				next = append(next, neighbour)
+				found = append(found, graphNode(neighbour, distance))
+			}
+		}
+		frontier = next
+	}
+	slices.SortFunc(found, func(a, b neo4j.GraphNode) int {
+		return cmp.Or(cmp.Compare(a.Distance, b.Distance), cmp.Compare(a.Label, b.Label))
+	})
+
+	subgraph := &neo4j.Subgraph{Center: center, Edges: []neo4j.GraphEdge{}}
+	if len(found) > maxNodes {
+		found = found[:maxNodes]
+		subgraph.Truncated = true
+	}
+	subgraph.Nodes = append([]neo4j.GraphNode{graphNode(center, 0)}, found...)
+
+	kept := make(map[string]bool, len(subgraph.Nodes))
+	for _, node := range subgraph.Nodes {
+		kept[node.ID] = true
+	}
+	for _, edge := range edges {
+		if kept[edge.Source] && kept[edge.Target] {
+			subgraph.Edges = append(subgraph.Edges, edge)
+		}
+	}
+	return subgraph, nil
+}
+
+// edges returns every relationship of the graph once, as the importer stores
+// them: programs hang off their department, or their institute when they have
+// none
+func (g *Graph) edges() []neo4j.GraphEdge {
+	seen := make(map[string]bool)
+	var edges []neo4j.GraphEdge
+	add := func(sourceKind, source, targetKind, target, relType string) {
+		if source == "" || target == "" {
+			return
+		}
+		edge := neo4j.NewGraphEdge(neo4j.NodeID(sourceKind, source), neo4j.NodeID(targetKind, target), relType)
+		if !seen[edge.ID] {
+			seen[edge.ID] = true
+			edges = append(edges, edge)
+		}
+	}
+
+	for _, name := range g.names[neo4j.KindProgram] {
+		program := g.programs[name]
+		if program.Department != "" {
+			add(neo4j.KindInstitute, program.Institute, neo4j.KindFaculty, program.Faculty, neo4j.RelHasFaculty)
+			add(neo4j.KindFaculty, program.Faculty, neo4j.KindDepartment, program.Department, neo4j.RelHasDepartment)
+			add(neo4j.KindDepartment, program.Department, neo4j.KindProgram, name, neo4j.RelOffers)
+		} else {
+			add(neo4j.KindInstitute, program.Institute, neo4j.KindProgram, name, neo4j.RelOffers)
+		}
+		for _, requirement := range program.Requirements {
+			add(neo4j.KindProgram, name, neo4j.KindQualification, requirement, neo4j.RelRequires)
+		}
+		for _, prerequisite := range program.Prerequisites {
+			if _, ok := g.programs[prerequisite]; ok {
+				add(neo4j.KindProgram, prerequisite, neo4j.KindProgram, name, neo4j.RelIsPrerequisite)
+			}
+		}
+		for _, career := range program.Careers {
+			add(neo4j.KindProgram, name, neo4j.KindCareer, career, neo4j.RelLeadsTo)
+		}
+	}
+	return edges
+}
+
+func graphNode(id string, distance int) neo4j.GraphNode {
+	kind, name := neo4j.ParseNodeID(id)
+	return neo4j.GraphNode{ID: id, Label: name, Group: kind, Distance: distance}
+}
+
+// StreamPathwayGraph passes the whole education graph, or the part of it around
+// a department when one is given, to node and edge: every node, by kind and
+// name, before the first edge
+func (g *Graph) StreamPathwayGraph(ctx context.Context, department string, node func(neo4j.GraphNode) error, edge func(neo4j.GraphEdge) error) error {
+	edges := g.edges()
+
+	var ids []string
+	if department == "" {
+		for _, kind := range []string{neo4j.KindInstitute, neo4j.KindFaculty, neo4j.KindDepartment, neo4j.KindProgram, neo4j.KindQualification, neo4j.KindCareer} {
+			for _, name := range g.names[kind] {
+				ids = append(ids, neo4j.NodeID(kind, name))
+			}
+		}
+	} else {
+		ids = departmentScope(edges, neo4j.NodeID(neo4j.KindDepartment, department))
+	}
+
+	nodes := make([]neo4j.GraphNode, 0, len(ids))
+	kept := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		nodes = append(nodes, graphNode(id, 0))
+		kept[id] = true
+	}
+	slices.SortFunc(nodes, func(a, b neo4j.GraphNode) int {
+		return cmp.Or(cmp.Compare(a.Group, b.Group), cmp.Compare(a.Label, b.Label))
+	})
+	for _, n := range nodes {
+		if err := node(n); err != nil {
+			return err
+		}
+	}
+
+	edges = slices.DeleteFunc(edges, func(e neo4j.GraphEdge) bool {
+		return !kept[e.Source] || !kept[e.Target]
+	})
+	slices.SortFunc(edges, func(a, b neo4j.GraphEdge) int {
+		_, sourceA := neo4j.ParseNodeID(a.Source)
+		_, sourceB := neo4j.ParseNodeID(b.Source)
+		_, targetA := neo4j.ParseNodeID(a.Target)
+		_, targetB := neo4j.ParseNodeID(b.Target)
+		return cmp.Or(cmp.Compare(sourceA, sourceB), cmp.Compare(a.Type, b.Type), cmp.Compare(targetA, targetB))
+	})
+	for _, e := range edges {
+		if err := edge(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// departmentScope returns the IDs of a department, the faculty and institute
+// above it, the programs it offers, and the qualifications, careers and
+// programs those are directly linked to
+func departmentScope(edges []neo4j.GraphEdge, department string) []string {
+	scope := make(map[string]bool)
+	programs := make(map[string]bool)
+	for _, e := range edges {
+		switch {
+		case e.Type == neo4j.RelOffers && e.Source == department:
+			programs[e.Target] = true
+			scope[e.Target] = true
+		case e.Type == neo4j.RelHasDepartment && e.Target == department:
+			scope[e.Source] = true
+			for _, above := range edges {
+				if above.Type == neo4j.RelHasFaculty && above.Target == e.Source {
+					scope[above.Source] = true
+				}
+			}
+		}
+	}
+	scope[department] = true
+	for _, e := range edges {
+		switch e.Type {
+		case neo4j.RelRequires, neo4j.RelIsPrerequisite, neo4j.RelLeadsTo:
+			if programs[e.Source] {
+				scope[e.Target] = true
+			}
+			if programs[e.Target] {
+				scope[e.Source] = true
+			}
+		}
+	}
+	ids := make([]string, 0, len(scope))
+	for id := range scope {
+		ids = append(ids, id)
+	}
+	return ids
+}
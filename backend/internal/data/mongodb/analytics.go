@@ -0,0 +1,260 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// Analytics counters collection name
+	AnalyticsCollection = "analytics_daily_counts"
+
+	// How long daily analytics counters are kept (2 years)
+	DefaultAnalyticsRetention = 2 * 365 * 24 * time.Hour
+
+	// Analytics event types
+	EventQualificationSearched = "qualification_searched"
+	EventCareerTargeted        = "career_targeted"
+
+	// Searches and eligibility checks that found nothing, whose values point at
+	// missing programs and qualification aliases
+	EventSearchNoResults      = "search_no_results"
+	EventEligibilityNoResults = "eligibility_no_results"
+
+	// District value used when the caller did not share one
+	UnknownDistrict = "unknown"
+)
+
+// AnalyticsEvent is a single anonymized query event. It deliberately carries no
+// user, session or network identifiers.
+type AnalyticsEvent struct {
+	EventType string
+	Value     string
+	District  string
+}
+
+// TrendItem is an aggregated count for one value of an event type
+type TrendItem struct {
+	Value string `bson:"_id" json:"value"`
+	Count int64  `bson:"count" json:"count"`
+}
+
+// GapItem is how often a value of an event type was recorded and the last day
+// it was
+type GapItem struct {
+	Value    string `bson:"_id" json:"value"`
+	Count    int64  `bson:"count" json:"count"`
+	LastSeen string `bson:"last_seen" json:"last_seen"`
+}
+
+// DailyCount is the number of events recorded on one day
+type DailyCount struct {
+	Day   string `bson:"_id" json:"day"`
+	Count int64  `bson:"count" json:"count"`
+}
+
+// AnalyticsStore keeps anonymized usage counters. Events are folded into daily
+// counters on write, so individual queries are never stored.
+type AnalyticsStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+	retention  time.Duration
+}
+
+// NewAnalyticsStore creates a new analytics store
+func NewAnalyticsStore(client *Client, logger *zap.Logger) *AnalyticsStore {
+	store := &AnalyticsStore{
+		client:     client,
+		collection: client.GetCollection(AnalyticsCollection),
+		logger:     logger,
+		retention:  DefaultAnalyticsRetention,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates necessary indexes for counter upserts and trend queries
+func (s *AnalyticsStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "day", Value: 1},
+				{Key: "event_type", Value: 1},
+				{Key: "value", Value: 1},
+				{Key: "district", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("day_event_value_district_idx"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "event_type", Value: 1},
+				{Key: "date", Value: -1},
+			},
+			Options: options.Index().SetName("event_date_idx"),
+		},
+		{
+			Keys: bson.D{{Key: "date", Value: 1}},
+			Options: options.Index().
+				SetExpireAfterSeconds(int32(s.retention.Seconds())). // Old counters are removed automatically
+				SetName("retention_index"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for analytics", zap.Error(err))
+	} else {
+		s.logger.Info("Analytics indexes created successfully")
+	}
+}
+
+// Record adds events to today's counters
+func (s *AnalyticsStore) Record(ctx context.Context, events []AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	day := date.Format("2006-01-02")
+
+	models := make([]mongo.WriteModel, 0, len(events))
+	for _, event := range events {
+		filter := bson.M{
+			"day":        day,
+			"event_type": event.EventType,
+			"value":      event.Value,
+			"district":   event.District,
+		}
+		update := bson.M{
+			"$inc":         bson.M{"count": 1},
+			"$setOnInsert": bson.M{"date": date},
+		}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	if _, err := s.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to record analytics events: %w", err)
+	}
+	return nil
+}
+
+// TopValues returns the most frequent values of an event type since a given time,
+// optionally restricted to a district. Values seen fewer than minCount times are
+// left out so small groups cannot be singled out.
+func (s *AnalyticsStore) TopValues(ctx context.Context, eventType, district string, since time.Time, minCount int64, limit int) ([]TrendItem, error) {
+	match := bson.M{
+		"event_type": eventType,
+		"date":       bson.M{"$gte": since},
+	}
+	if district != "" {
+		match["district"] = district
+	}
+
+	return s.aggregateCounts(ctx, match, "$value", minCount, limit)
+}
+
+// DistrictCounts returns the number of events per district since a given time
+func (s *AnalyticsStore) DistrictCounts(ctx context.Context, eventType string, since time.Time, minCount int64) ([]TrendItem, error) {
+	match := bson.M{
+		"date": bson.M{"$gte": since},
+	}
+	if eventType != "" {
+		match["event_type"] = eventType
+	}
+
+	return s.aggregateCounts(ctx, match, "$district", minCount, 0)
+}
+
+// DailyCounts returns the number of events per day since a given time
+func (s *AnalyticsStore) DailyCounts(ctx context.Context, eventType, district string, since time.Time) ([]DailyCount, error) {
+	match := bson.M{
+		"event_type": eventType,
+		"date":       bson.M{"$gte": since},
+	}
+	if district != "" {
+		match["district"] = district
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$day", "count": bson.M{"$sum": "$count"}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate daily analytics: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	counts := []DailyCount{}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, fmt.Errorf("failed to decode daily analytics: %w", err)
+	}
+	return counts, nil
+}
+
+// GapValues returns the most frequent values of an event type since a given
+// time with the last day each was seen. Values seen fewer than minCount times
+// are left out.
+func (s *AnalyticsStore) GapValues(ctx context.Context, eventType string, since time.Time, minCount int64, limit int) ([]GapItem, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_type": eventType, "date": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       "$value",
+			"count":     bson.M{"$sum": "$count"},
+			"last_seen": bson.M{"$max": "$day"},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gte": minCount}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate analytics gaps: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	items := []GapItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode analytics gaps: %w", err)
+	}
+	return items, nil
+}
+
+func (s *AnalyticsStore) aggregateCounts(ctx context.Context, match bson.M, groupBy string, minCount int64, limit int) ([]TrendItem, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": groupBy, "count": bson.M{"$sum": "$count"}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gte": minCount}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate analytics: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	items := []TrendItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode analytics: %w", err)
+	}
+	return items, nil
+}
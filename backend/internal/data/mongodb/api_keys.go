@@ -0,0 +1,240 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// API key collection names
+const (
+	APIKeyCollection      = "api_keys"
+	APIKeyUsageCollection = "api_key_usage"
+)
+
+// APIKeyQuota caps a key's use per calendar month (UTC). Zero leaves a measure
+// unlimited.
+type APIKeyQuota struct {
+	MonthlyRequests    int64 `bson:"monthly_requests" json:"monthly_requests"`
+	MonthlyGenerations int64 `bson:"monthly_generations" json:"monthly_generations"`
+	MonthlyBytes       int64 `bson:"monthly_bytes" json:"monthly_bytes"`
+}
+
+// APIKey identifies a partner calling the API. Only a hash of the secret is
+// stored; the secret itself is shown once, when the key is created.
+type APIKey struct {
+	ID        string      `bson:"_id" json:"id"`
+	Name      string      `bson:"name" json:"name"`
+	KeyHash   string      `bson:"key_hash" json:"-"`
+	Prefix    string      `bson:"prefix" json:"prefix"` // start of the secret, to tell keys apart
+	Quota     APIKeyQuota `bson:"quota" json:"quota"`
+	CreatedAt time.Time   `bson:"created_at" json:"created_at"`
+	RevokedAt *time.Time  `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+// UsageCounts is what a key used
+type UsageCounts struct {
+	Requests    int64 `bson:"requests" json:"requests"`
+	Generations int64 `bson:"generations" json:"generations"` // LLM generations the requests triggered
+	Bytes       int64 `bson:"bytes" json:"bytes"`             // request and response bodies
+}
+
+// IsZero reports whether nothing was used
+func (u UsageCounts) IsZero() bool {
+	return u == UsageCounts{}
+}
+
+// APIKeyUsage is a key's use in one calendar month, e.g. "2026-10"
+type APIKeyUsage struct {
+	KeyID       string `bson:"key_id" json:"key_id"`
+	Month       string `bson:"month" json:"month"`
+	UsageCounts `bson:",inline"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// APIKeyStore persists partner API keys and their monthly usage
+type APIKeyStore struct {
+	client *Client
+	keys   *mongo.Collection
+	usage  *mongo.Collection
+	logger *zap.Logger
+}
+
+// NewAPIKeyStore creates a new API key store
+func NewAPIKeyStore(client *Client, logger *zap.Logger) *APIKeyStore {
+	store := &APIKeyStore{
+		client: client,
+		keys:   client.GetCollection(APIKeyCollection),
+		usage:  client.GetCollection(APIKeyUsageCollection),
+		logger: logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the key hash and monthly usage indexes
+func (s *APIKeyStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	keyIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "key_hash", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("key_hash_idx"),
+		},
+	}
+	usageIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "key_id", Value: 1}, {Key: "month", Value: -1}},
+			Options: options.Index().SetUnique(true).SetName("key_month_idx"),
+		},
+	}
+
+	if _, err := s.keys.Indexes().CreateMany(ctx, keyIndexes); err != nil {
+		s.logger.Error("Failed to create indexes for API keys", zap.Error(err))
+		return
+	}
+	if _, err := s.usage.Indexes().CreateMany(ctx, usageIndexes); err != nil {
+		s.logger.Error("Failed to create indexes for API key usage", zap.Error(err))
+		return
+	}
+	s.logger.Info("API key indexes created successfully")
+}
+
+// Create stores a new key, assigning its ID
+func (s *APIKeyStore) Create(ctx context.Context, key *APIKey) error {
+	key.ID = uuid.New().String()
+	key.CreatedAt = time.Now()
+
+	if _, err := s.keys.InsertOne(ctx, key); err != nil {
+		s.logger.Error("Failed to create API key",
+			zap.String("name", key.Name),
+			zap.Error(err))
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+// Get returns a key by ID, or nil when it does not exist
+func (s *APIKeyStore) Get(ctx context.Context, id string) (*APIKey, error) {
+	return s.findOne(ctx, bson.M{"_id": id})
+}
+
+// GetByHash returns the key with the given secret hash, or nil when there is none
+func (s *APIKeyStore) GetByHash(ctx context.Context, hash string) (*APIKey, error) {
+	return s.findOne(ctx, bson.M{"key_hash": hash})
+}
+
+func (s *APIKeyStore) findOne(ctx context.Context, filter bson.M) (*APIKey, error) {
+	var key APIKey
+	err := s.keys.FindOne(ctx, filter).Decode(&key)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return &key, nil
+}
+
+// List returns all keys, newest first
+func (s *APIKeyStore) List(ctx context.Context) ([]APIKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := s.keys.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	keys := []APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// SetQuota replaces a key's quota, returning the updated key or nil when it does
+// not exist
+func (s *APIKeyStore) SetQuota(ctx context.Context, id string, quota APIKeyQuota) (*APIKey, error) {
+	return s.update(ctx, id, bson.M{"$set": bson.M{"quota": quota}})
+}
+
+// Revoke marks a key revoked, returning the updated key or nil when it does not
+// exist. Revoking a revoked key keeps its first revocation time.
+func (s *APIKeyStore) Revoke(ctx context.Context, id string) (*APIKey, error) {
+	return s.update(ctx, id, bson.A{
+		bson.M{"$set": bson.M{"revoked_at": bson.M{"$ifNull": bson.A{"$revoked_at", time.Now()}}}},
+	})
+}
+
+func (s *APIKeyStore) update(ctx context.Context, id string, update any) (*APIKey, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var key APIKey
+	err := s.keys.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&key)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update API key: %w", err)
+	}
+	return &key, nil
+}
+
+// AddUsage adds counts to a key's usage in a month and returns the month's totals
+func (s *APIKeyStore) AddUsage(ctx context.Context, keyID, month string, counts UsageCounts) (*APIKeyUsage, error) {
+	update := bson.M{
+		"$inc": bson.M{
+			"requests":    counts.Requests,
+			"generations": counts.Generations,
+			"bytes":       counts.Bytes,
+		},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var usage APIKeyUsage
+	err := s.usage.FindOneAndUpdate(ctx, bson.M{"key_id": keyID, "month": month}, update, opts).Decode(&usage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record API key usage: %w", err)
+	}
+	return &usage, nil
+}
+
+// GetUsage returns a key's usage in a month, or nil when it used nothing
+func (s *APIKeyStore) GetUsage(ctx context.Context, keyID, month string) (*APIKeyUsage, error) {
+	var usage APIKeyUsage
+	err := s.usage.FindOne(ctx, bson.M{"key_id": keyID, "month": month}).Decode(&usage)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key usage: %w", err)
+	}
+	return &usage, nil
+}
+
+// ListUsage returns a key's usage in up to limit of its most recent months, newest
+// first
+func (s *APIKeyStore) ListUsage(ctx context.Context, keyID string, limit int) ([]APIKeyUsage, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "month", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := s.usage.Find(ctx, bson.M{"key_id": keyID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API key usage: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	usage := []APIKeyUsage{}
+	if err := cursor.All(ctx, &usage); err != nil {
+		return nil, fmt.Errorf("failed to decode API key usage: %w", err)
+	}
+	return usage, nil
+}
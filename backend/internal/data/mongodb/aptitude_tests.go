@@ -0,0 +1,136 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Aptitude tests collection name
+const AptitudeTestCollection = "program_aptitude_tests"
+
+// Kinds of admission tests
+const (
+	TestKindAptitude  = "aptitude"
+	TestKindPractical = "practical"
+)
+
+// TestDate is a dated step of an admission test, such as the application
+// deadline or the test itself
+type TestDate struct {
+	Label string    `bson:"label" json:"label"`
+	Date  time.Time `bson:"date" json:"date"`
+}
+
+// PastPaper links to a past paper of an admission test
+type PastPaper struct {
+	Title string `bson:"title" json:"title"`
+	Year  int    `bson:"year,omitempty" json:"year,omitempty"`
+	URL   string `bson:"url" json:"url"`
+}
+
+// AptitudeTest is an aptitude or practical test a program admits students by,
+// in addition to its qualification requirements
+type AptitudeTest struct {
+	Name        string      `bson:"name" json:"name"`
+	Kind        string      `bson:"kind" json:"kind"`
+	Description string      `bson:"description,omitempty" json:"description,omitempty"`
+	Dates       []TestDate  `bson:"dates,omitempty" json:"dates,omitempty"`
+	PastPapers  []PastPaper `bson:"past_papers,omitempty" json:"past_papers,omitempty"`
+	Source      string      `bson:"source,omitempty" json:"source,omitempty"`
+}
+
+// programAptitudeTests holds all admission tests of one program
+type programAptitudeTests struct {
+	ProgramName string         `bson:"_id"`
+	Tests       []AptitudeTest `bson:"tests"`
+	UpdatedAt   time.Time      `bson:"updated_at"`
+}
+
+// AptitudeTestStore keeps the admission tests of programs. A nil store has no
+// tests.
+type AptitudeTestStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewAptitudeTestStore creates a new aptitude test store
+func NewAptitudeTestStore(client *Client, logger *zap.Logger) *AptitudeTestStore {
+	if client == nil {
+		return nil
+	}
+
+	return &AptitudeTestStore{
+		client:     client,
+		collection: client.GetCollection(AptitudeTestCollection),
+		logger:     logger,
+	}
+}
+
+// Replace sets the admission tests of a program, removing them all when tests
+// is empty
+func (s *AptitudeTestStore) Replace(ctx context.Context, programName string, tests []AptitudeTest) error {
+	if s == nil {
+		return nil
+	}
+
+	if len(tests) == 0 {
+		if _, err := s.collection.DeleteOne(ctx, bson.M{"_id": programName}); err != nil {
+			return fmt.Errorf("failed to remove aptitude tests: %w", err)
+		}
+		return nil
+	}
+
+	doc := programAptitudeTests{
+		ProgramName: programName,
+		Tests:       tests,
+		UpdatedAt:   time.Now(),
+	}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := s.collection.ReplaceOne(ctx, bson.M{"_id": programName}, doc, opts); err != nil {
+		return fmt.Errorf("failed to store aptitude tests: %w", err)
+	}
+	return nil
+}
+
+// ListByProgram returns the admission tests of a program
+func (s *AptitudeTestStore) ListByProgram(ctx context.Context, programName string) ([]AptitudeTest, error) {
+	tests, err := s.ByPrograms(ctx, []string{programName})
+	if err != nil {
+		return nil, err
+	}
+	if tests[programName] == nil {
+		return []AptitudeTest{}, nil
+	}
+	return tests[programName], nil
+}
+
+// ByPrograms returns the admission tests of the given programs, keyed by program
+// name. Programs without tests are left out.
+func (s *AptitudeTestStore) ByPrograms(ctx context.Context, programNames []string) (map[string][]AptitudeTest, error) {
+	tests := make(map[string][]AptitudeTest)
+	if s == nil || len(programNames) == 0 {
+		return tests, nil
+	}
+
+	cursor, err := s.collection.Find(ctx, bson.M{"_id": bson.M{"$in": programNames}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query aptitude tests: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []programAptitudeTests
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode aptitude tests: %w", err)
+	}
+	for _, doc := range docs {
+		tests[doc.ProgramName] = doc.Tests
+	}
+	return tests, nil
+}
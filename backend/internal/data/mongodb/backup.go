@@ -0,0 +1,93 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+// BackupCollections are the cache and ingestion collections included in backups.
+// Per-user data (plans, history) is left to the database's own backups.
+var BackupCollections = []string{
+	LearningRoadmapCollection,
+	RoadmapVersionCollection,
+	JobRoleCacheCollection,
+	ZScoreCutoffCollection,
+	ProgramIntakeCollection,
+	AptitudeTestCollection,
+	ProgramOutcomeCollection,
+	LivingCostCollection,
+	CareerSalaryCollection,
+}
+
+// PromotionCollections are the curated collections promoted from one environment
+// to another along with the graph. Caches are rebuilt by each environment.
+var PromotionCollections = []string{
+	ZScoreCutoffCollection,
+	ProgramIntakeCollection,
+	AptitudeTestCollection,
+	ProgramOutcomeCollection,
+	LivingCostCollection,
+}
+
+// restoreBatchSize is the number of documents inserted per request on restore
+const restoreBatchSize = 500
+
+// ExportCollection returns every document of a collection as canonical Extended
+// JSON, which keeps BSON types (ObjectIDs, dates) intact through a restore
+func (c *Client) ExportCollection(ctx context.Context, name string) ([]json.RawMessage, error) {
+	cursor, err := c.GetCollection(name).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export %s: %w", name, err)
+	}
+	defer cursor.Close(ctx)
+
+	docs := []json.RawMessage{}
+	for cursor.Next(ctx) {
+		doc, err := bson.MarshalExtJSON(cursor.Current, true, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s document: %w", name, err)
+		}
+		docs = append(docs, doc)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s: %w", name, err)
+	}
+
+	return docs, nil
+}
+
+// RestoreCollection replaces the contents of a collection with exported documents
+func (c *Client) RestoreCollection(ctx context.Context, name string, docs []json.RawMessage) error {
+	decoded := make([]interface{}, 0, len(docs))
+	for i, raw := range docs {
+		var doc bson.D
+		if err := bson.UnmarshalExtJSON(raw, true, &doc); err != nil {
+			return fmt.Errorf("failed to decode %s document %d: %w", name, i, err)
+		}
+		decoded = append(decoded, doc)
+	}
+
+	collection := c.GetCollection(name)
+	if _, err := collection.DeleteMany(ctx, bson.M{}); err != nil {
+		return fmt.Errorf("failed to clear %s: %w", name, err)
+	}
+
+	for start := 0; start < len(decoded); start += restoreBatchSize {
+		end := start + restoreBatchSize
+		if end > len(decoded) {
+			end = len(decoded)
+		}
+		if _, err := collection.InsertMany(ctx, decoded[start:end]); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+	}
+
+	c.logger.Info("Collection restored",
+		zap.String("collection", name),
+		zap.Int("documents", len(decoded)))
+	return nil
+}
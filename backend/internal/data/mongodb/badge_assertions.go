@@ -0,0 +1,129 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Badge assertions collection name
+const BadgeAssertionCollection = "badge_assertions"
+
+// BadgeAssertion is an issued Open Badge: the award of a program's roadmap
+// completion badge to one user. The recipient's email is only kept hashed.
+type BadgeAssertion struct {
+	ID            string    `bson:"_id" json:"id"`
+	UserID        string    `bson:"user_id" json:"user_id"`
+	ProgramName   string    `bson:"program_name" json:"program_name"`
+	RecipientHash string    `bson:"recipient_hash" json:"-"` // sha256$<hex of email + salt>
+	RecipientSalt string    `bson:"recipient_salt" json:"-"`
+	IssuedAt      time.Time `bson:"issued_at" json:"issued_at"`
+}
+
+// BadgeAssertionStore persists issued badges
+type BadgeAssertionStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewBadgeAssertionStore creates a new badge assertion store
+func NewBadgeAssertionStore(client *Client, logger *zap.Logger) *BadgeAssertionStore {
+	store := &BadgeAssertionStore{
+		client:     client,
+		collection: client.GetCollection(BadgeAssertionCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates necessary indexes for assertion lookups
+func (s *BadgeAssertionStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "program_name", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("user_program_idx"),
+		},
+		{
+			Keys:    bson.D{{Key: "program_name", Value: 1}},
+			Options: options.Index().SetName("program_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for badge assertions", zap.Error(err))
+	} else {
+		s.logger.Info("Badge assertion indexes created successfully")
+	}
+}
+
+// Create stores a new assertion, assigning its ID and issue time
+func (s *BadgeAssertionStore) Create(ctx context.Context, assertion *BadgeAssertion) error {
+	assertion.ID = uuid.New().String()
+	assertion.IssuedAt = time.Now().UTC().Truncate(time.Second)
+
+	if _, err := s.collection.InsertOne(ctx, assertion); err != nil {
+		return fmt.Errorf("failed to store badge assertion: %w", err)
+	}
+	return nil
+}
+
+// Get returns an assertion by ID, or nil when it does not exist
+func (s *BadgeAssertionStore) Get(ctx context.Context, id string) (*BadgeAssertion, error) {
+	return s.findOne(ctx, bson.M{"_id": id})
+}
+
+// GetForProgram returns the assertion a user was issued for a program, or nil
+func (s *BadgeAssertionStore) GetForProgram(ctx context.Context, userID, programName string) (*BadgeAssertion, error) {
+	return s.findOne(ctx, bson.M{"user_id": userID, "program_name": programName})
+}
+
+func (s *BadgeAssertionStore) findOne(ctx context.Context, filter bson.M) (*BadgeAssertion, error) {
+	var assertion BadgeAssertion
+	err := s.collection.FindOne(ctx, filter).Decode(&assertion)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get badge assertion: %w", err)
+	}
+	return &assertion, nil
+}
+
+// IssuedForProgram reports whether any badge was issued for a program
+func (s *BadgeAssertionStore) IssuedForProgram(ctx context.Context, programName string) (bool, error) {
+	count, err := s.collection.CountDocuments(ctx, bson.M{"program_name": programName}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to look up badge assertions: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ListByUser returns the assertions issued to a user, newest first
+func (s *BadgeAssertionStore) ListByUser(ctx context.Context, userID string) ([]BadgeAssertion, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "issued_at", Value: -1}})
+	cursor, err := s.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list badge assertions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	assertions := []BadgeAssertion{}
+	if err := cursor.All(ctx, &assertions); err != nil {
+		return nil, fmt.Errorf("failed to decode badge assertions: %w", err)
+	}
+	return assertions, nil
+}
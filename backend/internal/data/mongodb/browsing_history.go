@@ -0,0 +1,179 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// Browsing history collection name
+	BrowsingHistoryCollection = "browsing_history"
+
+	// How long browsing history is kept (180 days)
+	DefaultHistoryRetention = 180 * 24 * time.Hour
+
+	// Entity types tracked in browsing history
+	EntityTypeProgram = "program"
+	EntityTypeCareer  = "career"
+)
+
+// EntityView represents a user's views of a single program or career
+type EntityView struct {
+	UserID        string    `bson:"user_id" json:"-"`
+	EntityType    string    `bson:"entity_type" json:"entity_type"`
+	Name          string    `bson:"name" json:"name"`
+	ViewCount     int64     `bson:"view_count" json:"view_count"`
+	FirstViewedAt time.Time `bson:"first_viewed_at" json:"first_viewed_at"`
+	LastViewedAt  time.Time `bson:"last_viewed_at" json:"last_viewed_at"`
+}
+
+// BrowsingHistory records which programs and careers users view. A nil history
+// records nothing.
+type BrowsingHistory struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+	retention  time.Duration
+}
+
+// NewBrowsingHistory creates a new browsing history store
+func NewBrowsingHistory(client *Client, logger *zap.Logger) *BrowsingHistory {
+	if client == nil {
+		return nil
+	}
+
+	history := &BrowsingHistory{
+		client:     client,
+		collection: client.GetCollection(BrowsingHistoryCollection),
+		logger:     logger,
+		retention:  DefaultHistoryRetention,
+	}
+
+	// Initialize indexes in background
+	go history.ensureIndexes()
+
+	return history
+}
+
+// ensureIndexes creates necessary indexes for history lookups and retention
+func (h *BrowsingHistory) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "entity_type", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("user_entity_idx"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "last_viewed_at", Value: -1},
+			},
+			Options: options.Index().SetName("user_recent_idx"),
+		},
+		{
+			Keys: bson.D{{Key: "last_viewed_at", Value: 1}},
+			Options: options.Index().
+				SetExpireAfterSeconds(int32(h.retention.Seconds())). // Old history is removed automatically
+				SetName("retention_index"),
+		},
+	}
+
+	if _, err := h.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		h.logger.Error("Failed to create indexes for browsing history", zap.Error(err))
+	} else {
+		h.logger.Info("Browsing history indexes created successfully")
+	}
+}
+
+// RecordView records a view of a program or career by a user
+func (h *BrowsingHistory) RecordView(ctx context.Context, userID, entityType, name string) error {
+	if h == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	filter := bson.M{
+		"user_id":     userID,
+		"entity_type": entityType,
+		"name":        name,
+	}
+	update := bson.M{
+		"$inc":         bson.M{"view_count": 1},
+		"$set":         bson.M{"last_viewed_at": now},
+		"$setOnInsert": bson.M{"first_viewed_at": now},
+	}
+
+	if _, err := h.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to record view: %w", err)
+	}
+	return nil
+}
+
+// RecentViews returns a user's most recently viewed entities
+func (h *BrowsingHistory) RecentViews(ctx context.Context, userID string, limit int) ([]EntityView, error) {
+	if h == nil {
+		return nil, nil
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "last_viewed_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := h.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query browsing history: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	views := []EntityView{}
+	if err := cursor.All(ctx, &views); err != nil {
+		return nil, fmt.Errorf("failed to decode browsing history: %w", err)
+	}
+	return views, nil
+}
+
+// Viewers returns how many users viewed each of the named entities of a type,
+// leaving out entities no one viewed
+func (h *BrowsingHistory) Viewers(ctx context.Context, entityType string, names []string) (map[string]int64, error) {
+	if h == nil || len(names) == 0 {
+		return map[string]int64{}, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"entity_type": entityType, "name": bson.M{"$in": names}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$name", "viewers": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := h.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate viewers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Name    string `bson:"_id"`
+		Viewers int64  `bson:"viewers"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode viewers: %w", err)
+	}
+
+	viewers := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		viewers[row.Name] = row.Viewers
+	}
+	return viewers, nil
+}
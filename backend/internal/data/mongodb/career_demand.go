@@ -0,0 +1,156 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Career demand collection names
+const (
+	CareerDemandCollection         = "career_demand"
+	CareerDemandOverrideCollection = "career_demand_overrides"
+)
+
+// CareerDemand is a career's computed demand index, from 0 to 100, with the
+// signals it was computed from. A signal that was unavailable is left nil.
+type CareerDemand struct {
+	Career          string    `bson:"_id" json:"career"`
+	Index           float64   `bson:"index" json:"index"`
+	OpenVacancies   int64     `bson:"open_vacancies" json:"open_vacancies"`
+	VacancyScore    *float64  `bson:"vacancy_score,omitempty" json:"vacancy_score,omitempty"`
+	Assessment      string    `bson:"assessment,omitempty" json:"assessment,omitempty"`
+	AssessmentScore *float64  `bson:"assessment_score,omitempty" json:"assessment_score,omitempty"`
+	ComputedAt      time.Time `bson:"computed_at" json:"computed_at"`
+}
+
+// DemandOverride is a demand index set by an admin for a career, used in place
+// of the computed one
+type DemandOverride struct {
+	Career    string    `bson:"_id" json:"career"`
+	Index     float64   `bson:"index" json:"index"`
+	Reason    string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// CareerDemandStore keeps careers' computed demand indexes and the overrides
+// admins set. A nil store has neither.
+type CareerDemandStore struct {
+	client    *Client
+	scores    *mongo.Collection
+	overrides *mongo.Collection
+	logger    *zap.Logger
+}
+
+// NewCareerDemandStore creates a new career demand store
+func NewCareerDemandStore(client *Client, logger *zap.Logger) *CareerDemandStore {
+	if client == nil {
+		return nil
+	}
+
+	return &CareerDemandStore{
+		client:    client,
+		scores:    client.GetCollection(CareerDemandCollection),
+		overrides: client.GetCollection(CareerDemandOverrideCollection),
+		logger:    logger,
+	}
+}
+
+// SaveScores stores the indexes computed at computedAt, removing those of
+// careers left out, which are no longer in the graph
+func (s *CareerDemandStore) SaveScores(ctx context.Context, scores []CareerDemand, computedAt time.Time) error {
+	if s == nil {
+		return nil
+	}
+
+	if len(scores) > 0 {
+		models := make([]mongo.WriteModel, 0, len(scores))
+		for _, score := range scores {
+			score.ComputedAt = computedAt
+			models = append(models, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"_id": score.Career}).
+				SetReplacement(score).
+				SetUpsert(true))
+		}
+		if _, err := s.scores.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return fmt.Errorf("failed to store career demand: %w", err)
+		}
+	}
+
+	if _, err := s.scores.DeleteMany(ctx, bson.M{"computed_at": bson.M{"$lt": computedAt}}); err != nil {
+		return fmt.Errorf("failed to remove stale career demand: %w", err)
+	}
+	return nil
+}
+
+// Scores returns every computed index, by career
+func (s *CareerDemandStore) Scores(ctx context.Context) ([]CareerDemand, error) {
+	if s == nil {
+		return []CareerDemand{}, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := s.scores.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list career demand: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	scores := []CareerDemand{}
+	if err := cursor.All(ctx, &scores); err != nil {
+		return nil, fmt.Errorf("failed to decode career demand: %w", err)
+	}
+	return scores, nil
+}
+
+// SetOverride stores an override, replacing any set before for the career
+func (s *CareerDemandStore) SetOverride(ctx context.Context, override *DemandOverride) error {
+	if s == nil {
+		return nil
+	}
+
+	override.UpdatedAt = time.Now()
+	opts := options.Replace().SetUpsert(true)
+	if _, err := s.overrides.ReplaceOne(ctx, bson.M{"_id": override.Career}, override, opts); err != nil {
+		return fmt.Errorf("failed to store demand override: %w", err)
+	}
+	return nil
+}
+
+// DeleteOverride removes a career's override, reporting whether there was one
+func (s *CareerDemandStore) DeleteOverride(ctx context.Context, career string) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+
+	result, err := s.overrides.DeleteOne(ctx, bson.M{"_id": career})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete demand override: %w", err)
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// Overrides returns every override, by career
+func (s *CareerDemandStore) Overrides(ctx context.Context) ([]DemandOverride, error) {
+	if s == nil {
+		return []DemandOverride{}, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := s.overrides.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list demand overrides: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	overrides := []DemandOverride{}
+	if err := cursor.All(ctx, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to decode demand overrides: %w", err)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,107 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Career salaries collection name
+const CareerSalaryCollection = "career_salaries"
+
+// CareerSalary summarizes the monthly salaries advertised for a career on job
+// boards. Percentiles are taken over the midpoints of the advertised ranges.
+type CareerSalary struct {
+	CareerTitle string    `bson:"career_title" json:"career_title"`
+	Currency    string    `bson:"currency" json:"currency"`
+	Min         int64     `bson:"min" json:"min"`
+	P25         int64     `bson:"p25" json:"p25"`
+	Median      int64     `bson:"median" json:"median"`
+	P75         int64     `bson:"p75" json:"p75"`
+	Max         int64     `bson:"max" json:"max"`
+	SampleSize  int       `bson:"sample_size" json:"sample_size"`
+	Sources     []string  `bson:"sources" json:"sources"`
+	CollectedAt time.Time `bson:"collected_at" json:"collected_at"`
+}
+
+// CareerSalaryStore keeps salary data collected from job boards per career. A nil
+// store has no salaries.
+type CareerSalaryStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewCareerSalaryStore creates a new career salary store
+func NewCareerSalaryStore(client *Client, logger *zap.Logger) *CareerSalaryStore {
+	if client == nil {
+		return nil
+	}
+
+	store := &CareerSalaryStore{
+		client:     client,
+		collection: client.GetCollection(CareerSalaryCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the unique career index used for upserts and lookups
+func (s *CareerSalaryStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "career_title", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("career_title_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for career salaries", zap.Error(err))
+	} else {
+		s.logger.Info("Career salary indexes created successfully")
+	}
+}
+
+// Upsert stores salary data, replacing earlier data for the same career
+func (s *CareerSalaryStore) Upsert(ctx context.Context, salary CareerSalary) error {
+	if s == nil {
+		return nil
+	}
+
+	filter := bson.M{"career_title": salary.CareerTitle}
+	opts := options.Replace().SetUpsert(true)
+
+	if _, err := s.collection.ReplaceOne(ctx, filter, salary, opts); err != nil {
+		return fmt.Errorf("failed to store career salary: %w", err)
+	}
+	return nil
+}
+
+// Get returns the salary data of a career, or nil when none has been collected
+func (s *CareerSalaryStore) Get(ctx context.Context, careerTitle string) (*CareerSalary, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	var salary CareerSalary
+	err := s.collection.FindOne(ctx, bson.M{"career_title": careerTitle}).Decode(&salary)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query career salary: %w", err)
+	}
+	return &salary, nil
+}
@@ -196,6 +196,34 @@ func NewClientWithAuthTest(config Config) (*Client, error) {
 	}, nil
 }
 
+// Ping connects to MongoDB with config, authenticating when credentials are
+// set, and pings it without creating a client, so configuration can be checked
+// before the server starts
+func Ping(ctx context.Context, config Config) error {
+	clientOptions := options.Client().
+		ApplyURI(config.URI).
+		SetConnectTimeout(config.ConnectTimeout).
+		SetServerSelectionTimeout(config.ConnectTimeout)
+	if config.Username != "" && config.Password != "" {
+		clientOptions = clientOptions.SetAuth(options.Credential{
+			Username:   config.Username,
+			Password:   config.Password,
+			AuthSource: "admin", // Default auth source
+		})
+	}
+
+	mongoClient, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return fmt.Errorf("failed to create MongoDB client: %w", err)
+	}
+	defer mongoClient.Disconnect(context.Background())
+
+	if err := mongoClient.Ping(ctx, nil); err != nil {
+		return fmt.Errorf("failed to ping MongoDB: %w", err)
+	}
+	return nil
+}
+
 // maskConnectionString masks sensitive information in connection strings for logging
 func maskConnectionString(uri string) string {
 	if strings.Contains(uri, "@") {
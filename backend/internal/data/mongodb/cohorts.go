@@ -0,0 +1,208 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Cohort collection names
+const (
+	CohortCollection       = "cohorts"
+	CohortMemberCollection = "cohort_members"
+)
+
+const (
+	// cohortCodeAlphabet leaves out letters and digits easily confused when a
+	// code is read out in class
+	cohortCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	cohortCodeLength   = 8
+
+	// cohortCodeAttempts is how many codes are tried before giving up on a clash
+	cohortCodeAttempts = 3
+)
+
+// Cohort is a group of students, such as a class, whose teacher follows their
+// pathways in aggregate. Students join with the cohort's code.
+type Cohort struct {
+	Code      string    `bson:"_id" json:"code"`
+	Name      string    `bson:"name" json:"name"`
+	TeacherID string    `bson:"teacher_id" json:"-"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CohortMember records a student joining a cohort
+type CohortMember struct {
+	CohortCode string    `bson:"cohort_code" json:"cohort_code"`
+	UserID     string    `bson:"user_id" json:"-"`
+	JoinedAt   time.Time `bson:"joined_at" json:"joined_at"`
+}
+
+// CohortStore persists cohorts and their members
+type CohortStore struct {
+	client  *Client
+	cohorts *mongo.Collection
+	members *mongo.Collection
+	logger  *zap.Logger
+}
+
+// NewCohortStore creates a new cohort store
+func NewCohortStore(client *Client, logger *zap.Logger) *CohortStore {
+	store := &CohortStore{
+		client:  client,
+		cohorts: client.GetCollection(CohortCollection),
+		members: client.GetCollection(CohortMemberCollection),
+		logger:  logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the indexes for teachers' cohorts and cohort members
+func (s *CohortStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := s.cohorts.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "teacher_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("teacher_cohorts_idx"),
+	}); err != nil {
+		s.logger.Error("Failed to create indexes for cohorts", zap.Error(err))
+	}
+
+	if _, err := s.members.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "cohort_code", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("cohort_user_idx"),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("user_cohorts_idx"),
+		},
+	}); err != nil {
+		s.logger.Error("Failed to create indexes for cohort members", zap.Error(err))
+	}
+}
+
+// Create stores a new cohort under a fresh random code
+func (s *CohortStore) Create(ctx context.Context, cohort *Cohort) error {
+	cohort.CreatedAt = time.Now()
+	for attempt := 1; ; attempt++ {
+		code, err := newCohortCode()
+		if err != nil {
+			return err
+		}
+		cohort.Code = code
+
+		_, err = s.cohorts.InsertOne(ctx, cohort)
+		if err == nil {
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) || attempt == cohortCodeAttempts {
+			return fmt.Errorf("failed to create cohort: %w", err)
+		}
+	}
+
+	s.logger.Info("Cohort created", zap.String("code", cohort.Code))
+	return nil
+}
+
+// Get returns a cohort by its code, or nil when there is none
+func (s *CohortStore) Get(ctx context.Context, code string) (*Cohort, error) {
+	var cohort Cohort
+	err := s.cohorts.FindOne(ctx, bson.M{"_id": code}).Decode(&cohort)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cohort: %w", err)
+	}
+	return &cohort, nil
+}
+
+// ListByTeacher returns the cohorts a teacher created, newest first
+func (s *CohortStore) ListByTeacher(ctx context.Context, teacherID string) ([]Cohort, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := s.cohorts.Find(ctx, bson.M{"teacher_id": teacherID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cohorts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	cohorts := []Cohort{}
+	if err := cursor.All(ctx, &cohorts); err != nil {
+		return nil, fmt.Errorf("failed to decode cohorts: %w", err)
+	}
+	return cohorts, nil
+}
+
+// AddMember adds a student to a cohort, keeping when they first joined if they
+// already belong to it
+func (s *CohortStore) AddMember(ctx context.Context, code, userID string) (*CohortMember, error) {
+	filter := bson.M{"cohort_code": code, "user_id": userID}
+	update := bson.M{"$setOnInsert": bson.M{"joined_at": time.Now()}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var member CohortMember
+	if err := s.members.FindOneAndUpdate(ctx, filter, update, opts).Decode(&member); err != nil {
+		return nil, fmt.Errorf("failed to join cohort: %w", err)
+	}
+	return &member, nil
+}
+
+// RemoveMember takes a student out of a cohort, reporting whether they belonged
+// to it
+func (s *CohortStore) RemoveMember(ctx context.Context, code, userID string) (bool, error) {
+	result, err := s.members.DeleteOne(ctx, bson.M{"cohort_code": code, "user_id": userID})
+	if err != nil {
+		return false, fmt.Errorf("failed to leave cohort: %w", err)
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// CountMembers returns how many students belong to a cohort
+func (s *CohortStore) CountMembers(ctx context.Context, code string) (int64, error) {
+	count, err := s.members.CountDocuments(ctx, bson.M{"cohort_code": code})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count cohort members: %w", err)
+	}
+	return count, nil
+}
+
+// MemberIDs returns the user IDs of a cohort's students
+func (s *CohortStore) MemberIDs(ctx context.Context, code string) ([]string, error) {
+	values, err := s.members.Distinct(ctx, "user_id", bson.M{"cohort_code": code})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cohort members: %w", err)
+	}
+
+	ids := make([]string, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// newCohortCode generates a short random code that is easy to read out and type.
+// The alphabet's 32 letters divide 256, so every letter is equally likely.
+func newCohortCode() (string, error) {
+	buf := make([]byte, cohortCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate cohort code: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = cohortCodeAlphabet[int(b)%len(cohortCodeAlphabet)]
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,156 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Consent collection names
+const (
+	ConsentCollection      = "consents"
+	ConsentEventCollection = "consent_events"
+)
+
+// Consent event actions
+const (
+	ConsentAccepted        = "accepted"
+	ConsentParentalGranted = "parental_consent"
+	ConsentWithdrawn       = "withdrawn"
+)
+
+// ParentalConsent is a guardian's consent for a minor, given for one policy version
+type ParentalConsent struct {
+	PolicyID     string    `bson:"policy_id" json:"policy_id"`
+	GuardianName string    `bson:"guardian_name" json:"guardian_name"`
+	Relationship string    `bson:"relationship" json:"relationship"`
+	GrantedAt    time.Time `bson:"granted_at" json:"granted_at"`
+}
+
+// Consent is a user's current acceptance of the privacy policy
+type Consent struct {
+	UserID          string           `bson:"_id" json:"user_id"`
+	PolicyID        string           `bson:"policy_id" json:"policy_id"`
+	AcceptedAt      time.Time        `bson:"accepted_at" json:"accepted_at"`
+	Minor           bool             `bson:"minor" json:"minor"`
+	ParentalConsent *ParentalConsent `bson:"parental_consent,omitempty" json:"parental_consent,omitempty"`
+	WithdrawnAt     *time.Time       `bson:"withdrawn_at,omitempty" json:"withdrawn_at,omitempty"`
+	UpdatedAt       time.Time        `bson:"updated_at" json:"updated_at"`
+}
+
+// ConsentEvent is one change to a user's consent, kept as an audit trail
+type ConsentEvent struct {
+	ID              string           `bson:"_id" json:"id"`
+	UserID          string           `bson:"user_id" json:"user_id"`
+	Action          string           `bson:"action" json:"action"`
+	PolicyID        string           `bson:"policy_id,omitempty" json:"policy_id,omitempty"`
+	Minor           bool             `bson:"minor" json:"minor"`
+	ParentalConsent *ParentalConsent `bson:"parental_consent,omitempty" json:"parental_consent,omitempty"`
+	At              time.Time        `bson:"at" json:"at"`
+}
+
+// ConsentStore persists users' consents and every change to them
+type ConsentStore struct {
+	client   *Client
+	consents *mongo.Collection
+	events   *mongo.Collection
+	logger   *zap.Logger
+}
+
+// NewConsentStore creates a new consent store
+func NewConsentStore(client *Client, logger *zap.Logger) *ConsentStore {
+	store := &ConsentStore{
+		client:   client,
+		consents: client.GetCollection(ConsentCollection),
+		events:   client.GetCollection(ConsentEventCollection),
+		logger:   logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the index consent events are listed by
+func (s *ConsentStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "at", Value: -1}},
+			Options: options.Index().SetName("user_at_idx"),
+		},
+	}
+
+	if _, err := s.events.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for consent events", zap.Error(err))
+	} else {
+		s.logger.Info("Consent indexes created successfully")
+	}
+}
+
+// Get returns a user's consent, or nil when they never gave one
+func (s *ConsentStore) Get(ctx context.Context, userID string) (*Consent, error) {
+	var consent Consent
+	err := s.consents.FindOne(ctx, bson.M{"_id": userID}).Decode(&consent)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consent: %w", err)
+	}
+	return &consent, nil
+}
+
+// Save replaces a user's consent and records the change that led to it. The
+// event is written first, so no consent is ever stored without its record.
+func (s *ConsentStore) Save(ctx context.Context, consent *Consent, action string) error {
+	now := time.Now()
+	consent.UpdatedAt = now
+
+	event := ConsentEvent{
+		ID:              uuid.New().String(),
+		UserID:          consent.UserID,
+		Action:          action,
+		PolicyID:        consent.PolicyID,
+		Minor:           consent.Minor,
+		ParentalConsent: consent.ParentalConsent,
+		At:              now,
+	}
+	if _, err := s.events.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("failed to record consent event: %w", err)
+	}
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := s.consents.ReplaceOne(ctx, bson.M{"_id": consent.UserID}, consent, opts); err != nil {
+		s.logger.Error("Failed to save consent",
+			zap.String("action", action),
+			zap.Error(err))
+		return fmt.Errorf("failed to save consent: %w", err)
+	}
+	return nil
+}
+
+// ListEvents returns the changes to a user's consent, newest first
+func (s *ConsentStore) ListEvents(ctx context.Context, userID string) ([]ConsentEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: -1}})
+	cursor, err := s.events.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consent events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []ConsentEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode consent events: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,164 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Entity history collection name
+const EntityHistoryCollection = "entity_history"
+
+// EntitySnapshot is a graph entity as it was before or after an admin change. It
+// has the shape of the admin API's entity body, so a snapshot can be sent back
+// as is to undo a change.
+type EntitySnapshot struct {
+	Name          string            `bson:"name" json:"name"`
+	Institute     string            `bson:"institute,omitempty" json:"institute,omitempty"`
+	Faculty       string            `bson:"faculty,omitempty" json:"faculty,omitempty"`
+	Department    string            `bson:"department,omitempty" json:"department,omitempty"`
+	Requirements  []string          `bson:"requirements,omitempty" json:"requirements,omitempty"`
+	Prerequisites []string          `bson:"prerequisites,omitempty" json:"prerequisites,omitempty"`
+	Careers       []string          `bson:"careers,omitempty" json:"careers,omitempty"`
+	Provenance    map[string]string `bson:"provenance,omitempty" json:"provenance,omitempty"`
+	Contact       map[string]string `bson:"contact,omitempty" json:"contact,omitempty"`
+	Info          map[string]any    `bson:"info,omitempty" json:"info,omitempty"`
+	Programs      []string          `bson:"programs,omitempty" json:"programs,omitempty"`
+	Institutes    []string          `bson:"institutes,omitempty" json:"institutes,omitempty"`
+	Scholarship   map[string]any    `bson:"scholarship,omitempty" json:"scholarship,omitempty"`
+}
+
+// EntityChange records one admin mutation of a graph entity. Before is nil for
+// creations and After is nil for deletions. Names holds the entity's names before
+// and after the change so that its history follows renames.
+type EntityChange struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Kind      string             `bson:"kind" json:"kind"`
+	Name      string             `bson:"name" json:"name"`
+	Names     []string           `bson:"names" json:"-"`
+	Action    string             `bson:"action" json:"action"`
+	Actor     string             `bson:"actor" json:"actor"`
+	Before    *EntitySnapshot    `bson:"before,omitempty" json:"before,omitempty"`
+	After     *EntitySnapshot    `bson:"after,omitempty" json:"after,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// EntityHistoryStore keeps the audit trail of admin changes to graph entities
+type EntityHistoryStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewEntityHistoryStore creates a new entity history store
+func NewEntityHistoryStore(client *Client, logger *zap.Logger) *EntityHistoryStore {
+	store := &EntityHistoryStore{
+		client:     client,
+		collection: client.GetCollection(EntityHistoryCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the index used to read an entity's history
+func (s *EntityHistoryStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "kind", Value: 1},
+				{Key: "names", Value: 1},
+				{Key: "_id", Value: -1},
+			},
+			Options: options.Index().SetName("kind_names_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for entity history", zap.Error(err))
+	} else {
+		s.logger.Info("Entity history indexes created successfully")
+	}
+}
+
+// Record stores a change, stamping it with an ID and the current time
+func (s *EntityHistoryStore) Record(ctx context.Context, change *EntityChange) error {
+	change.ID = primitive.NewObjectID()
+	change.CreatedAt = time.Now()
+	change.Names = nil
+	for _, snapshot := range []*EntitySnapshot{change.Before, change.After} {
+		if snapshot != nil && (len(change.Names) == 0 || change.Names[0] != snapshot.Name) {
+			change.Names = append(change.Names, snapshot.Name)
+		}
+	}
+
+	if _, err := s.collection.InsertOne(ctx, change); err != nil {
+		return fmt.Errorf("failed to record entity change: %w", err)
+	}
+	return nil
+}
+
+// maxHistoryRenames bounds how many renames ListByEntity follows back
+const maxHistoryRenames = 10
+
+// ListByEntity returns up to limit changes to an entity, newest first, including
+// changes made while it had an earlier name
+func (s *EntityHistoryStore) ListByEntity(ctx context.Context, kind, name string, limit int) ([]EntityChange, error) {
+	names := []string{name}
+	seen := map[string]bool{name: true}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	for i := 0; ; i++ {
+		cursor, err := s.collection.Find(ctx, bson.M{"kind": kind, "names": bson.M{"$in": names}}, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query entity history: %w", err)
+		}
+		changes := []EntityChange{}
+		err = cursor.All(ctx, &changes)
+		cursor.Close(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode entity history: %w", err)
+		}
+
+		renamed := false
+		for _, change := range changes {
+			for _, n := range change.Names {
+				if !seen[n] {
+					seen[n] = true
+					names = append(names, n)
+					renamed = true
+				}
+			}
+		}
+		if !renamed || i == maxHistoryRenames {
+			return changes, nil
+		}
+	}
+}
+
+// Get returns a recorded change, or nil when there is none with that ID
+func (s *EntityHistoryStore) Get(ctx context.Context, id primitive.ObjectID) (*EntityChange, error) {
+	var change EntityChange
+	err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&change)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entity change: %w", err)
+	}
+	return &change, nil
+}
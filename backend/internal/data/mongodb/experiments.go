@@ -0,0 +1,127 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const ExperimentStatsCollection = "experiment_stats"
+
+// ExperimentCounts is what happened with one variant of an experiment
+type ExperimentCounts struct {
+	Exposures  int64 `bson:"exposures" json:"exposures"`   // responses the variant shaped
+	Selections int64 `bson:"selections" json:"selections"` // results opened from those responses
+	Helpful    int64 `bson:"helpful" json:"helpful"`
+	Unhelpful  int64 `bson:"unhelpful" json:"unhelpful"`
+}
+
+// IsZero reports whether nothing was counted
+func (c ExperimentCounts) IsZero() bool {
+	return c == ExperimentCounts{}
+}
+
+// Add returns the sum of two counts
+func (c ExperimentCounts) Add(other ExperimentCounts) ExperimentCounts {
+	return ExperimentCounts{
+		Exposures:  c.Exposures + other.Exposures,
+		Selections: c.Selections + other.Selections,
+		Helpful:    c.Helpful + other.Helpful,
+		Unhelpful:  c.Unhelpful + other.Unhelpful,
+	}
+}
+
+// ExperimentStats are the counts of one variant on one day (UTC), e.g. "2026-10-16"
+type ExperimentStats struct {
+	Experiment       string `bson:"experiment" json:"experiment"`
+	Variant          string `bson:"variant" json:"variant"`
+	Day              string `bson:"day" json:"day"`
+	ExperimentCounts `bson:",inline"`
+	UpdatedAt        time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// ExperimentStore persists daily counts of experiment variants
+type ExperimentStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewExperimentStore creates a new experiment store
+func NewExperimentStore(client *Client, logger *zap.Logger) *ExperimentStore {
+	store := &ExperimentStore{
+		client:     client,
+		collection: client.GetCollection(ExperimentStatsCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates one document per experiment, variant and day
+func (s *ExperimentStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "experiment", Value: 1}, {Key: "variant", Value: 1}, {Key: "day", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("experiment_variant_day_idx"),
+		},
+		{
+			Keys:    bson.D{{Key: "day", Value: 1}},
+			Options: options.Index().SetName("day_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for experiment stats", zap.Error(err))
+	} else {
+		s.logger.Info("Experiment stats indexes created successfully")
+	}
+}
+
+// AddCounts adds counts to a variant's day
+func (s *ExperimentStore) AddCounts(ctx context.Context, experiment, variant, day string, counts ExperimentCounts) error {
+	filter := bson.M{"experiment": experiment, "variant": variant, "day": day}
+	update := bson.M{
+		"$inc": bson.M{
+			"exposures":  counts.Exposures,
+			"selections": counts.Selections,
+			"helpful":    counts.Helpful,
+			"unhelpful":  counts.Unhelpful,
+		},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	if _, err := s.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to record experiment counts: %w", err)
+	}
+	return nil
+}
+
+// ListStats returns the daily counts of every variant between two days, inclusive
+func (s *ExperimentStore) ListStats(ctx context.Context, fromDay, toDay string) ([]ExperimentStats, error) {
+	filter := bson.M{"day": bson.M{"$gte": fromDay, "$lte": toDay}}
+	opts := options.Find().SetSort(bson.D{{Key: "experiment", Value: 1}, {Key: "variant", Value: 1}, {Key: "day", Value: 1}})
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiment stats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	stats := []ExperimentStats{}
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode experiment stats: %w", err)
+	}
+	return stats, nil
+}
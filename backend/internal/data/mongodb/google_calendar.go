@@ -0,0 +1,169 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// Collection names for Google Calendar connections and pending OAuth flows
+	GoogleCalendarCollection = "google_calendar_connections"
+	OAuthStateCollection     = "oauth_states"
+
+	// DefaultOAuthStateTTL is how long a student has to finish connecting
+	DefaultOAuthStateTTL = 15 * time.Minute
+)
+
+// GoogleCalendarConnection holds the OAuth tokens a user granted for their
+// Google Calendar and the events pushed to it
+type GoogleCalendarConnection struct {
+	UserID       string    `bson:"_id" json:"user_id"`
+	AccessToken  string    `bson:"access_token" json:"-"`
+	RefreshToken string    `bson:"refresh_token" json:"-"`
+	TokenType    string    `bson:"token_type" json:"-"`
+	Expiry       time.Time `bson:"expiry" json:"-"`
+	ConnectedAt  time.Time `bson:"connected_at" json:"connected_at"`
+	UpdatedAt    time.Time `bson:"updated_at" json:"updated_at"`
+	// IDs of the events pushed for each plan, replaced when it is pushed again
+	PlanEvents map[string][]string `bson:"plan_events,omitempty" json:"-"`
+}
+
+// OAuthState ties an OAuth redirect back to the user who started it
+type OAuthState struct {
+	State     string    `bson:"_id"`
+	UserID    string    `bson:"user_id"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// GoogleCalendarStore persists Google Calendar connections
+type GoogleCalendarStore struct {
+	client      *Client
+	connections *mongo.Collection
+	states      *mongo.Collection
+	logger      *zap.Logger
+}
+
+// NewGoogleCalendarStore creates a new Google Calendar store
+func NewGoogleCalendarStore(client *Client, logger *zap.Logger) *GoogleCalendarStore {
+	store := &GoogleCalendarStore{
+		client:      client,
+		connections: client.GetCollection(GoogleCalendarCollection),
+		states:      client.GetCollection(OAuthStateCollection),
+		logger:      logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes expires abandoned OAuth flows
+func (s *GoogleCalendarStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := s.states.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().
+			SetExpireAfterSeconds(0). // TTL index - abandoned flows are removed automatically
+			SetName("ttl_index"),
+	}); err != nil {
+		s.logger.Error("Failed to create indexes for OAuth states", zap.Error(err))
+	}
+}
+
+// CreateState starts an OAuth flow for a user and returns its random state
+func (s *GoogleCalendarStore) CreateState(ctx context.Context, userID string) (string, error) {
+	state, err := newShareToken()
+	if err != nil {
+		return "", err
+	}
+
+	doc := OAuthState{State: state, UserID: userID, ExpiresAt: time.Now().Add(DefaultOAuthStateTTL)}
+	if _, err := s.states.InsertOne(ctx, doc); err != nil {
+		return "", fmt.Errorf("failed to store OAuth state: %w", err)
+	}
+	return state, nil
+}
+
+// ConsumeState ends an OAuth flow, returning the user who started it, or an
+// empty string when the state is unknown, used or expired
+func (s *GoogleCalendarStore) ConsumeState(ctx context.Context, state string) (string, error) {
+	var doc OAuthState
+	err := s.states.FindOneAndDelete(ctx, bson.M{"_id": state}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read OAuth state: %w", err)
+	}
+	// The TTL monitor only runs once a minute
+	if time.Now().After(doc.ExpiresAt) {
+		return "", nil
+	}
+	return doc.UserID, nil
+}
+
+// SaveTokens stores a user's tokens, keeping the refresh token already stored
+// when Google does not send a new one
+func (s *GoogleCalendarStore) SaveTokens(ctx context.Context, conn *GoogleCalendarConnection) error {
+	now := time.Now()
+	set := bson.M{
+		"access_token": conn.AccessToken,
+		"token_type":   conn.TokenType,
+		"expiry":       conn.Expiry,
+		"updated_at":   now,
+	}
+	if conn.RefreshToken != "" {
+		set["refresh_token"] = conn.RefreshToken
+	}
+
+	_, err := s.connections.UpdateOne(ctx,
+		bson.M{"_id": conn.UserID},
+		bson.M{"$set": set, "$setOnInsert": bson.M{"connected_at": now}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save Google Calendar tokens: %w", err)
+	}
+	return nil
+}
+
+// Get returns a user's connection, or nil when they have not connected
+func (s *GoogleCalendarStore) Get(ctx context.Context, userID string) (*GoogleCalendarConnection, error) {
+	var conn GoogleCalendarConnection
+	err := s.connections.FindOne(ctx, bson.M{"_id": userID}).Decode(&conn)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Google Calendar connection: %w", err)
+	}
+	return &conn, nil
+}
+
+// SetPlanEvents records the events pushed for a plan
+func (s *GoogleCalendarStore) SetPlanEvents(ctx context.Context, userID, planID string, eventIDs []string) error {
+	_, err := s.connections.UpdateOne(ctx,
+		bson.M{"_id": userID},
+		bson.M{"$set": bson.M{"plan_events." + planID: eventIDs, "updated_at": time.Now()}})
+	if err != nil {
+		return fmt.Errorf("failed to record pushed events: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a user's connection
+func (s *GoogleCalendarStore) Delete(ctx context.Context, userID string) (bool, error) {
+	result, err := s.connections.DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete Google Calendar connection: %w", err)
+	}
+	return result.DeletedCount > 0, nil
+}
@@ -0,0 +1,127 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Graph change log collection name
+const GraphChangeCollection = "graph_changes"
+
+// GraphChange is an entry of the graph change log: one entity created, updated or
+// deleted by an admin edit, import or sync. PreviousName is set when an update
+// renamed the entity.
+type GraphChange struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Kind         string             `bson:"kind" json:"kind"`
+	Name         string             `bson:"name" json:"name"`
+	PreviousName string             `bson:"previous_name,omitempty" json:"previous_name,omitempty"`
+	Action       string             `bson:"action" json:"action"`
+	Source       string             `bson:"source" json:"source"`
+	SourceURL    string             `bson:"source_url,omitempty" json:"source_url,omitempty"`
+	Details      []string           `bson:"details,omitempty" json:"details,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// GraphChangeStore keeps the graph change log
+type GraphChangeStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewGraphChangeStore creates a new graph change store
+func NewGraphChangeStore(client *Client, logger *zap.Logger) *GraphChangeStore {
+	store := &GraphChangeStore{
+		client:     client,
+		collection: client.GetCollection(GraphChangeCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates indexes for reading the log in order
+func (s *GraphChangeStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("created_at_idx"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "kind", Value: 1},
+				{Key: "name", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().SetName("kind_name_created_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for graph changes", zap.Error(err))
+	} else {
+		s.logger.Info("Graph change indexes created successfully")
+	}
+}
+
+// Record appends changes to the log, stamping them with the current time
+func (s *GraphChangeStore) Record(ctx context.Context, changes []GraphChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	docs := make([]interface{}, 0, len(changes))
+	for _, change := range changes {
+		change.ID = primitive.NewObjectID()
+		change.CreatedAt = now
+		docs = append(docs, change)
+	}
+
+	if _, err := s.collection.InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("failed to record graph changes: %w", err)
+	}
+	return nil
+}
+
+// List returns up to limit changes, oldest first. Only changes recorded after the
+// change with ID after (when set) and no earlier than since (when set) are
+// returned, so consumers can page through the log by passing the last ID seen.
+func (s *GraphChangeStore) List(ctx context.Context, after primitive.ObjectID, since time.Time, limit int) ([]GraphChange, error) {
+	filter := bson.M{}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	if !since.IsZero() {
+		filter["created_at"] = bson.M{"$gte": since}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query graph changes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	changes := []GraphChange{}
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode graph changes: %w", err)
+	}
+	return changes, nil
+}
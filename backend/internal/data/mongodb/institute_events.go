@@ -0,0 +1,205 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Institute events collection name
+const InstituteEventCollection = "institute_events"
+
+// Kinds of institute events
+const (
+	EventOpenDay      = "open_day"
+	EventAptitudeTest = "aptitude_test"
+	EventCareerFair   = "career_fair"
+)
+
+// InstituteEvent is a dated event held by an institute that prospective students
+// may attend, such as an open day or an aptitude test for admission
+type InstituteEvent struct {
+	ID          string     `bson:"_id" json:"id"`
+	Institute   string     `bson:"institute" json:"institute"`
+	Kind        string     `bson:"kind" json:"kind"`
+	Title       string     `bson:"title" json:"title"`
+	Description string     `bson:"description,omitempty" json:"description,omitempty"`
+	StartsAt    time.Time  `bson:"starts_at" json:"starts_at"`
+	EndsAt      *time.Time `bson:"ends_at,omitempty" json:"ends_at,omitempty"`
+	Venue       string     `bson:"venue,omitempty" json:"venue,omitempty"`
+	District    string     `bson:"district,omitempty" json:"district,omitempty"`
+	URL         string     `bson:"url,omitempty" json:"url,omitempty"`
+	Programs    []string   `bson:"programs,omitempty" json:"programs,omitempty"` // programs an aptitude test admits to
+	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// EventFilter selects institute events. Zero fields do not filter.
+type EventFilter struct {
+	Institute    string
+	Kinds        []string
+	District     string
+	From         time.Time // events ending (or starting, without an end) at or after
+	To           time.Time // events starting before
+	UpdatedSince time.Time
+	Limit        int64
+}
+
+// InstituteEventStore keeps the events of institutes
+type InstituteEventStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewInstituteEventStore creates a new institute event store
+func NewInstituteEventStore(client *Client, logger *zap.Logger) *InstituteEventStore {
+	store := &InstituteEventStore{
+		client:     client,
+		collection: client.GetCollection(InstituteEventCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the indexes events are listed by
+func (s *InstituteEventStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "institute", Value: 1}, {Key: "starts_at", Value: 1}},
+			Options: options.Index().SetName("institute_starts_at_idx"),
+		},
+		{
+			Keys:    bson.D{{Key: "starts_at", Value: 1}},
+			Options: options.Index().SetName("starts_at_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for institute events", zap.Error(err))
+	} else {
+		s.logger.Info("Institute event indexes created successfully")
+	}
+}
+
+// Create stores a new event, assigning its ID
+func (s *InstituteEventStore) Create(ctx context.Context, event *InstituteEvent) error {
+	now := time.Now()
+	event.ID = uuid.New().String()
+	event.CreatedAt = now
+	event.UpdatedAt = now
+
+	if _, err := s.collection.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("failed to create institute event: %w", err)
+	}
+	return nil
+}
+
+// Get returns an event, or nil when there is none with the ID
+func (s *InstituteEventStore) Get(ctx context.Context, id string) (*InstituteEvent, error) {
+	var event InstituteEvent
+	err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&event)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get institute event: %w", err)
+	}
+	return &event, nil
+}
+
+// Replace overwrites an existing event, keeping its creation time. It reports
+// whether the event exists.
+func (s *InstituteEventStore) Replace(ctx context.Context, event *InstituteEvent) (bool, error) {
+	event.UpdatedAt = time.Now()
+
+	update := bson.M{"$set": bson.M{
+		"institute":   event.Institute,
+		"kind":        event.Kind,
+		"title":       event.Title,
+		"description": event.Description,
+		"starts_at":   event.StartsAt,
+		"ends_at":     event.EndsAt,
+		"venue":       event.Venue,
+		"district":    event.District,
+		"url":         event.URL,
+		"programs":    event.Programs,
+		"updated_at":  event.UpdatedAt,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err := s.collection.FindOneAndUpdate(ctx, bson.M{"_id": event.ID}, update, opts).Decode(event)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update institute event: %w", err)
+	}
+	return true, nil
+}
+
+// Delete removes an event and reports whether it existed
+func (s *InstituteEventStore) Delete(ctx context.Context, id string) (bool, error) {
+	result, err := s.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete institute event: %w", err)
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// List returns the events matching a filter, soonest first
+func (s *InstituteEventStore) List(ctx context.Context, filter EventFilter) ([]InstituteEvent, error) {
+	query := bson.M{}
+	if filter.Institute != "" {
+		query["institute"] = filter.Institute
+	}
+	if len(filter.Kinds) > 0 {
+		query["kind"] = bson.M{"$in": filter.Kinds}
+	}
+	if filter.District != "" {
+		query["district"] = filter.District
+	}
+	if !filter.From.IsZero() {
+		// Events running over several days stay listed until they end
+		query["$or"] = bson.A{
+			bson.M{"ends_at": bson.M{"$gte": filter.From}},
+			bson.M{"ends_at": nil, "starts_at": bson.M{"$gte": filter.From}},
+		}
+	}
+	if !filter.To.IsZero() {
+		query["starts_at"] = bson.M{"$lt": filter.To}
+	}
+	if !filter.UpdatedSince.IsZero() {
+		query["updated_at"] = bson.M{"$gt": filter.UpdatedSince}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "starts_at", Value: 1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+
+	cursor, err := s.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list institute events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []InstituteEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode institute events: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,175 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// Job role details cache collection name
+	JobRoleCacheCollection = "job_role_details"
+
+	// Default job role cache TTL (30 days - salary and demand data drift slowly)
+	DefaultJobRoleCacheTTL = 30 * 24 * time.Hour
+)
+
+// CachedJobRoleDetails represents cached LLM-generated job role details
+type CachedJobRoleDetails struct {
+	RoleKey        string                 `bson:"role_key" json:"role_key"`
+	RoleName       string                 `bson:"role_name" json:"role_name"`
+	ProgramContext string                 `bson:"program_context" json:"program_context"`
+	Data           map[string]interface{} `bson:"data" json:"data"`
+	CreatedAt      time.Time              `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time              `bson:"updated_at" json:"updated_at"`
+	ExpiresAt      time.Time              `bson:"expires_at" json:"expires_at"`
+}
+
+// JobRoleCache handles caching operations for job role details. A nil cache
+// never hits.
+type JobRoleCache struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+	cacheTTL   time.Duration
+}
+
+// NewJobRoleCache creates a new job role details cache
+func NewJobRoleCache(client *Client, logger *zap.Logger) *JobRoleCache {
+	if client == nil {
+		return nil
+	}
+
+	cache := &JobRoleCache{
+		client:     client,
+		collection: client.GetCollection(JobRoleCacheCollection),
+		logger:     logger,
+		cacheTTL:   DefaultJobRoleCacheTTL,
+	}
+
+	// Initialize indexes in background
+	go cache.ensureIndexes()
+
+	return cache
+}
+
+// ensureIndexes creates necessary indexes for job role lookups
+func (c *JobRoleCache) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "role_key", Value: 1},
+				{Key: "program_context", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("role_context_idx"),
+		},
+		{
+			Keys: bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().
+				SetExpireAfterSeconds(0). // TTL index - MongoDB auto-deletes expired docs
+				SetName("ttl_index"),
+		},
+	}
+
+	if _, err := c.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		c.logger.Error("Failed to create indexes for job role cache", zap.Error(err))
+	} else {
+		c.logger.Info("Job role cache indexes created successfully")
+	}
+}
+
+// Get retrieves cached job role details generated for a specific program context
+func (c *JobRoleCache) Get(ctx context.Context, roleName, programContext string) (map[string]interface{}, bool, error) {
+	if c == nil {
+		return nil, false, nil
+	}
+
+	filter := bson.M{
+		"role_key":        roleKey(roleName),
+		"program_context": programContext,
+		"expires_at":      bson.M{"$gt": time.Now()},
+	}
+	return c.findOne(ctx, filter, nil)
+}
+
+// GetAny retrieves the most recently cached job role details for a role, whatever
+// program context they were generated for
+func (c *JobRoleCache) GetAny(ctx context.Context, roleName string) (map[string]interface{}, bool, error) {
+	if c == nil {
+		return nil, false, nil
+	}
+
+	filter := bson.M{
+		"role_key":   roleKey(roleName),
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	return c.findOne(ctx, filter, opts)
+}
+
+func (c *JobRoleCache) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (map[string]interface{}, bool, error) {
+	var cached CachedJobRoleDetails
+	var err error
+	if opts != nil {
+		err = c.collection.FindOne(ctx, filter, opts).Decode(&cached)
+	} else {
+		err = c.collection.FindOne(ctx, filter).Decode(&cached)
+	}
+
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to retrieve cached job role details: %w", err)
+	}
+
+	return cached.Data, true, nil
+}
+
+// Set stores job role details in the cache
+func (c *JobRoleCache) Set(ctx context.Context, roleName, programContext string, data map[string]interface{}) error {
+	if c == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	filter := bson.M{
+		"role_key":        roleKey(roleName),
+		"program_context": programContext,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"role_name":  roleName,
+			"data":       data,
+			"updated_at": now,
+			"expires_at": now.Add(c.cacheTTL),
+		},
+		"$setOnInsert": bson.M{
+			"created_at": now,
+		},
+	}
+
+	if _, err := c.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to cache job role details: %w", err)
+	}
+
+	c.logger.Info("Job role details cached",
+		zap.String("role", roleName),
+		zap.String("context", programContext))
+	return nil
+}
+
+// roleKey normalizes a role name for case-insensitive lookups
+func roleKey(roleName string) string {
+	return strings.ToLower(strings.Join(strings.Fields(roleName), " "))
+}
@@ -25,13 +25,27 @@ type CachedLearningRoadmap struct {
 	Data           map[string]interface{} `bson:"data" json:"data"`
 	CreatedAt      time.Time              `bson:"created_at" json:"created_at"`
 	UpdatedAt      time.Time              `bson:"updated_at" json:"updated_at"`
-	ExpiresAt      time.Time              `bson:"expires_at" json:"expires_at"`
+	ExpiresAt      time.Time              `bson:"expires_at,omitempty" json:"expires_at,omitempty"` // unset while pinned
 	Version        int                    `bson:"version" json:"version"`
 	HitCount       int64                  `bson:"hit_count" json:"hit_count"`
 	LastAccessedAt time.Time              `bson:"last_accessed_at" json:"last_accessed_at"`
+
+	// Pinned entries, e.g. roadmaps reviewed by a counselor, never expire and
+	// are not replaced, invalidated or cleared until unpinned
+	Pinned   bool       `bson:"pinned,omitempty" json:"pinned,omitempty"`
+	PinnedAt *time.Time `bson:"pinned_at,omitempty" json:"pinned_at,omitempty"`
+}
+
+// servable matches the entries Get serves: unexpired or pinned
+func servable(now time.Time) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"expires_at": bson.M{"$gt": now}},
+		bson.M{"pinned": true},
+	}}
 }
 
-// LearningRoadmapCache handles caching operations for learning roadmaps
+// LearningRoadmapCache handles caching operations for learning roadmaps. Without
+// a database client (demo mode) the cache is nil: it never hits and drops writes.
 type LearningRoadmapCache struct {
 	client     *Client
 	collection *mongo.Collection
@@ -41,6 +55,10 @@ type LearningRoadmapCache struct {
 
 // NewLearningRoadmapCache creates a new learning roadmap cache
 func NewLearningRoadmapCache(client *Client, logger *zap.Logger) *LearningRoadmapCache {
+	if client == nil {
+		return nil
+	}
+
 	collection := client.GetCollection(LearningRoadmapCollection)
 
 	cache := &LearningRoadmapCache{
@@ -58,6 +76,10 @@ func NewLearningRoadmapCache(client *Client, logger *zap.Logger) *LearningRoadma
 
 // SetCacheTTL sets a custom cache TTL
 func (c *LearningRoadmapCache) SetCacheTTL(ttl time.Duration) {
+	if c == nil {
+		return
+	}
+
 	c.cacheTTL = ttl
 }
 
@@ -102,11 +124,13 @@ func (c *LearningRoadmapCache) ensureIndexes() {
 
 // Get retrieves a cached learning roadmap
 func (c *LearningRoadmapCache) Get(ctx context.Context, programName string) (map[string]interface{}, bool, error) {
-	filter := bson.M{
-		"program_name": programName,
-		"expires_at":   bson.M{"$gt": time.Now()}, // Only get non-expired entries
+	if c == nil {
+		return nil, false, nil
 	}
 
+	filter := servable(time.Now())
+	filter["program_name"] = programName
+
 	var cached CachedLearningRoadmap
 	err := c.collection.FindOne(ctx, filter).Decode(&cached)
 
@@ -134,33 +158,41 @@ func (c *LearningRoadmapCache) Get(ctx context.Context, programName string) (map
 	return cached.Data, true, nil
 }
 
-// Set stores a learning roadmap in the cache
+// Set stores a learning roadmap in the cache. A pinned roadmap is kept, and
+// the new one is dropped.
 func (c *LearningRoadmapCache) Set(ctx context.Context, programName string, data map[string]interface{}) error {
+	if c == nil {
+		return nil
+	}
+
 	now := time.Now()
 	expiresAt := now.Add(c.cacheTTL)
 
-	cached := CachedLearningRoadmap{
-		ProgramName:    programName,
-		Data:           data,
-		CreatedAt:      now,
-		UpdatedAt:      now,
-		ExpiresAt:      expiresAt,
-		Version:        1,
-		HitCount:       0,
-		LastAccessedAt: now,
-	}
-
-	filter := bson.M{"program_name": programName}
+	// Hit statistics are only set on insert, so a roadmap regenerated by any
+	// instance keeps the hits counted by all of them
+	filter := bson.M{"program_name": programName, "pinned": bson.M{"$ne": true}}
 	update := bson.M{
-		"$set": cached,
+		"$set": bson.M{
+			"data":       data,
+			"updated_at": now,
+			"expires_at": expiresAt,
+			"version":    1,
+		},
 		"$setOnInsert": bson.M{
-			"created_at": now,
+			"created_at":       now,
+			"hit_count":        0,
+			"last_accessed_at": now,
 		},
 	}
 
 	opts := options.Update().SetUpsert(true)
 	result, err := c.collection.UpdateOne(ctx, filter, update, opts)
-
+	if mongo.IsDuplicateKeyError(err) {
+		// The program's entry is pinned, so the upsert could not insert another
+		c.logger.Info("Learning roadmap pinned, keeping cached entry",
+			zap.String("program", programName))
+		return nil
+	}
 	if err != nil {
 		c.logger.Error("Failed to cache learning roadmap",
 			zap.String("program", programName),
@@ -200,9 +232,13 @@ func (c *LearningRoadmapCache) incrementHitCount(programName string) {
 	}
 }
 
-// Delete removes a cached learning roadmap
+// Delete removes a cached learning roadmap unless it is pinned
 func (c *LearningRoadmapCache) Delete(ctx context.Context, programName string) error {
-	filter := bson.M{"program_name": programName}
+	if c == nil {
+		return nil
+	}
+
+	filter := bson.M{"program_name": programName, "pinned": bson.M{"$ne": true}}
 
 	result, err := c.collection.DeleteOne(ctx, filter)
 	if err != nil {
@@ -222,6 +258,10 @@ func (c *LearningRoadmapCache) Delete(ctx context.Context, programName string) e
 
 // InvalidateExpired manually removes expired cache entries (MongoDB TTL does this automatically)
 func (c *LearningRoadmapCache) InvalidateExpired(ctx context.Context) (int64, error) {
+	if c == nil {
+		return 0, nil
+	}
+
 	filter := bson.M{"expires_at": bson.M{"$lt": time.Now()}}
 
 	result, err := c.collection.DeleteMany(ctx, filter)
@@ -240,23 +280,34 @@ func (c *LearningRoadmapCache) InvalidateExpired(ctx context.Context) (int64, er
 
 // GetStats returns cache statistics
 func (c *LearningRoadmapCache) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	if c == nil {
+		return map[string]interface{}{
+			"total_entries":   0,
+			"active_entries":  0,
+			"expired_entries": 0,
+			"top_programs":    []bson.M{},
+		}, nil
+	}
 	// Total entries
 	totalCount, err := c.collection.CountDocuments(ctx, bson.M{})
 	if err != nil {
 		return nil, err
 	}
 
-	// Active (non-expired) entries
-	activeCount, err := c.collection.CountDocuments(ctx, bson.M{
-		"expires_at": bson.M{"$gt": time.Now()},
-	})
+	// Active (non-expired or pinned) entries
+	activeCount, err := c.collection.CountDocuments(ctx, servable(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	pinnedCount, err := c.collection.CountDocuments(ctx, bson.M{"pinned": true})
 	if err != nil {
 		return nil, err
 	}
 
 	// Most accessed programs
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.M{"expires_at": bson.M{"$gt": time.Now()}}}},
+		{{Key: "$match", Value: servable(time.Now())}},
 		{{Key: "$sort", Value: bson.M{"hit_count": -1}}},
 		{{Key: "$limit", Value: 10}},
 		{{Key: "$project", Value: bson.M{
@@ -281,6 +332,7 @@ func (c *LearningRoadmapCache) GetStats(ctx context.Context) (map[string]interfa
 		"total_entries":   totalCount,
 		"active_entries":  activeCount,
 		"expired_entries": totalCount - activeCount,
+		"pinned_entries":  pinnedCount,
 		"cache_ttl_hours": c.cacheTTL.Hours(),
 		"top_programs":    topPrograms,
 	}
@@ -288,9 +340,13 @@ func (c *LearningRoadmapCache) GetStats(ctx context.Context) (map[string]interfa
 	return stats, nil
 }
 
-// Clear removes all cache entries (use with caution)
+// Clear removes all cache entries but the pinned ones (use with caution)
 func (c *LearningRoadmapCache) Clear(ctx context.Context) error {
-	result, err := c.collection.DeleteMany(ctx, bson.M{})
+	if c == nil {
+		return nil
+	}
+
+	result, err := c.collection.DeleteMany(ctx, bson.M{"pinned": bson.M{"$ne": true}})
 	if err != nil {
 		c.logger.Error("Failed to clear cache", zap.Error(err))
 		return err
@@ -304,6 +360,10 @@ func (c *LearningRoadmapCache) Clear(ctx context.Context) error {
 
 // RefreshTTL extends the expiration time for a cached entry
 func (c *LearningRoadmapCache) RefreshTTL(ctx context.Context, programName string) error {
+	if c == nil {
+		return nil
+	}
+
 	filter := bson.M{"program_name": programName}
 	update := bson.M{
 		"$set": bson.M{
@@ -326,3 +386,50 @@ func (c *LearningRoadmapCache) RefreshTTL(ctx context.Context, programName strin
 
 	return nil
 }
+
+// SetPinned pins or unpins a cached roadmap, reporting whether the program has
+// one. A pinned entry loses its expiry; an unpinned one expires after the cache
+// TTL from now.
+func (c *LearningRoadmapCache) SetPinned(ctx context.Context, programName string, pinned bool) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set":   bson.M{"pinned": true, "pinned_at": now, "updated_at": now},
+		"$unset": bson.M{"expires_at": ""},
+	}
+	if !pinned {
+		update = bson.M{
+			"$set":   bson.M{"expires_at": now.Add(c.cacheTTL), "updated_at": now},
+			"$unset": bson.M{"pinned": "", "pinned_at": ""},
+		}
+	}
+
+	result, err := c.collection.UpdateOne(ctx, bson.M{"program_name": programName}, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to pin cached learning roadmap: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return false, nil
+	}
+
+	c.logger.Info("Cached learning roadmap pin changed",
+		zap.String("program", programName),
+		zap.Bool("pinned", pinned))
+	return true, nil
+}
+
+// IsPinned reports whether the program's cached roadmap is pinned
+func (c *LearningRoadmapCache) IsPinned(ctx context.Context, programName string) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	count, err := c.collection.CountDocuments(ctx, bson.M{"program_name": programName, "pinned": true})
+	if err != nil {
+		return false, fmt.Errorf("failed to check pinned learning roadmap: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,116 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// Leases collection name
+const LeaseCollection = "leases"
+
+// Lease is a named, time-limited claim held by one server instance
+type Lease struct {
+	Name       string    `bson:"_id" json:"name"`
+	Holder     string    `bson:"holder" json:"holder"`
+	AcquiredAt time.Time `bson:"acquired_at" json:"acquired_at"`
+	ExpiresAt  time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+// LeaseStore grants leases so background work that writes shared state runs on
+// one instance at a time when several replicas share the database. A lease
+// expires on its own, so an instance that dies while holding one only delays
+// the work until another instance takes it over.
+type LeaseStore struct {
+	client     *Client
+	collection *mongo.Collection
+	holder     string
+	logger     *zap.Logger
+}
+
+// NewLeaseStore creates a lease store whose leases are held in the name of this
+// process
+func NewLeaseStore(client *Client, logger *zap.Logger) *LeaseStore {
+	return &LeaseStore{
+		client:     client,
+		collection: client.GetCollection(LeaseCollection),
+		holder:     newHolderID(),
+		logger:     logger,
+	}
+}
+
+// newHolderID names this process: the host, so leases can be traced to a
+// replica, and a random suffix, so restarts on the same host are told apart
+func newHolderID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return host + "-" + hex.EncodeToString(suffix)
+}
+
+// Holder returns the name leases are held in by this process
+func (s *LeaseStore) Holder() string {
+	return s.holder
+}
+
+// Acquire takes or renews the named lease for ttl and reports whether this
+// process holds it. It returns false while another process holds an unexpired
+// lease of the same name.
+func (s *LeaseStore) Acquire(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"holder": s.holder},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	// A pipeline update, so renewals keep the original acquisition time
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"acquired_at": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$holder", s.holder}}, "$acquired_at", now}},
+		"holder":      s.holder,
+		"expires_at":  now.Add(ttl),
+	}}}}
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease %s: %w", name, err)
+	}
+	if result.MatchedCount > 0 {
+		return true, nil
+	}
+
+	// No lease to take over: either none exists yet or another process holds it
+	_, err = s.collection.InsertOne(ctx, Lease{
+		Name:       name,
+		Holder:     s.holder,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(ttl),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// Release gives up the named lease if this process holds it, so another
+// instance can take it without waiting for it to expire
+func (s *LeaseStore) Release(ctx context.Context, name string) error {
+	_, err := s.collection.DeleteOne(ctx, bson.M{"_id": name, "holder": s.holder})
+	if err != nil {
+		return fmt.Errorf("failed to release lease %s: %w", name, err)
+	}
+	return nil
+}
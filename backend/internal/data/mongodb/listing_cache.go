@@ -0,0 +1,97 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Listing cache collection name
+const ListingCacheCollection = "listing_cache"
+
+// cachedListing is an encoded graph listing shared between instances
+type cachedListing struct {
+	Key       string    `bson:"_id"`
+	Data      []byte    `bson:"data"`
+	LoadedAt  time.Time `bson:"loaded_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// ListingCache shares encoded graph listings between instances, so a listing
+// read from the graph by one is served to the others until it expires. A nil
+// cache holds nothing.
+type ListingCache struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewListingCache creates a new listing cache
+func NewListingCache(client *Client, logger *zap.Logger) *ListingCache {
+	if client == nil {
+		return nil
+	}
+
+	cache := &ListingCache{
+		client:     client,
+		collection: client.GetCollection(ListingCacheCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go cache.ensureIndexes()
+
+	return cache
+}
+
+// ensureIndexes removes listings once they expire
+func (c *ListingCache) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0).SetName("expiry_index"),
+	}
+	if _, err := c.collection.Indexes().CreateOne(ctx, index); err != nil {
+		c.logger.Error("Failed to create indexes for listing cache", zap.Error(err))
+	}
+}
+
+// Get returns the listing cached under key and when it was read from the graph,
+// or false when there is none or it has expired
+func (c *ListingCache) Get(ctx context.Context, key string) ([]byte, time.Time, bool, error) {
+	if c == nil {
+		return nil, time.Time{}, false, nil
+	}
+
+	var listing cachedListing
+	err := c.collection.FindOne(ctx, bson.M{"_id": key, "expires_at": bson.M{"$gt": time.Now()}}).Decode(&listing)
+	if err == mongo.ErrNoDocuments {
+		return nil, time.Time{}, false, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("failed to get cached listing: %w", err)
+	}
+	return listing.Data, listing.LoadedAt, true, nil
+}
+
+// Set caches a listing read from the graph at loadedAt under key for ttl,
+// replacing any cached before
+func (c *ListingCache) Set(ctx context.Context, key string, data []byte, loadedAt time.Time, ttl time.Duration) error {
+	if c == nil {
+		return nil
+	}
+
+	listing := cachedListing{Key: key, Data: data, LoadedAt: loadedAt, ExpiresAt: time.Now().Add(ttl)}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := c.collection.ReplaceOne(ctx, bson.M{"_id": key}, listing, opts); err != nil {
+		return fmt.Errorf("failed to cache listing: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Living costs collection name
+const LivingCostCollection = "living_costs"
+
+// LivingCost is what a student living away from home typically spends per month
+// in a district, in rupees
+type LivingCost struct {
+	District  string    `bson:"_id" json:"district"`
+	Boarding  int       `bson:"boarding" json:"boarding"`
+	Transport int       `bson:"transport" json:"transport"` // local travel to classes
+	Food      int       `bson:"food" json:"food"`
+	Year      int       `bson:"year" json:"year"`
+	Source    string    `bson:"source,omitempty" json:"source,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Total returns the monthly cost of boarding, transport and food
+func (lc LivingCost) Total() int {
+	return lc.Boarding + lc.Transport + lc.Food
+}
+
+// LivingCostStore keeps living costs per district. A nil store has no costs.
+type LivingCostStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewLivingCostStore creates a new living cost store
+func NewLivingCostStore(client *Client, logger *zap.Logger) *LivingCostStore {
+	if client == nil {
+		return nil
+	}
+
+	return &LivingCostStore{
+		client:     client,
+		collection: client.GetCollection(LivingCostCollection),
+		logger:     logger,
+	}
+}
+
+// Upsert stores the living costs of a district, replacing earlier figures
+func (s *LivingCostStore) Upsert(ctx context.Context, cost *LivingCost) error {
+	if s == nil {
+		return nil
+	}
+
+	cost.UpdatedAt = time.Now()
+	opts := options.Replace().SetUpsert(true)
+	if _, err := s.collection.ReplaceOne(ctx, bson.M{"_id": cost.District}, cost, opts); err != nil {
+		return fmt.Errorf("failed to store living cost: %w", err)
+	}
+	return nil
+}
+
+// Get returns the living costs of a district, or nil when none are recorded
+func (s *LivingCostStore) Get(ctx context.Context, district string) (*LivingCost, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	var cost LivingCost
+	err := s.collection.FindOne(ctx, bson.M{"_id": district}).Decode(&cost)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get living cost: %w", err)
+	}
+	return &cost, nil
+}
+
+// List returns the living costs of every district recorded, by district name
+func (s *LivingCostStore) List(ctx context.Context) ([]LivingCost, error) {
+	if s == nil {
+		return []LivingCost{}, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := s.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list living costs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	costs := []LivingCost{}
+	if err := cursor.All(ctx, &costs); err != nil {
+		return nil, fmt.Errorf("failed to decode living costs: %w", err)
+	}
+	return costs, nil
+}
@@ -0,0 +1,211 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// LLM exchanges collection name
+	LLMExchangeCollection = "llm_exchanges"
+
+	// LLMExchangeRetention is how long captured exchanges are kept
+	LLMExchangeRetention = 90 * 24 * time.Hour
+)
+
+// LLMExchange is a captured prompt and response, scrubbed of personal details,
+// with the label a reviewer gave it
+type LLMExchange struct {
+	ID           string     `bson:"_id" json:"id"`
+	Task         string     `bson:"task" json:"task"`
+	Model        string     `bson:"model" json:"model"`
+	SystemPrompt string     `bson:"system_prompt" json:"system_prompt"`
+	UserPrompt   string     `bson:"user_prompt" json:"user_prompt"`
+	Temperature  float32    `bson:"temperature" json:"temperature"`
+	Response     string     `bson:"response,omitempty" json:"response,omitempty"`
+	Error        string     `bson:"error,omitempty" json:"error,omitempty"`
+	DurationMS   int64      `bson:"duration_ms" json:"duration_ms"`
+	CreatedAt    time.Time  `bson:"created_at" json:"created_at"`
+	Label        string     `bson:"label,omitempty" json:"label,omitempty"`
+	Note         string     `bson:"note,omitempty" json:"note,omitempty"`
+	LabeledBy    string     `bson:"labeled_by,omitempty" json:"labeled_by,omitempty"`
+	LabeledAt    *time.Time `bson:"labeled_at,omitempty" json:"labeled_at,omitempty"`
+}
+
+// LLMExchangeFilter narrows an exchange listing. Empty fields do not filter.
+type LLMExchangeFilter struct {
+	Task      string
+	Label     string
+	Unlabeled bool      // only exchanges no reviewer has labeled yet
+	Before    time.Time // only exchanges captured before, to page back in time
+}
+
+// LLMExchangeStore keeps captured exchanges for review
+type LLMExchangeStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewLLMExchangeStore creates a new LLM exchange store
+func NewLLMExchangeStore(client *Client, logger *zap.Logger) *LLMExchangeStore {
+	store := &LLMExchangeStore{
+		client:     client,
+		collection: client.GetCollection(LLMExchangeCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the indexes exchanges are listed by, and the TTL index
+// removing them after the retention period
+func (s *LLMExchangeStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "task", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("task_created_idx"),
+		},
+		{
+			Keys:    bson.D{{Key: "label", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("label_created_idx"),
+		},
+		{
+			Keys: bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().
+				SetExpireAfterSeconds(int32(LLMExchangeRetention.Seconds())).
+				SetName("ttl_index"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for LLM exchanges", zap.Error(err))
+	} else {
+		s.logger.Info("LLM exchange indexes created successfully")
+	}
+}
+
+// Insert stores a captured exchange, giving it an ID
+func (s *LLMExchangeStore) Insert(ctx context.Context, exchange *LLMExchange) error {
+	exchange.ID = uuid.New().String()
+	if _, err := s.collection.InsertOne(ctx, exchange); err != nil {
+		return fmt.Errorf("failed to store LLM exchange: %w", err)
+	}
+	return nil
+}
+
+// List returns exchanges matching the filter, newest first
+func (s *LLMExchangeStore) List(ctx context.Context, filter LLMExchangeFilter, limit int) ([]LLMExchange, error) {
+	query := bson.M{}
+	if filter.Task != "" {
+		query["task"] = filter.Task
+	}
+	switch {
+	case filter.Unlabeled:
+		query["label"] = bson.M{"$exists": false}
+	case filter.Label != "":
+		query["label"] = filter.Label
+	}
+	if !filter.Before.IsZero() {
+		query["created_at"] = bson.M{"$lt": filter.Before}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := s.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LLM exchanges: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	exchanges := []LLMExchange{}
+	if err := cursor.All(ctx, &exchanges); err != nil {
+		return nil, fmt.Errorf("failed to decode LLM exchanges: %w", err)
+	}
+	return exchanges, nil
+}
+
+// Get returns an exchange by ID, or nil if it does not exist
+func (s *LLMExchangeStore) Get(ctx context.Context, id string) (*LLMExchange, error) {
+	var exchange LLMExchange
+	err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&exchange)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM exchange: %w", err)
+	}
+	return &exchange, nil
+}
+
+// SetLabel labels an exchange, replacing any earlier label, and returns it, or
+// nil if it does not exist
+func (s *LLMExchangeStore) SetLabel(ctx context.Context, id, label, note, labeledBy string) (*LLMExchange, error) {
+	update := bson.M{"$set": bson.M{
+		"label":      label,
+		"note":       note,
+		"labeled_by": labeledBy,
+		"labeled_at": time.Now(),
+	}}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var exchange LLMExchange
+	err := s.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&exchange)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to label LLM exchange: %w", err)
+	}
+	return &exchange, nil
+}
+
+// CountByLabel returns the number of exchanges per task and label, with
+// unlabeled exchanges counted under "unlabeled"
+func (s *LLMExchangeStore) CountByLabel(ctx context.Context) (map[string]map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"task": "$task", "label": bson.M{"$ifNull": bson.A{"$label", "unlabeled"}}},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count LLM exchanges: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID struct {
+			Task  string `bson:"task"`
+			Label string `bson:"label"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode LLM exchange counts: %w", err)
+	}
+
+	counts := map[string]map[string]int64{}
+	for _, row := range rows {
+		if counts[row.ID.Task] == nil {
+			counts[row.ID.Task] = map[string]int64{}
+		}
+		counts[row.ID.Task][row.ID.Label] = row.Count
+	}
+	return counts, nil
+}
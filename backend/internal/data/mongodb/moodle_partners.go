@@ -0,0 +1,144 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Moodle partners collection name
+const MoodlePartnerCollection = "moodle_partners"
+
+// MoodlePartner is a partner school's Moodle site that roadmaps are exported to
+type MoodlePartner struct {
+	Name       string `bson:"name" json:"name"`
+	SiteURL    string `bson:"site_url" json:"site_url"` // e.g. https://lms.school.lk, without /webservice
+	Token      string `bson:"token" json:"token,omitempty"`
+	HasToken   bool   `bson:"-" json:"has_token"`
+	CategoryID int    `bson:"category_id" json:"category_id"` // course category new courses are created in
+	// CoursePrefix starts the short names of exported courses, which identify
+	// them on re-export
+	CoursePrefix string `bson:"course_prefix" json:"course_prefix"`
+	// SectionSummaries writes step descriptions and video links into section
+	// summaries through the local_wsmanagesections plugin, which the site must have
+	SectionSummaries bool       `bson:"section_summaries" json:"section_summaries"`
+	LastExportedAt   *time.Time `bson:"last_exported_at,omitempty" json:"last_exported_at,omitempty"`
+	CreatedAt        time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt        time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// MoodlePartnerStore persists Moodle partner configurations
+type MoodlePartnerStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewMoodlePartnerStore creates a new Moodle partner store
+func NewMoodlePartnerStore(client *Client, logger *zap.Logger) *MoodlePartnerStore {
+	store := &MoodlePartnerStore{
+		client:     client,
+		collection: client.GetCollection(MoodlePartnerCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates necessary indexes for partner lookups
+func (s *MoodlePartnerStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("name_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for Moodle partners", zap.Error(err))
+	} else {
+		s.logger.Info("Moodle partner indexes created successfully")
+	}
+}
+
+// Save creates or replaces a partner by name. An empty token keeps the stored one.
+func (s *MoodlePartnerStore) Save(ctx context.Context, partner *MoodlePartner) error {
+	now := time.Now()
+	partner.UpdatedAt = now
+
+	set := bson.M{
+		"site_url":          partner.SiteURL,
+		"category_id":       partner.CategoryID,
+		"course_prefix":     partner.CoursePrefix,
+		"section_summaries": partner.SectionSummaries,
+		"updated_at":        now,
+	}
+	if partner.Token != "" {
+		set["token"] = partner.Token
+	}
+	update := bson.M{
+		"$set": set,
+		"$setOnInsert": bson.M{
+			"name":       partner.Name,
+			"created_at": now,
+		},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := s.collection.UpdateOne(ctx, bson.M{"name": partner.Name}, update, opts); err != nil {
+		s.logger.Error("Failed to save Moodle partner",
+			zap.String("name", partner.Name),
+			zap.Error(err))
+		return fmt.Errorf("failed to save Moodle partner: %w", err)
+	}
+	return nil
+}
+
+// Get returns a partner by name, or nil when it does not exist
+func (s *MoodlePartnerStore) Get(ctx context.Context, name string) (*MoodlePartner, error) {
+	var partner MoodlePartner
+	err := s.collection.FindOne(ctx, bson.M{"name": name}).Decode(&partner)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Moodle partner: %w", err)
+	}
+	return &partner, nil
+}
+
+// List returns all partners ordered by name
+func (s *MoodlePartnerStore) List(ctx context.Context) ([]MoodlePartner, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := s.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Moodle partners: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	partners := []MoodlePartner{}
+	if err := cursor.All(ctx, &partners); err != nil {
+		return nil, fmt.Errorf("failed to decode Moodle partners: %w", err)
+	}
+	return partners, nil
+}
+
+// MarkExported records when a roadmap was last exported to a partner
+func (s *MoodlePartnerStore) MarkExported(ctx context.Context, name string, at time.Time) error {
+	_, err := s.collection.UpdateOne(ctx, bson.M{"name": name}, bson.M{"$set": bson.M{"last_exported_at": at}})
+	if err != nil {
+		return fmt.Errorf("failed to update Moodle partner: %w", err)
+	}
+	return nil
+}
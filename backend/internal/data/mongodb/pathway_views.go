@@ -0,0 +1,139 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Pathway views collection name
+const PathwayViewCollection = "pathway_views"
+
+// PathwayView is the precomputed complete pathway of a department: its programs
+// with their requirements, prerequisites and careers, as served to readers
+type PathwayView struct {
+	Department string                   `bson:"department" json:"department"`
+	Programs   []map[string]interface{} `bson:"programs" json:"programs"`
+	BuiltAt    time.Time                `bson:"built_at" json:"built_at"`
+}
+
+// PathwayViewStore keeps materialized department pathways, so reads need not
+// reach the graph. A nil store has no views, so reads fall back to the graph.
+type PathwayViewStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewPathwayViewStore creates a new pathway view store
+func NewPathwayViewStore(client *Client, logger *zap.Logger) *PathwayViewStore {
+	if client == nil {
+		return nil
+	}
+
+	store := &PathwayViewStore{
+		client:     client,
+		collection: client.GetCollection(PathwayViewCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the index views are looked up by
+func (s *PathwayViewStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "department", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("department_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for pathway views", zap.Error(err))
+	} else {
+		s.logger.Info("Pathway view indexes created successfully")
+	}
+}
+
+// Get returns the view of a department, or nil if none is stored
+func (s *PathwayViewStore) Get(ctx context.Context, department string) (*PathwayView, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	var view PathwayView
+	err := s.collection.FindOne(ctx, bson.M{"department": department}).Decode(&view)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pathway view: %w", err)
+	}
+	return &view, nil
+}
+
+// Save stores views, replacing any existing view of the same department
+func (s *PathwayViewStore) Save(ctx context.Context, views []PathwayView) error {
+	if s == nil {
+		return nil
+	}
+
+	if len(views) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(views))
+	for _, view := range views {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"department": view.Department}).
+			SetReplacement(view).
+			SetUpsert(true))
+	}
+
+	if _, err := s.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to store pathway views: %w", err)
+	}
+	return nil
+}
+
+// DeleteExcept removes the views of departments not in the given list, which
+// have been removed or renamed since they were built
+func (s *PathwayViewStore) DeleteExcept(ctx context.Context, departments []string) (int64, error) {
+	if s == nil {
+		return 0, nil
+	}
+
+	if departments == nil {
+		departments = []string{}
+	}
+	result, err := s.collection.DeleteMany(ctx, bson.M{"department": bson.M{"$nin": departments}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete pathway views: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+// DeleteAll removes every view, so reads fall back to the graph until the views
+// are rebuilt
+func (s *PathwayViewStore) DeleteAll(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	if _, err := s.collection.DeleteMany(ctx, bson.M{}); err != nil {
+		return fmt.Errorf("failed to delete pathway views: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Program intakes collection name
+const ProgramIntakeCollection = "program_intakes"
+
+// ProgramIntake is the number of students a program admitted in an academic year
+// and, when known, how many applied for it
+type ProgramIntake struct {
+	ProgramName  string    `bson:"program_name" json:"program_name"`
+	Year         int       `bson:"year" json:"year"`
+	Intake       int       `bson:"intake" json:"intake"`
+	Applications int       `bson:"applications,omitempty" json:"applications,omitempty"`
+	Source       string    `bson:"source,omitempty" json:"source,omitempty"`
+	UpdatedAt    time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// ProgramIntakeStore keeps annual intake sizes and application counts per program.
+// A nil store has no intakes.
+type ProgramIntakeStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewProgramIntakeStore creates a new program intake store
+func NewProgramIntakeStore(client *Client, logger *zap.Logger) *ProgramIntakeStore {
+	if client == nil {
+		return nil
+	}
+
+	store := &ProgramIntakeStore{
+		client:     client,
+		collection: client.GetCollection(ProgramIntakeCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the unique program/year index used for upserts and lookups
+func (s *ProgramIntakeStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "program_name", Value: 1},
+				{Key: "year", Value: -1},
+			},
+			Options: options.Index().SetUnique(true).SetName("program_year_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for program intakes", zap.Error(err))
+	} else {
+		s.logger.Info("Program intake indexes created successfully")
+	}
+}
+
+// Upsert stores intakes, replacing any existing record for the same program and year
+func (s *ProgramIntakeStore) Upsert(ctx context.Context, intakes []ProgramIntake) error {
+	if s == nil {
+		return nil
+	}
+
+	if len(intakes) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(intakes))
+	for _, intake := range intakes {
+		intake.UpdatedAt = now
+		filter := bson.M{
+			"program_name": intake.ProgramName,
+			"year":         intake.Year,
+		}
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(intake).SetUpsert(true))
+	}
+
+	if _, err := s.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to store program intakes: %w", err)
+	}
+	return nil
+}
+
+// ListByProgram returns all intakes of a program, newest year first
+func (s *ProgramIntakeStore) ListByProgram(ctx context.Context, programName string) ([]ProgramIntake, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "year", Value: -1}})
+
+	cursor, err := s.collection.Find(ctx, bson.M{"program_name": programName}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query program intakes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	intakes := []ProgramIntake{}
+	if err := cursor.All(ctx, &intakes); err != nil {
+		return nil, fmt.Errorf("failed to decode program intakes: %w", err)
+	}
+	return intakes, nil
+}
+
+// LatestByPrograms returns the most recent intake of each of the given programs,
+// keyed by program name. Programs without intake data are left out.
+func (s *ProgramIntakeStore) LatestByPrograms(ctx context.Context, programNames []string) (map[string]ProgramIntake, error) {
+	if s == nil {
+		return map[string]ProgramIntake{}, nil
+	}
+
+	latest := make(map[string]ProgramIntake)
+	if len(programNames) == 0 {
+		return latest, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"program_name": bson.M{"$in": programNames}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "program_name", Value: 1}, {Key: "year", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$program_name", "intake": bson.M{"$first": "$$ROOT"}}}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate program intakes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Intake ProgramIntake `bson:"intake"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("failed to decode program intakes: %w", err)
+	}
+	for _, group := range groups {
+		latest[group.Intake.ProgramName] = group.Intake
+	}
+	return latest, nil
+}
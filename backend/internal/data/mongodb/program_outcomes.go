@@ -0,0 +1,191 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Program outcome collection names
+const (
+	ProgramOutcomeCollection       = "program_outcomes"
+	ProgramOutcomeReportCollection = "program_outcome_reports"
+)
+
+// maxOutcomeReports bounds the self-reports read to summarize one program
+const maxOutcomeReports = 10000
+
+// ProgramOutcome is what became of a program's graduates of one year, as found
+// by a tracer study or another survey of graduates
+type ProgramOutcome struct {
+	ProgramName string `bson:"program_name" json:"program_name"`
+	Year        int    `bson:"year" json:"year"` // graduation year
+	Respondents int    `bson:"respondents" json:"respondents"`
+	// EmploymentRate is the share of respondents employed, between 0 and 1
+	EmploymentRate float64 `bson:"employment_rate" json:"employment_rate"`
+	// MonthsToEmployment is the median months from graduation to a first job
+	MonthsToEmployment float64   `bson:"months_to_employment,omitempty" json:"months_to_employment,omitempty"`
+	FirstJobs          []string  `bson:"first_jobs,omitempty" json:"first_jobs,omitempty"`
+	Source             string    `bson:"source,omitempty" json:"source,omitempty"`
+	UpdatedAt          time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// OutcomeReport is a graduate's own account of finding work after a program.
+// Each user has one report per program.
+type OutcomeReport struct {
+	UserID             string    `bson:"user_id" json:"-"`
+	ProgramName        string    `bson:"program_name" json:"program_name"`
+	GraduationYear     int       `bson:"graduation_year" json:"graduation_year"`
+	Employed           bool      `bson:"employed" json:"employed"`
+	MonthsToEmployment *int      `bson:"months_to_employment,omitempty" json:"months_to_employment,omitempty"`
+	FirstJob           string    `bson:"first_job,omitempty" json:"first_job,omitempty"`
+	UpdatedAt          time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// ProgramOutcomeStore keeps graduate outcomes per program, both ingested survey
+// results and graduates' self-reports. A nil store has no outcomes.
+type ProgramOutcomeStore struct {
+	client   *Client
+	outcomes *mongo.Collection
+	reports  *mongo.Collection
+	logger   *zap.Logger
+}
+
+// NewProgramOutcomeStore creates a new program outcome store
+func NewProgramOutcomeStore(client *Client, logger *zap.Logger) *ProgramOutcomeStore {
+	if client == nil {
+		return nil
+	}
+
+	store := &ProgramOutcomeStore{
+		client:   client,
+		outcomes: client.GetCollection(ProgramOutcomeCollection),
+		reports:  client.GetCollection(ProgramOutcomeReportCollection),
+		logger:   logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the unique indexes outcomes and self-reports are upserted by
+func (s *ProgramOutcomeStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	outcomeIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "program_name", Value: 1}, {Key: "year", Value: -1}},
+			Options: options.Index().SetUnique(true).SetName("program_year_idx"),
+		},
+	}
+	reportIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "program_name", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("program_user_idx"),
+		},
+	}
+
+	if _, err := s.outcomes.Indexes().CreateMany(ctx, outcomeIndexes); err != nil {
+		s.logger.Error("Failed to create indexes for program outcomes", zap.Error(err))
+		return
+	}
+	if _, err := s.reports.Indexes().CreateMany(ctx, reportIndexes); err != nil {
+		s.logger.Error("Failed to create indexes for outcome reports", zap.Error(err))
+		return
+	}
+	s.logger.Info("Program outcome indexes created successfully")
+}
+
+// Upsert stores survey outcomes, replacing any existing record for the same
+// program and year
+func (s *ProgramOutcomeStore) Upsert(ctx context.Context, outcomes []ProgramOutcome) error {
+	if s == nil || len(outcomes) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		outcome.UpdatedAt = now
+		filter := bson.M{
+			"program_name": outcome.ProgramName,
+			"year":         outcome.Year,
+		}
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(outcome).SetUpsert(true))
+	}
+
+	if _, err := s.outcomes.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to store program outcomes: %w", err)
+	}
+	return nil
+}
+
+// ListByProgram returns the survey outcomes of a program, newest year first
+func (s *ProgramOutcomeStore) ListByProgram(ctx context.Context, programName string) ([]ProgramOutcome, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "year", Value: -1}})
+
+	cursor, err := s.outcomes.Find(ctx, bson.M{"program_name": programName}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query program outcomes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	outcomes := []ProgramOutcome{}
+	if err := cursor.All(ctx, &outcomes); err != nil {
+		return nil, fmt.Errorf("failed to decode program outcomes: %w", err)
+	}
+	return outcomes, nil
+}
+
+// SaveReport stores a graduate's self-report, replacing their earlier report on
+// the same program
+func (s *ProgramOutcomeStore) SaveReport(ctx context.Context, report *OutcomeReport) error {
+	if s == nil {
+		return nil
+	}
+
+	report.UpdatedAt = time.Now()
+	filter := bson.M{
+		"program_name": report.ProgramName,
+		"user_id":      report.UserID,
+	}
+	if _, err := s.reports.ReplaceOne(ctx, filter, report, options.Replace().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to store outcome report: %w", err)
+	}
+	return nil
+}
+
+// ListReports returns the self-reports on a program, newest first
+func (s *ProgramOutcomeStore) ListReports(ctx context.Context, programName string) ([]OutcomeReport, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+		SetLimit(maxOutcomeReports)
+
+	cursor, err := s.reports.Find(ctx, bson.M{"program_name": programName}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outcome reports: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	reports := []OutcomeReport{}
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, fmt.Errorf("failed to decode outcome reports: %w", err)
+	}
+	return reports, nil
+}
@@ -0,0 +1,107 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Qualification aliases collection name
+const QualificationAliasCollection = "qualification_aliases"
+
+// QualificationAlias is another name students give a qualification or subject,
+// such as "AL" for "G.C.E. (A/L) Examination Pass" or "Maths" for "Combined
+// Mathematics". Key is the alias as AliasKey reduces it, so spellings differing
+// only in case, spacing or punctuation share one entry.
+type QualificationAlias struct {
+	Key       string    `bson:"_id" json:"key"`
+	Alias     string    `bson:"alias" json:"alias"`
+	Name      string    `bson:"name" json:"name"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// AliasKey reduces a qualification or subject name to its lowercase letters and
+// digits, so that "A/L", "a.l" and "AL" are looked up alike
+func AliasKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// QualificationAliasStore keeps the aliases of qualification and subject names.
+// A nil store has no aliases.
+type QualificationAliasStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewQualificationAliasStore creates a new qualification alias store
+func NewQualificationAliasStore(client *Client, logger *zap.Logger) *QualificationAliasStore {
+	if client == nil {
+		return nil
+	}
+
+	return &QualificationAliasStore{
+		client:     client,
+		collection: client.GetCollection(QualificationAliasCollection),
+		logger:     logger,
+	}
+}
+
+// Upsert stores an alias, replacing what it stood for before
+func (s *QualificationAliasStore) Upsert(ctx context.Context, alias *QualificationAlias) error {
+	if s == nil {
+		return nil
+	}
+
+	alias.UpdatedAt = time.Now()
+	opts := options.Replace().SetUpsert(true)
+	if _, err := s.collection.ReplaceOne(ctx, bson.M{"_id": alias.Key}, alias, opts); err != nil {
+		return fmt.Errorf("failed to store qualification alias: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the alias with a key, reporting whether there was one
+func (s *QualificationAliasStore) Delete(ctx context.Context, key string) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+
+	result, err := s.collection.DeleteOne(ctx, bson.M{"_id": key})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete qualification alias: %w", err)
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// List returns every alias, by key
+func (s *QualificationAliasStore) List(ctx context.Context) ([]QualificationAlias, error) {
+	if s == nil {
+		return []QualificationAlias{}, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := s.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list qualification aliases: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	aliases := []QualificationAlias{}
+	if err := cursor.All(ctx, &aliases); err != nil {
+		return nil, fmt.Errorf("failed to decode qualification aliases: %w", err)
+	}
+	return aliases, nil
+}
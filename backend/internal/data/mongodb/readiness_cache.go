@@ -0,0 +1,134 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// Readiness assessment cache collection name
+	ReadinessCacheCollection = "readiness_assessments"
+
+	// Default readiness cache TTL (14 days - requirements and tests change with intakes)
+	DefaultReadinessCacheTTL = 14 * 24 * time.Hour
+)
+
+// CachedReadinessAssessment is an LLM-generated readiness assessment of a student
+// profile for a program. Profiles are kept only as a hash.
+type CachedReadinessAssessment struct {
+	ProfileHash string                 `bson:"profile_hash" json:"profile_hash"`
+	ProgramName string                 `bson:"program_name" json:"program_name"`
+	Data        map[string]interface{} `bson:"data" json:"data"`
+	CreatedAt   time.Time              `bson:"created_at" json:"created_at"`
+	ExpiresAt   time.Time              `bson:"expires_at" json:"expires_at"`
+}
+
+// ReadinessCache handles caching of readiness assessments by profile hash and
+// program. A nil cache never hits.
+type ReadinessCache struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+	cacheTTL   time.Duration
+}
+
+// NewReadinessCache creates a new readiness assessment cache
+func NewReadinessCache(client *Client, logger *zap.Logger) *ReadinessCache {
+	if client == nil {
+		return nil
+	}
+
+	cache := &ReadinessCache{
+		client:     client,
+		collection: client.GetCollection(ReadinessCacheCollection),
+		logger:     logger,
+		cacheTTL:   DefaultReadinessCacheTTL,
+	}
+
+	// Initialize indexes in background
+	go cache.ensureIndexes()
+
+	return cache
+}
+
+// ensureIndexes creates the lookup index and the TTL index expiring assessments
+func (c *ReadinessCache) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "profile_hash", Value: 1},
+				{Key: "program_name", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("profile_program_idx"),
+		},
+		{
+			Keys: bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().
+				SetExpireAfterSeconds(0). // TTL index - MongoDB auto-deletes expired docs
+				SetName("ttl_index"),
+		},
+	}
+
+	if _, err := c.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		c.logger.Error("Failed to create indexes for readiness cache", zap.Error(err))
+	} else {
+		c.logger.Info("Readiness cache indexes created successfully")
+	}
+}
+
+// Get retrieves the cached assessment of a profile for a program
+func (c *ReadinessCache) Get(ctx context.Context, profileHash, programName string) (map[string]interface{}, bool, error) {
+	if c == nil {
+		return nil, false, nil
+	}
+
+	filter := bson.M{
+		"profile_hash": profileHash,
+		"program_name": programName,
+		"expires_at":   bson.M{"$gt": time.Now()},
+	}
+
+	var cached CachedReadinessAssessment
+	err := c.collection.FindOne(ctx, filter).Decode(&cached)
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to retrieve cached readiness assessment: %w", err)
+	}
+	return cached.Data, true, nil
+}
+
+// Set stores the assessment of a profile for a program
+func (c *ReadinessCache) Set(ctx context.Context, profileHash, programName string, data map[string]interface{}) error {
+	if c == nil {
+		return nil
+	}
+
+	now := time.Now()
+	cached := CachedReadinessAssessment{
+		ProfileHash: profileHash,
+		ProgramName: programName,
+		Data:        data,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(c.cacheTTL),
+	}
+	filter := bson.M{
+		"profile_hash": profileHash,
+		"program_name": programName,
+	}
+
+	if _, err := c.collection.ReplaceOne(ctx, filter, cached, options.Replace().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to cache readiness assessment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// Review queue collection name
+	ReviewQueueCollection = "review_queue"
+
+	// Review item statuses
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// ReviewSuggestion is a possible resolution offered to the reviewer
+type ReviewSuggestion struct {
+	Name  string  `bson:"name" json:"name"`
+	Score float64 `bson:"score" json:"score"`
+}
+
+// ReviewItem is a proposed change waiting for an admin decision. Source identifies
+// the pipeline that produced it and Kind tells that pipeline how to apply it.
+type ReviewItem struct {
+	ID          string                 `bson:"_id" json:"id"`
+	Source      string                 `bson:"source" json:"source"`
+	Kind        string                 `bson:"kind" json:"kind"`
+	Summary     string                 `bson:"summary" json:"summary"`
+	DedupKey    string                 `bson:"dedup_key,omitempty" json:"-"`
+	Payload     map[string]interface{} `bson:"payload" json:"payload"`
+	Suggestions []ReviewSuggestion     `bson:"suggestions,omitempty" json:"suggestions,omitempty"`
+	Status      string                 `bson:"status" json:"status"`
+	Resolution  map[string]interface{} `bson:"resolution,omitempty" json:"resolution,omitempty"`
+	ResolvedBy  string                 `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	CreatedAt   time.Time              `bson:"created_at" json:"created_at"`
+	ResolvedAt  *time.Time             `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+}
+
+// ReviewQueue stores proposed changes that need admin approval
+type ReviewQueue struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewReviewQueue creates a new review queue store
+func NewReviewQueue(client *Client, logger *zap.Logger) *ReviewQueue {
+	queue := &ReviewQueue{
+		client:     client,
+		collection: client.GetCollection(ReviewQueueCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go queue.ensureIndexes()
+
+	return queue
+}
+
+// ensureIndexes creates necessary indexes for queue listings and de-duplication
+func (q *ReviewQueue) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "source", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().SetName("status_source_created_idx"),
+		},
+		{
+			// Only one pending item per dedup key, so re-running a pipeline does
+			// not flood the queue with copies of the same proposal
+			Keys: bson.D{{Key: "dedup_key", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{
+					"status":    ReviewStatusPending,
+					"dedup_key": bson.M{"$exists": true},
+				}).
+				SetName("pending_dedup_idx"),
+		},
+	}
+
+	if _, err := q.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		q.logger.Error("Failed to create indexes for review queue", zap.Error(err))
+	} else {
+		q.logger.Info("Review queue indexes created successfully")
+	}
+}
+
+// Enqueue adds an item to the queue. When a pending item with the same dedup key
+// exists its payload and suggestions are refreshed instead; the returned bool
+// reports whether a new item was created.
+func (q *ReviewQueue) Enqueue(ctx context.Context, item *ReviewItem) (bool, error) {
+	now := time.Now()
+	item.Status = ReviewStatusPending
+
+	if item.DedupKey != "" {
+		filter := bson.M{"dedup_key": item.DedupKey, "status": ReviewStatusPending}
+		update := bson.M{
+			"$set": bson.M{
+				"summary":     item.Summary,
+				"payload":     item.Payload,
+				"suggestions": item.Suggestions,
+			},
+			"$setOnInsert": bson.M{
+				"_id":        uuid.New().String(),
+				"source":     item.Source,
+				"kind":       item.Kind,
+				"created_at": now,
+			},
+		}
+		result, err := q.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return false, fmt.Errorf("failed to enqueue review item: %w", err)
+		}
+		return result.UpsertedCount > 0, nil
+	}
+
+	item.ID = uuid.New().String()
+	item.CreatedAt = now
+	if _, err := q.collection.InsertOne(ctx, item); err != nil {
+		return false, fmt.Errorf("failed to enqueue review item: %w", err)
+	}
+	return true, nil
+}
+
+// List returns queue items filtered by source and status, newest first
+func (q *ReviewQueue) List(ctx context.Context, source, status string, limit int) ([]ReviewItem, error) {
+	filter := bson.M{}
+	if source != "" {
+		filter["source"] = source
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := q.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review queue: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	items := []ReviewItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode review queue: %w", err)
+	}
+	return items, nil
+}
+
+// Get returns a queue item by ID, or nil if it does not exist
+func (q *ReviewQueue) Get(ctx context.Context, id string) (*ReviewItem, error) {
+	var item ReviewItem
+	err := q.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve review item: %w", err)
+	}
+	return &item, nil
+}
+
+// Resolve marks a pending item as approved or rejected. It returns false when the
+// item does not exist or was already resolved.
+func (q *ReviewQueue) Resolve(ctx context.Context, id, status, resolvedBy string, resolution map[string]interface{}) (bool, error) {
+	now := time.Now()
+	filter := bson.M{"_id": id, "status": ReviewStatusPending}
+	update := bson.M{
+		"$set": bson.M{
+			"status":      status,
+			"resolution":  resolution,
+			"resolved_by": resolvedBy,
+			"resolved_at": now,
+		},
+	}
+
+	result, err := q.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve review item: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// CountPending returns the number of pending items per source
+func (q *ReviewQueue) CountPending(ctx context.Context) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": ReviewStatusPending}}},
+		{{Key: "$group", Value: bson.M{"_id": "$source", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := q.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending review items: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Source string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode pending counts: %w", err)
+	}
+
+	counts := make(map[string]int64, len(results))
+	for _, r := range results {
+		counts[r.Source] = r.Count
+	}
+	return counts, nil
+}
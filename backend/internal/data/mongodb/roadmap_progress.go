@@ -0,0 +1,184 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Roadmap progress collection name
+const RoadmapProgressCollection = "roadmap_progress"
+
+// RoadmapProgress records which steps of a program's learning roadmap a user has
+// completed
+type RoadmapProgress struct {
+	ID             string     `bson:"_id" json:"id"`
+	UserID         string     `bson:"user_id" json:"user_id"`
+	ProgramName    string     `bson:"program_name" json:"program_name"`
+	RoadmapVersion int        `bson:"roadmap_version,omitempty" json:"roadmap_version,omitempty"` // version followed, pinned when started
+	TotalSteps     int        `bson:"total_steps" json:"total_steps"`                             // steps of the roadmap when last updated
+	CompletedSteps []int      `bson:"completed_steps" json:"completed_steps"`
+	StartedAt      time.Time  `bson:"started_at" json:"started_at"`
+	UpdatedAt      time.Time  `bson:"updated_at" json:"updated_at"`
+	CompletedAt    *time.Time `bson:"completed_at,omitempty" json:"completed_at,omitempty"` // set while every step is completed
+}
+
+// Complete reports whether every step of the roadmap is completed
+func (p *RoadmapProgress) Complete() bool {
+	if p.TotalSteps == 0 {
+		return false
+	}
+	done := 0
+	for _, step := range p.CompletedSteps {
+		if step >= 1 && step <= p.TotalSteps {
+			done++
+		}
+	}
+	return done == p.TotalSteps
+}
+
+// RoadmapProgressStore persists users' roadmap progress
+type RoadmapProgressStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewRoadmapProgressStore creates a new roadmap progress store
+func NewRoadmapProgressStore(client *Client, logger *zap.Logger) *RoadmapProgressStore {
+	store := &RoadmapProgressStore{
+		client:     client,
+		collection: client.GetCollection(RoadmapProgressCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates necessary indexes for progress lookups
+func (s *RoadmapProgressStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "program_name", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("user_program_idx"),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "updated_at", Value: -1}},
+			Options: options.Index().SetName("user_updated_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for roadmap progress", zap.Error(err))
+	} else {
+		s.logger.Info("Roadmap progress indexes created successfully")
+	}
+}
+
+// SetStep marks a step completed or not, recording the roadmap's current number
+// of steps, and returns the updated progress. Progress is created on the first
+// completed step, pinned to the roadmap version given unless it is 0.
+// completed_at follows whether every step is now completed, keeping the time
+// the roadmap was first completed.
+func (s *RoadmapProgressStore) SetStep(ctx context.Context, userID, programName string, version, totalSteps, step int, completed bool) (*RoadmapProgress, error) {
+	now := time.Now()
+	filter := bson.M{"user_id": userID, "program_name": programName}
+
+	onInsert := bson.M{
+		"_id":        uuid.New().String(),
+		"started_at": now,
+	}
+	if version > 0 {
+		onInsert["roadmap_version"] = version
+	}
+	update := bson.M{
+		"$set":         bson.M{"total_steps": totalSteps, "updated_at": now},
+		"$setOnInsert": onInsert,
+	}
+	if completed {
+		update["$addToSet"] = bson.M{"completed_steps": step}
+	} else {
+		update["$pull"] = bson.M{"completed_steps": step}
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(completed).SetReturnDocument(options.After)
+	var progress RoadmapProgress
+	err := s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&progress)
+	if err == mongo.ErrNoDocuments {
+		// Uncompleting a step of a roadmap never started
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update roadmap progress: %w", err)
+	}
+
+	switch complete := progress.Complete(); {
+	case complete && progress.CompletedAt == nil:
+		progress.CompletedAt = &now
+		_, err = s.collection.UpdateOne(ctx, bson.M{"_id": progress.ID}, bson.M{"$set": bson.M{"completed_at": now}})
+	case !complete && progress.CompletedAt != nil:
+		progress.CompletedAt = nil
+		_, err = s.collection.UpdateOne(ctx, bson.M{"_id": progress.ID}, bson.M{"$unset": bson.M{"completed_at": ""}})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update roadmap completion: %w", err)
+	}
+	return &progress, nil
+}
+
+// Get returns a user's progress on a program's roadmap, or nil when not started
+func (s *RoadmapProgressStore) Get(ctx context.Context, userID, programName string) (*RoadmapProgress, error) {
+	var progress RoadmapProgress
+	err := s.collection.FindOne(ctx, bson.M{"user_id": userID, "program_name": programName}).Decode(&progress)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roadmap progress: %w", err)
+	}
+	return &progress, nil
+}
+
+// List returns a user's progress on every roadmap started, most recently updated
+// first
+func (s *RoadmapProgressStore) List(ctx context.Context, userID string) ([]RoadmapProgress, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err := s.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roadmap progress: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	progress := []RoadmapProgress{}
+	if err := cursor.All(ctx, &progress); err != nil {
+		return nil, fmt.Errorf("failed to decode roadmap progress: %w", err)
+	}
+	return progress, nil
+}
+
+// ListByUsers returns the progress of the given users on every roadmap they started
+func (s *RoadmapProgressStore) ListByUsers(ctx context.Context, userIDs []string) ([]RoadmapProgress, error) {
+	cursor, err := s.collection.Find(ctx, bson.M{"user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roadmap progress: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	progress := []RoadmapProgress{}
+	if err := cursor.All(ctx, &progress); err != nil {
+		return nil, fmt.Errorf("failed to decode roadmap progress: %w", err)
+	}
+	return progress, nil
+}
@@ -0,0 +1,189 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Roadmap versions collection name
+const RoadmapVersionCollection = "roadmap_versions"
+
+// appendAttempts bounds retries when another instance takes the next version first
+const appendAttempts = 3
+
+// RoadmapVersion is one generated version of a program's learning roadmap.
+// Versions are numbered from 1 per program and never change once stored.
+type RoadmapVersion struct {
+	ProgramName string                 `bson:"program_name" json:"program_name"`
+	Version     int                    `bson:"version" json:"version"`
+	Hash        string                 `bson:"hash" json:"-"` // of the content, to skip identical regenerations
+	Steps       int                    `bson:"steps" json:"steps"`
+	Data        map[string]interface{} `bson:"data" json:"-"`
+	CreatedAt   time.Time              `bson:"created_at" json:"created_at"`
+}
+
+// RoadmapVersionStore keeps every generated version of each program's roadmap,
+// so users following an older one are not switched when the cache is replaced.
+// A nil store (demo mode) keeps no versions.
+type RoadmapVersionStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewRoadmapVersionStore creates a new roadmap version store
+func NewRoadmapVersionStore(client *Client, logger *zap.Logger) *RoadmapVersionStore {
+	if client == nil {
+		return nil
+	}
+
+	store := &RoadmapVersionStore{
+		client:     client,
+		collection: client.GetCollection(RoadmapVersionCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates the index versions are numbered and looked up by
+func (s *RoadmapVersionStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "program_name", Value: 1}, {Key: "version", Value: -1}},
+			Options: options.Index().SetUnique(true).SetName("program_version_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for roadmap versions", zap.Error(err))
+	} else {
+		s.logger.Info("Roadmap version indexes created successfully")
+	}
+}
+
+// Append stores a roadmap as the program's next version and returns its number.
+// A roadmap with the same hash as the latest version is not stored again, and
+// the latest version's number is returned.
+func (s *RoadmapVersionStore) Append(ctx context.Context, programName, hash string, steps int, data map[string]interface{}) (int, error) {
+	if s == nil {
+		return 0, nil
+	}
+
+	for attempt := 0; attempt < appendAttempts; attempt++ {
+		latest, err := s.latest(ctx, programName)
+		if err != nil {
+			return 0, err
+		}
+		next := 1
+		if latest != nil {
+			if latest.Hash == hash {
+				return latest.Version, nil
+			}
+			next = latest.Version + 1
+		}
+
+		_, err = s.collection.InsertOne(ctx, RoadmapVersion{
+			ProgramName: programName,
+			Version:     next,
+			Hash:        hash,
+			Steps:       steps,
+			Data:        data,
+			CreatedAt:   time.Now(),
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			// Another instance stored a version meanwhile
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to store roadmap version: %w", err)
+		}
+
+		s.logger.Info("Roadmap version stored",
+			zap.String("program", programName),
+			zap.Int("version", next))
+		return next, nil
+	}
+	return 0, fmt.Errorf("failed to store roadmap version: version of %s kept changing", programName)
+}
+
+// latest returns the program's latest version without its data, or nil if none
+func (s *RoadmapVersionStore) latest(ctx context.Context, programName string) (*RoadmapVersion, error) {
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetProjection(bson.M{"data": 0})
+
+	var version RoadmapVersion
+	err := s.collection.FindOne(ctx, bson.M{"program_name": programName}, opts).Decode(&version)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest roadmap version: %w", err)
+	}
+	return &version, nil
+}
+
+// Latest returns the number of the program's latest version, or 0 if none
+func (s *RoadmapVersionStore) Latest(ctx context.Context, programName string) (int, error) {
+	if s == nil {
+		return 0, nil
+	}
+
+	latest, err := s.latest(ctx, programName)
+	if err != nil || latest == nil {
+		return 0, err
+	}
+	return latest.Version, nil
+}
+
+// Get returns a version of the program's roadmap, or nil if it does not exist
+func (s *RoadmapVersionStore) Get(ctx context.Context, programName string, version int) (*RoadmapVersion, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	var stored RoadmapVersion
+	err := s.collection.FindOne(ctx, bson.M{"program_name": programName, "version": version}).Decode(&stored)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roadmap version: %w", err)
+	}
+	return &stored, nil
+}
+
+// List returns the program's versions without their data, newest first
+func (s *RoadmapVersionStore) List(ctx context.Context, programName string) ([]RoadmapVersion, error) {
+	if s == nil {
+		return []RoadmapVersion{}, nil
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetProjection(bson.M{"data": 0})
+	cursor, err := s.collection.Find(ctx, bson.M{"program_name": programName}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roadmap versions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	versions := []RoadmapVersion{}
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, fmt.Errorf("failed to decode roadmap versions: %w", err)
+	}
+	return versions, nil
+}
@@ -0,0 +1,141 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Sheet mappings collection name
+const SheetMappingCollection = "sheet_mappings"
+
+// SheetMapping describes how a counselor-maintained Google Sheet maps onto
+// program import fields
+type SheetMapping struct {
+	Name          string            `bson:"name" json:"name"`
+	SpreadsheetID string            `bson:"spreadsheet_id" json:"spreadsheet_id"`
+	Sheet         string            `bson:"sheet,omitempty" json:"sheet,omitempty"` // tab name; first tab when empty
+	GID           string            `bson:"gid,omitempty" json:"gid,omitempty"`     // tab ID, used for CSV export
+	HeaderRow     int               `bson:"header_row" json:"header_row"`           // 1-based
+	Columns       map[string]string `bson:"columns" json:"columns"`                 // import field -> sheet header
+	Defaults      map[string]string `bson:"defaults,omitempty" json:"defaults,omitempty"`
+	ListSeparator string            `bson:"list_separator,omitempty" json:"list_separator,omitempty"`
+	LastSyncedAt  *time.Time        `bson:"last_synced_at,omitempty" json:"last_synced_at,omitempty"`
+	CreatedAt     time.Time         `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time         `bson:"updated_at" json:"updated_at"`
+}
+
+// SheetMappingStore persists sheet mapping configurations
+type SheetMappingStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewSheetMappingStore creates a new sheet mapping store
+func NewSheetMappingStore(client *Client, logger *zap.Logger) *SheetMappingStore {
+	store := &SheetMappingStore{
+		client:     client,
+		collection: client.GetCollection(SheetMappingCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates necessary indexes for mapping lookups
+func (s *SheetMappingStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("name_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for sheet mappings", zap.Error(err))
+	} else {
+		s.logger.Info("Sheet mapping indexes created successfully")
+	}
+}
+
+// Save creates or replaces a mapping by name
+func (s *SheetMappingStore) Save(ctx context.Context, mapping *SheetMapping) error {
+	now := time.Now()
+	mapping.UpdatedAt = now
+
+	update := bson.M{
+		"$set": bson.M{
+			"spreadsheet_id": mapping.SpreadsheetID,
+			"sheet":          mapping.Sheet,
+			"gid":            mapping.GID,
+			"header_row":     mapping.HeaderRow,
+			"columns":        mapping.Columns,
+			"defaults":       mapping.Defaults,
+			"list_separator": mapping.ListSeparator,
+			"updated_at":     now,
+		},
+		"$setOnInsert": bson.M{
+			"name":       mapping.Name,
+			"created_at": now,
+		},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := s.collection.UpdateOne(ctx, bson.M{"name": mapping.Name}, update, opts); err != nil {
+		s.logger.Error("Failed to save sheet mapping",
+			zap.String("name", mapping.Name),
+			zap.Error(err))
+		return fmt.Errorf("failed to save sheet mapping: %w", err)
+	}
+	return nil
+}
+
+// Get returns a mapping by name, or nil when it does not exist
+func (s *SheetMappingStore) Get(ctx context.Context, name string) (*SheetMapping, error) {
+	var mapping SheetMapping
+	err := s.collection.FindOne(ctx, bson.M{"name": name}).Decode(&mapping)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sheet mapping: %w", err)
+	}
+	return &mapping, nil
+}
+
+// List returns all mappings ordered by name
+func (s *SheetMappingStore) List(ctx context.Context) ([]SheetMapping, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := s.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sheet mappings: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	mappings := []SheetMapping{}
+	if err := cursor.All(ctx, &mappings); err != nil {
+		return nil, fmt.Errorf("failed to decode sheet mappings: %w", err)
+	}
+	return mappings, nil
+}
+
+// MarkSynced records when a mapping's sheet was last applied
+func (s *SheetMappingStore) MarkSynced(ctx context.Context, name string, at time.Time) error {
+	_, err := s.collection.UpdateOne(ctx, bson.M{"name": name}, bson.M{"$set": bson.M{"last_synced_at": at}})
+	if err != nil {
+		return fmt.Errorf("failed to update sheet mapping: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// Collection names for student plans and their share links
+	StudentPlanCollection = "student_plans"
+	ShareLinkCollection   = "share_links"
+
+	// Default lifetime of a share link (30 days - long enough for a family discussion)
+	DefaultShareLinkTTL = 30 * 24 * time.Hour
+
+	// ShareScopeGuardian grants read-only access to the guardian summary of a plan
+	ShareScopeGuardian = "guardian"
+)
+
+// StudentPlan represents the pathway a student has chosen to follow
+type StudentPlan struct {
+	ID          string    `bson:"_id" json:"id"`
+	UserID      string    `bson:"user_id" json:"user_id"`
+	ProgramName string    `bson:"program_name" json:"program_name"`
+	CareerTitle string    `bson:"career_title,omitempty" json:"career_title,omitempty"`
+	Notes       string    `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// ShareLink grants token-based, read-only access to a student plan
+type ShareLink struct {
+	Token     string     `bson:"_id" json:"token"`
+	PlanID    string     `bson:"plan_id" json:"plan_id"`
+	UserID    string     `bson:"user_id" json:"user_id"`
+	Scope     string     `bson:"scope" json:"scope"`
+	CreatedAt time.Time  `bson:"created_at" json:"created_at"`
+	ExpiresAt time.Time  `bson:"expires_at" json:"expires_at"`
+	RevokedAt *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+// StudentPlanStore handles persistence of student plans and share links
+type StudentPlanStore struct {
+	client   *Client
+	plans    *mongo.Collection
+	links    *mongo.Collection
+	logger   *zap.Logger
+	shareTTL time.Duration
+}
+
+// NewStudentPlanStore creates a new student plan store
+func NewStudentPlanStore(client *Client, logger *zap.Logger) *StudentPlanStore {
+	store := &StudentPlanStore{
+		client:   client,
+		plans:    client.GetCollection(StudentPlanCollection),
+		links:    client.GetCollection(ShareLinkCollection),
+		logger:   logger,
+		shareTTL: DefaultShareLinkTTL,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates necessary indexes for plan and share link lookups
+func (s *StudentPlanStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := s.plans.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("user_plans_idx"),
+	}); err != nil {
+		s.logger.Error("Failed to create indexes for student plans", zap.Error(err))
+	}
+
+	if _, err := s.links.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "plan_id", Value: 1}},
+			Options: options.Index().SetName("plan_links_idx"),
+		},
+		{
+			Keys: bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().
+				SetExpireAfterSeconds(0). // TTL index - expired links are removed automatically
+				SetName("ttl_index"),
+		},
+	}); err != nil {
+		s.logger.Error("Failed to create indexes for share links", zap.Error(err))
+	}
+}
+
+// CreatePlan stores a new plan for a user
+func (s *StudentPlanStore) CreatePlan(ctx context.Context, plan *StudentPlan) error {
+	now := time.Now()
+	plan.ID = uuid.New().String()
+	plan.CreatedAt = now
+	plan.UpdatedAt = now
+
+	if _, err := s.plans.InsertOne(ctx, plan); err != nil {
+		return fmt.Errorf("failed to create student plan: %w", err)
+	}
+
+	s.logger.Info("Student plan created",
+		zap.String("plan_id", plan.ID),
+		zap.String("program", plan.ProgramName))
+	return nil
+}
+
+// GetPlan retrieves a plan by ID. Returns nil when the plan does not exist.
+func (s *StudentPlanStore) GetPlan(ctx context.Context, planID string) (*StudentPlan, error) {
+	var plan StudentPlan
+	err := s.plans.FindOne(ctx, bson.M{"_id": planID}).Decode(&plan)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student plan: %w", err)
+	}
+	return &plan, nil
+}
+
+// ListPlans retrieves all plans belonging to a user, newest first
+func (s *StudentPlanStore) ListPlans(ctx context.Context, userID string) ([]StudentPlan, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := s.plans.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list student plans: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	plans := []StudentPlan{}
+	if err := cursor.All(ctx, &plans); err != nil {
+		return nil, fmt.Errorf("failed to decode student plans: %w", err)
+	}
+	return plans, nil
+}
+
+// CareerCount is how many users have a plan targeting a career
+type CareerCount struct {
+	CareerTitle string `bson:"_id" json:"career_title"`
+	Users       int    `bson:"users" json:"students"`
+}
+
+// CountCareersByUsers counts, for each career targeted by the given users' plans,
+// how many of those users target it, most common first
+func (s *StudentPlanStore) CountCareersByUsers(ctx context.Context, userIDs []string) ([]CareerCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"user_id":      bson.M{"$in": userIDs},
+			"career_title": bson.M{"$nin": bson.A{nil, ""}},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"career": "$career_title", "user": "$user_id"}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$_id.career", "users": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "users", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := s.plans.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count targeted careers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	counts := []CareerCount{}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, fmt.Errorf("failed to decode targeted careers: %w", err)
+	}
+	return counts, nil
+}
+
+// CreateShareLink issues a new random share token for a plan
+func (s *StudentPlanStore) CreateShareLink(ctx context.Context, plan *StudentPlan, scope string) (*ShareLink, error) {
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	link := &ShareLink{
+		Token:     token,
+		PlanID:    plan.ID,
+		UserID:    plan.UserID,
+		Scope:     scope,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.shareTTL),
+	}
+
+	if _, err := s.links.InsertOne(ctx, link); err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	s.logger.Info("Share link created",
+		zap.String("plan_id", plan.ID),
+		zap.String("scope", scope),
+		zap.Time("expires_at", link.ExpiresAt))
+	return link, nil
+}
+
+// GetShareLink retrieves an active (non-expired, non-revoked) share link.
+// Returns nil when the token is unknown or no longer valid.
+func (s *StudentPlanStore) GetShareLink(ctx context.Context, token string) (*ShareLink, error) {
+	filter := bson.M{
+		"_id":        token,
+		"expires_at": bson.M{"$gt": time.Now()},
+		"revoked_at": bson.M{"$exists": false},
+	}
+
+	var link ShareLink
+	err := s.links.FindOne(ctx, filter).Decode(&link)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+	return &link, nil
+}
+
+// RevokeShareLink revokes a share link owned by the given user
+func (s *StudentPlanStore) RevokeShareLink(ctx context.Context, userID, token string) (bool, error) {
+	result, err := s.links.UpdateOne(ctx,
+		bson.M{"_id": token, "user_id": userID},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// newShareToken generates an unguessable URL-safe token
+func newShareToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
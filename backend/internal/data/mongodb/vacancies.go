@@ -0,0 +1,240 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Vacancies collection name
+const VacancyCollection = "vacancies"
+
+// Vacancy is a job posting seen on a job board for a career. A posting is
+// considered open while it keeps appearing in searches.
+type Vacancy struct {
+	ID          string    `bson:"_id" json:"-"`
+	CareerTitle string    `bson:"career_title" json:"career_title"`
+	Title       string    `bson:"title" json:"title"`
+	URL         string    `bson:"url" json:"url"`
+	Board       string    `bson:"board" json:"board"`
+	District    string    `bson:"district,omitempty" json:"district,omitempty"`
+	FirstSeenAt time.Time `bson:"first_seen_at" json:"first_seen_at"`
+	LastSeenAt  time.Time `bson:"last_seen_at" json:"last_seen_at"`
+}
+
+// VacancyStore keeps job postings per career. Postings no longer seen are removed
+// after the retention period.
+type VacancyStore struct {
+	client     *Client
+	collection *mongo.Collection
+	retention  time.Duration
+	logger     *zap.Logger
+}
+
+// NewVacancyStore creates a new vacancy store
+func NewVacancyStore(client *Client, retention time.Duration, logger *zap.Logger) *VacancyStore {
+	store := &VacancyStore{
+		client:     client,
+		collection: client.GetCollection(VacancyCollection),
+		retention:  retention,
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates indexes for per-career listings and expiry of stale postings
+func (s *VacancyStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "career_title", Value: 1},
+				{Key: "last_seen_at", Value: -1},
+			},
+			Options: options.Index().SetName("career_last_seen_idx"),
+		},
+		{
+			Keys: bson.D{{Key: "last_seen_at", Value: 1}},
+			Options: options.Index().
+				SetExpireAfterSeconds(int32(s.retention.Seconds())).
+				SetName("last_seen_ttl"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for vacancies", zap.Error(err))
+	} else {
+		s.logger.Info("Vacancy indexes created successfully")
+	}
+}
+
+// vacancyID identifies a posting by career and URL, so a posting matching several
+// careers is counted for each of them
+func vacancyID(careerTitle, url string) string {
+	sum := sha256.Sum256([]byte(careerTitle + "\x00" + url))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Record marks postings as seen now, adding the ones not seen before
+func (s *VacancyStore) Record(ctx context.Context, vacancies []Vacancy) error {
+	if len(vacancies) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(vacancies))
+	for _, vacancy := range vacancies {
+		update := bson.M{
+			"$set": bson.M{
+				"title":        vacancy.Title,
+				"board":        vacancy.Board,
+				"district":     vacancy.District,
+				"last_seen_at": now,
+			},
+			"$setOnInsert": bson.M{
+				"career_title":  vacancy.CareerTitle,
+				"url":           vacancy.URL,
+				"first_seen_at": now,
+			},
+		}
+		filter := bson.M{"_id": vacancyID(vacancy.CareerTitle, vacancy.URL)}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	if _, err := s.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to record vacancies: %w", err)
+	}
+	return nil
+}
+
+// ListOpen returns a career's postings seen since the given time, newest first
+func (s *VacancyStore) ListOpen(ctx context.Context, careerTitle string, since time.Time, limit int) ([]Vacancy, error) {
+	filter := bson.M{
+		"career_title": careerTitle,
+		"last_seen_at": bson.M{"$gte": since},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "first_seen_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vacancies: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	vacancies := []Vacancy{}
+	if err := cursor.All(ctx, &vacancies); err != nil {
+		return nil, fmt.Errorf("failed to decode vacancies: %w", err)
+	}
+	return vacancies, nil
+}
+
+// CountOpen returns the number of a career's postings seen since the given time
+// and how many of them were first seen after newSince
+func (s *VacancyStore) CountOpen(ctx context.Context, careerTitle string, since, newSince time.Time) (open int64, recent int64, err error) {
+	filter := bson.M{
+		"career_title": careerTitle,
+		"last_seen_at": bson.M{"$gte": since},
+	}
+	if open, err = s.collection.CountDocuments(ctx, filter); err != nil {
+		return 0, 0, fmt.Errorf("failed to count vacancies: %w", err)
+	}
+
+	filter["first_seen_at"] = bson.M{"$gte": newSince}
+	if recent, err = s.collection.CountDocuments(ctx, filter); err != nil {
+		return 0, 0, fmt.Errorf("failed to count vacancies: %w", err)
+	}
+	return open, recent, nil
+}
+
+// CountOpenByCareer returns the number of postings seen since the given time for
+// each career with any
+func (s *VacancyStore) CountOpenByCareer(ctx context.Context, since time.Time) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"last_seen_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$career_title", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate vacancies by career: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Career string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode vacancies by career: %w", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Career] = row.Count
+	}
+	return counts, nil
+}
+
+// CountOpenByDistrict returns the number of postings seen since the given time in
+// each district, counting a posting listed under several careers once. Postings
+// not naming a district are left out.
+func (s *VacancyStore) CountOpenByDistrict(ctx context.Context, since time.Time) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"last_seen_at": bson.M{"$gte": since},
+			"district":     bson.M{"$nin": bson.A{nil, ""}},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"district": "$district", "url": "$url"}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$_id.district", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate vacancies by district: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		District string `bson:"_id"`
+		Count    int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode vacancies by district: %w", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.District] = row.Count
+	}
+	return counts, nil
+}
+
+// LastSeen returns when postings were last recorded for a career, or nil if never
+func (s *VacancyStore) LastSeen(ctx context.Context, careerTitle string) (*time.Time, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+
+	var vacancy Vacancy
+	err := s.collection.FindOne(ctx, bson.M{"career_title": careerTitle}, opts).Decode(&vacancy)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vacancies: %w", err)
+	}
+	return &vacancy.LastSeenAt, nil
+}
@@ -0,0 +1,204 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Z-score cutoffs collection name
+const ZScoreCutoffCollection = "zscore_cutoffs"
+
+// ZScoreCutoff is the minimum z-score a university program admitted in a district
+// in a given academic year. Institute is the offering institute as the source
+// names it, since institutes share program names.
+type ZScoreCutoff struct {
+	ProgramName string    `bson:"program_name" json:"program_name"`
+	Institute   string    `bson:"institute" json:"institute,omitempty"`
+	CourseCode  string    `bson:"course_code,omitempty" json:"course_code,omitempty"`
+	Year        int       `bson:"year" json:"year"`
+	District    string    `bson:"district" json:"district"`
+	ZScore      float64   `bson:"z_score" json:"z_score"`
+	Source      string    `bson:"source" json:"source"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// ZScoreCutoffStore keeps historical z-score cutoffs per program and district. A
+// nil store has no cutoffs.
+type ZScoreCutoffStore struct {
+	client     *Client
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewZScoreCutoffStore creates a new z-score cutoff store
+func NewZScoreCutoffStore(client *Client, logger *zap.Logger) *ZScoreCutoffStore {
+	if client == nil {
+		return nil
+	}
+
+	store := &ZScoreCutoffStore{
+		client:     client,
+		collection: client.GetCollection(ZScoreCutoffCollection),
+		logger:     logger,
+	}
+
+	// Initialize indexes in background
+	go store.ensureIndexes()
+
+	return store
+}
+
+// ensureIndexes creates necessary indexes for cutoff upserts and lookups.
+// Cutoffs stored before they were keyed by institute are given an empty one, and
+// the unique index that did not include it is dropped.
+func (s *ZScoreCutoffStore) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := s.collection.UpdateMany(ctx,
+		bson.M{"institute": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"institute": ""}},
+	); err != nil {
+		s.logger.Error("Failed to backfill z-score cutoff institutes", zap.Error(err))
+		return
+	}
+	if _, err := s.collection.Indexes().DropOne(ctx, "program_year_district_idx"); err != nil && !isIndexNotFound(err) {
+		s.logger.Error("Failed to drop z-score cutoff index without institute", zap.Error(err))
+		return
+	}
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "program_name", Value: 1},
+				{Key: "institute", Value: 1},
+				{Key: "year", Value: -1},
+				{Key: "district", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("program_institute_year_district_idx"),
+		},
+	}
+
+	if _, err := s.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		s.logger.Error("Failed to create indexes for z-score cutoffs", zap.Error(err))
+	} else {
+		s.logger.Info("Z-score cutoff indexes created successfully")
+	}
+}
+
+// Upsert stores cutoffs, replacing any existing value for the same program,
+// institute, year and district
+func (s *ZScoreCutoffStore) Upsert(ctx context.Context, cutoffs []ZScoreCutoff) error {
+	if s == nil {
+		return nil
+	}
+
+	if len(cutoffs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(cutoffs))
+	for _, cutoff := range cutoffs {
+		cutoff.UpdatedAt = now
+		filter := bson.M{
+			"program_name": cutoff.ProgramName,
+			"institute":    cutoff.Institute,
+			"year":         cutoff.Year,
+			"district":     cutoff.District,
+		}
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(cutoff).SetUpsert(true))
+	}
+
+	if _, err := s.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to store z-score cutoffs: %w", err)
+	}
+	return nil
+}
+
+// ListByProgram returns all cutoffs of a program, newest year first
+func (s *ZScoreCutoffStore) ListByProgram(ctx context.Context, programName string) ([]ZScoreCutoff, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "year", Value: -1}, {Key: "district", Value: 1}})
+
+	cursor, err := s.collection.Find(ctx, bson.M{"program_name": programName}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query z-score cutoffs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	cutoffs := []ZScoreCutoff{}
+	if err := cursor.All(ctx, &cutoffs); err != nil {
+		return nil, fmt.Errorf("failed to decode z-score cutoffs: %w", err)
+	}
+	return cutoffs, nil
+}
+
+// districtCollation compares district names case-insensitively, as handbook
+// extracts and users do not agree on capitalisation
+var districtCollation = &options.Collation{Locale: "en", Strength: 2}
+
+// ListByProgramDistrict returns the cutoffs of a program in one district, oldest
+// year first
+func (s *ZScoreCutoffStore) ListByProgramDistrict(ctx context.Context, programName, district string) ([]ZScoreCutoff, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	filter := bson.M{"program_name": programName, "district": district}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "year", Value: 1}}).
+		SetCollation(districtCollation)
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query z-score cutoffs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	cutoffs := []ZScoreCutoff{}
+	if err := cursor.All(ctx, &cutoffs); err != nil {
+		return nil, fmt.Errorf("failed to decode z-score cutoffs: %w", err)
+	}
+	return cutoffs, nil
+}
+
+// Latest returns the most recent cutoff of a program in a district, or nil if
+// none is recorded
+func (s *ZScoreCutoffStore) Latest(ctx context.Context, programName, district string) (*ZScoreCutoff, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	filter := bson.M{"program_name": programName, "district": district}
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "year", Value: -1}}).
+		SetCollation(districtCollation)
+
+	var cutoff ZScoreCutoff
+	err := s.collection.FindOne(ctx, filter, opts).Decode(&cutoff)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query z-score cutoffs: %w", err)
+	}
+	return &cutoff, nil
+}
+
+// isIndexNotFound reports whether dropping an index failed because it, or its
+// collection, does not exist
+func isIndexNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && (cmdErr.Name == "IndexNotFound" || cmdErr.Name == "NamespaceNotFound")
+}
@@ -0,0 +1,223 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"go.uber.org/zap"
+)
+
+// accessRelationship links a qualification to every program it opens, directly
+// or through a chain of prerequisite programs, with the number of prerequisite
+// steps in between as its distance. The edges are derived from REQUIRES and
+// IS_PREREQUISITE_FOR, so they are rebuilt rather than edited or backed up.
+const accessRelationship = "GRANTS_ACCESS"
+
+const (
+	// MaxPrerequisiteDepth bounds how many prerequisite steps a program may be
+	// from a qualification and still count as accessible from it
+	MaxPrerequisiteDepth = 6
+
+	// maxAccessiblePrograms caps the programs returned for one qualification
+	maxAccessiblePrograms = 500
+)
+
+// accessibilityState tracks whether the access edges match the graph. Each
+// staleness mark starts a new generation; the edges are usable only when the
+// last rebuild started in the current one.
+type accessibilityState struct {
+	mu      sync.Mutex // serializes rebuilds
+	stale   atomic.Uint64
+	builtAt atomic.Uint64 // generation + 1 of the last rebuild, 0 if never built
+}
+
+func (s *accessibilityState) ready() bool {
+	return s.builtAt.Load() == s.stale.Load()+1
+}
+
+// MarkAccessibilityStale stops pathway queries from using the access edges until
+// they are rebuilt. Call it whenever programs, their requirements or their
+// prerequisites change.
+func (c *Client) MarkAccessibilityStale() {
+	c.accessibility.stale.Add(1)
+}
+
+// RebuildAccessibility replaces the access edges with ones computed from the
+// current graph and returns the number of edges created. Edges are merged, so
+// instances rebuilding at the same time do not leave duplicates behind.
+func (c *Client) RebuildAccessibility(ctx context.Context) (int, error) {
+	c.accessibility.mu.Lock()
+	defer c.accessibility.mu.Unlock()
+
+	generation := c.accessibility.stale.Load()
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	edges, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		if _, err := runConsume(ctx, tx, "MATCH (:Qualification)-[r:"+accessRelationship+"]->(:Program) DELETE r", nil); err != nil {
+			return nil, err
+		}
+		summary, err := runConsume(ctx, tx, fmt.Sprintf(`
+			MATCH (q:Qualification)<-[:REQUIRES]-(entry:Program)
+			WHERE q.deleted_at IS NULL AND entry.deleted_at IS NULL
+			MATCH path = (entry)-[:IS_PREREQUISITE_FOR*0..%d]->(p:Program)
+			WHERE p.deleted_at IS NULL
+			WITH q, p, min(length(path)) as distance
+			MERGE (q)-[access:%s]->(p)
+			SET access.distance = distance
+		`, MaxPrerequisiteDepth, accessRelationship), nil)
+		if err != nil {
+			return nil, err
+		}
+		return summary.Counters().RelationshipsCreated(), nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild accessibility: %w", err)
+	}
+
+	c.accessibility.builtAt.Store(generation + 1)
+	c.logger.Info("Program accessibility rebuilt", zap.Int("edges", edges.(int)))
+	return edges.(int), nil
+}
+
+// accessibleProgramRow is a program accessible from a qualification
+type accessibleProgramRow struct {
+	Program       string   `cypher:"program"`
+	Institute     string   `cypher:"institute"`
+	Faculty       string   `cypher:"faculty"`
+	Department    string   `cypher:"department"`
+	Requirements  []string `cypher:"requirements"`
+	Prerequisites []string `cypher:"prerequisites"`
+	Careers       []string `cypher:"careers"`
+	Hops          int64    `cypher:"hops"`
+	NVQLevel      int64    `cypher:"nvq_level"`
+}
+
+// GetPathwayByQualification retrieves programs accessible from a specific
+// qualification level in departments whose name contains department (e.g.
+// "Engineering" matches "Civil Engineering"), nearest to the qualification
+// first and then by name. A program is accessible when it requires the
+// qualification or is at most maxHops prerequisite steps after one that does;
+// maxHops is bounded by MaxPrerequisiteDepth. The lookup uses the precomputed
+// access edges when they are current and otherwise walks the prerequisite
+// chains from the qualification's entry programs.
+func (c *Client) GetPathwayByQualification(ctx context.Context, department string, qualification string, maxHops int) ([]ProgramDetails, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	maxHops = max(0, min(maxHops, MaxPrerequisiteDepth))
+	accessible := fmt.Sprintf(`
+		MATCH (q:Qualification {name: $qualification})<-[:REQUIRES]-(entry:Program)
+		WHERE q.deleted_at IS NULL AND entry.deleted_at IS NULL
+		MATCH path = (entry)-[:IS_PREREQUISITE_FOR*0..%d]->(p:Program)
+		WHERE p.deleted_at IS NULL
+		WITH p, min(length(path)) as pathDistance
+	`, maxHops)
+	if c.accessibility.ready() {
+		accessible = `
+		MATCH (q:Qualification {name: $qualification})-[access:` + accessRelationship + `]->(p:Program)
+		WHERE access.distance <= $maxHops AND q.deleted_at IS NULL AND p.deleted_at IS NULL
+		WITH p, access.distance as pathDistance
+	`
+	}
+
+	query := accessible + `
+		MATCH (d:Department)-[:OFFERS]->(p)
+		WHERE d.name CONTAINS $department
+		WITH p, d, pathDistance
+		ORDER BY pathDistance, p.name
+		LIMIT $limit
+
+		OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY]->(f:Faculty)-[:HAS_DEPARTMENT]->(d)
+
+		RETURN p.name as program,
+		       i.name as institute,
+		       f.name as faculty,
+		       d.name as department,
+		       [(p)-[:REQUIRES]->(q:Qualification) | q.name] as requirements,
+		       [(prereq:Program)-[:IS_PREREQUISITE_FOR]->(p) | prereq.name] as prerequisites,
+		       [(p)-[:LEADS_TO]->(c:Career) | c.title] as careers,
+		       pathDistance as hops,
+		       coalesce(p.nvq_level, 0) as nvq_level
+		ORDER BY pathDistance, p.name
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{
+		"department":    department,
+		"qualification": qualification,
+		"maxHops":       maxHops,
+		"limit":         maxAccessiblePrograms,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pathway by qualification: %w", err)
+	}
+
+	var programs []ProgramDetails
+	err = readRecords(ctx, result, func(row accessibleProgramRow) error {
+		hops := int(row.Hops)
+		level := int(row.NVQLevel)
+		if level == 0 {
+			level = ProgramLevel(row.Program)
+		}
+		programs = append(programs, ProgramDetails{
+			Name:          row.Program,
+			Institute:     row.Institute,
+			Faculty:       row.Faculty,
+			Department:    row.Department,
+			Requirements:  qualificationsNamed(row.Requirements),
+			Prerequisites: programsNamed(row.Prerequisites),
+			CareerPaths:   careersTitled(row.Careers),
+			Hops:          &hops,
+			Level:         level,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating pathway by qualification: %w", err)
+	}
+
+	return programs, nil
+}
+
+// nvqLevelPattern finds the NVQ level a program name states
+var nvqLevelPattern = regexp.MustCompile(`(?i)\bNVQ\s*(?:Level\s*)?([1-7])\b`)
+
+// programLevels place programs on the NVQ scale by the award their name
+// mentions, checked in order; degrees sit at level 7, its top
+var programLevels = []struct {
+	Marker string
+	Level  int
+}{
+	{"master", 8},
+	{"msc", 8},
+	{"bachelor", 7},
+	{"bsc", 7},
+	{"degree", 7},
+	{"higher national diploma", 6},
+	{"higher diploma", 6},
+	{"diploma", 5},
+	{"advanced certificate", 4},
+	{"certificate", 3},
+}
+
+// ProgramLevel estimates the level of a program from its name: the NVQ level it
+// states, or else the NVQ level of the award it names, with postgraduate
+// programs at 8. It returns 0 when the name says neither.
+func ProgramLevel(name string) int {
+	if match := nvqLevelPattern.FindStringSubmatch(name); match != nil {
+		return int(match[1][0] - '0')
+	}
+	lower := strings.ToLower(name)
+	for _, level := range programLevels {
+		if strings.Contains(lower, level.Marker) {
+			return level.Level
+		}
+	}
+	return 0
+}
@@ -0,0 +1,220 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// legacyPathwayByQualificationQuery is the pathway-by-qualification query the
+// access edges replaced, kept to check the rewrite returns the same programs
+const legacyPathwayByQualificationQuery = `
+	MATCH (startQual:Qualification {name: $qualification})
+	MATCH (d:Department)-[:OFFERS]->(p:Program)
+	WHERE d.name CONTAINS $department
+	  AND (
+	    EXISTS {
+	      MATCH (p)-[:REQUIRES]->(startQual)
+	    }
+	    OR EXISTS {
+	      MATCH (startProg:Program)-[:REQUIRES]->(startQual)
+	      MATCH path = (startProg)-[:IS_PREREQUISITE_FOR*1..]->(p)
+	    }
+	    OR EXISTS {
+	      MATCH (p)-[:REQUIRES]->(altQual:Qualification)
+	      MATCH (bridgeProg:Program)-[:REQUIRES]->(startQual)
+	      MATCH (bridgeProg)-[:IS_PREREQUISITE_FOR*0..]->(p)
+	    }
+	  )
+	OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY]->(f:Faculty)-[:HAS_DEPARTMENT]->(d)
+	OPTIONAL MATCH (p)-[:REQUIRES]->(q:Qualification)
+	OPTIONAL MATCH (prereq:Program)-[:IS_PREREQUISITE_FOR]->(p)
+	OPTIONAL MATCH (p)-[:LEADS_TO]->(c:Career)
+	OPTIONAL MATCH shortestPath = shortestPath((startProg:Program)-[:IS_PREREQUISITE_FOR*0..]->(p))
+	WHERE (startProg)-[:REQUIRES]->(startQual) OR (p)-[:REQUIRES]->(startQual)
+	WITH DISTINCT p, i, f, d,
+	     COLLECT(DISTINCT q.name) as requirements,
+	     COLLECT(DISTINCT prereq.name) as prerequisites,
+	     COLLECT(DISTINCT c.title) as careers,
+	     COALESCE(LENGTH(shortestPath), 0) as pathDistance
+	RETURN p.name as program
+`
+
+// legacyPathwayByQualification returns the names of the programs the legacy
+// query finds, sorted
+func legacyPathwayByQualification(t *testing.T, c *Client, department, qualification string) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+	result, err := session.Run(ctx, legacyPathwayByQualificationQuery, map[string]any{
+		"department":    department,
+		"qualification": qualification,
+	})
+	if err != nil {
+		t.Fatalf("legacy query failed: %v", err)
+	}
+
+	var names []string
+	for result.Next(ctx) {
+		name, _ := result.Record().Get("program")
+		names = append(names, stringOrEmpty(name))
+	}
+	if err := result.Err(); err != nil {
+		t.Fatalf("legacy query failed: %v", err)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// chain returns programs Step 1 to Step n of department, each a prerequisite
+// for the next, the first requiring qualification
+func chain(department, qualification string, n int) []fixtureProgram {
+	programs := make([]fixtureProgram, 0, n)
+	for i := 1; i <= n; i++ {
+		program := fixtureProgram{Name: fmt.Sprintf("Step %d", i), Department: department}
+		if i == 1 {
+			program.Requires = []string{qualification}
+		} else {
+			program.Prerequisites = []string{fmt.Sprintf("Step %d", i-1)}
+		}
+		programs = append(programs, program)
+	}
+	return programs
+}
+
+func TestGetPathwayByQualificationMatchesLegacyQuery(t *testing.T) {
+	client := testClient(t)
+
+	tests := []struct {
+		name          string
+		programs      []fixtureProgram
+		department    string
+		qualification string
+		// want are the programs the rewrite returns, in order
+		want []string
+		// legacyOnly are programs the legacy query also returned, which the
+		// rewrite leaves out by design
+		legacyOnly []string
+	}{
+		{
+			name: "direct requirement",
+			programs: []fixtureProgram{
+				{Name: "Diploma in ICT", Department: "ICT", Requires: []string{"A/L"}},
+				{Name: "Certificate in ICT", Department: "ICT", Requires: []string{"O/L"}},
+			},
+			department:    "ICT",
+			qualification: "A/L",
+			want:          []string{"Diploma in ICT"},
+		},
+		{
+			name: "prerequisite chain",
+			programs: []fixtureProgram{
+				{Name: "NVQ Level 3 Welding", Department: "Mechanical", Requires: []string{"O/L"}},
+				{Name: "NVQ Level 4 Welding", Department: "Mechanical", Prerequisites: []string{"NVQ Level 3 Welding"}},
+				{Name: "NVQ Level 5 Welding", Department: "Mechanical", Prerequisites: []string{"NVQ Level 4 Welding"}},
+			},
+			department:    "Mechanical",
+			qualification: "O/L",
+			want:          []string{"NVQ Level 3 Welding", "NVQ Level 4 Welding", "NVQ Level 5 Welding"},
+		},
+		{
+			name: "department name containment",
+			programs: []fixtureProgram{
+				{Name: "BSc Civil Engineering", Department: "Civil Engineering", Requires: []string{"A/L"}},
+				{Name: "BSc Electrical Engineering", Department: "Electrical Engineering", Requires: []string{"A/L"}},
+				{Name: "BSc Accounting", Department: "Accounting", Requires: []string{"A/L"}},
+			},
+			department:    "Engineering",
+			qualification: "A/L",
+			want:          []string{"BSc Civil Engineering", "BSc Electrical Engineering"},
+		},
+		{
+			name: "prerequisite from another department",
+			programs: []fixtureProgram{
+				{Name: "Foundation in Science", Department: "Foundation", Requires: []string{"O/L"}},
+				{Name: "BSc Physics", Department: "Physics", Requires: []string{"A/L"}, Prerequisites: []string{"Foundation in Science"}},
+			},
+			department:    "Physics",
+			qualification: "O/L",
+			want:          []string{"BSc Physics"},
+		},
+		{
+			name: "shared successor counted once",
+			programs: []fixtureProgram{
+				{Name: "Certificate A", Department: "Business", Requires: []string{"O/L"}},
+				{Name: "Certificate B", Department: "Business", Requires: []string{"O/L"}},
+				{Name: "Diploma in Business", Department: "Business", Prerequisites: []string{"Certificate A", "Certificate B"}},
+			},
+			department:    "Business",
+			qualification: "O/L",
+			want:          []string{"Certificate A", "Certificate B", "Diploma in Business"},
+		},
+		{
+			name: "prerequisite cycle",
+			programs: []fixtureProgram{
+				{Name: "Module A", Department: "Arts", Requires: []string{"O/L"}, Prerequisites: []string{"Module B"}},
+				{Name: "Module B", Department: "Arts", Prerequisites: []string{"Module A"}},
+			},
+			department:    "Arts",
+			qualification: "O/L",
+			want:          []string{"Module A", "Module B"},
+		},
+		{
+			name: "unknown qualification",
+			programs: []fixtureProgram{
+				{Name: "Diploma in ICT", Department: "ICT", Requires: []string{"A/L"}},
+			},
+			department:    "ICT",
+			qualification: "PhD",
+		},
+		{
+			name:          "chain longer than the prerequisite depth",
+			programs:      chain("Nursing", "A/L", MaxPrerequisiteDepth+3),
+			department:    "Nursing",
+			qualification: "A/L",
+			want:          []string{"Step 1", "Step 2", "Step 3", "Step 4", "Step 5", "Step 6", "Step 7"},
+			legacyOnly:    []string{"Step 8", "Step 9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			loadFixture(t, client, tt.programs)
+
+			legacy := legacyPathwayByQualification(t, client, tt.department, tt.qualification)
+			expected := append(slices.Clone(tt.want), tt.legacyOnly...)
+			slices.Sort(expected)
+			if !slices.Equal(legacy, expected) {
+				t.Fatalf("legacy query returned %v, the table expects %v", legacy, expected)
+			}
+
+			// The fallback traversal while the access edges are stale, then the
+			// edges once rebuilt, must agree
+			client.MarkAccessibilityStale()
+			for _, mode := range []string{"traversal", "access edges"} {
+				if mode == "access edges" {
+					if _, err := client.RebuildAccessibility(ctx); err != nil {
+						t.Fatalf("failed to rebuild accessibility: %v", err)
+					}
+				}
+				programs, err := client.GetPathwayByQualification(ctx, tt.department, tt.qualification, MaxPrerequisiteDepth)
+				if err != nil {
+					t.Fatalf("%s: %v", mode, err)
+				}
+				var names []string
+				for _, program := range programs {
+					names = append(names, program.Name)
+				}
+				if !slices.Equal(names, tt.want) {
+					t.Errorf("%s returned %v, want %v", mode, names, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,823 @@
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"go.uber.org/zap"
+)
+
+// Entity kinds that can be managed through the admin API
+const (
+	KindInstitute     = "institute"
+	KindFaculty       = "faculty"
+	KindDepartment    = "department"
+	KindProgram       = "program"
+	KindQualification = "qualification"
+	KindCareer        = "career"
+	KindScholarship   = "scholarship"
+)
+
+var (
+	// ErrEntityNotFound is returned when the entity being updated or deleted does not exist
+	ErrEntityNotFound = errors.New("entity not found")
+
+	// ErrEntityExists is returned when creating or renaming to a name that is already taken
+	ErrEntityExists = errors.New("entity already exists")
+
+	// ErrEntityDeleted is returned when creating or renaming to the name of a
+	// deleted entity, which is restored rather than created again
+	ErrEntityDeleted = errors.New("entity was deleted")
+
+	// ErrNotDeleted is returned when restoring an entity that is not deleted
+	ErrNotDeleted = errors.New("entity is not deleted")
+
+	// ErrReferenceNotFound is returned when an entity refers to another entity that does not exist
+	ErrReferenceNotFound = errors.New("referenced entity not found")
+
+	// ErrHasDependents is returned when deleting an entity other entities still depend on
+	ErrHasDependents = errors.New("entity has dependents")
+
+	// ErrInvalidEntity is returned when an entity is missing required fields
+	ErrInvalidEntity = errors.New("invalid entity")
+)
+
+// entitySchema describes how an entity kind is stored in the graph
+type entitySchema struct {
+	Label string
+	Key   string
+	// Pattern matching the relationships through which other entities m depend
+	// on node n, empty when nothing can depend on it
+	Dependents string
+	// Patterns matching the relationships through which node n depends on
+	// other entities m: its parent and, for programs, what they link to
+	References []string
+}
+
+var entitySchemas = map[string]entitySchema{
+	KindInstitute: {Label: "Institute", Key: "name", Dependents: "(n)-[:HAS_FACULTY|OFFERS]->(m)"},
+	KindFaculty: {Label: "Faculty", Key: "name", Dependents: "(n)-[:HAS_DEPARTMENT]->(m)",
+		References: []string{"(m)-[:HAS_FACULTY]->(n)"}},
+	KindDepartment: {Label: "Department", Key: "name", Dependents: "(n)-[:OFFERS]->(m)",
+		References: []string{"(m)-[:HAS_DEPARTMENT]->(n)"}},
+	KindProgram: {Label: "Program", Key: "name", Dependents: "(n)-[:IS_PREREQUISITE_FOR]->(m)",
+		References: []string{"(m)-[:OFFERS|IS_PREREQUISITE_FOR]->(n)", "(n)-[:REQUIRES|LEADS_TO]->(m)"}},
+	KindQualification: {Label: "Qualification", Key: "name", Dependents: "(m)-[:REQUIRES]->(n)"},
+	KindCareer:        {Label: "Career", Key: "title", Dependents: "(m)-[:LEADS_TO]->(n)"},
+	KindScholarship:   {Label: "Scholarship", Key: "name"},
+}
+
+// IsEntityKind reports whether kind is a manageable entity kind
+func IsEntityKind(kind string) bool {
+	_, ok := entitySchemas[kind]
+	return ok
+}
+
+// GraphEntity is the writable form of any graph entity. Parent fields are only
+// used by the kinds they apply to: Institute for faculties (and programs offered
+// directly by an institute), Faculty for departments, Department for programs.
+// For programs, nil relationship lists leave existing relationships untouched on
+// update while empty lists clear them, as do the Programs and Institutes a
+// scholarship funds. Likewise a nil Provenance, Contact, Info or Scholarship is
+// left as is; Contact only applies to institutes and replaces all contact
+// details, Info only to programs, replacing all their details, and Scholarship
+// only to scholarships. Version and DeletedAt are read-only and ignored on
+// write.
+type GraphEntity struct {
+	Name          string            `json:"name"`
+	Institute     string            `json:"institute,omitempty"`
+	Faculty       string            `json:"faculty,omitempty"`
+	Department    string            `json:"department,omitempty"`
+	Requirements  []string          `json:"requirements,omitempty"`
+	Prerequisites []string          `json:"prerequisites,omitempty"`
+	Careers       []string          `json:"careers,omitempty"`
+	Provenance    *Provenance       `json:"provenance,omitempty"`
+	Contact       *InstituteContact `json:"contact,omitempty"`
+	Info          *ProgramInfo      `json:"info,omitempty"`
+	Programs      []string          `json:"programs,omitempty"`
+	Institutes    []string          `json:"institutes,omitempty"`
+	Scholarship   *ScholarshipInfo  `json:"scholarship,omitempty"`
+	Version       int64             `json:"version,omitempty"`
+	DeletedAt     string            `json:"deleted_at,omitempty"`
+}
+
+// CreateEntity creates a new entity after checking that everything it refers to exists
+func (c *Client) CreateEntity(ctx context.Context, kind string, entity GraphEntity) error {
+	schema, ok := entitySchemas[kind]
+	if !ok {
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		if err := checkNameFree(ctx, tx, kind, entity.Name); err != nil {
+			return nil, err
+		}
+
+		if err := checkReferences(ctx, tx, kind, entity, true); err != nil {
+			return nil, err
+		}
+
+		query := fmt.Sprintf("CREATE (n:%s {%s: $name, version: 1})", schema.Label, schema.Key)
+		if _, err := runConsume(ctx, tx, query, map[string]any{"name": entity.Name}); err != nil {
+			return nil, err
+		}
+
+		if err := linkEntity(ctx, tx, kind, entity.Name, entity, true); err != nil {
+			return nil, err
+		}
+		if err := setProvenance(ctx, tx, schema, entity.Name, entity.Provenance); err != nil {
+			return nil, err
+		}
+		if err := setContact(ctx, tx, entity.Name, entity.Contact); err != nil {
+			return nil, err
+		}
+		if err := setScholarshipInfo(ctx, tx, entity.Name, entity.Scholarship); err != nil {
+			return nil, err
+		}
+		return nil, setProgramInfo(ctx, tx, entity.Name, entity.Info)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("Graph entity created",
+		zap.String("kind", kind),
+		zap.String("name", entity.Name))
+	return nil
+}
+
+// UpdateEntity renames an entity and/or replaces its relationships
+func (c *Client) UpdateEntity(ctx context.Context, kind, name string, entity GraphEntity) error {
+	schema, ok := entitySchemas[kind]
+	if !ok {
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+	}
+	if entity.Name == "" {
+		entity.Name = name
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		exists, err := nodeExists(ctx, tx, schema, name)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: %s %q", ErrEntityNotFound, kind, name)
+		}
+
+		if entity.Name != name {
+			if err := checkNameFree(ctx, tx, kind, entity.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		if kind == KindProgram && contains(entity.Prerequisites, name) {
+			return nil, fmt.Errorf("%w: a program cannot be its own prerequisite", ErrInvalidEntity)
+		}
+		if err := checkReferences(ctx, tx, kind, entity, false); err != nil {
+			return nil, err
+		}
+
+		query := fmt.Sprintf("MATCH (n:%s {%s: $name}) SET n.%s = $newName %s", schema.Label, schema.Key, schema.Key, bumpVersion)
+		if _, err := runConsume(ctx, tx, query, map[string]any{"name": name, "newName": entity.Name}); err != nil {
+			return nil, err
+		}
+
+		if err := linkEntity(ctx, tx, kind, entity.Name, entity, false); err != nil {
+			return nil, err
+		}
+		if err := setProvenance(ctx, tx, schema, entity.Name, entity.Provenance); err != nil {
+			return nil, err
+		}
+		if err := setContact(ctx, tx, entity.Name, entity.Contact); err != nil {
+			return nil, err
+		}
+		if err := setScholarshipInfo(ctx, tx, entity.Name, entity.Scholarship); err != nil {
+			return nil, err
+		}
+		return nil, setProgramInfo(ctx, tx, entity.Name, entity.Info)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("Graph entity updated",
+		zap.String("kind", kind),
+		zap.String("name", name),
+		zap.String("new_name", entity.Name))
+	return nil
+}
+
+// DeleteEntity deletes an entity that no other entity depends on. Deletion is
+// soft: the node is kept with its relationships and a deleted_at timestamp,
+// hidden from every read but admin ones, until RestoreEntity brings it back.
+func (c *Client) DeleteEntity(ctx context.Context, kind, name string) error {
+	schema, ok := entitySchemas[kind]
+	if !ok {
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		exists, err := nodeExists(ctx, tx, schema, name)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: %s %q", ErrEntityNotFound, kind, name)
+		}
+
+		if schema.Dependents != "" {
+			query := fmt.Sprintf("MATCH (n:%s {%s: $name}) MATCH %s WHERE m.deleted_at IS NULL RETURN count(*) as count",
+				schema.Label, schema.Key, schema.Dependents)
+			dependents, err := runCount(ctx, tx, query, map[string]any{"name": name})
+			if err != nil {
+				return nil, err
+			}
+			if dependents > 0 {
+				return nil, fmt.Errorf("%w: %s %q is still referenced by %d relationship(s)", ErrHasDependents, kind, name, dependents)
+			}
+		}
+
+		query := fmt.Sprintf("MATCH (n:%s {%s: $name}) SET n.deleted_at = $now %s", schema.Label, schema.Key, bumpVersion)
+		_, err = runConsume(ctx, tx, query, map[string]any{
+			"name": name,
+			"now":  time.Now().UTC().Format(time.RFC3339),
+		})
+		return nil, err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("Graph entity deleted",
+		zap.String("kind", kind),
+		zap.String("name", name))
+	return nil
+}
+
+// RestoreEntity brings back a deleted entity as it was when deleted. Its parent
+// and, for a program, everything it links to must not be deleted themselves.
+func (c *Client) RestoreEntity(ctx context.Context, kind, name string) error {
+	schema, ok := entitySchemas[kind]
+	if !ok {
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		deleted, err := nodeDeleted(ctx, tx, schema, name)
+		if err != nil {
+			return nil, err
+		}
+		if !deleted {
+			exists, err := nodeExists(ctx, tx, schema, name)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, fmt.Errorf("%w: %s %q", ErrNotDeleted, kind, name)
+			}
+			return nil, fmt.Errorf("%w: %s %q", ErrEntityNotFound, kind, name)
+		}
+
+		for _, pattern := range schema.References {
+			query := fmt.Sprintf("MATCH (n:%s {%s: $name}) MATCH %s WHERE m.deleted_at IS NOT NULL RETURN count(*) as count",
+				schema.Label, schema.Key, pattern)
+			references, err := runCount(ctx, tx, query, map[string]any{"name": name})
+			if err != nil {
+				return nil, err
+			}
+			if references > 0 {
+				return nil, fmt.Errorf("%w: %s %q refers to %d deleted entities, restore them first", ErrReferenceNotFound, kind, name, references)
+			}
+		}
+
+		query := fmt.Sprintf("MATCH (n:%s {%s: $name}) REMOVE n.deleted_at %s", schema.Label, schema.Key, bumpVersion)
+		_, err = runConsume(ctx, tx, query, map[string]any{"name": name})
+		return nil, err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("Graph entity restored",
+		zap.String("kind", kind),
+		zap.String("name", name))
+	return nil
+}
+
+// GetEntity returns an entity in its writable form, with its parent, program
+// relationships, provenance, contact and program details, so that saving the result
+// through UpdateEntity or CreateEntity restores the entity as it is now. Deleted
+// entities are returned too, with their DeletedAt set.
+func (c *Client) GetEntity(ctx context.Context, kind, name string) (*GraphEntity, error) {
+	schema, ok := entitySchemas[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+	}
+
+	var query string
+	switch kind {
+	case KindFaculty:
+		query = `MATCH (n:Faculty {name: $name})
+			OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY]->(n)
+			RETURN properties(n) as properties, head(collect(i.name)) as institute`
+	case KindDepartment:
+		query = `MATCH (n:Department {name: $name})
+			OPTIONAL MATCH (f:Faculty)-[:HAS_DEPARTMENT]->(n)
+			RETURN properties(n) as properties, head(collect(f.name)) as faculty`
+	case KindProgram:
+		query = `MATCH (n:Program {name: $name})
+			OPTIONAL MATCH (d:Department)-[:OFFERS]->(n)
+			WITH n, head(collect(d.name)) as department
+			OPTIONAL MATCH (i:Institute)-[:OFFERS]->(n)
+			WITH n, department, head(collect(i.name)) as institute
+			OPTIONAL MATCH (n)-[:REQUIRES]->(q:Qualification)
+			WITH n, department, institute, collect(DISTINCT q.name) as requirements
+			OPTIONAL MATCH (p:Program)-[:IS_PREREQUISITE_FOR]->(n)
+			WITH n, department, institute, requirements, collect(DISTINCT p.name) as prerequisites
+			OPTIONAL MATCH (n)-[:LEADS_TO]->(c:Career)
+			RETURN properties(n) as properties, department, institute, requirements, prerequisites,
+				collect(DISTINCT c.title) as careers`
+	case KindScholarship:
+		query = `MATCH (n:Scholarship {name: $name})
+			OPTIONAL MATCH (n)-[:FUNDS]->(p:Program)
+			WITH n, collect(DISTINCT p.name) as programs
+			OPTIONAL MATCH (n)-[:FUNDS]->(i:Institute)
+			RETURN properties(n) as properties, programs, collect(DISTINCT i.name) as institutes`
+	default:
+		query = fmt.Sprintf("MATCH (n:%s {%s: $name}) RETURN properties(n) as properties", schema.Label, schema.Key)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, map[string]any{"name": name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", kind, err)
+	}
+	if !result.Next(ctx) {
+		if err := result.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", kind, err)
+		}
+		return nil, fmt.Errorf("%w: %s %q", ErrEntityNotFound, kind, name)
+	}
+
+	record := result.Record()
+	properties, _ := record.Get("properties")
+	entity := &GraphEntity{
+		Name:       name,
+		Provenance: provenanceFromProperties(properties),
+	}
+	if props, ok := properties.(map[string]any); ok {
+		entity.Version, _ = props["version"].(int64)
+		entity.DeletedAt = stringOrEmpty(props["deleted_at"])
+	}
+	switch kind {
+	case KindInstitute:
+		entity.Contact = contactFromProperties(properties)
+	case KindProgram:
+		entity.Info = programInfoFromProperties(properties)
+	case KindScholarship:
+		entity.Scholarship = scholarshipInfoFromProperties(properties)
+	}
+
+	get := func(key string) any {
+		value, _ := record.Get(key)
+		return value
+	}
+	switch kind {
+	case KindFaculty:
+		entity.Institute = stringOrEmpty(get("institute"))
+	case KindDepartment:
+		entity.Faculty = stringOrEmpty(get("faculty"))
+	case KindProgram:
+		entity.Department = stringOrEmpty(get("department"))
+		if entity.Department == "" {
+			entity.Institute = stringOrEmpty(get("institute"))
+		}
+		entity.Requirements = toStrings(get("requirements"))
+		entity.Prerequisites = toStrings(get("prerequisites"))
+		entity.Careers = toStrings(get("careers"))
+		sort.Strings(entity.Requirements)
+		sort.Strings(entity.Prerequisites)
+		sort.Strings(entity.Careers)
+	case KindScholarship:
+		entity.Programs = toStrings(get("programs"))
+		entity.Institutes = toStrings(get("institutes"))
+		sort.Strings(entity.Programs)
+		sort.Strings(entity.Institutes)
+	}
+	return entity, nil
+}
+
+// DependentPrograms returns the names of the programs a program is a
+// prerequisite for
+func (c *Client) DependentPrograms(ctx context.Context, name string) ([]string, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		MATCH (:Program {name: $name})-[:IS_PREREQUISITE_FOR]->(p:Program)
+		WHERE p.deleted_at IS NULL
+		RETURN DISTINCT p.name as name ORDER BY name
+	`, map[string]any{"name": name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependent programs: %w", err)
+	}
+
+	var names []string
+	for result.Next(ctx) {
+		value, _ := result.Record().Get("name")
+		if s := stringOrEmpty(value); s != "" {
+			names = append(names, s)
+		}
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dependent programs: %w", err)
+	}
+	return names, nil
+}
+
+// Program relationships that can be linked and unlinked one at a time, named
+// after the GraphEntity lists they appear in
+const (
+	RelationRequirements  = "requirements"
+	RelationPrerequisites = "prerequisites"
+	RelationCareers       = "careers"
+)
+
+// programLink describes how a program relationship is stored; in both
+// queries node n is the program and node t the entity it is linked to
+type programLink struct {
+	Kind   string
+	Link   string
+	Unlink string
+}
+
+var programLinks = map[string]programLink{
+	RelationRequirements: {
+		Kind:   KindQualification,
+		Link:   `MATCH (n:Program {name: $name}), (t:Qualification {name: $target}) MERGE (n)-[:REQUIRES]->(t)`,
+		Unlink: `MATCH (n:Program {name: $name})-[r:REQUIRES]->(t:Qualification {name: $target}) DELETE r`,
+	},
+	RelationPrerequisites: {
+		Kind:   KindProgram,
+		Link:   `MATCH (n:Program {name: $name}), (t:Program {name: $target}) MERGE (t)-[:IS_PREREQUISITE_FOR]->(n)`,
+		Unlink: `MATCH (t:Program {name: $target})-[r:IS_PREREQUISITE_FOR]->(n:Program {name: $name}) DELETE r`,
+	},
+	RelationCareers: {
+		Kind:   KindCareer,
+		Link:   `MATCH (n:Program {name: $name}), (t:Career {title: $target}) MERGE (n)-[:LEADS_TO]->(t)`,
+		Unlink: `MATCH (n:Program {name: $name})-[r:LEADS_TO]->(t:Career {title: $target}) DELETE r`,
+	},
+}
+
+// IsProgramRelation reports whether relation can be linked with LinkProgram
+func IsProgramRelation(relation string) bool {
+	_, ok := programLinks[relation]
+	return ok
+}
+
+// LinkProgram adds a single relationship from a program, e.g. a career it leads
+// to, leaving its other relationships untouched. It reports whether the
+// relationship is new.
+func (c *Client) LinkProgram(ctx context.Context, program, relation, target string) (bool, error) {
+	rel, ok := programLinks[relation]
+	if !ok {
+		return false, fmt.Errorf("%w: unknown program relationship %q", ErrInvalidEntity, relation)
+	}
+	if relation == RelationPrerequisites && program == target {
+		return false, fmt.Errorf("%w: a program cannot be its own prerequisite", ErrInvalidEntity)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	created, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		exists, err := nodeExists(ctx, tx, entitySchemas[KindProgram], program)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			return false, fmt.Errorf("%w: %s %q", ErrEntityNotFound, KindProgram, program)
+		}
+
+		exists, err = nodeExists(ctx, tx, entitySchemas[rel.Kind], target)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			return false, fmt.Errorf("%w: %s %q", ErrReferenceNotFound, rel.Kind, target)
+		}
+
+		summary, err := runConsume(ctx, tx, rel.Link, map[string]any{"name": program, "target": target})
+		if err != nil {
+			return false, err
+		}
+		if summary.Counters().RelationshipsCreated() == 0 {
+			return false, nil
+		}
+		return true, touchNode(ctx, tx, entitySchemas[KindProgram], program)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	c.logger.Info("Program linked",
+		zap.String("program", program),
+		zap.String("relation", relation),
+		zap.String("target", target),
+		zap.Bool("created", created.(bool)))
+	return created.(bool), nil
+}
+
+// UnlinkProgram removes a single relationship from a program. It reports
+// whether there was a relationship to remove.
+func (c *Client) UnlinkProgram(ctx context.Context, program, relation, target string) (bool, error) {
+	rel, ok := programLinks[relation]
+	if !ok {
+		return false, fmt.Errorf("%w: unknown program relationship %q", ErrInvalidEntity, relation)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	deleted, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		exists, err := nodeExists(ctx, tx, entitySchemas[KindProgram], program)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			return false, fmt.Errorf("%w: %s %q", ErrEntityNotFound, KindProgram, program)
+		}
+
+		summary, err := runConsume(ctx, tx, rel.Unlink, map[string]any{"name": program, "target": target})
+		if err != nil {
+			return false, err
+		}
+		if summary.Counters().RelationshipsDeleted() == 0 {
+			return false, nil
+		}
+		return true, touchNode(ctx, tx, entitySchemas[KindProgram], program)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	c.logger.Info("Program unlinked",
+		zap.String("program", program),
+		zap.String("relation", relation),
+		zap.String("target", target),
+		zap.Bool("deleted", deleted.(bool)))
+	return deleted.(bool), nil
+}
+
+// checkReferences verifies that every entity referred to by an entity exists.
+// Parents are mandatory when creating and optional when updating.
+func checkReferences(ctx context.Context, tx neo4j.ManagedTransaction, kind string, entity GraphEntity, creating bool) error {
+	type reference struct {
+		kind  string
+		names []string
+	}
+	var refs []reference
+
+	if entity.Contact != nil && kind != KindInstitute {
+		return fmt.Errorf("%w: only institutes have contact details", ErrInvalidEntity)
+	}
+	if entity.Info != nil && kind != KindProgram {
+		return fmt.Errorf("%w: only programs have program details", ErrInvalidEntity)
+	}
+	if (entity.Scholarship != nil || entity.Programs != nil || entity.Institutes != nil) && kind != KindScholarship {
+		return fmt.Errorf("%w: only scholarships have scholarship details and fund programs or institutes", ErrInvalidEntity)
+	}
+
+	switch kind {
+	case KindFaculty:
+		if entity.Institute == "" && creating {
+			return fmt.Errorf("%w: a faculty must belong to an institute", ErrInvalidEntity)
+		}
+		if entity.Institute != "" {
+			refs = append(refs, reference{KindInstitute, []string{entity.Institute}})
+		}
+	case KindDepartment:
+		if entity.Faculty == "" && creating {
+			return fmt.Errorf("%w: a department must belong to a faculty", ErrInvalidEntity)
+		}
+		if entity.Faculty != "" {
+			refs = append(refs, reference{KindFaculty, []string{entity.Faculty}})
+		}
+	case KindProgram:
+		if entity.Department == "" && entity.Institute == "" && creating {
+			return fmt.Errorf("%w: a program must be offered by a department or an institute", ErrInvalidEntity)
+		}
+		if entity.Department != "" && entity.Institute != "" {
+			return fmt.Errorf("%w: a program is offered by either a department or an institute, not both", ErrInvalidEntity)
+		}
+		if entity.Department != "" {
+			refs = append(refs, reference{KindDepartment, []string{entity.Department}})
+		}
+		if entity.Institute != "" {
+			refs = append(refs, reference{KindInstitute, []string{entity.Institute}})
+		}
+		if contains(entity.Prerequisites, entity.Name) {
+			return fmt.Errorf("%w: a program cannot be its own prerequisite", ErrInvalidEntity)
+		}
+		refs = append(refs,
+			reference{KindQualification, entity.Requirements},
+			reference{KindProgram, entity.Prerequisites},
+			reference{KindCareer, entity.Careers})
+	case KindScholarship:
+		refs = append(refs,
+			reference{KindProgram, entity.Programs},
+			reference{KindInstitute, entity.Institutes})
+	}
+
+	for _, ref := range refs {
+		schema := entitySchemas[ref.kind]
+		for _, name := range ref.names {
+			exists, err := nodeExists(ctx, tx, schema, name)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: %s %q", ErrReferenceNotFound, ref.kind, name)
+			}
+		}
+	}
+	return nil
+}
+
+// linkEntity creates the relationships of an entity. On update, a relationship
+// group is only replaced when the corresponding field is set.
+func linkEntity(ctx context.Context, tx neo4j.ManagedTransaction, kind, name string, entity GraphEntity, created bool) error {
+	params := map[string]any{"name": name}
+
+	switch kind {
+	case KindFaculty:
+		if entity.Institute == "" {
+			return nil
+		}
+		params["parent"] = entity.Institute
+		return runAll(ctx, tx, params,
+			`MATCH (:Institute)-[r:HAS_FACULTY]->(n:Faculty {name: $name}) DELETE r`,
+			`MATCH (i:Institute {name: $parent}), (n:Faculty {name: $name}) MERGE (i)-[:HAS_FACULTY]->(n)`)
+
+	case KindDepartment:
+		if entity.Faculty == "" {
+			return nil
+		}
+		params["parent"] = entity.Faculty
+		return runAll(ctx, tx, params,
+			`MATCH (:Faculty)-[r:HAS_DEPARTMENT]->(n:Department {name: $name}) DELETE r`,
+			`MATCH (f:Faculty {name: $parent}), (n:Department {name: $name}) MERGE (f)-[:HAS_DEPARTMENT]->(n)`)
+
+	case KindProgram:
+		var queries []string
+		if entity.Department != "" || entity.Institute != "" {
+			queries = append(queries, `MATCH ()-[r:OFFERS]->(n:Program {name: $name}) DELETE r`)
+			if entity.Department != "" {
+				params["parent"] = entity.Department
+				queries = append(queries, `MATCH (d:Department {name: $parent}), (n:Program {name: $name}) MERGE (d)-[:OFFERS]->(n)`)
+			} else {
+				params["parent"] = entity.Institute
+				queries = append(queries, `MATCH (i:Institute {name: $parent}), (n:Program {name: $name}) MERGE (i)-[:OFFERS]->(n)`)
+			}
+		}
+		if entity.Requirements != nil || created {
+			params["requirements"] = nonNil(entity.Requirements)
+			queries = append(queries,
+				`MATCH (n:Program {name: $name})-[r:REQUIRES]->(:Qualification) DELETE r`,
+				`MATCH (n:Program {name: $name}) UNWIND $requirements AS req MATCH (q:Qualification {name: req}) MERGE (n)-[:REQUIRES]->(q)`)
+		}
+		if entity.Prerequisites != nil || created {
+			params["prerequisites"] = nonNil(entity.Prerequisites)
+			queries = append(queries,
+				`MATCH (:Program)-[r:IS_PREREQUISITE_FOR]->(n:Program {name: $name}) DELETE r`,
+				`MATCH (n:Program {name: $name}) UNWIND $prerequisites AS prereq MATCH (p:Program {name: prereq}) MERGE (p)-[:IS_PREREQUISITE_FOR]->(n)`)
+		}
+		if entity.Careers != nil || created {
+			params["careers"] = nonNil(entity.Careers)
+			queries = append(queries,
+				`MATCH (n:Program {name: $name})-[r:LEADS_TO]->(:Career) DELETE r`,
+				`MATCH (n:Program {name: $name}) UNWIND $careers AS title MATCH (c:Career {title: title}) MERGE (n)-[:LEADS_TO]->(c)`)
+		}
+		return runAll(ctx, tx, params, queries...)
+
+	case KindScholarship:
+		return runAll(ctx, tx, params, scholarshipLinks(entity, created, params)...)
+	}
+
+	return nil
+}
+
+// bumpVersion counts a write to node n in its version, which starts at 1 on
+// creation
+const bumpVersion = "SET n.version = coalesce(n.version, 0) + 1"
+
+// nodeExists reports whether an entity exists and is not deleted
+func nodeExists(ctx context.Context, tx neo4j.ManagedTransaction, schema entitySchema, name string) (bool, error) {
+	query := fmt.Sprintf("MATCH (n:%s {%s: $name}) WHERE n.deleted_at IS NULL RETURN count(n) as count", schema.Label, schema.Key)
+	count, err := runCount(ctx, tx, query, map[string]any{"name": name})
+	return count > 0, err
+}
+
+// nodeDeleted reports whether an entity exists and is deleted
+func nodeDeleted(ctx context.Context, tx neo4j.ManagedTransaction, schema entitySchema, name string) (bool, error) {
+	query := fmt.Sprintf("MATCH (n:%s {%s: $name}) WHERE n.deleted_at IS NOT NULL RETURN count(n) as count", schema.Label, schema.Key)
+	count, err := runCount(ctx, tx, query, map[string]any{"name": name})
+	return count > 0, err
+}
+
+// checkNameFree verifies that no entity of a kind, deleted or not, has a name
+func checkNameFree(ctx context.Context, tx neo4j.ManagedTransaction, kind, name string) error {
+	schema := entitySchemas[kind]
+	exists, err := nodeExists(ctx, tx, schema, name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s %q", ErrEntityExists, kind, name)
+	}
+	deleted, err := nodeDeleted(ctx, tx, schema, name)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		return fmt.Errorf("%w: %s %q, restore it instead", ErrEntityDeleted, kind, name)
+	}
+	return nil
+}
+
+// touchNode counts a write to an entity made through its relationships alone
+func touchNode(ctx context.Context, tx neo4j.ManagedTransaction, schema entitySchema, name string) error {
+	query := fmt.Sprintf("MATCH (n:%s {%s: $name}) %s", schema.Label, schema.Key, bumpVersion)
+	_, err := runConsume(ctx, tx, query, map[string]any{"name": name})
+	return err
+}
+
+func runCount(ctx context.Context, tx neo4j.ManagedTransaction, query string, params map[string]any) (int64, error) {
+	result, err := tx.Run(ctx, query, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to run count query: %w", err)
+	}
+	record, err := result.Single(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read count: %w", err)
+	}
+	value, _ := record.Get("count")
+	count, _ := value.(int64)
+	return count, nil
+}
+
+func runConsume(ctx context.Context, tx neo4j.ManagedTransaction, query string, params map[string]any) (neo4j.ResultSummary, error) {
+	result, err := tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run write query: %w", err)
+	}
+	summary, err := result.Consume(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply write query: %w", err)
+	}
+	return summary, nil
+}
+
+func runAll(ctx context.Context, tx neo4j.ManagedTransaction, params map[string]any, queries ...string) error {
+	for _, query := range queries {
+		if _, err := runConsume(ctx, tx, query, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,28 @@
+package neo4j
+
+import "time"
+
+// TestDate is a dated step of an admission test
+type TestDate struct {
+	Label string    `json:"label"`
+	Date  time.Time `json:"date"`
+}
+
+// PastPaper links to a past paper of an admission test
+type PastPaper struct {
+	Title string `json:"title"`
+	Year  int    `json:"year,omitempty"`
+	URL   string `json:"url"`
+}
+
+// AptitudeTest is an aptitude or practical test a program admits students by, a
+// requirement on top of its qualifications. Tests are kept outside the graph and
+// attached to program details by the pathway service.
+type AptitudeTest struct {
+	Name        string      `json:"name"`
+	Kind        string      `json:"kind"`
+	Description string      `json:"description,omitempty"`
+	Dates       []TestDate  `json:"dates,omitempty"`
+	PastPapers  []PastPaper `json:"past_papers,omitempty"`
+	Source      string      `json:"source,omitempty"`
+}
@@ -0,0 +1,252 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"go.uber.org/zap"
+)
+
+// backupIDProperty temporarily holds a node's exported ID while a snapshot is restored
+const backupIDProperty = "__backup_id"
+
+// restoreBatchSize is the number of nodes or relationships created per query
+const restoreBatchSize = 500
+
+// SnapshotNode is a node of a graph snapshot
+type SnapshotNode struct {
+	ID         string         `json:"id"`
+	Labels     []string       `json:"labels"`
+	Properties map[string]any `json:"properties"`
+}
+
+// SnapshotRelationship is a relationship of a graph snapshot
+type SnapshotRelationship struct {
+	Start      string         `json:"start"`
+	End        string         `json:"end"`
+	Type       string         `json:"type"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// GraphSnapshot is a full export of the graph
+type GraphSnapshot struct {
+	Nodes         []SnapshotNode         `json:"nodes"`
+	Relationships []SnapshotRelationship `json:"relationships"`
+}
+
+// ExportGraph reads every node and relationship in the database, except the
+// derived access edges which are rebuilt after a restore
+func (c *Client) ExportGraph(ctx context.Context) (*GraphSnapshot, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	snapshot, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		snapshot := &GraphSnapshot{
+			Nodes:         []SnapshotNode{},
+			Relationships: []SnapshotRelationship{},
+		}
+
+		result, err := tx.Run(ctx, `
+			MATCH (n)
+			RETURN elementId(n) as id, labels(n) as labels, properties(n) as properties
+		`, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export nodes: %w", err)
+		}
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read nodes: %w", err)
+		}
+		for _, record := range records {
+			id, _ := record.Get("id")
+			labels, _ := record.Get("labels")
+			properties, _ := record.Get("properties")
+			snapshot.Nodes = append(snapshot.Nodes, SnapshotNode{
+				ID:         stringOrEmpty(id),
+				Labels:     toStrings(labels),
+				Properties: toProperties(properties),
+			})
+		}
+
+		result, err = tx.Run(ctx, `
+			MATCH (a)-[r]->(b)
+			WHERE type(r) <> $derived
+			RETURN elementId(a) as start, elementId(b) as end, type(r) as type, properties(r) as properties
+		`, map[string]any{"derived": accessRelationship})
+		if err != nil {
+			return nil, fmt.Errorf("failed to export relationships: %w", err)
+		}
+		records, err = result.Collect(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read relationships: %w", err)
+		}
+		for _, record := range records {
+			start, _ := record.Get("start")
+			end, _ := record.Get("end")
+			relType, _ := record.Get("type")
+			properties, _ := record.Get("properties")
+			rel := SnapshotRelationship{
+				Start: stringOrEmpty(start),
+				End:   stringOrEmpty(end),
+				Type:  stringOrEmpty(relType),
+			}
+			if props := toProperties(properties); len(props) > 0 {
+				rel.Properties = props
+			}
+			snapshot.Relationships = append(snapshot.Relationships, rel)
+		}
+
+		return snapshot, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.(*GraphSnapshot), nil
+}
+
+// RestoreGraph replaces the whole graph with a snapshot in a single transaction,
+// so a failed restore leaves the current graph untouched
+func (c *Client) RestoreGraph(ctx context.Context, snapshot *GraphSnapshot) error {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	// Group nodes by label set and relationships by type and endpoint labels so
+	// each batch can use a static query with label-scoped matches
+	labelsByID := make(map[string][]string, len(snapshot.Nodes))
+	nodeGroups := map[string][]map[string]any{}
+	for _, n := range snapshot.Nodes {
+		labelsByID[n.ID] = n.Labels
+		key := labelPattern(n.Labels)
+		nodeGroups[key] = append(nodeGroups[key], map[string]any{
+			"id":         n.ID,
+			"properties": nonNilProperties(n.Properties),
+		})
+	}
+
+	type relKey struct{ relType, start, end string }
+	relGroups := map[relKey][]map[string]any{}
+	for _, r := range snapshot.Relationships {
+		startLabels, ok := labelsByID[r.Start]
+		if !ok {
+			return fmt.Errorf("%w: relationship references unknown node %q", ErrInvalidEntity, r.Start)
+		}
+		endLabels, ok := labelsByID[r.End]
+		if !ok {
+			return fmt.Errorf("%w: relationship references unknown node %q", ErrInvalidEntity, r.End)
+		}
+		key := relKey{r.Type, labelPattern(firstLabel(startLabels)), labelPattern(firstLabel(endLabels))}
+		relGroups[key] = append(relGroups[key], map[string]any{
+			"start":      r.Start,
+			"end":        r.End,
+			"properties": nonNilProperties(r.Properties),
+		})
+	}
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		if _, err := runConsume(ctx, tx, "MATCH (n) DETACH DELETE n", nil); err != nil {
+			return nil, err
+		}
+
+		for labels, nodes := range nodeGroups {
+			query := fmt.Sprintf(`
+				UNWIND $batch AS row
+				CREATE (n%s)
+				SET n = row.properties, n.%s = row.id
+			`, labels, backupIDProperty)
+			if err := runBatches(ctx, tx, query, nodes); err != nil {
+				return nil, err
+			}
+		}
+
+		for key, rels := range relGroups {
+			query := fmt.Sprintf(`
+				UNWIND $batch AS row
+				MATCH (a%s {%s: row.start}), (b%s {%s: row.end})
+				CREATE (a)-[r:%s]->(b)
+				SET r = row.properties
+			`, key.start, backupIDProperty, key.end, backupIDProperty, quoteName(key.relType))
+			if err := runBatches(ctx, tx, query, rels); err != nil {
+				return nil, err
+			}
+		}
+
+		_, err := runConsume(ctx, tx, fmt.Sprintf(
+			"MATCH (n) WHERE n.%s IS NOT NULL REMOVE n.%s", backupIDProperty, backupIDProperty), nil)
+		return nil, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore graph: %w", err)
+	}
+	c.MarkAccessibilityStale()
+
+	c.logger.Info("Graph restored",
+		zap.Int("nodes", len(snapshot.Nodes)),
+		zap.Int("relationships", len(snapshot.Relationships)))
+	return nil
+}
+
+func runBatches(ctx context.Context, tx neo4j.ManagedTransaction, query string, rows []map[string]any) error {
+	for start := 0; start < len(rows); start += restoreBatchSize {
+		end := start + restoreBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		if _, err := runConsume(ctx, tx, query, map[string]any{"batch": rows[start:end]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// labelPattern renders labels as a Cypher label expression, e.g. ":`Program`"
+func labelPattern(labels []string) string {
+	sorted := append([]string(nil), labels...)
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	for _, label := range sorted {
+		b.WriteString(":" + quoteName(label))
+	}
+	return b.String()
+}
+
+func firstLabel(labels []string) []string {
+	if len(labels) == 0 {
+		return nil
+	}
+	sorted := append([]string(nil), labels...)
+	sort.Strings(sorted)
+	return sorted[:1]
+}
+
+// quoteName escapes a label or relationship type for use in a query
+func quoteName(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func toStrings(value any) []string {
+	items, _ := value.([]any)
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, stringOrEmpty(item))
+	}
+	return result
+}
+
+func toProperties(value any) map[string]any {
+	properties, _ := value.(map[string]any)
+	if properties == nil {
+		return map[string]any{}
+	}
+	return properties
+}
+
+func nonNilProperties(properties map[string]any) map[string]any {
+	if properties == nil {
+		return map[string]any{}
+	}
+	return properties
+}
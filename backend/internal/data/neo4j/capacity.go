@@ -0,0 +1,20 @@
+package neo4j
+
+// IntakeYear is a program's intake and application count for one academic year
+type IntakeYear struct {
+	Year         int `json:"year"`
+	Intake       int `json:"intake"`
+	Applications int `json:"applications,omitempty"`
+}
+
+// ProgramCapacity summarizes how many students a program admits and how many
+// compete for those places. The figures are kept outside the graph and attached
+// to program details by the pathway service.
+type ProgramCapacity struct {
+	Year              int          `json:"year"`
+	AnnualIntake      int          `json:"annual_intake"`
+	Applications      int          `json:"applications,omitempty"`
+	ApplicantsPerSeat float64      `json:"applicants_per_seat,omitempty"`
+	Competitiveness   string       `json:"competitiveness,omitempty"`
+	History           []IntakeYear `json:"history"`
+}
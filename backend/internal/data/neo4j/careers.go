@@ -0,0 +1,171 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// CareerEducation lists the programs in the graph that lead to a career
+type CareerEducation struct {
+	Title    string    `json:"title"`
+	Programs []Program `json:"programs"`
+}
+
+// GetCareerEducation retrieves the programs leading to each of the given careers.
+// Careers that do not exist in the graph are omitted from the result.
+func (c *Client) GetCareerEducation(ctx context.Context, careerTitles []string) ([]CareerEducation, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		UNWIND $careerTitles AS careerTitle
+		MATCH (c:Career {title: careerTitle})
+		WHERE c.deleted_at IS NULL
+		OPTIONAL MATCH (p:Program)-[:LEADS_TO]->(c)
+		WHERE p.deleted_at IS NULL
+		RETURN c.title as title,
+		       COLLECT(DISTINCT p.name) as programs
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{
+		"careerTitles": careerTitles,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query career education: %w", err)
+	}
+
+	var careers []CareerEducation
+	for result.Next(ctx) {
+		record := result.Record()
+
+		title, _ := record.Get("title")
+		programs, _ := record.Get("programs")
+
+		career := CareerEducation{
+			Title: stringOrEmpty(title),
+		}
+
+		// Convert programs
+		if progList, ok := programs.([]interface{}); ok {
+			for _, prog := range progList {
+				if progStr, ok := prog.(string); ok && progStr != "" {
+					career.Programs = append(career.Programs, Program{Name: progStr})
+				}
+			}
+		}
+
+		careers = append(careers, career)
+	}
+
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating career education: %w", err)
+	}
+
+	return careers, nil
+}
+
+// TransitionProgram is a program leading to the career someone is moving to,
+// with how it connects to the career they are leaving
+type TransitionProgram struct {
+	Name         string   `json:"name"`
+	Institute    string   `json:"institute,omitempty"`
+	Requirements []string `json:"requirements"`
+	// BuildsOn lists programs leading to the current career that are
+	// prerequisites of this one
+	BuildsOn []string `json:"builds_on"`
+	// LeadsToBoth is set when the program also leads to the current career
+	LeadsToBoth bool `json:"leads_to_both"`
+}
+
+// CareerTransitionData is the part of the graph around two careers that a
+// switch between them goes through: the programs leading to the current career
+// and the qualifications they require, and the programs leading to the target
+type CareerTransitionData struct {
+	From               string              `json:"from"`
+	To                 string              `json:"to"`
+	FromPrograms       []string            `json:"from_programs"`
+	FromQualifications []string            `json:"from_qualifications"`
+	TargetPrograms     []TransitionProgram `json:"target_programs"`
+}
+
+// GetCareerTransition retrieves what connects the programs leading to one
+// career with those leading to another
+func (c *Client) GetCareerTransition(ctx context.Context, from, to string) (*CareerTransitionData, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		OPTIONAL MATCH (from:Career {title: $from})
+		WHERE from.deleted_at IS NULL
+		OPTIONAL MATCH (to:Career {title: $to})
+		WHERE to.deleted_at IS NULL
+		OPTIONAL MATCH (fp:Program)-[:LEADS_TO]->(from)
+		WHERE fp.deleted_at IS NULL
+		WITH from, to, collect(DISTINCT fp) as fromPrograms
+		WITH from, to, fromPrograms,
+		     reduce(qs = [], fp IN fromPrograms | qs + [(fp)-[:REQUIRES]->(q:Qualification) WHERE NOT q.name IN qs | q.name]) as fromQualifications
+		OPTIONAL MATCH (tp:Program)-[:LEADS_TO]->(to)
+		WHERE tp.deleted_at IS NULL
+		WITH from, to, fromPrograms, fromQualifications, tp ORDER BY tp.name
+		RETURN from.title as from_title,
+		       to.title as to_title,
+		       [fp IN fromPrograms | fp.name] as from_programs,
+		       fromQualifications as from_qualifications,
+		       collect(CASE WHEN tp IS NULL THEN null ELSE {
+		           name: tp.name,
+		           institute: head([(i:Institute)-[:HAS_FACULTY|HAS_DEPARTMENT|OFFERS*1..3]->(tp) | i.name]),
+		           requirements: [(tp)-[:REQUIRES]->(q:Qualification) | q.name],
+		           builds_on: [(pre:Program)-[:IS_PREREQUISITE_FOR]->(tp) WHERE pre IN fromPrograms | pre.name],
+		           leads_to_both: tp IN fromPrograms
+		       } END) as target_programs
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{
+		"from": from,
+		"to":   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query career transition: %w", err)
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read career transition: %w", err)
+	}
+
+	fromTitle, _ := record.Get("from_title")
+	toTitle, _ := record.Get("to_title")
+	if fromTitle == nil {
+		return nil, fmt.Errorf("%w: career %q", ErrEntityNotFound, from)
+	}
+	if toTitle == nil {
+		return nil, fmt.Errorf("%w: career %q", ErrEntityNotFound, to)
+	}
+
+	fromPrograms, _ := record.Get("from_programs")
+	fromQualifications, _ := record.Get("from_qualifications")
+	targets, _ := record.Get("target_programs")
+
+	data := &CareerTransitionData{
+		From:               stringOrEmpty(fromTitle),
+		To:                 stringOrEmpty(toTitle),
+		FromPrograms:       nonNil(toStrings(fromPrograms)),
+		FromQualifications: nonNil(toStrings(fromQualifications)),
+		TargetPrograms:     []TransitionProgram{},
+	}
+	items, _ := targets.([]interface{})
+	for _, item := range items {
+		fields, _ := item.(map[string]interface{})
+		leadsToBoth, _ := fields["leads_to_both"].(bool)
+		data.TargetPrograms = append(data.TargetPrograms, TransitionProgram{
+			Name:         stringOrEmpty(fields["name"]),
+			Institute:    stringOrEmpty(fields["institute"]),
+			Requirements: nonNil(toStrings(fields["requirements"])),
+			BuildsOn:     nonNil(toStrings(fields["builds_on"])),
+			LeadsToBoth:  leadsToBoth,
+		})
+	}
+	return data, nil
+}
@@ -2,8 +2,8 @@ package neo4j
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
 
 	"github.com/mayura-andrew/fastfinder/internal/core/config"
 	"github.com/mayura-andrew/fastfinder/pkg/logger"
@@ -13,13 +13,16 @@ import (
 )
 
 type Client struct {
-	driver neo4j.Driver
-	logger *zap.Logger
+	driver        neo4j.Driver
+	logger        *zap.Logger
+	accessibility accessibilityState
 }
 
 // Domain models for the education knowledge graph
 type Institute struct {
-	Name string `json:"name"`
+	Name    string            `json:"name"`
+	Type    string            `json:"type,omitempty"`
+	Contact *InstituteContact `json:"contact,omitempty"`
 }
 
 type Faculty struct {
@@ -40,6 +43,10 @@ type Qualification struct {
 
 type Career struct {
 	Title string `json:"title"`
+	// DemandIndex rates from 0 to 100 how much the career is in demand. It is
+	// kept outside the graph and attached to career listings by the pathway
+	// service.
+	DemandIndex *float64 `json:"demand_index,omitempty"`
 }
 
 // Path represents a pathway from qualification to program to career
@@ -61,6 +68,24 @@ type ProgramDetails struct {
 	Requirements  []Qualification `json:"requirements"`
 	Prerequisites []Program       `json:"prerequisites"`
 	CareerPaths   []Career        `json:"career_paths"`
+	Info          *ProgramInfo    `json:"info,omitempty"`
+	Provenance    *Provenance     `json:"provenance,omitempty"`
+	// InstituteContact is filled for program details and an institute's programs
+	InstituteContact *InstituteContact `json:"institute_contact,omitempty"`
+	Capacity         *ProgramCapacity  `json:"capacity,omitempty"`
+	Outcomes         *ProgramOutcomes  `json:"outcomes,omitempty"`
+	// LivingCost is filled for program details requested with a home district
+	LivingCost *LivingCostEstimate `json:"living_cost,omitempty"`
+	// RequiresAptitudeTest flags programs admitting students by a test as well
+	// as by their qualifications; the tests are in AptitudeTests
+	RequiresAptitudeTest bool           `json:"requires_aptitude_test"`
+	AptitudeTests        []AptitudeTest `json:"aptitude_tests,omitempty"`
+	// Hops, Level and Rank are filled for programs accessible from a
+	// qualification: the prerequisite steps between them, the program's level as
+	// ProgramLevel gives it, and its place from 1 in the ranking asked for
+	Hops  *int `json:"hops,omitempty"`
+	Level int  `json:"level,omitempty"`
+	Rank  int  `json:"rank,omitempty"`
 }
 
 type Concept struct {
@@ -73,27 +98,13 @@ type Concept struct {
 func NewClient(cfg config.Neo4jConfig) (*Client, error) {
 	logger := logger.MustGetLogger()
 
-	// Configure driver with proper timeouts and connection pooling
-	driver, err := neo4j.NewDriver(
-		cfg.URI,
-		neo4j.BasicAuth(cfg.Username, cfg.Password, ""),
-		func(c *neo4jConfig.Config) {
-			// Connection pool settings
-			c.MaxConnectionPoolSize = 50
-			c.MaxConnectionLifetime = 1 * time.Hour
-			c.ConnectionAcquisitionTimeout = 5 * time.Second
-
-			// Socket connect timeout
-			c.SocketConnectTimeout = 5 * time.Second
-			c.SocketKeepalive = true
-		},
-	)
+	driver, err := newDriver(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
+		return nil, err
 	}
 
 	// Verify connectivity with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.VerifyTimeout)
 	defer cancel()
 
 	if err := driver.VerifyConnectivity(ctx); err != nil {
@@ -103,8 +114,12 @@ func NewClient(cfg config.Neo4jConfig) (*Client, error) {
 
 	logger.Info("Connected to Neo4j",
 		zap.String("uri", cfg.URI),
-		zap.Int("max_pool_size", 50),
-		zap.Duration("connection_timeout", 5*time.Second))
+		zap.String("auth_scheme", cfg.AuthScheme),
+		zap.Bool("aura", IsAura(cfg.URI)),
+		zap.Int("max_pool_size", cfg.MaxPoolSize),
+		zap.Duration("max_connection_lifetime", cfg.MaxConnectionLifetime),
+		zap.Duration("acquisition_timeout", cfg.AcquisitionTimeout),
+		zap.Duration("connection_timeout", cfg.ConnectTimeout))
 
 	return &Client{
 		driver: driver,
@@ -112,128 +127,172 @@ func NewClient(cfg config.Neo4jConfig) (*Client, error) {
 	}, nil
 }
 
+// VerifyConnectivity connects to Neo4j with cfg and authenticates, without
+// creating a client, so configuration can be checked before the server starts
+func VerifyConnectivity(ctx context.Context, cfg config.Neo4jConfig) error {
+	driver, err := newDriver(cfg)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(context.Background())
+
+	if err := driver.VerifyAuthentication(ctx, nil); err != nil {
+		return fmt.Errorf("failed to verify Neo4j connectivity: %w", err)
+	}
+	return nil
+}
+
+// newDriver configures a driver with proper timeouts and connection pooling, the
+// configured authentication scheme and TLS options
+func newDriver(cfg config.Neo4jConfig) (neo4j.Driver, error) {
+	auth, err := authManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var tlsErr error
+	driver, err := neo4j.NewDriver(
+		cfg.URI,
+		auth,
+		func(c *neo4jConfig.Config) {
+			// Connection pool settings
+			c.MaxConnectionPoolSize = cfg.MaxPoolSize
+			c.MaxConnectionLifetime = cfg.MaxConnectionLifetime
+			c.ConnectionAcquisitionTimeout = cfg.AcquisitionTimeout
+			if cfg.LivenessCheckTimeout > 0 {
+				c.ConnectionLivenessCheckTimeout = cfg.LivenessCheckTimeout
+			} else if IsAura(cfg.URI) {
+				c.ConnectionLivenessCheckTimeout = auraLivenessCheck
+			}
+
+			// Socket connect timeout
+			c.SocketConnectTimeout = cfg.ConnectTimeout
+			c.SocketKeepalive = true
+
+			tlsErr = configureTLS(c, cfg)
+		},
+	)
+	if tlsErr != nil {
+		if driver != nil {
+			driver.Close(context.Background())
+		}
+		return nil, tlsErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
+	}
+	return driver, nil
+}
+
 // Close closes the Neo4j driver
 func (c *Client) Close(ctx context.Context) error {
 	return c.driver.Close(ctx)
 }
 
+// instituteRow is a row of the institutes listing
+type instituteRow struct {
+	Name       string         `cypher:"name"`
+	Type       string         `cypher:"type"`
+	Properties map[string]any `cypher:"properties"`
+}
+
 // GetAllInstitutes retrieves all institutes
 func (c *Client) GetAllInstitutes(ctx context.Context) ([]Institute, error) {
 	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
-	result, err := session.Run(ctx, "MATCH (i:Institute) RETURN i.name as name ORDER BY i.name", nil)
+	query := `
+		MATCH (i:Institute)
+		WHERE i.deleted_at IS NULL
+		RETURN i.name as name, ` + instituteTypeExpr + ` as type, properties(i) as properties
+		ORDER BY i.name
+	`
+	result, err := session.Run(ctx, query, map[string]any{"instituteTypes": instituteTypeParams()})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query institutes: %w", err)
 	}
 
 	var institutes []Institute
-	for result.Next(ctx) {
-		record := result.Record()
-		name, _ := record.Get("name")
-		institutes = append(institutes, Institute{
-			Name: name.(string),
-		})
-	}
-
-	if err := result.Err(); err != nil {
+	err = readRecords(ctx, result, func(row instituteRow) error {
+		institutes = append(institutes, row.institute())
+		return nil
+	})
+	if err != nil {
 		return nil, fmt.Errorf("error iterating institutes: %w", err)
 	}
 
 	return institutes, nil
 }
 
+// instituteProgramRow is a row of an institute's program listing
+type instituteProgramRow struct {
+	Program             string         `cypher:"program"`
+	Properties          map[string]any `cypher:"properties"`
+	Faculty             string         `cypher:"faculty"`
+	Department          string         `cypher:"department"`
+	InstituteProperties map[string]any `cypher:"institute_properties"`
+}
+
 // GetProgramsByInstitute retrieves all programs offered by an institute
 func (c *Client) GetProgramsByInstitute(ctx context.Context, instituteName string) ([]ProgramDetails, error) {
-	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
-	defer session.Close(ctx)
-
 	query := `
-		MATCH (i:Institute {name: $instituteName})-[:HAS_FACULTY|OFFERS*]->(p:Program)
+		MATCH (i:Institute {name: $scope})-[:HAS_FACULTY|OFFERS*]->(p:Program)
+		WHERE i.deleted_at IS NULL AND p.deleted_at IS NULL
 		OPTIONAL MATCH (i)-[:HAS_FACULTY]->(f:Faculty)-[:HAS_DEPARTMENT]->(d:Department)-[:OFFERS]->(p)
-		OPTIONAL MATCH (p)-[:REQUIRES]->(q:Qualification)
-		OPTIONAL MATCH (prereq:Program)-[:IS_PREREQUISITE_FOR]->(p)
-		OPTIONAL MATCH (p)-[:LEADS_TO]->(c:Career)
-		RETURN DISTINCT p.name as program, 
-		       f.name as faculty, 
+		RETURN DISTINCT p.name as program,
+		       properties(p) as properties,
+		       f.name as faculty,
 		       d.name as department,
-		       COLLECT(DISTINCT q.name) as requirements,
-		       COLLECT(DISTINCT prereq.name) as prerequisites,
-		       COLLECT(DISTINCT c.title) as careers
+		       properties(i) as institute_properties
 		ORDER BY p.name
 	`
 
-	result, err := session.Run(ctx, query, map[string]interface{}{
-		"instituteName": instituteName,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to query programs: %w", err)
-	}
-
-	var programs []ProgramDetails
-	for result.Next(ctx) {
-		record := result.Record()
-
-		programName, _ := record.Get("program")
-		faculty, _ := record.Get("faculty")
-		department, _ := record.Get("department")
-		requirements, _ := record.Get("requirements")
-		prerequisites, _ := record.Get("prerequisites")
-		careers, _ := record.Get("careers")
-
-		details := ProgramDetails{
-			Name:       programName.(string),
-			Institute:  instituteName,
-			Faculty:    stringOrEmpty(faculty),
-			Department: stringOrEmpty(department),
+	return listPrograms(ctx, c, query, instituteProgramsScope, map[string]any{"scope": instituteName}, func(row instituteProgramRow) ProgramDetails {
+		return ProgramDetails{
+			Name:             row.Program,
+			Institute:        instituteName,
+			Faculty:          row.Faculty,
+			Department:       row.Department,
+			Info:             programInfoFromProperties(row.Properties),
+			InstituteContact: contactFromProperties(row.InstituteProperties),
 		}
+	})
+}
 
-		// Convert requirements
-		if reqList, ok := requirements.([]interface{}); ok {
-			for _, req := range reqList {
-				if reqStr, ok := req.(string); ok && reqStr != "" {
-					details.Requirements = append(details.Requirements, Qualification{Name: reqStr})
-				}
-			}
-		}
-
-		// Convert prerequisites
-		if preqList, ok := prerequisites.([]interface{}); ok {
-			for _, preq := range preqList {
-				if preqStr, ok := preq.(string); ok && preqStr != "" {
-					details.Prerequisites = append(details.Prerequisites, Program{Name: preqStr})
-				}
-			}
-		}
-
-		// Convert careers
-		if careerList, ok := careers.([]interface{}); ok {
-			for _, career := range careerList {
-				if careerStr, ok := career.(string); ok && careerStr != "" {
-					details.CareerPaths = append(details.CareerPaths, Career{Title: careerStr})
-				}
-			}
-		}
-
-		programs = append(programs, details)
-	}
-
-	if err := result.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating programs: %w", err)
+// GetCareerPaths retrieves possible career paths based on qualifications
+func (c *Client) GetCareerPaths(ctx context.Context, qualifications []string) ([]EducationPath, error) {
+	var paths []EducationPath
+	err := c.StreamCareerPaths(ctx, qualifications, func(path EducationPath) error {
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return paths, nil
+}
 
-	return programs, nil
+// careerPathRow is a program open to a set of qualifications
+type careerPathRow struct {
+	Program      string   `cypher:"program"`
+	Institute    string   `cypher:"institute"`
+	Faculty      string   `cypher:"faculty"`
+	Department   string   `cypher:"department"`
+	Requirements []string `cypher:"allRequirements"`
+	Careers      []string `cypher:"careers"`
 }
 
-// GetCareerPaths retrieves possible career paths based on qualifications
-func (c *Client) GetCareerPaths(ctx context.Context, qualifications []string) ([]EducationPath, error) {
+// StreamCareerPaths passes the career paths open to the given qualifications
+// to fn as they are read from the cursor, stopping at the first error fn returns
+func (c *Client) StreamCareerPaths(ctx context.Context, qualifications []string, fn func(EducationPath) error) error {
 	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
 	query := `
 		MATCH (q:Qualification)
-		WHERE q.name IN $qualifications
+		WHERE q.name IN $qualifications AND q.deleted_at IS NULL
 		MATCH (p:Program)-[:REQUIRES]->(q)
+		WHERE p.deleted_at IS NULL
 		OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY|OFFERS*]->(p)
 		OPTIONAL MATCH (f:Faculty)-[:HAS_DEPARTMENT]->(d:Department)-[:OFFERS]->(p)
 		OPTIONAL MATCH (p)-[:LEADS_TO]->(c:Career)
@@ -251,57 +310,36 @@ func (c *Client) GetCareerPaths(ctx context.Context, qualifications []string) ([
 		"qualifications": qualifications,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query career paths: %w", err)
+		return fmt.Errorf("failed to query career paths: %w", err)
 	}
 
-	var paths []EducationPath
-	for result.Next(ctx) {
-		record := result.Record()
-
-		programName, _ := record.Get("program")
-		institute, _ := record.Get("institute")
-		faculty, _ := record.Get("faculty")
-		department, _ := record.Get("department")
-		requirements, _ := record.Get("allRequirements")
-		careers, _ := record.Get("careers")
-
-		path := EducationPath{
-			Institute:  stringOrEmpty(institute),
-			Faculty:    stringOrEmpty(faculty),
-			Department: stringOrEmpty(department),
-		}
-
-		// Add program
-		if progStr, ok := programName.(string); ok {
-			path.Programs = append(path.Programs, Program{Name: progStr})
-		}
-
-		// Convert requirements
-		if reqList, ok := requirements.([]interface{}); ok {
-			for _, req := range reqList {
-				if reqStr, ok := req.(string); ok && reqStr != "" {
-					path.Qualifications = append(path.Qualifications, Qualification{Name: reqStr})
-				}
-			}
-		}
-
-		// Convert careers
-		if careerList, ok := careers.([]interface{}); ok {
-			for _, career := range careerList {
-				if careerStr, ok := career.(string); ok && careerStr != "" {
-					path.Careers = append(path.Careers, Career{Title: careerStr})
-				}
-			}
-		}
-
-		paths = append(paths, path)
+	err = readRecords(ctx, result, func(row careerPathRow) error {
+		return fn(EducationPath{
+			Programs:       []Program{{Name: row.Program}},
+			Qualifications: qualificationsNamed(row.Requirements),
+			Careers:        careersTitled(row.Careers),
+			Institute:      row.Institute,
+			Faculty:        row.Faculty,
+			Department:     row.Department,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error iterating career paths: %w", err)
 	}
 
-	if err := result.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating career paths: %w", err)
-	}
+	return nil
+}
 
-	return paths, nil
+// programDetailsRow is the row describing a single program
+type programDetailsRow struct {
+	Institute           string         `cypher:"institute"`
+	Faculty             string         `cypher:"faculty"`
+	Department          string         `cypher:"department"`
+	Requirements        []string       `cypher:"requirements"`
+	Prerequisites       []string       `cypher:"prerequisites"`
+	Careers             []string       `cypher:"careers"`
+	Properties          map[string]any `cypher:"properties"`
+	InstituteProperties map[string]any `cypher:"institute_properties"`
 }
 
 // GetProgramDetails retrieves detailed information about a specific program
@@ -311,6 +349,7 @@ func (c *Client) GetProgramDetails(ctx context.Context, programName string) (*Pr
 
 	query := `
 		MATCH (p:Program {name: $programName})
+		WHERE p.deleted_at IS NULL
 		OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY|OFFERS*]->(p)
 		OPTIONAL MATCH (f:Faculty)-[:HAS_DEPARTMENT]->(d:Department)-[:OFFERS]->(p)
 		OPTIONAL MATCH (p)-[:REQUIRES]->(q:Qualification)
@@ -322,7 +361,9 @@ func (c *Client) GetProgramDetails(ctx context.Context, programName string) (*Pr
 		       d.name as department,
 		       COLLECT(DISTINCT q.name) as requirements,
 		       COLLECT(DISTINCT prereq.name) as prerequisites,
-		       COLLECT(DISTINCT c.title) as careers
+		       COLLECT(DISTINCT c.title) as careers,
+		       properties(p) as properties,
+		       properties(i) as institute_properties
 	`
 
 	result, err := session.Run(ctx, query, map[string]interface{}{
@@ -336,71 +377,48 @@ func (c *Client) GetProgramDetails(ctx context.Context, programName string) (*Pr
 		return nil, fmt.Errorf("program not found: %s", programName)
 	}
 
-	record := result.Record()
-	institute, _ := record.Get("institute")
-	faculty, _ := record.Get("faculty")
-	department, _ := record.Get("department")
-	requirements, _ := record.Get("requirements")
-	prerequisites, _ := record.Get("prerequisites")
-	careers, _ := record.Get("careers")
-
-	details := &ProgramDetails{
-		Name:       programName,
-		Institute:  stringOrEmpty(institute),
-		Faculty:    stringOrEmpty(faculty),
-		Department: stringOrEmpty(department),
-	}
-
-	// Convert requirements
-	if reqList, ok := requirements.([]interface{}); ok {
-		for _, req := range reqList {
-			if reqStr, ok := req.(string); ok && reqStr != "" {
-				details.Requirements = append(details.Requirements, Qualification{Name: reqStr})
-			}
-		}
-	}
-
-	// Convert prerequisites
-	if preqList, ok := prerequisites.([]interface{}); ok {
-		for _, preq := range preqList {
-			if preqStr, ok := preq.(string); ok && preqStr != "" {
-				details.Prerequisites = append(details.Prerequisites, Program{Name: preqStr})
-			}
-		}
+	row, err := mapRecord[programDetailsRow](result.Record())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read program details: %w", err)
 	}
 
-	// Convert careers
-	if careerList, ok := careers.([]interface{}); ok {
-		for _, career := range careerList {
-			if careerStr, ok := career.(string); ok && careerStr != "" {
-				details.CareerPaths = append(details.CareerPaths, Career{Title: careerStr})
-			}
-		}
+	details := &ProgramDetails{
+		Name:             programName,
+		Institute:        row.Institute,
+		Faculty:          row.Faculty,
+		Department:       row.Department,
+		Requirements:     qualificationsNamed(row.Requirements),
+		Prerequisites:    programsNamed(row.Prerequisites),
+		CareerPaths:      careersTitled(row.Careers),
+		Info:             programInfoFromProperties(row.Properties),
+		Provenance:       provenanceFromProperties(row.Properties),
+		InstituteContact: contactFromProperties(row.InstituteProperties),
 	}
 
 	return details, nil
 }
 
+// careerRow is a row of the careers listing
+type careerRow struct {
+	Title string `cypher:"title"`
+}
+
 // GetAllCareers retrieves all available careers
 func (c *Client) GetAllCareers(ctx context.Context) ([]Career, error) {
 	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
-	result, err := session.Run(ctx, "MATCH (c:Career) RETURN c.title as title ORDER BY c.title", nil)
+	result, err := session.Run(ctx, "MATCH (c:Career) WHERE c.deleted_at IS NULL RETURN c.title as title ORDER BY c.title", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query careers: %w", err)
 	}
 
 	var careers []Career
-	for result.Next(ctx) {
-		record := result.Record()
-		title, _ := record.Get("title")
-		careers = append(careers, Career{
-			Title: title.(string),
-		})
-	}
-
-	if err := result.Err(); err != nil {
+	err = readRecords(ctx, result, func(row careerRow) error {
+		careers = append(careers, Career{Title: row.Title})
+		return nil
+	})
+	if err != nil {
 		return nil, fmt.Errorf("error iterating careers: %w", err)
 	}
 
@@ -409,11 +427,36 @@ func (c *Client) GetAllCareers(ctx context.Context) ([]Career, error) {
 
 // GetPathwayToCareer finds educational pathways to reach a specific career
 func (c *Client) GetPathwayToCareer(ctx context.Context, careerTitle string) ([]EducationPath, error) {
+	var paths []EducationPath
+	err := c.StreamPathwayToCareer(ctx, careerTitle, func(path EducationPath) error {
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// careerPathwayRow is a program leading to a career
+type careerPathwayRow struct {
+	Program       string   `cypher:"program"`
+	Institute     string   `cypher:"institute"`
+	Faculty       string   `cypher:"faculty"`
+	Department    string   `cypher:"department"`
+	Requirements  []string `cypher:"requirements"`
+	Prerequisites []string `cypher:"prerequisites"`
+}
+
+// StreamPathwayToCareer passes the educational pathways leading to a career to
+// fn as they are read from the cursor, stopping at the first error fn returns
+func (c *Client) StreamPathwayToCareer(ctx context.Context, careerTitle string, fn func(EducationPath) error) error {
 	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
 	query := `
 		MATCH (c:Career {title: $careerTitle})<-[:LEADS_TO]-(p:Program)
+		WHERE c.deleted_at IS NULL AND p.deleted_at IS NULL
 		OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY|OFFERS*]->(p)
 		OPTIONAL MATCH (f:Faculty)-[:HAS_DEPARTMENT]->(d:Department)-[:OFFERS]->(p)
 		OPTIONAL MATCH (p)-[:REQUIRES]->(q:Qualification)
@@ -431,82 +474,51 @@ func (c *Client) GetPathwayToCareer(ctx context.Context, careerTitle string) ([]
 		"careerTitle": careerTitle,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query career pathways: %w", err)
+		return fmt.Errorf("failed to query career pathways: %w", err)
 	}
 
-	var paths []EducationPath
-	for result.Next(ctx) {
-		record := result.Record()
-
-		programName, _ := record.Get("program")
-		institute, _ := record.Get("institute")
-		faculty, _ := record.Get("faculty")
-		department, _ := record.Get("department")
-		requirements, _ := record.Get("requirements")
-		prerequisites, _ := record.Get("prerequisites")
-
-		path := EducationPath{
-			Institute:  stringOrEmpty(institute),
-			Faculty:    stringOrEmpty(faculty),
-			Department: stringOrEmpty(department),
-			Careers:    []Career{{Title: careerTitle}},
-		}
-
-		// Add program
-		if progStr, ok := programName.(string); ok {
-			path.Programs = append(path.Programs, Program{Name: progStr})
-		}
-
-		// Add prerequisites as programs too
-		if preqList, ok := prerequisites.([]interface{}); ok {
-			for _, preq := range preqList {
-				if preqStr, ok := preq.(string); ok && preqStr != "" {
-					path.Programs = append(path.Programs, Program{Name: preqStr})
-				}
-			}
-		}
-
-		// Convert requirements
-		if reqList, ok := requirements.([]interface{}); ok {
-			for _, req := range reqList {
-				if reqStr, ok := req.(string); ok && reqStr != "" {
-					path.Qualifications = append(path.Qualifications, Qualification{Name: reqStr})
-				}
-			}
-		}
-
-		paths = append(paths, path)
+	err = readRecords(ctx, result, func(row careerPathwayRow) error {
+		// Prerequisites are listed as programs of the path too
+		return fn(EducationPath{
+			Programs:       append([]Program{{Name: row.Program}}, programsNamed(row.Prerequisites)...),
+			Qualifications: qualificationsNamed(row.Requirements),
+			Careers:        []Career{{Title: careerTitle}},
+			Institute:      row.Institute,
+			Faculty:        row.Faculty,
+			Department:     row.Department,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error iterating career pathways: %w", err)
 	}
 
-	if err := result.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating career pathways: %w", err)
-	}
+	return nil
+}
 
-	return paths, nil
+// programRow is a program with where it is offered
+type programRow struct {
+	Program    string         `cypher:"program"`
+	Properties map[string]any `cypher:"properties"`
+	Institute  string         `cypher:"institute"`
+	Faculty    string         `cypher:"faculty"`
+	Department string         `cypher:"department"`
 }
 
 // GetCompletePathway retrieves a complete educational pathway showing all levels
 // from qualifications -> prerequisite programs -> degree programs -> careers
 func (c *Client) GetCompletePathway(ctx context.Context, department string) ([]ProgramDetails, error) {
-	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
-	defer session.Close(ctx)
-
-	// Query to get all programs in a department including prerequisites
+	// Query to get all programs in a department, entry level first
 	query := `
-		MATCH (d:Department {name: $department})-[:OFFERS]->(p:Program)
-		OPTIONAL MATCH (p)-[:REQUIRES]->(q:Qualification)
-		OPTIONAL MATCH (prereq:Program)-[:IS_PREREQUISITE_FOR]->(p)
-		OPTIONAL MATCH (p)-[:LEADS_TO]->(c:Career)
+		MATCH (d:Department {name: $scope})-[:OFFERS]->(p:Program)
+		WHERE d.deleted_at IS NULL AND p.deleted_at IS NULL
 		OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY]->(f:Faculty)-[:HAS_DEPARTMENT]->(d)
 		RETURN DISTINCT p.name as program,
+		       properties(p) as properties,
 		       i.name as institute,
 		       f.name as faculty,
-		       d.name as department,
-		       COLLECT(DISTINCT q.name) as requirements,
-		       COLLECT(DISTINCT prereq.name) as prerequisites,
-		       COLLECT(DISTINCT c.title) as careers
-		ORDER BY 
-		  CASE 
+		       d.name as department
+		ORDER BY
+		  CASE
 		    WHEN p.name CONTAINS 'NVQ' THEN 1
 		    WHEN p.name CONTAINS 'Certificate' THEN 2
 		    WHEN p.name CONTAINS 'Bachelor' THEN 3
@@ -514,208 +526,18 @@ func (c *Client) GetCompletePathway(ctx context.Context, department string) ([]P
 		  END
 	`
 
-	result, err := session.Run(ctx, query, map[string]interface{}{
-		"department": department,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to query complete pathway: %w", err)
-	}
-
-	var programs []ProgramDetails
-	for result.Next(ctx) {
-		record := result.Record()
-
-		programName, _ := record.Get("program")
-		institute, _ := record.Get("institute")
-		faculty, _ := record.Get("faculty")
-		dept, _ := record.Get("department")
-		requirements, _ := record.Get("requirements")
-		prerequisites, _ := record.Get("prerequisites")
-		careers, _ := record.Get("careers")
-
-		details := ProgramDetails{
-			Name:       programName.(string),
-			Institute:  stringOrEmpty(institute),
-			Faculty:    stringOrEmpty(faculty),
-			Department: stringOrEmpty(dept),
-		}
-
-		// Convert requirements
-		if reqList, ok := requirements.([]interface{}); ok {
-			for _, req := range reqList {
-				if reqStr, ok := req.(string); ok && reqStr != "" {
-					details.Requirements = append(details.Requirements, Qualification{Name: reqStr})
-				}
-			}
-		}
-
-		// Convert prerequisites
-		if preqList, ok := prerequisites.([]interface{}); ok {
-			for _, preq := range preqList {
-				if preqStr, ok := preq.(string); ok && preqStr != "" {
-					details.Prerequisites = append(details.Prerequisites, Program{Name: preqStr})
-				}
-			}
+	programs, err := listPrograms(ctx, c, query, departmentProgramsScope, map[string]any{"scope": department}, func(row programRow) ProgramDetails {
+		return ProgramDetails{
+			Name:       row.Program,
+			Institute:  row.Institute,
+			Faculty:    row.Faculty,
+			Department: row.Department,
+			Info:       programInfoFromProperties(row.Properties),
 		}
-
-		// Convert careers
-		if careerList, ok := careers.([]interface{}); ok {
-			for _, career := range careerList {
-				if careerStr, ok := career.(string); ok && careerStr != "" {
-					details.CareerPaths = append(details.CareerPaths, Career{Title: careerStr})
-				}
-			}
-		}
-
-		programs = append(programs, details)
-	}
-
-	if err := result.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating complete pathway: %w", err)
-	}
-
-	return programs, nil
-}
-
-// GetPathwayByQualification retrieves programs accessible from a specific qualification level
-func (c *Client) GetPathwayByQualification(ctx context.Context, department string, qualification string) ([]ProgramDetails, error) {
-	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
-	defer session.Close(ctx)
-
-	// This query finds all programs accessible from the given qualification
-	// Strategy:
-	// 1. Find programs that directly require the qualification
-	// 2. Find programs reachable through prerequisite chains
-	// 3. Order by educational progression
-	query := `
-		// Find the starting qualification
-		MATCH (startQual:Qualification {name: $qualification})
-		
-		// Find departments that match the interest (e.g., "Engineering" matches "Civil Engineering")
-		// and their offered programs
-		MATCH (d:Department)-[:OFFERS]->(p:Program)
-		WHERE d.name CONTAINS $department
-		  AND (
-		    // Check if program is accessible from the qualification
-		    EXISTS {
-		      MATCH (p)-[:REQUIRES]->(startQual)
-		    }
-		    OR EXISTS {
-		      // Via prerequisite chain
-		      MATCH (startProg:Program)-[:REQUIRES]->(startQual)
-		      MATCH path = (startProg)-[:IS_PREREQUISITE_FOR*1..]->(p)
-		    }
-		    OR EXISTS {
-		      // Via alternative qualification that's equivalent
-		      MATCH (p)-[:REQUIRES]->(altQual:Qualification)
-		      MATCH (bridgeProg:Program)-[:REQUIRES]->(startQual)
-		      MATCH (bridgeProg)-[:IS_PREREQUISITE_FOR*0..]->(p)
-		    }
-		  )
-		
-		// Get institute and faculty info
-		OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY]->(f:Faculty)-[:HAS_DEPARTMENT]->(d)
-		
-		// Get all requirements for this program
-		OPTIONAL MATCH (p)-[:REQUIRES]->(q:Qualification)
-		
-		// Get prerequisites
-		OPTIONAL MATCH (prereq:Program)-[:IS_PREREQUISITE_FOR]->(p)
-		
-		// Get career paths
-		OPTIONAL MATCH (p)-[:LEADS_TO]->(c:Career)
-		
-		// Calculate path distance from starting qualification
-		OPTIONAL MATCH shortestPath = shortestPath((startProg:Program)-[:IS_PREREQUISITE_FOR*0..]->(p))
-		WHERE (startProg)-[:REQUIRES]->(startQual) OR (p)-[:REQUIRES]->(startQual)
-		
-		WITH DISTINCT p, i, f, d, 
-		     COLLECT(DISTINCT q.name) as requirements,
-		     COLLECT(DISTINCT prereq.name) as prerequisites,
-		     COLLECT(DISTINCT c.title) as careers,
-		     COALESCE(LENGTH(shortestPath), 0) as pathDistance
-		
-		RETURN p.name as program,
-		       i.name as institute,
-		       f.name as faculty,
-		       d.name as department,
-		       requirements,
-		       prerequisites,
-		       careers
-		ORDER BY 
-		  pathDistance ASC,
-		  CASE 
-		    WHEN p.name CONTAINS 'NVQ Level 3' THEN 1
-		    WHEN p.name CONTAINS 'NVQ Level 4' THEN 2
-		    WHEN p.name CONTAINS 'Advanced Certificate' THEN 3
-		    WHEN p.name CONTAINS 'Certificate' THEN 4
-		    WHEN p.name CONTAINS 'Bachelor' THEN 5
-		    WHEN p.name CONTAINS 'BSc' THEN 6
-		    ELSE 7
-		  END
-	`
-
-	result, err := session.Run(ctx, query, map[string]interface{}{
-		"department":    department,
-		"qualification": qualification,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query pathway by qualification: %w", err)
-	}
-
-	var programs []ProgramDetails
-	for result.Next(ctx) {
-		record := result.Record()
-
-		programName, _ := record.Get("program")
-		institute, _ := record.Get("institute")
-		faculty, _ := record.Get("faculty")
-		dept, _ := record.Get("department")
-		requirements, _ := record.Get("requirements")
-		prerequisites, _ := record.Get("prerequisites")
-		careers, _ := record.Get("careers")
-
-		details := ProgramDetails{
-			Name:       programName.(string),
-			Institute:  stringOrEmpty(institute),
-			Faculty:    stringOrEmpty(faculty),
-			Department: stringOrEmpty(dept),
-		}
-
-		// Convert requirements
-		if reqList, ok := requirements.([]interface{}); ok {
-			for _, req := range reqList {
-				if reqStr, ok := req.(string); ok && reqStr != "" {
-					details.Requirements = append(details.Requirements, Qualification{Name: reqStr})
-				}
-			}
-		}
-
-		// Convert prerequisites
-		if preqList, ok := prerequisites.([]interface{}); ok {
-			for _, preq := range preqList {
-				if preqStr, ok := preq.(string); ok && preqStr != "" {
-					details.Prerequisites = append(details.Prerequisites, Program{Name: preqStr})
-				}
-			}
-		}
-
-		// Convert careers
-		if careerList, ok := careers.([]interface{}); ok {
-			for _, career := range careerList {
-				if careerStr, ok := career.(string); ok && careerStr != "" {
-					details.CareerPaths = append(details.CareerPaths, Career{Title: careerStr})
-				}
-			}
-		}
-
-		programs = append(programs, details)
-	}
-
-	if err := result.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating pathway by qualification: %w", err)
+		return nil, fmt.Errorf("failed to query complete pathway: %w", err)
 	}
-
 	return programs, nil
 }
 
@@ -725,6 +547,22 @@ func (c *Client) IsHealthy(ctx context.Context) bool {
 	return err == nil
 }
 
+// IsUnavailable reports whether err means the database could not be reached or
+// did not answer in time, as opposed to rejecting a query
+func IsUnavailable(err error) bool {
+	var connectivity *neo4j.ConnectivityError
+	if errors.As(err, &connectivity) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	// The driver only recognizes pool timeouts and transient server errors unwrapped
+	for ; err != nil; err = errors.Unwrap(err) {
+		if neo4j.IsRetryable(err) {
+			return true
+		}
+	}
+	return false
+}
+
 // Helper function to safely convert interface to string
 func stringOrEmpty(val interface{}) string {
 	if val == nil {
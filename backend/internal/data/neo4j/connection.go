@@ -0,0 +1,108 @@
+package neo4j
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	neo4jAuth "github.com/neo4j/neo4j-go-driver/v6/neo4j/auth"
+	neo4jConfig "github.com/neo4j/neo4j-go-driver/v6/neo4j/config"
+)
+
+// Authentication schemes of NEO4J_AUTH_SCHEME
+const (
+	AuthBasic    = "basic"
+	AuthBearer   = "bearer"
+	AuthKerberos = "kerberos"
+	AuthNone     = "none"
+)
+
+// auraLivenessCheck is how long a pooled connection to Aura may sit idle before
+// it is checked, unless configured; Aura's load balancers drop idle connections
+const auraLivenessCheck = 2 * time.Minute
+
+// IsAura reports whether a URI points at a Neo4j Aura instance
+func IsAura(uri string) bool {
+	parsed, err := url.Parse(uri)
+	return err == nil && strings.HasSuffix(parsed.Hostname(), ".databases.neo4j.io")
+}
+
+// authManager returns the credentials of the configured authentication scheme.
+// Bearer and Kerberos tokens kept in a file are read again whenever the server
+// rejects the current one, so a sidecar renewing them keeps the driver connected.
+func authManager(cfg config.Neo4jConfig) (neo4jAuth.TokenManager, error) {
+	switch cfg.AuthScheme {
+	case AuthBasic, "":
+		return neo4j.BasicAuth(cfg.Username, cfg.Password, cfg.AuthRealm), nil
+	case AuthNone:
+		return neo4j.NoAuth(), nil
+	case AuthBearer, AuthKerberos:
+		token := func(credentials string) neo4j.AuthToken {
+			if cfg.AuthScheme == AuthKerberos {
+				return neo4j.KerberosAuth(credentials)
+			}
+			return neo4j.BearerAuth(credentials)
+		}
+		if cfg.AuthTokenFile == "" {
+			return token(cfg.AuthToken), nil
+		}
+		if _, err := readToken(cfg.AuthTokenFile); err != nil {
+			return nil, err
+		}
+		return neo4jAuth.BearerTokenManager(func(context.Context) (neo4j.AuthToken, *time.Time, error) {
+			credentials, err := readToken(cfg.AuthTokenFile)
+			if err != nil {
+				return neo4j.AuthToken{}, nil, err
+			}
+			return token(credentials), nil, nil
+		}), nil
+	}
+	return nil, fmt.Errorf("unsupported Neo4j auth scheme %q", cfg.AuthScheme)
+}
+
+func readToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Neo4j auth token: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("Neo4j auth token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// configureTLS trusts a private CA and presents a client certificate when
+// configured. The driver only encrypts for the +s and +ssc URI schemes.
+func configureTLS(c *neo4jConfig.Config, cfg config.Neo4jConfig) error {
+	if cfg.TLSCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read Neo4j CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("Neo4j CA file %s contains no PEM certificates", cfg.TLSCAFile)
+		}
+		c.TlsConfig = &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}
+	}
+
+	if cfg.TLSCertFile != "" {
+		provider, err := neo4jAuth.NewStaticClientCertificateProvider(neo4jAuth.ClientCertificate{
+			CertFile: cfg.TLSCertFile,
+			KeyFile:  cfg.TLSKeyFile,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load Neo4j client certificate: %w", err)
+		}
+		c.ClientCertificateProvider = provider
+	}
+	return nil
+}
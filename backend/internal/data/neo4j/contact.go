@@ -0,0 +1,67 @@
+package neo4j
+
+import (
+	"context"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// InstituteContact is how students can reach an institute and its admissions
+// office. The fields are stored as properties of the Institute node.
+type InstituteContact struct {
+	Address          string `json:"address,omitempty"`
+	District         string `json:"district,omitempty"`
+	Phone            string `json:"phone,omitempty"`
+	Email            string `json:"email,omitempty"`
+	Website          string `json:"website,omitempty"`
+	AdmissionsOffice string `json:"admissions_office,omitempty"`
+}
+
+// properties returns the contact as node properties. Empty fields map to nil so
+// that saving a contact removes the details it leaves out.
+func (ic InstituteContact) properties() map[string]any {
+	value := func(s string) any {
+		if s == "" {
+			return nil
+		}
+		return s
+	}
+	return map[string]any{
+		"address":           value(ic.Address),
+		"district":          value(ic.District),
+		"phone":             value(ic.Phone),
+		"email":             value(ic.Email),
+		"website":           value(ic.Website),
+		"admissions_office": value(ic.AdmissionsOffice),
+	}
+}
+
+// contactFromProperties reads contact details from an institute's properties,
+// returning nil when it has none
+func contactFromProperties(value any) *InstituteContact {
+	properties, _ := value.(map[string]any)
+	ic := &InstituteContact{
+		Address:          stringOrEmpty(properties["address"]),
+		District:         stringOrEmpty(properties["district"]),
+		Phone:            stringOrEmpty(properties["phone"]),
+		Email:            stringOrEmpty(properties["email"]),
+		Website:          stringOrEmpty(properties["website"]),
+		AdmissionsOffice: stringOrEmpty(properties["admissions_office"]),
+	}
+	if *ic == (InstituteContact{}) {
+		return nil
+	}
+	return ic
+}
+
+// setContact replaces the contact details of an institute, moving it to the
+// District node of its new district
+func setContact(ctx context.Context, tx neo4j.ManagedTransaction, name string, ic *InstituteContact) error {
+	if ic == nil {
+		return nil
+	}
+	params := map[string]any{"name": name, "contact": ic.properties()}
+	return runAll(ctx, tx, params,
+		"MATCH (i:Institute {name: $name}) SET i += $contact",
+		"MATCH (i:Institute {name: $name})"+locateInstitutes)
+}
@@ -0,0 +1,177 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/district"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// DistrictCount is how many institutes are located in a district and how many
+// programs they offer. Institutes without a district are counted under an empty
+// district.
+type DistrictCount struct {
+	District   string `cypher:"district"`
+	Institutes int    `cypher:"institutes"`
+	Programs   int    `cypher:"programs"`
+}
+
+// GetDistrictCounts counts institutes and the programs they offer per district
+func (c *Client) GetDistrictCounts(ctx context.Context) ([]DistrictCount, error) {
+	query := `
+		MATCH (i:Institute)
+		WHERE i.deleted_at IS NULL
+		OPTIONAL MATCH (i)-[:HAS_FACULTY|HAS_DEPARTMENT|OFFERS*1..3]->(p:Program)
+		WHERE p.deleted_at IS NULL
+		RETURN coalesce(i.district, '') as district,
+		       count(DISTINCT i) as institutes,
+		       count(DISTINCT p) as programs
+		ORDER BY district
+	`
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query district counts: %w", err)
+	}
+
+	var counts []DistrictCount
+	if err := readRecords(ctx, result, func(row DistrictCount) error {
+		counts = append(counts, row)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("error iterating district counts: %w", err)
+	}
+	return counts, nil
+}
+
+// locateInstitutes links institutes i to the District node named by their
+// district property, replacing the link they had. Institutes without a known
+// district are left unlinked.
+const locateInstitutes = `
+	OPTIONAL MATCH (i)-[r:LOCATED_IN]->(:District)
+	DELETE r
+	WITH DISTINCT i
+	MATCH (d:District) WHERE toLower(d.name) = toLower(i.district)
+	MERGE (i)-[:LOCATED_IN]->(d)
+`
+
+// linkDistricts creates a District node for every district, part of its
+// Province node, and links every institute to the district it is located in
+func (c *Client) linkDistricts(ctx context.Context) error {
+	districts := make([]map[string]any, len(district.Names))
+	for i, name := range district.Names {
+		districts[i] = map[string]any{"name": name, "province": district.ProvinceOf(name)}
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return nil, runAll(ctx, tx, map[string]any{"districts": districts},
+			`UNWIND $districts AS row
+			 MERGE (p:Province {name: row.province})
+			 MERGE (d:District {name: row.name})
+			 MERGE (d)-[:PART_OF]->(p)`,
+			`MATCH (i:Institute)`+locateInstitutes)
+	})
+	return err
+}
+
+// districtProgramRow is a program offered in a district, with the institute
+// offering it
+type districtProgramRow struct {
+	Program             string         `cypher:"program"`
+	Properties          map[string]any `cypher:"properties"`
+	Institute           string         `cypher:"institute"`
+	Faculty             string         `cypher:"faculty"`
+	Department          string         `cypher:"department"`
+	InstituteProperties map[string]any `cypher:"institute_properties"`
+}
+
+// ListProgramsByDistrict returns a page of the programs offered by institutes
+// located in a district matching a filter, by name, skipping the first offset
+func (c *Client) ListProgramsByDistrict(ctx context.Context, districtName string, filter ProgramFilter, offset, limit int) ([]ProgramDetails, error) {
+	// The page, as the scope of both the listing and its relationship queries
+	scopeMatch := `
+		MATCH (:District {name: $scope})<-[:LOCATED_IN]-(i:Institute)-[:HAS_FACULTY|OFFERS*]->(p:Program)
+		OPTIONAL MATCH (i)-[:HAS_FACULTY]->(f:Faculty)-[:HAS_DEPARTMENT]->(d:Department)-[:OFFERS]->(p)
+		WITH DISTINCT i, p, f, d
+		WHERE i.deleted_at IS NULL AND p.deleted_at IS NULL
+		  AND ($query = '' OR toLower(p.name) CONTAINS $query)
+		  AND ($faculty = '' OR toLower(f.name) = $faculty)
+		  AND ($department = '' OR toLower(d.name) = $department)
+		  AND (NOT $workingStudent OR p.delivery_mode IN $workingStudentModes OR size(coalesce(p.schedule, [])) > 0)
+		WITH i, p, f, d ORDER BY p.name SKIP $offset LIMIT $limit
+	`
+	query := scopeMatch + `
+		RETURN p.name as program,
+		       properties(p) as properties,
+		       i.name as institute,
+		       f.name as faculty,
+		       d.name as department,
+		       properties(i) as institute_properties
+		ORDER BY p.name
+	`
+	params := map[string]any{
+		"scope":      districtName,
+		"query":      filterValue(filter.Query),
+		"faculty":    filterValue(filter.Faculty),
+		"department": filterValue(filter.Department),
+		"offset":     offset,
+		"limit":      limit,
+
+		"workingStudent":      filter.WorkingStudent,
+		"workingStudentModes": workingStudentModes,
+	}
+
+	programs, err := listPrograms(ctx, c, query, scopeMatch, params, func(row districtProgramRow) ProgramDetails {
+		return ProgramDetails{
+			Name:             row.Program,
+			Institute:        row.Institute,
+			Faculty:          row.Faculty,
+			Department:       row.Department,
+			Info:             programInfoFromProperties(row.Properties),
+			InstituteContact: contactFromProperties(row.InstituteProperties),
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if programs == nil {
+		programs = []ProgramDetails{}
+	}
+	return programs, nil
+}
+
+// InstitutesInDistrict returns the names of the institutes located in a
+// district
+func (c *Client) InstitutesInDistrict(ctx context.Context, districtName string) ([]string, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		MATCH (:District {name: $district})<-[:LOCATED_IN]-(i:Institute)
+		WHERE i.deleted_at IS NULL
+		RETURN i.name as name
+		ORDER BY name
+	`, map[string]any{"district": districtName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query institutes in district: %w", err)
+	}
+
+	names := []string{}
+	for result.Next(ctx) {
+		name, _ := result.Record().Get("name")
+		if s := stringOrEmpty(name); s != "" {
+			names = append(names, s)
+		}
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating institutes in district: %w", err)
+	}
+	return names, nil
+}
@@ -0,0 +1,99 @@
+package neo4j
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// testClient connects to the Neo4j database named by NEO4J_TEST_URI, with
+// NEO4J_TEST_USERNAME and NEO4J_TEST_PASSWORD, and skips the test or benchmark
+// when it is not set. Fixtures wipe the database, so it must be a throwaway one.
+func testClient(tb testing.TB) *Client {
+	tb.Helper()
+
+	uri := os.Getenv("NEO4J_TEST_URI")
+	if uri == "" {
+		tb.Skip("NEO4J_TEST_URI is not set")
+	}
+	client, err := NewClient(config.Neo4jConfig{
+		URI:                   uri,
+		Username:              os.Getenv("NEO4J_TEST_USERNAME"),
+		Password:              os.Getenv("NEO4J_TEST_PASSWORD"),
+		AuthScheme:            "basic",
+		MaxPoolSize:           50,
+		MaxConnectionLifetime: time.Hour,
+		AcquisitionTimeout:    30 * time.Second,
+		ConnectTimeout:        10 * time.Second,
+		VerifyTimeout:         10 * time.Second,
+	})
+	if err != nil {
+		tb.Fatalf("failed to connect to the test database: %v", err)
+	}
+	tb.Cleanup(func() { client.Close(context.Background()) })
+	return client
+}
+
+// fixtureProgram is a program of a test graph, offered by department of the
+// one test institute and faculty
+type fixtureProgram struct {
+	Name          string
+	Department    string
+	Requires      []string // qualifications
+	Prerequisites []string // programs
+	Careers       []string
+}
+
+// loadFixture replaces everything in the test database with programs
+func loadFixture(tb testing.TB, c *Client, programs []fixtureProgram) {
+	tb.Helper()
+	ctx := context.Background()
+
+	rows := make([]map[string]any, 0, len(programs))
+	for _, program := range programs {
+		rows = append(rows, map[string]any{
+			"name":          program.Name,
+			"department":    program.Department,
+			"requires":      nonNil(program.Requires),
+			"prerequisites": nonNil(program.Prerequisites),
+			"careers":       nonNil(program.Careers),
+		})
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		for _, query := range []string{
+			`MATCH (n) DETACH DELETE n`,
+			`CREATE (:Institute {name: 'Test Institute'})-[:HAS_FACULTY]->(:Faculty {name: 'Test Faculty'})`,
+			`UNWIND $programs as row
+			 MATCH (f:Faculty {name: 'Test Faculty'})
+			 MERGE (d:Department {name: row.department})
+			 MERGE (f)-[:HAS_DEPARTMENT]->(d)
+			 MERGE (p:Program {name: row.name})
+			 MERGE (d)-[:OFFERS]->(p)
+			 FOREACH (name IN row.requires |
+			   MERGE (q:Qualification {name: name})
+			   MERGE (p)-[:REQUIRES]->(q))
+			 FOREACH (title IN row.careers |
+			   MERGE (c:Career {title: title})
+			   MERGE (p)-[:LEADS_TO]->(c))`,
+			`UNWIND $programs as row
+			 UNWIND row.prerequisites as prerequisite
+			 MATCH (p:Program {name: row.name}), (pre:Program {name: prerequisite})
+			 MERGE (pre)-[:IS_PREREQUISITE_FOR]->(p)`,
+		} {
+			if _, err := runConsume(ctx, tx, query, map[string]any{"programs": rows}); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		tb.Fatalf("failed to load fixture: %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"go.uber.org/zap"
+)
+
+// ImportProgram is one validated program row of a bulk import. The institute must
+// already exist; faculties, departments, qualifications and careers are created
+// when missing.
+type ImportProgram struct {
+	Institute     string
+	Faculty       string
+	Department    string
+	Program       string
+	Requirements  []string
+	Prerequisites []string
+	Careers       []string
+}
+
+// ExistingNames returns which of the given names exist, and are not deleted,
+// for an entity kind
+func (c *Client) ExistingNames(ctx context.Context, kind string, names []string) (map[string]bool, error) {
+	schema, ok := entitySchemas[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+	}
+
+	existing := make(map[string]bool)
+	if len(names) == 0 {
+		return existing, nil
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf(`
+		MATCH (n:%s)
+		WHERE n.%s IN $names AND n.deleted_at IS NULL
+		RETURN n.%s as name
+	`, schema.Label, schema.Key, schema.Key)
+
+	result, err := session.Run(ctx, query, map[string]interface{}{
+		"names": names,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query existing %s names: %w", kind, err)
+	}
+
+	for result.Next(ctx) {
+		name, _ := result.Record().Get("name")
+		existing[stringOrEmpty(name)] = true
+	}
+
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating existing %s names: %w", kind, err)
+	}
+
+	return existing, nil
+}
+
+// ImportPrograms writes all rows in a single transaction, so either every row is
+// applied or none is. Existing programs have their relationships replaced. Every
+// imported program is stamped with the provenance, as are the faculties,
+// departments, qualifications and careers the import creates.
+func (c *Client) ImportPrograms(ctx context.Context, rows []ImportProgram, provenance Provenance) error {
+	stamp := provenance.properties()
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		// Create programs and their place in the hierarchy first so prerequisites
+		// can refer to programs appearing later in the batch
+		for _, row := range rows {
+			params := map[string]any{
+				"institute":  row.Institute,
+				"faculty":    row.Faculty,
+				"department": row.Department,
+				"program":    row.Program,
+				"provenance": stamp,
+			}
+
+			var queries []string
+			queries = append(queries,
+				`MERGE (n:Program {name: $program}) SET n += $provenance `+stampAddedAt+` `+bumpVersion,
+				`MATCH ()-[r:OFFERS]->(p:Program {name: $program}) DELETE r`)
+			if row.Department != "" {
+				queries = append(queries, `
+					MATCH (i:Institute {name: $institute})
+					MERGE (f:Faculty {name: $faculty})
+					ON CREATE SET f += $provenance, f.version = 1
+					MERGE (i)-[:HAS_FACULTY]->(f)
+					MERGE (d:Department {name: $department})
+					ON CREATE SET d += $provenance, d.version = 1
+					MERGE (f)-[:HAS_DEPARTMENT]->(d)
+					WITH d
+					MATCH (p:Program {name: $program})
+					MERGE (d)-[:OFFERS]->(p)`)
+			} else {
+				queries = append(queries, `
+					MATCH (i:Institute {name: $institute}), (p:Program {name: $program})
+					MERGE (i)-[:OFFERS]->(p)`)
+			}
+
+			if err := runAll(ctx, tx, params, queries...); err != nil {
+				return nil, fmt.Errorf("failed to import program %q: %w", row.Program, err)
+			}
+		}
+
+		for _, row := range rows {
+			params := map[string]any{
+				"program":       row.Program,
+				"requirements":  nonNil(row.Requirements),
+				"prerequisites": nonNil(row.Prerequisites),
+				"careers":       nonNil(row.Careers),
+				"provenance":    stamp,
+			}
+
+			err := runAll(ctx, tx, params,
+				`MATCH (p:Program {name: $program})-[r:REQUIRES]->(:Qualification) DELETE r`,
+				`MATCH (p:Program {name: $program}) UNWIND $requirements AS req MERGE (q:Qualification {name: req}) ON CREATE SET q += $provenance, q.version = 1 MERGE (p)-[:REQUIRES]->(q)`,
+				`MATCH (:Program)-[r:IS_PREREQUISITE_FOR]->(p:Program {name: $program}) DELETE r`,
+				`MATCH (p:Program {name: $program}) UNWIND $prerequisites AS prereq MATCH (pre:Program {name: prereq}) MERGE (pre)-[:IS_PREREQUISITE_FOR]->(p)`,
+				`MATCH (p:Program {name: $program})-[r:LEADS_TO]->(:Career) DELETE r`,
+				`MATCH (p:Program {name: $program}) UNWIND $careers AS title MERGE (c:Career {title: title}) ON CREATE SET c += $provenance, c.version = 1 MERGE (p)-[:LEADS_TO]->(c)`)
+			if err != nil {
+				return nil, fmt.Errorf("failed to link program %q: %w", row.Program, err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("Programs imported", zap.Int("count", len(rows)))
+	return nil
+}
+
+// ListNames returns the names of all entities of a kind that are not deleted
+func (c *Client) ListNames(ctx context.Context, kind string) ([]string, error) {
+	schema, ok := entitySchemas[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("MATCH (n:%s) WHERE n.deleted_at IS NULL RETURN n.%s as name ORDER BY name", schema.Label, schema.Key)
+
+	result, err := session.Run(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s names: %w", kind, err)
+	}
+
+	var names []string
+	for result.Next(ctx) {
+		name, _ := result.Record().Get("name")
+		if s := stringOrEmpty(name); s != "" {
+			names = append(names, s)
+		}
+	}
+
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s names: %w", kind, err)
+	}
+
+	return names, nil
+}
+
+// AddRequirements links a program to existing qualifications, keeping any
+// requirements it already has
+func (c *Client) AddRequirements(ctx context.Context, programName string, qualifications []string) error {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return runConsume(ctx, tx, `
+			MATCH (p:Program {name: $program})
+			UNWIND $qualifications AS qualification
+			MATCH (q:Qualification {name: qualification})
+			MERGE (p)-[:REQUIRES]->(q)
+		`, map[string]any{
+			"program":        programName,
+			"qualifications": qualifications,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add requirements to %q: %w", programName, err)
+	}
+	return nil
+}
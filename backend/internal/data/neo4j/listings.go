@@ -0,0 +1,254 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// Institute types. An institute's type is its type property when set, or else
+// classified from its name.
+const (
+	InstituteTypeUniversity = "university"
+	InstituteTypeTechnical  = "technical"
+	InstituteTypeVocational = "vocational"
+	InstituteTypeOther      = "other"
+)
+
+// instituteTypePatterns classify institutes by name, checked in order. They are
+// matched in both Go and Cypher, so they keep to the syntax the two share.
+var instituteTypePatterns = []struct {
+	Type    string
+	Pattern string
+}{
+	{InstituteTypeUniversity, `(?i).*\buniversit.*`},
+	{InstituteTypeTechnical, `(?i).*\b(technical|technology|technological|polytechnic)\b.*`},
+	{InstituteTypeVocational, `(?i).*\b(vocational|training|vta|naita|tvec)\b.*`},
+}
+
+var instituteTypeRegexps = func() []*regexp.Regexp {
+	regexps := make([]*regexp.Regexp, len(instituteTypePatterns))
+	for i, p := range instituteTypePatterns {
+		regexps[i] = regexp.MustCompile(p.Pattern)
+	}
+	return regexps
+}()
+
+// instituteTypeExpr is the type of institute i, given the patterns as the
+// $instituteTypes parameter
+const instituteTypeExpr = `coalesce(i.type, head([t IN $instituteTypes WHERE i.name =~ t.pattern | t.type]), '` + InstituteTypeOther + `')`
+
+func instituteTypeParams() []map[string]any {
+	params := make([]map[string]any, len(instituteTypePatterns))
+	for i, p := range instituteTypePatterns {
+		params[i] = map[string]any{"type": p.Type, "pattern": p.Pattern}
+	}
+	return params
+}
+
+// IsInstituteType reports whether t is an institute type listings can be
+// filtered by
+func IsInstituteType(t string) bool {
+	switch t {
+	case InstituteTypeUniversity, InstituteTypeTechnical, InstituteTypeVocational, InstituteTypeOther:
+		return true
+	}
+	return false
+}
+
+// InstituteTypeOf classifies an institute without a type property by its name
+func InstituteTypeOf(name string) string {
+	for i, re := range instituteTypeRegexps {
+		if re.MatchString(name) {
+			return instituteTypePatterns[i].Type
+		}
+	}
+	return InstituteTypeOther
+}
+
+// InstituteFilter narrows an institutes listing. Empty fields match everything;
+// Query matches a case-insensitive substring of the name and the others whole
+// values, ignoring case.
+type InstituteFilter struct {
+	Query    string
+	Type     string
+	District string
+}
+
+// CareerFilter narrows a careers listing to careers that programs of a
+// department, or of institutes of a type, lead to. Empty fields match
+// everything.
+type CareerFilter struct {
+	Query         string
+	Department    string
+	InstituteType string
+}
+
+// ProgramFilter narrows a listing of programs. Empty fields match
+// everything; District keeps the programs of institutes located in it, and
+// WorkingStudent the programs that can be completed while employed.
+type ProgramFilter struct {
+	Query          string
+	Faculty        string
+	Department     string
+	District       string
+	WorkingStudent bool
+}
+
+// filterValue is a filter field as compared in Cypher, which lowercases the
+// properties it is compared with
+func filterValue(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+func (row instituteRow) institute() Institute {
+	return Institute{
+		Name:    row.Name,
+		Type:    row.Type,
+		Contact: contactFromProperties(row.Properties),
+	}
+}
+
+// ListInstitutes returns a page of the institutes matching a filter, by name,
+// skipping the first offset
+func (c *Client) ListInstitutes(ctx context.Context, filter InstituteFilter, offset, limit int) ([]Institute, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (i:Institute)
+		WITH i, ` + instituteTypeExpr + ` as type
+		WHERE i.deleted_at IS NULL
+		  AND ($query = '' OR toLower(i.name) CONTAINS $query)
+		  AND ($type = '' OR toLower(type) = $type)
+		  AND ($district = '' OR toLower(i.district) = $district)
+		RETURN i.name as name, type, properties(i) as properties
+		ORDER BY i.name
+		SKIP $offset LIMIT $limit
+	`
+	result, err := session.Run(ctx, query, map[string]any{
+		"instituteTypes": instituteTypeParams(),
+		"query":          filterValue(filter.Query),
+		"type":           filterValue(filter.Type),
+		"district":       filterValue(filter.District),
+		"offset":         offset,
+		"limit":          limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query institutes: %w", err)
+	}
+
+	institutes := []Institute{}
+	err = readRecords(ctx, result, func(row instituteRow) error {
+		institutes = append(institutes, row.institute())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating institutes: %w", err)
+	}
+	return institutes, nil
+}
+
+// ListCareers returns a page of the careers matching a filter, by title,
+// skipping the first offset
+func (c *Client) ListCareers(ctx context.Context, filter CareerFilter, offset, limit int) ([]Career, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (c:Career)
+		WHERE c.deleted_at IS NULL
+		  AND ($query = '' OR toLower(c.title) CONTAINS $query)
+		  AND ($department = '' OR EXISTS {
+		      MATCH (d:Department)-[:OFFERS]->(p:Program)-[:LEADS_TO]->(c)
+		      WHERE p.deleted_at IS NULL AND toLower(d.name) = $department
+		  })
+		  AND ($instituteType = '' OR EXISTS {
+		      MATCH (i:Institute)-[:HAS_FACULTY|HAS_DEPARTMENT|OFFERS*1..3]->(p:Program)-[:LEADS_TO]->(c)
+		      WHERE p.deleted_at IS NULL AND toLower(` + instituteTypeExpr + `) = $instituteType
+		  })
+		RETURN c.title as title
+		ORDER BY c.title
+		SKIP $offset LIMIT $limit
+	`
+	result, err := session.Run(ctx, query, map[string]any{
+		"instituteTypes": instituteTypeParams(),
+		"query":          filterValue(filter.Query),
+		"department":     filterValue(filter.Department),
+		"instituteType":  filterValue(filter.InstituteType),
+		"offset":         offset,
+		"limit":          limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query careers: %w", err)
+	}
+
+	careers := []Career{}
+	err = readRecords(ctx, result, func(row careerRow) error {
+		careers = append(careers, Career{Title: row.Title})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating careers: %w", err)
+	}
+	return careers, nil
+}
+
+// ListProgramsByInstitute returns a page of the programs an institute offers
+// matching a filter, by name, skipping the first offset
+func (c *Client) ListProgramsByInstitute(ctx context.Context, instituteName string, filter ProgramFilter, offset, limit int) ([]ProgramDetails, error) {
+	// The page, as the scope of both the listing and its relationship queries
+	scopeMatch := `
+		MATCH (i:Institute {name: $scope})-[:HAS_FACULTY|OFFERS*]->(p:Program)
+		OPTIONAL MATCH (i)-[:HAS_FACULTY]->(f:Faculty)-[:HAS_DEPARTMENT]->(d:Department)-[:OFFERS]->(p)
+		WITH DISTINCT i, p, f, d
+		WHERE i.deleted_at IS NULL AND p.deleted_at IS NULL
+		  AND ($query = '' OR toLower(p.name) CONTAINS $query)
+		  AND ($faculty = '' OR toLower(f.name) = $faculty)
+		  AND ($department = '' OR toLower(d.name) = $department)
+		  AND ($district = '' OR EXISTS { MATCH (i)-[:LOCATED_IN]->(n:District) WHERE toLower(n.name) = $district })
+		  AND (NOT $workingStudent OR p.delivery_mode IN $workingStudentModes OR size(coalesce(p.schedule, [])) > 0)
+		WITH i, p, f, d ORDER BY p.name SKIP $offset LIMIT $limit
+	`
+	query := scopeMatch + `
+		RETURN p.name as program,
+		       properties(p) as properties,
+		       f.name as faculty,
+		       d.name as department,
+		       properties(i) as institute_properties
+		ORDER BY p.name
+	`
+	params := map[string]any{
+		"scope":      instituteName,
+		"query":      filterValue(filter.Query),
+		"faculty":    filterValue(filter.Faculty),
+		"department": filterValue(filter.Department),
+		"district":   filterValue(filter.District),
+		"offset":     offset,
+		"limit":      limit,
+
+		"workingStudent":      filter.WorkingStudent,
+		"workingStudentModes": workingStudentModes,
+	}
+
+	programs, err := listPrograms(ctx, c, query, scopeMatch, params, func(row instituteProgramRow) ProgramDetails {
+		return ProgramDetails{
+			Name:             row.Program,
+			Institute:        instituteName,
+			Faculty:          row.Faculty,
+			Department:       row.Department,
+			Info:             programInfoFromProperties(row.Properties),
+			InstituteContact: contactFromProperties(row.InstituteProperties),
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if programs == nil {
+		programs = []ProgramDetails{}
+	}
+	return programs, nil
+}
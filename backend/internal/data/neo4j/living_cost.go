@@ -0,0 +1,19 @@
+package neo4j
+
+// LivingCostEstimate is what studying a program costs a student per month beyond
+// fees when it means moving away from their home district. Costs are kept
+// outside the graph and attached to program details by the pathway service.
+type LivingCostEstimate struct {
+	HomeDistrict string `json:"home_district"`
+	District     string `json:"district"` // the institute's district
+	Relocating   bool   `json:"relocating"`
+	Boarding     int    `json:"boarding,omitempty"`
+	Transport    int    `json:"transport,omitempty"`
+	Food         int    `json:"food,omitempty"`
+	// TotalMonthly is the estimated total monthly cost, given only when relocating
+	// and the district's costs are known
+	TotalMonthly int    `json:"estimated_total_monthly_cost,omitempty"`
+	Currency     string `json:"currency,omitempty"`
+	Year         int    `json:"year,omitempty"`
+	Source       string `json:"source,omitempty"`
+}
@@ -0,0 +1,172 @@
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"go.uber.org/zap"
+)
+
+// ErrMigrationFailed is returned when the graph schema could not be brought up to date
+var ErrMigrationFailed = errors.New("schema migration failed")
+
+// maxReportedDuplicates caps the duplicate names quoted in a migration error
+const maxReportedDuplicates = 5
+
+// Migration is a schema change applied at startup. Every migration runs on every
+// start, so it must be idempotent; this keeps a database restored from an older
+// backup up to date without keeping migration state in the graph itself.
+type Migration struct {
+	Version     int
+	Description string
+	Apply       func(c *Client, ctx context.Context) error
+}
+
+// migrations are applied in order of version
+var migrations = []Migration{
+	{Version: 1, Description: "unique entity names", Apply: (*Client).ensureUniqueKeys},
+	{Version: 2, Description: "program added_at", Apply: (*Client).backfillAddedAt},
+	{Version: 3, Description: "full-text entity names", Apply: (*Client).ensureFullTextIndex},
+	{Version: 4, Description: "district and province nodes", Apply: (*Client).linkDistricts},
+	{Version: 5, Description: "entity versions", Apply: (*Client).backfillVersions},
+}
+
+// Migrate applies the schema migrations in order, stopping at the first failure
+func (c *Client) Migrate(ctx context.Context) error {
+	for _, m := range migrations {
+		if err := m.Apply(c, ctx); err != nil {
+			return fmt.Errorf("%w: migration %d (%s): %w", ErrMigrationFailed, m.Version, m.Description, err)
+		}
+		c.logger.Debug("Schema migration applied",
+			zap.Int("version", m.Version),
+			zap.String("description", m.Description))
+	}
+
+	c.logger.Info("Graph schema up to date", zap.Int("migrations", len(migrations)))
+	return nil
+}
+
+// ensureUniqueKeys creates a uniqueness constraint on the key of every entity
+// kind, e.g. Institute.name and Career.title. Creating a constraint fails while
+// duplicates exist, in which case they are named in the error.
+func (c *Client) ensureUniqueKeys(ctx context.Context) error {
+	schemas := make([]entitySchema, 0, len(entitySchemas))
+	for _, schema := range entitySchemas {
+		schemas = append(schemas, schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Label < schemas[j].Label })
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	for _, schema := range schemas {
+		name := strings.ToLower(schema.Label) + "_" + schema.Key + "_unique"
+		query := fmt.Sprintf("CREATE CONSTRAINT %s IF NOT EXISTS FOR (n:%s) REQUIRE n.%s IS UNIQUE",
+			name, schema.Label, schema.Key)
+
+		result, err := session.Run(ctx, query, nil)
+		if err == nil {
+			_, err = result.Consume(ctx)
+		}
+		if err == nil {
+			continue
+		}
+
+		duplicates, dupErr := c.duplicateKeys(ctx, schema)
+		if dupErr == nil && len(duplicates) > 0 {
+			return fmt.Errorf("cannot make %s.%s unique, duplicates exist (%s); merge or rename them and restart: %w",
+				schema.Label, schema.Key, strings.Join(duplicates, ", "), err)
+		}
+		return fmt.Errorf("failed to create constraint %s: %w", name, err)
+	}
+	return nil
+}
+
+// duplicateKeys returns up to maxReportedDuplicates key values shared by several
+// nodes of a kind
+func (c *Client) duplicateKeys(ctx context.Context, schema entitySchema) ([]string, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf(`
+		MATCH (n:%s)
+		WITH n.%s as key, count(*) as nodes
+		WHERE nodes > 1
+		RETURN key, nodes ORDER BY nodes DESC, key LIMIT %d
+	`, schema.Label, schema.Key, maxReportedDuplicates)
+	result, err := session.Run(ctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicates []string
+	for result.Next(ctx) {
+		key, _ := result.Record().Get("key")
+		nodes, _ := result.Record().Get("nodes")
+		duplicates = append(duplicates, fmt.Sprintf("%q x%v", stringOrEmpty(key), nodes))
+	}
+	return duplicates, result.Err()
+}
+
+// backfillAddedAt dates programs imported before added_at was kept by their last
+// import, the best record left of when they were added
+func (c *Client) backfillAddedAt(ctx context.Context) error {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		MATCH (n:Program) WHERE n.added_at IS NULL AND n.imported_at IS NOT NULL
+		`+stampAddedAt, nil)
+	if err != nil {
+		return err
+	}
+	_, err = result.Consume(ctx)
+	return err
+}
+
+// backfillVersions starts the version of entities written before versions were
+// kept at 1
+func (c *Client) backfillVersions(ctx context.Context) error {
+	labels := make([]string, 0, len(entitySchemas))
+	for _, schema := range entitySchemas {
+		labels = append(labels, schema.Label)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		MATCH (n) WHERE n.version IS NULL AND any(label IN labels(n) WHERE label IN $labels)
+		SET n.version = 1
+	`, map[string]any{"labels": labels})
+	if err != nil {
+		return err
+	}
+	_, err = result.Consume(ctx)
+	return err
+}
+
+// ensureFullTextIndex creates the full-text index on the names of the entity
+// kinds SearchEntities covers
+func (c *Client) ensureFullTextIndex(ctx context.Context) error {
+	labels := make([]string, 0, len(SearchableKinds))
+	for _, kind := range SearchableKinds {
+		labels = append(labels, entitySchemas[kind].Label)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, fmt.Sprintf(
+		"CREATE FULLTEXT INDEX %s IF NOT EXISTS FOR (n:%s) ON EACH [n.name, n.title]",
+		entityNamesIndex, strings.Join(labels, "|")), nil)
+	if err != nil {
+		return err
+	}
+	_, err = result.Consume(ctx)
+	return err
+}
@@ -0,0 +1,16 @@
+package neo4j
+
+// ProgramOutcomes summarizes what became of a program's graduates: how many found
+// work, how soon, and in which first jobs. The figures are kept outside the graph,
+// from graduate surveys and graduates' own reports, and attached to program
+// details by the pathway service.
+type ProgramOutcomes struct {
+	Year               int      `json:"year,omitempty"` // graduation year of the latest survey
+	Respondents        int      `json:"respondents"`
+	EmploymentRate     float64  `json:"employment_rate"`
+	MonthsToEmployment float64  `json:"months_to_employment,omitempty"`
+	TypicalFirstJobs   []string `json:"typical_first_jobs,omitempty"`
+	Sources            []string `json:"sources"`
+	// EmployabilityScore rates graduates' prospects from 0 to 100
+	EmployabilityScore int `json:"employability_score"`
+}
@@ -0,0 +1,85 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// PathwayProfile summarizes a program or career through its neighbourhood in the
+// graph. For programs, Related lists the careers they lead to; for careers, the
+// programs leading to them.
+type PathwayProfile struct {
+	Kind         string   `json:"kind"`
+	Name         string   `json:"name"`
+	Institute    string   `json:"institute,omitempty"`
+	Requirements []string `json:"requirements,omitempty"`
+	Related      []string `json:"related"`
+}
+
+// PathwayProfiles returns the profiles of the programs or careers with the given
+// names, or of all of them when names is nil. Unknown names are left out.
+func (c *Client) PathwayProfiles(ctx context.Context, kind string, names []string) ([]PathwayProfile, error) {
+	var query string
+	switch kind {
+	case KindProgram:
+		query = `
+			MATCH (p:Program) WHERE p.deleted_at IS NULL AND ($names IS NULL OR p.name IN $names)
+			OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY|HAS_DEPARTMENT|OFFERS*1..3]->(p)
+			WITH p, head(collect(DISTINCT i.name)) as institute
+			OPTIONAL MATCH (p)-[:REQUIRES]->(q:Qualification)
+			WITH p, institute, collect(DISTINCT q.name) as requirements
+			OPTIONAL MATCH (p)-[:LEADS_TO]->(c:Career)
+			RETURN p.name as name, institute, requirements, collect(DISTINCT c.title) as related
+			ORDER BY name
+		`
+	case KindCareer:
+		query = `
+			MATCH (c:Career) WHERE c.deleted_at IS NULL AND ($names IS NULL OR c.title IN $names)
+			OPTIONAL MATCH (p:Program)-[:LEADS_TO]->(c) WHERE p.deleted_at IS NULL
+			RETURN c.title as name, null as institute, [] as requirements, collect(DISTINCT p.name) as related
+			ORDER BY name
+		`
+	default:
+		return nil, fmt.Errorf("%w: profiles are kept for programs and careers, not %q", ErrInvalidEntity, kind)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	// A nil slice would be sent as an empty list rather than null
+	params := map[string]any{"names": nil}
+	if names != nil {
+		params["names"] = names
+	}
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s profiles: %w", kind, err)
+	}
+
+	var profiles []PathwayProfile
+	for result.Next(ctx) {
+		record := result.Record()
+		name, _ := record.Get("name")
+		institute, _ := record.Get("institute")
+		requirements, _ := record.Get("requirements")
+		related, _ := record.Get("related")
+
+		profile := PathwayProfile{
+			Kind:         kind,
+			Name:         stringOrEmpty(name),
+			Institute:    stringOrEmpty(institute),
+			Requirements: toStrings(requirements),
+			Related:      toStrings(related),
+		}
+		sort.Strings(profile.Requirements)
+		sort.Strings(profile.Related)
+		profiles = append(profiles, profile)
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s profiles: %w", kind, err)
+	}
+	return profiles, nil
+}
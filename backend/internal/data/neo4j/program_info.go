@@ -0,0 +1,204 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// Delivery modes of a program
+const (
+	DeliveryFullTime = "full_time"
+	DeliveryPartTime = "part_time"
+	DeliveryDistance = "distance"
+	DeliveryOnline   = "online"
+	DeliveryBlended  = "blended"
+)
+
+// Mediums of instruction
+const (
+	MediumSinhala = "sinhala"
+	MediumTamil   = "tamil"
+	MediumEnglish = "english"
+)
+
+// Schedules a program offers besides weekday daytime classes
+const (
+	ScheduleEvening  = "evening"
+	ScheduleWeekend  = "weekend"
+	ScheduleDistance = "distance"
+)
+
+// workingStudentModes are the delivery modes that leave room for a job
+var workingStudentModes = []string{DeliveryPartTime, DeliveryDistance, DeliveryOnline}
+
+// IsDeliveryMode reports whether mode is a known delivery mode
+func IsDeliveryMode(mode string) bool {
+	switch mode {
+	case DeliveryFullTime, DeliveryPartTime, DeliveryDistance, DeliveryOnline, DeliveryBlended:
+		return true
+	}
+	return false
+}
+
+// IsMedium reports whether medium is a known medium of instruction
+func IsMedium(medium string) bool {
+	switch medium {
+	case MediumSinhala, MediumTamil, MediumEnglish:
+		return true
+	}
+	return false
+}
+
+// IsSchedule reports whether schedule is a known program schedule
+func IsSchedule(schedule string) bool {
+	switch schedule {
+	case ScheduleEvening, ScheduleWeekend, ScheduleDistance:
+		return true
+	}
+	return false
+}
+
+// ProgramInfo is what students compare programs by: how long they take, what
+// they cost, when they take students in and how they are taught. The fields
+// are stored as properties of the Program node. AnnualFeeLKR is nil when the
+// fee is unknown and zero for a free program.
+type ProgramInfo struct {
+	DurationMonths int    `json:"duration_months,omitempty"`
+	AnnualFeeLKR   *int64 `json:"annual_fee_lkr,omitempty"`
+	// IntakeMonths are the months (1-12) new students start in
+	IntakeMonths []int    `json:"intake_months,omitempty"`
+	DeliveryMode string   `json:"delivery_mode,omitempty"`
+	Mediums      []string `json:"medium_of_instruction,omitempty"`
+	// Schedules are the ways the program can be followed outside working hours
+	Schedules []string `json:"schedule,omitempty"`
+}
+
+// IsEmpty reports whether no detail of the program is known
+func (pi ProgramInfo) IsEmpty() bool {
+	return pi.DurationMonths == 0 && pi.AnnualFeeLKR == nil && len(pi.IntakeMonths) == 0 &&
+		pi.DeliveryMode == "" && len(pi.Mediums) == 0 && len(pi.Schedules) == 0
+}
+
+// SuitsWorkingStudents reports whether a program can be completed while
+// employed: it has an evening, weekend or distance schedule, or is taught part
+// time, at a distance or online
+func (pi *ProgramInfo) SuitsWorkingStudents() bool {
+	if pi == nil {
+		return false
+	}
+	return len(pi.Schedules) > 0 || slices.Contains(workingStudentModes, pi.DeliveryMode)
+}
+
+// properties returns the details as node properties. Unknown fields map to nil
+// so that saving the details removes the ones they leave out.
+func (pi ProgramInfo) properties() map[string]any {
+	properties := map[string]any{
+		"duration_months": nil,
+		"annual_fee_lkr":  nil,
+		"intake_months":   nil,
+		"delivery_mode":   nil,
+		"medium":          nil,
+		"schedule":        nil,
+	}
+	if pi.DurationMonths > 0 {
+		properties["duration_months"] = int64(pi.DurationMonths)
+	}
+	if pi.AnnualFeeLKR != nil {
+		properties["annual_fee_lkr"] = *pi.AnnualFeeLKR
+	}
+	if len(pi.IntakeMonths) > 0 {
+		months := make([]int64, len(pi.IntakeMonths))
+		for i, month := range pi.IntakeMonths {
+			months[i] = int64(month)
+		}
+		properties["intake_months"] = months
+	}
+	if pi.DeliveryMode != "" {
+		properties["delivery_mode"] = pi.DeliveryMode
+	}
+	if len(pi.Mediums) > 0 {
+		properties["medium"] = pi.Mediums
+	}
+	if len(pi.Schedules) > 0 {
+		properties["schedule"] = pi.Schedules
+	}
+	return properties
+}
+
+// programInfoFromProperties reads a program's details from its properties,
+// returning nil when none are known
+func programInfoFromProperties(value any) *ProgramInfo {
+	properties, _ := value.(map[string]any)
+	pi := &ProgramInfo{
+		DeliveryMode: stringOrEmpty(properties["delivery_mode"]),
+		Mediums:      toStrings(properties["medium"]),
+		Schedules:    toStrings(properties["schedule"]),
+	}
+	if months, ok := properties["duration_months"].(int64); ok {
+		pi.DurationMonths = int(months)
+	}
+	if fee, ok := properties["annual_fee_lkr"].(int64); ok {
+		pi.AnnualFeeLKR = &fee
+	}
+	if months, ok := properties["intake_months"].([]any); ok {
+		for _, month := range months {
+			if m, ok := month.(int64); ok {
+				pi.IntakeMonths = append(pi.IntakeMonths, int(m))
+			}
+		}
+		slices.Sort(pi.IntakeMonths)
+	}
+	if len(pi.Mediums) == 0 {
+		pi.Mediums = nil
+	}
+	if len(pi.Schedules) == 0 {
+		pi.Schedules = nil
+	}
+	if pi.IsEmpty() {
+		return nil
+	}
+	return pi
+}
+
+// setProgramInfo replaces the details of a program
+func setProgramInfo(ctx context.Context, tx neo4j.ManagedTransaction, name string, pi *ProgramInfo) error {
+	if pi == nil {
+		return nil
+	}
+	_, err := runConsume(ctx, tx, "MATCH (p:Program {name: $name}) SET p += $info",
+		map[string]any{"name": name, "info": pi.properties()})
+	return err
+}
+
+// WorkingStudentPrograms returns the names of the programs that can be
+// completed while employed, as ProgramInfo.SuitsWorkingStudents tells
+func (c *Client) WorkingStudentPrograms(ctx context.Context) ([]string, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		MATCH (p:Program)
+		WHERE p.deleted_at IS NULL
+		  AND (p.delivery_mode IN $modes OR size(coalesce(p.schedule, [])) > 0)
+		RETURN p.name as name
+		ORDER BY name
+	`, map[string]any{"modes": workingStudentModes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query working student programs: %w", err)
+	}
+
+	names := []string{}
+	for result.Next(ctx) {
+		name, _ := result.Record().Get("name")
+		if s := stringOrEmpty(name); s != "" {
+			names = append(names, s)
+		}
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating working student programs: %w", err)
+	}
+	return names, nil
+}
@@ -0,0 +1,118 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"golang.org/x/sync/errgroup"
+)
+
+// Scopes selecting the programs of a listing as p, for relationship queries
+const (
+	instituteProgramsScope  = `MATCH (:Institute {name: $scope})-[:HAS_FACULTY|OFFERS*]->(p:Program) WHERE p.deleted_at IS NULL WITH DISTINCT p`
+	departmentProgramsScope = `MATCH (:Department {name: $scope})-[:OFFERS]->(p:Program) WHERE p.deleted_at IS NULL WITH DISTINCT p`
+)
+
+// programRelations are the relationship lists of the programs in a listing,
+// keyed by program name
+type programRelations struct {
+	requirements  map[string][]string
+	prerequisites map[string][]string
+	careers       map[string][]string
+}
+
+// listPrograms builds a program listing from a query returning one row R per
+// program and three relationship queries over the same scope, both run with
+// params. Rather than one
+// query with an OPTIONAL MATCH per relationship, whose rows multiply, the four
+// smaller queries run concurrently in their own sessions and are merged here.
+func listPrograms[R any](ctx context.Context, c *Client, query, scopeMatch string, params map[string]any, toDetails func(R) ProgramDetails) ([]ProgramDetails, error) {
+	var programs []ProgramDetails
+	var relations programRelations
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		session := c.driver.NewSession(gCtx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(gCtx)
+
+		result, err := session.Run(gCtx, query, params)
+		if err != nil {
+			return fmt.Errorf("failed to query programs: %w", err)
+		}
+		err = readRecords(gCtx, result, func(row R) error {
+			programs = append(programs, toDetails(row))
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("error iterating programs: %w", err)
+		}
+		return nil
+	})
+
+	related := []struct {
+		target *map[string][]string
+		match  string
+		name   string
+	}{
+		{&relations.requirements, "(p)-[:REQUIRES]->(r:Qualification)", "r.name"},
+		{&relations.prerequisites, "(r:Program)-[:IS_PREREQUISITE_FOR]->(p)", "r.name"},
+		{&relations.careers, "(p)-[:LEADS_TO]->(r:Career)", "r.title"},
+	}
+	for _, rel := range related {
+		g.Go(func() error {
+			lists, err := c.relatedByProgram(gCtx, scopeMatch, rel.match, rel.name, params)
+			if err != nil {
+				return err
+			}
+			*rel.target = lists
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	for i := range programs {
+		name := programs[i].Name
+		programs[i].Requirements = qualificationsNamed(relations.requirements[name])
+		programs[i].Prerequisites = programsNamed(relations.prerequisites[name])
+		programs[i].CareerPaths = careersTitled(relations.careers[name])
+	}
+	return programs, nil
+}
+
+// relatedRow lists the entities related to one program
+type relatedRow struct {
+	Program string   `cypher:"program"`
+	Related []string `cypher:"related"`
+}
+
+// relatedByProgram returns the names of the entities related to each program in
+// a scope through one relationship pattern
+func (c *Client) relatedByProgram(ctx context.Context, scopeMatch, match, name string, params map[string]any) (map[string][]string, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf(`
+		%s
+		MATCH %s
+		RETURN p.name as program, collect(DISTINCT %s) as related
+	`, scopeMatch, match, name)
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query related entities: %w", err)
+	}
+
+	lists := make(map[string][]string)
+	err = readRecords(ctx, result, func(row relatedRow) error {
+		lists[row.Program] = row.Related
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating related entities: %w", err)
+	}
+	return lists, nil
+}
@@ -0,0 +1,113 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// The program listings as single queries with an OPTIONAL MATCH per
+// relationship, before they were split into concurrent relationship queries,
+// kept as the baseline the batched listings are measured against
+const (
+	singleInstituteProgramsQuery = `
+		MATCH (i:Institute {name: $scope})-[:HAS_FACULTY|OFFERS*]->(p:Program)
+		OPTIONAL MATCH (i)-[:HAS_FACULTY]->(f:Faculty)-[:HAS_DEPARTMENT]->(d:Department)-[:OFFERS]->(p)
+		OPTIONAL MATCH (p)-[:REQUIRES]->(q:Qualification)
+		OPTIONAL MATCH (prereq:Program)-[:IS_PREREQUISITE_FOR]->(p)
+		OPTIONAL MATCH (p)-[:LEADS_TO]->(c:Career)
+		RETURN DISTINCT p.name as program,
+		       f.name as faculty,
+		       d.name as department,
+		       COLLECT(DISTINCT q.name) as requirements,
+		       COLLECT(DISTINCT prereq.name) as prerequisites,
+		       COLLECT(DISTINCT c.title) as careers,
+		       properties(i) as institute_properties
+		ORDER BY p.name
+	`
+
+	singleDepartmentProgramsQuery = `
+		MATCH (d:Department {name: $scope})-[:OFFERS]->(p:Program)
+		OPTIONAL MATCH (p)-[:REQUIRES]->(q:Qualification)
+		OPTIONAL MATCH (prereq:Program)-[:IS_PREREQUISITE_FOR]->(p)
+		OPTIONAL MATCH (p)-[:LEADS_TO]->(c:Career)
+		OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY]->(f:Faculty)-[:HAS_DEPARTMENT]->(d)
+		RETURN DISTINCT p.name as program,
+		       i.name as institute,
+		       f.name as faculty,
+		       d.name as department,
+		       COLLECT(DISTINCT q.name) as requirements,
+		       COLLECT(DISTINCT prereq.name) as prerequisites,
+		       COLLECT(DISTINCT c.title) as careers
+		ORDER BY
+		  CASE
+		    WHEN p.name CONTAINS 'NVQ' THEN 1
+		    WHEN p.name CONTAINS 'Certificate' THEN 2
+		    WHEN p.name CONTAINS 'Bachelor' THEN 3
+		    ELSE 4
+		  END
+	`
+)
+
+// singleQueryListing runs a baseline listing query and reads every row
+func singleQueryListing(ctx context.Context, c *Client, query, scope string) error {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, map[string]any{"scope": scope})
+	if err != nil {
+		return err
+	}
+	for result.Next(ctx) {
+	}
+	return result.Err()
+}
+
+// BenchmarkProgramListings compares the batched institute and department
+// listings with the single queries they replaced, on graphs of growing size
+func BenchmarkProgramListings(b *testing.B) {
+	client := testClient(b)
+
+	for _, size := range []struct{ departments, perDepartment int }{
+		{5, 10},
+		{20, 25},
+		{50, 40},
+	} {
+		b.Run(fmt.Sprintf("%d programs", size.departments*size.perDepartment), func(b *testing.B) {
+			loadFixture(b, client, benchmarkGraph(size.departments, size.perDepartment))
+
+			listings := []struct {
+				name string
+				run  func(ctx context.Context) error
+			}{
+				{"institute/batched", func(ctx context.Context) error {
+					_, err := client.GetProgramsByInstitute(ctx, "Test Institute")
+					return err
+				}},
+				{"institute/single query", func(ctx context.Context) error {
+					return singleQueryListing(ctx, client, singleInstituteProgramsQuery, "Test Institute")
+				}},
+				{"department/batched", func(ctx context.Context) error {
+					_, err := client.GetCompletePathway(ctx, "Department 1")
+					return err
+				}},
+				{"department/single query", func(ctx context.Context) error {
+					return singleQueryListing(ctx, client, singleDepartmentProgramsQuery, "Department 1")
+				}},
+			}
+			for _, listing := range listings {
+				b.Run(listing.name, func(b *testing.B) {
+					ctx := context.Background()
+					b.ReportAllocs()
+					for range b.N {
+						if err := listing.run(ctx); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		})
+	}
+}
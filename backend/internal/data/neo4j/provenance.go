@@ -0,0 +1,194 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// Provenance records where a node's data came from and who last checked it.
+// It is stored as the source, source_url, imported_at and verified_by properties
+// of the node; imported_at is an RFC 3339 timestamp. AddedAt is the first
+// imported_at a node had; it is set by the graph and never overwritten.
+type Provenance struct {
+	Source     string `json:"source,omitempty"`
+	SourceURL  string `json:"source_url,omitempty"`
+	ImportedAt string `json:"imported_at,omitempty"`
+	AddedAt    string `json:"added_at,omitempty"`
+	VerifiedBy string `json:"verified_by,omitempty"`
+}
+
+// stampAddedAt keeps the first imported_at of the node n as its added_at
+const stampAddedAt = "SET n.added_at = coalesce(n.added_at, n.imported_at)"
+
+// NewProvenance returns provenance for data imported now from source
+func NewProvenance(source, sourceURL string) Provenance {
+	return Provenance{
+		Source:     source,
+		SourceURL:  sourceURL,
+		ImportedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// properties returns the non-empty provenance fields as node properties, except
+// added_at, which the graph sets itself
+func (p Provenance) properties() map[string]any {
+	properties := map[string]any{}
+	if p.Source != "" {
+		properties["source"] = p.Source
+	}
+	if p.SourceURL != "" {
+		properties["source_url"] = p.SourceURL
+	}
+	if p.ImportedAt != "" {
+		properties["imported_at"] = p.ImportedAt
+	}
+	if p.VerifiedBy != "" {
+		properties["verified_by"] = p.VerifiedBy
+	}
+	return properties
+}
+
+// provenanceFromProperties reads provenance from a node's properties, returning
+// nil when the node has none
+func provenanceFromProperties(value any) *Provenance {
+	properties, _ := value.(map[string]any)
+	p := &Provenance{
+		Source:     stringOrEmpty(properties["source"]),
+		SourceURL:  stringOrEmpty(properties["source_url"]),
+		ImportedAt: stringOrEmpty(properties["imported_at"]),
+		AddedAt:    stringOrEmpty(properties["added_at"]),
+		VerifiedBy: stringOrEmpty(properties["verified_by"]),
+	}
+	if *p == (Provenance{}) {
+		return nil
+	}
+	return p
+}
+
+// GetProvenance returns the provenance of an entity, or nil when it has none
+func (c *Client) GetProvenance(ctx context.Context, kind, name string) (*Provenance, error) {
+	schema, ok := entitySchemas[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("MATCH (n:%s {%s: $name}) RETURN properties(n) as properties", schema.Label, schema.Key)
+	result, err := session.Run(ctx, query, map[string]any{"name": name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query provenance: %w", err)
+	}
+	if !result.Next(ctx) {
+		if err := result.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read provenance: %w", err)
+		}
+		return nil, fmt.Errorf("%w: %s %q", ErrEntityNotFound, kind, name)
+	}
+
+	properties, _ := result.Record().Get("properties")
+	return provenanceFromProperties(properties), nil
+}
+
+// setProvenance merges the non-empty provenance fields into a node
+func setProvenance(ctx context.Context, tx neo4j.ManagedTransaction, schema entitySchema, name string, p *Provenance) error {
+	if p == nil {
+		return nil
+	}
+	properties := p.properties()
+	if len(properties) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("MATCH (n:%s {%s: $name}) SET n += $provenance %s", schema.Label, schema.Key, stampAddedAt)
+	_, err := runConsume(ctx, tx, query, map[string]any{"name": name, "provenance": properties})
+	return err
+}
+
+// NamesBySource returns the names of the entities of a kind whose data last came
+// from the given source and source URL, leaving deleted ones out
+func (c *Client) NamesBySource(ctx context.Context, kind, source, sourceURL string) ([]string, error) {
+	schema, ok := entitySchemas[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf(`MATCH (n:%s) WHERE n.source = $source AND n.source_url = $source_url AND n.deleted_at IS NULL
+		RETURN n.%s as name ORDER BY name`, schema.Label, schema.Key)
+	result, err := session.Run(ctx, query, map[string]any{"source": source, "source_url": sourceURL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s names by source: %w", kind, err)
+	}
+
+	var names []string
+	for result.Next(ctx) {
+		name, _ := result.Record().Get("name")
+		if s := stringOrEmpty(name); s != "" {
+			names = append(names, s)
+		}
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s names: %w", kind, err)
+	}
+	return names, nil
+}
+
+// addedProgramRow is a row of the recently added programs query
+type addedProgramRow struct {
+	Program    string         `cypher:"program"`
+	Institute  string         `cypher:"institute"`
+	Faculty    string         `cypher:"faculty"`
+	Department string         `cypher:"department"`
+	Careers    []string       `cypher:"careers"`
+	Properties map[string]any `cypher:"properties"`
+}
+
+// RecentlyAddedPrograms returns up to limit programs that are still registered,
+// most recently added first, with their provenance and careers
+func (c *Client) RecentlyAddedPrograms(ctx context.Context, limit int) ([]ProgramDetails, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		MATCH (p:Program)
+		WHERE p.added_at IS NOT NULL AND p.deleted_at IS NULL AND coalesce(p.deregistered, false) = false
+		WITH p ORDER BY p.added_at DESC, p.name LIMIT $limit
+		OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY|OFFERS*]->(p)
+		OPTIONAL MATCH (f:Faculty)-[:HAS_DEPARTMENT]->(d:Department)-[:OFFERS]->(p)
+		OPTIONAL MATCH (p)-[:LEADS_TO]->(c:Career)
+		RETURN p.name as program,
+		       i.name as institute,
+		       f.name as faculty,
+		       d.name as department,
+		       COLLECT(DISTINCT c.title) as careers,
+		       properties(p) as properties
+		ORDER BY p.added_at DESC, program
+	`, map[string]any{"limit": limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recently added programs: %w", err)
+	}
+
+	programs := []ProgramDetails{}
+	err = readRecords(ctx, result, func(row addedProgramRow) error {
+		programs = append(programs, ProgramDetails{
+			Name:        row.Program,
+			Institute:   row.Institute,
+			Faculty:     row.Faculty,
+			Department:  row.Department,
+			CareerPaths: careersTitled(row.Careers),
+			Provenance:  provenanceFromProperties(row.Properties),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating recently added programs: %w", err)
+	}
+	return programs, nil
+}
@@ -0,0 +1,105 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// benchmarkGraph returns departments departments of perDepartment programs
+// each. The first program of a department requires A/L, every other one has the
+// program before it as a prerequisite, and each leads to its department's career.
+func benchmarkGraph(departments, perDepartment int) []fixtureProgram {
+	programs := make([]fixtureProgram, 0, departments*perDepartment)
+	for d := 1; d <= departments; d++ {
+		department := fmt.Sprintf("Department %d", d)
+		career := fmt.Sprintf("Career %d", d)
+		for p := 1; p <= perDepartment; p++ {
+			program := fixtureProgram{
+				Name:       fmt.Sprintf("%s Program %d", department, p),
+				Department: department,
+				Requires:   []string{"O/L"},
+				Careers:    []string{career},
+			}
+			if p == 1 {
+				program.Requires = append(program.Requires, "A/L")
+			} else {
+				program.Prerequisites = []string{fmt.Sprintf("%s Program %d", department, p-1)}
+			}
+			programs = append(programs, program)
+		}
+	}
+	return programs
+}
+
+// benchmarkQuery times query against the benchmark graph, loaded before the
+// timer starts
+func benchmarkQuery(b *testing.B, query func(ctx context.Context, c *Client) error) {
+	client := testClient(b)
+	loadFixture(b, client, benchmarkGraph(10, 20))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if err := query(ctx, client); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetAllInstitutes(b *testing.B) {
+	benchmarkQuery(b, func(ctx context.Context, c *Client) error {
+		_, err := c.GetAllInstitutes(ctx)
+		return err
+	})
+}
+
+func BenchmarkGetProgramsByInstitute(b *testing.B) {
+	benchmarkQuery(b, func(ctx context.Context, c *Client) error {
+		_, err := c.GetProgramsByInstitute(ctx, "Test Institute")
+		return err
+	})
+}
+
+func BenchmarkGetCompletePathway(b *testing.B) {
+	benchmarkQuery(b, func(ctx context.Context, c *Client) error {
+		_, err := c.GetCompletePathway(ctx, "Department 1")
+		return err
+	})
+}
+
+func BenchmarkGetProgramDetails(b *testing.B) {
+	benchmarkQuery(b, func(ctx context.Context, c *Client) error {
+		_, err := c.GetProgramDetails(ctx, "Department 1 Program 10")
+		return err
+	})
+}
+
+func BenchmarkGetAllCareers(b *testing.B) {
+	benchmarkQuery(b, func(ctx context.Context, c *Client) error {
+		_, err := c.GetAllCareers(ctx)
+		return err
+	})
+}
+
+func BenchmarkGetPathwayToCareer(b *testing.B) {
+	benchmarkQuery(b, func(ctx context.Context, c *Client) error {
+		_, err := c.GetPathwayToCareer(ctx, "Career 1")
+		return err
+	})
+}
+
+func BenchmarkGetCareerPaths(b *testing.B) {
+	benchmarkQuery(b, func(ctx context.Context, c *Client) error {
+		_, err := c.GetCareerPaths(ctx, []string{"A/L"})
+		return err
+	})
+}
+
+func BenchmarkSearchNames(b *testing.B) {
+	benchmarkQuery(b, func(ctx context.Context, c *Client) error {
+		_, err := c.SearchNames(ctx, KindProgram, []string{"program", "1"}, 25)
+		return err
+	})
+}
@@ -0,0 +1,156 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// RelatedEntity is a program or career suggested through graph adjacency
+type RelatedEntity struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Reasons []string `json:"reasons"`
+	Via     []string `json:"via"`
+	Score   int64    `json:"score"`
+	// Competitiveness is set for programs with intake data
+	Competitiveness string `json:"competitiveness,omitempty"`
+}
+
+// GetRelatedPrograms suggests programs adjacent to the given programs and careers:
+// programs in the same department, programs sharing a career, and programs leading
+// to one of the careers
+func (c *Client) GetRelatedPrograms(ctx context.Context, programs []string, careers []string, limit int) ([]RelatedEntity, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		CALL {
+			UNWIND $programs AS viewed
+			MATCH (vp:Program {name: viewed})<-[:OFFERS]-(d:Department)-[:OFFERS]->(rp:Program)
+			RETURN rp, 'same_department' AS reason, d.name AS via
+			UNION ALL
+			UNWIND $programs AS viewed
+			MATCH (vp:Program {name: viewed})-[:LEADS_TO]->(c:Career)<-[:LEADS_TO]-(rp:Program)
+			RETURN rp, 'shared_career' AS reason, c.title AS via
+			UNION ALL
+			UNWIND $careers AS viewed
+			MATCH (c:Career {title: viewed})<-[:LEADS_TO]-(rp:Program)
+			RETURN rp, 'leads_to_viewed_career' AS reason, c.title AS via
+		}
+		WITH rp, reason, via
+		WHERE rp.deleted_at IS NULL AND NOT rp.name IN $programs
+		WITH rp,
+		     COLLECT(DISTINCT reason) as reasons,
+		     COLLECT(DISTINCT via) as via,
+		     COUNT(*) as score
+		RETURN rp.name as name, reasons, via, score
+		ORDER BY score DESC, name
+		LIMIT $limit
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{
+		"programs": programs,
+		"careers":  careers,
+		"limit":    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query related programs: %w", err)
+	}
+
+	var related []RelatedEntity
+	for result.Next(ctx) {
+		related = append(related, relatedEntityFromRecord(result.Record(), "program"))
+	}
+
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating related programs: %w", err)
+	}
+
+	return related, nil
+}
+
+// GetRelatedCareers suggests careers that share programs with the given careers
+// or that the given programs lead to
+func (c *Client) GetRelatedCareers(ctx context.Context, programs []string, careers []string, limit int) ([]RelatedEntity, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		CALL {
+			UNWIND $careers AS viewed
+			MATCH (vc:Career {title: viewed})<-[:LEADS_TO]-(p:Program)-[:LEADS_TO]->(rc:Career)
+			WHERE p.deleted_at IS NULL
+			RETURN rc, 'shared_program' AS reason, p.name AS via
+			UNION ALL
+			UNWIND $programs AS viewed
+			MATCH (p:Program {name: viewed})-[:LEADS_TO]->(rc:Career)
+			RETURN rc, 'from_viewed_program' AS reason, p.name AS via
+		}
+		WITH rc, reason, via
+		WHERE rc.deleted_at IS NULL AND NOT rc.title IN $careers
+		WITH rc,
+		     COLLECT(DISTINCT reason) as reasons,
+		     COLLECT(DISTINCT via) as via,
+		     COUNT(*) as score
+		RETURN rc.title as name, reasons, via, score
+		ORDER BY score DESC, name
+		LIMIT $limit
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{
+		"programs": programs,
+		"careers":  careers,
+		"limit":    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query related careers: %w", err)
+	}
+
+	var related []RelatedEntity
+	for result.Next(ctx) {
+		related = append(related, relatedEntityFromRecord(result.Record(), "career"))
+	}
+
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating related careers: %w", err)
+	}
+
+	return related, nil
+}
+
+// relatedEntityFromRecord converts a name/reasons/via/score record
+func relatedEntityFromRecord(record *neo4j.Record, entityType string) RelatedEntity {
+	name, _ := record.Get("name")
+	reasons, _ := record.Get("reasons")
+	via, _ := record.Get("via")
+	score, _ := record.Get("score")
+
+	entity := RelatedEntity{
+		Name: stringOrEmpty(name),
+		Type: entityType,
+	}
+
+	if reasonList, ok := reasons.([]interface{}); ok {
+		for _, reason := range reasonList {
+			if reasonStr, ok := reason.(string); ok && reasonStr != "" {
+				entity.Reasons = append(entity.Reasons, reasonStr)
+			}
+		}
+	}
+
+	if viaList, ok := via.([]interface{}); ok {
+		for _, v := range viaList {
+			if viaStr, ok := v.(string); ok && viaStr != "" {
+				entity.Via = append(entity.Via, viaStr)
+			}
+		}
+	}
+
+	if scoreInt, ok := score.(int64); ok {
+		entity.Score = scoreInt
+	}
+
+	return entity
+}
@@ -0,0 +1,162 @@
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// ErrUnexpectedRecord is returned when a query row does not have the shape of
+// the struct it is mapped onto
+var ErrUnexpectedRecord = errors.New("unexpected query record")
+
+// rowField is a struct field filled from the record column named by its tag
+type rowField struct {
+	index  int
+	column string
+}
+
+// rowFieldCache holds the tagged fields of each row type, keyed by reflect.Type
+var rowFieldCache sync.Map
+
+// mapRecord fills a T from the record columns named by its `cypher` field tags.
+// Null values leave fields at their zero value, as OPTIONAL MATCH misses are
+// expected, and null list items are skipped. Missing columns and values of the
+// wrong type are collected and returned together, wrapping ErrUnexpectedRecord,
+// instead of being read as empty.
+func mapRecord[T any](record *neo4j.Record) (T, error) {
+	var row T
+	v := reflect.ValueOf(&row).Elem()
+
+	var errs []error
+	for _, field := range rowFields(v.Type()) {
+		value, ok := record.Get(field.column)
+		if !ok {
+			errs = append(errs, fmt.Errorf("column %q missing", field.column))
+			continue
+		}
+		if err := assignValue(v.Field(field.index), value); err != nil {
+			errs = append(errs, fmt.Errorf("column %q: %w", field.column, err))
+		}
+	}
+	if len(errs) > 0 {
+		return row, fmt.Errorf("%w: %w", ErrUnexpectedRecord, errors.Join(errs...))
+	}
+	return row, nil
+}
+
+// readRecords maps every remaining record of result onto a T and passes it to
+// fn, stopping at the first mapping error or error fn returns
+func readRecords[T any](ctx context.Context, result neo4j.ResultWithContext, fn func(T) error) error {
+	for result.Next(ctx) {
+		row, err := mapRecord[T](result.Record())
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return result.Err()
+}
+
+// collectRecords maps every remaining record of result onto a T
+func collectRecords[T any](ctx context.Context, result neo4j.ResultWithContext) ([]T, error) {
+	var rows []T
+	err := readRecords(ctx, result, func(row T) error {
+		rows = append(rows, row)
+		return nil
+	})
+	return rows, err
+}
+
+func rowFields(t reflect.Type) []rowField {
+	if cached, ok := rowFieldCache.Load(t); ok {
+		return cached.([]rowField)
+	}
+
+	var fields []rowField
+	for i := range t.NumField() {
+		if column := t.Field(i).Tag.Get("cypher"); column != "" {
+			fields = append(fields, rowField{index: i, column: column})
+		}
+	}
+	rowFieldCache.Store(t, fields)
+	return fields
+}
+
+// assignValue sets a field from a value as returned by the driver. Lists are
+// converted item by item; integers, which the driver returns as int64, also fit
+// int fields.
+func assignValue(field reflect.Value, value any) error {
+	if value == nil {
+		return nil
+	}
+
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("expected a list, got %T", value)
+		}
+		list := reflect.MakeSlice(field.Type(), 0, len(items))
+		for i, item := range items {
+			if item == nil {
+				continue
+			}
+			element := reflect.New(field.Type().Elem()).Elem()
+			if err := assignValue(element, item); err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+			list = reflect.Append(list, element)
+		}
+		field.Set(list)
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Type().AssignableTo(field.Type()):
+		field.Set(v)
+	case field.Kind() == reflect.Int && v.Kind() == reflect.Int64:
+		field.SetInt(v.Int())
+	default:
+		return fmt.Errorf("expected %s, got %T", field.Type(), value)
+	}
+	return nil
+}
+
+// qualificationsNamed turns names read from the graph into qualifications. Blank
+// names, which are never valid keys, are left out here and below.
+func qualificationsNamed(names []string) []Qualification {
+	var qualifications []Qualification
+	for _, name := range names {
+		if name != "" {
+			qualifications = append(qualifications, Qualification{Name: name})
+		}
+	}
+	return qualifications
+}
+
+func programsNamed(names []string) []Program {
+	var programs []Program
+	for _, name := range names {
+		if name != "" {
+			programs = append(programs, Program{Name: name})
+		}
+	}
+	return programs
+}
+
+func careersTitled(titles []string) []Career {
+	var careers []Career
+	for _, title := range titles {
+		if title != "" {
+			careers = append(careers, Career{Title: title})
+		}
+	}
+	return careers
+}
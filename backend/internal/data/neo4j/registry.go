@@ -0,0 +1,121 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// RegisteredProgram is a vocational program as recorded in the graph, with the
+// registry properties used to reconcile it against the TVEC course list
+type RegisteredProgram struct {
+	Name         string
+	CourseCode   string
+	NVQLevel     int64
+	Deregistered bool
+}
+
+// registryProperties are the program properties external registries may set
+var registryProperties = map[string]bool{
+	"course_code":     true,
+	"nvq_level":       true,
+	"provider":        true,
+	"deregistered":    true,
+	"deregistered_at": true,
+	"source":          true,
+	"source_url":      true,
+	"imported_at":     true,
+	"verified_by":     true,
+}
+
+// ListRegisteredPrograms returns programs that carry registry properties or are
+// named as NVQ courses
+func (c *Client) ListRegisteredPrograms(ctx context.Context) ([]RegisteredProgram, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		MATCH (p:Program)
+		WHERE p.deleted_at IS NULL
+		  AND (p.course_code IS NOT NULL OR p.nvq_level IS NOT NULL OR p.name CONTAINS 'NVQ')
+		RETURN p.name as name, p.course_code as course_code, p.nvq_level as nvq_level,
+		       coalesce(p.deregistered, false) as deregistered
+		ORDER BY name
+	`, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registered programs: %w", err)
+	}
+
+	var programs []RegisteredProgram
+	for result.Next(ctx) {
+		record := result.Record()
+		name, _ := record.Get("name")
+		code, _ := record.Get("course_code")
+		level, _ := record.Get("nvq_level")
+		deregistered, _ := record.Get("deregistered")
+
+		program := RegisteredProgram{
+			Name:       stringOrEmpty(name),
+			CourseCode: stringOrEmpty(code),
+		}
+		if l, ok := level.(int64); ok {
+			program.NVQLevel = l
+		}
+		if d, ok := deregistered.(bool); ok {
+			program.Deregistered = d
+		}
+		programs = append(programs, program)
+	}
+
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating registered programs: %w", err)
+	}
+
+	return programs, nil
+}
+
+// SetProgramProperties sets registry properties on a program, optionally renaming
+// it. A nil value removes the property.
+func (c *Client) SetProgramProperties(ctx context.Context, name, newName string, properties map[string]any) error {
+	for key := range properties {
+		if !registryProperties[key] {
+			return fmt.Errorf("%w: property %q cannot be set", ErrInvalidEntity, key)
+		}
+	}
+	if newName == "" {
+		newName = name
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		if newName != name {
+			if err := checkNameFree(ctx, tx, KindProgram, newName); err != nil {
+				return nil, err
+			}
+		}
+
+		count, err := runCount(ctx, tx, `
+			MATCH (p:Program {name: $name})
+			SET p += $properties, p.name = $new_name, p.version = coalesce(p.version, 0) + 1
+			RETURN count(p) as count
+		`, map[string]any{
+			"name":       name,
+			"new_name":   newName,
+			"properties": properties,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("%w: program %q", ErrEntityNotFound, name)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update program %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// Periods a scholarship amount is paid for
+const (
+	AmountMonthly = "monthly"
+	AmountAnnual  = "annual"
+	AmountOnce    = "one_time"
+)
+
+// IsAmountPeriod reports whether period is a known scholarship amount period
+func IsAmountPeriod(period string) bool {
+	switch period {
+	case AmountMonthly, AmountAnnual, AmountOnce:
+		return true
+	}
+	return false
+}
+
+// ScholarshipEligibility is who may apply for a scholarship. Empty fields do
+// not restrict; Criteria are conditions in the provider's words, such as a
+// minimum number of A/L passes, shown to students as they are.
+type ScholarshipEligibility struct {
+	// MaxMonthlyIncomeLKR is the highest monthly household income accepted
+	MaxMonthlyIncomeLKR *int64   `json:"max_monthly_income_lkr,omitempty"`
+	Districts           []string `json:"districts,omitempty"`
+	Criteria            []string `json:"criteria,omitempty"`
+}
+
+// ScholarshipInfo is what a scholarship awards and who may apply. The fields
+// are stored as properties of the Scholarship node. AmountLKR is nil when the
+// amount varies or is unknown.
+type ScholarshipInfo struct {
+	Provider       string                 `json:"provider,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	AmountLKR      *int64                 `json:"amount_lkr,omitempty"`
+	AmountPeriod   string                 `json:"amount_period,omitempty"`
+	ApplicationURL string                 `json:"application_url,omitempty"`
+	Eligibility    ScholarshipEligibility `json:"eligibility"`
+}
+
+// IsEmpty reports whether nothing is known about the scholarship
+func (si ScholarshipInfo) IsEmpty() bool {
+	e := si.Eligibility
+	return si.Provider == "" && si.Description == "" && si.AmountLKR == nil && si.AmountPeriod == "" &&
+		si.ApplicationURL == "" && e.MaxMonthlyIncomeLKR == nil && len(e.Districts) == 0 && len(e.Criteria) == 0
+}
+
+// properties returns the scholarship as node properties. Unknown fields map to
+// nil so that saving the scholarship removes the ones it leaves out.
+func (si ScholarshipInfo) properties() map[string]any {
+	value := func(s string) any {
+		if s == "" {
+			return nil
+		}
+		return s
+	}
+	properties := map[string]any{
+		"provider":               value(si.Provider),
+		"description":            value(si.Description),
+		"amount_lkr":             nil,
+		"amount_period":          value(si.AmountPeriod),
+		"application_url":        value(si.ApplicationURL),
+		"max_monthly_income_lkr": nil,
+		"districts":              nil,
+		"criteria":               nil,
+	}
+	if si.AmountLKR != nil {
+		properties["amount_lkr"] = *si.AmountLKR
+	}
+	if si.Eligibility.MaxMonthlyIncomeLKR != nil {
+		properties["max_monthly_income_lkr"] = *si.Eligibility.MaxMonthlyIncomeLKR
+	}
+	if len(si.Eligibility.Districts) > 0 {
+		properties["districts"] = si.Eligibility.Districts
+	}
+	if len(si.Eligibility.Criteria) > 0 {
+		properties["criteria"] = si.Eligibility.Criteria
+	}
+	return properties
+}
+
+// scholarshipInfoFromProperties reads a scholarship from its properties,
+// returning nil when nothing is known about it
+func scholarshipInfoFromProperties(value any) *ScholarshipInfo {
+	properties, _ := value.(map[string]any)
+	si := &ScholarshipInfo{
+		Provider:       stringOrEmpty(properties["provider"]),
+		Description:    stringOrEmpty(properties["description"]),
+		AmountPeriod:   stringOrEmpty(properties["amount_period"]),
+		ApplicationURL: stringOrEmpty(properties["application_url"]),
+		Eligibility: ScholarshipEligibility{
+			Districts: toStrings(properties["districts"]),
+			Criteria:  toStrings(properties["criteria"]),
+		},
+	}
+	if amount, ok := properties["amount_lkr"].(int64); ok {
+		si.AmountLKR = &amount
+	}
+	if income, ok := properties["max_monthly_income_lkr"].(int64); ok {
+		si.Eligibility.MaxMonthlyIncomeLKR = &income
+	}
+	if len(si.Eligibility.Districts) == 0 {
+		si.Eligibility.Districts = nil
+	}
+	if len(si.Eligibility.Criteria) == 0 {
+		si.Eligibility.Criteria = nil
+	}
+	if si.IsEmpty() {
+		return nil
+	}
+	return si
+}
+
+// setScholarshipInfo replaces the details of a scholarship
+func setScholarshipInfo(ctx context.Context, tx neo4j.ManagedTransaction, name string, si *ScholarshipInfo) error {
+	if si == nil {
+		return nil
+	}
+	_, err := runConsume(ctx, tx, "MATCH (s:Scholarship {name: $name}) SET s += $scholarship",
+		map[string]any{"name": name, "scholarship": si.properties()})
+	return err
+}
+
+// Scholarship is a scholarship and what it funds: programs directly, and every
+// program of the institutes it lists
+type Scholarship struct {
+	Name string `json:"name"`
+	ScholarshipInfo
+	Programs   []string `json:"programs"`
+	Institutes []string `json:"institutes"`
+}
+
+// ScholarshipFilter narrows a scholarships listing. Empty fields match
+// everything. A scholarship matches an income when it has no income limit or
+// the income is within it, and a district when it is open to all districts or
+// lists it.
+type ScholarshipFilter struct {
+	Query            string
+	District         string
+	MonthlyIncomeLKR int64
+}
+
+type scholarshipRow struct {
+	Name       string         `cypher:"name"`
+	Properties map[string]any `cypher:"properties"`
+	Programs   []string       `cypher:"programs"`
+	Institutes []string       `cypher:"institutes"`
+}
+
+func (row scholarshipRow) scholarship() Scholarship {
+	scholarship := Scholarship{
+		Name:       row.Name,
+		Programs:   nonNil(row.Programs),
+		Institutes: nonNil(row.Institutes),
+	}
+	if si := scholarshipInfoFromProperties(row.Properties); si != nil {
+		scholarship.ScholarshipInfo = *si
+	}
+	return scholarship
+}
+
+// scholarshipReturn returns scholarship s with what it funds and is not
+// deleted, ordered by name
+const scholarshipReturn = `
+	OPTIONAL MATCH (s)-[:FUNDS]->(fp:Program) WHERE fp.deleted_at IS NULL
+	WITH s, collect(DISTINCT fp.name) as programs
+	OPTIONAL MATCH (s)-[:FUNDS]->(fi:Institute) WHERE fi.deleted_at IS NULL
+	RETURN s.name as name, properties(s) as properties, programs,
+	       collect(DISTINCT fi.name) as institutes
+	ORDER BY name
+`
+
+// ListScholarships returns the scholarships matching a filter, by name
+func (c *Client) ListScholarships(ctx context.Context, filter ScholarshipFilter) ([]Scholarship, error) {
+	query := `
+		MATCH (s:Scholarship)
+		WHERE s.deleted_at IS NULL
+		  AND ($query = '' OR toLower(s.name) CONTAINS $query OR toLower(coalesce(s.provider, '')) CONTAINS $query)
+		  AND ($district = '' OR size(coalesce(s.districts, [])) = 0 OR any(d IN s.districts WHERE toLower(d) = $district))
+		  AND ($income = 0 OR s.max_monthly_income_lkr IS NULL OR s.max_monthly_income_lkr >= $income)
+	` + scholarshipReturn
+	return c.scholarships(ctx, query, map[string]any{
+		"query":    filterValue(filter.Query),
+		"district": filterValue(filter.District),
+		"income":   filter.MonthlyIncomeLKR,
+	})
+}
+
+// ProgramScholarships returns the scholarships funding a program, directly or
+// through its institute, by name
+func (c *Client) ProgramScholarships(ctx context.Context, programName string) ([]Scholarship, error) {
+	query := `
+		MATCH (p:Program {name: $name})
+		MATCH (s:Scholarship)
+		WHERE s.deleted_at IS NULL
+		  AND ((s)-[:FUNDS]->(p)
+		   OR EXISTS { MATCH (s)-[:FUNDS]->(i:Institute)-[:HAS_FACULTY|HAS_DEPARTMENT|OFFERS*1..3]->(p) WHERE i.deleted_at IS NULL })
+		WITH DISTINCT s
+	` + scholarshipReturn
+	return c.scholarships(ctx, query, map[string]any{"name": programName})
+}
+
+func (c *Client) scholarships(ctx context.Context, query string, params map[string]any) ([]Scholarship, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scholarships: %w", err)
+	}
+
+	scholarships := []Scholarship{}
+	err = readRecords(ctx, result, func(row scholarshipRow) error {
+		scholarships = append(scholarships, row.scholarship())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating scholarships: %w", err)
+	}
+	return scholarships, nil
+}
+
+// scholarshipLinks replaces what a scholarship funds, leaving a group as is
+// when its list is nil on update
+func scholarshipLinks(entity GraphEntity, created bool, params map[string]any) []string {
+	var queries []string
+	if entity.Programs != nil || created {
+		params["programs"] = nonNil(entity.Programs)
+		queries = append(queries,
+			`MATCH (n:Scholarship {name: $name})-[r:FUNDS]->(:Program) DELETE r`,
+			`MATCH (n:Scholarship {name: $name}) UNWIND $programs AS program MATCH (p:Program {name: program}) MERGE (n)-[:FUNDS]->(p)`)
+	}
+	if entity.Institutes != nil || created {
+		params["institutes"] = nonNil(entity.Institutes)
+		queries = append(queries,
+			`MATCH (n:Scholarship {name: $name})-[r:FUNDS]->(:Institute) DELETE r`,
+			`MATCH (n:Scholarship {name: $name}) UNWIND $institutes AS institute MATCH (i:Institute {name: institute}) MERGE (n)-[:FUNDS]->(i)`)
+	}
+	return queries
+}
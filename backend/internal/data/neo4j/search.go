@@ -0,0 +1,332 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// Data quality issues entities can be searched by
+const (
+	// IssueUnverified marks entities no one has checked since they were imported
+	IssueUnverified = "unverified"
+	// IssueMissingCareers marks programs that lead to no career
+	IssueMissingCareers = "missing_careers"
+	// IssueNoRequirements marks programs without entry requirements
+	IssueNoRequirements = "no_requirements"
+	// IssueOrphaned marks faculties, departments and programs with no parent
+	IssueOrphaned = "orphaned"
+	// IssueDeleted marks deleted entities, which can be restored
+	IssueDeleted = "deleted"
+)
+
+var entityIssues = map[string]bool{
+	IssueUnverified:     true,
+	IssueMissingCareers: true,
+	IssueNoRequirements: true,
+	IssueOrphaned:       true,
+	IssueDeleted:        true,
+}
+
+// IsEntityIssue reports whether issue is a data quality issue entities can be
+// searched by
+func IsEntityIssue(issue string) bool {
+	return entityIssues[issue]
+}
+
+// EntitySearch filters entities of any kind. Empty fields match everything; an
+// entity must have all the listed issues to match.
+type EntitySearch struct {
+	Query  string
+	Kinds  []string
+	Source string
+	Issues []string
+	Offset int
+	Limit  int
+}
+
+// EntityMatch is an entity found by a search, with its data quality issues
+type EntityMatch struct {
+	Kind       string      `json:"kind"`
+	Name       string      `json:"name"`
+	Provenance *Provenance `json:"provenance,omitempty"`
+	Issues     []string    `json:"issues"`
+}
+
+// EntitySearchResult is a page of matches and the number of matches overall
+type EntitySearchResult struct {
+	Total   int           `json:"total"`
+	Matches []EntityMatch `json:"matches"`
+}
+
+// FindEntities finds entities by name (case-insensitive substring), kind,
+// provenance source and data quality issues, ordered by kind and name
+func (c *Client) FindEntities(ctx context.Context, search EntitySearch) (*EntitySearchResult, error) {
+	kinds := search.Kinds
+	if len(kinds) == 0 {
+		for kind := range entitySchemas {
+			kinds = append(kinds, kind)
+		}
+	}
+	labels := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		schema, ok := entitySchemas[kind]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+		}
+		labels = append(labels, schema.Label)
+	}
+	sort.Strings(labels)
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (n)
+		WHERE any(label IN labels(n) WHERE label IN $labels)
+		  AND ($query = '' OR toLower(coalesce(n.name, n.title, '')) CONTAINS $query)
+		  AND ($source = '' OR n.source = $source)
+		WITH n, [issue IN [
+		    CASE WHEN n.verified_by IS NULL THEN 'unverified' END,
+		    CASE WHEN n:Program AND NOT EXISTS { (n)-[:LEADS_TO]->(:Career) } THEN 'missing_careers' END,
+		    CASE WHEN n:Program AND NOT EXISTS { (n)-[:REQUIRES]->(:Qualification) } THEN 'no_requirements' END,
+		    CASE WHEN (n:Faculty OR n:Department OR n:Program)
+		        AND NOT EXISTS { ()-[:HAS_FACULTY|HAS_DEPARTMENT|OFFERS]->(n) } THEN 'orphaned' END,
+		    CASE WHEN n.deleted_at IS NOT NULL THEN 'deleted' END
+		  ] WHERE issue IS NOT NULL] as issues
+		WHERE all(issue IN $issues WHERE issue IN issues)
+		WITH n, issues ORDER BY head(labels(n)), coalesce(n.name, n.title)
+		WITH collect({node: n, issues: issues}) as matches
+		RETURN size(matches) as total,
+		       [m IN matches[$offset..($offset + $limit)] |
+		           {labels: labels(m.node), properties: properties(m.node), issues: m.issues}] as page
+	`
+	result, err := session.Run(ctx, query, map[string]any{
+		"labels": labels,
+		"query":  strings.ToLower(strings.TrimSpace(search.Query)),
+		"source": search.Source,
+		"issues": nonNil(search.Issues),
+		"offset": search.Offset,
+		"limit":  search.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search entities: %w", err)
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entity search: %w", err)
+	}
+
+	total, _ := record.Get("total")
+	page, _ := record.Get("page")
+	items, _ := page.([]any)
+
+	searchResult := &EntitySearchResult{Matches: make([]EntityMatch, 0, len(items))}
+	if n, ok := total.(int64); ok {
+		searchResult.Total = int(n)
+	}
+	for _, item := range items {
+		fields, _ := item.(map[string]any)
+		properties := toProperties(fields["properties"])
+		kind, schema := entityKindOf(toStrings(fields["labels"]))
+		searchResult.Matches = append(searchResult.Matches, EntityMatch{
+			Kind:       kind,
+			Name:       stringOrEmpty(properties[schema.Key]),
+			Provenance: provenanceFromProperties(properties),
+			Issues:     toStrings(fields["issues"]),
+		})
+	}
+	return searchResult, nil
+}
+
+// entityKindOf returns the entity kind of a node with the given labels
+func entityKindOf(labels []string) (string, entitySchema) {
+	for kind, schema := range entitySchemas {
+		for _, label := range labels {
+			if label == schema.Label {
+				return kind, schema
+			}
+		}
+	}
+	return "", entitySchema{Key: "name"}
+}
+
+// NameMatch is an entity whose name contains every word of a search. Institute
+// is the institute a faculty, department or program belongs to.
+type NameMatch struct {
+	Name      string `json:"name"`
+	Institute string `json:"institute,omitempty"`
+}
+
+// SearchNames returns up to limit entities of one kind whose names contain every
+// given word (lowercase), shortest names first
+func (c *Client) SearchNames(ctx context.Context, kind string, words []string, limit int) ([]NameMatch, error) {
+	schema, ok := entitySchemas[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+	}
+
+	institute := "null"
+	switch kind {
+	case KindFaculty, KindDepartment, KindProgram:
+		institute = `head([(i:Institute)-[:HAS_FACULTY|HAS_DEPARTMENT|OFFERS*1..3]->(n) | i.name])`
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf(`
+		MATCH (n:%s)
+		WHERE n.deleted_at IS NULL AND all(word IN $words WHERE toLower(n.%s) CONTAINS word)
+		WITH n ORDER BY size(n.%s), n.%s LIMIT $limit
+		RETURN n.%s as name, %s as institute
+	`, schema.Label, schema.Key, schema.Key, schema.Key, schema.Key, institute)
+
+	result, err := session.Run(ctx, query, map[string]any{
+		"words": nonNil(words),
+		"limit": limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s names: %w", kind, err)
+	}
+
+	var matches []NameMatch
+	for result.Next(ctx) {
+		record := result.Record()
+		name, _ := record.Get("name")
+		inst, _ := record.Get("institute")
+		matches = append(matches, NameMatch{
+			Name:      stringOrEmpty(name),
+			Institute: stringOrEmpty(inst),
+		})
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s names: %w", kind, err)
+	}
+	return matches, nil
+}
+
+// entityNamesIndex is the full-text index on the names of SearchableKinds
+const entityNamesIndex = "entity_names"
+
+// SearchableKinds are the entity kinds found by SearchEntities
+var SearchableKinds = []string{KindInstitute, KindProgram, KindQualification, KindCareer}
+
+// EntityHit is an entity found by full-text search, with its relevance score.
+// Institute is the institute a program belongs to.
+type EntityHit struct {
+	Kind      string  `json:"kind"`
+	Name      string  `json:"name"`
+	Institute string  `json:"institute,omitempty"`
+	Score     float64 `json:"score"`
+}
+
+// SearchEntities returns up to limit entities of the given kinds (all
+// SearchableKinds when empty) whose names match the query, best match first.
+// Words match exactly, as the start of a name word, or with a typo or two, so
+// "enginering" still finds engineering programs.
+func (c *Client) SearchEntities(ctx context.Context, query string, kinds []string, limit int) ([]EntityHit, error) {
+	if len(kinds) == 0 {
+		kinds = SearchableKinds
+	}
+	labels := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		if !slices.Contains(SearchableKinds, kind) {
+			return nil, fmt.Errorf("%w: %q is not searchable", ErrInvalidEntity, kind)
+		}
+		labels = append(labels, entitySchemas[kind].Label)
+	}
+
+	terms := FullTextQuery(query)
+	if terms == "" {
+		return []EntityHit{}, nil
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		CALL db.index.fulltext.queryNodes($index, $terms) YIELD node, score
+		WHERE node.deleted_at IS NULL AND any(label IN labels(node) WHERE label IN $labels)
+		WITH node, score ORDER BY score DESC, size(coalesce(node.name, node.title)) LIMIT $limit
+		RETURN labels(node) as labels,
+		       coalesce(node.name, node.title) as name,
+		       CASE WHEN node:Program
+		           THEN head([(i:Institute)-[:HAS_FACULTY|HAS_DEPARTMENT|OFFERS*1..3]->(node) | i.name])
+		       END as institute,
+		       score
+	`, map[string]any{
+		"index":  entityNamesIndex,
+		"terms":  terms,
+		"labels": labels,
+		"limit":  limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search entities: %w", err)
+	}
+
+	hits := []EntityHit{}
+	for result.Next(ctx) {
+		record := result.Record()
+		nodeLabels, _ := record.Get("labels")
+		name, _ := record.Get("name")
+		institute, _ := record.Get("institute")
+		score, _ := record.Get("score")
+
+		kind, _ := entityKindOf(toStrings(nodeLabels))
+		hit := EntityHit{
+			Kind:      kind,
+			Name:      stringOrEmpty(name),
+			Institute: stringOrEmpty(institute),
+		}
+		if s, ok := score.(float64); ok {
+			hit.Score = s
+		}
+		hits = append(hits, hit)
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating entity search: %w", err)
+	}
+	return hits, nil
+}
+
+// FullTextQuery turns what a user typed into a full-text (Lucene) query that
+// matches each word exactly, as a prefix, or within FuzzyEdits typos, ranking
+// exact words highest. Punctuation is dropped, so no input can break the query
+// syntax; it returns "" when nothing is left to search for.
+func FullTextQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	clauses := make([]string, 0, len(words))
+	for _, word := range words {
+		clause := word + "^3 " + word + "*"
+		if edits := FuzzyEdits(word); edits > 0 {
+			clause += fmt.Sprintf(" %s~%d", word, edits)
+		}
+		clauses = append(clauses, "("+clause+")")
+	}
+	return strings.Join(clauses, " ")
+}
+
+// FuzzyEdits is how many typos a search word may contain: none for words of up
+// to three letters, where one typo makes a different word, one for up to six
+// letters and two for longer words
+func FuzzyEdits(word string) int {
+	switch length := utf8.RuneCountInString(word); {
+	case length <= 3:
+		return 0
+	case length <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
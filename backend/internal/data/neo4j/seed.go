@@ -0,0 +1,44 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+	"go.uber.org/zap"
+)
+
+// CountNodes returns the number of nodes in the database
+func (c *Client) CountNodes(ctx context.Context) (int64, error) {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	count, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return runCount(ctx, tx, "MATCH (n) RETURN count(n) as count", nil)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count nodes: %w", err)
+	}
+	return count.(int64), nil
+}
+
+// MergeInstitutes creates the institutes that do not exist yet, stamping new ones
+// with the provenance
+func (c *Client) MergeInstitutes(ctx context.Context, names []string, provenance Provenance) error {
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return runConsume(ctx, tx, `
+			UNWIND $names AS name
+			MERGE (i:Institute {name: name})
+			ON CREATE SET i += $provenance, i.version = 1
+		`, map[string]any{"names": nonNil(names), "provenance": provenance.properties()})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge institutes: %w", err)
+	}
+
+	c.logger.Info("Institutes merged", zap.Int("count", len(names)))
+	return nil
+}
@@ -0,0 +1,293 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v6/neo4j"
+)
+
+// Relationship types of the education graph, as edge types of a subgraph
+const (
+	RelHasFaculty     = "HAS_FACULTY"
+	RelHasDepartment  = "HAS_DEPARTMENT"
+	RelOffers         = "OFFERS"
+	RelRequires       = "REQUIRES"
+	RelIsPrerequisite = "IS_PREREQUISITE_FOR"
+	RelLeadsTo        = "LEADS_TO"
+)
+
+// subgraphRelTypes are the relationship types a subgraph follows, as a pattern
+const subgraphRelTypes = RelHasFaculty + "|" + RelHasDepartment + "|" + RelOffers + "|" +
+	RelRequires + "|" + RelIsPrerequisite + "|" + RelLeadsTo
+
+// GraphNode is an entity in a subgraph. ID is its kind and name, e.g.
+// "program:Bachelor of Medicine", and Group its kind.
+type GraphNode struct {
+	ID       string `json:"id"`
+	Label    string `json:"label"`
+	Group    string `json:"group"`
+	Distance int    `json:"distance"`
+}
+
+// GraphEdge is a relationship between two nodes of a subgraph, pointing the way
+// it is stored (e.g. from a program to the career it leads to)
+type GraphEdge struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// Subgraph is the neighbourhood of an entity. Truncated is set when nodes
+// beyond the limit were left out, farthest from the center first.
+type Subgraph struct {
+	Center    string      `json:"center"`
+	Nodes     []GraphNode `json:"nodes"`
+	Edges     []GraphEdge `json:"edges"`
+	Truncated bool        `json:"truncated"`
+}
+
+// NodeID returns the subgraph node ID of an entity
+func NodeID(kind, name string) string {
+	return kind + ":" + name
+}
+
+// ParseNodeID splits a subgraph node ID into its kind and name. A value without
+// a known kind is taken as a name of any kind.
+func ParseNodeID(id string) (kind, name string) {
+	if kind, name, ok := strings.Cut(id, ":"); ok && IsEntityKind(kind) {
+		return kind, name
+	}
+	return "", id
+}
+
+// GetSubgraph returns the entities within depth relationships of an entity,
+// nearest first and at most maxNodes besides the center, with the
+// relationships between them. Kind may be empty to look the name up among all
+// kinds, preferring programs, then careers, institutes and qualifications.
+func (c *Client) GetSubgraph(ctx context.Context, kind, name string, depth, maxNodes int) (*Subgraph, error) {
+	kinds := []string{KindProgram, KindCareer, KindInstitute, KindQualification, KindDepartment, KindFaculty}
+	if kind != "" {
+		if !IsEntityKind(kind) {
+			return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidEntity, kind)
+		}
+		kinds = []string{kind}
+	}
+
+	// The center is matched by label and key, so each kind uses its index
+	centers := make([]string, 0, len(kinds))
+	for i, k := range kinds {
+		schema := entitySchemas[k]
+		centers = append(centers, fmt.Sprintf(`
+			MATCH (center:%s {%s: $name})
+			WHERE center.deleted_at IS NULL
+			RETURN center, %d AS priority`, schema.Label, schema.Key, i))
+	}
+
+	// Nodes are found a level at a time, so each is reached once at its
+	// shortest distance rather than by every path to it. Expansion stops once
+	// more than maxNodes have been found, as the rest would be cut anyway.
+	var levels strings.Builder
+	for distance := 1; distance <= depth; distance++ {
+		fmt.Fprintf(&levels, `
+		CALL {
+			WITH seen, frontier, found
+			UNWIND CASE WHEN size(found) > $maxNodes THEN [] ELSE frontier END AS f
+			MATCH (f)-[:%s]-(n)
+			WHERE n.deleted_at IS NULL AND NOT n IN seen
+			WITH DISTINCT n
+			ORDER BY coalesce(n.name, n.title)
+			RETURN collect(n) AS next
+		}
+		WITH center, seen + next AS seen, next AS frontier, found + [n IN next | {node: n, distance: %d}] AS found`,
+			subgraphRelTypes, distance)
+	}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf(`
+		CALL {%s
+		}
+		WITH center
+		ORDER BY priority
+		LIMIT 1
+		WITH center, [center] AS seen, [center] AS frontier, [] AS found
+		%s
+		WITH center, found[..$maxNodes] as kept, size(found) > $maxNodes as truncated
+		WITH [{node: center, distance: 0}] + kept as kept, truncated
+		WITH kept, truncated, [k IN kept | k.node] as nodes
+		RETURN [k IN kept | {labels: labels(k.node), name: coalesce(k.node.name, k.node.title), distance: k.distance}] as nodes,
+		       reduce(edges = [], a IN nodes | edges +
+		           [(a)-[r:%s]->(b) WHERE b IN nodes |
+		               {source_labels: labels(a), source: coalesce(a.name, a.title),
+		                target_labels: labels(b), target: coalesce(b.name, b.title), type: type(r)}]) as edges,
+		       truncated
+	`, strings.Join(centers, "\n\t\t\tUNION ALL"), levels.String(), subgraphRelTypes)
+
+	result, err := session.Run(ctx, query, map[string]any{
+		"name":     name,
+		"maxNodes": maxNodes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subgraph: %w", err)
+	}
+
+	records, err := result.Collect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subgraph: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrEntityNotFound, name)
+	}
+	record := records[0]
+
+	nodes, _ := record.Get("nodes")
+	edges, _ := record.Get("edges")
+	truncated, _ := record.Get("truncated")
+
+	subgraph := &Subgraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	subgraph.Truncated, _ = truncated.(bool)
+
+	items, _ := nodes.([]any)
+	for _, item := range items {
+		fields, _ := item.(map[string]any)
+		nodeKind, _ := entityKindOf(toStrings(fields["labels"]))
+		nodeName := stringOrEmpty(fields["name"])
+		node := GraphNode{
+			ID:    NodeID(nodeKind, nodeName),
+			Label: nodeName,
+			Group: nodeKind,
+		}
+		if distance, ok := fields["distance"].(int64); ok {
+			node.Distance = int(distance)
+		}
+		subgraph.Nodes = append(subgraph.Nodes, node)
+	}
+	if len(subgraph.Nodes) > 0 {
+		subgraph.Center = subgraph.Nodes[0].ID
+	}
+
+	items, _ = edges.([]any)
+	for _, item := range items {
+		fields, _ := item.(map[string]any)
+		sourceKind, _ := entityKindOf(toStrings(fields["source_labels"]))
+		targetKind, _ := entityKindOf(toStrings(fields["target_labels"]))
+		subgraph.Edges = append(subgraph.Edges, NewGraphEdge(
+			NodeID(sourceKind, stringOrEmpty(fields["source"])),
+			NodeID(targetKind, stringOrEmpty(fields["target"])),
+			stringOrEmpty(fields["type"])))
+	}
+	return subgraph, nil
+}
+
+// NewGraphEdge returns the edge of type between two nodes
+func NewGraphEdge(source, target, relType string) GraphEdge {
+	return GraphEdge{
+		ID:     source + "|" + relType + "|" + target,
+		Source: source,
+		Target: target,
+		Type:   relType,
+	}
+}
+
+// pathwayKinds are the kinds of entity the education graph is made of
+var pathwayKinds = []string{KindInstitute, KindFaculty, KindDepartment, KindProgram, KindQualification, KindCareer}
+
+// departmentScope matches, as n, a department with the faculty and institute
+// above it, the programs it offers, and the qualifications, careers and
+// programs those are directly linked to
+const departmentScope = `
+	MATCH (d:Department {name: $department})
+	OPTIONAL MATCH (f:Faculty)-[:HAS_DEPARTMENT]->(d)
+	OPTIONAL MATCH (i:Institute)-[:HAS_FACULTY]->(f)
+	OPTIONAL MATCH (d)-[:OFFERS]->(p:Program)
+	OPTIONAL MATCH (p)-[:REQUIRES|IS_PREREQUISITE_FOR|LEADS_TO]-(linked)
+	WITH [d, f, i] + collect(DISTINCT p) + collect(DISTINCT linked) as scope
+	UNWIND scope as n
+	WITH DISTINCT n
+	WHERE n IS NOT NULL AND n.deleted_at IS NULL
+`
+
+type graphNodeRow struct {
+	Labels []string `cypher:"labels"`
+	Name   string   `cypher:"name"`
+}
+
+type graphEdgeRow struct {
+	SourceLabels []string `cypher:"source_labels"`
+	Source       string   `cypher:"source"`
+	TargetLabels []string `cypher:"target_labels"`
+	Target       string   `cypher:"target"`
+	Type         string   `cypher:"type"`
+}
+
+// StreamPathwayGraph passes the whole education graph, or the part of it around
+// a department when one is given, to node and edge as it is read from the
+// cursor: every node, by kind and name, before the first edge. Nodes have no
+// distance, as an export has no center.
+func (c *Client) StreamPathwayGraph(ctx context.Context, department string, node func(GraphNode) error, edge func(GraphEdge) error) error {
+	labels := make([]string, 0, len(pathwayKinds))
+	for _, kind := range pathwayKinds {
+		labels = append(labels, entitySchemas[kind].Label)
+	}
+
+	nodesQuery := `
+		MATCH (n)
+		WHERE n.deleted_at IS NULL AND any(label IN labels(n) WHERE label IN $labels)
+	`
+	edgesQuery := fmt.Sprintf(`
+		MATCH (a)-[r:%s]->(b)
+		WHERE a.deleted_at IS NULL AND b.deleted_at IS NULL
+	`, subgraphRelTypes)
+	if department != "" {
+		nodesQuery = departmentScope
+		edgesQuery = departmentScope + fmt.Sprintf(`
+			WITH collect(n) as nodes
+			UNWIND nodes as a
+			MATCH (a)-[r:%s]->(b)
+			WHERE b IN nodes
+		`, subgraphRelTypes)
+	}
+	nodesQuery += `
+		RETURN labels(n) as labels, coalesce(n.name, n.title) as name
+		ORDER BY labels(n)[0], name
+	`
+	edgesQuery += `
+		RETURN labels(a) as source_labels, coalesce(a.name, a.title) as source,
+		       labels(b) as target_labels, coalesce(b.name, b.title) as target, type(r) as type
+		ORDER BY source, type, target
+	`
+	params := map[string]any{"labels": labels, "department": department}
+
+	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, nodesQuery, params)
+	if err != nil {
+		return fmt.Errorf("failed to query graph nodes: %w", err)
+	}
+	err = readRecords(ctx, result, func(row graphNodeRow) error {
+		kind, _ := entityKindOf(row.Labels)
+		return node(GraphNode{ID: NodeID(kind, row.Name), Label: row.Name, Group: kind})
+	})
+	if err != nil {
+		return fmt.Errorf("error iterating graph nodes: %w", err)
+	}
+
+	result, err = session.Run(ctx, edgesQuery, params)
+	if err != nil {
+		return fmt.Errorf("failed to query graph edges: %w", err)
+	}
+	err = readRecords(ctx, result, func(row graphEdgeRow) error {
+		sourceKind, _ := entityKindOf(row.SourceLabels)
+		targetKind, _ := entityKindOf(row.TargetLabels)
+		return edge(NewGraphEdge(NodeID(sourceKind, row.Source), NodeID(targetKind, row.Target), row.Type))
+	})
+	if err != nil {
+		return fmt.Errorf("error iterating graph edges: %w", err)
+	}
+	return nil
+}
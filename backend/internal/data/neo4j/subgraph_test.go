@@ -0,0 +1,86 @@
+package neo4j
+
+import (
+	"context"
+	"testing"
+)
+
+// subgraphFixture has a program and a career sharing the name Nursing, and a
+// chain of two programs so some nodes are reachable along several paths
+func subgraphFixture() []fixtureProgram {
+	return []fixtureProgram{
+		{Name: "Nursing", Department: "Health", Requires: []string{"A/L"}, Careers: []string{"Nursing"}},
+		{Name: "Diploma in Nursing", Department: "Health", Requires: []string{"O/L"}, Careers: []string{"Nursing"}},
+		{Name: "Advanced Nursing", Department: "Health", Prerequisites: []string{"Diploma in Nursing"}},
+	}
+}
+
+func TestGetSubgraphCenterKind(t *testing.T) {
+	client := testClient(t)
+	loadFixture(t, client, subgraphFixture())
+	ctx := context.Background()
+
+	tests := []struct {
+		kind string
+		want string
+	}{
+		{"", "program:Nursing"}, // programs are preferred
+		{KindProgram, "program:Nursing"},
+		{KindCareer, "career:Nursing"},
+	}
+	for _, tt := range tests {
+		subgraph, err := client.GetSubgraph(ctx, tt.kind, "Nursing", 1, 150)
+		if err != nil {
+			t.Fatalf("GetSubgraph(%q) error = %v", tt.kind, err)
+		}
+		if subgraph.Center != tt.want {
+			t.Errorf("GetSubgraph(%q) center = %q, want %q", tt.kind, subgraph.Center, tt.want)
+		}
+	}
+
+	if _, err := client.GetSubgraph(ctx, KindInstitute, "Nursing", 1, 150); err == nil {
+		t.Error("GetSubgraph() of a missing institute succeeded, want not found")
+	}
+}
+
+func TestGetSubgraphDistances(t *testing.T) {
+	client := testClient(t)
+	loadFixture(t, client, subgraphFixture())
+
+	subgraph, err := client.GetSubgraph(context.Background(), KindProgram, "Advanced Nursing", 2, 150)
+	if err != nil {
+		t.Fatalf("GetSubgraph() error = %v", err)
+	}
+
+	distances := make(map[string]int, len(subgraph.Nodes))
+	for _, node := range subgraph.Nodes {
+		if _, ok := distances[node.ID]; ok {
+			t.Errorf("node %q listed twice", node.ID)
+		}
+		distances[node.ID] = node.Distance
+	}
+
+	// Diploma in Nursing is also two steps away through the department, and
+	// keeps its shortest distance
+	for id, want := range map[string]int{
+		"program:Advanced Nursing":   0,
+		"department:Health":          1,
+		"program:Diploma in Nursing": 1,
+		"faculty:Test Faculty":       2,
+		"program:Nursing":            2,
+		"career:Nursing":             2,
+		"qualification:O/L":          2,
+	} {
+		got, ok := distances[id]
+		if !ok {
+			t.Errorf("node %q missing", id)
+			continue
+		}
+		if got != want {
+			t.Errorf("node %q distance = %d, want %d", id, got, want)
+		}
+	}
+	if _, ok := distances["qualification:A/L"]; ok {
+		t.Error("node qualification:A/L is three steps away, want it left out at depth 2")
+	}
+}
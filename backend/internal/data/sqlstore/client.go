@@ -0,0 +1,155 @@
+// Package sqlstore keeps the roadmap and job role caches, the largest and most
+// written collections, in a relational database, SQLite or Postgres, so a small
+// deployment's MongoDB only holds user data. MongoDB is still required: plans,
+// progress, consent and the other stored data have no SQL implementation. The
+// queries use the SQL both dialects share: numbered placeholders, ON CONFLICT
+// upserts and times stored as unix seconds.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"go.uber.org/zap"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the pgx database/sql driver
+)
+
+// Postgres is the store name of the Postgres dialect
+const Postgres = "postgres"
+
+// drivers maps the store names built into this binary to their database/sql
+// driver. Drivers behind build tags add themselves when compiled in.
+var drivers = map[string]string{
+	Postgres: "pgx",
+}
+
+// schema creates the cache tables. Statements are run one by one, as not every
+// driver accepts several in one Exec.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS learning_roadmaps (
+		program_name     TEXT PRIMARY KEY,
+		data             TEXT NOT NULL,
+		created_at       BIGINT NOT NULL,
+		updated_at       BIGINT NOT NULL,
+		expires_at       BIGINT NOT NULL,
+		hit_count        BIGINT NOT NULL DEFAULT 0,
+		last_accessed_at BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS learning_roadmaps_expires_idx ON learning_roadmaps (expires_at)`,
+	// Pins live in their own table so existing caches need no migration
+	`CREATE TABLE IF NOT EXISTS pinned_roadmaps (
+		program_name TEXT PRIMARY KEY,
+		pinned_at    BIGINT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS job_role_details (
+		role_key        TEXT NOT NULL,
+		program_context TEXT NOT NULL,
+		role_name       TEXT NOT NULL,
+		data            TEXT NOT NULL,
+		created_at      BIGINT NOT NULL,
+		updated_at      BIGINT NOT NULL,
+		expires_at      BIGINT NOT NULL,
+		PRIMARY KEY (role_key, program_context)
+	)`,
+	`CREATE INDEX IF NOT EXISTS job_role_details_expires_idx ON job_role_details (expires_at)`,
+}
+
+// Client is a connection pool to the cache database
+type Client struct {
+	db     *sql.DB
+	store  string
+	logger *zap.Logger
+}
+
+// NewClient opens the cache database, creates its tables if needed and drops
+// entries that expired while the server was down
+func NewClient(cfg config.CacheConfig, logger *zap.Logger) (*Client, error) {
+	driver, ok := drivers[cfg.Store]
+	if !ok {
+		return nil, fmt.Errorf("cache store %q is not built into this binary, available: %s", cfg.Store, strings.Join(Stores(), ", "))
+	}
+
+	db, err := sql.Open(driver, cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s cache database: %w", cfg.Store, err)
+	}
+	if cfg.Store != Postgres {
+		// SQLite allows one writer at a time; a single connection queues writes
+		// instead of failing them with "database is locked"
+		db.SetMaxOpenConns(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to %s cache database: %w", cfg.Store, err)
+	}
+	for _, statement := range schema {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create cache tables: %w", err)
+		}
+	}
+
+	client := &Client{db: db, store: cfg.Store, logger: logger}
+	if removed, err := client.PurgeExpired(ctx); err != nil {
+		logger.Warn("Failed to purge expired cache entries", zap.Error(err))
+	} else if removed > 0 {
+		logger.Info("Purged expired cache entries", zap.Int64("count", removed))
+	}
+	return client, nil
+}
+
+// Stores lists the store names built into this binary
+func Stores() []string {
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Store returns the name of the database the client is connected to
+func (c *Client) Store() string {
+	return c.store
+}
+
+// Ping checks the cache database is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	return c.db.PingContext(ctx)
+}
+
+// Close closes the connection pool
+func (c *Client) Close() error {
+	return c.db.Close()
+}
+
+// PurgeExpired deletes expired cache entries, returning how many were removed.
+// Expired entries are never served, but unlike MongoDB's TTL indexes nothing
+// removes them on its own. Pinned roadmaps never expire.
+func (c *Client) PurgeExpired(ctx context.Context) (int64, error) {
+	now := time.Now().Unix()
+	var removed int64
+	for _, table := range []string{"learning_roadmaps", "job_role_details"} {
+		query := `DELETE FROM ` + table + ` WHERE expires_at <= $1`
+		if table == "learning_roadmaps" {
+			query += ` AND ` + unpinned
+		}
+		result, err := c.db.ExecContext(ctx, query, now)
+		if err != nil {
+			return removed, fmt.Errorf("failed to purge expired %s: %w", table, err)
+		}
+		n, _ := result.RowsAffected()
+		removed += n
+	}
+	return removed, nil
+}
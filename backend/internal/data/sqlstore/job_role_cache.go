@@ -0,0 +1,101 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.uber.org/zap"
+)
+
+// JobRoleCache caches job role details in the job_role_details table, keyed like
+// the MongoDB cache by role and program context
+type JobRoleCache struct {
+	client   *Client
+	logger   *zap.Logger
+	cacheTTL time.Duration
+}
+
+// NewJobRoleCache creates a new job role details cache
+func NewJobRoleCache(client *Client, logger *zap.Logger) *JobRoleCache {
+	return &JobRoleCache{
+		client:   client,
+		logger:   logger,
+		cacheTTL: mongodb.DefaultJobRoleCacheTTL,
+	}
+}
+
+// Get retrieves cached job role details generated for a specific program context
+func (c *JobRoleCache) Get(ctx context.Context, roleName, programContext string) (map[string]interface{}, bool, error) {
+	return c.findOne(ctx, `
+		SELECT data FROM job_role_details
+		WHERE role_key = $1 AND program_context = $2 AND expires_at > $3`,
+		roleKey(roleName), programContext, time.Now().Unix())
+}
+
+// GetAny retrieves the most recently cached job role details for a role, whatever
+// program context they were generated for
+func (c *JobRoleCache) GetAny(ctx context.Context, roleName string) (map[string]interface{}, bool, error) {
+	return c.findOne(ctx, `
+		SELECT data FROM job_role_details
+		WHERE role_key = $1 AND expires_at > $2
+		ORDER BY updated_at DESC
+		LIMIT 1`,
+		roleKey(roleName), time.Now().Unix())
+}
+
+func (c *JobRoleCache) findOne(ctx context.Context, query string, args ...any) (map[string]interface{}, bool, error) {
+	var raw string
+	err := c.client.db.QueryRowContext(ctx, query, args...).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to retrieve cached job role details: %w", err)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, false, fmt.Errorf("failed to decode cached job role details: %w", err)
+	}
+	return data, true, nil
+}
+
+// Set stores job role details in the cache
+func (c *JobRoleCache) Set(ctx context.Context, roleName, programContext string, data map[string]interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode job role details: %w", err)
+	}
+
+	now := time.Now()
+	_, err = c.client.db.ExecContext(ctx, `
+		INSERT INTO job_role_details (role_key, program_context, role_name, data, created_at, updated_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $5, $6)
+		ON CONFLICT (role_key, program_context) DO UPDATE SET
+			role_name = excluded.role_name,
+			data = excluded.data,
+			updated_at = excluded.updated_at,
+			expires_at = excluded.expires_at`,
+		roleKey(roleName), programContext, roleName, string(raw), now.Unix(), now.Add(c.cacheTTL).Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cache job role details: %w", err)
+	}
+
+	c.logger.Info("Job role details cached",
+		zap.String("role", roleName),
+		zap.String("context", programContext))
+	return nil
+}
+
+// roleKey normalizes a role name for case-insensitive lookups, as the MongoDB
+// cache does
+func roleKey(roleName string) string {
+	return strings.ToLower(strings.Join(strings.Fields(roleName), " "))
+}
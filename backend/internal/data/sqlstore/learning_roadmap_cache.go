@@ -0,0 +1,256 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.uber.org/zap"
+)
+
+// unpinned matches the learning_roadmaps rows that may expire, be replaced or
+// be removed
+const unpinned = `program_name NOT IN (SELECT program_name FROM pinned_roadmaps)`
+
+// LearningRoadmapCache caches learning roadmaps in the learning_roadmaps table,
+// with the same expiry, pins and hit statistics as the MongoDB cache
+type LearningRoadmapCache struct {
+	client   *Client
+	logger   *zap.Logger
+	cacheTTL time.Duration
+}
+
+// NewLearningRoadmapCache creates a new learning roadmap cache
+func NewLearningRoadmapCache(client *Client, logger *zap.Logger) *LearningRoadmapCache {
+	return &LearningRoadmapCache{
+		client:   client,
+		logger:   logger,
+		cacheTTL: mongodb.DefaultCacheTTL,
+	}
+}
+
+// Get retrieves a cached learning roadmap
+func (c *LearningRoadmapCache) Get(ctx context.Context, programName string) (map[string]interface{}, bool, error) {
+	var (
+		raw      string
+		hitCount int64
+	)
+	err := c.client.db.QueryRowContext(ctx,
+		`SELECT data, hit_count FROM learning_roadmaps WHERE program_name = $1 AND (expires_at > $2 OR NOT `+unpinned+`)`,
+		programName, time.Now().Unix(),
+	).Scan(&raw, &hitCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.logger.Debug("Cache miss for learning roadmap",
+			zap.String("program", programName))
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to retrieve cached learning roadmap: %w", err)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, false, fmt.Errorf("failed to decode cached learning roadmap: %w", err)
+	}
+
+	// Update hit count and last accessed time asynchronously
+	go c.incrementHitCount(programName)
+
+	c.logger.Info("Cache hit for learning roadmap",
+		zap.String("program", programName),
+		zap.Int64("hit_count", hitCount))
+
+	return data, true, nil
+}
+
+// Set stores a learning roadmap in the cache. Hit statistics of an existing
+// entry are kept, and a pinned entry is not replaced.
+func (c *LearningRoadmapCache) Set(ctx context.Context, programName string, data map[string]interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode learning roadmap: %w", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(c.cacheTTL)
+	_, err = c.client.db.ExecContext(ctx, `
+		INSERT INTO learning_roadmaps (program_name, data, created_at, updated_at, expires_at, hit_count, last_accessed_at)
+		VALUES ($1, $2, $3, $3, $4, 0, $3)
+		ON CONFLICT (program_name) DO UPDATE SET
+			data = excluded.data,
+			updated_at = excluded.updated_at,
+			expires_at = excluded.expires_at
+		WHERE learning_roadmaps.`+unpinned,
+		programName, string(raw), now.Unix(), expiresAt.Unix(),
+	)
+	if err != nil {
+		c.logger.Error("Failed to cache learning roadmap",
+			zap.String("program", programName),
+			zap.Error(err))
+		return fmt.Errorf("failed to cache learning roadmap: %w", err)
+	}
+
+	c.logger.Info("Learning roadmap cached",
+		zap.String("program", programName),
+		zap.Time("expires_at", expiresAt))
+	return nil
+}
+
+// incrementHitCount updates hit statistics asynchronously
+func (c *LearningRoadmapCache) incrementHitCount(programName string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := c.client.db.ExecContext(ctx,
+		`UPDATE learning_roadmaps SET hit_count = hit_count + 1, last_accessed_at = $1 WHERE program_name = $2`,
+		time.Now().Unix(), programName,
+	)
+	if err != nil {
+		c.logger.Warn("Failed to increment hit count",
+			zap.String("program", programName),
+			zap.Error(err))
+	}
+}
+
+// Delete removes a cached learning roadmap unless it is pinned
+func (c *LearningRoadmapCache) Delete(ctx context.Context, programName string) error {
+	result, err := c.client.db.ExecContext(ctx, `DELETE FROM learning_roadmaps WHERE program_name = $1 AND `+unpinned, programName)
+	if err != nil {
+		c.logger.Error("Failed to delete cached learning roadmap",
+			zap.String("program", programName),
+			zap.Error(err))
+		return fmt.Errorf("failed to delete cache entry: %w", err)
+	}
+
+	if n, _ := result.RowsAffected(); n > 0 {
+		c.logger.Info("Deleted cached learning roadmap",
+			zap.String("program", programName))
+	}
+	return nil
+}
+
+// GetStats returns cache statistics
+func (c *LearningRoadmapCache) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	now := time.Now().Unix()
+
+	var totalCount, activeCount, pinnedCount int64
+	err := c.client.db.QueryRowContext(ctx, `
+		SELECT COUNT(*),
+			COUNT(CASE WHEN expires_at > $1 OR NOT `+unpinned+` THEN 1 END),
+			COUNT(CASE WHEN NOT `+unpinned+` THEN 1 END)
+		FROM learning_roadmaps`, now,
+	).Scan(&totalCount, &activeCount, &pinnedCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count cached learning roadmaps: %w", err)
+	}
+
+	// Most accessed programs
+	rows, err := c.client.db.QueryContext(ctx, `
+		SELECT program_name, hit_count, created_at FROM learning_roadmaps
+		WHERE expires_at > $1 OR NOT `+unpinned+`
+		ORDER BY hit_count DESC
+		LIMIT 10`, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query most accessed roadmaps: %w", err)
+	}
+	defer rows.Close()
+
+	topPrograms := []map[string]interface{}{}
+	for rows.Next() {
+		var (
+			programName string
+			hitCount    int64
+			createdAt   int64
+		)
+		if err := rows.Scan(&programName, &hitCount, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to read most accessed roadmaps: %w", err)
+		}
+		topPrograms = append(topPrograms, map[string]interface{}{
+			"program_name": programName,
+			"hit_count":    hitCount,
+			"created_at":   time.Unix(createdAt, 0).UTC(),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read most accessed roadmaps: %w", err)
+	}
+
+	return map[string]interface{}{
+		"total_entries":   totalCount,
+		"active_entries":  activeCount,
+		"expired_entries": totalCount - activeCount,
+		"pinned_entries":  pinnedCount,
+		"cache_ttl_hours": c.cacheTTL.Hours(),
+		"top_programs":    topPrograms,
+		"store":           c.client.store,
+	}, nil
+}
+
+// Clear removes all cache entries but the pinned ones (use with caution)
+func (c *LearningRoadmapCache) Clear(ctx context.Context) error {
+	result, err := c.client.db.ExecContext(ctx, `DELETE FROM learning_roadmaps WHERE `+unpinned)
+	if err != nil {
+		c.logger.Error("Failed to clear cache", zap.Error(err))
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	n, _ := result.RowsAffected()
+	c.logger.Warn("Cache cleared",
+		zap.Int64("deleted_count", n))
+	return nil
+}
+
+// SetPinned pins or unpins a cached roadmap, reporting whether the program has
+// one. An unpinned entry expires after the cache TTL from now.
+func (c *LearningRoadmapCache) SetPinned(ctx context.Context, programName string, pinned bool) (bool, error) {
+	var exists bool
+	err := c.client.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM learning_roadmaps WHERE program_name = $1)`, programName,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to find cached learning roadmap: %w", err)
+	}
+	if !exists {
+		return false, nil
+	}
+
+	now := time.Now()
+	if pinned {
+		_, err = c.client.db.ExecContext(ctx,
+			`INSERT INTO pinned_roadmaps (program_name, pinned_at) VALUES ($1, $2) ON CONFLICT (program_name) DO NOTHING`,
+			programName, now.Unix(),
+		)
+	} else {
+		_, err = c.client.db.ExecContext(ctx, `DELETE FROM pinned_roadmaps WHERE program_name = $1`, programName)
+		if err == nil {
+			_, err = c.client.db.ExecContext(ctx,
+				`UPDATE learning_roadmaps SET expires_at = $1, updated_at = $2 WHERE program_name = $3`,
+				now.Add(c.cacheTTL).Unix(), now.Unix(), programName,
+			)
+		}
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to pin cached learning roadmap: %w", err)
+	}
+
+	c.logger.Info("Cached learning roadmap pin changed",
+		zap.String("program", programName),
+		zap.Bool("pinned", pinned))
+	return true, nil
+}
+
+// IsPinned reports whether the program's cached roadmap is pinned
+func (c *LearningRoadmapCache) IsPinned(ctx context.Context, programName string) (bool, error) {
+	var pinned bool
+	err := c.client.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pinned_roadmaps WHERE program_name = $1)`, programName,
+	).Scan(&pinned)
+	if err != nil {
+		return false, fmt.Errorf("failed to check pinned learning roadmap: %w", err)
+	}
+	return pinned, nil
+}
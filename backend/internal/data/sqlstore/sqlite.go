@@ -0,0 +1,15 @@
+//go:build sqlite && cgo
+
+package sqlstore
+
+import (
+	_ "github.com/mattn/go-sqlite3" // registers the sqlite3 database/sql driver
+)
+
+// SQLite is the store name of the SQLite dialect. Its driver needs cgo, so it
+// is only built in with the sqlite build tag.
+const SQLite = "sqlite"
+
+func init() {
+	drivers[SQLite] = "sqlite3"
+}
@@ -0,0 +1,152 @@
+package weaviate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/graphql"
+	"github.com/weaviate/weaviate/entities/models"
+	"go.uber.org/zap"
+)
+
+// PathwayClass holds programs and careers described in plain language, so that
+// students can find them by describing their interests
+const PathwayClass = "PathwayEntity"
+
+// pathwayNamespace seeds the deterministic object IDs of pathway documents
+var pathwayNamespace = uuid.MustParse("0b6c4a3e-6f1d-4d0e-9a57-3c2f1b8e7d21")
+
+// PathwayDocument is a program or career and the text its embedding is made from
+type PathwayDocument struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// PathwayHit is a program or career found by a semantic search. Certainty ranges
+// from 0 to 1, higher being closer.
+type PathwayHit struct {
+	Kind      string  `json:"kind"`
+	Name      string  `json:"name"`
+	Certainty float64 `json:"certainty"`
+}
+
+// ReplacePathwayIndex replaces the pathway index with the given documents. The
+// class is recreated, so documents of removed programs and careers do not linger.
+func (c *Client) ReplacePathwayIndex(ctx context.Context, docs []PathwayDocument) error {
+	exists, err := c.client.Schema().ClassExistenceChecker().WithClassName(PathwayClass).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check class existence: %w", err)
+	}
+	if exists {
+		if err := c.client.Schema().ClassDeleter().WithClassName(PathwayClass).Do(ctx); err != nil {
+			return fmt.Errorf("failed to delete pathway class: %w", err)
+		}
+	}
+
+	classObj := &models.Class{
+		Class:      PathwayClass,
+		Vectorizer: "text2vec-weaviate",
+		Properties: []*models.Property{
+			{
+				DataType:    []string{"text"},
+				Name:        "kind",
+				Description: "Whether the entity is a program or a career",
+			},
+			{
+				DataType:    []string{"text"},
+				Name:        "name",
+				Description: "The program name or career title",
+			},
+			{
+				DataType:    []string{"text"},
+				Name:        "description",
+				Description: "Plain language description the embedding is made from",
+			},
+		},
+	}
+	if err := c.client.Schema().ClassCreator().WithClass(classObj).Do(ctx); err != nil {
+		return fmt.Errorf("failed to create pathway class: %w", err)
+	}
+
+	if len(docs) == 0 {
+		return nil
+	}
+
+	batcher := c.client.Batch().ObjectsBatcher()
+	for _, doc := range docs {
+		batcher = batcher.WithObjects(&models.Object{
+			Class: PathwayClass,
+			ID:    strfmt.UUID(uuid.NewSHA1(pathwayNamespace, []byte(doc.Kind+"\x00"+doc.Name)).String()),
+			Properties: map[string]interface{}{
+				"kind":        doc.Kind,
+				"name":        doc.Name,
+				"description": doc.Description,
+			},
+		})
+	}
+
+	batchResult, err := batcher.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("batch insert failed: %w", err)
+	}
+
+	failed := 0
+	for _, result := range batchResult {
+		if result.Result != nil && result.Result.Errors != nil && len(result.Result.Errors.Error) > 0 {
+			failed++
+		}
+	}
+	if failed > 0 {
+		c.logger.Warn("Some pathway documents failed to index",
+			zap.Int("documents", len(docs)),
+			zap.Int("failed", failed))
+	}
+
+	c.logger.Info("Pathway index replaced", zap.Int("documents", len(docs)-failed))
+	return nil
+}
+
+// SearchPathways returns the programs and careers closest in meaning to text
+func (c *Client) SearchPathways(ctx context.Context, text string, limit int) ([]PathwayHit, error) {
+	nearText := c.client.GraphQL().NearTextArgBuilder().
+		WithConcepts([]string{text})
+
+	result, err := c.client.GraphQL().Get().
+		WithClassName(PathwayClass).
+		WithFields(
+			graphql.Field{Name: "kind"},
+			graphql.Field{Name: "name"},
+			graphql.Field{Name: "_additional", Fields: []graphql.Field{{Name: "certainty"}}},
+		).
+		WithNearText(nearText).
+		WithLimit(limit).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pathway search failed: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("pathway search failed: %s", result.Errors[0].Message)
+	}
+
+	var hits []PathwayHit
+	get, _ := result.Data["Get"].(map[string]interface{})
+	items, _ := get[PathwayClass].([]interface{})
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		hit := PathwayHit{
+			Kind: getStringField(obj, "kind"),
+			Name: getStringField(obj, "name"),
+		}
+		if additional, ok := obj["_additional"].(map[string]interface{}); ok {
+			hit.Certainty, _ = additional["certainty"].(float64)
+		}
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
@@ -0,0 +1,182 @@
+// Package jobs runs recurring background work (registry syncs, cache refreshes)
+// alongside the API server.
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Func is the work done by a job on each run
+type Func func(ctx context.Context) error
+
+// Lock grants named leases shared by every instance of the server. A lease is
+// held by one instance until it is released or its ttl passes.
+type Lock interface {
+	Acquire(ctx context.Context, name string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, name string) error
+}
+
+// job is a registered recurring job
+type job struct {
+	name      string
+	interval  time.Duration
+	timeout   time.Duration
+	exclusive bool
+	run       Func
+}
+
+// Scheduler runs registered jobs on fixed intervals until stopped
+type Scheduler struct {
+	logger *zap.Logger
+	lock   Lock
+	jobs   []job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a new job scheduler. Exclusive jobs take a lease from
+// lock before each run; a nil lock runs them like any other job, which is only
+// right for a single instance.
+func NewScheduler(lock Lock, logger *zap.Logger) *Scheduler {
+	return &Scheduler{lock: lock, logger: logger}
+}
+
+// Register adds a job that runs every interval on every instance, for work on
+// state the instance keeps itself. Each run is cancelled after timeout; a zero
+// timeout defaults to the interval.
+func (s *Scheduler) Register(name string, interval, timeout time.Duration, run Func) {
+	s.register(name, interval, timeout, false, run)
+}
+
+// RegisterExclusive adds a job that runs every interval on only one of the
+// instances sharing the scheduler's lock, for work on shared state such as the
+// graph or the database. The instance that runs it keeps the job's lease
+// between runs; another instance takes over once the lease lapses.
+func (s *Scheduler) RegisterExclusive(name string, interval, timeout time.Duration, run Func) {
+	s.register(name, interval, timeout, true, run)
+}
+
+func (s *Scheduler) register(name string, interval, timeout time.Duration, exclusive bool, run Func) {
+	if interval <= 0 {
+		s.logger.Warn("Job not registered: interval must be positive",
+			zap.String("job", name),
+			zap.Duration("interval", interval))
+		return
+	}
+	if timeout <= 0 {
+		timeout = interval
+	}
+	s.jobs = append(s.jobs, job{
+		name:      name,
+		interval:  interval,
+		timeout:   timeout,
+		exclusive: exclusive,
+		run:       run,
+	})
+}
+
+// leaseTTL is how long an exclusive job's lease outlives a run: past the next
+// run, so the holder renews it before another instance can take it
+func leaseTTL(j job) time.Duration {
+	return j.interval + j.interval/2
+}
+
+// Start launches all registered jobs in the background
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j)
+	}
+
+	s.logger.Info("Job scheduler started", zap.Int("jobs", len(s.jobs)))
+}
+
+// Stop cancels running jobs, waits for them to return and releases the leases
+// of exclusive jobs, so another instance picks them up at its next interval
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+
+	if s.lock != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for _, j := range s.jobs {
+			if !j.exclusive {
+				continue
+			}
+			if err := s.lock.Release(ctx, j.name); err != nil {
+				s.logger.Warn("Failed to release job lease",
+					zap.String("job", j.name),
+					zap.Error(err))
+			}
+		}
+	}
+	s.logger.Info("Job scheduler stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runOnce(ctx, j)
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, j job) {
+	ctx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("Job panicked",
+				zap.String("job", j.name),
+				zap.Any("error", r))
+		}
+	}()
+
+	if j.exclusive && s.lock != nil {
+		held, err := s.lock.Acquire(ctx, j.name, leaseTTL(j))
+		if err != nil {
+			s.logger.Error("Job skipped: lease unavailable",
+				zap.String("job", j.name),
+				zap.Error(err))
+			return
+		}
+		if !held {
+			s.logger.Debug("Job skipped: running on another instance",
+				zap.String("job", j.name))
+			return
+		}
+	}
+
+	started := time.Now()
+	if err := j.run(ctx); err != nil {
+		s.logger.Error("Job failed",
+			zap.String("job", j.name),
+			zap.Duration("duration", time.Since(started)),
+			zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Job completed",
+		zap.String("job", j.name),
+		zap.Duration("duration", time.Since(started)))
+}
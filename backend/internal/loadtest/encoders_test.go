@@ -0,0 +1,119 @@
+package loadtest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/api/jsoncodec"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"github.com/mayura-andrew/fastfinder/internal/services/scraper"
+)
+
+const (
+	// Shape of the sample roadmap: the most steps a roadmap is generated with
+	// and the most videos fetched per step
+	sampleRoadmapSteps = 8
+	sampleStepVideos   = 3
+)
+
+// roadmapBody is a full learning roadmap response as the roadmap endpoints
+// send it
+func roadmapBody() map[string]any {
+	return map[string]any{
+		"success":        true,
+		"data":           sampleRoadmap(sampleRoadmapSteps, sampleStepVideos),
+		"program":        "Bachelor of Software Engineering Honours",
+		"total_steps":    sampleRoadmapSteps,
+		"has_more_steps": false,
+		"personalized":   true,
+		"request_id":     "loadtest",
+		"timestamp":      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// BenchmarkEncodeRoadmap encodes a roadmap response with every JSON encoder
+// built into the test binary, reporting the payload size with and without gzip
+// alongside time and allocations. Encoders behind build tags are only compared
+// when the tests are built with those tags, e.g. -tags sonic.
+func BenchmarkEncodeRoadmap(b *testing.B) {
+	body := roadmapBody()
+	std, _ := jsoncodec.Get(jsoncodec.Std)
+	reference, err := std.Marshal(body)
+	if err != nil {
+		b.Fatalf("failed to encode sample roadmap: %v", err)
+	}
+
+	for _, name := range jsoncodec.Names() {
+		b.Run(name, func(b *testing.B) {
+			encoder, _ := jsoncodec.Get(name)
+			encoded, err := encoder.Marshal(body)
+			if err != nil {
+				b.Fatalf("failed to encode sample roadmap: %v", err)
+			}
+			if !bytes.Equal(encoded, reference) {
+				b.Logf("%s output differs from the standard library's", name)
+			}
+
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := encoder.Marshal(body); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(encoded)), "payload-bytes")
+			b.ReportMetric(float64(gzippedSize(encoded)), "gzip-bytes")
+		})
+	}
+}
+
+func gzippedSize(data []byte) int {
+	var b bytes.Buffer
+	zw := gzip.NewWriter(&b)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	return b.Len()
+}
+
+// sampleRoadmap builds a roadmap response of the given shape with realistic
+// text lengths, including characters the encoders escape differently
+func sampleRoadmap(steps, videosPerStep int) *pathway.LearningRoadmapResponse {
+	roadmap := &pathway.LearningRoadmapResponse{
+		ProgramName:    "Bachelor of Software Engineering Honours",
+		Overview:       strings.Repeat("Programming, software design, databases & web development for industry. ", 3),
+		TotalDuration:  "4 years",
+		Prerequisites:  []string{"G.C.E. (A/L) Examination Pass", "Advanced Certificate in Science"},
+		KeySkills:      []string{"Programming", "Software design", "Databases", "Teamwork"},
+		RecommendedFor: "Students who enjoy problem solving and want careers as software or QA engineers",
+	}
+	published := time.Date(2024, 3, 14, 9, 30, 0, 0, time.UTC)
+	for i := range steps {
+		step := pathway.LearningStepWithVideos{
+			StepNumber:  i + 1,
+			Title:       fmt.Sprintf("Step %d: Core concepts", i+1),
+			Description: strings.Repeat("Learn the <core> ideas of this step and practise them on small projects. ", 2),
+			Topics:      []string{"Control structures", "Functions and modules", "Debugging", "Testing"},
+			Duration:    "6 weeks",
+			Difficulty:  "intermediate",
+		}
+		for j := range videosPerStep {
+			id := fmt.Sprintf("vid%02d%02dxyzAB", i, j)
+			step.Videos = append(step.Videos, scraper.Video{
+				VideoID:     id,
+				Title:       "Full course for beginners – learn in one video | Tutorial",
+				URL:         "https://www.youtube.com/watch?v=" + id,
+				Channel:     "Example Academy",
+				Duration:    "PT1H32M10S",
+				ViewCount:   1_250_000,
+				PublishedAt: published,
+				Thumbnail:   "https://i.ytimg.com/vi/" + id + "/hqdefault.jpg",
+				Description: strings.Repeat("In this tutorial you will learn step by step with examples & exercises. ", 3),
+			})
+		}
+		roadmap.Steps = append(roadmap.Steps, step)
+	}
+	return roadmap
+}
@@ -0,0 +1,281 @@
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Summary describes the latencies of a set of requests or queries. Errors are
+// failed requests (transport errors and 4xx/5xx responses) or failed queries.
+type Summary struct {
+	Count  int      `json:"count"`
+	Errors int      `json:"errors"`
+	Mean   Duration `json:"mean"`
+	P50    Duration `json:"p50"`
+	P95    Duration `json:"p95"`
+	P99    Duration `json:"p99"`
+	Max    Duration `json:"max"`
+}
+
+// ErrorRate is the share of failed requests
+func (s Summary) ErrorRate() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Count)
+}
+
+// TargetReport summarizes the requests sent to one target
+type TargetReport struct {
+	Name     string      `json:"name"`
+	Summary  Summary     `json:"summary"`
+	Statuses map[int]int `json:"statuses"`
+}
+
+// Report is the outcome of a scenario run. Dropped counts requests that were not
+// sent because the concurrency limit was reached, meaning the server could not
+// keep up with the rate. Failures lists the thresholds that were exceeded.
+type Report struct {
+	Scenario string         `json:"scenario"`
+	BaseURL  string         `json:"base_url"`
+	Rate     int            `json:"rate"`
+	Elapsed  Duration       `json:"elapsed"`
+	Dropped  int            `json:"dropped"`
+	Overall  Summary        `json:"overall"`
+	Targets  []TargetReport `json:"targets"`
+	Failures []string       `json:"failures,omitempty"`
+}
+
+// Passed reports whether the run stayed within the scenario's thresholds
+func (r *Report) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// sample is the outcome of one request
+type sample struct {
+	target  int
+	latency time.Duration
+	status  int
+	failed  bool
+}
+
+// Run sends the scenario's requests to baseURL at its rate until its duration
+// has passed or ctx is cancelled, then waits for requests in flight
+func Run(ctx context.Context, client *http.Client, baseURL string, scenario *Scenario) (*Report, error) {
+	base, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", baseURL)
+	}
+	if client == nil {
+		client = &http.Client{}
+	}
+	client.Timeout = time.Duration(scenario.Timeout)
+
+	// Targets are sent in a fixed rotation where each appears as often as its
+	// weight, so short runs keep the intended mix
+	var rotation []int
+	for i, target := range scenario.Targets {
+		for range target.Weight {
+			rotation = append(rotation, i)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(scenario.Duration))
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		samples []sample
+		wg      sync.WaitGroup
+		dropped int
+	)
+	slots := make(chan struct{}, scenario.Concurrency)
+	ticker := time.NewTicker(time.Second / time.Duration(scenario.Rate))
+	defer ticker.Stop()
+
+	started := time.Now()
+	for next := 0; ; next++ {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return buildReport(scenario, baseURL, time.Since(started), samples, dropped), nil
+		case <-ticker.C:
+		}
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			dropped++
+			continue
+		}
+
+		index := rotation[next%len(rotation)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			// Requests in flight when the run ends are allowed to finish
+			result := send(context.WithoutCancel(ctx), client, base.String(), scenario, index)
+			mu.Lock()
+			samples = append(samples, result)
+			mu.Unlock()
+		}()
+	}
+}
+
+// send makes one request to a target and times it, including reading the body
+func send(ctx context.Context, client *http.Client, base string, scenario *Scenario, index int) sample {
+	target := scenario.Targets[index]
+	result := sample{target: index}
+
+	var body io.Reader
+	if len(target.Body) > 0 {
+		body = strings.NewReader(scenario.Sample.expand(string(target.Body), jsonEscape))
+	}
+	req, err := http.NewRequestWithContext(ctx, target.Method, base+scenario.Sample.expand(target.Path, url.PathEscape), body)
+	if err != nil {
+		result.failed = true
+		return result
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.latency = time.Since(start)
+		result.failed = true
+		return result
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	result.latency = time.Since(start)
+	result.status = resp.StatusCode
+	result.failed = resp.StatusCode >= http.StatusBadRequest
+	return result
+}
+
+func buildReport(scenario *Scenario, baseURL string, elapsed time.Duration, samples []sample, dropped int) *Report {
+	report := &Report{
+		Scenario: scenario.Name,
+		BaseURL:  baseURL,
+		Rate:     scenario.Rate,
+		Elapsed:  Duration(elapsed),
+		Dropped:  dropped,
+	}
+
+	all := make([]time.Duration, 0, len(samples))
+	allErrors := 0
+	for i, target := range scenario.Targets {
+		var latencies []time.Duration
+		errors := 0
+		statuses := map[int]int{}
+		for _, s := range samples {
+			if s.target != i {
+				continue
+			}
+			latencies = append(latencies, s.latency)
+			statuses[s.status]++
+			if s.failed {
+				errors++
+			}
+		}
+		all = append(all, latencies...)
+		allErrors += errors
+		report.Targets = append(report.Targets, TargetReport{
+			Name:     target.Name,
+			Summary:  summarize(latencies, errors),
+			Statuses: statuses,
+		})
+	}
+	report.Overall = summarize(all, allErrors)
+
+	thresholds := scenario.Thresholds
+	if thresholds.P95 > 0 && report.Overall.P95 > thresholds.P95 {
+		report.Failures = append(report.Failures, fmt.Sprintf("p95 latency %s exceeds %s",
+			time.Duration(report.Overall.P95), time.Duration(thresholds.P95)))
+	}
+	if thresholds.MaxErrorRate != nil && report.Overall.ErrorRate() > *thresholds.MaxErrorRate {
+		report.Failures = append(report.Failures, fmt.Sprintf("error rate %.2f%% exceeds %.2f%%",
+			report.Overall.ErrorRate()*100, *thresholds.MaxErrorRate*100))
+	}
+	if dropped > 0 {
+		report.Failures = append(report.Failures, fmt.Sprintf("%d requests dropped at the concurrency limit of %d",
+			dropped, scenario.Concurrency))
+	}
+	return report
+}
+
+// summarize computes latency percentiles using the nearest-rank method
+func summarize(latencies []time.Duration, errors int) Summary {
+	summary := Summary{Count: len(latencies), Errors: errors}
+	if len(latencies) == 0 {
+		return summary
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+	percentile := func(p int) Duration {
+		rank := (p*len(sorted) + 99) / 100
+		return Duration(sorted[max(rank, 1)-1])
+	}
+
+	summary.Mean = Duration(total / time.Duration(len(sorted)))
+	summary.P50 = percentile(50)
+	summary.P95 = percentile(95)
+	summary.P99 = percentile(99)
+	summary.Max = Duration(sorted[len(sorted)-1])
+	return summary
+}
+
+// Format writes a report as a plain text table
+func (r *Report) Format(w io.Writer) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Scenario %s against %s: %d requests in %s at %d/s, %d dropped\n\n",
+		r.Scenario, r.BaseURL, r.Overall.Count, time.Duration(r.Elapsed).Round(time.Millisecond), r.Rate, r.Dropped)
+	writeSummaries(&b, "TARGET", func(row func(string, Summary)) {
+		for _, target := range r.Targets {
+			row(target.Name, target.Summary)
+		}
+		row("all", r.Overall)
+	})
+	if len(r.Failures) > 0 {
+		fmt.Fprintf(&b, "\nFAILED:\n")
+		for _, failure := range r.Failures {
+			fmt.Fprintf(&b, "  - %s\n", failure)
+		}
+	} else {
+		fmt.Fprintf(&b, "\nPASSED\n")
+	}
+	_, _ = w.Write(b.Bytes())
+}
+
+// writeSummaries writes a table with the rows added by rows
+func writeSummaries(b *bytes.Buffer, heading string, rows func(row func(string, Summary))) {
+	fmt.Fprintf(b, "%-28s %7s %6s %10s %10s %10s %10s %10s\n", heading, "COUNT", "ERRORS", "MEAN", "P50", "P95", "P99", "MAX")
+	row := func(name string, s Summary) {
+		fmt.Fprintf(b, "%-28s %7d %6d %10s %10s %10s %10s %10s\n", name, s.Count, s.Errors,
+			ms(s.Mean), ms(s.P50), ms(s.P95), ms(s.P99), ms(s.Max))
+	}
+	rows(row)
+}
+
+func ms(d Duration) string {
+	return fmt.Sprintf("%.1fms", float64(time.Duration(d).Microseconds())/1000)
+}
@@ -0,0 +1,190 @@
+// Package loadtest replays weighted mixes of API requests at a fixed rate and
+// times the graph queries behind them, so performance regressions show up
+// before they reach a demo day.
+package loadtest
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed scenarios/*.json
+var scenarios embed.FS
+
+// DefaultScenario is the scenario run when none is requested
+const DefaultScenario = "demo-day"
+
+var (
+	// ErrUnknownScenario is returned for a scenario that is not bundled
+	ErrUnknownScenario = errors.New("unknown load test scenario")
+
+	// ErrInvalidScenario is returned for a scenario that cannot be run
+	ErrInvalidScenario = errors.New("invalid load test scenario")
+)
+
+// Sample names real entities for requests to ask about. Paths and
+// bodies refer to them as {institute}, {department}, {program}, {career} and
+// {qualification}.
+type Sample struct {
+	Institute     string `json:"institute"`
+	Department    string `json:"department"`
+	Program       string `json:"program"`
+	Career        string `json:"career"`
+	Qualification string `json:"qualification"`
+}
+
+// Target is one kind of request in a scenario, sent in proportion to its weight
+type Target struct {
+	Name   string          `json:"name"`
+	Method string          `json:"method,omitempty"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Weight int             `json:"weight"`
+}
+
+// Thresholds fail a run whose 95th percentile latency or error rate is higher.
+// Unset thresholds are not checked.
+type Thresholds struct {
+	P95          Duration `json:"p95,omitempty"`
+	MaxErrorRate *float64 `json:"max_error_rate,omitempty"`
+}
+
+// Scenario is a mix of requests sent at a fixed rate for a fixed duration
+type Scenario struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Rate        int        `json:"rate"` // requests per second
+	Duration    Duration   `json:"duration"`
+	Concurrency int        `json:"concurrency"` // requests in flight at most
+	Timeout     Duration   `json:"timeout"`
+	Sample      Sample     `json:"sample"`
+	Targets     []Target   `json:"targets"`
+	Thresholds  Thresholds `json:"thresholds"`
+}
+
+// Duration is a time.Duration written as a string such as "30s" in scenarios
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON writes a duration string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Scenarios lists the bundled scenario names
+func Scenarios() []string {
+	entries, _ := scenarios.ReadDir("scenarios")
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load returns a bundled scenario
+func Load(name string) (*Scenario, error) {
+	if name == "" {
+		name = DefaultScenario
+	}
+	f, err := scenarios.Open("scenarios/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScenario, name)
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// ReadFile loads a scenario from a JSON file on disk
+func ReadFile(filename string) (*Scenario, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open scenario: %w", err)
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse reads and validates a JSON scenario
+func Parse(r io.Reader) (*Scenario, error) {
+	var scenario Scenario
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&scenario); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScenario, err)
+	}
+	if err := scenario.validate(); err != nil {
+		return nil, err
+	}
+	return &scenario, nil
+}
+
+func (s *Scenario) validate() error {
+	switch {
+	case s.Rate <= 0:
+		return fmt.Errorf("%w: rate must be positive", ErrInvalidScenario)
+	case s.Duration <= 0:
+		return fmt.Errorf("%w: duration must be positive", ErrInvalidScenario)
+	case s.Concurrency <= 0:
+		return fmt.Errorf("%w: concurrency must be positive", ErrInvalidScenario)
+	case len(s.Targets) == 0:
+		return fmt.Errorf("%w: no targets", ErrInvalidScenario)
+	}
+	for i := range s.Targets {
+		target := &s.Targets[i]
+		if target.Name == "" || !strings.HasPrefix(target.Path, "/") {
+			return fmt.Errorf("%w: target %d needs a name and a path starting with /", ErrInvalidScenario, i+1)
+		}
+		if target.Weight <= 0 {
+			return fmt.Errorf("%w: target %s needs a positive weight", ErrInvalidScenario, target.Name)
+		}
+		if target.Method == "" {
+			target.Method = "GET"
+		}
+	}
+	if s.Timeout <= 0 {
+		s.Timeout = Duration(10 * time.Second)
+	}
+	return nil
+}
+
+// expand replaces the sample placeholders in a path or body, escaping the names
+// for where they are placed
+func (s Sample) expand(text string, escape func(string) string) string {
+	return strings.NewReplacer(
+		"{institute}", escape(s.Institute),
+		"{department}", escape(s.Department),
+		"{program}", escape(s.Program),
+		"{career}", escape(s.Career),
+		"{qualification}", escape(s.Qualification),
+	).Replace(text)
+}
+
+// jsonEscape escapes a name for use inside a JSON string
+func jsonEscape(name string) string {
+	encoded, _ := json.Marshal(name)
+	return string(encoded[1 : len(encoded)-1])
+}
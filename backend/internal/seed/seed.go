@@ -0,0 +1,303 @@
+// Package seed loads versioned seed datasets into an empty graph so new
+// environments get a working set of institutes, programs and careers.
+package seed
+
+import (
+	"context"
+	"embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/importer"
+	"go.uber.org/zap"
+)
+
+//go:embed datasets/*.json
+var datasets embed.FS
+
+const (
+	// LatestVersion is the dataset loaded when no version is requested
+	LatestVersion = "v1"
+
+	// SourceSeed is the provenance source of seeded nodes
+	SourceSeed = "seed"
+)
+
+var (
+	// ErrUnknownVersion is returned for a dataset version that is not bundled
+	ErrUnknownVersion = errors.New("unknown seed dataset version")
+
+	// ErrGraphNotEmpty is returned when seeding a graph that already has data
+	ErrGraphNotEmpty = errors.New("graph is not empty")
+)
+
+// Program is one program of a seed dataset and its place in the hierarchy
+type Program struct {
+	Institute     string   `json:"institute"`
+	Faculty       string   `json:"faculty,omitempty"`
+	Department    string   `json:"department,omitempty"`
+	Program       string   `json:"program"`
+	Requirements  []string `json:"requirements,omitempty"`
+	Prerequisites []string `json:"prerequisites,omitempty"`
+	Careers       []string `json:"careers,omitempty"`
+}
+
+// Dataset is a versioned seed dataset
+type Dataset struct {
+	Version     string    `json:"version"`
+	Description string    `json:"description,omitempty"`
+	Institutes  []string  `json:"institutes"`
+	Programs    []Program `json:"programs"`
+}
+
+// Versions lists the bundled dataset versions
+func Versions() []string {
+	entries, _ := datasets.ReadDir("datasets")
+	var versions []string
+	for _, entry := range entries {
+		versions = append(versions, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// Load returns a bundled dataset
+func Load(version string) (*Dataset, error) {
+	if version == "" {
+		version = LatestVersion
+	}
+	f, err := datasets.Open("datasets/" + version + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownVersion, version)
+	}
+	defer f.Close()
+
+	return Parse(f, "json", version)
+}
+
+// ReadFile loads a dataset from a JSON or CSV file on disk
+func ReadFile(filename string) (*Dataset, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	defer f.Close()
+
+	base := path.Base(filename)
+	return Parse(f, strings.TrimPrefix(path.Ext(base), "."), strings.TrimSuffix(base, path.Ext(base)))
+}
+
+// Fetch downloads a JSON or CSV dataset, e.g. from a public bucket URL
+func Fetch(ctx context.Context, url string) (*Dataset, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dataset request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download dataset: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("dataset download returned status %d", resp.StatusCode)
+	}
+
+	base := path.Base(strings.SplitN(url, "?", 2)[0])
+	format := strings.TrimPrefix(path.Ext(base), ".")
+	if strings.Contains(resp.Header.Get("Content-Type"), "csv") {
+		format = "csv"
+	}
+	return Parse(resp.Body, format, strings.TrimSuffix(base, path.Ext(base)))
+}
+
+// Parse reads a dataset. JSON datasets carry their own version; CSV datasets use
+// the import spreadsheet columns and take the given fallback version.
+func Parse(r io.Reader, format, fallbackVersion string) (*Dataset, error) {
+	var dataset Dataset
+	switch strings.ToLower(format) {
+	case "json", "":
+		if err := json.NewDecoder(r).Decode(&dataset); err != nil {
+			return nil, fmt.Errorf("invalid dataset JSON: %w", err)
+		}
+	case "csv":
+		programs, err := parseCSV(r)
+		if err != nil {
+			return nil, err
+		}
+		dataset.Programs = programs
+	default:
+		return nil, fmt.Errorf("unsupported dataset format %q", format)
+	}
+
+	if dataset.Version == "" {
+		dataset.Version = fallbackVersion
+	}
+
+	// Institutes referenced by programs are seeded even when not listed
+	for _, p := range dataset.Programs {
+		if p.Institute != "" && !contains(dataset.Institutes, p.Institute) {
+			dataset.Institutes = append(dataset.Institutes, p.Institute)
+		}
+	}
+
+	return &dataset, nil
+}
+
+func parseCSV(r io.Reader) ([]Program, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["program"]; !ok {
+		return nil, errors.New("dataset must have a program column")
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var programs []Program
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid dataset CSV: %w", err)
+		}
+		programs = append(programs, Program{
+			Institute:     field(record, "institute"),
+			Faculty:       field(record, "faculty"),
+			Department:    field(record, "department"),
+			Program:       field(record, "program"),
+			Requirements:  splitList(field(record, "requirements")),
+			Prerequisites: splitList(field(record, "prerequisites")),
+			Careers:       splitList(field(record, "careers")),
+		})
+	}
+	return programs, nil
+}
+
+// Rows converts the dataset into import rows
+func (d *Dataset) Rows() []importer.Row {
+	rows := make([]importer.Row, 0, len(d.Programs))
+	for _, p := range d.Programs {
+		rows = append(rows, importer.Row{
+			Institute:     p.Institute,
+			Faculty:       p.Faculty,
+			Department:    p.Department,
+			Program:       p.Program,
+			Requirements:  strings.Join(p.Requirements, "; "),
+			Prerequisites: strings.Join(p.Prerequisites, "; "),
+			Careers:       strings.Join(p.Careers, "; "),
+		})
+	}
+	return rows
+}
+
+// Seeder writes seed datasets to the graph through the import pipeline, so the
+// same validation applies as for admin imports
+type Seeder struct {
+	neo4jClient *neo4j.Client
+	importer    *importer.Service
+	logger      *zap.Logger
+}
+
+// NewSeeder creates a new seeder
+func NewSeeder(neo4jClient *neo4j.Client, logger *zap.Logger) *Seeder {
+	return &Seeder{
+		neo4jClient: neo4jClient,
+		importer:    importer.NewService(neo4jClient, nil, logger),
+		logger:      logger,
+	}
+}
+
+// Apply seeds the graph with a dataset. The graph must be empty unless force is
+// set, in which case existing programs in the dataset are updated in place.
+// Nothing is written when any row is invalid; the report returned then lists
+// the errors and is not marked applied.
+func (s *Seeder) Apply(ctx context.Context, dataset *Dataset, force bool) (*importer.Report, error) {
+	s.logger.Info("Seeding graph",
+		zap.String("version", dataset.Version),
+		zap.Int("institutes", len(dataset.Institutes)),
+		zap.Int("programs", len(dataset.Programs)),
+		zap.Bool("force", force))
+
+	nodes, err := s.neo4jClient.CountNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if nodes > 0 && !force {
+		return nil, fmt.Errorf("%w: %d nodes found", ErrGraphNotEmpty, nodes)
+	}
+
+	// The dataset is curated, so similar names in it are reported but not
+	// rejected. Every row is validated before anything is written, counting the
+	// dataset's institutes as existing since they are created first.
+	rows := dataset.Rows()
+	report, err := s.importer.ValidatePrograms(ctx, rows, true, dataset.Institutes)
+	if err != nil {
+		return nil, err
+	}
+	if report.Summary.Invalid > 0 {
+		return report, nil
+	}
+
+	provenance := neo4j.NewProvenance(SourceSeed, "seed:"+dataset.Version)
+	if err := s.neo4jClient.MergeInstitutes(ctx, dataset.Institutes, provenance); err != nil {
+		return nil, err
+	}
+
+	report, err = s.importer.ImportPrograms(ctx, rows, false, true, provenance)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Applied {
+		s.logger.Info("Graph seeded",
+			zap.String("version", dataset.Version),
+			zap.Int("created", report.Summary.Created),
+			zap.Int("updated", report.Summary.Updated))
+	}
+	return report, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
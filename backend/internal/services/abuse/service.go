@@ -0,0 +1,323 @@
+// Package abuse detects scripted use of the endpoints that cost money to serve,
+// those calling the LLM or scraping videos, and blocks the clients behind it for
+// a while. Clients are counted in memory, so each instance blocks on its own.
+package abuse
+
+import (
+	"errors"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"go.uber.org/zap"
+)
+
+// What a block applies to
+const (
+	ScopeIP     = "ip"
+	ScopeSubnet = "subnet"
+	ScopeUser   = "user"
+	ScopeAgent  = "agent"
+)
+
+// Why a client was blocked
+const (
+	ReasonBurst    = "burst"
+	ReasonRotation = "rotating_ips"
+	ReasonHoneypot = "honeypot"
+)
+
+// builtinHoneypots are paths no page links to that scripts probing the API for
+// bulk roadmap access, or for admin panels and secrets, tend to try
+var builtinHoneypots = []string{
+	"/api/v1/pathway/roadmaps/export",
+	"/api/v1/pathway/learning-roadmaps",
+	"/api/v1/llm/generate",
+	"/wp-login.php",
+	"/.env",
+}
+
+// ErrBlockNotFound is returned when lifting a block that does not exist or has expired
+var ErrBlockNotFound = errors.New("block not found")
+
+// Client is who made a request, as far as it can be told
+type Client struct {
+	IP        string
+	UserID    string
+	UserAgent string
+}
+
+// Block keeps a client, subnet or fingerprint away from the expensive endpoints
+// until it expires
+type Block struct {
+	// Key identifies the block for lifting it, e.g. "ip:203.0.113.7"
+	Key   string `json:"key"`
+	Scope string `json:"scope"`
+	Value string `json:"value"`
+
+	Reason string `json:"reason"`
+	// Requests is how many requests, or distinct IPs for rotating clients, were
+	// counted in the window that tripped the block
+	Requests int `json:"requests,omitempty"`
+	// Path is the honeypot path that was requested
+	Path string `json:"path,omitempty"`
+	// LastIP is the IP of the request that tripped the block
+	LastIP string `json:"last_ip"`
+
+	BlockedAt time.Time `json:"blocked_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// Refused counts requests turned away by the block
+	Refused int `json:"refused"`
+}
+
+// counter counts requests under one key in the current window, and the
+// distinct IPs they came from
+type counter struct {
+	start    time.Time
+	requests int
+	ips      map[string]struct{}
+}
+
+// Service counts requests to expensive endpoints and keeps the blocks tripped
+type Service struct {
+	cfg       config.AbuseConfig
+	honeypots map[string]bool
+	logger    *zap.Logger
+
+	mu       sync.Mutex
+	counters map[string]*counter
+	blocks   map[string]*Block
+	swept    time.Time
+}
+
+// NewService creates a new abuse detection service
+func NewService(cfg config.AbuseConfig, logger *zap.Logger) *Service {
+	honeypots := make(map[string]bool)
+	for _, path := range append(builtinHoneypots, cfg.HoneypotPaths...) {
+		honeypots[strings.TrimSpace(path)] = true
+	}
+
+	logger.Info("Abuse detection configured",
+		zap.Bool("enabled", cfg.Enabled),
+		zap.Duration("window", cfg.Window),
+		zap.Int("burst_limit", cfg.BurstLimit),
+		zap.Int("subnet_limit", cfg.SubnetLimit),
+		zap.Int("rotation_limit", cfg.RotationLimit),
+		zap.Int("honeypots", len(honeypots)))
+
+	return &Service{
+		cfg:       cfg,
+		honeypots: honeypots,
+		logger:    logger,
+		counters:  make(map[string]*counter),
+		blocks:    make(map[string]*Block),
+	}
+}
+
+// Enabled reports whether requests are counted and blocks enforced
+func (s *Service) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+// HoneypotPaths returns the honeypot paths, sorted
+func (s *Service) HoneypotPaths() []string {
+	paths := make([]string, 0, len(s.honeypots))
+	for path := range s.honeypots {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// IsHoneypot reports whether path is a honeypot
+func (s *Service) IsHoneypot(path string) bool {
+	return s.cfg.Enabled && s.honeypots[path]
+}
+
+// Admit counts a request to an expensive endpoint and reports whether it may be
+// served. A refused request returns the block refusing it, which is either one
+// already in place or one the request has just tripped.
+func (s *Service) Admit(client Client) (Block, bool) {
+	if !s.cfg.Enabled {
+		return Block{}, true
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+
+	ip, subnet, fingerprint := keys(client)
+	for _, key := range []string{ip, subnet, fingerprint} {
+		if block := s.active(key, now); block != nil {
+			block.Refused++
+			return *block, false
+		}
+	}
+
+	if count := s.count(ip, client.IP, now).requests; count > s.cfg.BurstLimit {
+		return s.block(ip, ReasonBurst, count, "", client, now), false
+	}
+	if subnet != "" {
+		if count := s.count(subnet, client.IP, now).requests; count > s.cfg.SubnetLimit {
+			return s.block(subnet, ReasonBurst, count, "", client, now), false
+		}
+	}
+	if fingerprint != "" {
+		if ips := len(s.count(fingerprint, client.IP, now).ips); ips > s.cfg.RotationLimit {
+			return s.block(fingerprint, ReasonRotation, ips, "", client, now), false
+		}
+	}
+	return Block{}, true
+}
+
+// Trap blocks a client that requested a honeypot path, by its IP and, when it
+// has one, its fingerprint
+func (s *Service) Trap(client Client, path string) Block {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ip, _, fingerprint := keys(client)
+	if fingerprint != "" {
+		s.block(fingerprint, ReasonHoneypot, 0, path, client, now)
+	}
+	return s.block(ip, ReasonHoneypot, 0, path, client, now)
+}
+
+// Blocks returns the blocks in place, most recent first
+func (s *Service) Blocks() []Block {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blocks := make([]Block, 0, len(s.blocks))
+	for _, block := range s.blocks {
+		if now.Before(block.ExpiresAt) {
+			blocks = append(blocks, *block)
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].BlockedAt.After(blocks[j].BlockedAt)
+	})
+	return blocks
+}
+
+// Unblock lifts a block before it expires, and forgets what was counted
+// against it so the client starts afresh
+func (s *Service) Unblock(key string, actor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active(key, time.Now()) == nil {
+		return ErrBlockNotFound
+	}
+	delete(s.blocks, key)
+	delete(s.counters, key)
+
+	s.logger.Info("Client unblocked",
+		zap.String("key", key),
+		zap.String("actor", actor))
+	return nil
+}
+
+// active returns the unexpired block under key, if any
+func (s *Service) active(key string, now time.Time) *Block {
+	if key == "" {
+		return nil
+	}
+	block, ok := s.blocks[key]
+	if !ok || !now.Before(block.ExpiresAt) {
+		return nil
+	}
+	return block
+}
+
+// count counts a request from ip under key, starting a new window when the
+// last one has ended
+func (s *Service) count(key, ip string, now time.Time) *counter {
+	c, ok := s.counters[key]
+	if !ok || now.Sub(c.start) >= s.cfg.Window {
+		c = &counter{start: now, ips: make(map[string]struct{})}
+		s.counters[key] = c
+	}
+	c.requests++
+	c.ips[ip] = struct{}{}
+	return c
+}
+
+// block puts a block under key in place, replacing any expired one
+func (s *Service) block(key, reason string, requests int, path string, client Client, now time.Time) Block {
+	scope, value, _ := strings.Cut(key, ":")
+	block := &Block{
+		Key:       key,
+		Scope:     scope,
+		Value:     value,
+		Reason:    reason,
+		Requests:  requests,
+		Path:      path,
+		LastIP:    client.IP,
+		BlockedAt: now,
+		ExpiresAt: now.Add(s.cfg.BlockDuration),
+	}
+	s.blocks[key] = block
+	delete(s.counters, key)
+
+	s.logger.Warn("Client blocked from expensive endpoints",
+		zap.String("key", key),
+		zap.String("reason", reason),
+		zap.Int("requests", requests),
+		zap.String("path", path),
+		zap.String("ip", client.IP),
+		zap.String("user_agent", client.UserAgent),
+		zap.Time("expires_at", block.ExpiresAt))
+	return *block
+}
+
+// sweep drops ended windows and expired blocks, at most once per window
+func (s *Service) sweep(now time.Time) {
+	if now.Sub(s.swept) < s.cfg.Window {
+		return
+	}
+	s.swept = now
+	for key, c := range s.counters {
+		if now.Sub(c.start) >= s.cfg.Window {
+			delete(s.counters, key)
+		}
+	}
+	for key, block := range s.blocks {
+		if !now.Before(block.ExpiresAt) {
+			delete(s.blocks, key)
+		}
+	}
+}
+
+// keys returns the keys a client is counted and blocked under: its IP, its
+// subnet (/24 for IPv4, /64 for IPv6) and its fingerprint. The fingerprint is
+// the user ID, or else the user agent of clients that are not browsers, since
+// many people share the user agent of a popular browser; it is empty for
+// browsers without a user ID.
+func keys(client Client) (ip, subnet, fingerprint string) {
+	ip = ScopeIP + ":" + client.IP
+	if addr, err := netip.ParseAddr(client.IP); err == nil {
+		addr = addr.Unmap()
+		bits := 64
+		if addr.Is4() {
+			bits = 24
+		}
+		if prefix, err := addr.Prefix(bits); err == nil {
+			subnet = ScopeSubnet + ":" + prefix.String()
+		}
+	}
+
+	switch agent := strings.TrimSpace(client.UserAgent); {
+	case client.UserID != "":
+		fingerprint = ScopeUser + ":" + client.UserID
+	case !strings.HasPrefix(agent, "Mozilla/"):
+		fingerprint = ScopeAgent + ":" + agent
+	}
+	return ip, subnet, fingerprint
+}
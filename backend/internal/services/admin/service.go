@@ -0,0 +1,920 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/district"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// SourceAdmin is the provenance source of entities created through the admin API
+	SourceAdmin = "admin"
+
+	// maxProgramMonths is the longest program duration accepted, that of the
+	// longest professional degrees
+	maxProgramMonths = 84
+
+	// minIntakeYear is the earliest academic year intake figures are accepted for
+	minIntakeYear = 1990
+
+	// Bounds for the number of changes returned from an entity's history
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 200
+
+	// Bounds for the number of entities returned per search page
+	defaultSearchLimit = 50
+	maxSearchLimit     = 200
+)
+
+// Actions recorded in entity history, shared with the change log
+const (
+	ActionCreate  = changelog.ActionCreate
+	ActionUpdate  = changelog.ActionUpdate
+	ActionDelete  = changelog.ActionDelete
+	ActionRestore = changelog.ActionRestore
+)
+
+var (
+	// ErrChangeNotFound is returned when reverting a change that is not in the
+	// entity's history
+	ErrChangeNotFound = errors.New("change not found")
+
+	// ErrInvalidFilter is returned for an entity search with an unknown filter
+	ErrInvalidFilter = errors.New("invalid search filter")
+)
+
+// phonePattern accepts phone numbers such as "+94 11 290 3903" or "(011) 290-3903"
+var phonePattern = regexp.MustCompile(`^\+?[0-9(][0-9 ()./-]{5,}[0-9]$`)
+
+// Service handles administrative writes to the education graph
+type Service struct {
+	neo4jClient *neo4j.Client
+	changes     *changelog.Service
+	intakes     *mongodb.ProgramIntakeStore
+	tests       *mongodb.AptitudeTestStore
+	livingCosts *mongodb.LivingCostStore
+	aliases     *mongodb.QualificationAliasStore
+	history     *mongodb.EntityHistoryStore
+	logger      *zap.Logger
+}
+
+// NewService creates a new admin service
+func NewService(neo4jClient *neo4j.Client, mongoClient *mongodb.Client, changelogService *changelog.Service, logger *zap.Logger) *Service {
+	return &Service{
+		neo4jClient: neo4jClient,
+		changes:     changelogService,
+		intakes:     mongodb.NewProgramIntakeStore(mongoClient, logger),
+		tests:       mongodb.NewAptitudeTestStore(mongoClient, logger),
+		livingCosts: mongodb.NewLivingCostStore(mongoClient, logger),
+		aliases:     mongodb.NewQualificationAliasStore(mongoClient, logger),
+		history:     mongodb.NewEntityHistoryStore(mongoClient, logger),
+		logger:      logger,
+	}
+}
+
+// CreateEntity creates a graph entity of the given kind. Entities created without
+// provenance are recorded as entered by an admin. actor is recorded in the
+// entity's history.
+func (s *Service) CreateEntity(ctx context.Context, kind string, entity neo4j.GraphEntity, actor string) error {
+	s.logger.Debug("Creating graph entity", zap.String("kind", kind))
+
+	entity = normalizeEntity(entity)
+	if entity.Name == "" {
+		return fmt.Errorf("%w: name is required", neo4j.ErrInvalidEntity)
+	}
+	if err := validateContact(entity.Contact); err != nil {
+		return err
+	}
+	if err := validateProgramInfo(entity.Info); err != nil {
+		return err
+	}
+	if err := validateScholarship(entity.Scholarship); err != nil {
+		return err
+	}
+	if entity.Provenance == nil {
+		provenance := neo4j.NewProvenance(SourceAdmin, "")
+		entity.Provenance = &provenance
+	}
+
+	if err := s.neo4jClient.CreateEntity(ctx, kind, entity); err != nil {
+		s.logger.Warn("Failed to create graph entity",
+			zap.String("kind", kind),
+			zap.String("name", entity.Name),
+			zap.Error(err))
+		return err
+	}
+
+	s.recordChange(ctx, kind, ActionCreate, actor, nil, s.snapshot(ctx, kind, entity.Name))
+	s.emitChanges(ctx, mongodb.GraphChange{Kind: kind, Name: entity.Name, Action: ActionCreate})
+	return nil
+}
+
+// UpdateEntity renames a graph entity and/or replaces its relationships
+func (s *Service) UpdateEntity(ctx context.Context, kind, name string, entity neo4j.GraphEntity, actor string) error {
+	s.logger.Debug("Updating graph entity", zap.String("kind", kind), zap.String("name", name))
+
+	name = normalizeName(name)
+	entity = normalizeEntity(entity)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", neo4j.ErrInvalidEntity)
+	}
+	if err := validateContact(entity.Contact); err != nil {
+		return err
+	}
+	if err := validateProgramInfo(entity.Info); err != nil {
+		return err
+	}
+	if err := validateScholarship(entity.Scholarship); err != nil {
+		return err
+	}
+
+	newName := name
+	if entity.Name != "" {
+		newName = entity.Name
+	}
+
+	// A renamed program's dependents refer to it by name in their roadmaps
+	var dependents []string
+	if kind == neo4j.KindProgram && newName != name {
+		var err error
+		if dependents, err = s.neo4jClient.DependentPrograms(ctx, name); err != nil {
+			s.logger.Warn("Failed to look up dependent programs",
+				zap.String("program", name),
+				zap.Error(err))
+		}
+	}
+
+	before := s.snapshot(ctx, kind, name)
+	if err := s.neo4jClient.UpdateEntity(ctx, kind, name, entity); err != nil {
+		s.logger.Warn("Failed to update graph entity",
+			zap.String("kind", kind),
+			zap.String("name", name),
+			zap.Error(err))
+		return err
+	}
+
+	s.recordChange(ctx, kind, ActionUpdate, actor, before, s.snapshot(ctx, kind, newName))
+
+	changes := []mongodb.GraphChange{{Kind: kind, Name: newName, Action: ActionUpdate}}
+	if newName != name {
+		changes[0].PreviousName = name
+		changes[0].Details = []string{"renamed from " + name}
+	}
+	for _, dependent := range dependents {
+		changes = append(changes, mongodb.GraphChange{
+			Kind:    neo4j.KindProgram,
+			Name:    dependent,
+			Action:  ActionUpdate,
+			Details: []string{fmt.Sprintf("prerequisite %s renamed to %s", name, newName)},
+		})
+	}
+	s.emitChanges(ctx, changes...)
+	return nil
+}
+
+// GetProvenance returns where an entity's data came from, or nil when unknown
+func (s *Service) GetProvenance(ctx context.Context, kind, name string) (*neo4j.Provenance, error) {
+	s.logger.Debug("Getting provenance", zap.String("kind", kind), zap.String("name", name))
+
+	name = normalizeName(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", neo4j.ErrInvalidEntity)
+	}
+	return s.neo4jClient.GetProvenance(ctx, kind, name)
+}
+
+// SaveProgramIntakes records a program's intake size and application count for one
+// or more academic years, replacing earlier figures for the same years
+func (s *Service) SaveProgramIntakes(ctx context.Context, programName string, intakes []mongodb.ProgramIntake) ([]mongodb.ProgramIntake, error) {
+	s.logger.Debug("Saving program intakes", zap.String("program", programName), zap.Int("years", len(intakes)))
+
+	programName = normalizeName(programName)
+	if len(intakes) == 0 {
+		return nil, fmt.Errorf("%w: at least one intake is required", neo4j.ErrInvalidEntity)
+	}
+
+	maxYear := time.Now().Year() + 1
+	seen := make(map[int]bool, len(intakes))
+	for i := range intakes {
+		intake := &intakes[i]
+		switch {
+		case intake.Year < minIntakeYear || intake.Year > maxYear:
+			return nil, fmt.Errorf("%w: year must be between %d and %d", neo4j.ErrInvalidEntity, minIntakeYear, maxYear)
+		case seen[intake.Year]:
+			return nil, fmt.Errorf("%w: year %d is listed twice", neo4j.ErrInvalidEntity, intake.Year)
+		case intake.Intake <= 0:
+			return nil, fmt.Errorf("%w: intake for %d must be positive", neo4j.ErrInvalidEntity, intake.Year)
+		case intake.Applications < 0:
+			return nil, fmt.Errorf("%w: applications for %d cannot be negative", neo4j.ErrInvalidEntity, intake.Year)
+		}
+		seen[intake.Year] = true
+		intake.ProgramName = programName
+		intake.Source = strings.TrimSpace(intake.Source)
+	}
+
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, []string{programName})
+	if err != nil {
+		return nil, err
+	}
+	if !existing[programName] {
+		return nil, fmt.Errorf("%w: program %q", neo4j.ErrEntityNotFound, programName)
+	}
+
+	if err := s.intakes.Upsert(ctx, intakes); err != nil {
+		s.logger.Error("Failed to save program intakes",
+			zap.String("program", programName),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Program intakes saved",
+		zap.String("program", programName),
+		zap.Int("years", len(intakes)))
+	return s.intakes.ListByProgram(ctx, programName)
+}
+
+// SaveAptitudeTests replaces the aptitude and practical tests a program admits
+// students by. An empty list records that the program has no tests.
+func (s *Service) SaveAptitudeTests(ctx context.Context, programName string, tests []mongodb.AptitudeTest) ([]mongodb.AptitudeTest, error) {
+	s.logger.Debug("Saving aptitude tests", zap.String("program", programName), zap.Int("tests", len(tests)))
+
+	programName = normalizeName(programName)
+	seen := make(map[string]bool, len(tests))
+	for i := range tests {
+		test := &tests[i]
+		test.Name = normalizeName(test.Name)
+		test.Description = strings.TrimSpace(test.Description)
+		test.Source = strings.TrimSpace(test.Source)
+		if err := validateAptitudeTest(test); err != nil {
+			return nil, err
+		}
+		if seen[test.Name] {
+			return nil, fmt.Errorf("%w: test %q is listed twice", neo4j.ErrInvalidEntity, test.Name)
+		}
+		seen[test.Name] = true
+		slices.SortFunc(test.Dates, func(a, b mongodb.TestDate) int {
+			return a.Date.Compare(b.Date)
+		})
+	}
+
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, []string{programName})
+	if err != nil {
+		return nil, err
+	}
+	if !existing[programName] {
+		return nil, fmt.Errorf("%w: program %q", neo4j.ErrEntityNotFound, programName)
+	}
+
+	if err := s.tests.Replace(ctx, programName, tests); err != nil {
+		s.logger.Error("Failed to save aptitude tests",
+			zap.String("program", programName),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Aptitude tests saved",
+		zap.String("program", programName),
+		zap.Int("tests", len(tests)))
+	return s.tests.ListByProgram(ctx, programName)
+}
+
+func validateAptitudeTest(test *mongodb.AptitudeTest) error {
+	switch {
+	case test.Name == "":
+		return fmt.Errorf("%w: test name is required", neo4j.ErrInvalidEntity)
+	case test.Kind != mongodb.TestKindAptitude && test.Kind != mongodb.TestKindPractical:
+		return fmt.Errorf("%w: kind of test %q must be %q or %q", neo4j.ErrInvalidEntity, test.Name, mongodb.TestKindAptitude, mongodb.TestKindPractical)
+	}
+	for _, date := range test.Dates {
+		if strings.TrimSpace(date.Label) == "" || date.Date.IsZero() {
+			return fmt.Errorf("%w: dates of test %q need a label and date", neo4j.ErrInvalidEntity, test.Name)
+		}
+	}
+	for _, paper := range test.PastPapers {
+		u, err := url.Parse(paper.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: past paper links of test %q must be http(s) URLs", neo4j.ErrInvalidEntity, test.Name)
+		}
+		if strings.TrimSpace(paper.Title) == "" {
+			return fmt.Errorf("%w: past papers of test %q need a title", neo4j.ErrInvalidEntity, test.Name)
+		}
+	}
+	return nil
+}
+
+// SaveLivingCost records what a student living away from home spends per month
+// in a district, replacing earlier figures
+func (s *Service) SaveLivingCost(ctx context.Context, districtName string, cost mongodb.LivingCost) (*mongodb.LivingCost, error) {
+	s.logger.Debug("Saving living cost", zap.String("district", districtName))
+
+	name, ok := district.Canonical(districtName)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown district %q", neo4j.ErrInvalidEntity, districtName)
+	}
+
+	maxYear := time.Now().Year()
+	switch {
+	case cost.Boarding < 0 || cost.Transport < 0 || cost.Food < 0:
+		return nil, fmt.Errorf("%w: costs cannot be negative", neo4j.ErrInvalidEntity)
+	case cost.Total() == 0:
+		return nil, fmt.Errorf("%w: at least one cost is required", neo4j.ErrInvalidEntity)
+	case cost.Year < minIntakeYear || cost.Year > maxYear:
+		return nil, fmt.Errorf("%w: year must be between %d and %d", neo4j.ErrInvalidEntity, minIntakeYear, maxYear)
+	}
+	cost.District = name
+	cost.Source = strings.TrimSpace(cost.Source)
+
+	if err := s.livingCosts.Upsert(ctx, &cost); err != nil {
+		s.logger.Error("Failed to save living cost",
+			zap.String("district", name),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Living cost saved",
+		zap.String("district", name),
+		zap.Int("total", cost.Total()))
+	return &cost, nil
+}
+
+// ListQualificationAliases returns every qualification and subject alias
+func (s *Service) ListQualificationAliases(ctx context.Context) ([]mongodb.QualificationAlias, error) {
+	aliases, err := s.aliases.List(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list qualification aliases", zap.Error(err))
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// SaveQualificationAlias makes an alias stand for a qualification or subject
+// name in eligibility, search and career path requests, replacing what it
+// stood for before. Aliases do not chain, so a name cannot itself be an alias.
+func (s *Service) SaveQualificationAlias(ctx context.Context, alias, name string) (*mongodb.QualificationAlias, error) {
+	s.logger.Debug("Saving qualification alias", zap.String("alias", alias))
+
+	saved := &mongodb.QualificationAlias{
+		Key:   mongodb.AliasKey(alias),
+		Alias: normalizeName(alias),
+		Name:  normalizeName(name),
+	}
+	switch {
+	case saved.Key == "":
+		return nil, fmt.Errorf("%w: alias needs a letter or digit", neo4j.ErrInvalidEntity)
+	case saved.Name == "":
+		return nil, fmt.Errorf("%w: name is required", neo4j.ErrInvalidEntity)
+	case mongodb.AliasKey(saved.Name) == saved.Key:
+		return nil, fmt.Errorf("%w: alias %q only respells %q", neo4j.ErrInvalidEntity, saved.Alias, saved.Name)
+	}
+
+	aliases, err := s.aliases.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range aliases {
+		if existing.Key == mongodb.AliasKey(saved.Name) {
+			return nil, fmt.Errorf("%w: %q is itself an alias of %q", neo4j.ErrInvalidEntity, saved.Name, existing.Name)
+		}
+		if mongodb.AliasKey(existing.Name) == saved.Key {
+			return nil, fmt.Errorf("%w: %q is what alias %q stands for", neo4j.ErrInvalidEntity, saved.Alias, existing.Alias)
+		}
+	}
+
+	if err := s.aliases.Upsert(ctx, saved); err != nil {
+		s.logger.Error("Failed to save qualification alias",
+			zap.String("alias", saved.Alias),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Qualification alias saved",
+		zap.String("alias", saved.Alias),
+		zap.String("name", saved.Name))
+	return saved, nil
+}
+
+// DeleteQualificationAlias removes an alias, given in any spelling it matches
+func (s *Service) DeleteQualificationAlias(ctx context.Context, alias string) error {
+	s.logger.Debug("Deleting qualification alias", zap.String("alias", alias))
+
+	deleted, err := s.aliases.Delete(ctx, mongodb.AliasKey(alias))
+	if err != nil {
+		s.logger.Error("Failed to delete qualification alias",
+			zap.String("alias", alias),
+			zap.Error(err))
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: alias %q", neo4j.ErrEntityNotFound, alias)
+	}
+
+	s.logger.Info("Qualification alias deleted", zap.String("alias", alias))
+	return nil
+}
+
+// DeleteEntity deletes a graph entity no other entity depends on. The entity
+// is kept, hidden, and can be brought back with RestoreEntity.
+func (s *Service) DeleteEntity(ctx context.Context, kind, name string, actor string) error {
+	s.logger.Debug("Deleting graph entity", zap.String("kind", kind), zap.String("name", name))
+
+	name = normalizeName(name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", neo4j.ErrInvalidEntity)
+	}
+
+	before := s.snapshot(ctx, kind, name)
+	if err := s.neo4jClient.DeleteEntity(ctx, kind, name); err != nil {
+		s.logger.Warn("Failed to delete graph entity",
+			zap.String("kind", kind),
+			zap.String("name", name),
+			zap.Error(err))
+		return err
+	}
+
+	s.recordChange(ctx, kind, ActionDelete, actor, before, nil)
+	s.emitChanges(ctx, mongodb.GraphChange{Kind: kind, Name: name, Action: ActionDelete})
+	return nil
+}
+
+// RestoreEntity brings back a deleted graph entity as it was when deleted
+func (s *Service) RestoreEntity(ctx context.Context, kind, name string, actor string) error {
+	s.logger.Debug("Restoring graph entity", zap.String("kind", kind), zap.String("name", name))
+
+	name = normalizeName(name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", neo4j.ErrInvalidEntity)
+	}
+
+	if err := s.neo4jClient.RestoreEntity(ctx, kind, name); err != nil {
+		s.logger.Warn("Failed to restore graph entity",
+			zap.String("kind", kind),
+			zap.String("name", name),
+			zap.Error(err))
+		return err
+	}
+
+	s.recordChange(ctx, kind, ActionRestore, actor, nil, s.snapshot(ctx, kind, name))
+	s.emitChanges(ctx, mongodb.GraphChange{Kind: kind, Name: name, Action: ActionRestore})
+	return nil
+}
+
+// LinkProgram links a program to one qualification it requires, program it
+// follows on from or career it leads to, without replacing its other
+// relationships. It reports whether the link is new; linking twice is not an
+// error.
+func (s *Service) LinkProgram(ctx context.Context, program, relation, target, actor string) (bool, error) {
+	return s.changeProgramLink(ctx, program, relation, target, actor, true)
+}
+
+// UnlinkProgram removes one relationship from a program. It reports whether
+// there was a relationship to remove.
+func (s *Service) UnlinkProgram(ctx context.Context, program, relation, target, actor string) (bool, error) {
+	return s.changeProgramLink(ctx, program, relation, target, actor, false)
+}
+
+func (s *Service) changeProgramLink(ctx context.Context, program, relation, target, actor string, link bool) (bool, error) {
+	s.logger.Debug("Changing program relationship",
+		zap.String("program", program),
+		zap.String("relation", relation),
+		zap.String("target", target),
+		zap.Bool("link", link))
+
+	program = normalizeName(program)
+	target = normalizeName(target)
+	if program == "" || target == "" {
+		return false, fmt.Errorf("%w: program and %s names are required", neo4j.ErrInvalidEntity, relation)
+	}
+
+	before := s.snapshot(ctx, neo4j.KindProgram, program)
+
+	var changed bool
+	var err error
+	detail := fmt.Sprintf("%s: added %s", relation, target)
+	if link {
+		changed, err = s.neo4jClient.LinkProgram(ctx, program, relation, target)
+	} else {
+		changed, err = s.neo4jClient.UnlinkProgram(ctx, program, relation, target)
+		detail = fmt.Sprintf("%s: removed %s", relation, target)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to change program relationship",
+			zap.String("program", program),
+			zap.String("relation", relation),
+			zap.String("target", target),
+			zap.Error(err))
+		return false, err
+	}
+	if !changed {
+		return false, nil
+	}
+
+	s.recordChange(ctx, neo4j.KindProgram, ActionUpdate, actor, before, s.snapshot(ctx, neo4j.KindProgram, program))
+	s.emitChanges(ctx, mongodb.GraphChange{
+		Kind:    neo4j.KindProgram,
+		Name:    program,
+		Action:  ActionUpdate,
+		Details: []string{detail},
+	})
+	return true, nil
+}
+
+// SearchEntities finds entities across kinds for data quality review. The page
+// size defaults to 50 and is capped at 200.
+func (s *Service) SearchEntities(ctx context.Context, search neo4j.EntitySearch) (*neo4j.EntitySearchResult, error) {
+	s.logger.Debug("Searching entities",
+		zap.String("query", search.Query),
+		zap.Strings("kinds", search.Kinds),
+		zap.Strings("issues", search.Issues))
+
+	for _, kind := range search.Kinds {
+		if !neo4j.IsEntityKind(kind) {
+			return nil, fmt.Errorf("%w: unknown entity type %q", ErrInvalidFilter, kind)
+		}
+	}
+	for _, issue := range search.Issues {
+		if !neo4j.IsEntityIssue(issue) {
+			return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidFilter, issue)
+		}
+	}
+	search.Query = normalizeName(search.Query)
+	search.Source = strings.TrimSpace(search.Source)
+	if search.Offset < 0 {
+		search.Offset = 0
+	}
+	if search.Limit <= 0 {
+		search.Limit = defaultSearchLimit
+	}
+	if search.Limit > maxSearchLimit {
+		search.Limit = maxSearchLimit
+	}
+
+	return s.neo4jClient.FindEntities(ctx, search)
+}
+
+// GetHistory returns the recorded admin changes to an entity, newest first
+func (s *Service) GetHistory(ctx context.Context, kind, name string, limit int) ([]mongodb.EntityChange, error) {
+	s.logger.Debug("Getting entity history", zap.String("kind", kind), zap.String("name", name))
+
+	name = normalizeName(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", neo4j.ErrInvalidEntity)
+	}
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+	return s.history.ListByEntity(ctx, kind, name, limit)
+}
+
+// RevertChange restores an entity to its state before a recorded change: a
+// created or restored entity is deleted, a deleted one restored and an updated one saved
+// with its earlier name, relationships and details. Changes made after the
+// reverted one are overwritten. The revert is itself recorded in the history.
+func (s *Service) RevertChange(ctx context.Context, kind, name, changeID, actor string) error {
+	s.logger.Debug("Reverting entity change",
+		zap.String("kind", kind),
+		zap.String("name", name),
+		zap.String("change_id", changeID))
+
+	id, err := primitive.ObjectIDFromHex(changeID)
+	if err != nil {
+		return ErrChangeNotFound
+	}
+	change, err := s.history.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	name = normalizeName(name)
+	if change == nil || change.Kind != kind || !slices.Contains(change.Names, name) {
+		return ErrChangeNotFound
+	}
+
+	created := change.Action == ActionCreate || change.Action == ActionRestore
+	if (!created && change.Before == nil) || (change.Action != ActionDelete && change.After == nil) {
+		return fmt.Errorf("%w: the change was recorded without the state needed to revert it", neo4j.ErrInvalidEntity)
+	}
+
+	switch change.Action {
+	case ActionCreate, ActionRestore:
+		err = s.DeleteEntity(ctx, kind, change.After.Name, actor)
+	case ActionDelete:
+		err = s.RestoreEntity(ctx, kind, change.Before.Name, actor)
+	default:
+		err = s.UpdateEntity(ctx, kind, change.After.Name, restoredEntity(kind, change.Before), actor)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Entity change reverted",
+		zap.String("kind", kind),
+		zap.String("name", name),
+		zap.String("change_id", changeID),
+		zap.String("actor", actor))
+	return nil
+}
+
+// snapshot returns the current state of an entity for its history, or nil when
+// it cannot be read; history is best effort and never blocks a change
+func (s *Service) snapshot(ctx context.Context, kind, name string) *mongodb.EntitySnapshot {
+	entity, err := s.neo4jClient.GetEntity(ctx, kind, name)
+	if err != nil {
+		if !errors.Is(err, neo4j.ErrEntityNotFound) {
+			s.logger.Warn("Failed to read entity for its history",
+				zap.String("kind", kind),
+				zap.String("name", name),
+				zap.Error(err))
+		}
+		return nil
+	}
+
+	var snapshot mongodb.EntitySnapshot
+	if err := convert(entity, &snapshot); err != nil {
+		s.logger.Warn("Failed to snapshot entity", zap.String("kind", kind), zap.String("name", name), zap.Error(err))
+		return nil
+	}
+	return &snapshot
+}
+
+// recordChange adds a change to the entity's history. Failures are logged only,
+// as the graph has already changed.
+func (s *Service) recordChange(ctx context.Context, kind, action, actor string, before, after *mongodb.EntitySnapshot) {
+	if before == nil && after == nil {
+		return
+	}
+	change := &mongodb.EntityChange{
+		Kind:   kind,
+		Action: action,
+		Actor:  actor,
+		Before: before,
+		After:  after,
+	}
+	if after != nil {
+		change.Name = after.Name
+	} else {
+		change.Name = before.Name
+	}
+
+	if err := s.history.Record(ctx, change); err != nil {
+		s.logger.Error("Failed to record entity change",
+			zap.String("kind", kind),
+			zap.String("name", change.Name),
+			zap.String("action", action),
+			zap.Error(err))
+	}
+}
+
+// restoredEntity turns a history snapshot back into an entity. Relationship
+// lists, program, scholarship and contact details the snapshot lacks are
+// cleared rather than left as is.
+func restoredEntity(kind string, snapshot *mongodb.EntitySnapshot) neo4j.GraphEntity {
+	var entity neo4j.GraphEntity
+	_ = convert(snapshot, &entity)
+
+	switch kind {
+	case neo4j.KindProgram:
+		entity.Requirements = nonNilNames(entity.Requirements)
+		entity.Prerequisites = nonNilNames(entity.Prerequisites)
+		entity.Careers = nonNilNames(entity.Careers)
+		if entity.Info == nil {
+			entity.Info = &neo4j.ProgramInfo{}
+		}
+	case neo4j.KindScholarship:
+		entity.Programs = nonNilNames(entity.Programs)
+		entity.Institutes = nonNilNames(entity.Institutes)
+		if entity.Scholarship == nil {
+			entity.Scholarship = &neo4j.ScholarshipInfo{}
+		}
+	case neo4j.KindInstitute:
+		if entity.Contact == nil {
+			entity.Contact = &neo4j.InstituteContact{}
+		}
+	}
+	return entity
+}
+
+// convert copies between an entity and its snapshot, which share a JSON shape
+func convert(from, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
+
+func nonNilNames(names []string) []string {
+	if names == nil {
+		return []string{}
+	}
+	return names
+}
+
+// emitChanges passes admin changes to the change log, whose subscribers drop
+// stale cached roadmaps and notify webhooks
+func (s *Service) emitChanges(ctx context.Context, changes ...mongodb.GraphChange) {
+	for i := range changes {
+		changes[i].Source = SourceAdmin
+	}
+	if err := s.changes.Emit(ctx, changes); err != nil {
+		s.logger.Warn("Failed to record admin changes", zap.Error(err))
+	}
+}
+
+// normalizeEntity trims all names in an entity, dropping empty list entries
+func normalizeEntity(entity neo4j.GraphEntity) neo4j.GraphEntity {
+	entity.Name = normalizeName(entity.Name)
+	entity.Institute = normalizeName(entity.Institute)
+	entity.Faculty = normalizeName(entity.Faculty)
+	entity.Department = normalizeName(entity.Department)
+	entity.Requirements = normalizeNames(entity.Requirements)
+	entity.Prerequisites = normalizeNames(entity.Prerequisites)
+	entity.Careers = normalizeNames(entity.Careers)
+	entity.Programs = normalizeNames(entity.Programs)
+	entity.Institutes = normalizeNames(entity.Institutes)
+	if entity.Contact != nil {
+		contact := *entity.Contact
+		contact.Address = strings.TrimSpace(contact.Address)
+		contact.District = normalizeName(contact.District)
+		if name, ok := district.Canonical(contact.District); ok {
+			contact.District = name
+		}
+		contact.Phone = normalizeName(contact.Phone)
+		contact.Email = strings.TrimSpace(contact.Email)
+		contact.Website = strings.TrimSpace(contact.Website)
+		contact.AdmissionsOffice = strings.TrimSpace(contact.AdmissionsOffice)
+		entity.Contact = &contact
+	}
+	if entity.Info != nil {
+		info := *entity.Info
+		info.DeliveryMode = strings.ReplaceAll(strings.ToLower(normalizeName(info.DeliveryMode)), " ", "_")
+		info.DeliveryMode = strings.ReplaceAll(info.DeliveryMode, "-", "_")
+		info.Mediums = normalizeTags(info.Mediums)
+		info.Schedules = normalizeTags(info.Schedules)
+		info.IntakeMonths = slices.Compact(slices.Sorted(slices.Values(info.IntakeMonths)))
+		entity.Info = &info
+	}
+	if entity.Scholarship != nil {
+		scholarship := *entity.Scholarship
+		scholarship.Provider = normalizeName(scholarship.Provider)
+		scholarship.Description = strings.TrimSpace(scholarship.Description)
+		scholarship.AmountPeriod = strings.ReplaceAll(strings.ToLower(normalizeName(scholarship.AmountPeriod)), " ", "_")
+		scholarship.ApplicationURL = strings.TrimSpace(scholarship.ApplicationURL)
+		eligibility := &scholarship.Eligibility
+		eligibility.Districts = normalizeNames(eligibility.Districts)
+		for i, name := range eligibility.Districts {
+			if canonical, ok := district.Canonical(name); ok {
+				eligibility.Districts[i] = canonical
+			}
+		}
+		eligibility.Criteria = normalizeNames(eligibility.Criteria)
+		entity.Scholarship = &scholarship
+	}
+	return entity
+}
+
+// validateContact checks that contact details are usable by students: a plain
+// email address, an http(s) website, a phone number made of dialable characters
+// and a known district
+func validateContact(contact *neo4j.InstituteContact) error {
+	if contact == nil {
+		return nil
+	}
+	if contact.Email != "" {
+		address, err := mail.ParseAddress(contact.Email)
+		if err != nil || address.Address != contact.Email {
+			return fmt.Errorf("%w: invalid email %q", neo4j.ErrInvalidEntity, contact.Email)
+		}
+	}
+	if contact.Website != "" {
+		u, err := url.Parse(contact.Website)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: website must be an http(s) URL", neo4j.ErrInvalidEntity)
+		}
+	}
+	if contact.Phone != "" && !phonePattern.MatchString(contact.Phone) {
+		return fmt.Errorf("%w: invalid phone number %q", neo4j.ErrInvalidEntity, contact.Phone)
+	}
+	if _, ok := district.Canonical(contact.District); contact.District != "" && !ok {
+		return fmt.Errorf("%w: unknown district %q", neo4j.ErrInvalidEntity, contact.District)
+	}
+	return nil
+}
+
+// validateProgramInfo checks that program details are plausible: a duration of
+// at most maxProgramMonths, a fee that is not negative, calendar months for
+// intakes and known delivery modes, mediums and schedules
+func validateProgramInfo(info *neo4j.ProgramInfo) error {
+	if info == nil {
+		return nil
+	}
+	if info.DurationMonths < 0 || info.DurationMonths > maxProgramMonths {
+		return fmt.Errorf("%w: duration must be between 1 and %d months", neo4j.ErrInvalidEntity, maxProgramMonths)
+	}
+	if info.AnnualFeeLKR != nil && *info.AnnualFeeLKR < 0 {
+		return fmt.Errorf("%w: annual fee cannot be negative", neo4j.ErrInvalidEntity)
+	}
+	for _, month := range info.IntakeMonths {
+		if month < 1 || month > 12 {
+			return fmt.Errorf("%w: invalid intake month %d", neo4j.ErrInvalidEntity, month)
+		}
+	}
+	if info.DeliveryMode != "" && !neo4j.IsDeliveryMode(info.DeliveryMode) {
+		return fmt.Errorf("%w: unknown delivery mode %q", neo4j.ErrInvalidEntity, info.DeliveryMode)
+	}
+	for _, medium := range info.Mediums {
+		if !neo4j.IsMedium(medium) {
+			return fmt.Errorf("%w: unknown medium of instruction %q", neo4j.ErrInvalidEntity, medium)
+		}
+	}
+	for _, schedule := range info.Schedules {
+		if !neo4j.IsSchedule(schedule) {
+			return fmt.Errorf("%w: unknown schedule %q", neo4j.ErrInvalidEntity, schedule)
+		}
+	}
+	return nil
+}
+
+// validateScholarship checks that a scholarship is usable by students: an
+// amount that is not negative and says what period it is paid for, an http(s)
+// application page, an income limit that is not negative and known districts
+func validateScholarship(scholarship *neo4j.ScholarshipInfo) error {
+	if scholarship == nil {
+		return nil
+	}
+	if scholarship.AmountLKR != nil && *scholarship.AmountLKR < 0 {
+		return fmt.Errorf("%w: scholarship amount cannot be negative", neo4j.ErrInvalidEntity)
+	}
+	if scholarship.AmountLKR != nil && scholarship.AmountPeriod == "" {
+		return fmt.Errorf("%w: a scholarship amount needs its amount_period", neo4j.ErrInvalidEntity)
+	}
+	if scholarship.AmountPeriod != "" && !neo4j.IsAmountPeriod(scholarship.AmountPeriod) {
+		return fmt.Errorf("%w: unknown amount period %q", neo4j.ErrInvalidEntity, scholarship.AmountPeriod)
+	}
+	if scholarship.ApplicationURL != "" {
+		u, err := url.Parse(scholarship.ApplicationURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: application URL must be an http(s) URL", neo4j.ErrInvalidEntity)
+		}
+	}
+	eligibility := scholarship.Eligibility
+	if eligibility.MaxMonthlyIncomeLKR != nil && *eligibility.MaxMonthlyIncomeLKR < 0 {
+		return fmt.Errorf("%w: income limit cannot be negative", neo4j.ErrInvalidEntity)
+	}
+	for _, name := range eligibility.Districts {
+		if _, ok := district.Canonical(name); !ok {
+			return fmt.Errorf("%w: unknown district %q", neo4j.ErrInvalidEntity, name)
+		}
+	}
+	return nil
+}
+
+// normalizeTags lowercases tag values such as mediums, dropping empty and
+// repeated ones
+func normalizeTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(normalizeName(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// normalizeNames keeps nil lists nil so updates can tell "unchanged" from "cleared"
+func normalizeNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = normalizeName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
@@ -0,0 +1,319 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/district"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/vacancies"
+	"go.uber.org/zap"
+)
+
+const (
+	// Groups smaller than this are suppressed from aggregate results
+	MinReportableCount = 5
+
+	// Defaults and bounds for trend queries
+	DefaultTrendDays  = 30
+	MaxTrendDays      = 365
+	DefaultTrendLimit = 20
+	MaxTrendLimit     = 100
+
+	// Longest value stored for an event
+	maxValueLength = 120
+
+	// Zero-result queries asked fewer times than this are left out of the gap
+	// report, dropping typos and one-off text that may identify someone
+	MinGapCount = 2
+)
+
+// ErrUnknownEventType is returned when trends are requested for an untracked event type
+var ErrUnknownEventType = errors.New("unknown event type")
+
+// Service records anonymized usage events and reports aggregate trends, and maps
+// educational and job opportunities by district
+type Service struct {
+	store       *mongodb.AnalyticsStore
+	neo4jClient *neo4j.Client
+	vacancies   *vacancies.Service
+	logger      *zap.Logger
+}
+
+// NewService creates a new analytics service
+func NewService(mongoClient *mongodb.Client, neo4jClient *neo4j.Client, logger *zap.Logger) *Service {
+	return &Service{
+		store:       mongodb.NewAnalyticsStore(mongoClient, logger),
+		neo4jClient: neo4jClient,
+		logger:      logger,
+	}
+}
+
+// UseVacancies sets the service whose open postings are counted per district.
+// Call it before the service handles requests.
+func (s *Service) UseVacancies(service *vacancies.Service) {
+	s.vacancies = service
+}
+
+// Record stores events asynchronously. Recording is best-effort so it never slows
+// down or fails the request that produced the events.
+func (s *Service) Record(events []mongodb.AnalyticsEvent) {
+	normalized := make([]mongodb.AnalyticsEvent, 0, len(events))
+	for _, event := range events {
+		value := normalizeValue(event.Value)
+		if event.EventType == "" || value == "" {
+			continue
+		}
+		if isGapEventType(event.EventType) {
+			// Queries differing only in case point at the same gap
+			value = strings.ToLower(value)
+		}
+		normalized = append(normalized, mongodb.AnalyticsEvent{
+			EventType: event.EventType,
+			Value:     value,
+			District:  NormalizeDistrict(event.District),
+		})
+	}
+	if len(normalized) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := s.store.Record(ctx, normalized); err != nil {
+			s.logger.Warn("Failed to record analytics events",
+				zap.Int("count", len(normalized)),
+				zap.Error(err))
+		}
+	}()
+}
+
+// TrendQuery selects the events aggregated in a trend report
+type TrendQuery struct {
+	EventType string
+	District  string
+	Days      int
+	Limit     int
+}
+
+// TrendReport contains aggregate counts for an event type
+type TrendReport struct {
+	EventType string               `json:"event_type"`
+	District  string               `json:"district,omitempty"`
+	Days      int                  `json:"days"`
+	MinCount  int64                `json:"min_reportable_count"`
+	Top       []mongodb.TrendItem  `json:"top"`
+	Daily     []mongodb.DailyCount `json:"daily"`
+}
+
+// GetTrends returns the most frequent values and daily volume for an event type
+func (s *Service) GetTrends(ctx context.Context, query TrendQuery) (*TrendReport, error) {
+	s.logger.Debug("Fetching analytics trends", zap.String("event_type", query.EventType))
+
+	if !IsKnownEventType(query.EventType) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, query.EventType)
+	}
+	days := clamp(query.Days, DefaultTrendDays, MaxTrendDays)
+	limit := clamp(query.Limit, DefaultTrendLimit, MaxTrendLimit)
+	district := ""
+	if query.District != "" {
+		district = NormalizeDistrict(query.District)
+	}
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+
+	top, err := s.store.TopValues(ctx, query.EventType, district, since, MinReportableCount, limit)
+	if err != nil {
+		s.logger.Error("Failed to fetch top analytics values", zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch trends: %w", err)
+	}
+
+	daily, err := s.store.DailyCounts(ctx, query.EventType, district, since)
+	if err != nil {
+		s.logger.Error("Failed to fetch daily analytics counts", zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch trends: %w", err)
+	}
+
+	// Suppress days with too few events to report
+	reportable := make([]mongodb.DailyCount, 0, len(daily))
+	for _, d := range daily {
+		if d.Count >= MinReportableCount {
+			reportable = append(reportable, d)
+		}
+	}
+
+	return &TrendReport{
+		EventType: query.EventType,
+		District:  district,
+		Days:      days,
+		MinCount:  MinReportableCount,
+		Top:       top,
+		Daily:     reportable,
+	}, nil
+}
+
+// GetDistrictBreakdown returns event volume per district
+func (s *Service) GetDistrictBreakdown(ctx context.Context, eventType string, days int) ([]mongodb.TrendItem, error) {
+	s.logger.Debug("Fetching analytics district breakdown", zap.String("event_type", eventType))
+
+	if eventType != "" && !IsKnownEventType(eventType) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+	days = clamp(days, DefaultTrendDays, MaxTrendDays)
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+
+	items, err := s.store.DistrictCounts(ctx, eventType, since, MinReportableCount)
+	if err != nil {
+		s.logger.Error("Failed to fetch district analytics", zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch district breakdown: %w", err)
+	}
+	return items, nil
+}
+
+// DistrictOpportunities are the institutes, programs and open job postings in a
+// district
+type DistrictOpportunities struct {
+	District   string `json:"district"`
+	Institutes int    `json:"institutes"`
+	Programs   int    `json:"programs"`
+	Vacancies  int64  `json:"vacancies"`
+}
+
+// OpportunityMap lists the opportunities in every district, including those
+// with none, so access gaps show on a map. Institutes without a recorded
+// district are counted apart.
+type OpportunityMap struct {
+	Districts          []DistrictOpportunities `json:"districts"`
+	UnplacedInstitutes int                     `json:"unplaced_institutes"`
+	UnplacedPrograms   int                     `json:"unplaced_programs"`
+}
+
+// GetOpportunityMap counts institutes, programs and open job postings per district
+func (s *Service) GetOpportunityMap(ctx context.Context) (*OpportunityMap, error) {
+	s.logger.Debug("Fetching district opportunity map")
+
+	counts, err := s.neo4jClient.GetDistrictCounts(ctx)
+	if err != nil {
+		s.logger.Error("Failed to count institutes by district", zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch opportunity map: %w", err)
+	}
+	openings := map[string]int64{}
+	if s.vacancies != nil {
+		if openings, err = s.vacancies.OpenByDistrict(ctx); err != nil {
+			s.logger.Error("Failed to count vacancies by district", zap.Error(err))
+			return nil, fmt.Errorf("failed to fetch opportunity map: %w", err)
+		}
+	}
+
+	result := &OpportunityMap{Districts: make([]DistrictOpportunities, 0, len(district.Names))}
+	index := make(map[string]int, len(district.Names))
+	for _, name := range district.Names {
+		index[name] = len(result.Districts)
+		result.Districts = append(result.Districts, DistrictOpportunities{
+			District:  name,
+			Vacancies: openings[name],
+		})
+	}
+	for _, count := range counts {
+		name, ok := district.Canonical(count.District)
+		if !ok {
+			result.UnplacedInstitutes += count.Institutes
+			result.UnplacedPrograms += count.Programs
+			continue
+		}
+		entry := &result.Districts[index[name]]
+		entry.Institutes += count.Institutes
+		entry.Programs += count.Programs
+	}
+
+	s.logger.Info("District opportunity map fetched",
+		zap.Int("unplaced_institutes", result.UnplacedInstitutes))
+	return result, nil
+}
+
+// GapReport lists the searches and eligibility checks that most often found
+// nothing, pointing at programs and qualification aliases missing from the graph
+type GapReport struct {
+	Days        int               `json:"days"`
+	MinCount    int64             `json:"min_count"`
+	Searches    []mongodb.GapItem `json:"searches"`
+	Eligibility []mongodb.GapItem `json:"eligibility"`
+}
+
+// GetGapReport returns the most frequent zero-result searches and eligibility
+// checks of the last days
+func (s *Service) GetGapReport(ctx context.Context, days, limit int) (*GapReport, error) {
+	s.logger.Debug("Fetching zero-result report", zap.Int("days", days))
+
+	days = clamp(days, DefaultTrendDays, MaxTrendDays)
+	limit = clamp(limit, DefaultTrendLimit, MaxTrendLimit)
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+
+	report := &GapReport{Days: days, MinCount: MinGapCount}
+	for eventType, items := range map[string]*[]mongodb.GapItem{
+		mongodb.EventSearchNoResults:      &report.Searches,
+		mongodb.EventEligibilityNoResults: &report.Eligibility,
+	} {
+		values, err := s.store.GapValues(ctx, eventType, since, MinGapCount, limit)
+		if err != nil {
+			s.logger.Error("Failed to fetch zero-result queries",
+				zap.String("event_type", eventType),
+				zap.Error(err))
+			return nil, fmt.Errorf("failed to fetch zero-result report: %w", err)
+		}
+		*items = values
+	}
+
+	s.logger.Info("Zero-result report fetched",
+		zap.Int("searches", len(report.Searches)),
+		zap.Int("eligibility", len(report.Eligibility)))
+	return report, nil
+}
+
+// isGapEventType reports whether an event type records a query that found
+// nothing. These are reported to admins only, not through public trends.
+func isGapEventType(eventType string) bool {
+	return eventType == mongodb.EventSearchNoResults || eventType == mongodb.EventEligibilityNoResults
+}
+
+// IsKnownEventType reports whether an event type is tracked
+func IsKnownEventType(eventType string) bool {
+	switch eventType {
+	case mongodb.EventQualificationSearched, mongodb.EventCareerTargeted:
+		return true
+	}
+	return false
+}
+
+// NormalizeDistrict lowercases a district name, defaulting to "unknown"
+func NormalizeDistrict(district string) string {
+	district = normalizeValue(district)
+	if district == "" {
+		return mongodb.UnknownDistrict
+	}
+	return strings.ToLower(district)
+}
+
+// normalizeValue trims and collapses whitespace and caps the length of free text
+func normalizeValue(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if runes := []rune(value); len(runes) > maxValueLength {
+		value = string(runes[:maxValueLength])
+	}
+	return value
+}
+
+func clamp(value, fallback, max int) int {
+	if value <= 0 {
+		return fallback
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,476 @@
+// Package apikeys issues partner API keys and meters their use: requests, the
+// LLM generations those requests trigger and the bytes they transfer, counted per
+// calendar month (UTC) against each key's quota.
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.uber.org/zap"
+)
+
+const (
+	// secretPrefix starts every key secret, so leaked keys are easy to recognize
+	secretPrefix = "plk_"
+
+	// keyCacheTTL is how long a key looked up by its secret is trusted before it
+	// is read again; a key revoked on another instance is honored within it
+	keyCacheTTL = time.Minute
+
+	// maxCachedKeys bounds the key cache, which also remembers unknown secrets
+	maxCachedKeys = 10000
+
+	// historyMonths is how many months of usage a report lists
+	historyMonths = 12
+
+	// MonthLayout formats the months usage is counted in
+	MonthLayout = "2006-01"
+)
+
+var (
+	// ErrKeyNotFound is returned for key IDs that do not exist
+	ErrKeyNotFound = errors.New("API key not found")
+
+	// ErrInvalidKey is returned for secrets that belong to no key
+	ErrInvalidKey = errors.New("invalid API key")
+
+	// ErrKeyRevoked is returned for secrets of revoked keys
+	ErrKeyRevoked = errors.New("API key has been revoked")
+
+	// ErrQuotaExceeded is returned once a key has used up a monthly quota
+	ErrQuotaExceeded = errors.New("API key quota exceeded")
+
+	// ErrInvalidKeyRequest is returned for keys without a name or with negative quotas
+	ErrInvalidKeyRequest = errors.New("invalid API key request")
+
+	// ErrInvalidMonth is returned for usage months not formatted as YYYY-MM
+	ErrInvalidMonth = errors.New("month must be formatted as YYYY-MM")
+)
+
+// CreatedKey is a new key with its secret, which is not shown again
+type CreatedKey struct {
+	mongodb.APIKey
+	Secret string `json:"secret"`
+}
+
+// UsageReport is a key's use in one month against its quota, with its recent
+// monthly history
+type UsageReport struct {
+	Key      mongodb.APIKey        `json:"key"`
+	Month    string                `json:"month"`
+	Usage    mongodb.UsageCounts   `json:"usage"`
+	Quota    mongodb.APIKeyQuota   `json:"quota"`
+	Exceeded []string              `json:"exceeded"`
+	History  []mongodb.APIKeyUsage `json:"history"`
+}
+
+// cachedKey is a key looked up by its secret; key is nil for unknown secrets
+type cachedKey struct {
+	key      *mongodb.APIKey
+	loadedAt time.Time
+}
+
+// meter counts a key's use in one month. Stored is the month's total across all
+// instances as of the last load or flush, pending what this instance counted
+// since.
+type meter struct {
+	keyID   string
+	month   string
+	stored  mongodb.UsageCounts
+	pending mongodb.UsageCounts
+	loaded  bool
+}
+
+func (m *meter) used() mongodb.UsageCounts {
+	return mongodb.UsageCounts{
+		Requests:    m.stored.Requests + m.pending.Requests,
+		Generations: m.stored.Generations + m.pending.Generations,
+		Bytes:       m.stored.Bytes + m.pending.Bytes,
+	}
+}
+
+// Service issues API keys and meters their use. Use is counted in memory and
+// added to the stored monthly totals by Flush, so quotas are enforced against
+// totals that lag other instances by up to the flush interval.
+type Service struct {
+	store  *mongodb.APIKeyStore
+	cfg    config.APIKeysConfig
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	keys   map[string]cachedKey // by secret hash
+	meters map[string]*meter    // by key ID and month
+}
+
+// NewService creates a new API key service
+func NewService(mongoClient *mongodb.Client, cfg config.APIKeysConfig, logger *zap.Logger) *Service {
+	return &Service{
+		store:  mongodb.NewAPIKeyStore(mongoClient, logger),
+		cfg:    cfg,
+		logger: logger,
+		keys:   make(map[string]cachedKey),
+		meters: make(map[string]*meter),
+	}
+}
+
+// Create issues a new key. A nil quota takes the configured defaults.
+func (s *Service) Create(ctx context.Context, name string, quota *mongodb.APIKeyQuota) (*CreatedKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: a name is required", ErrInvalidKeyRequest)
+	}
+	if quota == nil {
+		quota = &mongodb.APIKeyQuota{
+			MonthlyRequests:    s.cfg.DefaultMonthlyRequests,
+			MonthlyGenerations: s.cfg.DefaultMonthlyGenerations,
+			MonthlyBytes:       s.cfg.DefaultMonthlyBytes,
+		}
+	}
+	if err := validateQuota(*quota); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("Creating API key", zap.String("name", name))
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	secret := secretPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	key := &mongodb.APIKey{
+		Name:    name,
+		KeyHash: hashSecret(secret),
+		Prefix:  secret[:len(secretPrefix)+6],
+		Quota:   *quota,
+	}
+	if err := s.store.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("API key created",
+		zap.String("key_id", key.ID),
+		zap.String("name", name))
+	return &CreatedKey{APIKey: *key, Secret: secret}, nil
+}
+
+// List returns all keys, newest first
+func (s *Service) List(ctx context.Context) ([]mongodb.APIKey, error) {
+	return s.store.List(ctx)
+}
+
+// SetQuota replaces a key's quota
+func (s *Service) SetQuota(ctx context.Context, id string, quota mongodb.APIKeyQuota) (*mongodb.APIKey, error) {
+	if err := validateQuota(quota); err != nil {
+		return nil, err
+	}
+
+	key, err := s.store.SetQuota(ctx, id, quota)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+	}
+	s.remember(key)
+
+	s.logger.Info("API key quota updated", zap.String("key_id", id))
+	return key, nil
+}
+
+// Revoke revokes a key; requests made with it are rejected from then on
+func (s *Service) Revoke(ctx context.Context, id string) (*mongodb.APIKey, error) {
+	key, err := s.store.Revoke(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+	}
+	s.remember(key)
+
+	s.logger.Info("API key revoked", zap.String("key_id", id))
+	return key, nil
+}
+
+// Usage reports a key's use in a month, the current one when month is empty,
+// including what this instance has not flushed yet
+func (s *Service) Usage(ctx context.Context, id, month string) (*UsageReport, error) {
+	if month == "" {
+		month = time.Now().UTC().Format(MonthLayout)
+	} else if _, err := time.Parse(MonthLayout, month); err != nil {
+		return nil, fmt.Errorf("%w, got %q", ErrInvalidMonth, month)
+	}
+
+	key, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+	}
+
+	stored, err := s.store.GetUsage(ctx, id, month)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.store.ListUsage(ctx, id, historyMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UsageReport{
+		Key:     *key,
+		Month:   month,
+		Quota:   key.Quota,
+		History: history,
+	}
+	if stored != nil {
+		report.Usage = stored.UsageCounts
+	}
+	s.mu.Lock()
+	if m := s.meters[meterKey(id, month)]; m != nil {
+		report.Usage.Requests += m.pending.Requests
+		report.Usage.Generations += m.pending.Generations
+		report.Usage.Bytes += m.pending.Bytes
+	}
+	s.mu.Unlock()
+	report.Exceeded = exceeded(report.Usage, key.Quota, false)
+	return report, nil
+}
+
+// Authenticate returns the key a secret belongs to
+func (s *Service) Authenticate(ctx context.Context, secret string) (*mongodb.APIKey, error) {
+	hash := hashSecret(secret)
+
+	s.mu.Lock()
+	cached, ok := s.keys[hash]
+	s.mu.Unlock()
+
+	if !ok || time.Since(cached.loadedAt) > keyCacheTTL {
+		key, err := s.store.GetByHash(ctx, hash)
+		if err != nil {
+			s.logger.Warn("Failed to look up API key", zap.Error(err))
+			return nil, err
+		}
+		cached = cachedKey{key: key, loadedAt: time.Now()}
+
+		s.mu.Lock()
+		if len(s.keys) >= maxCachedKeys {
+			clear(s.keys)
+		}
+		s.keys[hash] = cached
+		s.mu.Unlock()
+	}
+
+	switch {
+	case cached.key == nil:
+		return nil, ErrInvalidKey
+	case cached.key.RevokedAt != nil:
+		return nil, ErrKeyRevoked
+	}
+	return cached.key, nil
+}
+
+// Admit counts a request made with a key, unless the key has used up its monthly
+// request or bandwidth quota
+func (s *Service) Admit(ctx context.Context, key *mongodb.APIKey) error {
+	m := s.meter(key, time.Now())
+	s.load(ctx, m)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if over := exceeded(m.used(), key.Quota, true); len(over) > 0 {
+		return fmt.Errorf("%w: monthly %s quota reached", ErrQuotaExceeded, over[0])
+	}
+	m.pending.Requests++
+	return nil
+}
+
+// GenerationMeter returns the LLM generation meter of a request made with a key,
+// which counts generations until the key has used up its monthly quota of them
+func (s *Service) GenerationMeter(key *mongodb.APIKey) func() bool {
+	return func() bool {
+		m := s.meter(key, time.Now())
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if key.Quota.MonthlyGenerations > 0 && m.used().Generations >= key.Quota.MonthlyGenerations {
+			return false
+		}
+		m.pending.Generations++
+		return true
+	}
+}
+
+// RecordBytes counts bytes transferred for a request made with a key
+func (s *Service) RecordBytes(key *mongodb.APIKey, bytes int64) {
+	if bytes <= 0 {
+		return
+	}
+	m := s.meter(key, time.Now())
+
+	s.mu.Lock()
+	m.pending.Bytes += bytes
+	s.mu.Unlock()
+}
+
+// Flush adds the use counted since the last flush to the stored monthly totals.
+// Counts that cannot be stored are kept for the next flush.
+func (s *Service) Flush(ctx context.Context) error {
+	type flush struct {
+		meter  *meter
+		counts mongodb.UsageCounts
+	}
+
+	s.mu.Lock()
+	var flushes []flush
+	for _, m := range s.meters {
+		if !m.pending.IsZero() {
+			flushes = append(flushes, flush{meter: m, counts: m.pending})
+			m.pending = mongodb.UsageCounts{}
+		}
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, f := range flushes {
+		usage, err := s.store.AddUsage(ctx, f.meter.keyID, f.meter.month, f.counts)
+
+		s.mu.Lock()
+		if err != nil {
+			f.meter.pending.Requests += f.counts.Requests
+			f.meter.pending.Generations += f.counts.Generations
+			f.meter.pending.Bytes += f.counts.Bytes
+			errs = append(errs, err)
+		} else {
+			f.meter.stored = usage.UsageCounts
+			f.meter.loaded = true
+		}
+		s.mu.Unlock()
+	}
+
+	// Meters of past months are done with once flushed
+	month := time.Now().UTC().Format(MonthLayout)
+	s.mu.Lock()
+	for id, m := range s.meters {
+		if m.month != month && m.pending.IsZero() {
+			delete(s.meters, id)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(errs) > 0 {
+		s.logger.Warn("Failed to store API key usage",
+			zap.Int("failed", len(errs)),
+			zap.Int("meters", len(flushes)))
+		return errors.Join(errs...)
+	}
+	if len(flushes) > 0 {
+		s.logger.Debug("API key usage stored", zap.Int("meters", len(flushes)))
+	}
+	return nil
+}
+
+// NextMonth returns when the month of t ends and quotas start over
+func NextMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// meter returns the meter of a key in the month of now
+func (s *Service) meter(key *mongodb.APIKey, now time.Time) *meter {
+	month := now.UTC().Format(MonthLayout)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.meters[meterKey(key.ID, month)]
+	if m == nil {
+		m = &meter{keyID: key.ID, month: month}
+		s.meters[meterKey(key.ID, month)] = m
+	}
+	return m
+}
+
+// load reads the stored monthly total of a meter the first time it is used. A
+// failed read is retried on the next request; until then the meter counts from
+// zero rather than turning requests away.
+func (s *Service) load(ctx context.Context, m *meter) {
+	s.mu.Lock()
+	loaded := m.loaded
+	s.mu.Unlock()
+	if loaded {
+		return
+	}
+
+	usage, err := s.store.GetUsage(ctx, m.keyID, m.month)
+	if err != nil {
+		s.logger.Warn("Failed to load API key usage",
+			zap.String("key_id", m.keyID),
+			zap.Error(err))
+		return
+	}
+
+	s.mu.Lock()
+	if !m.loaded {
+		if usage != nil {
+			m.stored = usage.UsageCounts
+		}
+		m.loaded = true
+	}
+	s.mu.Unlock()
+}
+
+// remember replaces a cached key after it changed
+func (s *Service) remember(key *mongodb.APIKey) {
+	s.mu.Lock()
+	s.keys[key.KeyHash] = cachedKey{key: key, loadedAt: time.Now()}
+	s.mu.Unlock()
+}
+
+// exceeded names the quotas the used counts have reached. Requests are admitted
+// regardless of the generations quota: a key out of generations gets templates
+// instead of generated roadmaps, and may still make other requests.
+func exceeded(used mongodb.UsageCounts, quota mongodb.APIKeyQuota, admitting bool) []string {
+	over := []string{}
+	if quota.MonthlyRequests > 0 && used.Requests >= quota.MonthlyRequests {
+		over = append(over, "requests")
+	}
+	if quota.MonthlyBytes > 0 && used.Bytes >= quota.MonthlyBytes {
+		over = append(over, "bandwidth")
+	}
+	if !admitting && quota.MonthlyGenerations > 0 && used.Generations >= quota.MonthlyGenerations {
+		over = append(over, "generations")
+	}
+	return over
+}
+
+func validateQuota(quota mongodb.APIKeyQuota) error {
+	if quota.MonthlyRequests < 0 || quota.MonthlyGenerations < 0 || quota.MonthlyBytes < 0 {
+		return fmt.Errorf("%w: quotas must not be negative", ErrInvalidKeyRequest)
+	}
+	return nil
+}
+
+func meterKey(keyID, month string) string {
+	return keyID + "/" + month
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,159 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrChecksumMismatch is returned when an imported snapshot does not match the
+	// checksum given at export
+	ErrChecksumMismatch = errors.New("snapshot checksum does not match")
+
+	// ErrInvalidSnapshot is returned for imported data that is not a snapshot
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+)
+
+// PromotionExport is a snapshot of the curated data of this environment, ready to
+// be imported into another one
+type PromotionExport struct {
+	Data          []byte
+	Checksum      string
+	CreatedAt     time.Time
+	Nodes         int
+	Relationships int
+}
+
+// PromotionResult summarises a snapshot imported from another environment
+type PromotionResult struct {
+	Checksum      string    `json:"checksum"`
+	ExportedAt    time.Time `json:"exported_at"`
+	RollbackPoint string    `json:"rollback_point"`
+	Nodes         int       `json:"nodes"`
+	Relationships int       `json:"relationships"`
+	Documents     int       `json:"documents"`
+	PromotedAt    time.Time `json:"promoted_at"`
+}
+
+// Checksum returns the hex SHA-256 of snapshot data
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ExportForPromotion snapshots the graph and the curated collections, such as a
+// reviewed staging graph about to be promoted to production
+func (s *Service) ExportForPromotion(ctx context.Context) (*PromotionExport, error) {
+	if !s.running.TryLock() {
+		return nil, ErrBusy
+	}
+	defer s.running.Unlock()
+
+	snapshot, err := s.snapshot(ctx, "promotion export", mongodb.PromotionCollections)
+	if err != nil {
+		return nil, err
+	}
+	data, err := encodeSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &PromotionExport{
+		Data:          data,
+		Checksum:      Checksum(data),
+		CreatedAt:     snapshot.CreatedAt,
+		Nodes:         len(snapshot.Graph.Nodes),
+		Relationships: len(snapshot.Graph.Relationships),
+	}
+
+	s.logger.Info("Snapshot exported for promotion",
+		zap.String("checksum", export.Checksum),
+		zap.Int("size", len(data)),
+		zap.Int("nodes", export.Nodes))
+	return export, nil
+}
+
+// ImportPromotion replaces the graph and curated collections with a snapshot
+// exported from another environment. The data must match the checksum from the
+// export. A backup of the current state is stored first as a rollback point, and
+// is applied again automatically if the import fails part way.
+func (s *Service) ImportPromotion(ctx context.Context, data []byte, checksum string) (*PromotionResult, error) {
+	if s.store == nil {
+		// The rollback point needs somewhere to live
+		return nil, ErrStorageNotConfigured
+	}
+
+	checksum = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(checksum), "sha256:"))
+	actual := Checksum(data)
+	if checksum != actual {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, checksum, actual)
+	}
+
+	snapshot, err := decodeSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+
+	if !s.running.TryLock() {
+		return nil, ErrBusy
+	}
+	defer s.running.Unlock()
+
+	s.logger.Debug("Importing promoted snapshot",
+		zap.String("checksum", actual),
+		zap.Time("exported_at", snapshot.CreatedAt))
+
+	current, err := s.snapshot(ctx, "pre-promotion of "+actual[:12], mongodb.BackupCollections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot current state: %w", err)
+	}
+	rollback, err := s.save(ctx, current, "-pre-promotion")
+	if err != nil {
+		return nil, fmt.Errorf("failed to store rollback point: %w", err)
+	}
+
+	documents, err := s.apply(ctx, snapshot, mongodb.PromotionCollections)
+	if err != nil {
+		// The graph is replaced in one transaction but the collections are not, so
+		// put back everything the import may have touched
+		if _, rollbackErr := s.apply(ctx, current, mongodb.PromotionCollections); rollbackErr != nil {
+			s.logger.Error("Failed to roll back promotion",
+				zap.String("rollback_point", rollback.Name),
+				zap.Error(rollbackErr))
+			return nil, fmt.Errorf("promotion failed (%v) and rollback failed, restore backup %s: %w", err, rollback.Name, rollbackErr)
+		}
+		// Reads during the import may have cached the promoted graph
+		s.emitReplaced(ctx, rollback.Name, "rolled back after a failed promotion")
+		s.logger.Warn("Promotion failed and was rolled back",
+			zap.String("rollback_point", rollback.Name),
+			zap.Error(err))
+		return nil, fmt.Errorf("promotion failed and was rolled back: %w", err)
+	}
+
+	result := &PromotionResult{
+		Checksum:      actual,
+		ExportedAt:    snapshot.CreatedAt,
+		RollbackPoint: rollback.Name,
+		Nodes:         len(snapshot.Graph.Nodes),
+		Relationships: len(snapshot.Graph.Relationships),
+		Documents:     documents,
+		PromotedAt:    time.Now().UTC(),
+	}
+	s.emitReplaced(ctx, "promotion "+actual[:12], "promoted from another environment")
+
+	s.logger.Info("Promoted snapshot imported",
+		zap.String("checksum", actual),
+		zap.String("rollback_point", rollback.Name),
+		zap.Int("nodes", result.Nodes),
+		zap.Int("documents", documents))
+
+	return result, nil
+}
@@ -0,0 +1,451 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"github.com/mayura-andrew/fastfinder/pkg/objectstore"
+	"go.uber.org/zap"
+)
+
+const (
+	// FormatVersion is the version of the backup file layout
+	FormatVersion = 1
+
+	// nameLayout formats backup names, which sort chronologically
+	nameLayout = "20060102T150405Z"
+
+	// fileSuffix is appended to backup names to form object keys
+	fileSuffix = ".json.gz"
+
+	// SourceBackup identifies graph changes made by restores and promotions
+	SourceBackup = "backup"
+)
+
+var (
+	// ErrStorageNotConfigured is returned when no backup storage is available
+	ErrStorageNotConfigured = errors.New("backup storage is not configured")
+
+	// ErrBackupNotFound is returned when restoring a backup that does not exist
+	ErrBackupNotFound = errors.New("backup not found")
+
+	// ErrInvalidBackupName is returned for malformed backup names
+	ErrInvalidBackupName = errors.New("invalid backup name")
+
+	// ErrBusy is returned when a backup or restore is already running
+	ErrBusy = errors.New("a backup or restore is already running")
+)
+
+// namePattern matches backup names, with an optional suffix such as "-pre-restore"
+var namePattern = regexp.MustCompile(`^\d{8}T\d{6}Z(-[a-z-]+)?$`)
+
+// Snapshot is the content of a backup file
+type Snapshot struct {
+	FormatVersion int                          `json:"format_version"`
+	CreatedAt     time.Time                    `json:"created_at"`
+	Reason        string                       `json:"reason"`
+	Graph         *neo4j.GraphSnapshot         `json:"graph"`
+	Collections   map[string][]json.RawMessage `json:"collections"`
+}
+
+// Info describes a stored backup
+type Info struct {
+	Name          string    `json:"name"`
+	Key           string    `json:"key"`
+	Size          int64     `json:"size"`
+	CreatedAt     time.Time `json:"created_at"`
+	Nodes         int       `json:"nodes,omitempty"`
+	Relationships int       `json:"relationships,omitempty"`
+	Documents     int       `json:"documents,omitempty"`
+}
+
+// RestoreResult summarises a restore
+type RestoreResult struct {
+	Restored      string    `json:"restored"`
+	SafetyBackup  string    `json:"safety_backup"`
+	Nodes         int       `json:"nodes"`
+	Relationships int       `json:"relationships"`
+	Documents     int       `json:"documents"`
+	RestoredAt    time.Time `json:"restored_at"`
+}
+
+// Service exports the graph and cache collections to object storage, prunes old
+// backups and restores a chosen backup
+type Service struct {
+	neo4jClient *neo4j.Client
+	mongoClient *mongodb.Client
+	changes     *changelog.Service
+	store       objectstore.Store
+	cfg         config.BackupConfig
+	logger      *zap.Logger
+	running     sync.Mutex
+}
+
+// NewService creates a new backup service. A nil store disables backups.
+func NewService(neo4jClient *neo4j.Client, mongoClient *mongodb.Client, changelogService *changelog.Service, store objectstore.Store, cfg config.BackupConfig, logger *zap.Logger) *Service {
+	return &Service{
+		neo4jClient: neo4jClient,
+		mongoClient: mongoClient,
+		changes:     changelogService,
+		store:       store,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+// NewStore creates the object store selected in the backup configuration
+func NewStore(cfg config.BackupConfig) (objectstore.Store, error) {
+	switch strings.ToLower(cfg.Storage) {
+	case "", "local":
+		return objectstore.NewLocalStore(cfg.LocalDir)
+	case "s3", "gcs":
+		return objectstore.NewS3Store(objectstore.S3Config{
+			Endpoint:  cfg.Endpoint,
+			Region:    cfg.Region,
+			Bucket:    cfg.Bucket,
+			AccessKey: cfg.AccessKey,
+			SecretKey: cfg.SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown backup storage %q", cfg.Storage)
+	}
+}
+
+// Run creates a backup and prunes expired ones; it is the scheduled job
+func (s *Service) Run(ctx context.Context) error {
+	if _, err := s.Create(ctx, "scheduled"); err != nil {
+		return err
+	}
+	_, err := s.Prune(ctx)
+	return err
+}
+
+// Create exports the graph and cache collections into a new backup
+func (s *Service) Create(ctx context.Context, reason string) (*Info, error) {
+	if s.store == nil {
+		return nil, ErrStorageNotConfigured
+	}
+	if !s.running.TryLock() {
+		return nil, ErrBusy
+	}
+	defer s.running.Unlock()
+
+	return s.create(ctx, reason, "")
+}
+
+func (s *Service) create(ctx context.Context, reason, suffix string) (*Info, error) {
+	snapshot, err := s.snapshot(ctx, reason, mongodb.BackupCollections)
+	if err != nil {
+		return nil, err
+	}
+	return s.save(ctx, snapshot, suffix)
+}
+
+// snapshot exports the graph and the given collections
+func (s *Service) snapshot(ctx context.Context, reason string, collections []string) (*Snapshot, error) {
+	s.logger.Debug("Creating backup", zap.String("reason", reason))
+
+	graph, err := s.neo4jClient.ExportGraph(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export graph: %w", err)
+	}
+
+	snapshot := &Snapshot{
+		FormatVersion: FormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		Reason:        reason,
+		Graph:         graph,
+		Collections:   make(map[string][]json.RawMessage),
+	}
+
+	for _, name := range collections {
+		docs, err := s.mongoClient.ExportCollection(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Collections[name] = docs
+	}
+	return snapshot, nil
+}
+
+// save uploads a snapshot as a backup named after its creation time
+func (s *Service) save(ctx context.Context, snapshot *Snapshot, suffix string) (*Info, error) {
+	data, err := encodeSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	name := snapshot.CreatedAt.Format(nameLayout) + suffix
+	key := s.key(name)
+	if err := s.store.Put(ctx, key, data, "application/gzip"); err != nil {
+		return nil, fmt.Errorf("failed to upload backup: %w", err)
+	}
+
+	documents := 0
+	for _, docs := range snapshot.Collections {
+		documents += len(docs)
+	}
+	info := &Info{
+		Name:          name,
+		Key:           key,
+		Size:          int64(len(data)),
+		CreatedAt:     snapshot.CreatedAt,
+		Nodes:         len(snapshot.Graph.Nodes),
+		Relationships: len(snapshot.Graph.Relationships),
+		Documents:     documents,
+	}
+
+	s.logger.Info("Backup created",
+		zap.String("name", name),
+		zap.String("reason", snapshot.Reason),
+		zap.Int64("size", info.Size),
+		zap.Int("nodes", info.Nodes),
+		zap.Int("documents", documents))
+
+	return info, nil
+}
+
+// List returns stored backups, newest first
+func (s *Service) List(ctx context.Context) ([]Info, error) {
+	if s.store == nil {
+		return nil, ErrStorageNotConfigured
+	}
+
+	objects, err := s.store.List(ctx, s.cfg.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	backups := []Info{}
+	for _, obj := range objects {
+		name := strings.TrimSuffix(strings.TrimPrefix(obj.Key, s.cfg.Prefix), fileSuffix)
+		if !namePattern.MatchString(name) || !strings.HasSuffix(obj.Key, fileSuffix) {
+			continue
+		}
+		createdAt, err := time.Parse(nameLayout, name[:len(nameLayout)])
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Info{
+			Name:      name,
+			Key:       obj.Key,
+			Size:      obj.Size,
+			CreatedAt: createdAt,
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Name > backups[j].Name })
+	return backups, nil
+}
+
+// Prune deletes backups older than the retention period, always keeping the
+// newest KeepMin backups. Returns the names of deleted backups.
+func (s *Service) Prune(ctx context.Context) ([]string, error) {
+	backups, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().UTC().Add(-s.cfg.Retention)
+	var deleted []string
+	for i, b := range backups {
+		if i < s.cfg.KeepMin || s.cfg.Retention <= 0 || !b.CreatedAt.Before(cutoff) {
+			continue
+		}
+		if err := s.store.Delete(ctx, b.Key); err != nil {
+			return deleted, fmt.Errorf("failed to delete backup %s: %w", b.Name, err)
+		}
+		deleted = append(deleted, b.Name)
+	}
+
+	if len(deleted) > 0 {
+		s.logger.Info("Expired backups pruned", zap.Strings("deleted", deleted))
+	}
+	return deleted, nil
+}
+
+// Restore replaces the graph and cache collections with a backup. A safety
+// backup of the current state is taken first so the restore can be undone.
+func (s *Service) Restore(ctx context.Context, name string) (*RestoreResult, error) {
+	if s.store == nil {
+		return nil, ErrStorageNotConfigured
+	}
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBackupName, name)
+	}
+	if !s.running.TryLock() {
+		return nil, ErrBusy
+	}
+	defer s.running.Unlock()
+
+	s.logger.Debug("Restoring backup", zap.String("name", name))
+
+	data, err := s.store.Get(ctx, s.key(name))
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrBackupNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download backup: %w", err)
+	}
+
+	snapshot, err := decodeSnapshot(data)
+	if err != nil {
+		return nil, err
+	}
+
+	safety, err := s.create(ctx, "pre-restore of "+name, "-pre-restore")
+	if err != nil {
+		return nil, fmt.Errorf("failed to take safety backup: %w", err)
+	}
+
+	documents, err := s.apply(ctx, snapshot, mongodb.BackupCollections)
+	if err != nil {
+		return nil, err
+	}
+	s.emitReplaced(ctx, name, "restored from backup")
+
+	result := &RestoreResult{
+		Restored:      name,
+		SafetyBackup:  safety.Name,
+		Nodes:         len(snapshot.Graph.Nodes),
+		Relationships: len(snapshot.Graph.Relationships),
+		Documents:     documents,
+		RestoredAt:    time.Now().UTC(),
+	}
+
+	s.logger.Info("Backup restored",
+		zap.String("name", name),
+		zap.String("safety_backup", safety.Name),
+		zap.Int("nodes", result.Nodes),
+		zap.Int("documents", documents))
+
+	return result, nil
+}
+
+// emitReplaced passes a replacement of the whole graph to the change log, whose
+// subscribers drop cached listings, pathway views and the typeahead index built
+// from the graph that was replaced
+func (s *Service) emitReplaced(ctx context.Context, name, detail string) {
+	err := s.changes.Emit(ctx, []mongodb.GraphChange{{
+		Kind:    changelog.KindGraph,
+		Name:    name,
+		Action:  changelog.ActionUpdate,
+		Source:  SourceBackup,
+		Details: []string{detail},
+	}})
+	if err != nil {
+		s.logger.Warn("Failed to record graph replacement", zap.String("name", name), zap.Error(err))
+	}
+}
+
+// apply replaces the graph with a snapshot's graph, then each of the given
+// collections the snapshot contains. Returns the number of documents restored.
+func (s *Service) apply(ctx context.Context, snapshot *Snapshot, collections []string) (int, error) {
+	if err := s.neo4jClient.RestoreGraph(ctx, snapshot.Graph); err != nil {
+		return 0, err
+	}
+
+	documents := 0
+	for _, collection := range collections {
+		docs, ok := snapshot.Collections[collection]
+		if !ok {
+			continue
+		}
+		if err := s.mongoClient.RestoreCollection(ctx, collection, docs); err != nil {
+			return documents, err
+		}
+		documents += len(docs)
+	}
+	return documents, nil
+}
+
+func (s *Service) key(name string) string {
+	return s.cfg.Prefix + name + fileSuffix
+}
+
+// encodeSnapshot serializes a snapshot as gzipped JSON
+func encodeSnapshot(snapshot *Snapshot) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to encode backup: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress backup: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeSnapshot(data []byte) (*Snapshot, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer gz.Close()
+
+	raw, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress backup: %w", err)
+	}
+
+	// Decode numbers exactly so integer graph properties stay integers. JSON does
+	// not distinguish 2.0 from 2, so whole-number floats come back as integers.
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var snapshot Snapshot
+	if err := decoder.Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode backup: %w", err)
+	}
+	if snapshot.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", snapshot.FormatVersion)
+	}
+	if snapshot.Graph == nil {
+		return nil, errors.New("backup has no graph")
+	}
+
+	for i := range snapshot.Graph.Nodes {
+		convertNumbers(snapshot.Graph.Nodes[i].Properties)
+	}
+	for i := range snapshot.Graph.Relationships {
+		convertNumbers(snapshot.Graph.Relationships[i].Properties)
+	}
+	return &snapshot, nil
+}
+
+// convertNumbers replaces json.Number property values with int64 or float64
+func convertNumbers(properties map[string]any) {
+	for key, value := range properties {
+		properties[key] = convertNumber(value)
+	}
+}
+
+func convertNumber(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i := range v {
+			v[i] = convertNumber(v[i])
+		}
+	}
+	return value
+}
@@ -0,0 +1,136 @@
+package badges
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// openBadgesContext is the JSON-LD context of Open Badges 2.0 documents
+const openBadgesContext = "https://w3id.org/openbadges/v2"
+
+// Issuer is the Open Badges profile of the organization issuing the badges
+type Issuer struct {
+	Context   string `json:"@context"`
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	URL       string `json:"url,omitempty"`
+	Email     string `json:"email,omitempty"`
+	PublicKey string `json:"publicKey"` // ID of the key assertions are signed with
+}
+
+// CryptographicKey is the public half of the signing key, published so that
+// signed assertions can be verified
+type CryptographicKey struct {
+	Context      string `json:"@context"`
+	Type         string `json:"type"`
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+// BadgeClass describes the achievement a badge stands for: completing every step
+// of one program's learning roadmap
+type BadgeClass struct {
+	Context     string   `json:"@context"`
+	Type        string   `json:"type"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Image       string   `json:"image,omitempty"`
+	Criteria    Criteria `json:"criteria"`
+	Issuer      string   `json:"issuer"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// Criteria explains how the badge is earned
+type Criteria struct {
+	Narrative string `json:"narrative"`
+}
+
+// Assertion is the award of a badge class to one recipient
+type Assertion struct {
+	Context      string       `json:"@context"`
+	Type         string       `json:"type"`
+	ID           string       `json:"id"`
+	Recipient    Recipient    `json:"recipient"`
+	Badge        string       `json:"badge"`
+	IssuedOn     string       `json:"issuedOn"`
+	Verification Verification `json:"verification"`
+}
+
+// Recipient identifies the badge earner by a salted hash of their email
+type Recipient struct {
+	Type     string `json:"type"`
+	Hashed   bool   `json:"hashed"`
+	Salt     string `json:"salt"`
+	Identity string `json:"identity"`
+}
+
+// Verification tells verifiers to check the assertion's signature against the
+// issuer's key
+type Verification struct {
+	Type    string `json:"type"`
+	Creator string `json:"creator"`
+}
+
+// loadSigningKey reads an RSA private key from a PEM file in PKCS #1 or PKCS #8
+// form
+func loadSigningKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("signing key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("signing key is not an RSA key")
+	}
+	return key, nil
+}
+
+// publicKeyPEM encodes the public half of key for the CryptographicKey document
+func publicKeyPEM(key *rsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// signJWS signs the assertion as a compact JSON Web Signature with RS256, the
+// form Open Badges 2.0 expects for signed badges
+func signJWS(key *rsa.PrivateKey, assertion *Assertion) (string, error) {
+	payload, err := json.Marshal(assertion)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode assertion: %w", err)
+	}
+
+	encode := base64.RawURLEncoding.EncodeToString
+	signingInput := encode([]byte(`{"alg":"RS256"}`)) + "." + encode(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign assertion: %w", err)
+	}
+	return signingInput + "." + encode(signature), nil
+}
@@ -0,0 +1,344 @@
+// Package badges issues Open Badges 2.0 for completed learning roadmaps. Each
+// assertion is signed with the issuer's RSA key, so students can attach it to
+// applications and anyone can verify it against the published key.
+package badges
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/core/mail"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/progress"
+	"go.uber.org/zap"
+)
+
+// basePath is where the badge routes are mounted, below the public URL
+const basePath = "/api/v1/badges"
+
+var (
+	// ErrBadgesDisabled is returned when no signing key or public URL is
+	// configured
+	ErrBadgesDisabled = errors.New("badges are not enabled")
+
+	// ErrBadgeNotFound is returned for unknown assertions and badge classes
+	ErrBadgeNotFound = errors.New("badge not found")
+
+	// ErrRoadmapIncomplete is returned when claiming a badge before every step
+	// of the roadmap is completed
+	ErrRoadmapIncomplete = errors.New("roadmap is not completed")
+
+	// ErrInvalidRecipient is returned for recipient emails that cannot be used
+	ErrInvalidRecipient = errors.New("invalid recipient email")
+
+	// ErrAlreadyIssued is returned when a badge for the roadmap was already
+	// issued to a different email
+	ErrAlreadyIssued = errors.New("badge was already issued to another email")
+)
+
+// IssuedBadge is an issued badge with its hosted and signed forms
+type IssuedBadge struct {
+	ID           string    `json:"id"`
+	Program      string    `json:"program"`
+	IssuedAt     time.Time `json:"issued_at"`
+	AssertionURL string    `json:"assertion_url"`
+	BadgeURL     string    `json:"badge_url"`
+	Assertion    Assertion `json:"assertion"`
+	Signed       string    `json:"signed"` // compact JWS to attach to applications
+}
+
+// Service issues and serves roadmap completion badges
+type Service struct {
+	store    *mongodb.BadgeAssertionStore
+	progress *progress.Service
+	mailer   *mail.Mailer
+	cfg      config.BadgeConfig
+	key      *rsa.PrivateKey // nil while badges are disabled
+	logger   *zap.Logger
+}
+
+// NewService creates a new badge service. Badges stay disabled, and every call
+// returns ErrBadgesDisabled, unless the signing key loads and a public URL is set.
+func NewService(mongoClient *mongodb.Client, progressService *progress.Service, mailer *mail.Mailer, cfg config.BadgeConfig, logger *zap.Logger) *Service {
+	s := &Service{
+		store:    mongodb.NewBadgeAssertionStore(mongoClient, logger),
+		progress: progressService,
+		mailer:   mailer,
+		cfg:      cfg,
+		logger:   logger,
+	}
+
+	if cfg.SigningKeyFile == "" || cfg.PublicURL == "" {
+		logger.Info("Badges disabled; BADGE_SIGNING_KEY_FILE and BADGE_PUBLIC_URL are not both set")
+		return s
+	}
+	key, err := loadSigningKey(cfg.SigningKeyFile)
+	if err != nil {
+		logger.Error("Badges disabled; failed to load signing key", zap.Error(err))
+		return s
+	}
+	s.key = key
+	return s
+}
+
+// Issuer returns the issuer profile
+func (s *Service) Issuer() (*Issuer, error) {
+	if s.key == nil {
+		return nil, ErrBadgesDisabled
+	}
+	return &Issuer{
+		Context:   openBadgesContext,
+		Type:      "Issuer",
+		ID:        s.issuerURL(),
+		Name:      s.cfg.IssuerName,
+		URL:       s.cfg.IssuerURL,
+		Email:     s.cfg.IssuerEmail,
+		PublicKey: s.keyURL(),
+	}, nil
+}
+
+// Key returns the public key assertions are signed with
+func (s *Service) Key() (*CryptographicKey, error) {
+	if s.key == nil {
+		return nil, ErrBadgesDisabled
+	}
+	pemKey, err := publicKeyPEM(s.key)
+	if err != nil {
+		return nil, err
+	}
+	return &CryptographicKey{
+		Context:      openBadgesContext,
+		Type:         "CryptographicKey",
+		ID:           s.keyURL(),
+		Owner:        s.issuerURL(),
+		PublicKeyPem: pemKey,
+	}, nil
+}
+
+// BadgeClass returns the badge class of a program's roadmap. Classes are only
+// served for programs whose badge was issued at least once.
+func (s *Service) BadgeClass(ctx context.Context, programName string) (*BadgeClass, error) {
+	if s.key == nil {
+		return nil, ErrBadgesDisabled
+	}
+	issued, err := s.store.IssuedForProgram(ctx, programName)
+	if err != nil {
+		return nil, err
+	}
+	if !issued {
+		return nil, ErrBadgeNotFound
+	}
+	return s.badgeClass(programName), nil
+}
+
+// Assertion returns an issued badge's assertion
+func (s *Service) Assertion(ctx context.Context, id string) (*IssuedBadge, error) {
+	if s.key == nil {
+		return nil, ErrBadgesDisabled
+	}
+	stored, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, ErrBadgeNotFound
+	}
+	return s.issued(stored)
+}
+
+// List returns the badges issued to a user
+func (s *Service) List(ctx context.Context, userID string) ([]IssuedBadge, error) {
+	if s.key == nil {
+		return nil, ErrBadgesDisabled
+	}
+	stored, err := s.store.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	badges := make([]IssuedBadge, 0, len(stored))
+	for i := range stored {
+		badge, err := s.issued(&stored[i])
+		if err != nil {
+			return nil, err
+		}
+		badges = append(badges, *badge)
+	}
+	return badges, nil
+}
+
+// Claim issues the badge of a roadmap the user has completed to their email, and
+// emails it to them in the given language. Claiming again with the same email
+// returns the badge issued the first time.
+func (s *Service) Claim(ctx context.Context, userID, programName, email, language string) (*IssuedBadge, error) {
+	if s.key == nil {
+		return nil, ErrBadgesDisabled
+	}
+	s.logger.Debug("Claiming roadmap badge", zap.String("program", programName))
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	roadmapProgress, err := s.progress.Get(ctx, userID, programName)
+	if errors.Is(err, progress.ErrProgressNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrRoadmapIncomplete, programName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !roadmapProgress.Complete {
+		return nil, fmt.Errorf("%w: %d of %d steps completed", ErrRoadmapIncomplete,
+			roadmapProgress.Completed, roadmapProgress.TotalSteps)
+	}
+	programName = roadmapProgress.ProgramName
+
+	existing, err := s.store.GetForProgram(ctx, userID, programName)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.RecipientHash != hashIdentity(email, existing.RecipientSalt) {
+			return nil, ErrAlreadyIssued
+		}
+		return s.issued(existing)
+	}
+
+	salt, err := newSalt()
+	if err != nil {
+		return nil, err
+	}
+	assertion := &mongodb.BadgeAssertion{
+		UserID:        userID,
+		ProgramName:   programName,
+		RecipientHash: hashIdentity(email, salt),
+		RecipientSalt: salt,
+	}
+	if err := s.store.Create(ctx, assertion); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Roadmap badge issued",
+		zap.String("assertion_id", assertion.ID),
+		zap.String("program", programName))
+
+	issued, err := s.issued(assertion)
+	if err != nil {
+		return nil, err
+	}
+	go s.emailBadge(email, language, issued)
+	return issued, nil
+}
+
+// emailBadge sends a newly issued badge to its recipient. Failures are only
+// logged; the badge stays available through the API.
+func (s *Service) emailBadge(email, language string, badge *IssuedBadge) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	data := map[string]string{"Program": badge.Program, "AssertionURL": badge.AssertionURL}
+	if err := s.mailer.Send(ctx, email, "badge_issued", language, data); err != nil {
+		s.logger.Warn("Failed to email badge",
+			zap.String("assertion_id", badge.ID),
+			zap.Error(err))
+	}
+}
+
+// issued builds and signs the Open Badges assertion of a stored badge
+func (s *Service) issued(stored *mongodb.BadgeAssertion) (*IssuedBadge, error) {
+	assertion := Assertion{
+		Context: openBadgesContext,
+		Type:    "Assertion",
+		// Signed assertions are identified by URN rather than a hosted URL
+		ID: "urn:uuid:" + stored.ID,
+		Recipient: Recipient{
+			Type:     "email",
+			Hashed:   true,
+			Salt:     stored.RecipientSalt,
+			Identity: stored.RecipientHash,
+		},
+		Badge:    s.badgeClassURL(stored.ProgramName),
+		IssuedOn: stored.IssuedAt.UTC().Format(time.RFC3339),
+		Verification: Verification{
+			Type:    "SignedBadge",
+			Creator: s.keyURL(),
+		},
+	}
+
+	signed, err := signJWS(s.key, &assertion)
+	if err != nil {
+		return nil, err
+	}
+	return &IssuedBadge{
+		ID:           stored.ID,
+		Program:      stored.ProgramName,
+		IssuedAt:     stored.IssuedAt,
+		AssertionURL: s.cfg.PublicURL + basePath + "/assertions/" + stored.ID,
+		BadgeURL:     assertion.Badge,
+		Assertion:    assertion,
+		Signed:       signed,
+	}, nil
+}
+
+func (s *Service) badgeClass(programName string) *BadgeClass {
+	return &BadgeClass{
+		Context:     openBadgesContext,
+		Type:        "BadgeClass",
+		ID:          s.badgeClassURL(programName),
+		Name:        programName + " Roadmap",
+		Description: fmt.Sprintf("Completed every step of the %s learning roadmap, preparing for entry to the %s program.", s.cfg.IssuerName, programName),
+		Image:       s.cfg.ImageURL,
+		Criteria: Criteria{
+			Narrative: fmt.Sprintf("Work through each step of the learning roadmap for %s and mark it completed.", programName),
+		},
+		Issuer: s.issuerURL(),
+		Tags:   []string{"learning-roadmap"},
+	}
+}
+
+func (s *Service) issuerURL() string {
+	return s.cfg.PublicURL + basePath + "/issuer"
+}
+
+func (s *Service) keyURL() string {
+	return s.cfg.PublicURL + basePath + "/issuer/key"
+}
+
+func (s *Service) badgeClassURL(programName string) string {
+	return s.cfg.PublicURL + basePath + "/classes/" + url.PathEscape(programName)
+}
+
+// normalizeEmail checks the recipient email and lowercases it, so that hashes
+// match however the address is capitalized
+func normalizeEmail(email string) (string, error) {
+	address, err := netmail.ParseAddress(strings.TrimSpace(email))
+	if err != nil || address.Name != "" {
+		return "", ErrInvalidRecipient
+	}
+	return strings.ToLower(address.Address), nil
+}
+
+// hashIdentity hashes a recipient email the way Open Badges verifiers do
+func hashIdentity(email, salt string) string {
+	sum := sha256.Sum256([]byte(email + salt))
+	return "sha256$" + hex.EncodeToString(sum[:])
+}
+
+func newSalt() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
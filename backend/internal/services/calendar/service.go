@@ -0,0 +1,406 @@
+// Package calendar pushes study schedules to students' Google Calendars. Students
+// connect their calendar once through Google's OAuth consent screen; each push
+// replaces the events pushed for the same plan before.
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/plans"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+const (
+	// eventsScope lets the API manage events without reading other calendar data
+	eventsScope = "https://www.googleapis.com/auth/calendar.events"
+
+	eventsURL = "https://www.googleapis.com/calendar/v3/calendars/primary/events"
+)
+
+var (
+	// ErrCalendarDisabled is returned when no Google OAuth client is configured
+	ErrCalendarDisabled = errors.New("google calendar sync is not enabled")
+
+	// ErrNotConnected is returned when the user has not connected a calendar
+	ErrNotConnected = errors.New("google calendar is not connected")
+
+	// ErrInvalidState is returned for OAuth callbacks that do not belong to a
+	// flow started here, or whose flow expired
+	ErrInvalidState = errors.New("calendar connection request is invalid or has expired")
+
+	// ErrConsentDenied is returned when the student declines on Google's
+	// consent page
+	ErrConsentDenied = errors.New("calendar access was not granted")
+
+	// ErrGoogleUnavailable is returned when Google refuses or fails a request
+	ErrGoogleUnavailable = errors.New("google calendar unavailable")
+)
+
+// Status tells whether a user has connected their calendar
+type Status struct {
+	Enabled     bool       `json:"enabled"`
+	Connected   bool       `json:"connected"`
+	ConnectedAt *time.Time `json:"connected_at,omitempty"`
+}
+
+// PushReport is the outcome of pushing a study schedule
+type PushReport struct {
+	PlanID   string   `json:"plan_id"`
+	Events   int      `json:"events"`  // one recurring event per roadmap step
+	Removed  int      `json:"removed"` // events of an earlier push that were replaced
+	StartsOn string   `json:"starts_on"`
+	EndsOn   string   `json:"ends_on"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Service connects Google Calendars and pushes study schedules to them
+type Service struct {
+	store       *mongodb.GoogleCalendarStore
+	planService *plans.Service
+	oauth       *oauth2.Config
+	httpClient  *http.Client
+	logger      *zap.Logger
+}
+
+// NewService creates a new calendar service
+func NewService(mongoClient *mongodb.Client, planService *plans.Service, cfg config.CalendarConfig, logger *zap.Logger) *Service {
+	s := &Service{
+		store:       mongodb.NewGoogleCalendarStore(mongoClient, logger),
+		planService: planService,
+		httpClient:  &http.Client{Timeout: cfg.Timeout},
+		logger:      logger,
+	}
+	if cfg.GoogleClientID != "" {
+		s.oauth = &oauth2.Config{
+			ClientID:     cfg.GoogleClientID,
+			ClientSecret: cfg.GoogleClientSecret,
+			RedirectURL:  cfg.GoogleRedirectURL,
+			Endpoint:     endpoints.Google,
+			Scopes:       []string{eventsScope},
+		}
+	}
+	return s
+}
+
+// ConnectURL starts connecting a user's calendar and returns the Google consent
+// page to send them to
+func (s *Service) ConnectURL(ctx context.Context, userID string) (string, error) {
+	if s.oauth == nil {
+		return "", ErrCalendarDisabled
+	}
+	state, err := s.store.CreateState(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	// Offline access with forced consent, so that Google sends a refresh token
+	// even to students who connected before
+	return s.oauth.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce), nil
+}
+
+// CompleteConnect finishes connecting the calendar of the user who started the
+// flow identified by state
+func (s *Service) CompleteConnect(ctx context.Context, state, code string) error {
+	if s.oauth == nil {
+		return ErrCalendarDisabled
+	}
+	userID, err := s.store.ConsumeState(ctx, state)
+	if err != nil {
+		return err
+	}
+	if userID == "" {
+		return ErrInvalidState
+	}
+
+	token, err := s.oauth.Exchange(s.oauthContext(ctx), code)
+	if err != nil {
+		return fmt.Errorf("%w: failed to exchange authorization code: %v", ErrGoogleUnavailable, err)
+	}
+	if err := s.saveToken(ctx, userID, token); err != nil {
+		return err
+	}
+
+	s.logger.Info("Google Calendar connected")
+	return nil
+}
+
+// Status reports whether the user has connected their calendar
+func (s *Service) Status(ctx context.Context, userID string) (*Status, error) {
+	status := &Status{Enabled: s.oauth != nil}
+	if s.oauth == nil {
+		return status, nil
+	}
+	conn, err := s.store.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if conn != nil {
+		status.Connected = true
+		status.ConnectedAt = &conn.ConnectedAt
+	}
+	return status, nil
+}
+
+// Disconnect forgets the user's tokens and revokes them at Google. Events already
+// pushed stay in the calendar.
+func (s *Service) Disconnect(ctx context.Context, userID string) error {
+	if s.oauth == nil {
+		return ErrCalendarDisabled
+	}
+	conn, err := s.store.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if conn == nil {
+		return ErrNotConnected
+	}
+	if _, err := s.store.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	token := conn.RefreshToken
+	if token == "" {
+		token = conn.AccessToken
+	}
+	if err := s.revoke(ctx, token); err != nil {
+		s.logger.Warn("Failed to revoke Google token; it expires on its own", zap.Error(err))
+	}
+	return nil
+}
+
+// PushSchedule builds the study schedule of a plan and pushes it to the user's
+// calendar, replacing the events an earlier push created
+func (s *Service) PushSchedule(ctx context.Context, userID, planID string, opts plans.ScheduleOptions) (*PushReport, error) {
+	if s.oauth == nil {
+		return nil, ErrCalendarDisabled
+	}
+	s.logger.Debug("Pushing study schedule to Google Calendar", zap.String("plan_id", planID))
+
+	conn, err := s.store.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return nil, ErrNotConnected
+	}
+
+	schedule, err := s.planService.StudySchedule(ctx, userID, planID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	client, finish := s.calendarClient(ctx, conn)
+	defer finish()
+
+	report := &PushReport{PlanID: planID, StartsOn: schedule.StartDate, EndsOn: schedule.EndDate}
+	for _, eventID := range conn.PlanEvents[planID] {
+		switch err := s.deleteEvent(ctx, client, eventID); {
+		case err == nil:
+			report.Removed++
+		case errors.Is(err, errEventGone):
+			// Already deleted by the student
+		default:
+			report.Warnings = append(report.Warnings, fmt.Sprintf("could not remove an earlier event: %v", err))
+		}
+	}
+
+	var created []string
+	for i := range schedule.Blocks {
+		eventID, err := s.insertEvent(ctx, client, schedule, &schedule.Blocks[i])
+		if err != nil {
+			// Remember what was created so that the next push cleans it up
+			if recordErr := s.store.SetPlanEvents(ctx, userID, planID, created); recordErr != nil {
+				s.logger.Error("Failed to record pushed events", zap.Error(recordErr))
+			}
+			return nil, err
+		}
+		created = append(created, eventID)
+	}
+	if err := s.store.SetPlanEvents(ctx, userID, planID, created); err != nil {
+		return nil, err
+	}
+	report.Events = len(created)
+
+	s.logger.Info("Study schedule pushed to Google Calendar",
+		zap.String("plan_id", planID),
+		zap.Int("events", report.Events),
+		zap.Int("removed", report.Removed))
+	return report, nil
+}
+
+// calendarClient returns an HTTP client authorized as the user. The returned
+// function saves the access token if it was refreshed meanwhile.
+func (s *Service) calendarClient(ctx context.Context, conn *mongodb.GoogleCalendarConnection) (*http.Client, func()) {
+	stored := &oauth2.Token{
+		AccessToken:  conn.AccessToken,
+		RefreshToken: conn.RefreshToken,
+		TokenType:    conn.TokenType,
+		Expiry:       conn.Expiry,
+	}
+	source := s.oauth.TokenSource(s.oauthContext(ctx), stored)
+
+	finish := func() {
+		token, err := source.Token()
+		if err != nil || token.AccessToken == stored.AccessToken {
+			return
+		}
+		if err := s.saveToken(context.WithoutCancel(ctx), conn.UserID, token); err != nil {
+			s.logger.Warn("Failed to save refreshed Google token", zap.Error(err))
+		}
+	}
+	return oauth2.NewClient(s.oauthContext(ctx), source), finish
+}
+
+func (s *Service) saveToken(ctx context.Context, userID string, token *oauth2.Token) error {
+	return s.store.SaveTokens(ctx, &mongodb.GoogleCalendarConnection{
+		UserID:       userID,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+		Expiry:       token.Expiry,
+	})
+}
+
+// oauthContext makes the oauth2 package use the service's HTTP client
+func (s *Service) oauthContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, s.httpClient)
+}
+
+// revoke revokes a token at Google
+func (s *Service) revoke(ctx context.Context, token string) error {
+	form := url.Values{"token": {token}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://oauth2.googleapis.com/revoke",
+		bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("revoke returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// errEventGone is returned when deleting an event that no longer exists
+var errEventGone = errors.New("event no longer exists")
+
+// eventTime is a Calendar API event start or end
+type eventTime struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+type eventReminders struct {
+	UseDefault bool               `json:"useDefault"`
+	Overrides  []reminderOverride `json:"overrides,omitempty"`
+}
+
+type reminderOverride struct {
+	Method  string `json:"method"`
+	Minutes int    `json:"minutes"`
+}
+
+type calendarEvent struct {
+	ID          string         `json:"id,omitempty"`
+	Summary     string         `json:"summary"`
+	Description string         `json:"description"`
+	Start       eventTime      `json:"start"`
+	End         eventTime      `json:"end"`
+	Recurrence  []string       `json:"recurrence"`
+	Reminders   eventReminders `json:"reminders"`
+}
+
+// insertEvent creates the recurring event of a schedule block and returns its ID
+func (s *Service) insertEvent(ctx context.Context, client *http.Client, schedule *plans.StudySchedule, block *plans.StudyBlock) (string, error) {
+	const layout = "2006-01-02T15:04:05"
+	end := block.FirstSession.Add(time.Duration(schedule.SessionMinutes) * time.Minute)
+	event := calendarEvent{
+		Summary:     block.Summary(schedule.ProgramName),
+		Description: block.Details(),
+		Start:       eventTime{DateTime: block.FirstSession.Format(layout), TimeZone: schedule.TimeZone},
+		End:         eventTime{DateTime: end.Format(layout), TimeZone: schedule.TimeZone},
+		Recurrence:  []string{"RRULE:" + schedule.RRule(block)},
+		Reminders:   eventReminders{UseDefault: false, Overrides: []reminderOverride{}},
+	}
+	if schedule.ReminderMinutes >= 0 {
+		event.Reminders.Overrides = append(event.Reminders.Overrides,
+			reminderOverride{Method: "popup", Minutes: schedule.ReminderMinutes})
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, eventsURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create event request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrGoogleUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", googleError(resp)
+	}
+
+	var created calendarEvent
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("%w: invalid event response: %v", ErrGoogleUnavailable, err)
+	}
+	return created.ID, nil
+}
+
+// deleteEvent deletes an event pushed earlier
+func (s *Service) deleteEvent(ctx context.Context, client *http.Client, eventID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, eventsURL+"/"+url.PathEscape(eventID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create delete request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGoogleUnavailable, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusOK:
+		return nil
+	case http.StatusNotFound, http.StatusGone:
+		return errEventGone
+	}
+	return googleError(resp)
+}
+
+// googleError describes a failed Calendar API response
+func googleError(resp *http.Response) error {
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(data, &body) == nil && body.Error.Message != "" {
+		return fmt.Errorf("%w: %s (status %d)", ErrGoogleUnavailable, body.Error.Message, resp.StatusCode)
+	}
+	return fmt.Errorf("%w: status %d", ErrGoogleUnavailable, resp.StatusCode)
+}
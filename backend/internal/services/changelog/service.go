@@ -0,0 +1,109 @@
+package changelog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// Actions recorded in the change log
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+)
+
+// KindGraph is the kind of changes replacing the whole graph, such as backup
+// restores and promotions. They name what was applied rather than an entity,
+// and consumers treat every entity as changed.
+const KindGraph = "graph"
+
+const (
+	// Defaults and bounds for the number of changes returned per page
+	DefaultPageSize = 100
+	MaxPageSize     = 500
+)
+
+// ErrInvalidCursor is returned for a change log cursor that is not a change ID
+var ErrInvalidCursor = errors.New("invalid change log cursor")
+
+// Listener is notified of changes once they have been applied to the graph
+type Listener func(ctx context.Context, changes []mongodb.GraphChange)
+
+// Service records graph changes made by admin edits, imports and syncs, and
+// passes them on to subscribed consumers such as cache invalidation and webhooks
+type Service struct {
+	store     *mongodb.GraphChangeStore
+	mu        sync.RWMutex
+	listeners []Listener
+	logger    *zap.Logger
+}
+
+// NewService creates a new change log service
+func NewService(mongoClient *mongodb.Client, logger *zap.Logger) *Service {
+	return &Service{
+		store:  mongodb.NewGraphChangeStore(mongoClient, logger),
+		logger: logger,
+	}
+}
+
+// Subscribe registers a listener for changes emitted from now on
+func (s *Service) Subscribe(listener Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+// Emit records applied changes and notifies the listeners. Listeners are
+// notified even when recording fails, as the graph has already changed.
+func (s *Service) Emit(ctx context.Context, changes []mongodb.GraphChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	err := s.store.Record(ctx, changes)
+	if err != nil {
+		s.logger.Error("Failed to record graph changes",
+			zap.Int("changes", len(changes)),
+			zap.Error(err))
+	}
+
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+	for _, listener := range listeners {
+		listener(ctx, changes)
+	}
+
+	s.logger.Info("Graph changes emitted", zap.Int("changes", len(changes)))
+	return err
+}
+
+// List returns a page of the change log, oldest first. after is the ID of the
+// last change already seen and since the earliest time of interest; both are
+// optional.
+func (s *Service) List(ctx context.Context, after string, since time.Time, limit int) ([]mongodb.GraphChange, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	var afterID primitive.ObjectID
+	if after != "" {
+		id, err := primitive.ObjectIDFromHex(after)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		afterID = id
+	}
+
+	return s.store.List(ctx, afterID, since, limit)
+}
@@ -0,0 +1,259 @@
+// Package cohorts lets a teacher group their students under a code and follow
+// the class's pathways in aggregate, without seeing any one student's plans or
+// progress.
+package cohorts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/plans"
+	"github.com/mayura-andrew/fastfinder/internal/services/progress"
+	"go.uber.org/zap"
+)
+
+const (
+	// MinReportableMembers is how many students a cohort needs before its
+	// aggregates are shown; in smaller groups they could identify students
+	MinReportableMembers = 5
+
+	// minCareerStudents is how many students must target a career for it to be
+	// listed, so that no career points to a single student
+	minCareerStudents = 2
+
+	// MaxMembers caps the students in one cohort
+	MaxMembers = 200
+
+	// maxNameLength bounds a cohort's name
+	maxNameLength = 100
+
+	// topCareers is how many of the most targeted careers are listed
+	topCareers = 10
+)
+
+var (
+	// ErrCohortNotFound is returned for unknown codes, and to anyone but its
+	// teacher asking for a cohort's summary
+	ErrCohortNotFound = errors.New("cohort not found")
+
+	// ErrInvalidCohort is returned for cohorts without a usable name and for
+	// teachers joining their own cohort
+	ErrInvalidCohort = errors.New("invalid cohort")
+
+	// ErrCohortFull is returned when a cohort already has MaxMembers students
+	ErrCohortFull = errors.New("cohort is full")
+)
+
+// Cohort is a cohort with the number of students in it
+type Cohort struct {
+	mongodb.Cohort
+	Members int64 `json:"members"`
+}
+
+// Summary is what a teacher sees of a cohort: how many students joined and, once
+// there are MinReportableMembers of them, the careers they target and how far
+// they are through their roadmaps
+type Summary struct {
+	Cohort
+	Reportable bool `json:"reportable"`
+	MinMembers int  `json:"min_reportable_members"`
+
+	TopCareers []mongodb.CareerCount `json:"top_careers"`
+	// StudentsStarted counts the students who started a learning roadmap
+	StudentsStarted int `json:"students_started"`
+	// AveragePercent is the mean of the started students' average progress
+	// through their roadmaps
+	AveragePercent float64 `json:"average_percent"`
+	// RoadmapsCompleted counts the roadmaps completed across the cohort
+	RoadmapsCompleted int `json:"roadmaps_completed"`
+}
+
+// Service manages cohorts and reports on them
+type Service struct {
+	store    *mongodb.CohortStore
+	plans    *plans.Service
+	progress *progress.Service
+	logger   *zap.Logger
+}
+
+// NewService creates a new cohort service
+func NewService(mongoClient *mongodb.Client, planService *plans.Service, progressService *progress.Service, logger *zap.Logger) *Service {
+	return &Service{
+		store:    mongodb.NewCohortStore(mongoClient, logger),
+		plans:    planService,
+		progress: progressService,
+		logger:   logger,
+	}
+}
+
+// Create starts a cohort taught by the given user
+func (s *Service) Create(ctx context.Context, teacherID, name string) (*Cohort, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidCohort, maxNameLength)
+	}
+
+	cohort := &mongodb.Cohort{Name: name, TeacherID: teacherID}
+	if err := s.store.Create(ctx, cohort); err != nil {
+		return nil, err
+	}
+	return &Cohort{Cohort: *cohort}, nil
+}
+
+// ListTaught returns the cohorts a user teaches, newest first
+func (s *Service) ListTaught(ctx context.Context, teacherID string) ([]Cohort, error) {
+	stored, err := s.store.ListByTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+
+	cohorts := make([]Cohort, 0, len(stored))
+	for _, cohort := range stored {
+		members, err := s.store.CountMembers(ctx, cohort.Code)
+		if err != nil {
+			return nil, err
+		}
+		cohorts = append(cohorts, Cohort{Cohort: cohort, Members: members})
+	}
+	return cohorts, nil
+}
+
+// Join adds a student to the cohort with the given code. Joining again leaves
+// the student's membership as it was.
+func (s *Service) Join(ctx context.Context, userID, code string) (*Cohort, error) {
+	cohort, err := s.get(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if cohort.TeacherID == userID {
+		return nil, fmt.Errorf("%w: teachers cannot join their own cohort", ErrInvalidCohort)
+	}
+
+	members, err := s.store.CountMembers(ctx, cohort.Code)
+	if err != nil {
+		return nil, err
+	}
+	if members >= MaxMembers {
+		return nil, fmt.Errorf("%w: it has %d students", ErrCohortFull, MaxMembers)
+	}
+
+	if _, err := s.store.AddMember(ctx, cohort.Code, userID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Student joined cohort", zap.String("code", cohort.Code))
+	return &Cohort{Cohort: *cohort, Members: members + 1}, nil
+}
+
+// Leave takes a student out of a cohort
+func (s *Service) Leave(ctx context.Context, userID, code string) error {
+	removed, err := s.store.RemoveMember(ctx, normalizeCode(code), userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrCohortNotFound
+	}
+	return nil
+}
+
+// Summary reports on a cohort to its teacher
+func (s *Service) Summary(ctx context.Context, teacherID, code string) (*Summary, error) {
+	cohort, err := s.get(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if cohort.TeacherID != teacherID {
+		return nil, ErrCohortNotFound
+	}
+
+	memberIDs, err := s.store.MemberIDs(ctx, cohort.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		Cohort:     Cohort{Cohort: *cohort, Members: int64(len(memberIDs))},
+		MinMembers: MinReportableMembers,
+		TopCareers: []mongodb.CareerCount{},
+	}
+	if len(memberIDs) < MinReportableMembers {
+		return summary, nil
+	}
+	summary.Reportable = true
+
+	careers, err := s.plans.CareerCounts(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, career := range careers {
+		if career.Users < minCareerStudents || len(summary.TopCareers) == topCareers {
+			break
+		}
+		summary.TopCareers = append(summary.TopCareers, career)
+	}
+
+	roadmaps, err := s.progress.ListByUsers(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+	summarizeProgress(summary, roadmaps)
+
+	s.logger.Info("Cohort summarized",
+		zap.String("code", cohort.Code),
+		zap.Int("members", len(memberIDs)))
+	return summary, nil
+}
+
+// summarizeProgress averages each student's progress over the roadmaps they
+// started, then averages those across the students
+func summarizeProgress(summary *Summary, roadmaps []progress.Progress) {
+	type studentProgress struct {
+		percent  int
+		roadmaps int
+	}
+	students := make(map[string]*studentProgress)
+	for _, roadmap := range roadmaps {
+		student := students[roadmap.UserID]
+		if student == nil {
+			student = &studentProgress{}
+			students[roadmap.UserID] = student
+		}
+		student.percent += roadmap.Percent
+		student.roadmaps++
+		if roadmap.Complete {
+			summary.RoadmapsCompleted++
+		}
+	}
+
+	summary.StudentsStarted = len(students)
+	if len(students) == 0 {
+		return
+	}
+	var total float64
+	for _, student := range students {
+		total += float64(student.percent) / float64(student.roadmaps)
+	}
+	summary.AveragePercent = math.Round(total/float64(len(students))*10) / 10
+}
+
+// get returns the cohort with the given code, ignoring case and spacing
+func (s *Service) get(ctx context.Context, code string) (*mongodb.Cohort, error) {
+	cohort, err := s.store.Get(ctx, normalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if cohort == nil {
+		return nil, ErrCohortNotFound
+	}
+	return cohort, nil
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(code), ""))
+}
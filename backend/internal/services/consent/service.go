@@ -0,0 +1,254 @@
+// Package consent tracks users' acceptance of the privacy policy and, for minors,
+// their guardian's consent. Profile data is only stored for users whose consent
+// covers the current policy version.
+package consent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.uber.org/zap"
+)
+
+// cacheTTL is how long a user's consent is trusted before it is read again; a
+// consent withdrawn on another instance is honored within it
+const cacheTTL = time.Minute
+
+// maxCachedUsers bounds the consent cache
+const maxCachedUsers = 50000
+
+// Actions a user still has to take before profile data may be stored
+const (
+	ActionAcceptPolicy    = "accept_policy"
+	ActionParentalConsent = "parental_consent"
+)
+
+var (
+	// ErrPolicyOutdated is returned when consent is given for a policy version
+	// other than the current one
+	ErrPolicyOutdated = errors.New("policy is not the current version")
+
+	// ErrNotAccepted is returned for parental consent to a policy the minor has
+	// not accepted
+	ErrNotAccepted = errors.New("the privacy policy has not been accepted")
+
+	// ErrInvalidConsent is returned for parental consent without a guardian
+	ErrInvalidConsent = errors.New("invalid consent")
+)
+
+// Guardian identifies who gives parental consent
+type Guardian struct {
+	Name         string `json:"guardian_name"`
+	Relationship string `json:"relationship"`
+}
+
+// Status is whether profile data of a user may be stored, and if not, what is
+// missing
+type Status struct {
+	PolicyID       string           `json:"policy_id"` // current policy version
+	Consent        *mongodb.Consent `json:"consent,omitempty"`
+	Allowed        bool             `json:"allowed"`
+	RequiredAction string           `json:"required_action,omitempty"`
+}
+
+type cachedConsent struct {
+	consent  *mongodb.Consent
+	loadedAt time.Time
+}
+
+// Service records and checks consent
+type Service struct {
+	store  *mongodb.ConsentStore
+	cfg    config.ConsentConfig
+	logger *zap.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedConsent // by user ID
+}
+
+// NewService creates a new consent service
+func NewService(mongoClient *mongodb.Client, cfg config.ConsentConfig, logger *zap.Logger) *Service {
+	return &Service{
+		store:  mongodb.NewConsentStore(mongoClient, logger),
+		cfg:    cfg,
+		logger: logger,
+		cache:  make(map[string]cachedConsent),
+	}
+}
+
+// PolicyID returns the current privacy policy version
+func (s *Service) PolicyID() string {
+	return s.cfg.PolicyID
+}
+
+// Accept records a user's acceptance of the current policy. Minors may bring their
+// guardian's consent along; otherwise it is given separately with GrantParental.
+func (s *Service) Accept(ctx context.Context, userID, policyID string, minor bool, guardian *Guardian) (*Status, error) {
+	if err := s.checkPolicy(policyID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("Recording policy acceptance",
+		zap.String("policy_id", policyID),
+		zap.Bool("minor", minor))
+
+	consent := &mongodb.Consent{
+		UserID:     userID,
+		PolicyID:   policyID,
+		AcceptedAt: time.Now(),
+		Minor:      minor,
+	}
+	if minor && guardian != nil {
+		parental, err := newParentalConsent(policyID, guardian)
+		if err != nil {
+			return nil, err
+		}
+		consent.ParentalConsent = parental
+	}
+	if err := s.store.Save(ctx, consent, mongodb.ConsentAccepted); err != nil {
+		return nil, err
+	}
+	s.remember(userID, consent)
+
+	s.logger.Info("Privacy policy accepted",
+		zap.String("policy_id", policyID),
+		zap.Bool("minor", minor),
+		zap.Bool("parental_consent", consent.ParentalConsent != nil))
+	return s.status(consent), nil
+}
+
+// GrantParental records a guardian's consent for a minor who has accepted the
+// current policy
+func (s *Service) GrantParental(ctx context.Context, userID, policyID string, guardian Guardian) (*Status, error) {
+	if err := s.checkPolicy(policyID); err != nil {
+		return nil, err
+	}
+
+	consent, err := s.store.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if consent == nil || consent.WithdrawnAt != nil || consent.PolicyID != policyID {
+		return nil, fmt.Errorf("%w: accept policy %s first", ErrNotAccepted, policyID)
+	}
+
+	parental, err := newParentalConsent(policyID, &guardian)
+	if err != nil {
+		return nil, err
+	}
+	consent.Minor = true
+	consent.ParentalConsent = parental
+	if err := s.store.Save(ctx, consent, mongodb.ConsentParentalGranted); err != nil {
+		return nil, err
+	}
+	s.remember(userID, consent)
+
+	s.logger.Info("Parental consent recorded", zap.String("policy_id", policyID))
+	return s.status(consent), nil
+}
+
+// Withdraw withdraws a user's consent. Nothing more is stored for them until they
+// accept the policy again; what was stored before stays until they delete it.
+func (s *Service) Withdraw(ctx context.Context, userID string) (*Status, error) {
+	consent, err := s.store.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if consent == nil || consent.WithdrawnAt != nil {
+		return s.status(consent), nil
+	}
+
+	now := time.Now()
+	consent.WithdrawnAt = &now
+	if err := s.store.Save(ctx, consent, mongodb.ConsentWithdrawn); err != nil {
+		return nil, err
+	}
+	s.remember(userID, consent)
+
+	s.logger.Info("Consent withdrawn", zap.String("policy_id", consent.PolicyID))
+	return s.status(consent), nil
+}
+
+// Status returns whether profile data of a user may be stored
+func (s *Service) Status(ctx context.Context, userID string) (*Status, error) {
+	consent, err := s.store.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.remember(userID, consent)
+	return s.status(consent), nil
+}
+
+// History returns the changes to a user's consent, newest first
+func (s *Service) History(ctx context.Context, userID string) ([]mongodb.ConsentEvent, error) {
+	return s.store.ListEvents(ctx, userID)
+}
+
+// Allowed reports whether profile data of a user may be stored, from a cache
+// refreshed every minute
+func (s *Service) Allowed(ctx context.Context, userID string) (bool, error) {
+	s.mu.Lock()
+	cached, ok := s.cache[userID]
+	s.mu.Unlock()
+
+	if !ok || time.Since(cached.loadedAt) > cacheTTL {
+		consent, err := s.store.Get(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		s.remember(userID, consent)
+		cached.consent = consent
+	}
+	return s.status(cached.consent).Allowed, nil
+}
+
+// status works out what a consent allows
+func (s *Service) status(consent *mongodb.Consent) *Status {
+	status := &Status{PolicyID: s.cfg.PolicyID, Consent: consent}
+	switch {
+	case consent == nil, consent.WithdrawnAt != nil, consent.PolicyID != s.cfg.PolicyID:
+		status.RequiredAction = ActionAcceptPolicy
+	case consent.Minor && (consent.ParentalConsent == nil || consent.ParentalConsent.PolicyID != s.cfg.PolicyID):
+		status.RequiredAction = ActionParentalConsent
+	default:
+		status.Allowed = true
+	}
+	return status
+}
+
+func (s *Service) checkPolicy(policyID string) error {
+	if policyID != s.cfg.PolicyID {
+		return fmt.Errorf("%w: %q, the current policy is %q", ErrPolicyOutdated, policyID, s.cfg.PolicyID)
+	}
+	return nil
+}
+
+// remember caches a user's consent, nil when they have none
+func (s *Service) remember(userID string, consent *mongodb.Consent) {
+	s.mu.Lock()
+	if len(s.cache) >= maxCachedUsers {
+		clear(s.cache)
+	}
+	s.cache[userID] = cachedConsent{consent: consent, loadedAt: time.Now()}
+	s.mu.Unlock()
+}
+
+func newParentalConsent(policyID string, guardian *Guardian) (*mongodb.ParentalConsent, error) {
+	name := strings.TrimSpace(guardian.Name)
+	relationship := strings.TrimSpace(guardian.Relationship)
+	if name == "" || relationship == "" {
+		return nil, fmt.Errorf("%w: parental consent needs the guardian's name and relationship", ErrInvalidConsent)
+	}
+	return &mongodb.ParentalConsent{
+		PolicyID:     policyID,
+		GuardianName: name,
+		Relationship: relationship,
+		GrantedAt:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,314 @@
+// Package demand rates how much each career is in demand, from 0 to 100, by
+// combining open job vacancies with the LLM's assessment of the local market.
+// Admins can override a career's index where they know better.
+package demand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// MaxIndex is the demand index of a career in the highest demand
+const MaxIndex = 100
+
+var (
+	// ErrCareerNotFound is returned for an override of a career not in the graph
+	ErrCareerNotFound = errors.New("career not found")
+
+	// ErrInvalidOverride is returned for an override index out of range
+	ErrInvalidOverride = errors.New("invalid demand override")
+
+	// ErrOverrideNotFound is returned when removing an override never set
+	ErrOverrideNotFound = errors.New("demand override not found")
+)
+
+// assessmentLevels score the demand levels the LLM describes local markets
+// with. The level named first in an assessment counts.
+var assessmentLevels = []struct {
+	Level string
+	Score float64
+}{
+	{"very high", 100},
+	{"high", 80},
+	{"growing", 70},
+	{"medium", 50},
+	{"moderate", 50},
+	{"stable", 40},
+	{"declining", 20},
+	{"very low", 10},
+	{"low", 20},
+}
+
+// Vacancies counts the open vacancies of each career. It is implemented by the
+// vacancy service.
+type Vacancies interface {
+	OpenByCareer(ctx context.Context) (map[string]int64, error)
+}
+
+// Assessor returns the LLM's assessment of a career's local demand already on
+// hand, or an empty string. It is implemented by the pathway service.
+type Assessor interface {
+	DemandAssessment(ctx context.Context, career string) (string, error)
+}
+
+// CareerIndex is a career's demand index with what it was computed from and
+// any override replacing it
+type CareerIndex struct {
+	Career   string                  `json:"career"`
+	Index    float64                 `json:"index"`
+	Computed *mongodb.CareerDemand   `json:"computed,omitempty"`
+	Override *mongodb.DemandOverride `json:"override,omitempty"`
+}
+
+// RecomputeReport summarises a recompute of the demand indexes
+type RecomputeReport struct {
+	Careers int `json:"careers"`
+	Scored  int `json:"scored"`
+}
+
+// Service computes and keeps careers' demand indexes
+type Service struct {
+	neo4jClient *neo4j.Client
+	store       *mongodb.CareerDemandStore
+	vacancies   Vacancies
+	assessor    Assessor
+	config      config.DemandConfig
+	logger      *zap.Logger
+}
+
+// NewService creates a new demand service. Either signal source may be nil, in
+// which case careers are scored on the other.
+func NewService(neo4jClient *neo4j.Client, mongoClient *mongodb.Client, vacancies Vacancies, assessor Assessor, cfg config.DemandConfig, logger *zap.Logger) *Service {
+	return &Service{
+		neo4jClient: neo4jClient,
+		store:       mongodb.NewCareerDemandStore(mongoClient, logger),
+		vacancies:   vacancies,
+		assessor:    assessor,
+		config:      cfg,
+		logger:      logger,
+	}
+}
+
+// Recompute scores every career in the graph from the current signals,
+// replacing the indexes computed before. Careers without any signal are left
+// unscored.
+func (s *Service) Recompute(ctx context.Context) (*RecomputeReport, error) {
+	started := time.Now()
+
+	titles, err := s.neo4jClient.ListNames(ctx, neo4j.KindCareer)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Recomputing career demand", zap.Int("careers", len(titles)))
+
+	var open map[string]int64
+	if s.vacancies != nil {
+		if open, err = s.vacancies.OpenByCareer(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	scores := make([]mongodb.CareerDemand, 0, len(titles))
+	for _, title := range titles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		score := mongodb.CareerDemand{Career: title, OpenVacancies: open[title]}
+		// Before any vacancy has been seen, a career without postings says
+		// nothing about its demand
+		if len(open) > 0 {
+			vacancyScore := VacancyScore(open[title], s.config.VacancySaturation)
+			score.VacancyScore = &vacancyScore
+		}
+		if s.assessor != nil {
+			assessment, err := s.assessor.DemandAssessment(ctx, title)
+			if err != nil {
+				s.logger.Warn("Failed to read demand assessment",
+					zap.String("career", title),
+					zap.Error(err))
+			}
+			if assessmentScore, ok := AssessmentScore(assessment); ok {
+				score.Assessment = assessment
+				score.AssessmentScore = &assessmentScore
+			}
+		}
+
+		index, ok := Combine(score.VacancyScore, score.AssessmentScore, s.config.VacancyWeight, s.config.AssessmentWeight)
+		if !ok {
+			continue
+		}
+		score.Index = index
+		scores = append(scores, score)
+	}
+
+	if err := s.store.SaveScores(ctx, scores, started); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Career demand recomputed",
+		zap.Int("careers", len(titles)),
+		zap.Int("scored", len(scores)))
+	return &RecomputeReport{Careers: len(titles), Scored: len(scores)}, nil
+}
+
+// Indexes returns the demand index of each scored or overridden career
+func (s *Service) Indexes(ctx context.Context) (map[string]float64, error) {
+	scores, err := s.store.Scores(ctx)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.store.Overrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string]float64, len(scores)+len(overrides))
+	for _, score := range scores {
+		indexes[score.Career] = score.Index
+	}
+	for _, override := range overrides {
+		indexes[override.Career] = override.Index
+	}
+	return indexes, nil
+}
+
+// List returns every scored or overridden career, highest index first
+func (s *Service) List(ctx context.Context) ([]CareerIndex, error) {
+	scores, err := s.store.Scores(ctx)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.store.Overrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byCareer := make(map[string]*CareerIndex, len(scores)+len(overrides))
+	for i := range scores {
+		score := &scores[i]
+		byCareer[score.Career] = &CareerIndex{Career: score.Career, Index: score.Index, Computed: score}
+	}
+	for i := range overrides {
+		override := &overrides[i]
+		entry, ok := byCareer[override.Career]
+		if !ok {
+			entry = &CareerIndex{Career: override.Career}
+			byCareer[override.Career] = entry
+		}
+		entry.Index = override.Index
+		entry.Override = override
+	}
+
+	list := make([]CareerIndex, 0, len(byCareer))
+	for _, entry := range byCareer {
+		list = append(list, *entry)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Index != list[j].Index {
+			return list[i].Index > list[j].Index
+		}
+		return list[i].Career < list[j].Career
+	})
+	return list, nil
+}
+
+// SetOverride sets the demand index of a career in place of the computed one
+func (s *Service) SetOverride(ctx context.Context, career string, index float64, reason string) (*mongodb.DemandOverride, error) {
+	s.logger.Debug("Setting demand override", zap.String("career", career), zap.Float64("index", index))
+
+	if index < 0 || index > MaxIndex || math.IsNaN(index) {
+		return nil, fmt.Errorf("%w: index must be between 0 and %d", ErrInvalidOverride, MaxIndex)
+	}
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindCareer, []string{career})
+	if err != nil {
+		return nil, err
+	}
+	if !existing[career] {
+		return nil, fmt.Errorf("%w: %q", ErrCareerNotFound, career)
+	}
+
+	override := &mongodb.DemandOverride{
+		Career: career,
+		Index:  round(index),
+		Reason: strings.Join(strings.Fields(reason), " "),
+	}
+	if err := s.store.SetOverride(ctx, override); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Demand override set",
+		zap.String("career", career),
+		zap.Float64("index", override.Index))
+	return override, nil
+}
+
+// DeleteOverride removes a career's override, restoring its computed index
+func (s *Service) DeleteOverride(ctx context.Context, career string) error {
+	deleted, err := s.store.DeleteOverride(ctx, career)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: %q", ErrOverrideNotFound, career)
+	}
+
+	s.logger.Info("Demand override removed", zap.String("career", career))
+	return nil
+}
+
+// VacancyScore rates open vacancies from 0 to 100 on a logarithmic scale, so
+// the first few postings count most, reaching 100 at saturation postings
+func VacancyScore(open int64, saturation int) float64 {
+	if open <= 0 || saturation <= 0 {
+		return 0
+	}
+	return round(MaxIndex * math.Min(1, math.Log1p(float64(open))/math.Log1p(float64(saturation))))
+}
+
+// AssessmentScore rates an LLM demand assessment by the level it names first,
+// reporting false when it names none
+func AssessmentScore(assessment string) (float64, bool) {
+	text := strings.ToLower(assessment)
+	first, score := -1, 0.0
+	for _, level := range assessmentLevels {
+		at := strings.Index(text, level.Level)
+		if at >= 0 && (first < 0 || at < first) {
+			first, score = at, level.Score
+		}
+	}
+	return score, first >= 0
+}
+
+// Combine weighs the available signals into an index, reporting false when
+// neither is available or the available one carries no weight
+func Combine(vacancyScore, assessmentScore *float64, vacancyWeight, assessmentWeight float64) (float64, bool) {
+	var total, weights float64
+	if vacancyScore != nil && vacancyWeight > 0 {
+		total += *vacancyScore * vacancyWeight
+		weights += vacancyWeight
+	}
+	if assessmentScore != nil && assessmentWeight > 0 {
+		total += *assessmentScore * assessmentWeight
+		weights += assessmentWeight
+	}
+	if weights == 0 {
+		return 0, false
+	}
+	return round(total / weights), true
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
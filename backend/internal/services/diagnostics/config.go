@@ -0,0 +1,103 @@
+package diagnostics
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// masked replaces secrets in the sanitized configuration
+const masked = "***"
+
+// sanitize returns a configuration value as JSON-ready maps, slices and scalars
+// keyed by the mapstructure names of its fields. Secrets are masked and
+// connection strings cut down to their scheme and host, so the result is safe to
+// show to anyone holding the admin key.
+func sanitize(v reflect.Value, name string) any {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(v.Int()).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return sanitize(v.Elem(), name)
+	case reflect.Struct:
+		fields := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			key, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+			if key == "" || key == "-" {
+				key = field.Name
+			}
+			fields[key] = sanitize(v.Field(i), key)
+		}
+		return fields
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return []any{}
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = sanitize(v.Index(i), name)
+		}
+		return items
+	case reflect.Map:
+		entries := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			if name == "headers" {
+				// Headers sent to other services carry their credentials
+				entries[key] = masked
+				continue
+			}
+			entries[key] = sanitize(iter.Value(), strings.ToLower(strings.ReplaceAll(key, "-", "_")))
+		}
+		return entries
+	case reflect.String:
+		return sanitizeString(name, v.String())
+	}
+	return v.Interface()
+}
+
+// sanitizeString masks a configuration string by the name of its setting
+func sanitizeString(name, value string) string {
+	if value == "" {
+		return ""
+	}
+	switch {
+	case isSecret(name):
+		return masked
+	case name == "uri" || name == "dsn" || name == "urls":
+		return hostOnly(value)
+	}
+	return value
+}
+
+// isSecret reports whether a setting holds a password, key or token. Files
+// holding keys are paths, not secrets.
+func isSecret(name string) bool {
+	return name == "password" || name == "secret" || name == "key" || name == "token" ||
+		strings.HasSuffix(name, "_password") ||
+		strings.HasSuffix(name, "_secret") ||
+		strings.HasSuffix(name, "_key") ||
+		strings.HasSuffix(name, "_token")
+}
+
+// hostOnly keeps the scheme and host of a connection string, which may carry
+// credentials in its user info, path or query
+func hostOnly(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return masked
+	}
+	return u.Scheme + "://" + u.Host
+}
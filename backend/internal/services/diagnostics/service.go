@@ -0,0 +1,241 @@
+// Package diagnostics lets admins inspect a running instance in any
+// environment: its configuration with secrets masked, the health of the
+// services it depends on, and a smoke test of the pathway reads students rely
+// on. They are available in production too, since production issues are when
+// such checks are needed most.
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"go.uber.org/zap"
+)
+
+// Statuses of an instance's health and of smoke test steps
+const (
+	StatusHealthy  = "healthy"
+	StatusDegraded = "degraded"
+
+	StepPassed  = "passed"
+	StepFailed  = "failed"
+	StepSkipped = "skipped"
+)
+
+// Dependencies are what diagnostics check, provided by the container
+type Dependencies interface {
+	HealthCheck(ctx context.Context) map[string]bool
+	Readiness(ctx context.Context) error
+	PathwayService() *pathway.Service
+}
+
+// Health is the state of the services an instance depends on
+type Health struct {
+	Status       string          `json:"status"`
+	Dependencies map[string]bool `json:"dependencies"`
+	Ready        bool            `json:"ready"`
+	// NotReadyReason is why the instance should not take traffic, when it
+	// should not
+	NotReadyReason string    `json:"not_ready_reason,omitempty"`
+	Environment    string    `json:"environment"`
+	Demo           bool      `json:"demo"`
+	Uptime         string    `json:"uptime"`
+	CheckedAt      time.Time `json:"checked_at"`
+	DurationMS     int64     `json:"duration_ms"`
+}
+
+// SmokeStep is one read of a smoke test
+type SmokeStep struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Count      int    `json:"count,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// SmokeTest is the result of reading through the pathway graph the way a
+// student browsing it would, each step using what the one before found
+type SmokeTest struct {
+	Passed     bool        `json:"passed"`
+	Steps      []SmokeStep `json:"steps"`
+	RanAt      time.Time   `json:"ran_at"`
+	DurationMS int64       `json:"duration_ms"`
+}
+
+// Service runs diagnostics of the instance it is part of
+type Service struct {
+	cfg       *config.Config
+	deps      Dependencies
+	startedAt time.Time
+	logger    *zap.Logger
+}
+
+// NewService creates a new diagnostics service. deps are only used once a
+// diagnostic runs, so they may still be initializing.
+func NewService(cfg *config.Config, deps Dependencies, logger *zap.Logger) *Service {
+	return &Service{
+		cfg:       cfg,
+		deps:      deps,
+		startedAt: time.Now(),
+		logger:    logger,
+	}
+}
+
+// Config returns the configuration the instance runs with, keyed by setting
+// name, with passwords, keys and tokens masked and connection strings cut down
+// to their scheme and host
+func (s *Service) Config() map[string]any {
+	sanitized, _ := sanitize(reflect.ValueOf(*s.cfg), "").(map[string]any)
+	return sanitized
+}
+
+// Health checks every service the instance depends on
+func (s *Service) Health(ctx context.Context) *Health {
+	s.logger.Debug("Checking dependency health")
+
+	start := time.Now()
+	health := &Health{
+		Status:       StatusHealthy,
+		Dependencies: s.deps.HealthCheck(ctx),
+		Ready:        true,
+		Environment:  s.cfg.Server.Environment,
+		Demo:         s.cfg.Server.Demo,
+		Uptime:       time.Since(s.startedAt).Round(time.Second).String(),
+	}
+	for _, healthy := range health.Dependencies {
+		if !healthy {
+			health.Status = StatusDegraded
+		}
+	}
+	if err := s.deps.Readiness(ctx); err != nil {
+		health.Ready = false
+		health.NotReadyReason = err.Error()
+		health.Status = StatusDegraded
+	}
+	health.CheckedAt = time.Now().UTC()
+	health.DurationMS = time.Since(start).Milliseconds()
+
+	s.logger.Info("Dependency health checked",
+		zap.String("status", health.Status),
+		zap.Bool("ready", health.Ready))
+	return health
+}
+
+// RunSmokeTest reads institutes, the programs of the first institute offering
+// any, the details of one of them, careers, and the pathway from that program's
+// first requirement. Steps with nothing to read are skipped; the test passes when no
+// step fails.
+func (s *Service) RunSmokeTest(ctx context.Context) *SmokeTest {
+	s.logger.Debug("Running pathway smoke test")
+
+	start := time.Now()
+	test := &SmokeTest{Passed: true, RanAt: start.UTC()}
+	service := s.deps.PathwayService()
+
+	run := func(name string, read func() (int, string, error)) {
+		stepStart := time.Now()
+		count, detail, err := read()
+		step := SmokeStep{
+			Name:       name,
+			Status:     StepPassed,
+			Count:      count,
+			Detail:     detail,
+			DurationMS: time.Since(stepStart).Milliseconds(),
+		}
+		if err != nil {
+			step.Status = StepFailed
+			step.Error = err.Error()
+			test.Passed = false
+		}
+		test.Steps = append(test.Steps, step)
+	}
+	skip := func(name, detail string) {
+		test.Steps = append(test.Steps, SmokeStep{Name: name, Status: StepSkipped, Detail: detail})
+	}
+
+	var institutes []string
+	run("institutes", func() (int, string, error) {
+		found, err := service.GetAllInstitutes(ctx)
+		if err != nil {
+			return 0, "", err
+		}
+		if len(found) == 0 {
+			return 0, "", fmt.Errorf("the graph has no institutes")
+		}
+		for _, institute := range found {
+			institutes = append(institutes, institute.Name)
+		}
+		return len(found), "", nil
+	})
+
+	var program string
+	if len(institutes) == 0 {
+		skip("institute_programs", "no institute to read programs of")
+	} else {
+		run("institute_programs", func() (int, string, error) {
+			for _, institute := range institutes {
+				programs, err := service.GetProgramsByInstitute(ctx, institute)
+				if err != nil {
+					return 0, institute, err
+				}
+				if len(programs) > 0 {
+					program = programs[0].Name
+					for _, p := range programs {
+						// Prefer a program the pathway step can start from
+						if p.Department != "" && len(p.Requirements) > 0 {
+							program = p.Name
+							break
+						}
+					}
+					return len(programs), institute, nil
+				}
+			}
+			return 0, "", fmt.Errorf("no institute offers any program")
+		})
+	}
+
+	var department, qualification string
+	if program == "" {
+		skip("program_details", "no program to read")
+	} else {
+		run("program_details", func() (int, string, error) {
+			details, err := service.GetProgramDetails(ctx, program)
+			if err != nil {
+				return 0, program, err
+			}
+			department = details.Department
+			if len(details.Requirements) > 0 {
+				qualification = details.Requirements[0].Name
+			}
+			return len(details.Requirements), program, nil
+		})
+	}
+
+	run("careers", func() (int, string, error) {
+		careers, err := service.GetAllCareers(ctx)
+		return len(careers), "", err
+	})
+
+	if department == "" || qualification == "" {
+		skip("pathway_by_qualification", "no program with a department and a requirement to start from")
+	} else {
+		run("pathway_by_qualification", func() (int, string, error) {
+			programs, err := service.GetPathwayByQualification(ctx, department, qualification, neo4j.MaxPrerequisiteDepth, "")
+			return len(programs), qualification + " in " + department, err
+		})
+	}
+
+	test.DurationMS = time.Since(start).Milliseconds()
+	if test.Passed {
+		s.logger.Info("Pathway smoke test passed", zap.Int64("duration_ms", test.DurationMS))
+	} else {
+		s.logger.Warn("Pathway smoke test failed", zap.Int64("duration_ms", test.DurationMS))
+	}
+	return test
+}
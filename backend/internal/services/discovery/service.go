@@ -0,0 +1,305 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/data/weaviate"
+	"go.uber.org/zap"
+)
+
+const (
+	// Bounds on the length of a description of interests
+	MinInterestsLength = 10
+	MaxInterestsLength = 500
+
+	// Defaults and bounds for the number of suggestions returned
+	DefaultSuggestions = 8
+	MaxSuggestions     = 20
+
+	// minCertainty is the semantic closeness below which hits are not suggested
+	minCertainty = 0.55
+
+	// overfetch is the number of hits searched per suggestion, as some may no
+	// longer be in the graph
+	overfetch = 2
+
+	// maxListed caps the related names quoted in a fallback explanation
+	maxListed = 3
+)
+
+var (
+	// ErrUnavailable is returned when no vector store is configured
+	ErrUnavailable = errors.New("semantic discovery is not available")
+
+	// ErrInvalidInterests is returned for a description that is empty or too long
+	ErrInvalidInterests = errors.New("invalid interests")
+)
+
+// Suggestion is a program or career that matches a student's interests and is
+// still in the graph, with the context a student needs to follow it up
+type Suggestion struct {
+	Kind         string   `json:"kind"`
+	Name         string   `json:"name"`
+	Score        float64  `json:"score"`
+	Explanation  string   `json:"explanation"`
+	Institute    string   `json:"institute,omitempty"`
+	Requirements []string `json:"requirements,omitempty"`
+	Careers      []string `json:"careers,omitempty"`
+	Programs     []string `json:"programs,omitempty"`
+}
+
+// Discovery is the outcome of a "describe your interests" search. Explained is
+// false when the explanations are generic because the LLM was unavailable.
+type Discovery struct {
+	Interests   string       `json:"interests"`
+	Suggestions []Suggestion `json:"suggestions"`
+	Explained   bool         `json:"explained"`
+}
+
+// Service matches free-text descriptions of interests to programs and careers
+type Service struct {
+	neo4jClient *neo4j.Client
+	vectors     *weaviate.Client
+	llmClient   *llm.Client
+	logger      *zap.Logger
+}
+
+// NewService creates a new discovery service. weaviateClient may be nil, in which
+// case discovery is unavailable; without llmClient explanations are generic.
+func NewService(neo4jClient *neo4j.Client, weaviateClient *weaviate.Client, llmClient *llm.Client, logger *zap.Logger) *Service {
+	return &Service{
+		neo4jClient: neo4jClient,
+		vectors:     weaviateClient,
+		llmClient:   llmClient,
+		logger:      logger,
+	}
+}
+
+// Available reports whether a vector store is configured
+func (s *Service) Available() bool {
+	return s.vectors != nil
+}
+
+// Reindex rebuilds the vector index from the programs and careers in the graph,
+// returning the number of documents indexed
+func (s *Service) Reindex(ctx context.Context) (int, error) {
+	s.logger.Debug("Reindexing discovery documents")
+
+	if s.vectors == nil {
+		return 0, ErrUnavailable
+	}
+
+	var docs []weaviate.PathwayDocument
+	for _, kind := range []string{neo4j.KindProgram, neo4j.KindCareer} {
+		profiles, err := s.neo4jClient.PathwayProfiles(ctx, kind, nil)
+		if err != nil {
+			return 0, err
+		}
+		for _, profile := range profiles {
+			docs = append(docs, weaviate.PathwayDocument{
+				Kind:        kind,
+				Name:        profile.Name,
+				Description: describe(profile),
+			})
+		}
+	}
+
+	if err := s.vectors.ReplacePathwayIndex(ctx, docs); err != nil {
+		s.logger.Error("Failed to reindex discovery documents", zap.Error(err))
+		return 0, err
+	}
+
+	s.logger.Info("Discovery documents reindexed", zap.Int("documents", len(docs)))
+	return len(docs), nil
+}
+
+// Discover finds the programs and careers closest in meaning to a description
+// of interests, keeps those still in the graph and explains each match
+func (s *Service) Discover(ctx context.Context, interests string, limit int) (*Discovery, error) {
+	s.logger.Debug("Discovering pathways from interests", zap.Int("length", len(interests)))
+
+	interests = strings.Join(strings.Fields(interests), " ")
+	suggestions, err := s.Match(ctx, interests, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	discovery := &Discovery{
+		Interests:   interests,
+		Suggestions: suggestions,
+	}
+	discovery.Explained = s.explain(ctx, interests, suggestions)
+
+	s.logger.Info("Pathways discovered",
+		zap.Int("suggestions", len(suggestions)),
+		zap.Bool("explained", discovery.Explained))
+	return discovery, nil
+}
+
+// Match finds the programs and careers closest in meaning to a description of
+// interests that are still in the graph, closest first, without explaining them
+func (s *Service) Match(ctx context.Context, interests string, limit int) ([]Suggestion, error) {
+	if s.vectors == nil {
+		return nil, ErrUnavailable
+	}
+	interests = strings.Join(strings.Fields(interests), " ")
+	if len(interests) < MinInterestsLength || len(interests) > MaxInterestsLength {
+		return nil, fmt.Errorf("%w: describe your interests in %d to %d characters",
+			ErrInvalidInterests, MinInterestsLength, MaxInterestsLength)
+	}
+	if limit <= 0 {
+		limit = DefaultSuggestions
+	}
+	if limit > MaxSuggestions {
+		limit = MaxSuggestions
+	}
+
+	hits, err := s.vectors.SearchPathways(ctx, interests, limit*overfetch)
+	if err != nil {
+		return nil, err
+	}
+	suggestions, err := s.validate(ctx, hits, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("Pathways matched",
+		zap.Int("hits", len(hits)),
+		zap.Int("suggestions", len(suggestions)))
+	return suggestions, nil
+}
+
+// validate keeps the hits that are close enough and still in the graph, filling
+// in their current relationships. Hits arrive closest first.
+func (s *Service) validate(ctx context.Context, hits []weaviate.PathwayHit, limit int) ([]Suggestion, error) {
+	names := map[string][]string{}
+	for _, hit := range hits {
+		if hit.Certainty >= minCertainty {
+			names[hit.Kind] = append(names[hit.Kind], hit.Name)
+		}
+	}
+
+	profiles := map[string]neo4j.PathwayProfile{}
+	for kind, kindNames := range names {
+		found, err := s.neo4jClient.PathwayProfiles(ctx, kind, kindNames)
+		if err != nil {
+			return nil, err
+		}
+		for _, profile := range found {
+			profiles[kind+"\x00"+profile.Name] = profile
+		}
+	}
+
+	suggestions := []Suggestion{}
+	for _, hit := range hits {
+		profile, ok := profiles[hit.Kind+"\x00"+hit.Name]
+		if !ok || hit.Certainty < minCertainty {
+			continue
+		}
+		suggestion := Suggestion{
+			Kind:         hit.Kind,
+			Name:         hit.Name,
+			Score:        hit.Certainty,
+			Institute:    profile.Institute,
+			Requirements: profile.Requirements,
+		}
+		if hit.Kind == neo4j.KindProgram {
+			suggestion.Careers = profile.Related
+		} else {
+			suggestion.Programs = profile.Related
+		}
+		suggestions = append(suggestions, suggestion)
+		if len(suggestions) == limit {
+			break
+		}
+	}
+	return suggestions, nil
+}
+
+// explain fills in the explanations, asking the LLM when available and falling
+// back to generic ones. It reports whether the LLM explained every suggestion.
+func (s *Service) explain(ctx context.Context, interests string, suggestions []Suggestion) bool {
+	if len(suggestions) == 0 {
+		return false
+	}
+
+	var explanations map[string]string
+	if s.llmClient != nil {
+		matches := make([]llm.InterestMatch, 0, len(suggestions))
+		for _, suggestion := range suggestions {
+			matches = append(matches, llm.InterestMatch{
+				Kind:         suggestion.Kind,
+				Name:         suggestion.Name,
+				Requirements: suggestion.Requirements,
+				Related:      append(suggestion.Careers, suggestion.Programs...),
+			})
+		}
+		var err error
+		if explanations, err = s.llmClient.ExplainInterestMatches(ctx, interests, matches); err != nil {
+			s.logger.Warn("Failed to explain discovered pathways, using generic explanations", zap.Error(err))
+		}
+	}
+
+	explained := true
+	for i := range suggestions {
+		if explanation, ok := explanations[suggestions[i].Name]; ok {
+			suggestions[i].Explanation = explanation
+			continue
+		}
+		suggestions[i].Explanation = genericExplanation(suggestions[i])
+		explained = false
+	}
+	return explained
+}
+
+// describe writes the text a program or career is embedded from
+func describe(profile neo4j.PathwayProfile) string {
+	var b strings.Builder
+	if profile.Kind == neo4j.KindProgram {
+		fmt.Fprintf(&b, "Study program: %s.", profile.Name)
+		if profile.Institute != "" {
+			fmt.Fprintf(&b, " Offered by %s.", profile.Institute)
+		}
+		if len(profile.Related) > 0 {
+			fmt.Fprintf(&b, " Leads to careers as %s.", strings.Join(profile.Related, ", "))
+		}
+		if len(profile.Requirements) > 0 {
+			fmt.Fprintf(&b, " Entry requirements: %s.", strings.Join(profile.Requirements, ", "))
+		}
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Career: %s.", profile.Name)
+	if len(profile.Related) > 0 {
+		fmt.Fprintf(&b, " Reached by studying %s.", strings.Join(profile.Related, ", "))
+	}
+	return b.String()
+}
+
+// genericExplanation explains a suggestion from its graph context alone
+func genericExplanation(suggestion Suggestion) string {
+	if suggestion.Kind == neo4j.KindProgram {
+		if len(suggestion.Careers) == 0 {
+			return "This program is close to the interests you described."
+		}
+		return fmt.Sprintf("This program is close to the interests you described and leads to careers such as %s.",
+			listed(suggestion.Careers))
+	}
+	if len(suggestion.Programs) == 0 {
+		return "This career is close to the interests you described."
+	}
+	return fmt.Sprintf("This career is close to the interests you described; programs such as %s lead to it.",
+		listed(suggestion.Programs))
+}
+
+func listed(names []string) string {
+	if len(names) > maxListed {
+		names = names[:maxListed]
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,219 @@
+// Package drafts issues draft profiles: a student's answers to the eligibility
+// and recommendation wizards, held by the client between steps and signed by
+// the server so they can be trusted when sent back. Nothing is stored on the
+// server, so wizards work without an account and without keeping personal data.
+package drafts
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"go.uber.org/zap"
+)
+
+// Header carries a draft profile on the requests it fills in
+const Header = "X-Draft-Profile"
+
+const (
+	// Bounds on a profile, which keep drafts small enough for a header
+	maxQualifications  = 20
+	maxSubjects        = 15
+	maxInterestsLength = 1000
+	maxStudyHours      = 84
+
+	// maxTokenLength rejects drafts too long to have been issued here before
+	// their signature is checked
+	maxTokenLength = 8 << 10
+)
+
+var (
+	// ErrInvalidDraft is returned for drafts that are malformed or not signed
+	// by this server
+	ErrInvalidDraft = errors.New("invalid draft profile")
+
+	// ErrDraftExpired is returned for drafts issued longer ago than the TTL
+	ErrDraftExpired = errors.New("draft profile has expired")
+
+	// ErrInvalidProfile is returned for profile answers out of bounds
+	ErrInvalidProfile = errors.New("invalid profile")
+)
+
+// Profile is what a student has answered so far. Empty fields are unanswered.
+type Profile struct {
+	Qualifications      []string                 `json:"qualifications,omitempty"`
+	Subjects            []llm.SubjectGrade       `json:"subjects,omitempty"`
+	StudyHoursPerWeek   int                      `json:"study_hours_per_week,omitempty"`
+	HouseholdIncomeBand string                   `json:"household_income_band,omitempty"`
+	Experience          []pathway.WorkExperience `json:"experience,omitempty"`
+	Interests           string                   `json:"interests,omitempty"`
+}
+
+// Merge returns the profile with the answers given in update replacing its own
+func (p Profile) Merge(update Profile) Profile {
+	if len(update.Qualifications) > 0 {
+		p.Qualifications = update.Qualifications
+	}
+	if len(update.Subjects) > 0 {
+		p.Subjects = update.Subjects
+	}
+	if update.StudyHoursPerWeek > 0 {
+		p.StudyHoursPerWeek = update.StudyHoursPerWeek
+	}
+	if update.HouseholdIncomeBand != "" {
+		p.HouseholdIncomeBand = update.HouseholdIncomeBand
+	}
+	if len(update.Experience) > 0 {
+		p.Experience = update.Experience
+	}
+	if update.Interests != "" {
+		p.Interests = update.Interests
+	}
+	return p
+}
+
+func (p Profile) validate() error {
+	switch {
+	case len(p.Qualifications) > maxQualifications:
+		return fmt.Errorf("%w: at most %d qualifications", ErrInvalidProfile, maxQualifications)
+	case len(p.Subjects) > maxSubjects:
+		return fmt.Errorf("%w: at most %d subjects", ErrInvalidProfile, maxSubjects)
+	case len(p.Experience) > pathway.MaxWorkExperiences:
+		return fmt.Errorf("%w: at most %d jobs", ErrInvalidProfile, pathway.MaxWorkExperiences)
+	case len([]rune(p.Interests)) > maxInterestsLength:
+		return fmt.Errorf("%w: interests must be at most %d characters", ErrInvalidProfile, maxInterestsLength)
+	case p.StudyHoursPerWeek < 0 || p.StudyHoursPerWeek > maxStudyHours:
+		return fmt.Errorf("%w: study_hours_per_week must be between 0 and %d", ErrInvalidProfile, maxStudyHours)
+	case p.HouseholdIncomeBand != "" && !slices.Contains(pathway.IncomeBands, p.HouseholdIncomeBand):
+		return fmt.Errorf("%w: household_income_band must be one of %s", ErrInvalidProfile, strings.Join(pathway.IncomeBands, ", "))
+	}
+	return nil
+}
+
+// Draft is a signed profile issued to a client
+type Draft struct {
+	Token     string    `json:"token"`
+	Profile   Profile   `json:"profile"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// claims are what a draft token signs
+type claims struct {
+	Profile   Profile `json:"p"`
+	ExpiresAt int64   `json:"exp"`
+}
+
+// Service issues and verifies draft profiles
+type Service struct {
+	secret []byte
+	ttl    time.Duration
+	logger *zap.Logger
+}
+
+// NewService creates a new draft profile service. Without a configured secret
+// a random one is used, valid until the process exits.
+func NewService(cfg config.DraftsConfig, logger *zap.Logger) *Service {
+	secret := []byte(cfg.Secret)
+	if len(secret) == 0 {
+		logger.Warn("DRAFT_PROFILE_SECRET is not set, draft profiles will not survive a restart")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic("drafts: failed to generate a secret: " + err.Error())
+		}
+	}
+	return &Service{
+		secret: secret,
+		ttl:    cfg.TTL,
+		logger: logger,
+	}
+}
+
+// Issue signs a profile for the client to hold: the answers in update on top
+// of those in the previous draft, if any. Every issue starts the TTL again.
+func (s *Service) Issue(ctx context.Context, previous string, update Profile) (*Draft, error) {
+	var profile Profile
+	if previous != "" {
+		verified, err := s.Verify(ctx, previous)
+		if err != nil {
+			return nil, err
+		}
+		profile = *verified
+	}
+	profile = profile.Merge(update)
+	if err := profile.validate(); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.ttl).Truncate(time.Second).UTC()
+	payload, err := json.Marshal(claims{Profile: profile, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return nil, err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	token := encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded))
+
+	// Answers are personal, so only their shape is logged
+	s.logger.Debug("Draft profile issued",
+		zap.Int("qualifications", len(profile.Qualifications)),
+		zap.Int("subjects", len(profile.Subjects)),
+		zap.Int("experience", len(profile.Experience)))
+	return &Draft{Token: token, Profile: profile, ExpiresAt: expiresAt}, nil
+}
+
+// Verify returns the profile of a draft issued by this server that has not
+// expired
+func (s *Service) Verify(_ context.Context, token string) (*Profile, error) {
+	if len(token) > maxTokenLength {
+		return nil, ErrInvalidDraft
+	}
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidDraft
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(encoded)) {
+		return nil, ErrInvalidDraft
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidDraft
+	}
+	var signed claims
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return nil, ErrInvalidDraft
+	}
+	if time.Now().Unix() >= signed.ExpiresAt {
+		return nil, ErrDraftExpired
+	}
+	return &signed.Profile, nil
+}
+
+func (s *Service) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+type profileKey struct{}
+
+// WithProfile returns a context carrying a verified draft profile
+func WithProfile(ctx context.Context, profile *Profile) context.Context {
+	return context.WithValue(ctx, profileKey{}, profile)
+}
+
+// FromContext returns the verified draft profile ctx carries, or nil
+func FromContext(ctx context.Context) *Profile {
+	profile, _ := ctx.Value(profileKey{}).(*Profile)
+	return profile
+}
@@ -0,0 +1,256 @@
+// Package events lists the open days, aptitude tests and career fairs held by
+// institutes. Admins maintain the events; students see them on institute pages,
+// and the upcoming-events feed is what notifications about them are sent from.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+const (
+	// Defaults and bounds for the days ahead covered by the upcoming-events feed
+	DefaultUpcomingDays = 30
+	MaxUpcomingDays     = 365
+
+	// maxFeedEvents bounds the events returned by one feed request
+	maxFeedEvents = 500
+)
+
+// Kinds are the kinds of events institutes hold
+var Kinds = []string{mongodb.EventOpenDay, mongodb.EventAptitudeTest, mongodb.EventCareerFair}
+
+var (
+	// ErrInstituteNotFound is returned for events of an institute not in the graph
+	ErrInstituteNotFound = errors.New("institute not found")
+
+	// ErrEventNotFound is returned for an event ID that does not exist
+	ErrEventNotFound = errors.New("event not found")
+
+	// ErrInvalidEvent is returned for events missing a title or date, or with an
+	// unknown kind, a malformed link or programs not in the graph
+	ErrInvalidEvent = errors.New("invalid event")
+)
+
+// UpcomingQuery selects the events of the upcoming-events feed
+type UpcomingQuery struct {
+	Days     int      // days ahead, DefaultUpcomingDays when zero
+	Kinds    []string // all kinds when empty
+	District string
+
+	// UpdatedSince limits the feed to events added or changed after it, so a
+	// notifier polling the feed only sees what it has not sent yet
+	UpdatedSince time.Time
+}
+
+// Feed is the upcoming-events feed
+type Feed struct {
+	From   time.Time                `json:"from"`
+	To     time.Time                `json:"to"`
+	Events []mongodb.InstituteEvent `json:"events"`
+	Count  int                      `json:"count"`
+}
+
+// Service maintains and lists institute events
+type Service struct {
+	neo4jClient *neo4j.Client
+	store       *mongodb.InstituteEventStore
+	logger      *zap.Logger
+}
+
+// NewService creates a new institute event service
+func NewService(neo4jClient *neo4j.Client, mongoClient *mongodb.Client, logger *zap.Logger) *Service {
+	return &Service{
+		neo4jClient: neo4jClient,
+		store:       mongodb.NewInstituteEventStore(mongoClient, logger),
+		logger:      logger,
+	}
+}
+
+// Create adds an event to an institute
+func (s *Service) Create(ctx context.Context, event mongodb.InstituteEvent) (*mongodb.InstituteEvent, error) {
+	if err := s.validate(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("Creating institute event",
+		zap.String("institute", event.Institute),
+		zap.String("kind", event.Kind))
+
+	if err := s.store.Create(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Institute event created",
+		zap.String("id", event.ID),
+		zap.String("institute", event.Institute),
+		zap.String("kind", event.Kind))
+	return &event, nil
+}
+
+// Get returns an event
+func (s *Service) Get(ctx context.Context, id string) (*mongodb.InstituteEvent, error) {
+	event, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, fmt.Errorf("%w: %q", ErrEventNotFound, id)
+	}
+	return event, nil
+}
+
+// Update replaces an event's details
+func (s *Service) Update(ctx context.Context, id string, event mongodb.InstituteEvent) (*mongodb.InstituteEvent, error) {
+	if err := s.validate(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("Updating institute event", zap.String("id", id))
+
+	event.ID = id
+	found, err := s.store.Replace(ctx, &event)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %q", ErrEventNotFound, id)
+	}
+
+	s.logger.Info("Institute event updated",
+		zap.String("id", id),
+		zap.String("institute", event.Institute))
+	return &event, nil
+}
+
+// Delete removes an event
+func (s *Service) Delete(ctx context.Context, id string) error {
+	found, err := s.store.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: %q", ErrEventNotFound, id)
+	}
+
+	s.logger.Info("Institute event deleted", zap.String("id", id))
+	return nil
+}
+
+// ForInstitute returns an institute's events that have not ended yet, soonest
+// first, or all of its events when includePast is set
+func (s *Service) ForInstitute(ctx context.Context, institute string, includePast bool) ([]mongodb.InstituteEvent, error) {
+	if err := s.checkInstitute(ctx, institute); err != nil {
+		return nil, err
+	}
+
+	filter := mongodb.EventFilter{Institute: institute}
+	if !includePast {
+		filter.From = time.Now()
+	}
+	return s.store.List(ctx, filter)
+}
+
+// Upcoming returns the events of all institutes in the coming days, soonest first
+func (s *Service) Upcoming(ctx context.Context, query UpcomingQuery) (*Feed, error) {
+	days := query.Days
+	if days <= 0 {
+		days = DefaultUpcomingDays
+	}
+	if days > MaxUpcomingDays {
+		days = MaxUpcomingDays
+	}
+	for _, kind := range query.Kinds {
+		if !validKind(kind) {
+			return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidEvent, kind)
+		}
+	}
+
+	now := time.Now().UTC()
+	feed := &Feed{From: now, To: now.AddDate(0, 0, days)}
+
+	events, err := s.store.List(ctx, mongodb.EventFilter{
+		Kinds:        query.Kinds,
+		District:     query.District,
+		From:         feed.From,
+		To:           feed.To,
+		UpdatedSince: query.UpdatedSince,
+		Limit:        maxFeedEvents,
+	})
+	if err != nil {
+		return nil, err
+	}
+	feed.Events = events
+	feed.Count = len(events)
+	return feed, nil
+}
+
+// validate checks an event's fields and that its institute and programs are in
+// the graph
+func (s *Service) validate(ctx context.Context, event *mongodb.InstituteEvent) error {
+	event.Institute = strings.TrimSpace(event.Institute)
+	event.Title = strings.TrimSpace(event.Title)
+
+	switch {
+	case event.Title == "":
+		return fmt.Errorf("%w: title is required", ErrInvalidEvent)
+	case !validKind(event.Kind):
+		return fmt.Errorf("%w: kind must be one of %s", ErrInvalidEvent, strings.Join(Kinds, ", "))
+	case event.StartsAt.IsZero():
+		return fmt.Errorf("%w: starts_at is required", ErrInvalidEvent)
+	case event.EndsAt != nil && event.EndsAt.Before(event.StartsAt):
+		return fmt.Errorf("%w: ends_at is before starts_at", ErrInvalidEvent)
+	}
+
+	if event.URL != "" {
+		parsed, err := url.Parse(event.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%w: url must be an http(s) link", ErrInvalidEvent)
+		}
+	}
+
+	if err := s.checkInstitute(ctx, event.Institute); err != nil {
+		return err
+	}
+
+	if len(event.Programs) > 0 {
+		existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, event.Programs)
+		if err != nil {
+			return err
+		}
+		for _, program := range event.Programs {
+			if !existing[program] {
+				return fmt.Errorf("%w: program %q not found", ErrInvalidEvent, program)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) checkInstitute(ctx context.Context, institute string) error {
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindInstitute, []string{institute})
+	if err != nil {
+		return err
+	}
+	if !existing[institute] {
+		return fmt.Errorf("%w: %q", ErrInstituteNotFound, institute)
+	}
+	return nil
+}
+
+func validKind(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
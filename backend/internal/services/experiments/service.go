@@ -0,0 +1,275 @@
+// Package experiments runs the A/B experiments defined in core/experiment: it
+// assigns users (or single anonymous requests) to variants, counts the responses
+// each variant shaped and the feedback on them, and reports per variant.
+package experiments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/core/experiment"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.uber.org/zap"
+)
+
+const (
+	// DayLayout formats the days counts are kept for
+	DayLayout = "2006-01-02"
+
+	// reportDays is the period a report covers by default, ending today
+	reportDays = 30
+
+	// maxReportDays bounds the period of a report
+	maxReportDays = 366
+)
+
+// Feedback actions on a response shaped by an experiment
+const (
+	ActionSelected  = "selected" // a result was opened
+	ActionHelpful   = "helpful"
+	ActionUnhelpful = "unhelpful"
+)
+
+var (
+	// ErrUnknownExperiment is returned for feedback on an experiment that is not
+	// running, or a variant it does not have
+	ErrUnknownExperiment = errors.New("unknown experiment")
+
+	// ErrInvalidFeedback is returned for an unknown feedback action
+	ErrInvalidFeedback = errors.New("invalid feedback")
+
+	// ErrInvalidRange is returned for report days that are malformed or out of order
+	ErrInvalidRange = errors.New("invalid report range")
+)
+
+// VariantReport is how one variant did over a report's period
+type VariantReport struct {
+	Variant string `json:"variant"`
+	mongodb.ExperimentCounts
+	EngagementRate float64 `json:"engagement_rate"` // selections per exposure
+	HelpfulRate    float64 `json:"helpful_rate"`    // helpful share of helpful/unhelpful feedback
+}
+
+// ExperimentReport compares the variants of an experiment
+type ExperimentReport struct {
+	experiment.Definition
+	Running  bool            `json:"running"`
+	Variants []VariantReport `json:"variant_reports"`
+}
+
+// Report compares variants of every experiment between two days, inclusive
+type Report struct {
+	From        string             `json:"from"`
+	To          string             `json:"to"`
+	Experiments []ExperimentReport `json:"experiments"`
+}
+
+type countKey struct {
+	experiment, variant, day string
+}
+
+// Service assigns variants and counts what happens with them. Counts are kept in
+// memory and added to the store on Flush.
+type Service struct {
+	store   *mongodb.ExperimentStore
+	running []experiment.Definition
+	logger  *zap.Logger
+
+	mu      sync.Mutex
+	pending map[countKey]mongodb.ExperimentCounts
+}
+
+// NewService creates a new experiments service running the configured experiments
+func NewService(mongoClient *mongodb.Client, cfg config.ExperimentsConfig, logger *zap.Logger) *Service {
+	var running []experiment.Definition
+	for _, name := range cfg.Running {
+		def, ok := experiment.Lookup(name)
+		if !ok {
+			logger.Warn("Skipping unknown experiment", zap.String("experiment", name))
+			continue
+		}
+		running = append(running, def)
+	}
+
+	return &Service{
+		store:   mongodb.NewExperimentStore(mongoClient, logger),
+		running: running,
+		logger:  logger,
+		pending: make(map[countKey]mongodb.ExperimentCounts),
+	}
+}
+
+// Running returns the experiments being run
+func (s *Service) Running() []experiment.Definition {
+	return s.running
+}
+
+// Assign returns the variant of every running experiment for a unit: a user ID,
+// or a request ID for anonymous callers
+func (s *Service) Assign(unit string) map[string]string {
+	variants := make(map[string]string, len(s.running))
+	for _, def := range s.running {
+		variants[def.Name] = def.Assign(unit)
+	}
+	return variants
+}
+
+// Expose counts a response shaped by a variant
+func (s *Service) Expose(name, variant string) {
+	s.add(name, variant, mongodb.ExperimentCounts{Exposures: 1})
+}
+
+// RecordFeedback counts feedback on a response shaped by a variant
+func (s *Service) RecordFeedback(name, variant, action string) error {
+	def, ok := s.runningExperiment(name)
+	if !ok || !def.HasVariant(variant) {
+		return fmt.Errorf("%w: %q has no running variant %q", ErrUnknownExperiment, name, variant)
+	}
+
+	var counts mongodb.ExperimentCounts
+	switch action {
+	case ActionSelected:
+		counts.Selections = 1
+	case ActionHelpful:
+		counts.Helpful = 1
+	case ActionUnhelpful:
+		counts.Unhelpful = 1
+	default:
+		return fmt.Errorf("%w: action must be %s, %s or %s", ErrInvalidFeedback, ActionSelected, ActionHelpful, ActionUnhelpful)
+	}
+
+	s.add(name, variant, counts)
+	return nil
+}
+
+// Flush adds the counts kept since the last flush to the store. Counts that fail
+// to be stored are kept for the next flush.
+func (s *Service) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[countKey]mongodb.ExperimentCounts)
+	s.mu.Unlock()
+
+	var errs []error
+	for key, counts := range pending {
+		if err := s.store.AddCounts(ctx, key.experiment, key.variant, key.day, counts); err != nil {
+			s.mu.Lock()
+			s.pending[key] = s.pending[key].Add(counts)
+			s.mu.Unlock()
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		s.logger.Warn("Failed to store experiment counts",
+			zap.Int("failed", len(errs)),
+			zap.Int("total", len(pending)))
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// Report compares the variants of every experiment between two days (YYYY-MM-DD),
+// by default the last 30 days. Experiments no longer running are included while
+// they have counts in the period.
+func (s *Service) Report(ctx context.Context, from, to string) (*Report, error) {
+	s.logger.Debug("Building experiment report", zap.String("from", from), zap.String("to", to))
+
+	from, to, err := reportRange(from, to, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.store.ListStats(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]map[string]mongodb.ExperimentCounts)
+	for _, stat := range stats {
+		if totals[stat.Experiment] == nil {
+			totals[stat.Experiment] = make(map[string]mongodb.ExperimentCounts)
+		}
+		totals[stat.Experiment][stat.Variant] = totals[stat.Experiment][stat.Variant].Add(stat.ExperimentCounts)
+	}
+
+	report := &Report{From: from, To: to, Experiments: []ExperimentReport{}}
+	for _, def := range experiment.Definitions {
+		_, running := s.runningExperiment(def.Name)
+		if !running && totals[def.Name] == nil {
+			continue
+		}
+
+		experimentReport := ExperimentReport{Definition: def, Running: running, Variants: []VariantReport{}}
+		for _, variant := range def.Variants {
+			experimentReport.Variants = append(experimentReport.Variants, variantReport(variant, totals[def.Name][variant]))
+		}
+		report.Experiments = append(report.Experiments, experimentReport)
+	}
+
+	s.logger.Info("Experiment report built",
+		zap.String("from", from),
+		zap.String("to", to),
+		zap.Int("experiments", len(report.Experiments)))
+	return report, nil
+}
+
+func (s *Service) add(name, variant string, counts mongodb.ExperimentCounts) {
+	key := countKey{experiment: name, variant: variant, day: time.Now().UTC().Format(DayLayout)}
+	s.mu.Lock()
+	s.pending[key] = s.pending[key].Add(counts)
+	s.mu.Unlock()
+}
+
+func (s *Service) runningExperiment(name string) (experiment.Definition, bool) {
+	for _, def := range s.running {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return experiment.Definition{}, false
+}
+
+// reportRange validates the days of a report, filling in the defaults
+func reportRange(from, to string, now time.Time) (string, string, error) {
+	end := now
+	if to != "" {
+		parsed, err := time.Parse(DayLayout, to)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: to must be formatted as YYYY-MM-DD", ErrInvalidRange)
+		}
+		end = parsed
+	}
+
+	start := end.AddDate(0, 0, -(reportDays - 1))
+	if from != "" {
+		parsed, err := time.Parse(DayLayout, from)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: from must be formatted as YYYY-MM-DD", ErrInvalidRange)
+		}
+		start = parsed
+	}
+
+	if start.After(end) {
+		return "", "", fmt.Errorf("%w: from is after to", ErrInvalidRange)
+	}
+	if end.Sub(start) >= maxReportDays*24*time.Hour {
+		return "", "", fmt.Errorf("%w: a report covers at most %d days", ErrInvalidRange, maxReportDays)
+	}
+	return start.Format(DayLayout), end.Format(DayLayout), nil
+}
+
+func variantReport(variant string, counts mongodb.ExperimentCounts) VariantReport {
+	report := VariantReport{Variant: variant, ExperimentCounts: counts}
+	if counts.Exposures > 0 {
+		report.EngagementRate = float64(counts.Selections) / float64(counts.Exposures)
+	}
+	if rated := counts.Helpful + counts.Unhelpful; rated > 0 {
+		report.HelpfulRate = float64(counts.Helpful) / float64(rated)
+	}
+	return report
+}
@@ -0,0 +1,126 @@
+package handout
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+)
+
+// section is a titled block of lines on a handout
+type section struct {
+	Title string
+	Lines []string
+}
+
+// dateLayout is how dates are printed on handouts
+const dateLayout = "2 January 2006"
+
+// sections lays out a summary's content in print order. Sections without
+// content are left out, except the requirements, whose absence is worth saying.
+func sections(summary *Summary) []section {
+	var out []section
+
+	requirements := section{Title: "Entry requirements"}
+	for _, requirement := range summary.Requirements {
+		requirements.Lines = append(requirements.Lines, "• "+requirement)
+	}
+	if len(requirements.Lines) == 0 {
+		requirements.Lines = []string{"No specific requirements are listed. Check with the institute."}
+	}
+	out = append(out, requirements)
+
+	if cost := summary.LivingCost; cost != nil {
+		living := section{Title: "Living costs"}
+		switch {
+		case !cost.Relocating:
+			living.Lines = []string{fmt.Sprintf("The institute is in %s, so students from there can live at home.", cost.District)}
+		case cost.TotalMonthly > 0:
+			living.Lines = []string{
+				fmt.Sprintf("Moving from %s to %s costs about %s %s a month:", cost.HomeDistrict, cost.District, cost.Currency, formatAmount(cost.TotalMonthly)),
+				fmt.Sprintf("boarding %s, transport %s, food %s", formatAmount(cost.Boarding), formatAmount(cost.Transport), formatAmount(cost.Food)),
+			}
+		default:
+			living.Lines = []string{fmt.Sprintf("Studying here means moving from %s to %s; living costs there are not recorded yet.", cost.HomeDistrict, cost.District)}
+		}
+		out = append(out, living)
+	}
+
+	if len(summary.Timeline) > 0 {
+		timeline := section{Title: "Preparation timeline"}
+		if summary.TotalDuration != "" {
+			timeline.Title += " (" + summary.TotalDuration + ")"
+		}
+		for i, step := range summary.Timeline {
+			line := fmt.Sprintf("%d. %s", i+1, step.Title)
+			if step.Duration != "" {
+				line += " – " + step.Duration
+			}
+			timeline.Lines = append(timeline.Lines, line)
+		}
+		out = append(out, timeline)
+	}
+
+	if deadline := summary.NextDeadline; deadline != nil {
+		out = append(out, section{
+			Title: "Next date",
+			Lines: []string{deadline.Label + ": " + deadline.Date.Format(dateLayout)},
+		})
+	}
+
+	if len(summary.Careers) > 0 {
+		careers := section{Title: "Leads to careers such as"}
+		for _, career := range summary.Careers {
+			careers.Lines = append(careers.Lines, "• "+career)
+		}
+		out = append(out, careers)
+	}
+	return out
+}
+
+// footer is the small print at the bottom of a handout
+func footer(summary *Summary) string {
+	text := "Generated " + summary.GeneratedAt.Format(dateLayout) + ". Details change; check with the institute before applying."
+	if summary.URL != "" {
+		text = "Scan the code for the full pathway. " + text
+	}
+	return text
+}
+
+// qrCode encodes a URL as a square QR code image of the given size in pixels
+func qrCode(url string, size int) (image.Image, error) {
+	code, err := qr.Encode(url, qr.M, qr.Auto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	return barcode.Scale(code, size, size)
+}
+
+// qrPNG encodes a URL as a QR code PNG of the given size in pixels
+func qrPNG(url string, size int) ([]byte, error) {
+	code, err := qrCode(url, size)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, code); err != nil {
+		return nil, fmt.Errorf("failed to encode QR code image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// formatAmount writes a rupee amount with thousands separators
+func formatAmount(amount int) string {
+	digits := fmt.Sprint(amount)
+	var out []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, digits[i])
+	}
+	return string(out)
+}
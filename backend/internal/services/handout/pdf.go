@@ -0,0 +1,91 @@
+package handout
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// Page geometry of PDF handouts, in millimetres on A4
+const (
+	pdfMargin    = 18.0
+	pdfWidth     = 210.0
+	pdfHeight    = 297.0
+	pdfQRSize    = 38.0
+	pdfFooterTop = pdfHeight - pdfMargin - pdfQRSize
+)
+
+// RenderPDF renders a summary as a one-page A4 PDF. Sections that would run
+// into the footer are skipped rather than spilling onto a second page.
+func RenderPDF(summary *Summary) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetCreationDate(summary.GeneratedAt)
+	pdf.SetTitle(summary.Program, true)
+	pdf.AddUTF8FontFromBytes("go", "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes("go", "B", gobold.TTF)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+
+	width := pdfWidth - 2*pdfMargin
+
+	pdf.SetFont("go", "B", 20)
+	pdf.MultiCell(width, 9, summary.Program, "", "L", false)
+	if summary.Institute != "" {
+		pdf.SetFont("go", "", 13)
+		pdf.SetTextColor(90, 90, 90)
+		pdf.MultiCell(width, 7, summary.Institute, "", "L", false)
+		pdf.SetTextColor(0, 0, 0)
+	}
+	pdf.Ln(2)
+	pdf.SetDrawColor(30, 90, 160)
+	pdf.SetLineWidth(0.6)
+	pdf.Line(pdfMargin, pdf.GetY(), pdfWidth-pdfMargin, pdf.GetY())
+	pdf.Ln(4)
+
+	for _, s := range sections(summary) {
+		pdf.SetFont("go", "", 11)
+		lines := 0
+		for _, line := range s.Lines {
+			lines += len(pdf.SplitText(line, width))
+		}
+		if pdf.GetY()+7+float64(lines)*5.5 > pdfFooterTop-4 {
+			continue
+		}
+
+		pdf.SetFont("go", "B", 13)
+		pdf.SetTextColor(30, 90, 160)
+		pdf.MultiCell(width, 7, s.Title, "", "L", false)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont("go", "", 11)
+		for _, line := range s.Lines {
+			pdf.MultiCell(width, 5.5, line, "", "L", false)
+		}
+		pdf.Ln(3)
+	}
+
+	footerWidth := width
+	if summary.URL != "" {
+		code, err := qrPNG(summary.URL, 512)
+		if err != nil {
+			return nil, err
+		}
+		options := fpdf.ImageOptions{ImageType: "PNG"}
+		pdf.RegisterImageOptionsReader("qr", options, bytes.NewReader(code))
+		pdf.ImageOptions("qr", pdfWidth-pdfMargin-pdfQRSize, pdfFooterTop, pdfQRSize, pdfQRSize, false, options, 0, summary.URL)
+		footerWidth -= pdfQRSize + 6
+	}
+	pdf.SetFont("go", "", 9)
+	pdf.SetTextColor(90, 90, 90)
+	pdf.SetXY(pdfMargin, pdfFooterTop+pdfQRSize/2-4.5)
+	pdf.MultiCell(footerWidth, 4.5, footer(summary), "", "L", false)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,203 @@
+package handout
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// pngDPI is the resolution of PNG handouts, an A4 page at 150 dots per inch
+const pngDPI = 150
+
+// pngScale converts the PDF page geometry from millimetres to pixels
+const pngScale = pngDPI / 25.4
+
+var (
+	pngInk    = color.Black
+	pngMuted  = color.RGBA{90, 90, 90, 255}
+	pngAccent = color.RGBA{30, 90, 160, 255}
+)
+
+// pngFonts are the parsed regular and bold Go fonts
+var pngFonts = sync.OnceValues(func() ([2]*opentype.Font, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return [2]*opentype.Font{}, err
+	}
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return [2]*opentype.Font{}, err
+	}
+	return [2]*opentype.Font{regular, bold}, nil
+})
+
+// pngCanvas draws wrapped text down an image
+type pngCanvas struct {
+	img   *image.RGBA
+	fonts [2]*opentype.Font
+	faces map[[2]float64]font.Face
+	y     int
+}
+
+// RenderPNG renders a summary as an A4 page image with the same layout as
+// RenderPDF
+func RenderPNG(summary *Summary) ([]byte, error) {
+	fonts, err := pngFonts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fonts: %w", err)
+	}
+
+	canvas := &pngCanvas{
+		img:   image.NewRGBA(image.Rect(0, 0, px(pdfWidth), px(pdfHeight))),
+		fonts: fonts,
+		faces: map[[2]float64]font.Face{},
+		y:     px(pdfMargin),
+	}
+	defer canvas.close()
+	draw.Draw(canvas.img, canvas.img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	width := px(pdfWidth - 2*pdfMargin)
+	left := px(pdfMargin)
+	footerTop := px(pdfFooterTop)
+
+	if err := canvas.text(summary.Program, true, 20, 9, left, width, pngInk); err != nil {
+		return nil, err
+	}
+	if summary.Institute != "" {
+		if err := canvas.text(summary.Institute, false, 13, 7, left, width, pngMuted); err != nil {
+			return nil, err
+		}
+	}
+	canvas.y += px(2)
+	draw.Draw(canvas.img, image.Rect(left, canvas.y, left+width, canvas.y+px(0.6)), image.NewUniform(pngAccent), image.Point{}, draw.Src)
+	canvas.y += px(4)
+
+	for _, s := range sections(summary) {
+		face, err := canvas.face(false, 11)
+		if err != nil {
+			return nil, err
+		}
+		lines := 0
+		for _, line := range s.Lines {
+			lines += len(wrap(face, line, width))
+		}
+		if canvas.y+px(7)+lines*px(5.5) > footerTop-px(4) {
+			continue
+		}
+
+		if err := canvas.text(s.Title, true, 13, 7, left, width, pngAccent); err != nil {
+			return nil, err
+		}
+		for _, line := range s.Lines {
+			if err := canvas.text(line, false, 11, 5.5, left, width, pngInk); err != nil {
+				return nil, err
+			}
+		}
+		canvas.y += px(3)
+	}
+
+	footerWidth := width
+	if summary.URL != "" {
+		size := px(pdfQRSize)
+		code, err := qrCode(summary.URL, size)
+		if err != nil {
+			return nil, err
+		}
+		at := image.Pt(px(pdfWidth-pdfMargin-pdfQRSize), footerTop)
+		draw.Draw(canvas.img, image.Rectangle{Min: at, Max: at.Add(image.Pt(size, size))}, code, image.Point{}, draw.Src)
+		footerWidth -= px(pdfQRSize + 6)
+	}
+	canvas.y = footerTop + px(pdfQRSize/2-4.5)
+	if err := canvas.text(footer(summary), false, 9, 4.5, left, footerWidth, pngMuted); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas.img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// face returns the regular or bold face at a size in points
+func (c *pngCanvas) face(bold bool, size float64) (font.Face, error) {
+	style := 0.0
+	if bold {
+		style = 1
+	}
+	key := [2]float64{style, size}
+	if face, ok := c.faces[key]; ok {
+		return face, nil
+	}
+	face, err := opentype.NewFace(c.fonts[int(style)], &opentype.FaceOptions{
+		Size:    size,
+		DPI:     pngDPI,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	c.faces[key] = face
+	return face, nil
+}
+
+// text draws text wrapped to width at the current position, lineHeight
+// millimetres per line, and moves down past it
+func (c *pngCanvas) text(text string, bold bool, size, lineHeight float64, left, width int, ink color.Color) error {
+	face, err := c.face(bold, size)
+	if err != nil {
+		return err
+	}
+	drawer := &font.Drawer{Dst: c.img, Src: image.NewUniform(ink), Face: face}
+	ascent := face.Metrics().Ascent.Ceil()
+	for _, line := range wrap(face, text, width) {
+		drawer.Dot = fixed.P(left, c.y+ascent)
+		drawer.DrawString(line)
+		c.y += px(lineHeight)
+	}
+	return nil
+}
+
+func (c *pngCanvas) close() {
+	for _, face := range c.faces {
+		face.Close()
+	}
+}
+
+// wrap breaks text into lines no wider than width pixels. Words wider than a
+// line are left whole.
+func wrap(face font.Face, text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && font.MeasureString(face, candidate).Ceil() > width {
+			lines = append(lines, line)
+			candidate = word
+		}
+		line = candidate
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// px converts millimetres to pixels
+func px(mm float64) int {
+	return int(mm*pngScale + 0.5)
+}
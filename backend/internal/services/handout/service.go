@@ -0,0 +1,242 @@
+// Package handout composes one-page program summaries for print, to hand out at
+// career guidance events, and renders them as PDF or PNG.
+package handout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/events"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"github.com/mayura-andrew/fastfinder/internal/services/seo"
+	"go.uber.org/zap"
+)
+
+// Formats a handout can be rendered in
+const (
+	FormatPDF = "pdf"
+	FormatPNG = "png"
+)
+
+const (
+	// maxRequirements and maxCareers bound the lists printed, so the summary
+	// keeps to one page
+	maxRequirements = 8
+	maxCareers      = 6
+
+	// maxTimelineSteps is how many roadmap steps the timeline shows
+	maxTimelineSteps = 6
+)
+
+var (
+	// ErrProgramNotFound is returned for handouts of a program not in the graph
+	ErrProgramNotFound = errors.New("program not found")
+
+	// ErrUnknownFormat is returned for formats other than FormatPDF and FormatPNG
+	ErrUnknownFormat = errors.New("unknown handout format")
+)
+
+// TimelineStep is a stage of preparing for a program, from its learning roadmap
+type TimelineStep struct {
+	Title    string `json:"title"`
+	Duration string `json:"duration"`
+}
+
+// Deadline is the next dated step of applying to a program
+type Deadline struct {
+	Label string    `json:"label"`
+	Date  time.Time `json:"date"`
+}
+
+// Summary is what a handout shows of a program
+type Summary struct {
+	Program       string                    `json:"program"`
+	Institute     string                    `json:"institute"`
+	Requirements  []string                  `json:"requirements"`
+	Careers       []string                  `json:"careers"`
+	LivingCost    *neo4j.LivingCostEstimate `json:"living_cost,omitempty"`
+	TotalDuration string                    `json:"total_duration,omitempty"`
+	Timeline      []TimelineStep            `json:"timeline"`
+	NextDeadline  *Deadline                 `json:"next_deadline,omitempty"`
+	URL           string                    `json:"url,omitempty"` // the program's page, printed as a QR code
+	GeneratedAt   time.Time                 `json:"generated_at"`
+}
+
+// Graph is the part of the education graph handouts check programs against
+type Graph interface {
+	ExistingNames(ctx context.Context, kind string, names []string) (map[string]bool, error)
+}
+
+// Service composes and renders handouts
+type Service struct {
+	graph   Graph
+	pathway *pathway.Service
+	seo     *seo.Service
+	events  *events.Service
+	logger  *zap.Logger
+}
+
+// NewService creates a new handout service
+func NewService(graph Graph, pathwayService *pathway.Service, seoService *seo.Service, logger *zap.Logger) *Service {
+	return &Service{
+		graph:   graph,
+		pathway: pathwayService,
+		seo:     seoService,
+		logger:  logger,
+	}
+}
+
+// UseEvents sets the service whose aptitude tests and open days count towards a
+// program's next deadline. Call it before the service handles requests.
+func (s *Service) UseEvents(service *events.Service) {
+	s.events = service
+}
+
+// Compose gathers a program's summary. Living costs are included for students
+// from homeDistrict when it is given; the timeline comes from the program's
+// cached roadmap, as printing never waits on the LLM.
+func (s *Service) Compose(ctx context.Context, programName, homeDistrict string) (*Summary, error) {
+	s.logger.Debug("Composing handout", zap.String("program", programName))
+
+	existing, err := s.graph.ExistingNames(ctx, neo4j.KindProgram, []string{programName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check program: %w", err)
+	}
+	if !existing[programName] {
+		return nil, fmt.Errorf("%w: %q", ErrProgramNotFound, programName)
+	}
+
+	details, err := s.pathway.GetProgramDetails(ctx, programName)
+	if err != nil {
+		return nil, err
+	}
+	if homeDistrict != "" {
+		if err := s.pathway.AttachLivingCost(ctx, details, homeDistrict); err != nil {
+			return nil, err
+		}
+	}
+
+	summary := &Summary{
+		Program:      details.Name,
+		Institute:    details.Institute,
+		Requirements: []string{},
+		Careers:      []string{},
+		LivingCost:   details.LivingCost,
+		Timeline:     []TimelineStep{},
+		GeneratedAt:  time.Now().UTC(),
+	}
+	if s.seo.Enabled() {
+		summary.URL = s.seo.ProgramURL(details.Name)
+	}
+	for _, requirement := range details.Requirements {
+		summary.Requirements = append(summary.Requirements, requirement.Name)
+	}
+	for _, career := range details.CareerPaths {
+		summary.Careers = append(summary.Careers, career.Title)
+	}
+	summary.Requirements = truncate(summary.Requirements, maxRequirements)
+	summary.Careers = truncate(summary.Careers, maxCareers)
+
+	if roadmap, err := s.pathway.GetCachedLearningRoadmap(ctx, details.Name); err == nil && roadmap != nil {
+		summary.TotalDuration = roadmap.TotalDuration
+		for _, step := range roadmap.Steps {
+			if len(summary.Timeline) == maxTimelineSteps {
+				break
+			}
+			summary.Timeline = append(summary.Timeline, TimelineStep{Title: step.Title, Duration: step.Duration})
+		}
+	}
+
+	summary.NextDeadline = s.nextDeadline(ctx, details)
+
+	s.logger.Info("Handout composed",
+		zap.String("program", details.Name),
+		zap.Int("timeline_steps", len(summary.Timeline)),
+		zap.Bool("deadline", summary.NextDeadline != nil))
+	return summary, nil
+}
+
+// Render composes a program's summary and renders it in the given format,
+// returning the document and its content type
+func (s *Service) Render(ctx context.Context, programName, homeDistrict, format string) ([]byte, string, error) {
+	var render func(*Summary) ([]byte, error)
+	var contentType string
+	switch format {
+	case FormatPDF:
+		render, contentType = RenderPDF, "application/pdf"
+	case FormatPNG:
+		render, contentType = RenderPNG, "image/png"
+	default:
+		return nil, "", fmt.Errorf("%w: %q, use %s or %s", ErrUnknownFormat, format, FormatPDF, FormatPNG)
+	}
+
+	summary, err := s.Compose(ctx, programName, homeDistrict)
+	if err != nil {
+		return nil, "", err
+	}
+	document, err := render(summary)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to render %s handout: %w", format, err)
+	}
+	return document, contentType, nil
+}
+
+// nextDeadline is the soonest upcoming date of the program's aptitude tests and
+// of its institute's open days and tests that admit to it
+func (s *Service) nextDeadline(ctx context.Context, details *neo4j.ProgramDetails) *Deadline {
+	now := time.Now()
+	var next *Deadline
+	consider := func(label string, date time.Time) {
+		if date.Before(now) || (next != nil && !date.Before(next.Date)) {
+			return
+		}
+		next = &Deadline{Label: label, Date: date}
+	}
+
+	for _, test := range details.AptitudeTests {
+		for _, date := range test.Dates {
+			consider(test.Name+": "+date.Label, date.Date)
+		}
+	}
+
+	if s.events != nil && details.Institute != "" {
+		upcoming, err := s.events.ForInstitute(ctx, details.Institute, false)
+		if err != nil {
+			s.logger.Warn("Failed to fetch institute events for handout",
+				zap.String("institute", details.Institute),
+				zap.Error(err))
+		}
+		for _, event := range upcoming {
+			if event.Kind == mongodb.EventAptitudeTest && len(event.Programs) > 0 && !contains(event.Programs, details.Name) {
+				continue
+			}
+			if event.Kind == mongodb.EventCareerFair {
+				continue
+			}
+			consider(event.Title, event.StartsAt)
+		}
+	}
+	return next
+}
+
+// truncate keeps the first max items, noting how many more there are
+func truncate(items []string, max int) []string {
+	if len(items) <= max {
+		return items
+	}
+	return append(items[:max-1:max-1], fmt.Sprintf("and %d more", len(items)-max+1))
+}
+
+func contains(items []string, item string) bool {
+	for _, candidate := range items {
+		if strings.EqualFold(candidate, item) {
+			return true
+		}
+	}
+	return false
+}
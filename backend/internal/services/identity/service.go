@@ -0,0 +1,130 @@
+// Package identity verifies who a caller is. Students sign in through the
+// frontend, whose server issues them a user token signed with the secret it
+// shares with this one; the token is sent as a bearer token or a cookie and
+// verified on every request. Nothing the client sends unsigned is trusted, so
+// one student cannot read or change another's plans, progress or consent.
+package identity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"go.uber.org/zap"
+)
+
+// CookieName is the cookie a browser session carries its user token in
+const CookieName = "pathway_user"
+
+const (
+	// maxTokenLength rejects tokens too long to have been issued with the
+	// shared secret before their signature is checked
+	maxTokenLength = 1 << 10
+
+	// maxUserIDLength bounds the user IDs tokens may carry
+	maxUserIDLength = 128
+)
+
+var (
+	// ErrDisabled is returned when no secret is configured, so no token can
+	// be trusted
+	ErrDisabled = errors.New("user tokens are disabled")
+
+	// ErrInvalidToken is returned for tokens that are malformed or not signed
+	// with the shared secret
+	ErrInvalidToken = errors.New("invalid user token")
+
+	// ErrTokenExpired is returned for tokens past their expiry
+	ErrTokenExpired = errors.New("user token has expired")
+)
+
+// claims are what a user token signs
+type claims struct {
+	UserID    string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Service issues and verifies user tokens
+type Service struct {
+	secret []byte
+	logger *zap.Logger
+}
+
+// NewService creates a new identity service. Without a configured secret no
+// token verifies, so every caller is anonymous and features needing a user
+// answer 401.
+func NewService(cfg config.IdentityConfig, logger *zap.Logger) *Service {
+	if cfg.Secret == "" {
+		logger.Warn("USER_TOKEN_SECRET is not set, every caller is treated as anonymous")
+	}
+	return &Service{
+		secret: []byte(cfg.Secret),
+		logger: logger,
+	}
+}
+
+// Issue signs a token identifying userID until ttl has passed. Tokens are
+// normally issued by the frontend's server at sign-in; this is the reference
+// implementation of the format it must follow.
+func (s *Service) Issue(_ context.Context, userID string, ttl time.Duration) (string, error) {
+	if len(s.secret) == 0 {
+		return "", ErrDisabled
+	}
+	userID = strings.TrimSpace(userID)
+	if userID == "" || len(userID) > maxUserIDLength {
+		return "", ErrInvalidToken
+	}
+	payload, err := json.Marshal(claims{UserID: userID, ExpiresAt: time.Now().Add(ttl).Unix()})
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), nil
+}
+
+// Verify returns the user ID of a token signed with the shared secret that has
+// not expired
+func (s *Service) Verify(_ context.Context, token string) (string, error) {
+	if len(s.secret) == 0 {
+		return "", ErrDisabled
+	}
+	if len(token) > maxTokenLength {
+		return "", ErrInvalidToken
+	}
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(encoded)) {
+		return "", ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	var signed claims
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return "", ErrInvalidToken
+	}
+	userID := strings.TrimSpace(signed.UserID)
+	if userID == "" || len(userID) > maxUserIDLength {
+		return "", ErrInvalidToken
+	}
+	if time.Now().Unix() >= signed.ExpiresAt {
+		return "", ErrTokenExpired
+	}
+	return userID, nil
+}
+
+func (s *Service) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
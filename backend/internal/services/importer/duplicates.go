@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/mayura-andrew/fastfinder/pkg/fuzzy"
+)
+
+// DuplicateScore is the similarity at or above which a name an import would
+// create is flagged as a probable duplicate of another
+const DuplicateScore = 0.85
+
+// Duplicate is a name an import would create that closely matches an existing
+// node, or a name created by an earlier row of the same import
+type Duplicate struct {
+	Kind    string  `json:"kind"`
+	Name    string  `json:"name"`
+	Matches string  `json:"matches"`
+	Score   float64 `json:"score"`
+}
+
+// duplicateFinder compares new names with the existing names of their kind and
+// with the names created earlier in the batch
+type duplicateFinder struct {
+	candidates map[string][]string
+	checked    map[string]*Duplicate
+}
+
+// newDuplicateFinder loads the existing names of every kind the batch adds to
+func (s *Service) newDuplicateFinder(ctx context.Context, names map[string][]string, existing map[string]map[string]bool) (*duplicateFinder, error) {
+	f := &duplicateFinder{
+		candidates: map[string][]string{},
+		checked:    map[string]*Duplicate{},
+	}
+
+	for kind, values := range names {
+		for _, v := range values {
+			if existing[kind][v] {
+				continue
+			}
+			all, err := s.neo4jClient.ListNames(ctx, kind)
+			if err != nil {
+				return nil, err
+			}
+			f.candidates[kind] = all
+			break
+		}
+	}
+	return f, nil
+}
+
+// check returns the closest match of a new name when it is probably a duplicate.
+// The name then becomes a candidate for the rest of the batch; checking it again
+// returns the same result.
+func (f *duplicateFinder) check(kind, name string) *Duplicate {
+	key := kind + "\x00" + name
+	if dup, ok := f.checked[key]; ok {
+		return dup
+	}
+
+	var dup *Duplicate
+	if best := fuzzy.Best(name, f.candidates[kind]); best.Score >= DuplicateScore {
+		dup = &Duplicate{
+			Kind:    kind,
+			Name:    name,
+			Matches: best.Name,
+			Score:   best.Score,
+		}
+	}
+	f.checked[key] = dup
+	f.candidates[kind] = append(f.candidates[kind], name)
+	return dup
+}
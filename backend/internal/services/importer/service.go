@@ -0,0 +1,148 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxImportRows caps the number of rows accepted in a single import
+	MaxImportRows = 2000
+
+	// SourceBulkImport is the provenance source of programs imported through the admin API
+	SourceBulkImport = "bulk_import"
+)
+
+var (
+	// ErrEmptyImport is returned when an import contains no rows
+	ErrEmptyImport = errors.New("import contains no rows")
+
+	// ErrTooManyRows is returned when an import exceeds MaxImportRows
+	ErrTooManyRows = fmt.Errorf("import exceeds %d rows", MaxImportRows)
+)
+
+// Row is one program row of an import, as it appears in a spreadsheet. List
+// columns (requirements, prerequisites, careers) are separated by semicolons.
+type Row struct {
+	Institute     string `json:"institute"`
+	Faculty       string `json:"faculty"`
+	Department    string `json:"department"`
+	Program       string `json:"program"`
+	Requirements  string `json:"requirements"`
+	Prerequisites string `json:"prerequisites"`
+	Careers       string `json:"careers"`
+}
+
+// Service validates and applies bulk program imports
+type Service struct {
+	neo4jClient *neo4j.Client
+	changes     *changelog.Service
+	logger      *zap.Logger
+}
+
+// NewService creates a new import service. Applied imports are reported to the
+// change log unless changelogService is nil, as when seeding an empty graph.
+func NewService(neo4jClient *neo4j.Client, changelogService *changelog.Service, logger *zap.Logger) *Service {
+	return &Service{
+		neo4jClient: neo4jClient,
+		changes:     changelogService,
+		logger:      logger,
+	}
+}
+
+// ImportPrograms validates rows and, unless dryRun is set, writes them to the
+// graph stamped with the given provenance. Nothing is written when any row has
+// errors; probable duplicates of existing names count as errors unless
+// allowDuplicates is set.
+func (s *Service) ImportPrograms(ctx context.Context, rows []Row, dryRun, allowDuplicates bool, provenance neo4j.Provenance) (*Report, error) {
+	s.logger.Debug("Importing programs",
+		zap.Int("rows", len(rows)),
+		zap.Bool("dry_run", dryRun),
+		zap.Bool("allow_duplicates", allowDuplicates))
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+	if len(rows) > MaxImportRows {
+		return nil, ErrTooManyRows
+	}
+
+	report, programs, err := s.validate(ctx, rows, allowDuplicates, nil)
+	if err != nil {
+		s.logger.Error("Failed to validate import", zap.Error(err))
+		return nil, fmt.Errorf("failed to validate import: %w", err)
+	}
+	report.DryRun = dryRun
+
+	if dryRun || report.Summary.Invalid > 0 {
+		s.logger.Info("Import validated without applying",
+			zap.Bool("dry_run", dryRun),
+			zap.Int("rows", report.Summary.Total),
+			zap.Int("invalid", report.Summary.Invalid),
+			zap.Int("probable_duplicates", report.Summary.Duplicates))
+		return report, nil
+	}
+
+	if err := s.neo4jClient.ImportPrograms(ctx, programs, provenance); err != nil {
+		s.logger.Error("Failed to apply import", zap.Error(err))
+		return nil, fmt.Errorf("failed to apply import: %w", err)
+	}
+	report.Applied = true
+
+	if s.changes != nil {
+		if err := s.changes.Emit(ctx, changeLog(report, provenance)); err != nil {
+			s.logger.Warn("Failed to record import changes", zap.Error(err))
+		}
+	}
+
+	s.logger.Info("Import applied",
+		zap.Int("rows", report.Summary.Total),
+		zap.Int("created", report.Summary.Created),
+		zap.Int("updated", report.Summary.Updated))
+
+	return report, nil
+}
+
+// ValidatePrograms validates rows as a dry run of ImportPrograms would, except
+// that the institutes named in pending count as existing, for imports that
+// create their institutes once the rows are known to be valid
+func (s *Service) ValidatePrograms(ctx context.Context, rows []Row, allowDuplicates bool, pending []string) (*Report, error) {
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+	if len(rows) > MaxImportRows {
+		return nil, ErrTooManyRows
+	}
+
+	report, _, err := s.validate(ctx, rows, allowDuplicates, pending)
+	if err != nil {
+		s.logger.Error("Failed to validate import", zap.Error(err))
+		return nil, fmt.Errorf("failed to validate import: %w", err)
+	}
+	report.DryRun = true
+	return report, nil
+}
+
+// changeLog lists the programs an applied import created or updated
+func changeLog(report *Report, provenance neo4j.Provenance) []mongodb.GraphChange {
+	var changes []mongodb.GraphChange
+	for _, row := range report.Rows {
+		if row.Action != ActionCreate && row.Action != ActionUpdate {
+			continue
+		}
+		changes = append(changes, mongodb.GraphChange{
+			Kind:      neo4j.KindProgram,
+			Name:      row.Program,
+			Action:    row.Action,
+			Source:    provenance.Source,
+			SourceURL: provenance.SourceURL,
+		})
+	}
+	return changes
+}
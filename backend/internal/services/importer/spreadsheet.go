@@ -0,0 +1,348 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Spreadsheet formats accepted by ParseSpreadsheet
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// ErrInvalidSpreadsheet is returned when an uploaded spreadsheet cannot be read
+var ErrInvalidSpreadsheet = errors.New("invalid spreadsheet")
+
+// headerFields maps normalized spreadsheet headers to the Row field they fill
+var headerFields = map[string]string{
+	"institute":          "institute",
+	"institution":        "institute",
+	"university":         "institute",
+	"faculty":            "faculty",
+	"department":         "department",
+	"program":            "program",
+	"programme":          "program",
+	"program_name":       "program",
+	"programme_name":     "program",
+	"degree":             "program",
+	"requirements":       "requirements",
+	"entry_requirements": "requirements",
+	"qualifications":     "requirements",
+	"prerequisites":      "prerequisites",
+	"careers":            "careers",
+	"career_paths":       "careers",
+}
+
+// ParseSpreadsheet reads import rows from a CSV file or the first sheet of an
+// XLSX workbook. The first non-empty row holds the headers, matched case
+// insensitively (e.g. "Programme Name" for program); unknown columns are
+// ignored. List cells are separated by semicolons or line breaks. Rows without
+// any value are skipped, so it also returns the spreadsheet row number of each
+// row for reporting.
+func ParseSpreadsheet(format string, r io.Reader) ([]Row, []int, error) {
+	var (
+		records [][]string
+		lines   []int
+		err     error
+	)
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		records, lines, err = readCSV(r)
+	case FormatXLSX:
+		records, lines, err = readXLSX(r)
+	default:
+		return nil, nil, fmt.Errorf("%w: format must be %q or %q", ErrInvalidSpreadsheet, FormatCSV, FormatXLSX)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return rowsFromRecords(records, lines)
+}
+
+// rowsFromRecords maps records to rows by the header record
+func rowsFromRecords(records [][]string, lines []int) ([]Row, []int, error) {
+	header := -1
+	for i, record := range records {
+		if !blankRecord(record) {
+			header = i
+			break
+		}
+	}
+	if header < 0 {
+		return nil, nil, ErrEmptyImport
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[header] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+		if field, ok := headerFields[name]; ok {
+			if _, taken := columns[field]; !taken {
+				columns[field] = i
+			}
+		}
+	}
+	if _, ok := columns["program"]; !ok {
+		return nil, nil, fmt.Errorf("%w: no program column in the header row", ErrInvalidSpreadsheet)
+	}
+
+	var rows []Row
+	var rowLines []int
+	for i, record := range records[header+1:] {
+		if blankRecord(record) {
+			continue
+		}
+		cell := func(field string) string {
+			if j, ok := columns[field]; ok && j < len(record) {
+				return strings.TrimSpace(record[j])
+			}
+			return ""
+		}
+		list := func(field string) string {
+			return strings.NewReplacer("\r\n", ";", "\n", ";").Replace(cell(field))
+		}
+
+		rows = append(rows, Row{
+			Institute:     cell("institute"),
+			Faculty:       cell("faculty"),
+			Department:    cell("department"),
+			Program:       cell("program"),
+			Requirements:  list("requirements"),
+			Prerequisites: list("prerequisites"),
+			Careers:       list("careers"),
+		})
+		rowLines = append(rowLines, lines[header+1+i])
+	}
+
+	if len(rows) == 0 {
+		return nil, nil, ErrEmptyImport
+	}
+	return rows, rowLines, nil
+}
+
+func blankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// readCSV reads every record with the line it starts on. A byte order mark, as
+// written by Excel, is dropped.
+func readCSV(r io.Reader) ([][]string, []int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var records [][]string
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidSpreadsheet, err)
+		}
+		if len(records) == 0 && len(record) > 0 {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	return records, lines, nil
+}
+
+// Parts of an XLSX workbook needed to read the cell values of its first sheet
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+
+	// xlsxText is plain text in <t> or rich text split into runs
+	xlsxText struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+
+	xlsxWorksheet struct {
+		Rows []struct {
+			Number int `xml:"r,attr"`
+			Cells  []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+// readXLSX reads the cell values of the first sheet of a workbook, with the
+// row number of each row. Formulas are read as their last calculated value.
+func readXLSX(r io.Reader) ([][]string, []int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read spreadsheet: %w", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: not an XLSX workbook", ErrInvalidSpreadsheet)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var shared xlsxSharedStrings
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXML(file, &shared); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	file, ok := files[sheetPath]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: workbook has no sheet %s", ErrInvalidSpreadsheet, sheetPath)
+	}
+	var sheet xlsxWorksheet
+	if err := decodeXML(file, &sheet); err != nil {
+		return nil, nil, err
+	}
+
+	var records [][]string
+	var lines []int
+	for i, row := range sheet.Rows {
+		line := row.Number
+		if line == 0 {
+			line = i + 1
+		}
+
+		var record []string
+		for j, cell := range row.Cells {
+			column := columnIndex(cell.Ref)
+			if column < 0 {
+				column = j
+			}
+			for len(record) <= column {
+				record = append(record, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				var index int
+				if _, err := fmt.Sscan(cell.Value, &index); err != nil || index < 0 || index >= len(shared.Items) {
+					return nil, nil, fmt.Errorf("%w: cell %s refers to a missing shared string", ErrInvalidSpreadsheet, cell.Ref)
+				}
+				record[column] = shared.Items[index].String()
+			case "inlineStr":
+				record[column] = cell.Inline.String()
+			default:
+				record[column] = cell.Value
+			}
+		}
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	return records, lines, nil
+}
+
+// firstSheetPath finds the part holding the workbook's first sheet
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("%w: not an XLSX workbook", ErrInvalidSpreadsheet)
+	}
+	var workbook xlsxWorkbook
+	if err := decodeXML(workbookFile, &workbook); err != nil {
+		return "", err
+	}
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if len(workbook.Sheets) == 0 || !ok {
+		return fallback, nil
+	}
+	var rels xlsxRelationships
+	if err := decodeXML(relsFile, &rels); err != nil {
+		return "", err
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].ID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+func decodeXML(file *zip.File, v interface{}) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSpreadsheet, err)
+	}
+	defer reader.Close()
+	if err := xml.NewDecoder(reader).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidSpreadsheet, file.Name, err)
+	}
+	return nil
+}
+
+// columnIndex converts the letters of a cell reference such as "C7" to a
+// zero-based column index, or -1 without letters
+func columnIndex(ref string) int {
+	index := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+	}
+	return index - 1
+}
@@ -0,0 +1,333 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+)
+
+const (
+	// Longest name accepted for any entity
+	maxNameLength = 200
+
+	// Row actions
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionSkip   = "skip"
+
+	// Row statuses
+	StatusOK      = "ok"
+	StatusWarning = "warning"
+	StatusError   = "error"
+)
+
+// Report is the per-row outcome of validating (and possibly applying) an import
+type Report struct {
+	DryRun  bool        `json:"dry_run"`
+	Applied bool        `json:"applied"`
+	Summary Summary     `json:"summary"`
+	Rows    []RowReport `json:"rows"`
+}
+
+// Summary counts rows by outcome
+type Summary struct {
+	Total    int `json:"total"`
+	Valid    int `json:"valid"`
+	Invalid  int `json:"invalid"`
+	Warnings int `json:"with_warnings"`
+	// Duplicates counts names flagged as probable duplicates of another
+	Duplicates int `json:"probable_duplicates"`
+	Created    int `json:"created"`
+	Updated    int `json:"updated"`
+}
+
+// RowReport describes what happens (or would happen) to a single row
+type RowReport struct {
+	Row        int         `json:"row"`
+	Program    string      `json:"program"`
+	Action     string      `json:"action"`
+	Status     string      `json:"status"`
+	Errors     []string    `json:"errors,omitempty"`
+	Warnings   []string    `json:"warnings,omitempty"`
+	Duplicates []Duplicate `json:"duplicates,omitempty"`
+}
+
+// Renumber replaces the row numbers of the report, which count the imported
+// rows from one, with the given numbers, such as the spreadsheet rows the
+// import was read from
+func (r *Report) Renumber(numbers []int) {
+	for i := range r.Rows {
+		if i < len(numbers) {
+			r.Rows[i].Row = numbers[i]
+		}
+	}
+}
+
+func (r *RowReport) errorf(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+func (r *RowReport) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// validate checks every row against the graph and the rest of the batch. Names
+// that would be created but closely match another are errors unless
+// allowDuplicates is set, in which case they are warnings. Institutes named in
+// pending count as existing. It returns the report and, for valid rows, the
+// programs ready to be written.
+func (s *Service) validate(ctx context.Context, rows []Row, allowDuplicates bool, pending []string) (*Report, []neo4j.ImportProgram, error) {
+	report := &Report{Rows: make([]RowReport, len(rows))}
+	programs := make([]neo4j.ImportProgram, len(rows))
+
+	// Parse rows and collect every name they refer to
+	names := map[string][]string{}
+	seenName := map[string]map[string]bool{}
+	collect := func(kind string, values ...string) {
+		if seenName[kind] == nil {
+			seenName[kind] = map[string]bool{}
+		}
+		for _, v := range values {
+			if v != "" && !seenName[kind][v] {
+				seenName[kind][v] = true
+				names[kind] = append(names[kind], v)
+			}
+		}
+	}
+
+	for i, row := range rows {
+		rr := &report.Rows[i]
+		rr.Row = i + 1
+		programs[i] = parseRow(row, rr)
+		rr.Program = programs[i].Program
+
+		p := programs[i]
+		collect(neo4j.KindInstitute, p.Institute)
+		collect(neo4j.KindFaculty, p.Faculty)
+		collect(neo4j.KindDepartment, p.Department)
+		collect(neo4j.KindProgram, p.Program)
+		collect(neo4j.KindProgram, p.Prerequisites...)
+		collect(neo4j.KindQualification, p.Requirements...)
+		collect(neo4j.KindCareer, p.Careers...)
+	}
+
+	existing := map[string]map[string]bool{}
+	for kind, values := range names {
+		found, err := s.neo4jClient.ExistingNames(ctx, kind, values)
+		if err != nil {
+			return nil, nil, err
+		}
+		existing[kind] = found
+	}
+	for _, institute := range pending {
+		if existing[neo4j.KindInstitute] == nil {
+			existing[neo4j.KindInstitute] = map[string]bool{}
+		}
+		existing[neo4j.KindInstitute][institute] = true
+	}
+
+	dupes, err := s.newDuplicateFinder(ctx, names, existing)
+	if err != nil {
+		return nil, nil, err
+	}
+	flagDuplicate := func(rr *RowReport, kind, name string) {
+		dup := dupes.check(kind, name)
+		if dup == nil {
+			return
+		}
+		rr.Duplicates = append(rr.Duplicates, *dup)
+		report.Summary.Duplicates++
+		if allowDuplicates {
+			rr.warnf("%s %q looks like a duplicate of %q", kind, name, dup.Matches)
+		} else {
+			rr.errorf("%s %q looks like a duplicate of %q; use the existing name or allow duplicates", kind, name, dup.Matches)
+		}
+	}
+
+	// Programs defined anywhere in the batch can be used as prerequisites
+	inBatch := map[string]int{}
+	for i, p := range programs {
+		if p.Program != "" {
+			if first, dup := inBatch[p.Program]; dup {
+				report.Rows[i].errorf("duplicate program %q (first defined in row %d)", p.Program, first+1)
+				continue
+			}
+			inBatch[p.Program] = i
+		}
+	}
+
+	for i, p := range programs {
+		rr := &report.Rows[i]
+
+		if p.Institute != "" && !existing[neo4j.KindInstitute][p.Institute] {
+			if dup := dupes.check(neo4j.KindInstitute, p.Institute); dup != nil {
+				rr.errorf("unknown institute %q (did you mean %q?)", p.Institute, dup.Matches)
+			} else {
+				rr.errorf("unknown institute %q", p.Institute)
+			}
+		}
+		if p.Faculty != "" && !existing[neo4j.KindFaculty][p.Faculty] {
+			rr.warnf("faculty %q will be created", p.Faculty)
+			flagDuplicate(rr, neo4j.KindFaculty, p.Faculty)
+		}
+		if p.Department != "" && !existing[neo4j.KindDepartment][p.Department] {
+			rr.warnf("department %q will be created", p.Department)
+			flagDuplicate(rr, neo4j.KindDepartment, p.Department)
+		}
+		if p.Program != "" && !existing[neo4j.KindProgram][p.Program] && inBatch[p.Program] == i {
+			flagDuplicate(rr, neo4j.KindProgram, p.Program)
+		}
+		for _, prereq := range p.Prerequisites {
+			if _, ok := inBatch[prereq]; !ok && !existing[neo4j.KindProgram][prereq] {
+				rr.errorf("unknown prerequisite program %q", prereq)
+			}
+		}
+		for _, req := range p.Requirements {
+			if !existing[neo4j.KindQualification][req] {
+				rr.warnf("qualification %q will be created", req)
+				flagDuplicate(rr, neo4j.KindQualification, req)
+			}
+		}
+		for _, career := range p.Careers {
+			if !existing[neo4j.KindCareer][career] {
+				rr.warnf("career %q will be created", career)
+				flagDuplicate(rr, neo4j.KindCareer, career)
+			}
+		}
+
+		switch {
+		case len(rr.Errors) > 0:
+			rr.Action = ActionSkip
+			rr.Status = StatusError
+			report.Summary.Invalid++
+		default:
+			rr.Status = StatusOK
+			if len(rr.Warnings) > 0 {
+				rr.Status = StatusWarning
+				report.Summary.Warnings++
+			}
+			rr.Action = ActionCreate
+			if existing[neo4j.KindProgram][p.Program] {
+				rr.Action = ActionUpdate
+				report.Summary.Updated++
+			} else {
+				report.Summary.Created++
+			}
+			report.Summary.Valid++
+		}
+	}
+	report.Summary.Total = len(rows)
+
+	var valid []neo4j.ImportProgram
+	for i, p := range programs {
+		if report.Rows[i].Status != StatusError {
+			valid = append(valid, p)
+		}
+	}
+
+	return report, valid, nil
+}
+
+// parseRow normalizes a row and records structural problems on its report
+func parseRow(row Row, rr *RowReport) neo4j.ImportProgram {
+	p := neo4j.ImportProgram{
+		Institute:  parseName("institute", row.Institute, rr),
+		Faculty:    parseName("faculty", row.Faculty, rr),
+		Department: parseName("department", row.Department, rr),
+		Program:    parseName("program", row.Program, rr),
+	}
+	p.Requirements = parseList("requirements", row.Requirements, rr)
+	p.Prerequisites = parseList("prerequisites", row.Prerequisites, rr)
+	p.Careers = parseList("careers", row.Careers, rr)
+
+	if p.Program == "" {
+		rr.errorf("program is required")
+	}
+	if p.Institute == "" {
+		rr.errorf("institute is required")
+	}
+	if p.Department != "" && p.Faculty == "" {
+		rr.errorf("faculty is required when a department is given")
+	}
+	if p.Faculty != "" && p.Department == "" {
+		rr.errorf("department is required when a faculty is given")
+	}
+	for _, prereq := range p.Prerequisites {
+		if prereq == p.Program {
+			rr.errorf("program cannot be its own prerequisite")
+		}
+	}
+	return p
+}
+
+// parseName normalizes whitespace in a single name and checks it is well formed
+func parseName(column, value string, rr *RowReport) string {
+	name := strings.Join(strings.Fields(value), " ")
+	if name == "" {
+		return ""
+	}
+	if problem := nameProblem(name); problem != "" {
+		rr.errorf("malformed %s %q: %s", column, name, problem)
+	}
+	return name
+}
+
+// parseList splits a semicolon-separated column into names
+func parseList(column, value string, rr *RowReport) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	var items []string
+	seen := map[string]bool{}
+	parts := strings.Split(value, ";")
+	for i, part := range parts {
+		item := strings.Join(strings.Fields(part), " ")
+		if item == "" {
+			if i < len(parts)-1 {
+				rr.warnf("%s: ignored empty entry at position %d", column, i+1)
+			}
+			continue
+		}
+		if problem := nameProblem(item); problem != "" {
+			rr.errorf("malformed %s entry %q: %s", column, item, problem)
+			continue
+		}
+		if seen[item] {
+			rr.warnf("%s: duplicate entry %q", column, item)
+			continue
+		}
+		seen[item] = true
+		items = append(items, item)
+	}
+	return items
+}
+
+// nameProblem explains why a name is malformed, or returns "" when it is fine
+func nameProblem(name string) string {
+	if len([]rune(name)) > maxNameLength {
+		return fmt.Sprintf("longer than %d characters", maxNameLength)
+	}
+
+	depth := 0
+	for _, r := range name {
+		switch {
+		case unicode.IsControl(r):
+			return "contains control characters"
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return "unbalanced parentheses"
+			}
+		}
+	}
+	if depth != 0 {
+		return "unbalanced parentheses"
+	}
+	return ""
+}
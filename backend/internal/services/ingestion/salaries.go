@@ -0,0 +1,122 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/scraper"
+	"go.uber.org/zap"
+)
+
+// ErrJobBoardsNotConfigured is returned when no job board search URL is set
+var ErrJobBoardsNotConfigured = errors.New("job board search URLs are not configured")
+
+// SalaryReport summarises a job board salary sync run
+type SalaryReport struct {
+	Careers      int      `json:"careers"`
+	Updated      []string `json:"updated"`
+	Insufficient []string `json:"insufficient"`
+	Failed       []string `json:"failed,omitempty"`
+}
+
+// SyncJobBoardSalaries searches the configured job boards for every career in the
+// graph and stores the advertised salaries of careers seen in enough ads. Careers
+// with too few ads keep their previous data.
+func (s *Service) SyncJobBoardSalaries(ctx context.Context) (*SalaryReport, error) {
+	if !s.jobBoards.Configured() {
+		return nil, ErrJobBoardsNotConfigured
+	}
+
+	titles, err := s.neo4jClient.ListNames(ctx, neo4j.KindCareer)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Syncing salaries from job boards", zap.Int("careers", len(titles)))
+
+	report := &SalaryReport{
+		Careers:      len(titles),
+		Updated:      []string{},
+		Insufficient: []string{},
+	}
+	for _, title := range titles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		results, err := s.jobBoards.SearchSalaries(ctx, title)
+		if err != nil {
+			report.Failed = append(report.Failed, title)
+			continue
+		}
+
+		salary := summarizeSalaries(title, results)
+		if salary.SampleSize < s.jobBoard.MinSamples || salary.SampleSize == 0 {
+			report.Insufficient = append(report.Insufficient, title)
+			continue
+		}
+
+		if err := s.salaries.Upsert(ctx, salary); err != nil {
+			return nil, err
+		}
+		report.Updated = append(report.Updated, title)
+	}
+
+	if len(titles) > 0 && len(report.Failed) == len(titles) {
+		return nil, errors.New("job boards could not be reached for any career")
+	}
+
+	s.logger.Info("Job board salary sync completed",
+		zap.Int("careers", report.Careers),
+		zap.Int("updated", len(report.Updated)),
+		zap.Int("insufficient", len(report.Insufficient)),
+		zap.Int("failed", len(report.Failed)))
+	return report, nil
+}
+
+// summarizeSalaries aggregates the salaries found on all boards for a career
+func summarizeSalaries(title string, results []scraper.BoardSalaries) mongodb.CareerSalary {
+	salary := mongodb.CareerSalary{
+		CareerTitle: title,
+		Currency:    "LKR",
+		Sources:     []string{},
+		CollectedAt: time.Now().UTC(),
+	}
+
+	var midpoints []int64
+	for _, result := range results {
+		if len(result.Salaries) == 0 {
+			continue
+		}
+		salary.Sources = append(salary.Sources, result.Board)
+		for _, r := range result.Salaries {
+			if salary.Min == 0 || r.Min < salary.Min {
+				salary.Min = r.Min
+			}
+			if r.Max > salary.Max {
+				salary.Max = r.Max
+			}
+			midpoints = append(midpoints, (r.Min+r.Max)/2)
+		}
+	}
+	if len(midpoints) == 0 {
+		return salary
+	}
+
+	sort.Slice(midpoints, func(i, j int) bool { return midpoints[i] < midpoints[j] })
+	salary.SampleSize = len(midpoints)
+	salary.P25 = percentile(midpoints, 0.25)
+	salary.Median = percentile(midpoints, 0.5)
+	salary.P75 = percentile(midpoints, 0.75)
+	return salary
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []int64, p float64) int64 {
+	index := int(p*float64(len(sorted)-1) + 0.5)
+	return sorted[index]
+}
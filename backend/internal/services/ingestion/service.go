@@ -0,0 +1,73 @@
+package ingestion
+
+import (
+	"net/http"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"github.com/mayura-andrew/fastfinder/internal/services/review"
+	"github.com/mayura-andrew/fastfinder/internal/services/scraper"
+	"github.com/mayura-andrew/fastfinder/pkg/fuzzy"
+	"go.uber.org/zap"
+)
+
+const (
+	// Names scoring at least this similarity are mapped without review
+	AutoMatchScore = 0.92
+
+	// Existing entities scoring at least this similarity are offered as suggestions
+	SuggestionScore = 0.6
+
+	// Maximum number of suggestions attached to a review item
+	maxSuggestions = 5
+)
+
+// Service runs the pipelines that bring external datasets (handbooks, registries)
+// into the graph. Entries that cannot be mapped confidently are queued for review.
+type Service struct {
+	neo4jClient *neo4j.Client
+	cutoffs     *mongodb.ZScoreCutoffStore
+	salaries    *mongodb.CareerSalaryStore
+	review      *review.Service
+	changes     *changelog.Service
+	tvec        config.TVECConfig
+	jobBoard    config.JobBoardConfig
+	jobBoards   *scraper.JobBoardScraper
+	httpClient  *http.Client
+	logger      *zap.Logger
+}
+
+// NewService creates a new ingestion service and registers its review appliers
+func NewService(neo4jClient *neo4j.Client, mongoClient *mongodb.Client, reviewService *review.Service, changelogService *changelog.Service, tvecConfig config.TVECConfig, jobBoardConfig config.JobBoardConfig, logger *zap.Logger) *Service {
+	s := &Service{
+		neo4jClient: neo4jClient,
+		cutoffs:     mongodb.NewZScoreCutoffStore(mongoClient, logger),
+		salaries:    mongodb.NewCareerSalaryStore(mongoClient, logger),
+		review:      reviewService,
+		changes:     changelogService,
+		tvec:        tvecConfig,
+		jobBoard:    jobBoardConfig,
+		jobBoards:   scraper.NewJobBoardScraper(jobBoardConfig.SearchURLs, jobBoardConfig.Timeout, jobBoardConfig.RequestDelay, logger),
+		httpClient:  &http.Client{Timeout: tvecConfig.Timeout},
+		logger:      logger,
+	}
+
+	reviewService.RegisterApplier(KindUGCProgram, s.applyUGCProgram)
+	reviewService.RegisterApplier(KindUGCRequirement, s.applyUGCRequirement)
+	reviewService.RegisterApplier(KindTVECNewCourse, s.applyTVECNewCourse)
+	reviewService.RegisterApplier(KindTVECRemovedCourse, s.applyTVECRemovedCourse)
+	reviewService.RegisterApplier(KindTVECLevelChange, s.applyTVECLevelChange)
+
+	return s
+}
+
+// suggestions converts fuzzy matches into review suggestions
+func suggestions(query string, candidates []string) []mongodb.ReviewSuggestion {
+	var result []mongodb.ReviewSuggestion
+	for _, m := range fuzzy.Rank(query, candidates, SuggestionScore, maxSuggestions) {
+		result = append(result, mongodb.ReviewSuggestion{Name: m.Name, Score: m.Score})
+	}
+	return result
+}
@@ -0,0 +1,619 @@
+package ingestion
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"github.com/mayura-andrew/fastfinder/internal/services/review"
+	"github.com/mayura-andrew/fastfinder/pkg/fuzzy"
+	"go.uber.org/zap"
+)
+
+const (
+	// SourceTVECRegistry identifies review items produced by the TVEC registry sync
+	SourceTVECRegistry = "tvec_registry"
+
+	// Review item kinds produced by the TVEC registry sync
+	KindTVECNewCourse     = "tvec_new_course"
+	KindTVECRemovedCourse = "tvec_removed_course"
+	KindTVECLevelChange   = "tvec_level_change"
+
+	// maxRegistrySize caps the size of a downloaded registry export
+	maxRegistrySize = 50 << 20 // 50MB
+)
+
+// ErrRegistryNotConfigured is returned when no TVEC registry URL is set
+var ErrRegistryNotConfigured = errors.New("TVEC registry URL is not configured")
+
+// nvqLevelPattern finds the NVQ level in a course name, e.g. "(NVQ Level 4)"
+var nvqLevelPattern = regexp.MustCompile(`(?i)NVQ\s*(?:Level|L)?\s*-?\s*([1-7])`)
+
+// TVECCourse is one course of the TVEC registered-course list
+type TVECCourse struct {
+	CourseCode string `json:"course_code" bson:"course_code"`
+	CourseName string `json:"course_name" bson:"course_name"`
+	NVQLevel   int    `json:"nvq_level" bson:"nvq_level"`
+	Provider   string `json:"provider,omitempty" bson:"provider"`
+}
+
+// TVECChange is a proposed change found by reconciling the registry with the graph
+type TVECChange struct {
+	CourseCode    string  `json:"course_code,omitempty"`
+	CourseName    string  `json:"course_name,omitempty"`
+	Program       string  `json:"program,omitempty"`
+	Provider      string  `json:"provider,omitempty"`
+	CurrentLevel  int     `json:"current_level,omitempty"`
+	RegistryLevel int     `json:"registry_level,omitempty"`
+	Score         float64 `json:"score,omitempty"`
+}
+
+// TVECReport summarises a registry sync run
+type TVECReport struct {
+	DryRun         bool           `json:"dry_run"`
+	Courses        int            `json:"courses"`
+	Matched        int            `json:"matched"`
+	NewCourses     []TVECChange   `json:"new_courses"`
+	RemovedCourses []TVECChange   `json:"removed_courses"`
+	LevelChanges   []TVECChange   `json:"level_changes"`
+	QueuedItems    int            `json:"queued_items"`
+	Problems       []ParseProblem `json:"problems,omitempty"`
+}
+
+// tvecProgramPayload is the payload of a registry change to an existing program
+type tvecProgramPayload struct {
+	Program       string `bson:"program"`
+	CourseCode    string `bson:"course_code"`
+	CurrentLevel  int    `bson:"current_level"`
+	RegistryLevel int    `bson:"registry_level"`
+}
+
+// SyncTVECRegistry downloads the configured registry export and reconciles it
+// against the graph
+func (s *Service) SyncTVECRegistry(ctx context.Context, dryRun bool) (*TVECReport, error) {
+	if s.tvec.RegistryURL == "" {
+		return nil, ErrRegistryNotConfigured
+	}
+
+	courses, problems, err := s.fetchTVECRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ReconcileTVECRegistry(ctx, courses, problems, dryRun)
+}
+
+func (s *Service) fetchTVECRegistry(ctx context.Context) ([]TVECCourse, []ParseProblem, error) {
+	s.logger.Debug("Fetching TVEC registry", zap.String("url", s.tvec.RegistryURL))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.tvec.RegistryURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create registry request: %w", err)
+	}
+	req.Header.Set("Accept", "text/csv, application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch TVEC registry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("TVEC registry returned status %d", resp.StatusCode)
+	}
+
+	return ParseTVECRegistry(io.LimitReader(resp.Body, maxRegistrySize))
+}
+
+// ParseTVECRegistry parses a registry export. JSON (an array of courses or an
+// object with a "courses" array) and CSV with a header row are accepted.
+func ParseTVECRegistry(r io.Reader) ([]TVECCourse, []ParseProblem, error) {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			if err == io.EOF {
+				return nil, nil, nil
+			}
+			return nil, nil, fmt.Errorf("failed to read registry: %w", err)
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			br.ReadByte()
+			continue
+		case '[', '{':
+			return parseTVECJSON(br)
+		}
+		return parseTVECCSV(br)
+	}
+}
+
+func parseTVECJSON(r io.Reader) ([]TVECCourse, []ParseProblem, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read registry: %w", err)
+	}
+
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		var wrapped struct {
+			Courses []map[string]interface{} `json:"courses"`
+		}
+		if err := json.Unmarshal(raw, &wrapped); err != nil {
+			return nil, nil, fmt.Errorf("invalid registry JSON: %w", err)
+		}
+		rows = wrapped.Courses
+	}
+
+	var courses []TVECCourse
+	var problems []ParseProblem
+	for i, row := range rows {
+		fields := map[string]string{}
+		for key, value := range row {
+			if column, ok := tvecColumns[normalizeHeader(key)]; ok {
+				fields[column] = strings.TrimSpace(fmt.Sprint(value))
+			}
+		}
+		course, problem := tvecCourse(fields)
+		if problem != "" {
+			problems = append(problems, ParseProblem{Line: i + 1, Problem: problem})
+			continue
+		}
+		courses = append(courses, course)
+	}
+	return courses, problems, nil
+}
+
+// tvecColumns maps accepted header names to course fields
+var tvecColumns = map[string]string{
+	"course_code":     "code",
+	"code":            "code",
+	"registration_no": "code",
+	"course_name":     "name",
+	"course":          "name",
+	"name":            "name",
+	"title":           "name",
+	"nvq_level":       "level",
+	"level":           "level",
+	"provider":        "provider",
+	"institute":       "provider",
+	"training_centre": "provider",
+}
+
+// normalizeHeader maps a column name such as "Course Name" to "course_name"
+func normalizeHeader(name string) string {
+	name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	return strings.NewReplacer(" ", "_", "-", "_", ".", "").Replace(name)
+}
+
+func parseTVECCSV(r io.Reader) ([]TVECCourse, []ParseProblem, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read registry header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		if column, ok := tvecColumns[normalizeHeader(name)]; ok {
+			if _, seen := columns[column]; !seen {
+				columns[column] = i
+			}
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, nil, errors.New("registry must have a course name column")
+	}
+
+	var courses []TVECCourse
+	var problems []ParseProblem
+	line := 1
+	for {
+		record, err := reader.Read()
+		line++
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			problems = append(problems, ParseProblem{Line: line, Problem: err.Error()})
+			continue
+		}
+
+		fields := map[string]string{}
+		for column, i := range columns {
+			if i < len(record) {
+				fields[column] = strings.TrimSpace(record[i])
+			}
+		}
+		course, problem := tvecCourse(fields)
+		if problem != "" {
+			problems = append(problems, ParseProblem{Line: line, Problem: problem})
+			continue
+		}
+		courses = append(courses, course)
+	}
+
+	return courses, problems, nil
+}
+
+// tvecCourse builds a course from its fields, returning a problem when unusable.
+// The NVQ level falls back to the one in the course name.
+func tvecCourse(fields map[string]string) (TVECCourse, string) {
+	course := TVECCourse{
+		CourseCode: fields["code"],
+		CourseName: fields["name"],
+		Provider:   fields["provider"],
+	}
+	if course.CourseName == "" {
+		return course, "missing course name"
+	}
+
+	if level := strings.TrimPrefix(strings.ToUpper(fields["level"]), "NVQ"); strings.TrimSpace(level) != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(level), "LEVEL")))
+		if err != nil || n < 1 || n > 7 {
+			return course, fmt.Sprintf("invalid NVQ level %q", fields["level"])
+		}
+		course.NVQLevel = n
+	} else {
+		course.NVQLevel = nvqLevelFromName(course.CourseName)
+	}
+	return course, ""
+}
+
+// nvqLevelFromName returns the NVQ level mentioned in a name, or 0
+func nvqLevelFromName(name string) int {
+	match := nvqLevelPattern.FindStringSubmatch(name)
+	if match == nil {
+		return 0
+	}
+	level, _ := strconv.Atoi(match[1])
+	return level
+}
+
+// renameForLevel rewrites the NVQ level mentioned in a program name
+func renameForLevel(name string, level int) string {
+	if !nvqLevelPattern.MatchString(name) {
+		return name
+	}
+	return nvqLevelPattern.ReplaceAllString(name, fmt.Sprintf("NVQ Level %d", level))
+}
+
+// ReconcileTVECRegistry compares registry courses with the graph's vocational
+// programs and queues new courses, removed courses and NVQ level changes for
+// admin review. Nothing is changed in the graph until an item is approved.
+func (s *Service) ReconcileTVECRegistry(ctx context.Context, courses []TVECCourse, problems []ParseProblem, dryRun bool) (*TVECReport, error) {
+	s.logger.Debug("Reconciling TVEC registry",
+		zap.Int("courses", len(courses)),
+		zap.Bool("dry_run", dryRun))
+
+	programs, err := s.neo4jClient.ListRegisteredPrograms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allPrograms, err := s.neo4jClient.ListNames(ctx, neo4j.KindProgram)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+
+	byCode := map[string]int{}
+	var names []string
+	for i, p := range programs {
+		if p.CourseCode != "" {
+			byCode[strings.ToUpper(p.CourseCode)] = i
+		}
+		names = append(names, p.Name)
+	}
+
+	report := &TVECReport{
+		DryRun:         dryRun,
+		Courses:        len(courses),
+		NewCourses:     []TVECChange{},
+		RemovedCourses: []TVECChange{},
+		LevelChanges:   []TVECChange{},
+		Problems:       problems,
+	}
+
+	matched := make([]bool, len(programs))
+	for _, course := range courses {
+		index, score := -1, 1.0
+		if i, ok := byCode[strings.ToUpper(course.CourseCode)]; ok && course.CourseCode != "" {
+			index = i
+		} else if best := fuzzy.Best(course.CourseName, names); best.Score >= AutoMatchScore {
+			for i, p := range programs {
+				if p.Name == best.Name {
+					index, score = i, best.Score
+					break
+				}
+			}
+		}
+
+		if index < 0 {
+			change := TVECChange{
+				CourseCode:    course.CourseCode,
+				CourseName:    course.CourseName,
+				Provider:      course.Provider,
+				RegistryLevel: course.NVQLevel,
+			}
+			report.NewCourses = append(report.NewCourses, change)
+			if !dryRun {
+				if err := s.enqueueTVECNewCourse(ctx, course, allPrograms); err != nil {
+					return nil, err
+				}
+				report.QueuedItems++
+			}
+			continue
+		}
+
+		matched[index] = true
+		report.Matched++
+
+		program := programs[index]
+		current := int(program.NVQLevel)
+		if current == 0 {
+			current = nvqLevelFromName(program.Name)
+		}
+		if course.NVQLevel == 0 || course.NVQLevel == current {
+			continue
+		}
+
+		change := TVECChange{
+			CourseCode:    course.CourseCode,
+			CourseName:    course.CourseName,
+			Program:       program.Name,
+			CurrentLevel:  current,
+			RegistryLevel: course.NVQLevel,
+			Score:         score,
+		}
+		report.LevelChanges = append(report.LevelChanges, change)
+		if !dryRun {
+			if err := s.enqueueTVECProgramChange(ctx, KindTVECLevelChange, change,
+				fmt.Sprintf("Change %s from NVQ Level %d to NVQ Level %d", program.Name, current, course.NVQLevel)); err != nil {
+				return nil, err
+			}
+			report.QueuedItems++
+		}
+	}
+
+	// An empty or entirely unusable export must not propose removing every course
+	if len(courses) > 0 {
+		for i, program := range programs {
+			if matched[i] || program.Deregistered {
+				continue
+			}
+			change := TVECChange{
+				CourseCode:   program.CourseCode,
+				Program:      program.Name,
+				CurrentLevel: int(program.NVQLevel),
+			}
+			report.RemovedCourses = append(report.RemovedCourses, change)
+			if !dryRun {
+				if err := s.enqueueTVECProgramChange(ctx, KindTVECRemovedCourse, change,
+					fmt.Sprintf("Mark %s as no longer registered with TVEC", program.Name)); err != nil {
+					return nil, err
+				}
+				report.QueuedItems++
+			}
+		}
+	}
+
+	s.logger.Info("TVEC registry reconciled",
+		zap.Bool("dry_run", dryRun),
+		zap.Int("courses", report.Courses),
+		zap.Int("matched", report.Matched),
+		zap.Int("new", len(report.NewCourses)),
+		zap.Int("removed", len(report.RemovedCourses)),
+		zap.Int("level_changes", len(report.LevelChanges)))
+
+	return report, nil
+}
+
+func (s *Service) enqueueTVECNewCourse(ctx context.Context, course TVECCourse, programNames []string) error {
+	payload, err := review.EncodePayload(course)
+	if err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("Add TVEC course %q", course.CourseName)
+	if course.Provider != "" {
+		summary += fmt.Sprintf(" (%s)", course.Provider)
+	}
+
+	key := course.CourseCode
+	if key == "" {
+		key = fuzzy.Normalize(course.CourseName)
+	}
+
+	_, err = s.review.Enqueue(ctx, &mongodb.ReviewItem{
+		Source:      SourceTVECRegistry,
+		Kind:        KindTVECNewCourse,
+		Summary:     summary,
+		DedupKey:    KindTVECNewCourse + ":" + strings.ToUpper(key),
+		Payload:     payload,
+		Suggestions: suggestions(course.CourseName, programNames),
+	})
+	return err
+}
+
+func (s *Service) enqueueTVECProgramChange(ctx context.Context, kind string, change TVECChange, summary string) error {
+	payload, err := review.EncodePayload(tvecProgramPayload{
+		Program:       change.Program,
+		CourseCode:    change.CourseCode,
+		CurrentLevel:  change.CurrentLevel,
+		RegistryLevel: change.RegistryLevel,
+	})
+	if err != nil {
+		return err
+	}
+
+	dedupKey := fmt.Sprintf("%s:%s", kind, fuzzy.Normalize(change.Program))
+	if kind == KindTVECLevelChange {
+		dedupKey += fmt.Sprintf(":%d", change.RegistryLevel)
+	}
+
+	_, err = s.review.Enqueue(ctx, &mongodb.ReviewItem{
+		Source:   SourceTVECRegistry,
+		Kind:     kind,
+		Summary:  summary,
+		DedupKey: dedupKey,
+		Payload:  payload,
+	})
+	return err
+}
+
+// applyTVECNewCourse adds a registry course to the graph. The resolution either
+// names an existing program the course corresponds to ("program") or the
+// institute that should offer the new program ("institute").
+func (s *Service) applyTVECNewCourse(ctx context.Context, item *mongodb.ReviewItem, resolution map[string]interface{}) error {
+	var course TVECCourse
+	if err := review.DecodePayload(item.Payload, &course); err != nil {
+		return err
+	}
+
+	provenance := neo4j.NewProvenance(SourceTVECRegistry, s.tvec.RegistryURL)
+	properties := map[string]any{
+		"deregistered":    nil,
+		"deregistered_at": nil,
+		"source":          provenance.Source,
+		"source_url":      provenance.SourceURL,
+		"imported_at":     provenance.ImportedAt,
+	}
+	if course.CourseCode != "" {
+		properties["course_code"] = course.CourseCode
+	}
+	if course.NVQLevel > 0 {
+		properties["nvq_level"] = int64(course.NVQLevel)
+	}
+	if course.Provider != "" {
+		properties["provider"] = course.Provider
+	}
+
+	if programName := review.ResolutionString(resolution, "program"); programName != "" {
+		if err := s.requireExisting(ctx, neo4j.KindProgram, programName); err != nil {
+			return err
+		}
+		if err := s.neo4jClient.SetProgramProperties(ctx, programName, "", properties); err != nil {
+			return err
+		}
+		s.emitTVECChange(ctx, mongodb.GraphChange{
+			Kind:    neo4j.KindProgram,
+			Name:    programName,
+			Action:  changelog.ActionUpdate,
+			Details: []string{"linked to registry course " + course.CourseName},
+		})
+		return nil
+	}
+
+	institute := review.ResolutionString(resolution, "institute")
+	if err := s.requireExisting(ctx, neo4j.KindInstitute, institute); err != nil {
+		return err
+	}
+
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, []string{course.CourseName})
+	if err != nil {
+		return err
+	}
+	if existing[course.CourseName] {
+		return fmt.Errorf("%w: program %q already exists; approve with \"program\" to link it",
+			review.ErrInvalidResolution, course.CourseName)
+	}
+
+	if err := s.neo4jClient.ImportPrograms(ctx, []neo4j.ImportProgram{{
+		Institute: institute,
+		Program:   course.CourseName,
+	}}, provenance); err != nil {
+		return err
+	}
+	if err := s.neo4jClient.SetProgramProperties(ctx, course.CourseName, "", properties); err != nil {
+		return err
+	}
+	s.emitTVECChange(ctx, mongodb.GraphChange{
+		Kind:    neo4j.KindProgram,
+		Name:    course.CourseName,
+		Action:  changelog.ActionCreate,
+		Details: []string{"offered by " + institute},
+	})
+	return nil
+}
+
+// applyTVECRemovedCourse marks a program as deregistered. The program is kept so
+// existing plans and roadmaps that reference it stay valid.
+func (s *Service) applyTVECRemovedCourse(ctx context.Context, item *mongodb.ReviewItem, _ map[string]interface{}) error {
+	var payload tvecProgramPayload
+	if err := review.DecodePayload(item.Payload, &payload); err != nil {
+		return err
+	}
+
+	if err := s.neo4jClient.SetProgramProperties(ctx, payload.Program, "", map[string]any{
+		"deregistered":    true,
+		"deregistered_at": time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		return err
+	}
+	s.emitTVECChange(ctx, mongodb.GraphChange{
+		Kind:    neo4j.KindProgram,
+		Name:    payload.Program,
+		Action:  changelog.ActionUpdate,
+		Details: []string{"deregistered"},
+	})
+	return nil
+}
+
+// applyTVECLevelChange updates a program's NVQ level, rewriting the level in its
+// name when the name mentions one
+func (s *Service) applyTVECLevelChange(ctx context.Context, item *mongodb.ReviewItem, _ map[string]interface{}) error {
+	var payload tvecProgramPayload
+	if err := review.DecodePayload(item.Payload, &payload); err != nil {
+		return err
+	}
+
+	properties := map[string]any{"nvq_level": int64(payload.RegistryLevel)}
+	if payload.CourseCode != "" {
+		properties["course_code"] = payload.CourseCode
+	}
+
+	newName := renameForLevel(payload.Program, payload.RegistryLevel)
+	if err := s.neo4jClient.SetProgramProperties(ctx, payload.Program, newName, properties); err != nil {
+		if errors.Is(err, neo4j.ErrEntityExists) || errors.Is(err, neo4j.ErrEntityNotFound) {
+			return fmt.Errorf("%w: %v", review.ErrInvalidResolution, err)
+		}
+		return err
+	}
+
+	change := mongodb.GraphChange{
+		Kind:    neo4j.KindProgram,
+		Name:    payload.Program,
+		Action:  changelog.ActionUpdate,
+		Details: []string{fmt.Sprintf("NVQ level %d", payload.RegistryLevel)},
+	}
+	if newName != payload.Program {
+		change.Name = newName
+		change.PreviousName = payload.Program
+	}
+	s.emitTVECChange(ctx, change)
+	return nil
+}
+
+// emitTVECChange passes an approved registry change to the change log, whose
+// subscribers drop stale cached roadmaps and lists and notify webhooks
+func (s *Service) emitTVECChange(ctx context.Context, change mongodb.GraphChange) {
+	change.Source = SourceTVECRegistry
+	change.SourceURL = s.tvec.RegistryURL
+	if err := s.changes.Emit(ctx, []mongodb.GraphChange{change}); err != nil {
+		s.logger.Warn("Failed to record registry changes", zap.Error(err))
+	}
+}
@@ -0,0 +1,598 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/review"
+	"github.com/mayura-andrew/fastfinder/pkg/fuzzy"
+	"go.uber.org/zap"
+)
+
+const (
+	// SourceUGCHandbook identifies review items produced by the UGC pipeline
+	SourceUGCHandbook = "ugc_handbook"
+
+	// Review item kinds produced by the UGC pipeline
+	KindUGCProgram     = "ugc_program"
+	KindUGCRequirement = "ugc_requirement"
+
+	// Supported handbook extract formats
+	FormatCSV  = "csv"
+	FormatText = "text"
+)
+
+// ErrUnsupportedFormat is returned for handbook extracts in an unknown format
+var ErrUnsupportedFormat = errors.New("unsupported handbook format")
+
+// UGCEntry is one program/district cutoff line of the UGC admissions handbook
+type UGCEntry struct {
+	Line         int      `json:"line"`
+	CourseCode   string   `json:"course_code"`
+	Program      string   `json:"program"`
+	Institute    string   `json:"institute"`
+	Requirements []string `json:"requirements,omitempty"`
+	District     string   `json:"district"`
+	ZScore       *float64 `json:"z_score"`
+	Year         int      `json:"year"`
+}
+
+// ParseProblem describes a line of the extract that could not be used
+type ParseProblem struct {
+	Line    int    `json:"line"`
+	Problem string `json:"problem"`
+}
+
+// ugcCutoff is a cutoff carried in a review payload
+type ugcCutoff struct {
+	Year     int     `bson:"year" json:"year"`
+	District string  `bson:"district" json:"district"`
+	ZScore   float64 `bson:"z_score" json:"z_score"`
+}
+
+// ugcProgramPayload is the payload of a program that needs manual mapping
+type ugcProgramPayload struct {
+	Program      string      `bson:"program"`
+	Institute    string      `bson:"institute"`
+	CourseCode   string      `bson:"course_code"`
+	Requirements []string    `bson:"requirements"`
+	Cutoffs      []ugcCutoff `bson:"cutoffs"`
+}
+
+// ugcRequirementPayload is the payload of a requirement that needs manual mapping
+type ugcRequirementPayload struct {
+	Program     string `bson:"program"`
+	Requirement string `bson:"requirement"`
+}
+
+// UGCReport summarises a handbook ingestion run
+type UGCReport struct {
+	DryRun             bool              `json:"dry_run"`
+	Entries            int               `json:"entries"`
+	Programs           int               `json:"programs"`
+	MatchedPrograms    []UGCProgramMatch `json:"matched_programs"`
+	QueuedPrograms     []UGCProgramMatch `json:"queued_programs"`
+	LinkedRequirements int               `json:"linked_requirements"`
+	QueuedRequirements int               `json:"queued_requirements"`
+	CutoffsStored      int               `json:"cutoffs_stored"`
+	Problems           []ParseProblem    `json:"problems,omitempty"`
+	QueuedItems        int               `json:"queued_items"`
+}
+
+// UGCProgramMatch shows how a handbook program name was mapped
+type UGCProgramMatch struct {
+	HandbookName string  `json:"handbook_name"`
+	Institute    string  `json:"institute"`
+	MatchedName  string  `json:"matched_name,omitempty"`
+	Score        float64 `json:"score"`
+	Cutoffs      int     `json:"cutoffs"`
+}
+
+// ParseUGCHandbook reads a handbook extract. CSV extracts need a header row with
+// program, district and z_score columns (course_code, institute, requirements and
+// year are optional; requirements are separated by semicolons). Text extracts are
+// the output of `pdftotext -layout` on the cutoff tables: one line per cutoff with
+// course code, program, institute, district and z-score in columns separated by
+// tabs, pipes or runs of spaces. defaultYear is used when a line has no year.
+func ParseUGCHandbook(format string, r io.Reader, defaultYear int) ([]UGCEntry, []ParseProblem, error) {
+	switch format {
+	case FormatCSV, "":
+		return parseUGCCSV(r, defaultYear)
+	case FormatText:
+		return parseUGCText(r, defaultYear)
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
+
+func parseUGCCSV(r io.Reader, defaultYear int) ([]UGCEntry, []ParseProblem, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+		columns[key] = i
+	}
+	column := func(aliases ...string) int {
+		for _, alias := range aliases {
+			if i, ok := columns[alias]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+
+	programCol := column("program", "course", "course_name")
+	districtCol := column("district")
+	zscoreCol := column("z_score", "zscore", "cutoff", "cut_off")
+	if programCol < 0 || districtCol < 0 || zscoreCol < 0 {
+		return nil, nil, fmt.Errorf("CSV header must include program, district and z_score columns")
+	}
+	codeCol := column("course_code", "code")
+	instituteCol := column("institute", "university")
+	requirementsCol := column("requirements", "subject_requirements")
+	yearCol := column("year", "academic_year")
+
+	var entries []UGCEntry
+	var problems []ParseProblem
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			problems = append(problems, ParseProblem{Line: line, Problem: err.Error()})
+			continue
+		}
+
+		get := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.Join(strings.Fields(record[i]), " ")
+		}
+
+		entry := UGCEntry{
+			Line:       line,
+			CourseCode: get(codeCol),
+			Program:    get(programCol),
+			Institute:  get(instituteCol),
+			District:   get(districtCol),
+			Year:       defaultYear,
+		}
+		if reqs := get(requirementsCol); reqs != "" {
+			for _, req := range strings.Split(reqs, ";") {
+				if req = strings.TrimSpace(req); req != "" {
+					entry.Requirements = append(entry.Requirements, req)
+				}
+			}
+		}
+		if y := get(yearCol); y != "" {
+			year, err := parseYear(y)
+			if err != nil {
+				problems = append(problems, ParseProblem{Line: line, Problem: err.Error()})
+				continue
+			}
+			entry.Year = year
+		}
+
+		if problem := finishEntry(&entry, get(zscoreCol)); problem != "" {
+			problems = append(problems, ParseProblem{Line: line, Problem: problem})
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, problems, nil
+}
+
+var textColumnSeparator = regexp.MustCompile(`\t+|\s*\|\s*|\s{2,}`)
+
+func parseUGCText(r io.Reader, defaultYear int) ([]UGCEntry, []ParseProblem, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read handbook text: %w", err)
+	}
+
+	var entries []UGCEntry
+	var problems []ParseProblem
+	for i, raw := range strings.Split(string(data), "\n") {
+		text := strings.TrimSpace(raw)
+		if text == "" {
+			continue
+		}
+
+		fields := textColumnSeparator.Split(text, -1)
+		// Table headers, page numbers and footnotes do not have five columns
+		if len(fields) < 5 {
+			continue
+		}
+
+		entry := UGCEntry{
+			Line:       i + 1,
+			CourseCode: strings.TrimSpace(fields[0]),
+			Program:    strings.TrimSpace(fields[1]),
+			Institute:  strings.TrimSpace(fields[2]),
+			District:   strings.TrimSpace(fields[3]),
+			Year:       defaultYear,
+		}
+		if _, err := strconv.ParseFloat(strings.TrimSpace(fields[4]), 64); err != nil && !isNoCutoff(fields[4]) {
+			// Not a cutoff row
+			continue
+		}
+
+		if problem := finishEntry(&entry, fields[4]); problem != "" {
+			problems = append(problems, ParseProblem{Line: entry.Line, Problem: problem})
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, problems, nil
+}
+
+// finishEntry validates an entry and parses its z-score. A handbook marks districts
+// where nobody qualified as "NQC" or "-"; those entries keep a nil z-score.
+func finishEntry(entry *UGCEntry, zscore string) string {
+	if entry.Program == "" {
+		return "program is missing"
+	}
+	if entry.District == "" {
+		return "district is missing"
+	}
+	if entry.Year == 0 {
+		return "year is missing (pass ?year= or add a year column)"
+	}
+
+	zscore = strings.TrimSpace(zscore)
+	if isNoCutoff(zscore) {
+		return ""
+	}
+	value, err := strconv.ParseFloat(zscore, 64)
+	if err != nil {
+		return fmt.Sprintf("invalid z-score %q", zscore)
+	}
+	if value < -5 || value > 5 {
+		return fmt.Sprintf("z-score %.4f is out of range", value)
+	}
+	entry.ZScore = &value
+	return ""
+}
+
+func isNoCutoff(value string) bool {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "NQC", "-", "N/A", "":
+		return true
+	}
+	return false
+}
+
+// parseYear accepts "2023" or academic years such as "2023/2024" (using the first year)
+func parseYear(value string) (int, error) {
+	if i := strings.IndexAny(value, "/-"); i > 0 {
+		value = value[:i]
+	}
+	year, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || year < 1990 || year > 2100 {
+		return 0, fmt.Errorf("invalid year %q", value)
+	}
+	return year, nil
+}
+
+// ugcProgramGroup collects the entries of one handbook program
+type ugcProgramGroup struct {
+	payload ugcProgramPayload
+	order   int
+}
+
+// IngestUGCHandbook maps handbook entries onto the graph. Programs matching one
+// their institute offers confidently, and requirements matching an existing
+// qualification, are applied directly (unless dryRun); everything else is queued
+// for admin review.
+func (s *Service) IngestUGCHandbook(ctx context.Context, entries []UGCEntry, problems []ParseProblem, dryRun bool) (*UGCReport, error) {
+	s.logger.Debug("Ingesting UGC handbook",
+		zap.Int("entries", len(entries)),
+		zap.Bool("dry_run", dryRun))
+
+	programNames, err := s.neo4jClient.ListNames(ctx, neo4j.KindProgram)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+	qualificationNames, err := s.neo4jClient.ListNames(ctx, neo4j.KindQualification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list qualifications: %w", err)
+	}
+	instituteNames, err := s.neo4jClient.ListNames(ctx, neo4j.KindInstitute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list institutes: %w", err)
+	}
+	institutePrograms := map[string][]string{}
+
+	report := &UGCReport{
+		DryRun:          dryRun,
+		Entries:         len(entries),
+		MatchedPrograms: []UGCProgramMatch{},
+		QueuedPrograms:  []UGCProgramMatch{},
+		Problems:        problems,
+	}
+
+	// Group cutoff lines by program and institute
+	groups := map[string]*ugcProgramGroup{}
+	var order []string
+	for _, entry := range entries {
+		key := fuzzy.Normalize(entry.Program) + "|" + fuzzy.Normalize(entry.Institute)
+		group, ok := groups[key]
+		if !ok {
+			group = &ugcProgramGroup{payload: ugcProgramPayload{
+				Program:    entry.Program,
+				Institute:  entry.Institute,
+				CourseCode: entry.CourseCode,
+			}}
+			groups[key] = group
+			order = append(order, key)
+		}
+		for _, req := range entry.Requirements {
+			if !containsString(group.payload.Requirements, req) {
+				group.payload.Requirements = append(group.payload.Requirements, req)
+			}
+		}
+		if entry.ZScore != nil {
+			group.payload.Cutoffs = append(group.payload.Cutoffs, ugcCutoff{
+				Year:     entry.Year,
+				District: entry.District,
+				ZScore:   *entry.ZScore,
+			})
+		}
+	}
+	report.Programs = len(order)
+
+	for _, key := range order {
+		payload := groups[key].payload
+		candidates, err := s.ugcCandidates(ctx, payload.Institute, programNames, instituteNames, institutePrograms)
+		if err != nil {
+			return nil, err
+		}
+		best := fuzzy.Best(payload.Program, candidates)
+		match := UGCProgramMatch{
+			HandbookName: payload.Program,
+			Institute:    payload.Institute,
+			Score:        best.Score,
+			Cutoffs:      len(payload.Cutoffs),
+		}
+
+		if best.Score < AutoMatchScore {
+			report.QueuedPrograms = append(report.QueuedPrograms, match)
+			if !dryRun {
+				if err := s.enqueueUGCProgram(ctx, payload, programNames); err != nil {
+					return nil, err
+				}
+				report.QueuedItems++
+			}
+			continue
+		}
+
+		match.MatchedName = best.Name
+		report.MatchedPrograms = append(report.MatchedPrograms, match)
+
+		linked, queued, err := s.mapUGCRequirements(ctx, best.Name, payload.Requirements, qualificationNames, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		report.LinkedRequirements += linked
+		report.QueuedRequirements += queued
+		if !dryRun {
+			report.QueuedItems += queued
+		}
+
+		if !dryRun {
+			if err := s.storeUGCCutoffs(ctx, best.Name, payload); err != nil {
+				return nil, err
+			}
+		}
+		report.CutoffsStored += len(payload.Cutoffs)
+	}
+
+	s.logger.Info("UGC handbook ingested",
+		zap.Bool("dry_run", dryRun),
+		zap.Int("programs", report.Programs),
+		zap.Int("matched", len(report.MatchedPrograms)),
+		zap.Int("queued", len(report.QueuedPrograms)),
+		zap.Int("cutoffs", report.CutoffsStored))
+
+	return report, nil
+}
+
+// ugcCandidates returns the programs a handbook program of institute may be
+// matched to: those the institute offers, as institutes share program names, or
+// none when the institute matches no graph institute confidently, leaving the
+// program for review. Handbook lines naming no institute may match any program.
+// The programs of each institute are read once per run, through offered.
+func (s *Service) ugcCandidates(ctx context.Context, institute string, programNames, instituteNames []string, offered map[string][]string) ([]string, error) {
+	if institute == "" {
+		return programNames, nil
+	}
+	best := fuzzy.Best(institute, instituteNames)
+	if best.Score < AutoMatchScore {
+		return nil, nil
+	}
+	if names, ok := offered[best.Name]; ok {
+		return names, nil
+	}
+
+	programs, err := s.neo4jClient.GetProgramsByInstitute(ctx, best.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list programs of %s: %w", best.Name, err)
+	}
+	names := make([]string, 0, len(programs))
+	for _, program := range programs {
+		names = append(names, program.Name)
+	}
+	offered[best.Name] = names
+	return names, nil
+}
+
+// mapUGCRequirements links requirements that match an existing qualification and
+// queues the rest. Returns the number linked and queued.
+func (s *Service) mapUGCRequirements(ctx context.Context, programName string, requirements, qualificationNames []string, dryRun bool) (int, int, error) {
+	var linked []string
+	queued := 0
+	for _, req := range requirements {
+		best := fuzzy.Best(req, qualificationNames)
+		if best.Score >= AutoMatchScore {
+			linked = append(linked, best.Name)
+			continue
+		}
+
+		queued++
+		if dryRun {
+			continue
+		}
+		payload, err := review.EncodePayload(ugcRequirementPayload{Program: programName, Requirement: req})
+		if err != nil {
+			return 0, 0, err
+		}
+		if _, err := s.review.Enqueue(ctx, &mongodb.ReviewItem{
+			Source:      SourceUGCHandbook,
+			Kind:        KindUGCRequirement,
+			Summary:     fmt.Sprintf("Map requirement %q of %s to a qualification", req, programName),
+			DedupKey:    KindUGCRequirement + ":" + fuzzy.Normalize(programName) + ":" + fuzzy.Normalize(req),
+			Payload:     payload,
+			Suggestions: suggestions(req, qualificationNames),
+		}); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if len(linked) > 0 && !dryRun {
+		if err := s.neo4jClient.AddRequirements(ctx, programName, linked); err != nil {
+			return 0, 0, err
+		}
+	}
+	return len(linked), queued, nil
+}
+
+func (s *Service) enqueueUGCProgram(ctx context.Context, payload ugcProgramPayload, programNames []string) error {
+	encoded, err := review.EncodePayload(payload)
+	if err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("Map handbook program %q", payload.Program)
+	if payload.Institute != "" {
+		summary += fmt.Sprintf(" (%s)", payload.Institute)
+	}
+
+	_, err = s.review.Enqueue(ctx, &mongodb.ReviewItem{
+		Source:      SourceUGCHandbook,
+		Kind:        KindUGCProgram,
+		Summary:     summary,
+		DedupKey:    KindUGCProgram + ":" + fuzzy.Normalize(payload.Program) + ":" + fuzzy.Normalize(payload.Institute),
+		Payload:     encoded,
+		Suggestions: suggestions(payload.Program, programNames),
+	})
+	return err
+}
+
+func (s *Service) storeUGCCutoffs(ctx context.Context, programName string, payload ugcProgramPayload) error {
+	cutoffs := make([]mongodb.ZScoreCutoff, 0, len(payload.Cutoffs))
+	for _, c := range payload.Cutoffs {
+		cutoffs = append(cutoffs, mongodb.ZScoreCutoff{
+			ProgramName: programName,
+			Institute:   payload.Institute,
+			CourseCode:  payload.CourseCode,
+			Year:        c.Year,
+			District:    c.District,
+			ZScore:      c.ZScore,
+			Source:      SourceUGCHandbook,
+		})
+	}
+	if err := s.cutoffs.Upsert(ctx, cutoffs); err != nil {
+		s.logger.Error("Failed to store UGC cutoffs",
+			zap.String("program", programName),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// applyUGCProgram maps a queued handbook program onto the existing program named
+// in the resolution ("program") and stores its cutoffs and requirements
+func (s *Service) applyUGCProgram(ctx context.Context, item *mongodb.ReviewItem, resolution map[string]interface{}) error {
+	var payload ugcProgramPayload
+	if err := review.DecodePayload(item.Payload, &payload); err != nil {
+		return err
+	}
+
+	programName := review.ResolutionString(resolution, "program")
+	if err := s.requireExisting(ctx, neo4j.KindProgram, programName); err != nil {
+		return err
+	}
+
+	if err := s.storeUGCCutoffs(ctx, programName, payload); err != nil {
+		return err
+	}
+
+	qualificationNames, err := s.neo4jClient.ListNames(ctx, neo4j.KindQualification)
+	if err != nil {
+		return fmt.Errorf("failed to list qualifications: %w", err)
+	}
+	_, _, err = s.mapUGCRequirements(ctx, programName, payload.Requirements, qualificationNames, false)
+	return err
+}
+
+// applyUGCRequirement links a queued requirement to the existing qualification
+// named in the resolution ("qualification")
+func (s *Service) applyUGCRequirement(ctx context.Context, item *mongodb.ReviewItem, resolution map[string]interface{}) error {
+	var payload ugcRequirementPayload
+	if err := review.DecodePayload(item.Payload, &payload); err != nil {
+		return err
+	}
+
+	qualification := review.ResolutionString(resolution, "qualification")
+	if err := s.requireExisting(ctx, neo4j.KindQualification, qualification); err != nil {
+		return err
+	}
+
+	return s.neo4jClient.AddRequirements(ctx, payload.Program, []string{qualification})
+}
+
+// requireExisting checks that a resolution names an existing entity
+func (s *Service) requireExisting(ctx context.Context, kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: %s is required", review.ErrInvalidResolution, kind)
+	}
+	existing, err := s.neo4jClient.ExistingNames(ctx, kind, []string{name})
+	if err != nil {
+		return err
+	}
+	if !existing[name] {
+		return fmt.Errorf("%w: %s %q does not exist", review.ErrInvalidResolution, kind, name)
+	}
+	return nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+// Package moderation screens text users submit, such as suggested corrections,
+// against wordlists and optionally the LLM, and quarantines flagged submissions
+// in the review queue until an admin releases or rejects them.
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/review"
+	"go.uber.org/zap"
+)
+
+const (
+	// SourceModeration identifies quarantined submissions in the review queue
+	SourceModeration = "moderation"
+
+	// KindQuarantined is the review item kind of a quarantined submission.
+	// Approving it releases the submission into the review queue as it would
+	// have been queued; rejecting it discards the submission.
+	KindQuarantined = "quarantined"
+)
+
+// Verdict is the outcome of screening text
+type Verdict struct {
+	Flagged bool `json:"flagged" bson:"flagged"`
+	// Matches are the blocked words and phrases found by the wordlists
+	Matches []string `json:"matches,omitempty" bson:"matches,omitempty"`
+	// Categories and Reason are given by the LLM
+	Categories []string `json:"categories,omitempty" bson:"categories,omitempty"`
+	Reason     string   `json:"reason,omitempty" bson:"reason,omitempty"`
+}
+
+// quarantine is the payload of a quarantined review item: the item the
+// submission would have been queued as, with the text that was flagged
+type quarantine struct {
+	Source      string                     `bson:"source"`
+	Kind        string                     `bson:"kind"`
+	Summary     string                     `bson:"summary"`
+	DedupKey    string                     `bson:"dedup_key,omitempty"`
+	Payload     map[string]interface{}     `bson:"payload"`
+	Suggestions []mongodb.ReviewSuggestion `bson:"suggestions,omitempty"`
+	Text        []string                   `bson:"text"`
+	Verdict     Verdict                    `bson:"verdict"`
+}
+
+// Service screens user submissions before they are queued for review
+type Service struct {
+	enabled   bool
+	words     *wordlist
+	llmClient *llm.Client
+	review    *review.Service
+	logger    *zap.Logger
+}
+
+// NewService creates a new moderation service and registers the applier
+// releasing quarantined submissions. Wordlist files that cannot be read are
+// logged and skipped. The LLM is only asked when cfg.UseLLM is set and a client
+// is given.
+func NewService(cfg config.ModerationConfig, llmClient *llm.Client, reviewService *review.Service, logger *zap.Logger) *Service {
+	words := newWordlist()
+	_ = words.read(strings.NewReader(builtinWordlist))
+	for _, path := range cfg.WordlistFiles {
+		if err := readWordlistFile(words, path); err != nil {
+			logger.Warn("Failed to read moderation wordlist",
+				zap.String("path", path),
+				zap.Error(err))
+		}
+	}
+	for _, word := range cfg.BlockedWords {
+		words.add(word)
+	}
+
+	s := &Service{
+		enabled: cfg.Enabled,
+		words:   words,
+		review:  reviewService,
+		logger:  logger,
+	}
+	if cfg.UseLLM {
+		s.llmClient = llmClient
+	}
+
+	reviewService.RegisterApplier(KindQuarantined, s.release)
+
+	logger.Info("Moderation configured",
+		zap.Bool("enabled", cfg.Enabled),
+		zap.Int("blocked_entries", words.size()),
+		zap.Bool("llm", s.llmClient != nil))
+	return s
+}
+
+func readWordlistFile(words *wordlist, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return words.read(file)
+}
+
+// Check screens text against the wordlists and, when they pass it, the LLM. An
+// LLM that cannot be reached lets the text through, so submissions are not
+// lost while it is down.
+func (s *Service) Check(ctx context.Context, text ...string) Verdict {
+	var verdict Verdict
+	if !s.enabled {
+		return verdict
+	}
+
+	for _, t := range text {
+		verdict.Matches = append(verdict.Matches, s.words.match(t)...)
+	}
+	if len(verdict.Matches) > 0 {
+		verdict.Flagged = true
+		return verdict
+	}
+
+	joined := strings.TrimSpace(strings.Join(text, "\n"))
+	if s.llmClient == nil || joined == "" {
+		return verdict
+	}
+	moderation, err := s.llmClient.ModerateText(ctx, joined)
+	if err != nil {
+		s.logger.Warn("LLM moderation failed, accepting text", zap.Error(err))
+		return verdict
+	}
+	verdict.Flagged = moderation.Flagged
+	if moderation.Flagged {
+		verdict.Categories = moderation.Categories
+		verdict.Reason = moderation.Reason
+	}
+	return verdict
+}
+
+// Enqueue screens the user-written text of a submission and queues item for
+// review, or quarantines it when the text is flagged. It reports whether a new
+// item was created and whether the submission was quarantined.
+func (s *Service) Enqueue(ctx context.Context, item *mongodb.ReviewItem, text ...string) (created, quarantined bool, err error) {
+	verdict := s.Check(ctx, text...)
+	if !verdict.Flagged {
+		created, err = s.review.Enqueue(ctx, item)
+		return created, false, err
+	}
+
+	payload, err := review.EncodePayload(quarantine{
+		Source:      item.Source,
+		Kind:        item.Kind,
+		Summary:     item.Summary,
+		DedupKey:    item.DedupKey,
+		Payload:     item.Payload,
+		Suggestions: item.Suggestions,
+		Text:        text,
+		Verdict:     verdict,
+	})
+	if err != nil {
+		return false, false, err
+	}
+
+	held := &mongodb.ReviewItem{
+		Source:  SourceModeration,
+		Kind:    KindQuarantined,
+		Summary: fmt.Sprintf("Flagged (%s): %s", reasons(verdict), item.Summary),
+		Payload: payload,
+	}
+	if item.DedupKey != "" {
+		held.DedupKey = SourceModeration + "|" + item.DedupKey
+	}
+	if created, err = s.review.Enqueue(ctx, held); err != nil {
+		return false, false, err
+	}
+
+	s.logger.Info("Submission quarantined",
+		zap.String("source", item.Source),
+		zap.String("kind", item.Kind),
+		zap.Strings("matches", verdict.Matches),
+		zap.Strings("categories", verdict.Categories))
+	return created, true, nil
+}
+
+// release queues an approved quarantined submission for its usual review
+func (s *Service) release(ctx context.Context, item *mongodb.ReviewItem, _ map[string]interface{}) error {
+	var held quarantine
+	if err := review.DecodePayload(item.Payload, &held); err != nil {
+		return err
+	}
+
+	_, err := s.review.Enqueue(ctx, &mongodb.ReviewItem{
+		Source:      held.Source,
+		Kind:        held.Kind,
+		Summary:     held.Summary,
+		DedupKey:    held.DedupKey,
+		Payload:     held.Payload,
+		Suggestions: held.Suggestions,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Quarantined submission released",
+		zap.String("id", item.ID),
+		zap.String("kind", held.Kind),
+		zap.String("reviewer", item.ResolvedBy))
+	return nil
+}
+
+// reasons describes why a verdict flagged text, for the review queue listing
+func reasons(verdict Verdict) string {
+	switch {
+	case len(verdict.Matches) > 0:
+		return strings.Join(verdict.Matches, ", ")
+	case len(verdict.Categories) > 0:
+		return strings.Join(verdict.Categories, ", ")
+	case verdict.Reason != "":
+		return verdict.Reason
+	default:
+		return "flagged by moderation"
+	}
+}
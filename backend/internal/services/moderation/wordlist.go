@@ -0,0 +1,128 @@
+package moderation
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"strings"
+	"unicode"
+)
+
+//go:embed wordlists/en.txt
+var builtinWordlist string
+
+// substitutions undoes letters commonly swapped for look-alike characters to
+// slip past word filters
+var substitutions = strings.NewReplacer(
+	"@", "a", "4", "a", "3", "e", "1", "i", "!", "i", "0", "o", "$", "s", "5", "s", "7", "t",
+)
+
+// wordlist matches blocked words and phrases against the words of a text
+type wordlist struct {
+	words    map[string]bool
+	prefixes []string
+	phrases  [][]string
+}
+
+func newWordlist() *wordlist {
+	return &wordlist{words: make(map[string]bool)}
+}
+
+// read adds the entries of a wordlist file: one word or phrase per line, with
+// blank lines and lines starting with # skipped
+func (w *wordlist) read(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		w.add(line)
+	}
+	return scanner.Err()
+}
+
+// add adds a word or phrase. A single word ending in * also blocks the words
+// it starts.
+func (w *wordlist) add(entry string) {
+	prefix := strings.HasSuffix(entry, "*")
+	tokens := tokenize(strings.TrimSuffix(entry, "*"))
+	switch {
+	case len(tokens) == 0:
+	case len(tokens) > 1:
+		w.phrases = append(w.phrases, tokens)
+	case prefix:
+		w.prefixes = append(w.prefixes, tokens[0])
+	default:
+		w.words[tokens[0]] = true
+	}
+}
+
+// size is the number of entries in the list
+func (w *wordlist) size() int {
+	return len(w.words) + len(w.prefixes) + len(w.phrases)
+}
+
+// match returns the blocked words and phrases found in text, once each
+func (w *wordlist) match(text string) []string {
+	tokens := tokenize(text)
+
+	var matches []string
+	seen := make(map[string]bool)
+	found := func(match string) {
+		if !seen[match] {
+			seen[match] = true
+			matches = append(matches, match)
+		}
+	}
+
+	for i, token := range tokens {
+		if w.words[token] {
+			found(token)
+		}
+		for _, prefix := range w.prefixes {
+			if strings.HasPrefix(token, prefix) {
+				found(token)
+			}
+		}
+		for _, phrase := range w.phrases {
+			if hasPhrase(tokens[i:], phrase) {
+				found(strings.Join(phrase, " "))
+			}
+		}
+	}
+	return matches
+}
+
+func hasPhrase(tokens, phrase []string) bool {
+	if len(tokens) < len(phrase) {
+		return false
+	}
+	for i, word := range phrase {
+		if tokens[i] != word {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenize lowercases text, undoes look-alike substitutions and splits it into
+// words. Substitutions are undone within words only, so numbers such as "2025"
+// and exclamation marks ending a word are left alone.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r) && !strings.ContainsRune("@$!", r)
+	})
+
+	tokens := fields[:0]
+	for _, field := range fields {
+		field = strings.Trim(field, "!")
+		if strings.IndexFunc(field, unicode.IsLetter) >= 0 {
+			field = substitutions.Replace(field)
+		}
+		if field != "" {
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,83 @@
+package moodle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxResponseSize bounds web service responses read into memory
+const maxResponseSize = 10 << 20
+
+// wsClient calls a Moodle site's REST web service with one token
+type wsClient struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+func newWSClient(siteURL, token string, httpClient *http.Client) *wsClient {
+	return &wsClient{
+		endpoint:   strings.TrimRight(siteURL, "/") + "/webservice/rest/server.php",
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// wsException is the body Moodle answers failed calls with, still with status 200
+type wsException struct {
+	Exception string `json:"exception"`
+	ErrorCode string `json:"errorcode"`
+	Message   string `json:"message"`
+}
+
+// call runs a web service function with form-encoded params, decoding the JSON
+// result into out when it is not nil
+func (c *wsClient) call(ctx context.Context, function string, params url.Values, out any) error {
+	form := url.Values{}
+	for key, values := range params {
+		form[key] = values
+	}
+	form.Set("wstoken", c.token)
+	form.Set("wsfunction", function)
+	form.Set("moodlewsrestformat", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMoodleUnavailable, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// The error names the URL only, the token is in the body
+		return fmt.Errorf("%w: %v", ErrMoodleUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("%w: failed to read %s response: %v", ErrMoodleUnavailable, function, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s returned status %d", ErrMoodleUnavailable, function, resp.StatusCode)
+	}
+
+	var exception wsException
+	if json.Unmarshal(body, &exception) == nil && exception.Exception != "" {
+		return fmt.Errorf("%w: %s: %s (%s)", ErrMoodleRejected, function, exception.Message, exception.ErrorCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("%w: unexpected %s response: %v", ErrMoodleUnavailable, function, err)
+	}
+	return nil
+}
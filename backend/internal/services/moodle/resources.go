@@ -0,0 +1,119 @@
+package moodle
+
+import (
+	"context"
+	"errors"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/services/resources"
+	"go.uber.org/zap"
+)
+
+// maxSummaryLength caps course summaries shown with a resource
+const maxSummaryLength = 300
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+func init() {
+	resources.Register("moodle", newResourceProvider)
+}
+
+// resourceProvider suggests courses from a local Moodle-based e-learning site
+// for roadmap topics, using core_course_search_courses. Settings: url and token
+// (RESOURCE_MOODLE_URL and RESOURCE_MOODLE_TOKEN), and optionally the language
+// its courses are taught in.
+type resourceProvider struct {
+	siteURL  string
+	language string
+	ws       *wsClient
+}
+
+func newResourceProvider(settings map[string]string, logger *zap.Logger) (resources.Provider, error) {
+	siteURL := strings.TrimRight(settings["url"], "/")
+	if parsed, err := url.Parse(siteURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, errors.New("url must be an http(s) URL")
+	}
+	if settings["token"] == "" {
+		return nil, errors.New("token is required")
+	}
+
+	return &resourceProvider{
+		siteURL:  siteURL,
+		language: settings["language"],
+		ws:       newWSClient(siteURL, settings["token"], &http.Client{Timeout: 10 * time.Second}),
+	}, nil
+}
+
+func (p *resourceProvider) Name() string {
+	return "moodle"
+}
+
+// Search returns the site's courses matching a topic
+func (p *resourceProvider) Search(ctx context.Context, topic string, limit int) ([]resources.Resource, error) {
+	var result struct {
+		Courses []struct {
+			ID       int    `json:"id"`
+			FullName string `json:"fullname"`
+			Summary  string `json:"summary"`
+			// Enrolment plugins enabled on the course, e.g. "self" or "fee"
+			EnrollmentMethods []string `json:"enrollmentmethods"`
+		} `json:"courses"`
+	}
+	params := url.Values{
+		"criterianame":  {"search"},
+		"criteriavalue": {topic},
+		"page":          {"0"},
+		"perpage":       {strconv.Itoa(limit)},
+	}
+	if err := p.ws.call(ctx, "core_course_search_courses", params, &result); err != nil {
+		return nil, err
+	}
+
+	found := make([]resources.Resource, 0, len(result.Courses))
+	for _, course := range result.Courses {
+		if len(found) == limit {
+			break
+		}
+		found = append(found, resources.Resource{
+			Provider:    p.Name(),
+			Kind:        resources.KindCourse,
+			Title:       course.FullName,
+			URL:         p.siteURL + "/course/view.php?id=" + strconv.Itoa(course.ID),
+			Description: plainSummary(course.Summary),
+			Language:    p.language,
+			Pricing:     coursePricing(course.EnrollmentMethods),
+		})
+	}
+	return found, nil
+}
+
+// coursePricing tells from a course's enrolment methods whether taking it is
+// paid. Moodle does not report fees in course searches, so paid courses carry
+// no price.
+func coursePricing(methods []string) string {
+	pricing := resources.PricingUnknown
+	for _, method := range methods {
+		switch method {
+		case "fee", "paypal":
+			return resources.PricingPaid
+		case "self", "guest":
+			pricing = resources.PricingFree
+		}
+	}
+	return pricing
+}
+
+// plainSummary turns a course's HTML summary into shortened plain text
+func plainSummary(summary string) string {
+	text := strings.Join(strings.Fields(html.UnescapeString(tagPattern.ReplaceAllString(summary, " "))), " ")
+	if runes := []rune(text); len(runes) > maxSummaryLength {
+		text = strings.TrimSpace(string(runes[:maxSummaryLength])) + "…"
+	}
+	return text
+}
@@ -0,0 +1,417 @@
+// Package moodle exports learning roadmaps to partner schools' Moodle sites as
+// courses: one section per roadmap step, named after it, with the step's videos
+// linked from the section summary.
+package moodle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultCategoryID is Moodle's built-in "Miscellaneous" course category
+	defaultCategoryID = 1
+
+	// defaultCoursePrefix starts exported course short names unless a partner
+	// sets its own
+	defaultCoursePrefix = "pathwaylk"
+
+	// maxShortNameLength keeps short names well inside Moodle's 255 characters
+	maxShortNameLength = 100
+)
+
+var (
+	// ErrPartnerNotFound is returned for partners that are not configured
+	ErrPartnerNotFound = errors.New("moodle partner not found")
+
+	// ErrInvalidPartner is returned for partner configurations that cannot be used
+	ErrInvalidPartner = errors.New("invalid moodle partner")
+
+	// ErrProgramNotFound is returned when exporting a program not in the graph
+	ErrProgramNotFound = errors.New("program not found")
+
+	// ErrMoodleUnavailable is returned when a Moodle site cannot be reached or
+	// answers with something other than a web service result
+	ErrMoodleUnavailable = errors.New("moodle site unavailable")
+
+	// ErrMoodleRejected is returned when a Moodle site refuses a web service
+	// call, e.g. for a wrong token or a function the token may not use
+	ErrMoodleRejected = errors.New("moodle rejected the request")
+)
+
+var (
+	partnerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+	prefixPattern      = regexp.MustCompile(`^[A-Za-z0-9_-]{1,30}$`)
+	nonSlugPattern     = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// ExportReport is the outcome of exporting a roadmap to a partner's site
+type ExportReport struct {
+	Partner   string `json:"partner"`
+	Program   string `json:"program"`
+	CourseID  int    `json:"course_id"`
+	ShortName string `json:"short_name"`
+	CourseURL string `json:"course_url"`
+	Created   bool   `json:"created"` // false when an earlier export was updated
+	Sections  int    `json:"sections"`
+	// Videos linked from section summaries; zero unless the partner has section
+	// summaries enabled
+	Videos   int      `json:"videos"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Service manages Moodle partners and exports roadmaps to their sites
+type Service struct {
+	partners    *mongodb.MoodlePartnerStore
+	pathway     *pathway.Service
+	neo4jClient *neo4j.Client
+	httpClient  *http.Client
+	logger      *zap.Logger
+}
+
+// NewService creates a new Moodle export service
+func NewService(neo4jClient *neo4j.Client, mongoClient *mongodb.Client, pathwayService *pathway.Service, cfg config.MoodleConfig, logger *zap.Logger) *Service {
+	return &Service{
+		partners:    mongodb.NewMoodlePartnerStore(mongoClient, logger),
+		pathway:     pathwayService,
+		neo4jClient: neo4jClient,
+		httpClient:  &http.Client{Timeout: cfg.Timeout},
+		logger:      logger,
+	}
+}
+
+// SavePartner validates and stores a partner. The token is required for new
+// partners; leaving it out on an update keeps the stored one. The saved partner
+// is returned without its token.
+func (s *Service) SavePartner(ctx context.Context, partner *mongodb.MoodlePartner) error {
+	s.logger.Debug("Saving Moodle partner", zap.String("name", partner.Name))
+
+	partner.Name = strings.ToLower(strings.TrimSpace(partner.Name))
+	if !partnerNamePattern.MatchString(partner.Name) {
+		return fmt.Errorf("%w: name must be lowercase letters, digits, '-' or '_'", ErrInvalidPartner)
+	}
+
+	partner.SiteURL = strings.TrimRight(strings.TrimSpace(partner.SiteURL), "/")
+	site, err := url.Parse(partner.SiteURL)
+	if err != nil || (site.Scheme != "http" && site.Scheme != "https") || site.Host == "" {
+		return fmt.Errorf("%w: site_url must be an http or https URL", ErrInvalidPartner)
+	}
+	if strings.Contains(site.Path, "/webservice") {
+		return fmt.Errorf("%w: site_url must be the site root, without /webservice", ErrInvalidPartner)
+	}
+
+	partner.Token = strings.TrimSpace(partner.Token)
+	if partner.Token == "" {
+		existing, err := s.partners.Get(ctx, partner.Name)
+		if err != nil {
+			return err
+		}
+		if existing == nil || existing.Token == "" {
+			return fmt.Errorf("%w: token is required", ErrInvalidPartner)
+		}
+	}
+
+	if partner.CategoryID <= 0 {
+		partner.CategoryID = defaultCategoryID
+	}
+	if partner.CoursePrefix == "" {
+		partner.CoursePrefix = defaultCoursePrefix
+	}
+	if !prefixPattern.MatchString(partner.CoursePrefix) {
+		return fmt.Errorf("%w: course_prefix must be up to 30 letters, digits, '-' or '_'", ErrInvalidPartner)
+	}
+
+	if err := s.partners.Save(ctx, partner); err != nil {
+		return err
+	}
+
+	s.logger.Info("Moodle partner saved",
+		zap.String("name", partner.Name),
+		zap.String("site_url", partner.SiteURL))
+
+	partner.Token = ""
+	partner.HasToken = true
+	return nil
+}
+
+// ListPartners returns all partners, without their tokens
+func (s *Service) ListPartners(ctx context.Context) ([]mongodb.MoodlePartner, error) {
+	partners, err := s.partners.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range partners {
+		partners[i].HasToken = partners[i].Token != ""
+		partners[i].Token = ""
+	}
+	return partners, nil
+}
+
+// Export creates or updates the partner's course for a program's learning
+// roadmap, generating the roadmap first if it is not cached. The course is found
+// again on later exports by its short name, so re-exporting updates it in place.
+//
+// Core Moodle web services cannot add activities to a course, so the step
+// details and video links go into section summaries. Writing those needs the
+// local_wsmanagesections plugin, enabled per partner with section_summaries;
+// without it only the course and its section names are exported.
+func (s *Service) Export(ctx context.Context, partnerName, programName string) (*ExportReport, error) {
+	s.logger.Debug("Exporting roadmap to Moodle",
+		zap.String("partner", partnerName),
+		zap.String("program", programName))
+
+	partner, err := s.partners.Get(ctx, partnerName)
+	if err != nil {
+		return nil, err
+	}
+	if partner == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPartnerNotFound, partnerName)
+	}
+
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, []string{programName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check program: %w", err)
+	}
+	if !existing[programName] {
+		return nil, fmt.Errorf("%w: %q", ErrProgramNotFound, programName)
+	}
+
+	roadmap, err := s.pathway.GetLearningRoadmap(ctx, programName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get learning roadmap: %w", err)
+	}
+
+	ws := newWSClient(partner.SiteURL, partner.Token, s.httpClient)
+	report := &ExportReport{
+		Partner:   partner.Name,
+		Program:   programName,
+		ShortName: shortName(partner.CoursePrefix, programName),
+	}
+
+	courseID, format, err := s.upsertCourse(ctx, ws, partner, roadmap, report)
+	if err != nil {
+		return nil, err
+	}
+	report.CourseID = courseID
+	report.CourseURL = partner.SiteURL + "/course/view.php?id=" + strconv.Itoa(courseID)
+
+	sections, err := courseSections(ctx, ws, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sections are numbered from 1; section 0 is the course's general section
+	for _, step := range roadmap.Steps {
+		sectionID, ok := sections[step.StepNumber]
+		if !ok {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("course has no section %d for step %q", step.StepNumber, step.Title))
+			continue
+		}
+		params := url.Values{}
+		params.Set("component", "format_"+format)
+		params.Set("itemtype", "sectionname")
+		params.Set("itemid", strconv.Itoa(sectionID))
+		params.Set("value", step.Title)
+		if err := ws.call(ctx, "core_update_inplace_editable", params, nil); err != nil {
+			return nil, fmt.Errorf("failed to name section %d: %w", step.StepNumber, err)
+		}
+		report.Sections++
+	}
+
+	if partner.SectionSummaries {
+		videos, err := writeSectionSummaries(ctx, ws, courseID, roadmap.Steps)
+		if err != nil {
+			return nil, err
+		}
+		report.Videos = videos
+	} else {
+		report.Warnings = append(report.Warnings, "section summaries are disabled for this partner, so step details and videos were not exported")
+	}
+
+	if err := s.partners.MarkExported(ctx, partner.Name, time.Now()); err != nil {
+		s.logger.Warn("Failed to record Moodle export",
+			zap.String("partner", partner.Name),
+			zap.Error(err))
+	}
+
+	s.logger.Info("Roadmap exported to Moodle",
+		zap.String("partner", partner.Name),
+		zap.String("program", programName),
+		zap.Int("course_id", courseID),
+		zap.Bool("created", report.Created),
+		zap.Int("sections", report.Sections),
+		zap.Int("videos", report.Videos))
+	return report, nil
+}
+
+// upsertCourse creates the program's course, or updates the one an earlier
+// export created, returning its ID and course format
+func (s *Service) upsertCourse(ctx context.Context, ws *wsClient, partner *mongodb.MoodlePartner, roadmap *pathway.LearningRoadmapResponse, report *ExportReport) (int, string, error) {
+	var found struct {
+		Courses []struct {
+			ID     int    `json:"id"`
+			Format string `json:"format"`
+		} `json:"courses"`
+	}
+	lookup := url.Values{}
+	lookup.Set("field", "shortname")
+	lookup.Set("value", report.ShortName)
+	if err := ws.call(ctx, "core_course_get_courses_by_field", lookup, &found); err != nil {
+		return 0, "", fmt.Errorf("failed to look up course: %w", err)
+	}
+
+	numSections := strconv.Itoa(len(roadmap.Steps))
+	if len(found.Courses) > 0 {
+		course := found.Courses[0]
+		params := url.Values{}
+		params.Set("courses[0][id]", strconv.Itoa(course.ID))
+		params.Set("courses[0][fullname]", roadmap.ProgramName)
+		params.Set("courses[0][summary]", courseSummary(roadmap))
+		params.Set("courses[0][summaryformat]", "1")
+		params.Set("courses[0][courseformatoptions][0][name]", "numsections")
+		params.Set("courses[0][courseformatoptions][0][value]", numSections)
+		if err := ws.call(ctx, "core_course_update_courses", params, nil); err != nil {
+			return 0, "", fmt.Errorf("failed to update course: %w", err)
+		}
+		return course.ID, course.Format, nil
+	}
+
+	params := url.Values{}
+	params.Set("courses[0][fullname]", roadmap.ProgramName)
+	params.Set("courses[0][shortname]", report.ShortName)
+	params.Set("courses[0][categoryid]", strconv.Itoa(partner.CategoryID))
+	params.Set("courses[0][summary]", courseSummary(roadmap))
+	params.Set("courses[0][summaryformat]", "1")
+	params.Set("courses[0][format]", "topics")
+	params.Set("courses[0][courseformatoptions][0][name]", "numsections")
+	params.Set("courses[0][courseformatoptions][0][value]", numSections)
+	var created []struct {
+		ID int `json:"id"`
+	}
+	if err := ws.call(ctx, "core_course_create_courses", params, &created); err != nil {
+		return 0, "", fmt.Errorf("failed to create course: %w", err)
+	}
+	if len(created) == 0 {
+		return 0, "", fmt.Errorf("%w: core_course_create_courses returned no course", ErrMoodleUnavailable)
+	}
+	report.Created = true
+	return created[0].ID, "topics", nil
+}
+
+// courseSections maps a course's section numbers to their IDs
+func courseSections(ctx context.Context, ws *wsClient, courseID int) (map[int]int, error) {
+	var contents []struct {
+		ID      int `json:"id"`
+		Section int `json:"section"`
+	}
+	params := url.Values{}
+	params.Set("courseid", strconv.Itoa(courseID))
+	params.Set("options[0][name]", "excludemodules")
+	params.Set("options[0][value]", "1")
+	if err := ws.call(ctx, "core_course_get_contents", params, &contents); err != nil {
+		return nil, fmt.Errorf("failed to read course sections: %w", err)
+	}
+
+	sections := make(map[int]int, len(contents))
+	for _, section := range contents {
+		sections[section.Section] = section.ID
+	}
+	return sections, nil
+}
+
+// writeSectionSummaries sets each step's section summary through the
+// local_wsmanagesections plugin, returning the number of videos linked
+func writeSectionSummaries(ctx context.Context, ws *wsClient, courseID int, steps []pathway.LearningStepWithVideos) (int, error) {
+	params := url.Values{}
+	params.Set("courseid", strconv.Itoa(courseID))
+	videos := 0
+	for i, step := range steps {
+		prefix := "sections[" + strconv.Itoa(i) + "]"
+		params.Set(prefix+"[type]", "num")
+		params.Set(prefix+"[section]", strconv.Itoa(step.StepNumber))
+		params.Set(prefix+"[summary]", stepSummary(step))
+		params.Set(prefix+"[summaryformat]", "1")
+		videos += len(step.Videos)
+	}
+	if err := ws.call(ctx, "local_wsmanagesections_update_sections", params, nil); err != nil {
+		return 0, fmt.Errorf("failed to write section summaries: %w", err)
+	}
+	return videos, nil
+}
+
+// courseSummary describes the whole roadmap in HTML
+func courseSummary(roadmap *pathway.LearningRoadmapResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<p>%s</p>", html.EscapeString(roadmap.Overview))
+	if roadmap.TotalDuration != "" {
+		fmt.Fprintf(&b, "<p><strong>Duration:</strong> %s</p>", html.EscapeString(roadmap.TotalDuration))
+	}
+	if len(roadmap.Prerequisites) > 0 {
+		fmt.Fprintf(&b, "<p><strong>Prerequisites:</strong> %s</p>", html.EscapeString(strings.Join(roadmap.Prerequisites, ", ")))
+	}
+	if len(roadmap.KeySkills) > 0 {
+		fmt.Fprintf(&b, "<p><strong>Key skills:</strong> %s</p>", html.EscapeString(strings.Join(roadmap.KeySkills, ", ")))
+	}
+	b.WriteString("<p><em>Exported from PathwayLK.</em></p>")
+	return b.String()
+}
+
+// stepSummary describes a step and links its videos in HTML
+func stepSummary(step pathway.LearningStepWithVideos) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<p>%s</p>", html.EscapeString(step.Description))
+	if step.Duration != "" || step.Difficulty != "" {
+		fmt.Fprintf(&b, "<p><strong>Duration:</strong> %s &middot; <strong>Level:</strong> %s</p>",
+			html.EscapeString(step.Duration), html.EscapeString(step.Difficulty))
+	}
+	if len(step.Topics) > 0 {
+		b.WriteString("<p><strong>Topics</strong></p><ul>")
+		for _, topic := range step.Topics {
+			fmt.Fprintf(&b, "<li>%s</li>", html.EscapeString(topic))
+		}
+		b.WriteString("</ul>")
+	}
+	if len(step.Videos) > 0 {
+		b.WriteString("<p><strong>Videos</strong></p><ul>")
+		for _, video := range step.Videos {
+			fmt.Fprintf(&b, `<li><a href="%s" target="_blank" rel="noopener">%s</a>`, html.EscapeString(video.URL), html.EscapeString(video.Title))
+			if video.Channel != "" {
+				fmt.Fprintf(&b, " (%s)", html.EscapeString(video.Channel))
+			}
+			b.WriteString("</li>")
+		}
+		b.WriteString("</ul>")
+	}
+	return b.String()
+}
+
+// shortName identifies a program's course on a partner's site
+func shortName(prefix, programName string) string {
+	slug := strings.Trim(nonSlugPattern.ReplaceAllString(strings.ToLower(programName), "-"), "-")
+	if slug == "" {
+		// Names in Sinhala or Tamil have no ASCII letters to keep
+		hash := fnv.New32a()
+		hash.Write([]byte(programName))
+		slug = fmt.Sprintf("%08x", hash.Sum32())
+	}
+	name := prefix + "-" + slug
+	if len(name) > maxShortNameLength {
+		name = strings.TrimRight(name[:maxShortNameLength], "-")
+	}
+	return name
+}
@@ -0,0 +1,337 @@
+// Package outcomes keeps what became of programs' graduates, from graduate surveys
+// ingested by admins and from graduates reporting for themselves, and rates each
+// program's employability from them.
+package outcomes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+const (
+	// SourceSelfReported marks outcomes reported by graduates themselves
+	SourceSelfReported = "self_reported"
+
+	// minSelfReports is how many self-reports a program needs before they are
+	// summarized; fewer say little and could identify the graduates
+	minSelfReports = 5
+
+	// minGraduationYear is the earliest graduation year outcomes are accepted for
+	minGraduationYear = 1990
+
+	// maxMonthsToEmployment bounds the months to a first job
+	maxMonthsToEmployment = 120
+
+	// maxFirstJobs is how many typical first jobs are listed
+	maxFirstJobs = 5
+
+	// fastEmployment is how many months to a first job still earns part of the
+	// employability score for speed
+	fastEmployment = 12.0
+)
+
+var (
+	// ErrProgramNotFound is returned for outcomes of a program not in the graph
+	ErrProgramNotFound = errors.New("program not found")
+
+	// ErrInvalidOutcome is returned for outcomes or reports out of range
+	ErrInvalidOutcome = errors.New("invalid outcome")
+)
+
+// Report is a graduate's account of finding work after a program
+type Report struct {
+	GraduationYear     int    `json:"graduation_year" binding:"required"`
+	Employed           bool   `json:"employed"`
+	MonthsToEmployment *int   `json:"months_to_employment"`
+	FirstJob           string `json:"first_job"`
+}
+
+// ProgramReport is a program's outcome summary and the surveys it draws on
+type ProgramReport struct {
+	Program  string                   `json:"program"`
+	Summary  *neo4j.ProgramOutcomes   `json:"summary,omitempty"`
+	Surveys  []mongodb.ProgramOutcome `json:"surveys"`
+	Reported int                      `json:"self_reports"`
+}
+
+// Service stores and summarizes graduate outcomes
+type Service struct {
+	neo4jClient *neo4j.Client
+	store       *mongodb.ProgramOutcomeStore
+	logger      *zap.Logger
+}
+
+// NewService creates a new outcomes service
+func NewService(neo4jClient *neo4j.Client, mongoClient *mongodb.Client, logger *zap.Logger) *Service {
+	return &Service{
+		neo4jClient: neo4jClient,
+		store:       mongodb.NewProgramOutcomeStore(mongoClient, logger),
+		logger:      logger,
+	}
+}
+
+// SaveSurveys records survey outcomes of a program for one or more graduation
+// years, replacing earlier figures for the same years
+func (s *Service) SaveSurveys(ctx context.Context, programName string, surveys []mongodb.ProgramOutcome) ([]mongodb.ProgramOutcome, error) {
+	s.logger.Debug("Saving program outcomes", zap.String("program", programName), zap.Int("years", len(surveys)))
+
+	if len(surveys) == 0 {
+		return nil, fmt.Errorf("%w: at least one survey is required", ErrInvalidOutcome)
+	}
+
+	seen := make(map[int]bool, len(surveys))
+	for i := range surveys {
+		survey := &surveys[i]
+		if err := validateSurvey(survey); err != nil {
+			return nil, err
+		}
+		if seen[survey.Year] {
+			return nil, fmt.Errorf("%w: year %d is listed twice", ErrInvalidOutcome, survey.Year)
+		}
+		seen[survey.Year] = true
+		survey.ProgramName = programName
+		survey.Source = strings.TrimSpace(survey.Source)
+		survey.FirstJobs = normalizeJobs(survey.FirstJobs)
+	}
+
+	if err := s.checkProgram(ctx, programName); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Upsert(ctx, surveys); err != nil {
+		s.logger.Error("Failed to save program outcomes",
+			zap.String("program", programName),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Program outcomes saved",
+		zap.String("program", programName),
+		zap.Int("years", len(surveys)))
+	return s.store.ListByProgram(ctx, programName)
+}
+
+// SaveReport records a graduate's own outcome, replacing their earlier report on
+// the same program
+func (s *Service) SaveReport(ctx context.Context, userID, programName string, report Report) error {
+	maxYear := time.Now().Year()
+	switch {
+	case report.GraduationYear < minGraduationYear || report.GraduationYear > maxYear:
+		return fmt.Errorf("%w: graduation_year must be between %d and %d", ErrInvalidOutcome, minGraduationYear, maxYear)
+	case report.MonthsToEmployment != nil && !report.Employed:
+		return fmt.Errorf("%w: months_to_employment is only given when employed", ErrInvalidOutcome)
+	case report.MonthsToEmployment != nil && (*report.MonthsToEmployment < 0 || *report.MonthsToEmployment > maxMonthsToEmployment):
+		return fmt.Errorf("%w: months_to_employment must be between 0 and %d", ErrInvalidOutcome, maxMonthsToEmployment)
+	}
+
+	if err := s.checkProgram(ctx, programName); err != nil {
+		return err
+	}
+
+	stored := &mongodb.OutcomeReport{
+		UserID:             userID,
+		ProgramName:        programName,
+		GraduationYear:     report.GraduationYear,
+		Employed:           report.Employed,
+		MonthsToEmployment: report.MonthsToEmployment,
+	}
+	if report.Employed {
+		stored.FirstJob = strings.Join(strings.Fields(report.FirstJob), " ")
+	}
+	if err := s.store.SaveReport(ctx, stored); err != nil {
+		return err
+	}
+
+	s.logger.Info("Graduate outcome reported",
+		zap.String("program", programName),
+		zap.Int("graduation_year", report.GraduationYear))
+	return nil
+}
+
+// ForProgram returns a program's outcome summary, or nil when too little is known
+func (s *Service) ForProgram(ctx context.Context, programName string) (*neo4j.ProgramOutcomes, error) {
+	surveys, err := s.store.ListByProgram(ctx, programName)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := s.store.ListReports(ctx, programName)
+	if err != nil {
+		return nil, err
+	}
+	return Summarize(surveys, reports), nil
+}
+
+// Report returns a program's outcome summary with the surveys behind it
+func (s *Service) Report(ctx context.Context, programName string) (*ProgramReport, error) {
+	if err := s.checkProgram(ctx, programName); err != nil {
+		return nil, err
+	}
+
+	surveys, err := s.store.ListByProgram(ctx, programName)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := s.store.ListReports(ctx, programName)
+	if err != nil {
+		return nil, err
+	}
+	if surveys == nil {
+		surveys = []mongodb.ProgramOutcome{}
+	}
+
+	return &ProgramReport{
+		Program:  programName,
+		Summary:  Summarize(surveys, reports),
+		Surveys:  surveys,
+		Reported: len(reports),
+	}, nil
+}
+
+// Summarize combines the latest survey of a program, newest year first, with
+// graduates' self-reports, weighting each by its respondents. Self-reports only
+// count once there are minSelfReports of them. It returns nil when neither is
+// usable.
+func Summarize(surveys []mongodb.ProgramOutcome, reports []mongodb.OutcomeReport) *neo4j.ProgramOutcomes {
+	if len(reports) < minSelfReports {
+		reports = nil
+	}
+	if len(surveys) == 0 && len(reports) == 0 {
+		return nil
+	}
+
+	summary := &neo4j.ProgramOutcomes{Sources: []string{}}
+	var employed, monthsTotal, monthsWeight float64
+	var jobs []string
+
+	if len(surveys) > 0 {
+		latest := surveys[0]
+		summary.Year = latest.Year
+		summary.Respondents = latest.Respondents
+		employed = latest.EmploymentRate * float64(latest.Respondents)
+		if latest.MonthsToEmployment > 0 {
+			weight := employed
+			monthsTotal += latest.MonthsToEmployment * weight
+			monthsWeight += weight
+		}
+		jobs = append(jobs, latest.FirstJobs...)
+		if latest.Source != "" {
+			summary.Sources = append(summary.Sources, latest.Source)
+		}
+	}
+
+	if len(reports) > 0 {
+		jobCounts := make(map[string]int)
+		for _, report := range reports {
+			summary.Respondents++
+			if !report.Employed {
+				continue
+			}
+			employed++
+			if report.MonthsToEmployment != nil {
+				monthsTotal += float64(*report.MonthsToEmployment)
+				monthsWeight++
+			}
+			if report.FirstJob != "" {
+				jobCounts[report.FirstJob]++
+			}
+		}
+		jobs = append(jobs, mostCommon(jobCounts)...)
+		summary.Sources = append(summary.Sources, SourceSelfReported)
+	}
+
+	if summary.Respondents > 0 {
+		summary.EmploymentRate = round(employed / float64(summary.Respondents))
+	}
+	if monthsWeight > 0 {
+		summary.MonthsToEmployment = math.Round(monthsTotal/monthsWeight*10) / 10
+	}
+	summary.TypicalFirstJobs = normalizeJobs(jobs)
+	if len(summary.TypicalFirstJobs) > maxFirstJobs {
+		summary.TypicalFirstJobs = summary.TypicalFirstJobs[:maxFirstJobs]
+	}
+	summary.EmployabilityScore = EmployabilityScore(summary.EmploymentRate, summary.MonthsToEmployment)
+	return summary
+}
+
+// EmployabilityScore rates a program from 0 to 100: 80 points for the share of
+// graduates employed and 20 for how soon they find work, with nothing for speed
+// beyond fastEmployment months. Without a known time to employment the score is
+// the employment rate alone.
+func EmployabilityScore(employmentRate, monthsToEmployment float64) int {
+	if monthsToEmployment <= 0 {
+		return int(math.Round(employmentRate * 100))
+	}
+	speed := math.Max(0, 1-monthsToEmployment/fastEmployment)
+	return int(math.Round(employmentRate*80 + speed*20))
+}
+
+func validateSurvey(survey *mongodb.ProgramOutcome) error {
+	maxYear := time.Now().Year()
+	switch {
+	case survey.Year < minGraduationYear || survey.Year > maxYear:
+		return fmt.Errorf("%w: year must be between %d and %d", ErrInvalidOutcome, minGraduationYear, maxYear)
+	case survey.Respondents <= 0:
+		return fmt.Errorf("%w: respondents for %d must be positive", ErrInvalidOutcome, survey.Year)
+	case survey.EmploymentRate < 0 || survey.EmploymentRate > 1:
+		return fmt.Errorf("%w: employment_rate for %d must be between 0 and 1", ErrInvalidOutcome, survey.Year)
+	case survey.MonthsToEmployment < 0 || survey.MonthsToEmployment > maxMonthsToEmployment:
+		return fmt.Errorf("%w: months_to_employment for %d must be between 0 and %d", ErrInvalidOutcome, survey.Year, maxMonthsToEmployment)
+	}
+	return nil
+}
+
+func (s *Service) checkProgram(ctx context.Context, programName string) error {
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, []string{programName})
+	if err != nil {
+		return err
+	}
+	if !existing[programName] {
+		return fmt.Errorf("%w: %q", ErrProgramNotFound, programName)
+	}
+	return nil
+}
+
+// mostCommon returns job titles by how often they are reported, then by name
+func mostCommon(counts map[string]int) []string {
+	jobs := make([]string, 0, len(counts))
+	for job := range counts {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if counts[jobs[i]] != counts[jobs[j]] {
+			return counts[jobs[i]] > counts[jobs[j]]
+		}
+		return jobs[i] < jobs[j]
+	})
+	return jobs
+}
+
+// normalizeJobs collapses whitespace and drops empty and repeated job titles,
+// ignoring case, keeping the first spelling
+func normalizeJobs(jobs []string) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		job = strings.Join(strings.Fields(job), " ")
+		key := strings.ToLower(job)
+		if job == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, job)
+	}
+	return normalized
+}
+
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
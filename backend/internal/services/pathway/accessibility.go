@@ -0,0 +1,49 @@
+package pathway
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"go.uber.org/zap"
+)
+
+// RebuildAccessibility recomputes which programs each qualification opens, so
+// pathways by qualification are looked up instead of walking prerequisite chains.
+// It returns the number of access edges stored.
+func (s *Service) RebuildAccessibility(ctx context.Context) (int, error) {
+	s.logger.Debug("Rebuilding program accessibility")
+
+	edges, err := s.neo4jClient.RebuildAccessibility(ctx)
+	if err != nil {
+		s.logger.Error("Failed to rebuild program accessibility", zap.Error(err))
+		return 0, fmt.Errorf("failed to rebuild program accessibility: %w", err)
+	}
+	return edges, nil
+}
+
+// refreshAccessibility stops using the access edges after changes to programs or
+// qualifications and rebuilds them in the background; until then pathways by
+// qualification are read by walking the graph
+func (s *Service) refreshAccessibility(changes []mongodb.GraphChange) {
+	affected := slices.ContainsFunc(changes, func(change mongodb.GraphChange) bool {
+		return change.Kind == neo4j.KindProgram || change.Kind == neo4j.KindQualification ||
+			change.Kind == changelog.KindGraph
+	})
+	if !affected {
+		return
+	}
+
+	s.neo4jClient.MarkAccessibilityStale()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if _, err := s.RebuildAccessibility(ctx); err != nil {
+			s.logger.Warn("Program accessibility left stale after graph changes", zap.Error(err))
+		}
+	}()
+}
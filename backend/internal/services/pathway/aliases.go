@@ -0,0 +1,61 @@
+package pathway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.uber.org/zap"
+)
+
+// qualificationAliases returns the names that qualification and subject
+// aliases stand for, by alias key, or nil when they cannot be loaded: aliases
+// only help match what students type, so requests go ahead without them
+func (s *Service) qualificationAliases(ctx context.Context) map[string]string {
+	aliases, err := cachedList(ctx, s.lists, listKeyQualificationAliases, func(ctx context.Context) (map[string]string, error) {
+		aliases, err := s.aliases.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[string]string, len(aliases))
+		for _, alias := range aliases {
+			names[alias.Key] = alias.Name
+		}
+		return names, nil
+	})
+	if err != nil {
+		s.logger.Warn("Failed to load qualification aliases", zap.Error(err))
+		return nil
+	}
+	return aliases
+}
+
+// resolveQualification returns the name a qualification or subject alias
+// stands for, or the name as given with its spacing normalized
+func (s *Service) resolveQualification(ctx context.Context, name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if resolved, ok := s.qualificationAliases(ctx)[mongodb.AliasKey(name)]; ok {
+		return resolved
+	}
+	return name
+}
+
+// resolveQualifications resolves aliases among names as resolveQualification
+// does, dropping blank names and those repeated once resolved
+func (s *Service) resolveQualifications(ctx context.Context, names []string) []string {
+	aliases := s.qualificationAliases(ctx)
+	resolved := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		if alias, ok := aliases[mongodb.AliasKey(name)]; ok {
+			name = alias
+		}
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		resolved = append(resolved, name)
+	}
+	return resolved
+}
@@ -0,0 +1,78 @@
+package pathway
+
+import (
+	"context"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// aptitudeTests converts stored admission tests to their place in program details
+func aptitudeTests(stored []mongodb.AptitudeTest) []neo4j.AptitudeTest {
+	if len(stored) == 0 {
+		return nil
+	}
+
+	tests := make([]neo4j.AptitudeTest, 0, len(stored))
+	for _, test := range stored {
+		converted := neo4j.AptitudeTest{
+			Name:        test.Name,
+			Kind:        test.Kind,
+			Description: test.Description,
+			Source:      test.Source,
+		}
+		for _, date := range test.Dates {
+			converted.Dates = append(converted.Dates, neo4j.TestDate{Label: date.Label, Date: date.Date})
+		}
+		for _, paper := range test.PastPapers {
+			converted.PastPapers = append(converted.PastPapers, neo4j.PastPaper{Title: paper.Title, Year: paper.Year, URL: paper.URL})
+		}
+		tests = append(tests, converted)
+	}
+	return tests
+}
+
+// attachAptitudeTests adds a program's admission tests to its details. Tests are
+// optional data, so failures are logged rather than returned.
+func (s *Service) attachAptitudeTests(ctx context.Context, details *neo4j.ProgramDetails) {
+	stored, err := s.aptitudeTests.ListByProgram(ctx, details.Name)
+	if err != nil {
+		s.logger.Warn("Failed to fetch aptitude tests",
+			zap.String("program", details.Name),
+			zap.Error(err))
+		return
+	}
+	details.AptitudeTests = aptitudeTests(stored)
+	details.RequiresAptitudeTest = len(details.AptitudeTests) > 0
+}
+
+// withAptitudeTests returns programs with their admission tests attached. The
+// programs are copied, as listings may be shared through the list cache.
+func (s *Service) withAptitudeTests(ctx context.Context, programs []neo4j.ProgramDetails) []neo4j.ProgramDetails {
+	if s.aptitudeTests == nil || len(programs) == 0 {
+		return programs
+	}
+
+	names := make([]string, 0, len(programs))
+	for _, program := range programs {
+		names = append(names, program.Name)
+	}
+
+	stored, err := s.aptitudeTests.ByPrograms(ctx, names)
+	if err != nil {
+		s.logger.Warn("Failed to fetch aptitude tests for programs", zap.Error(err))
+		return programs
+	}
+	if len(stored) == 0 {
+		return programs
+	}
+
+	withTests := make([]neo4j.ProgramDetails, len(programs))
+	copy(withTests, programs)
+	for i := range withTests {
+		withTests[i].AptitudeTests = aptitudeTests(stored[withTests[i].Name])
+		withTests[i].RequiresAptitudeTest = len(withTests[i].AptitudeTests) > 0
+	}
+	return withTests
+}
@@ -0,0 +1,123 @@
+package pathway
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// Competitiveness levels derived from applicants per seat
+const (
+	CompetitivenessHigh       = "competitive"
+	CompetitivenessModerate   = "moderate"
+	CompetitivenessAccessible = "accessible"
+
+	// Programs with at least this many applicants per seat are competitive; those
+	// with at most accessibleRatio are accessible
+	competitiveRatio = 4.0
+	accessibleRatio  = 1.5
+)
+
+// Competitiveness classifies a program by applicants per seat. It returns an
+// empty level when the intake or application count is unknown.
+func Competitiveness(intake, applications int) (float64, string) {
+	if intake <= 0 || applications <= 0 {
+		return 0, ""
+	}
+
+	ratio := math.Round(float64(applications)/float64(intake)*100) / 100
+	switch {
+	case ratio >= competitiveRatio:
+		return ratio, CompetitivenessHigh
+	case ratio <= accessibleRatio:
+		return ratio, CompetitivenessAccessible
+	}
+	return ratio, CompetitivenessModerate
+}
+
+// programCapacity summarizes intake history, newest year first, or returns nil
+// when there is none
+func programCapacity(intakes []mongodb.ProgramIntake) *neo4j.ProgramCapacity {
+	if len(intakes) == 0 {
+		return nil
+	}
+
+	latest := intakes[0]
+	capacity := &neo4j.ProgramCapacity{
+		Year:         latest.Year,
+		AnnualIntake: latest.Intake,
+		Applications: latest.Applications,
+		History:      make([]neo4j.IntakeYear, 0, len(intakes)),
+	}
+	capacity.ApplicantsPerSeat, capacity.Competitiveness = Competitiveness(latest.Intake, latest.Applications)
+
+	for _, intake := range intakes {
+		capacity.History = append(capacity.History, neo4j.IntakeYear{
+			Year:         intake.Year,
+			Intake:       intake.Intake,
+			Applications: intake.Applications,
+		})
+	}
+	return capacity
+}
+
+// attachCapacity adds intake data to program details. Intake data is optional, so
+// failures are logged rather than returned.
+func (s *Service) attachCapacity(ctx context.Context, details *neo4j.ProgramDetails) {
+	intakes, err := s.intakes.ListByProgram(ctx, details.Name)
+	if err != nil {
+		s.logger.Warn("Failed to fetch program intakes",
+			zap.String("program", details.Name),
+			zap.Error(err))
+		return
+	}
+	details.Capacity = programCapacity(intakes)
+}
+
+// rankByCompetitiveness labels related programs with their competitiveness and
+// re-ranks them so accessible programs move ahead of equally related competitive
+// ones. At most limit programs are returned.
+func (s *Service) rankByCompetitiveness(ctx context.Context, related []neo4j.RelatedEntity, limit int) []neo4j.RelatedEntity {
+	names := make([]string, 0, len(related))
+	for _, entity := range related {
+		names = append(names, entity.Name)
+	}
+
+	latest, err := s.intakes.LatestByPrograms(ctx, names)
+	if err != nil {
+		s.logger.Warn("Failed to fetch program intakes for ranking", zap.Error(err))
+		latest = nil
+	}
+
+	for i := range related {
+		intake, ok := latest[related[i].Name]
+		if !ok {
+			continue
+		}
+		_, related[i].Competitiveness = Competitiveness(intake.Intake, intake.Applications)
+		switch related[i].Competitiveness {
+		case CompetitivenessAccessible:
+			related[i].Score++
+		case CompetitivenessHigh:
+			if related[i].Score > 1 {
+				related[i].Score--
+			}
+		}
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].Name < related[j].Name
+	})
+
+	if len(related) > limit {
+		related = related[:limit]
+	}
+	return related
+}
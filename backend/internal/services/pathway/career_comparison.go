@@ -0,0 +1,181 @@
+package pathway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"go.uber.org/zap"
+)
+
+const (
+	// Bounds on how many careers can be compared side by side
+	minCompareCareers = 2
+	maxCompareCareers = 4
+
+	// Sources of the job market data in a comparison entry
+	DetailsSourceCache       = "cache"
+	DetailsSourceGenerated   = "generated"
+	DetailsSourceUnavailable = "unavailable"
+
+	// Program context used when generating job details outside a specific program
+	generalCareerContext = "General career path"
+)
+
+// ErrInvalidCareerComparison is returned when too few or too many careers are compared
+var ErrInvalidCareerComparison = errors.New("between 2 and 4 distinct careers are required")
+
+// CareerComparison is a side-by-side matrix of careers
+type CareerComparison struct {
+	Careers  []CareerComparisonEntry `json:"careers"`
+	NotFound []string                `json:"not_found,omitempty"`
+}
+
+// CareerComparisonEntry holds the comparable attributes of one career
+type CareerComparisonEntry struct {
+	Title            string         `json:"title"`
+	Salary           llm.SalaryInfo `json:"salary"`
+	Demand           string         `json:"demand"`
+	GrowthProjection string         `json:"growth_projection"`
+	WorkType         string         `json:"work_type"`
+	RemoteOption     *bool          `json:"remote_option"`
+	EducationMonths  int            `json:"education_months"`
+	EducationLength  string         `json:"education_length"`
+	ShortestProgram  string         `json:"shortest_program"`
+	ProgramCount     int            `json:"program_count"`
+	Programs         []string       `json:"programs"`
+	DetailsSource    string         `json:"details_source"`
+}
+
+// CompareCareers builds a comparison matrix for two to four careers, combining
+// graph data with cached (or freshly generated) job market details
+func (s *Service) CompareCareers(ctx context.Context, careerTitles []string) (*CareerComparison, error) {
+	s.logger.Debug("Comparing careers", zap.Strings("careers", careerTitles))
+
+	titles := uniqueTitles(careerTitles)
+	if len(titles) < minCompareCareers || len(titles) > maxCompareCareers {
+		return nil, ErrInvalidCareerComparison
+	}
+
+	education, err := s.neo4jClient.GetCareerEducation(ctx, titles)
+	if err != nil {
+		s.logger.Error("Failed to get career education", zap.Error(err))
+		return nil, fmt.Errorf("failed to get career education: %w", err)
+	}
+
+	comparison := &CareerComparison{
+		Careers: make([]CareerComparisonEntry, 0, len(titles)),
+	}
+
+	// Keep the order the careers were requested in
+	byTitle := make(map[string]int, len(education))
+	for i, career := range education {
+		byTitle[strings.ToLower(career.Title)] = i
+	}
+	for _, title := range titles {
+		i, ok := byTitle[strings.ToLower(title)]
+		if !ok {
+			comparison.NotFound = append(comparison.NotFound, title)
+			continue
+		}
+
+		career := education[i]
+		entry := CareerComparisonEntry{
+			Title:        career.Title,
+			ProgramCount: len(career.Programs),
+			Programs:     []string{},
+		}
+		for _, program := range career.Programs {
+			entry.Programs = append(entry.Programs, program.Name)
+			months := EstimateProgramDurationMonths(program.Name)
+			if months > 0 && (entry.EducationMonths == 0 || months < entry.EducationMonths) {
+				entry.EducationMonths = months
+				entry.ShortestProgram = program.Name
+			}
+		}
+		entry.EducationLength = FormatDurationMonths(entry.EducationMonths)
+
+		comparison.Careers = append(comparison.Careers, entry)
+	}
+
+	// Fill in job market details concurrently - generation can be slow
+	var wg sync.WaitGroup
+	for i := range comparison.Careers {
+		wg.Add(1)
+		go func(entry *CareerComparisonEntry) {
+			defer wg.Done()
+			s.fillJobMarketDetails(ctx, entry)
+		}(&comparison.Careers[i])
+	}
+	wg.Wait()
+
+	s.logger.Info("Career comparison built",
+		zap.Int("careers", len(comparison.Careers)),
+		zap.Int("not_found", len(comparison.NotFound)))
+
+	return comparison, nil
+}
+
+// fillJobMarketDetails adds salary, demand and work environment data to an entry
+func (s *Service) fillJobMarketDetails(ctx context.Context, entry *CareerComparisonEntry) {
+	entry.DetailsSource = DetailsSourceUnavailable
+
+	var details *llm.JobRoleDetails
+	cachedData, found, err := s.jobRoleCache.GetAny(ctx, entry.Title)
+	if err != nil {
+		s.logger.Warn("Job role cache error during comparison",
+			zap.String("role", entry.Title),
+			zap.Error(err))
+	}
+	if found {
+		var cached llm.JobRoleDetails
+		if err := remarshal(cachedData, &cached); err == nil {
+			// Cached details hold the LLM's estimate, so job board figures
+			// replace it as they do for a single role
+			details = s.withMarketSalary(ctx, entry.Title, &cached)
+			entry.DetailsSource = DetailsSourceCache
+		}
+	}
+
+	if details == nil && s.llmClient != nil {
+		generated, err := s.GetJobRoleDetails(ctx, entry.Title, generalCareerContext)
+		if err != nil {
+			s.logger.Warn("Failed to generate job role details for comparison",
+				zap.String("role", entry.Title),
+				zap.Error(err))
+			return
+		}
+		details = generated
+		entry.DetailsSource = DetailsSourceGenerated
+	}
+
+	if details == nil {
+		return
+	}
+
+	entry.Salary = details.SalaryInfo
+	entry.Demand = details.LocalMarket.Demand
+	entry.GrowthProjection = details.LocalMarket.GrowthProjection
+	entry.WorkType = details.WorkEnvironment.Type
+	remote := details.WorkEnvironment.RemoteOption
+	entry.RemoteOption = &remote
+}
+
+// uniqueTitles trims titles and drops empty and case-insensitive duplicates
+func uniqueTitles(titles []string) []string {
+	seen := make(map[string]bool, len(titles))
+	var unique []string
+	for _, title := range titles {
+		title = strings.TrimSpace(title)
+		key := strings.ToLower(title)
+		if title == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, title)
+	}
+	return unique
+}
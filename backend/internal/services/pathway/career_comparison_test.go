@@ -0,0 +1,71 @@
+package pathway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// comparisonGraph serves career education for the comparison tests; any other
+// graph call panics through the nil embedded Graph
+type comparisonGraph struct {
+	Graph
+	education []neo4j.CareerEducation
+}
+
+func (g comparisonGraph) GetCareerEducation(ctx context.Context, careerTitles []string) ([]neo4j.CareerEducation, error) {
+	return g.education, nil
+}
+
+// cachedJobRoles is a job role cache holding details for every role
+type cachedJobRoles struct {
+	JobRoleCache
+	details map[string]interface{}
+}
+
+func (c cachedJobRoles) GetAny(ctx context.Context, roleName string) (map[string]interface{}, bool, error) {
+	return c.details, true, nil
+}
+
+func TestCompareCareersCacheHit(t *testing.T) {
+	graph := comparisonGraph{education: []neo4j.CareerEducation{
+		{Title: "Software Engineer"},
+		{Title: "Data Analyst"},
+	}}
+	service := NewService(graph, nil, nil, nil, 0, "", zap.NewNop())
+	service.UseCaches(nil, cachedJobRoles{details: map[string]interface{}{
+		"salary_info": map[string]interface{}{
+			"entry_level": "LKR 80,000 - 120,000",
+			"currency":    "LKR",
+		},
+		"local_market": map[string]interface{}{
+			"demand": "High",
+		},
+	}})
+
+	comparison, err := service.CompareCareers(context.Background(), []string{"Software Engineer", "Data Analyst"})
+	if err != nil {
+		t.Fatalf("CompareCareers() error = %v", err)
+	}
+	if len(comparison.Careers) != 2 {
+		t.Fatalf("got %d careers, want 2", len(comparison.Careers))
+	}
+
+	for _, entry := range comparison.Careers {
+		if entry.DetailsSource != DetailsSourceCache {
+			t.Errorf("%s: details source = %q, want %q", entry.Title, entry.DetailsSource, DetailsSourceCache)
+		}
+		if entry.Demand != "High" {
+			t.Errorf("%s: demand = %q, want %q", entry.Title, entry.Demand, "High")
+		}
+		// Without job board data the cached estimate is kept, and marked as one
+		if entry.Salary.EntryLevel != "LKR 80,000 - 120,000" {
+			t.Errorf("%s: entry level salary = %q, want the cached estimate", entry.Title, entry.Salary.EntryLevel)
+		}
+		if entry.Salary.Source != SalarySourceEstimate {
+			t.Errorf("%s: salary source = %q, want %q", entry.Title, entry.Salary.Source, SalarySourceEstimate)
+		}
+	}
+}
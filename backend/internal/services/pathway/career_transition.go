@@ -0,0 +1,148 @@
+package pathway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// Reasons a program suits a career switch
+	TransitionLeadsToBoth          = "leads_to_both"
+	TransitionBuildsOn             = "builds_on"
+	TransitionSharedQualifications = "shared_qualifications"
+	TransitionOpenEntry            = "open_entry"
+	TransitionConversionCourse     = "conversion_course"
+
+	// maxConversionMonths is the longest program counted as a conversion course,
+	// short enough to take alongside work or between jobs
+	maxConversionMonths = 18
+)
+
+// ErrInvalidTransition is returned for a transition without two distinct careers
+var ErrInvalidTransition = errors.New("two different careers are required")
+
+// TransitionOption is a program that helps someone in one career move into
+// another, with why it suits them and what they may still need to enter it
+type TransitionOption struct {
+	Program   string   `json:"program"`
+	Institute string   `json:"institute,omitempty"`
+	Score     float64  `json:"score"`
+	Reasons   []string `json:"reasons"`
+	// SharedQualifications are entry requirements also asked of the programs
+	// leading to the current career, which the person likely holds
+	SharedQualifications []string `json:"shared_qualifications"`
+	// MissingRequirements are the other entry requirements
+	MissingRequirements []string `json:"missing_requirements"`
+	BuildsOn            []string `json:"builds_on"`
+	DurationMonths      int      `json:"duration_months,omitempty"`
+	Duration            string   `json:"duration,omitempty"`
+}
+
+// CareerTransition lists the programs enabling a switch from one career to
+// another, most suitable first
+type CareerTransition struct {
+	From    string             `json:"from"`
+	To      string             `json:"to"`
+	Options []TransitionOption `json:"options"`
+}
+
+// GetCareerTransition finds programs for someone retraining from one career
+// into another. Programs leading to the target career rank higher the more they
+// connect to what the person already has: leading to both careers, building on
+// a program of the current career, or asking for the qualifications the
+// current career's programs ask for. Short certificate and diploma programs
+// rank higher as conversion courses an adult can take on.
+func (s *Service) GetCareerTransition(ctx context.Context, from, to string) (*CareerTransition, error) {
+	s.logger.Debug("Finding career transition", zap.String("from", from), zap.String("to", to))
+
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" || to == "" || strings.EqualFold(from, to) {
+		return nil, ErrInvalidTransition
+	}
+
+	data, err := s.neo4jClient.GetCareerTransition(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get career transition: %w", err)
+	}
+	suitable, err := s.workingStudentPrograms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transition := &CareerTransition{
+		From:    data.From,
+		To:      data.To,
+		Options: make([]TransitionOption, 0, len(data.TargetPrograms)),
+	}
+	for _, program := range data.TargetPrograms {
+		// Someone switching careers usually has to keep working meanwhile
+		if suitable != nil && !suitable[program.Name] {
+			continue
+		}
+		option := TransitionOption{
+			Program:              program.Name,
+			Institute:            program.Institute,
+			Reasons:              []string{},
+			SharedQualifications: []string{},
+			MissingRequirements:  []string{},
+			BuildsOn:             program.BuildsOn,
+			DurationMonths:       EstimateProgramDurationMonths(program.Name),
+		}
+		option.Duration = FormatDurationMonths(option.DurationMonths)
+
+		for _, requirement := range program.Requirements {
+			if slices.Contains(data.FromQualifications, requirement) {
+				option.SharedQualifications = append(option.SharedQualifications, requirement)
+			} else {
+				option.MissingRequirements = append(option.MissingRequirements, requirement)
+			}
+		}
+
+		if program.LeadsToBoth {
+			option.Score += 3
+			option.Reasons = append(option.Reasons, TransitionLeadsToBoth)
+		}
+		if len(program.BuildsOn) > 0 {
+			option.Score += 2
+			option.Reasons = append(option.Reasons, TransitionBuildsOn)
+		}
+		switch {
+		case len(program.Requirements) == 0:
+			option.Score += 1
+			option.Reasons = append(option.Reasons, TransitionOpenEntry)
+		case len(option.SharedQualifications) > 0:
+			option.Score += 2 * float64(len(option.SharedQualifications)) / float64(len(program.Requirements))
+			option.Reasons = append(option.Reasons, TransitionSharedQualifications)
+		}
+		if option.DurationMonths > 0 && option.DurationMonths <= maxConversionMonths {
+			option.Score += 1
+			option.Reasons = append(option.Reasons, TransitionConversionCourse)
+		}
+
+		transition.Options = append(transition.Options, option)
+	}
+
+	// Among equally suitable programs, the quickest to finish first
+	sort.SliceStable(transition.Options, func(i, j int) bool {
+		a, b := transition.Options[i], transition.Options[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.DurationMonths != b.DurationMonths {
+			return a.DurationMonths > 0 && (b.DurationMonths == 0 || a.DurationMonths < b.DurationMonths)
+		}
+		return a.Program < b.Program
+	})
+
+	s.logger.Info("Career transition found",
+		zap.String("from", transition.From),
+		zap.String("to", transition.To),
+		zap.Int("options", len(transition.Options)))
+	return transition, nil
+}
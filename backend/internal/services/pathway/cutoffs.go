@@ -0,0 +1,154 @@
+package pathway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// Directions of a district's cutoff between its two most recent years
+const (
+	CutoffRising  = "rising"
+	CutoffFalling = "falling"
+	CutoffStable  = "stable"
+
+	// stableCutoffChange is the largest year-on-year change still reported as stable
+	stableCutoffChange = 0.01
+)
+
+// ErrProgramNotFound is returned for cutoffs of a program not in the graph
+var ErrProgramNotFound = errors.New("program not found")
+
+// CutoffPoint is a program's cutoff in one academic year
+type CutoffPoint struct {
+	Year   int     `json:"year"`
+	ZScore float64 `json:"z_score"`
+}
+
+// DistrictCutoffTrend is the cutoff history of a program in one district, oldest
+// year first, with the change between the two most recent years
+type DistrictCutoffTrend struct {
+	District  string        `json:"district"`
+	Latest    CutoffPoint   `json:"latest"`
+	Change    *float64      `json:"change,omitempty"`
+	Direction string        `json:"direction,omitempty"`
+	History   []CutoffPoint `json:"history"`
+}
+
+// ProgramCutoffs is the z-score cutoff history of a program by district
+type ProgramCutoffs struct {
+	Program    string                `json:"program"`
+	CourseCode string                `json:"course_code,omitempty"`
+	LatestYear int                   `json:"latest_year,omitempty"`
+	Districts  []DistrictCutoffTrend `json:"districts"`
+}
+
+// GetZScoreCutoffs returns the cutoff trend of a program in every district it has
+// cutoffs for, or only in the given district
+func (s *Service) GetZScoreCutoffs(ctx context.Context, programName, district string) (*ProgramCutoffs, error) {
+	s.logger.Debug("Fetching z-score cutoffs",
+		zap.String("program", programName),
+		zap.String("district", district))
+
+	if programName == "" {
+		return nil, fmt.Errorf("program name is required")
+	}
+
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, []string{programName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check program: %w", err)
+	}
+	if !existing[programName] {
+		return nil, fmt.Errorf("%w: %q", ErrProgramNotFound, programName)
+	}
+
+	var cutoffs []mongodb.ZScoreCutoff
+	if district = strings.TrimSpace(district); district != "" {
+		cutoffs, err = s.cutoffs.ListByProgramDistrict(ctx, programName, district)
+	} else {
+		cutoffs, err = s.cutoffs.ListByProgram(ctx, programName)
+	}
+	if err != nil {
+		s.logger.Error("Failed to fetch z-score cutoffs",
+			zap.String("program", programName),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch z-score cutoffs: %w", err)
+	}
+
+	result := cutoffTrends(programName, cutoffs)
+	s.logger.Info("Successfully fetched z-score cutoffs",
+		zap.String("program", programName),
+		zap.Int("districts", len(result.Districts)))
+	return result, nil
+}
+
+// LatestCutoff returns the most recent cutoff a program admitted students from a
+// district with, or nil when none is recorded. This is the value eligibility
+// checks compare a student's z-score against.
+func (s *Service) LatestCutoff(ctx context.Context, programName, district string) (*mongodb.ZScoreCutoff, error) {
+	cutoff, err := s.cutoffs.Latest(ctx, programName, strings.TrimSpace(district))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest z-score cutoff: %w", err)
+	}
+	return cutoff, nil
+}
+
+// cutoffTrends groups cutoffs by district and derives each district's trend
+func cutoffTrends(programName string, cutoffs []mongodb.ZScoreCutoff) *ProgramCutoffs {
+	result := &ProgramCutoffs{
+		Program:   programName,
+		Districts: []DistrictCutoffTrend{},
+	}
+
+	byDistrict := map[string]*DistrictCutoffTrend{}
+	for _, cutoff := range cutoffs {
+		if result.CourseCode == "" {
+			result.CourseCode = cutoff.CourseCode
+		}
+		if cutoff.Year > result.LatestYear {
+			result.LatestYear = cutoff.Year
+		}
+
+		key := strings.ToLower(cutoff.District)
+		trend, ok := byDistrict[key]
+		if !ok {
+			trend = &DistrictCutoffTrend{District: cutoff.District}
+			byDistrict[key] = trend
+		}
+		trend.History = append(trend.History, CutoffPoint{Year: cutoff.Year, ZScore: cutoff.ZScore})
+	}
+
+	for _, trend := range byDistrict {
+		sort.Slice(trend.History, func(i, j int) bool {
+			return trend.History[i].Year < trend.History[j].Year
+		})
+
+		n := len(trend.History)
+		trend.Latest = trend.History[n-1]
+		if n > 1 {
+			change := math.Round((trend.Latest.ZScore-trend.History[n-2].ZScore)*10000) / 10000
+			trend.Change = &change
+			switch {
+			case change > stableCutoffChange:
+				trend.Direction = CutoffRising
+			case change < -stableCutoffChange:
+				trend.Direction = CutoffFalling
+			default:
+				trend.Direction = CutoffStable
+			}
+		}
+		result.Districts = append(result.Districts, *trend)
+	}
+
+	sort.Slice(result.Districts, func(i, j int) bool {
+		return result.Districts[i].District < result.Districts[j].District
+	})
+	return result
+}
@@ -0,0 +1,92 @@
+package pathway
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// Orders of a careers listing
+const (
+	CareerSortTitle  = "title"
+	CareerSortDemand = "demand"
+)
+
+// IsCareerSort reports whether sort is an order careers can be listed in; an
+// empty sort lists them by title
+func IsCareerSort(sort string) bool {
+	switch sort {
+	case "", CareerSortTitle, CareerSortDemand:
+		return true
+	}
+	return false
+}
+
+// DemandAssessment returns the local demand for a career described in job role
+// details already generated for it, or an empty string. Nothing is generated,
+// so scoring every career costs no LLM calls.
+func (s *Service) DemandAssessment(ctx context.Context, career string) (string, error) {
+	cachedData, found, err := s.jobRoleCache.GetAny(ctx, career)
+	if err != nil || !found {
+		return "", err
+	}
+	var details llm.JobRoleDetails
+	if err := remarshal(cachedData, &details); err != nil {
+		return "", err
+	}
+	return details.LocalMarket.Demand, nil
+}
+
+// demandIndexes returns the demand index of each career that has one, or nil
+// without a demand service. Indexes are optional data, so failures are logged
+// rather than returned.
+func (s *Service) demandIndexes(ctx context.Context) map[string]float64 {
+	if s.demand == nil {
+		return nil
+	}
+	indexes, err := s.demand.Indexes(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to fetch career demand indexes", zap.Error(err))
+		return nil
+	}
+	return indexes
+}
+
+// withDemandIndexes returns careers with their demand indexes attached. The
+// careers are copied, as listings may be shared through the list cache.
+func withDemandIndexes(careers []neo4j.Career, indexes map[string]float64) []neo4j.Career {
+	if len(indexes) == 0 {
+		return careers
+	}
+	withIndexes := make([]neo4j.Career, len(careers))
+	copy(withIndexes, careers)
+	for i := range withIndexes {
+		if index, ok := indexes[withIndexes[i].Title]; ok {
+			withIndexes[i].DemandIndex = &index
+		}
+	}
+	return withIndexes
+}
+
+// sortByDemand orders careers from the highest demand index down, and those
+// without an index last, by title
+func sortByDemand(careers []neo4j.Career) {
+	sort.SliceStable(careers, func(i, j int) bool {
+		a, b := demandOf(careers[i]), demandOf(careers[j])
+		if a != b {
+			return a > b
+		}
+		return careers[i].Title < careers[j].Title
+	})
+}
+
+func demandOf(career neo4j.Career) float64 {
+	if career.DemandIndex == nil {
+		return math.Inf(-1)
+	}
+	return *career.DemandIndex
+}
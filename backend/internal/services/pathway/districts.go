@@ -0,0 +1,122 @@
+package pathway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/district"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// ErrDistrictNotFound is returned for a district that is not one of Sri Lanka's
+var ErrDistrictNotFound = errors.New("district not found")
+
+type districtKey struct{}
+
+// WithDistrict returns a context in which pathway reads only return programs,
+// and paths made of programs, offered by institutes located in a district,
+// named as in district.Names
+func WithDistrict(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, districtKey{}, name)
+}
+
+// DistrictOf returns the district ctx narrows pathways to, or an empty string
+func DistrictOf(ctx context.Context) string {
+	name, _ := ctx.Value(districtKey{}).(string)
+	return name
+}
+
+// districtInstitutes returns the institutes located in the district ctx
+// narrows pathways to, or nil when it names none
+func (s *Service) districtInstitutes(ctx context.Context) (map[string]bool, error) {
+	name := DistrictOf(ctx)
+	if name == "" {
+		return nil, nil
+	}
+	names, err := cachedList(ctx, s.lists, listKeyDistrictInstitutes+name, func(ctx context.Context) ([]string, error) {
+		return s.neo4jClient.InstitutesInDistrict(ctx, name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch institutes in district: %w", err)
+	}
+	institutes := make(map[string]bool, len(names))
+	for _, name := range names {
+		institutes[name] = true
+	}
+	return institutes, nil
+}
+
+// narrowPrograms keeps the programs a student asked for through ctx: those
+// that can be completed while employed and those offered in their district.
+// The programs are copied, as listings may be shared through the list cache.
+func (s *Service) narrowPrograms(ctx context.Context, programs []neo4j.ProgramDetails) ([]neo4j.ProgramDetails, error) {
+	programs, err := s.forWorkingStudents(ctx, programs)
+	if err != nil {
+		return nil, err
+	}
+	institutes, err := s.districtInstitutes(ctx)
+	if err != nil || institutes == nil {
+		return programs, err
+	}
+	kept := make([]neo4j.ProgramDetails, 0, len(programs))
+	for _, program := range programs {
+		if institutes[program.Institute] {
+			kept = append(kept, program)
+		}
+	}
+	return kept, nil
+}
+
+// narrowEmit wraps emit to skip the paths a student did not ask for through
+// ctx, as narrowPrograms does for programs
+func (s *Service) narrowEmit(ctx context.Context, emit func(neo4j.EducationPath) error) (func(neo4j.EducationPath) error, error) {
+	emit, err := s.emitForWorkingStudents(ctx, emit)
+	if err != nil {
+		return nil, err
+	}
+	institutes, err := s.districtInstitutes(ctx)
+	if err != nil || institutes == nil {
+		return emit, err
+	}
+	return func(path neo4j.EducationPath) error {
+		if !institutes[path.Institute] {
+			return nil
+		}
+		return emit(path)
+	}, nil
+}
+
+// ListProgramsByDistrict returns a page of the programs offered by institutes
+// located in a district matching a filter, continuing from cursor (empty for
+// the first page). The district may be spelled as students commonly do.
+func (s *Service) ListProgramsByDistrict(ctx context.Context, districtName string, filter neo4j.ProgramFilter, cursor string, limit int) (*ListPage[neo4j.ProgramDetails], error) {
+	s.logger.Debug("Listing programs in district",
+		zap.String("district", districtName),
+		zap.String("cursor", cursor))
+
+	name, ok := district.Canonical(districtName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrDistrictNotFound, districtName)
+	}
+	offset, size, err := pageBounds(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.WorkingStudent = IsWorkingStudent(ctx)
+	programs, err := s.neo4jClient.ListProgramsByDistrict(ctx, name, filter, offset, size+1)
+	if err != nil {
+		s.logger.Error("Failed to list programs in district", zap.String("district", name), zap.Error(err))
+		return nil, fmt.Errorf("failed to list programs in district: %w", err)
+	}
+
+	page := newListPage(programs, offset, size)
+	page.Items = s.withAptitudeTests(ctx, page.Items)
+	s.logger.Info("Successfully listed programs in district",
+		zap.String("district", name),
+		zap.Int("offset", offset),
+		zap.Int("count", len(page.Items)))
+	return page, nil
+}
@@ -0,0 +1,57 @@
+package pathway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+)
+
+// ProgramDurationMonths is a program's recorded duration, or an estimate from
+// its name when none is recorded
+func ProgramDurationMonths(details *neo4j.ProgramDetails) int {
+	if details.Info != nil && details.Info.DurationMonths > 0 {
+		return details.Info.DurationMonths
+	}
+	return EstimateProgramDurationMonths(details.Name)
+}
+
+// EstimateProgramDurationMonths gives a rough program length based on the kind of
+// program named. Returns 0 when the program type is not recognised.
+func EstimateProgramDurationMonths(programName string) int {
+	name := strings.ToLower(programName)
+	switch {
+	case strings.Contains(name, "nvq level 3"):
+		return 6
+	case strings.Contains(name, "nvq level 4"):
+		return 12
+	case strings.Contains(name, "nvq"):
+		return 18
+	case strings.Contains(name, "advanced certificate"):
+		return 12
+	case strings.Contains(name, "certificate"):
+		return 9
+	case strings.Contains(name, "diploma"):
+		return 18
+	case strings.Contains(name, "bachelor"), strings.Contains(name, "bsc"), strings.Contains(name, "degree"):
+		return 48
+	}
+	return 0
+}
+
+// FormatDurationMonths renders a month count in plain language
+func FormatDurationMonths(months int) string {
+	switch {
+	case months <= 0:
+		return ""
+	case months < 12:
+		return fmt.Sprintf("%d months", months)
+	case months == 12:
+		return "1 year"
+	case months%12 == 0:
+		return fmt.Sprintf("%d years", months/12)
+	case months%6 == 0:
+		return fmt.Sprintf("%.1f years", float64(months)/12)
+	}
+	return fmt.Sprintf("%d months", months)
+}
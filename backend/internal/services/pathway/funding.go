@@ -0,0 +1,218 @@
+package pathway
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidFundingRequest is returned for funding checks with an unknown income band
+var ErrInvalidFundingRequest = errors.New("invalid funding request")
+
+// IncomeBands are the monthly household income bands in rupees, lowest first
+var IncomeBands = []string{
+	"below_25000",
+	"25000_50000",
+	"50000_100000",
+	"100000_200000",
+	"above_200000",
+}
+
+// Program levels funding schemes are restricted to
+const (
+	LevelDegree      = "degree"
+	LevelDiploma     = "diploma"
+	LevelCertificate = "certificate"
+	LevelNVQ         = "nvq"
+)
+
+//go:embed funding/schemes.json
+var fundingSchemesJSON []byte
+
+// FundingScheme is a scholarship, bursary or loan and the rules deciding who it
+// is likely open to. Empty rules do not restrict.
+type FundingScheme struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Provider       string `json:"provider"`
+	Kind           string `json:"kind"` // scholarship, bursary or loan
+	Description    string `json:"description"`
+	ApplicationURL string `json:"application_url"`
+	HowToApply     string `json:"how_to_apply,omitempty"`
+
+	Levels                   []string `json:"levels,omitempty"`
+	InstitutePatterns        []string `json:"institute_patterns,omitempty"`
+	ExcludeInstitutePatterns []string `json:"exclude_institute_patterns,omitempty"`
+	MaxIncomeBand            string   `json:"max_income_band,omitempty"`
+}
+
+// FundingRequest asks which schemes could fund a program for a household
+type FundingRequest struct {
+	ProgramName         string `json:"program_name" binding:"required"`
+	HouseholdIncomeBand string `json:"household_income_band"`
+}
+
+// FundingOption is a scheme the student is likely eligible for, and why
+type FundingOption struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Provider       string   `json:"provider"`
+	Kind           string   `json:"kind"`
+	Description    string   `json:"description"`
+	ApplicationURL string   `json:"application_url"`
+	HowToApply     string   `json:"how_to_apply,omitempty"`
+	Reasons        []string `json:"reasons"`
+}
+
+// FundingOptions are the funding options for a program
+type FundingOptions struct {
+	ProgramName string          `json:"program_name"`
+	Institute   string          `json:"institute"`
+	Level       string          `json:"level,omitempty"`
+	IncomeBand  string          `json:"household_income_band"`
+	Options     []FundingOption `json:"options"`
+	Disclaimer  string          `json:"disclaimer"`
+}
+
+const fundingDisclaimer = "Eligibility is decided by each scheme's provider. Check the current rules and deadlines on the application page before applying."
+
+// fundingSchemes are the bundled schemes, checked in order
+var fundingSchemes = sync.OnceValues(func() ([]FundingScheme, error) {
+	var schemes []FundingScheme
+	if err := json.Unmarshal(fundingSchemesJSON, &schemes); err != nil {
+		return nil, err
+	}
+	for _, scheme := range schemes {
+		if scheme.MaxIncomeBand != "" && !slices.Contains(IncomeBands, scheme.MaxIncomeBand) {
+			return nil, fmt.Errorf("funding scheme %s has unknown income band %q", scheme.ID, scheme.MaxIncomeBand)
+		}
+	}
+	return schemes, nil
+})
+
+// ProgramLevel classifies a program by its name, returning an empty level when
+// the kind of program is not recognised
+func ProgramLevel(programName string) string {
+	name := strings.ToLower(programName)
+	switch {
+	case strings.Contains(name, "nvq"):
+		return LevelNVQ
+	case strings.Contains(name, "certificate"):
+		return LevelCertificate
+	case strings.Contains(name, "diploma"), strings.Contains(name, "higher national"):
+		return LevelDiploma
+	case strings.Contains(name, "bachelor"), strings.Contains(name, "bsc"), strings.Contains(name, "degree"):
+		return LevelDegree
+	}
+	return ""
+}
+
+// GetFundingOptions checks the bundled funding schemes against a program and a
+// household's income band and returns the schemes the student is likely
+// eligible for
+func (s *Service) GetFundingOptions(ctx context.Context, req FundingRequest) (*FundingOptions, error) {
+	s.logger.Debug("Checking funding options",
+		zap.String("program", req.ProgramName),
+		zap.String("income_band", req.HouseholdIncomeBand))
+
+	band := slices.Index(IncomeBands, req.HouseholdIncomeBand)
+	if band < 0 {
+		return nil, fmt.Errorf("%w: household_income_band must be one of %s", ErrInvalidFundingRequest, strings.Join(IncomeBands, ", "))
+	}
+
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, []string{req.ProgramName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check program: %w", err)
+	}
+	if !existing[req.ProgramName] {
+		return nil, fmt.Errorf("%w: %q", ErrProgramNotFound, req.ProgramName)
+	}
+	details, err := s.neo4jClient.GetProgramDetails(ctx, req.ProgramName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch program details: %w", err)
+	}
+
+	schemes, err := fundingSchemes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load funding schemes: %w", err)
+	}
+
+	result := &FundingOptions{
+		ProgramName: details.Name,
+		Institute:   details.Institute,
+		Level:       ProgramLevel(details.Name),
+		IncomeBand:  req.HouseholdIncomeBand,
+		Options:     []FundingOption{},
+		Disclaimer:  fundingDisclaimer,
+	}
+	for _, scheme := range schemes {
+		reasons, ok := scheme.matches(result.Level, details.Institute, band)
+		if !ok {
+			continue
+		}
+		result.Options = append(result.Options, FundingOption{
+			ID:             scheme.ID,
+			Name:           scheme.Name,
+			Provider:       scheme.Provider,
+			Kind:           scheme.Kind,
+			Description:    scheme.Description,
+			ApplicationURL: scheme.ApplicationURL,
+			HowToApply:     scheme.HowToApply,
+			Reasons:        reasons,
+		})
+	}
+
+	s.logger.Info("Funding options checked",
+		zap.String("program", details.Name),
+		zap.Int("options", len(result.Options)))
+	return result, nil
+}
+
+// matches applies a scheme's rules, returning why the scheme applies when all
+// of them pass
+func (fs FundingScheme) matches(level, institute string, band int) ([]string, bool) {
+	reasons := []string{}
+
+	if len(fs.Levels) > 0 {
+		if !slices.Contains(fs.Levels, level) {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("Open to %s programs", level))
+	}
+
+	if len(fs.InstitutePatterns) > 0 {
+		if !containsAnyFold(institute, fs.InstitutePatterns) {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("Available to students of %s", institute))
+	}
+	if containsAnyFold(institute, fs.ExcludeInstitutePatterns) {
+		return nil, false
+	}
+
+	if fs.MaxIncomeBand != "" {
+		if band > slices.Index(IncomeBands, fs.MaxIncomeBand) {
+			return nil, false
+		}
+		reasons = append(reasons, "Household income is within the scheme's limit")
+	}
+	return reasons, true
+}
+
+func containsAnyFold(s string, patterns []string) bool {
+	s = strings.ToLower(s)
+	for _, pattern := range patterns {
+		if strings.Contains(s, strings.ToLower(pattern)) {
+			return true
+		}
+	}
+	return false
+}
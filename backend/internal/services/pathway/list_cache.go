@@ -0,0 +1,249 @@
+package pathway
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// Bounds on how long list queries are cached; shorter would barely spare the
+// graph, longer would leave edits unseen by readers on other instances
+const (
+	minListCacheTTL = 30 * time.Second
+	maxListCacheTTL = 300 * time.Second
+
+	// maxListCacheEntries bounds the lists kept in memory. Per-name listings
+	// are keyed by names taken from request paths, so without a bound requests
+	// for made-up names would grow the cache without limit.
+	maxListCacheEntries = 1024
+
+	// listLoadTimeout bounds a shared load, which outlives the request that
+	// started it so the callers waiting on it are not failed by its cancellation
+	listLoadTimeout = 30 * time.Second
+)
+
+// Keys of cached list queries; per-name listings append the name
+const (
+	listKeyInstitutes         = "institutes"
+	listKeyCareers            = "careers"
+	listKeyInstitutePrograms  = "institute-programs:"
+	listKeyDepartmentPrograms = "department-programs:"
+
+	listKeyWorkingStudentPrograms = "working-student-programs"
+	listKeyDistrictInstitutes     = "district-institutes:"
+	listKeyQualificationAliases   = "qualification-aliases"
+	listKeySpellingIndex          = "spelling-index:"
+)
+
+// listCache keeps the results of list queries that change rarely (institutes,
+// careers, the programs of an institute or department) in memory for a short
+// time, so page loads do not all reach the graph. Identical loads in flight at
+// the same time are coalesced into one query, even with caching disabled. Cached
+// values are shared between callers and must not be modified.
+//
+// Listings read through sharedList are also kept in a shared cache, when one is
+// set, so instances behind a load balancer do not each query the graph for them.
+//
+// At most maxListCacheEntries lists are kept; the least recently used is
+// dropped to make room, and expired lists are swept at most once per TTL.
+type listCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]*listCacheEntry
+	flight  singleflight.Group
+	swept   time.Time
+
+	// generation counts clears, so loads started before a clear are not cached
+	generation uint64
+	// clearedAt is when the cache was last cleared, or created; shared copies
+	// loaded before then may predate a graph change
+	clearedAt time.Time
+
+	shared SharedListCache
+	logger *zap.Logger
+}
+
+type listCacheEntry struct {
+	value    any
+	expires  time.Time
+	lastUsed time.Time
+}
+
+// newListCache creates a list cache keeping results for ttl, clamped to 30s-300s.
+// A ttl of zero or less disables caching.
+func newListCache(ttl time.Duration) *listCache {
+	if ttl > 0 && ttl < minListCacheTTL {
+		ttl = minListCacheTTL
+	}
+	if ttl > maxListCacheTTL {
+		ttl = maxListCacheTTL
+	}
+	return &listCache{
+		ttl:       ttl,
+		entries:   make(map[string]*listCacheEntry),
+		swept:     time.Now(),
+		clearedAt: time.Now(),
+	}
+}
+
+func (c *listCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	now := time.Now()
+	if now.After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	entry.lastUsed = now
+	return entry.value, true
+}
+
+func (c *listCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// set caches a value loaded during the given generation, unless the cache has
+// been cleared since
+func (c *listCache) set(key string, value any, generation uint64) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+
+	now := time.Now()
+	c.sweep(now)
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxListCacheEntries {
+		c.evictLeastRecentlyUsed()
+	}
+	c.entries[key] = &listCacheEntry{value: value, expires: now.Add(c.ttl), lastUsed: now}
+}
+
+// sweep drops expired lists, at most once per TTL. Callers hold c.mu.
+func (c *listCache) sweep(now time.Time) {
+	if now.Sub(c.swept) < c.ttl {
+		return
+	}
+	c.swept = now
+	for key, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// evictLeastRecentlyUsed drops the list read longest ago. Callers hold c.mu.
+func (c *listCache) evictLeastRecentlyUsed() {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if oldestKey == "" || entry.lastUsed.Before(oldest) {
+			oldestKey, oldest = key, entry.lastUsed
+		}
+	}
+	delete(c.entries, oldestKey)
+}
+
+// clear drops every cached list
+func (c *listCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*listCacheEntry)
+	c.generation++
+	c.clearedAt = time.Now()
+}
+
+func (c *listCache) lastCleared() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.clearedAt
+}
+
+// size returns the number of cached lists, expired ones included
+func (c *listCache) size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// cachedList returns the cached result under key, or loads and caches it.
+// Callers arriving while the same key is loading wait for that load and share
+// its result, or its error; errors are not cached. The load is given a context
+// carrying ctx's values but not its cancellation, as other callers may be
+// waiting on it after the one that started it has gone.
+func cachedList[T any](ctx context.Context, c *listCache, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	if value, ok := c.get(key); ok {
+		if typed, ok := value.(T); ok {
+			return typed, nil
+		}
+	}
+
+	value, err, _ := c.flight.Do(key, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), listLoadTimeout)
+		defer cancel()
+
+		generation := c.currentGeneration()
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.set(key, value, generation)
+		return value, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	typed, _ := value.(T)
+	return typed, nil
+}
+
+// sharedList is cachedList for listings that are also shared between instances
+// through the shared cache, when one is set and caching is enabled. A shared
+// copy is used unless it was loaded before this cache was last cleared, and
+// listings loaded here are shared for the others. Shared copies are JSON, so T
+// must survive encoding. Failures of the shared cache fall back to load.
+func sharedList[T any](ctx context.Context, c *listCache, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	if c.shared == nil || c.ttl <= 0 {
+		return cachedList(ctx, c, key, load)
+	}
+	return cachedList(ctx, c, key, func(ctx context.Context) (T, error) {
+		data, loadedAt, ok, err := c.shared.Get(ctx, key)
+		if err != nil {
+			c.logger.Warn("Failed to read shared listing", zap.String("key", key), zap.Error(err))
+		}
+		if ok && loadedAt.After(c.lastCleared()) {
+			var value T
+			if err := json.Unmarshal(data, &value); err == nil {
+				return value, nil
+			}
+		}
+
+		loadedAt = time.Now()
+		value, err := load(ctx)
+		if err != nil {
+			return value, err
+		}
+		if data, err := json.Marshal(value); err == nil {
+			if err := c.shared.Set(ctx, key, data, loadedAt, c.ttl); err != nil {
+				c.logger.Warn("Failed to share listing", zap.String("key", key), zap.Error(err))
+			}
+		}
+		return value, nil
+	})
+}
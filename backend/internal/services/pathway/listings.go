@@ -0,0 +1,198 @@
+package pathway
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+const (
+	// Defaults and bounds for the size of a listing page
+	DefaultListPageSize = 50
+	MaxListPageSize     = 200
+
+	// cursorPrefix marks the offset a listing cursor encodes
+	cursorPrefix = "offset:"
+)
+
+var (
+	// ErrInvalidCursor is returned for a listing cursor this service did not issue
+	ErrInvalidCursor = errors.New("invalid page cursor")
+
+	// ErrInvalidFilter is returned for a listing filter with an unknown value
+	ErrInvalidFilter = errors.New("invalid listing filter")
+)
+
+// ListPage is a page of a listing. NextCursor fetches the page after it and is
+// empty on the last page.
+type ListPage[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// EncodeCursor returns the opaque cursor of a listing page starting at offset
+func EncodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset a cursor starts at; an empty cursor starts at
+// the beginning
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	value, ok := strings.CutPrefix(string(raw), cursorPrefix)
+	if !ok {
+		return 0, ErrInvalidCursor
+	}
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return offset, nil
+}
+
+// pageBounds returns the offset and size of the page a cursor and requested
+// limit ask for
+func pageBounds(cursor string, limit int) (offset, size int, err error) {
+	offset, err = decodeCursor(cursor)
+	if err != nil {
+		return 0, 0, err
+	}
+	if limit <= 0 {
+		limit = DefaultListPageSize
+	}
+	return offset, min(limit, MaxListPageSize), nil
+}
+
+// newListPage returns the page of items read from offset. The graph is asked
+// for one item more than the page holds, which tells whether a page follows.
+func newListPage[T any](items []T, offset, size int) *ListPage[T] {
+	page := &ListPage[T]{Items: items}
+	if len(items) > size {
+		page.Items = items[:size]
+		page.NextCursor = EncodeCursor(offset + size)
+	}
+	return page
+}
+
+// ListInstitutes returns a page of the institutes matching a filter, continuing
+// from cursor (empty for the first page)
+func (s *Service) ListInstitutes(ctx context.Context, filter neo4j.InstituteFilter, cursor string, limit int) (*ListPage[neo4j.Institute], error) {
+	s.logger.Debug("Listing institutes",
+		zap.String("type", filter.Type),
+		zap.String("district", filter.District),
+		zap.String("cursor", cursor))
+
+	if t := strings.TrimSpace(filter.Type); t != "" && !neo4j.IsInstituteType(strings.ToLower(t)) {
+		return nil, fmt.Errorf("%w: unknown institute type %q", ErrInvalidFilter, filter.Type)
+	}
+	offset, size, err := pageBounds(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	institutes, err := s.neo4jClient.ListInstitutes(ctx, filter, offset, size+1)
+	if err != nil {
+		s.logger.Error("Failed to list institutes", zap.Error(err))
+		return nil, fmt.Errorf("failed to list institutes: %w", err)
+	}
+
+	page := newListPage(institutes, offset, size)
+	s.logger.Info("Successfully listed institutes",
+		zap.Int("offset", offset),
+		zap.Int("count", len(page.Items)))
+	return page, nil
+}
+
+// ListCareers returns a page of the careers matching a filter in the given
+// order, continuing from cursor (empty for the first page). Careers carry their
+// demand indexes; ordering by demand reads every matching career to sort them.
+func (s *Service) ListCareers(ctx context.Context, filter neo4j.CareerFilter, order, cursor string, limit int) (*ListPage[neo4j.Career], error) {
+	s.logger.Debug("Listing careers",
+		zap.String("department", filter.Department),
+		zap.String("institute_type", filter.InstituteType),
+		zap.String("sort", order),
+		zap.String("cursor", cursor))
+
+	if t := strings.TrimSpace(filter.InstituteType); t != "" && !neo4j.IsInstituteType(strings.ToLower(t)) {
+		return nil, fmt.Errorf("%w: unknown institute type %q", ErrInvalidFilter, filter.InstituteType)
+	}
+	if !IsCareerSort(order) {
+		return nil, fmt.Errorf("%w: unknown sort %q", ErrInvalidFilter, order)
+	}
+	offset, size, err := pageBounds(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := s.demandIndexes(ctx)
+	var careers []neo4j.Career
+	if order == CareerSortDemand {
+		careers, err = s.neo4jClient.ListCareers(ctx, filter, 0, math.MaxInt32)
+	} else {
+		careers, err = s.neo4jClient.ListCareers(ctx, filter, offset, size+1)
+	}
+	if err != nil {
+		s.logger.Error("Failed to list careers", zap.Error(err))
+		return nil, fmt.Errorf("failed to list careers: %w", err)
+	}
+
+	careers = withDemandIndexes(careers, indexes)
+	if order == CareerSortDemand {
+		sortByDemand(careers)
+		careers = careers[min(offset, len(careers)):min(offset+size+1, len(careers))]
+	}
+
+	page := newListPage(careers, offset, size)
+	s.logger.Info("Successfully listed careers",
+		zap.Int("offset", offset),
+		zap.Int("count", len(page.Items)))
+	return page, nil
+}
+
+// ListProgramsByInstitute returns a page of the programs an institute offers
+// matching a filter, continuing from cursor (empty for the first page)
+func (s *Service) ListProgramsByInstitute(ctx context.Context, instituteName string, filter neo4j.ProgramFilter, cursor string, limit int) (*ListPage[neo4j.ProgramDetails], error) {
+	s.logger.Debug("Listing programs for institute",
+		zap.String("institute", instituteName),
+		zap.String("department", filter.Department),
+		zap.String("cursor", cursor))
+
+	if instituteName == "" {
+		return nil, fmt.Errorf("institute name is required")
+	}
+	offset, size, err := pageBounds(cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.WorkingStudent = IsWorkingStudent(ctx)
+	if filter.District == "" {
+		filter.District = DistrictOf(ctx)
+	}
+	programs, err := s.neo4jClient.ListProgramsByInstitute(ctx, instituteName, filter, offset, size+1)
+	if err != nil {
+		s.logger.Error("Failed to list programs", zap.String("institute", instituteName), zap.Error(err))
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+
+	page := newListPage(programs, offset, size)
+	page.Items = s.withAptitudeTests(ctx, page.Items)
+	s.logger.Info("Successfully listed programs",
+		zap.String("institute", instituteName),
+		zap.Int("offset", offset),
+		zap.Int("count", len(page.Items)))
+	return page, nil
+}
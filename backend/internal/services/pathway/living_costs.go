@@ -0,0 +1,67 @@
+package pathway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/district"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// livingCostCurrency is the currency living costs are recorded in
+const livingCostCurrency = "LKR"
+
+// ErrUnknownDistrict is returned for a district that is not one of Sri Lanka's
+var ErrUnknownDistrict = errors.New("unknown district")
+
+// ListLivingCosts returns the monthly living costs of every district recorded
+func (s *Service) ListLivingCosts(ctx context.Context) ([]mongodb.LivingCost, error) {
+	return s.livingCosts.List(ctx)
+}
+
+// AttachLivingCost adds to program details what studying it costs a student from
+// homeDistrict per month. The institute's district has to be known; the total
+// is only estimated when the student would have to relocate to attend. Costs are
+// optional data, so failures to read them are logged rather than returned.
+func (s *Service) AttachLivingCost(ctx context.Context, details *neo4j.ProgramDetails, homeDistrict string) error {
+	home, ok := district.Canonical(homeDistrict)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownDistrict, homeDistrict)
+	}
+	if details.InstituteContact == nil || details.InstituteContact.District == "" {
+		return nil
+	}
+
+	estimate := &neo4j.LivingCostEstimate{
+		HomeDistrict: home,
+		District:     details.InstituteContact.District,
+		Relocating:   details.InstituteContact.District != home,
+	}
+	details.LivingCost = estimate
+	if !estimate.Relocating {
+		return nil
+	}
+
+	cost, err := s.livingCosts.Get(ctx, estimate.District)
+	if err != nil {
+		s.logger.Warn("Failed to fetch living costs",
+			zap.String("district", estimate.District),
+			zap.Error(err))
+		return nil
+	}
+	if cost == nil {
+		return nil
+	}
+
+	estimate.Boarding = cost.Boarding
+	estimate.Transport = cost.Transport
+	estimate.Food = cost.Food
+	estimate.TotalMonthly = cost.Total()
+	estimate.Currency = livingCostCurrency
+	estimate.Year = cost.Year
+	estimate.Source = cost.Source
+	return nil
+}
@@ -0,0 +1,137 @@
+package pathway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// OfflineBundleSchemaVersion is bumped whenever the bundle layout changes so the
+// mobile app knows when it has to discard a stored bundle
+const OfflineBundleSchemaVersion = 1
+
+// OfflineBundle is a self-contained snapshot of a department for offline use
+type OfflineBundle struct {
+	SchemaVersion int                                 `json:"schema_version"`
+	Version       string                              `json:"version"`
+	GeneratedAt   time.Time                           `json:"generated_at"`
+	Department    string                              `json:"department"`
+	Institutes    []neo4j.Institute                   `json:"institutes"`
+	Programs      []neo4j.ProgramDetails              `json:"programs"`
+	Careers       []neo4j.Career                      `json:"careers"`
+	Roadmaps      map[string]*LearningRoadmapResponse `json:"roadmaps"`
+}
+
+// GetOfflineBundle builds an offline snapshot of a department's programs, the
+// institutes offering them, the careers they lead to and any cached roadmaps.
+// Roadmaps are only taken from the cache - building a bundle never calls the LLM.
+// An unknown department is an error wrapping neo4j.ErrEntityNotFound.
+func (s *Service) GetOfflineBundle(ctx context.Context, department string) (*OfflineBundle, error) {
+	s.logger.Debug("Building offline bundle", zap.String("department", department))
+
+	if department == "" {
+		return nil, fmt.Errorf("department is required")
+	}
+
+	programs, err := s.neo4jClient.GetCompletePathway(ctx, department)
+	if err != nil {
+		s.logger.Error("Failed to fetch department programs for offline bundle",
+			zap.String("department", department),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch department programs: %w", err)
+	}
+	if len(programs) == 0 {
+		// An unknown department must not be mistaken for an empty one, or
+		// clients would store its empty bundle
+		existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindDepartment, []string{department})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check department: %w", err)
+		}
+		if !existing[department] {
+			return nil, fmt.Errorf("%w: department %q", neo4j.ErrEntityNotFound, department)
+		}
+	}
+
+	bundle := &OfflineBundle{
+		SchemaVersion: OfflineBundleSchemaVersion,
+		Department:    department,
+		Institutes:    []neo4j.Institute{},
+		Programs:      programs,
+		Careers:       []neo4j.Career{},
+		Roadmaps:      make(map[string]*LearningRoadmapResponse),
+	}
+	if bundle.Programs == nil {
+		bundle.Programs = []neo4j.ProgramDetails{}
+	}
+
+	institutes := make(map[string]bool)
+	careers := make(map[string]bool)
+	for _, program := range programs {
+		if program.Institute != "" && !institutes[program.Institute] {
+			institutes[program.Institute] = true
+			bundle.Institutes = append(bundle.Institutes, neo4j.Institute{Name: program.Institute})
+		}
+		for _, career := range program.CareerPaths {
+			if career.Title != "" && !careers[career.Title] {
+				careers[career.Title] = true
+				bundle.Careers = append(bundle.Careers, career)
+			}
+		}
+
+		cachedData, found, err := s.cache.Get(ctx, program.Name)
+		if err != nil {
+			s.logger.Warn("Cache error while building offline bundle",
+				zap.String("program", program.Name),
+				zap.Error(err))
+			continue
+		}
+		if !found {
+			continue
+		}
+		roadmap, err := s.unmarshalCachedRoadmap(cachedData)
+		if err != nil {
+			s.logger.Warn("Skipping invalid cached roadmap in offline bundle",
+				zap.String("program", program.Name),
+				zap.Error(err))
+			continue
+		}
+		bundle.Roadmaps[program.Name] = roadmap
+	}
+
+	sort.Slice(bundle.Institutes, func(i, j int) bool { return bundle.Institutes[i].Name < bundle.Institutes[j].Name })
+	sort.Slice(bundle.Careers, func(i, j int) bool { return bundle.Careers[i].Title < bundle.Careers[j].Title })
+
+	version, err := bundleVersion(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to version offline bundle: %w", err)
+	}
+	bundle.Version = version
+	bundle.GeneratedAt = time.Now().UTC()
+
+	s.logger.Info("Offline bundle built",
+		zap.String("department", department),
+		zap.Int("programs", len(bundle.Programs)),
+		zap.Int("careers", len(bundle.Careers)),
+		zap.Int("roadmaps", len(bundle.Roadmaps)),
+		zap.String("version", bundle.Version))
+
+	return bundle, nil
+}
+
+// bundleVersion hashes the bundle content so clients can tell whether their
+// stored copy is still current. It must be computed before GeneratedAt is set.
+func bundleVersion(bundle *OfflineBundle) (string, error) {
+	content, err := json.Marshal(bundle)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:8]), nil
+}
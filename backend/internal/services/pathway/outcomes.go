@@ -0,0 +1,25 @@
+package pathway
+
+import (
+	"context"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// attachOutcomes adds graduate outcomes and the employability score to program
+// details. Outcomes are optional, so failures are logged rather than returned.
+func (s *Service) attachOutcomes(ctx context.Context, details *neo4j.ProgramDetails) {
+	if s.outcomes == nil {
+		return
+	}
+
+	summary, err := s.outcomes.ForProgram(ctx, details.Name)
+	if err != nil {
+		s.logger.Warn("Failed to fetch program outcomes",
+			zap.String("program", details.Name),
+			zap.Error(err))
+		return
+	}
+	details.Outcomes = summary
+}
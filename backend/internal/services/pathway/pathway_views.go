@@ -0,0 +1,138 @@
+package pathway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// MaterializeViews precomputes the complete pathway of every department and
+// stores it, so department pages are served without querying the graph. Views
+// of departments that no longer exist are removed. It returns the number of
+// views stored.
+func (s *Service) MaterializeViews(ctx context.Context) (int, error) {
+	s.logger.Debug("Materializing pathway views")
+
+	// Views built while the graph changes would be stale as soon as they are stored
+	changed := s.LastChanged()
+
+	departments, err := s.neo4jClient.ListNames(ctx, neo4j.KindDepartment)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list departments: %w", err)
+	}
+
+	views := make([]mongodb.PathwayView, 0, len(departments))
+	for _, department := range departments {
+		programs, err := s.neo4jClient.GetCompletePathway(ctx, department)
+		if err != nil {
+			return 0, err
+		}
+		view, err := newPathwayView(department, programs)
+		if err != nil {
+			return 0, err
+		}
+		views = append(views, *view)
+	}
+
+	if !s.LastChanged().Equal(changed) {
+		s.logger.Warn("Graph changed while materializing pathway views, keeping them unbuilt until the next run")
+		return 0, nil
+	}
+
+	if err := s.views.Save(ctx, views); err != nil {
+		return 0, err
+	}
+	removed, err := s.views.DeleteExcept(ctx, departments)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("Pathway views materialized",
+		zap.Int("views", len(views)),
+		zap.Int64("removed", removed))
+	return len(views), nil
+}
+
+// materializedPathway returns a department's complete pathway from its view,
+// falling back to the graph when there is no view (never built, or dropped after
+// a graph change). A pathway read from the graph is stored as the new view.
+func (s *Service) materializedPathway(ctx context.Context, department string) ([]neo4j.ProgramDetails, error) {
+	view, err := s.views.Get(ctx, department)
+	if err != nil {
+		s.logger.Warn("Failed to read pathway view, querying the graph",
+			zap.String("department", department),
+			zap.Error(err))
+	}
+	if view != nil {
+		programs, err := pathwayFromView(view)
+		if err == nil {
+			return programs, nil
+		}
+		s.logger.Warn("Invalid pathway view, querying the graph",
+			zap.String("department", department),
+			zap.Error(err))
+	}
+
+	changed := s.LastChanged()
+	programs, err := s.neo4jClient.GetCompletePathway(ctx, department)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unknown departments are not stored, and neither are pathways read while
+	// the graph changed
+	if len(programs) > 0 && s.LastChanged().Equal(changed) {
+		if view, err := newPathwayView(department, programs); err == nil {
+			if err := s.views.Save(ctx, []mongodb.PathwayView{*view}); err != nil {
+				s.logger.Warn("Failed to store pathway view",
+					zap.String("department", department),
+					zap.Error(err))
+			}
+		}
+	}
+	return programs, nil
+}
+
+// dropViews removes every materialized view after a graph change; views are
+// rebuilt as departments are read, or all at once by the next materialization
+func (s *Service) dropViews(ctx context.Context) {
+	if err := s.views.DeleteAll(ctx); err != nil {
+		s.logger.Warn("Failed to drop pathway views after graph change", zap.Error(err))
+	}
+}
+
+func newPathwayView(department string, programs []neo4j.ProgramDetails) (*mongodb.PathwayView, error) {
+	// Convert to JSON and back to maps (ensures proper serialization)
+	jsonData, err := json.Marshal(programs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pathway view: %w", err)
+	}
+
+	view := &mongodb.PathwayView{
+		Department: department,
+		Programs:   []map[string]interface{}{},
+		BuiltAt:    time.Now().UTC(),
+	}
+	if err := json.Unmarshal(jsonData, &view.Programs); err != nil {
+		return nil, fmt.Errorf("failed to encode pathway view: %w", err)
+	}
+	return view, nil
+}
+
+func pathwayFromView(view *mongodb.PathwayView) ([]neo4j.ProgramDetails, error) {
+	jsonData, err := json.Marshal(view.Programs)
+	if err != nil {
+		return nil, err
+	}
+
+	var programs []neo4j.ProgramDetails
+	if err := json.Unmarshal(jsonData, &programs); err != nil {
+		return nil, err
+	}
+	return programs, nil
+}
@@ -0,0 +1,73 @@
+package pathway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// ProgramOverview combines a program's details with its learning roadmap, so a
+// program page loads in one request. Roadmap is nil when none has been generated
+// yet; its videos are included as they were cached.
+type ProgramOverview struct {
+	Program *neo4j.ProgramDetails    `json:"program"`
+	Roadmap *LearningRoadmapResponse `json:"roadmap"`
+}
+
+// GetProgramOverview returns a program's details together with its cached
+// learning roadmap, if any. A roadmap is never generated here, and failing to
+// read the cache only leaves the roadmap out.
+func (s *Service) GetProgramOverview(ctx context.Context, programName string) (*ProgramOverview, error) {
+	s.logger.Debug("Fetching program overview", zap.String("program", programName))
+
+	if programName == "" {
+		return nil, fmt.Errorf("program name is required")
+	}
+
+	// The cache is read while the graph is queried
+	roadmap := make(chan *LearningRoadmapResponse, 1)
+	go func() {
+		roadmap <- s.cachedRoadmap(ctx, programName)
+	}()
+
+	details, err := s.GetProgramDetails(ctx, programName)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &ProgramOverview{
+		Program: details,
+		Roadmap: <-roadmap,
+	}
+
+	s.logger.Info("Successfully fetched program overview",
+		zap.String("program", programName),
+		zap.Bool("roadmap", overview.Roadmap != nil))
+	return overview, nil
+}
+
+// cachedRoadmap returns the cached roadmap of a program, or nil when there is
+// none or it cannot be read
+func (s *Service) cachedRoadmap(ctx context.Context, programName string) *LearningRoadmapResponse {
+	cachedData, found, err := s.cache.Get(ctx, programName)
+	if err != nil {
+		s.logger.Warn("Cache error while retrieving roadmap for overview",
+			zap.String("program", programName),
+			zap.Error(err))
+		return nil
+	}
+	if !found || cachedData == nil {
+		return nil
+	}
+
+	roadmap, err := s.unmarshalCachedRoadmap(cachedData)
+	if err != nil {
+		s.logger.Warn("Failed to unmarshal cached roadmap for overview",
+			zap.String("program", programName),
+			zap.Error(err))
+		return nil
+	}
+	return roadmap
+}
@@ -0,0 +1,109 @@
+package pathway
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// Rankings of the programs accessible from a qualification
+const (
+	// RankByDistance puts the programs fewest prerequisite steps away first
+	RankByDistance = "distance"
+	// RankByLevel puts the programs of the lowest level first, entry level
+	// programs before degrees
+	RankByLevel = "level"
+	// RankByPopularity puts the programs the most students viewed first
+	RankByPopularity = "popularity"
+)
+
+// ErrInvalidTraversal is returned for a pathway query with hops out of range
+// or an unknown ranking
+var ErrInvalidTraversal = errors.New("invalid pathway traversal")
+
+// programRanking returns how to order accessible programs, best first. It may
+// read what it ranks by for the programs before they are compared.
+type programRanking func(ctx context.Context, s *Service, programs []neo4j.ProgramDetails) func(a, b neo4j.ProgramDetails) int
+
+// programRankings are the rankings by name. Each breaks its ties by distance,
+// level and then name.
+var programRankings = map[string]programRanking{
+	RankByDistance: func(context.Context, *Service, []neo4j.ProgramDetails) func(a, b neo4j.ProgramDetails) int {
+		return compareByDistance
+	},
+	RankByLevel: func(context.Context, *Service, []neo4j.ProgramDetails) func(a, b neo4j.ProgramDetails) int {
+		return func(a, b neo4j.ProgramDetails) int {
+			return cmp.Or(cmp.Compare(levelKey(a), levelKey(b)), compareByDistance(a, b))
+		}
+	},
+	RankByPopularity: func(ctx context.Context, s *Service, programs []neo4j.ProgramDetails) func(a, b neo4j.ProgramDetails) int {
+		viewers := s.programViewers(ctx, programs)
+		return func(a, b neo4j.ProgramDetails) int {
+			return cmp.Or(cmp.Compare(viewers[b.Name], viewers[a.Name]), compareByDistance(a, b))
+		}
+	},
+}
+
+// IsProgramRanking reports whether rankBy names a ranking of accessible
+// programs; an empty name ranks them by distance
+func IsProgramRanking(rankBy string) bool {
+	_, ok := programRankings[rankBy]
+	return ok || rankBy == ""
+}
+
+// rankPrograms orders programs by a ranking and numbers them from 1 in their
+// Rank
+func (s *Service) rankPrograms(ctx context.Context, rankBy string, programs []neo4j.ProgramDetails) {
+	if rankBy == "" {
+		rankBy = RankByDistance
+	}
+	slices.SortStableFunc(programs, programRankings[rankBy](ctx, s, programs))
+	for i := range programs {
+		programs[i].Rank = i + 1
+	}
+}
+
+// programViewers returns how many students viewed each program. Views are
+// optional data, so failures are logged and rank programs as unviewed.
+func (s *Service) programViewers(ctx context.Context, programs []neo4j.ProgramDetails) map[string]int64 {
+	names := make([]string, 0, len(programs))
+	for _, program := range programs {
+		names = append(names, program.Name)
+	}
+	viewers, err := s.history.Viewers(ctx, mongodb.EntityTypeProgram, names)
+	if err != nil {
+		s.logger.Warn("Failed to fetch program viewers", zap.Error(err))
+		return nil
+	}
+	return viewers
+}
+
+func compareByDistance(a, b neo4j.ProgramDetails) int {
+	return cmp.Or(
+		cmp.Compare(hopsKey(a), hopsKey(b)),
+		cmp.Compare(levelKey(a), levelKey(b)),
+		cmp.Compare(a.Name, b.Name),
+	)
+}
+
+// hopsKey orders programs of unknown distance last
+func hopsKey(program neo4j.ProgramDetails) int {
+	if program.Hops == nil {
+		return math.MaxInt
+	}
+	return *program.Hops
+}
+
+// levelKey orders programs of unknown level last
+func levelKey(program neo4j.ProgramDetails) int {
+	if program.Level <= 0 {
+		return math.MaxInt
+	}
+	return program.Level
+}
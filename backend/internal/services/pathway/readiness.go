@@ -0,0 +1,301 @@
+package pathway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// Bounds on the student profile a readiness assessment is asked for
+const (
+	maxProfileQualifications = 20
+	maxProfileSubjects       = 15
+	maxStudyHoursPerWeek     = 84
+
+	// maxPrepWeeks caps the weeks suggested to prepare in one subject
+	maxPrepWeeks = 52
+)
+
+// Grades is the set of examination grades a subject result may have, as given in
+// G.C.E. O/L and A/L results
+var Grades = []string{"A", "B", "C", "S", "W", "F"}
+
+var (
+	// ErrInvalidProfile is returned for readiness requests with an empty or
+	// malformed student profile
+	ErrInvalidProfile = errors.New("invalid student profile")
+
+	// ErrReadinessUnavailable is returned when an assessment is not cached and
+	// the LLM cannot generate one
+	ErrReadinessUnavailable = errors.New("readiness assessment unavailable")
+)
+
+// ReadinessRequest is the student profile a program readiness assessment is made for
+type ReadinessRequest struct {
+	Qualifications    []string           `json:"qualifications"`
+	Subjects          []llm.SubjectGrade `json:"subjects"`
+	StudyHoursPerWeek int                `json:"study_hours_per_week"`
+}
+
+// Readiness is how ready a student is for a program. The requirements met and
+// unmet come from the graph; the rest of the assessment is generated from them.
+type Readiness struct {
+	ProgramName       string   `json:"program_name"`
+	Institute         string   `json:"institute"`
+	ProfileHash       string   `json:"profile_hash"`
+	MetRequirements   []string `json:"met_requirements"`
+	UnmetRequirements []string `json:"unmet_requirements"`
+	llm.ReadinessAssessment
+	TotalPrepWeeks int       `json:"total_prep_weeks"`
+	GeneratedAt    time.Time `json:"generated_at"`
+	Cached         bool      `json:"cached"`
+}
+
+// GetReadinessAssessment assesses how ready a student is for a program, with
+// their strong and weak areas and the weeks to prepare per subject. Assessments
+// are cached per profile and program.
+func (s *Service) GetReadinessAssessment(ctx context.Context, programName string, req ReadinessRequest) (*Readiness, error) {
+	s.logger.Debug("Assessing program readiness",
+		zap.String("program", programName),
+		zap.Int("qualifications", len(req.Qualifications)),
+		zap.Int("subjects", len(req.Subjects)))
+
+	req.Qualifications = s.resolveQualifications(ctx, req.Qualifications)
+	req.Subjects = slices.Clone(req.Subjects)
+	for i := range req.Subjects {
+		req.Subjects[i].Subject = s.resolveQualification(ctx, req.Subjects[i].Subject)
+	}
+	profile, err := normalizeProfile(req)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := profileHash(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash profile: %w", err)
+	}
+
+	cachedData, found, err := s.readiness.Get(ctx, hash, programName)
+	if err != nil {
+		s.logger.Warn("Readiness cache error, proceeding with generation",
+			zap.String("program", programName),
+			zap.Error(err))
+	}
+	if found {
+		var cached Readiness
+		if err := remarshal(cachedData, &cached); err == nil {
+			cached.Cached = true
+			return &cached, nil
+		}
+	}
+
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, []string{programName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check program: %w", err)
+	}
+	if !existing[programName] {
+		return nil, fmt.Errorf("%w: %q", ErrProgramNotFound, programName)
+	}
+	if !s.llmClient.Available() {
+		return nil, ErrReadinessUnavailable
+	}
+
+	details, err := s.GetProgramDetails(ctx, programName)
+	if err != nil {
+		return nil, err
+	}
+	program := readinessContext(details, profile.Qualifications)
+
+	assessment, err := s.llmClient.GenerateReadinessAssessment(ctx, program, profile)
+	if err != nil {
+		s.logger.Error("Failed to generate readiness assessment",
+			zap.String("program", programName),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to generate readiness assessment: %w", err)
+	}
+
+	readiness := &Readiness{
+		ProgramName:         details.Name,
+		Institute:           details.Institute,
+		ProfileHash:         hash,
+		MetRequirements:     program.MetRequirements,
+		UnmetRequirements:   program.UnmetRequirements,
+		ReadinessAssessment: groundAssessment(*assessment, len(program.UnmetRequirements) > 0),
+		GeneratedAt:         time.Now().UTC(),
+	}
+	for _, prep := range readiness.Preparation {
+		readiness.TotalPrepWeeks += prep.Weeks
+	}
+
+	s.logger.Info("Program readiness assessed",
+		zap.String("program", programName),
+		zap.String("level", readiness.Level),
+		zap.Int("prep_weeks", readiness.TotalPrepWeeks))
+
+	go s.cacheReadiness(hash, programName, readiness)
+
+	return readiness, nil
+}
+
+// cacheReadiness caches a generated readiness assessment asynchronously
+func (s *Service) cacheReadiness(hash, programName string, readiness *Readiness) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var data map[string]interface{}
+	if err := remarshal(readiness, &data); err != nil {
+		s.logger.Error("Failed to marshal readiness assessment for caching",
+			zap.String("program", programName),
+			zap.Error(err))
+		return
+	}
+
+	if err := s.readiness.Set(ctx, hash, programName, data); err != nil {
+		s.logger.Error("Failed to cache readiness assessment",
+			zap.String("program", programName),
+			zap.Error(err))
+	}
+}
+
+// normalizeProfile validates a student profile and puts it in a canonical form,
+// so that profiles differing only in case, spacing or order hash alike
+func normalizeProfile(req ReadinessRequest) (llm.ReadinessProfile, error) {
+	switch {
+	case len(req.Qualifications) == 0 && len(req.Subjects) == 0:
+		return llm.ReadinessProfile{}, fmt.Errorf("%w: give your qualifications or subject grades", ErrInvalidProfile)
+	case len(req.Qualifications) > maxProfileQualifications:
+		return llm.ReadinessProfile{}, fmt.Errorf("%w: at most %d qualifications", ErrInvalidProfile, maxProfileQualifications)
+	case len(req.Subjects) > maxProfileSubjects:
+		return llm.ReadinessProfile{}, fmt.Errorf("%w: at most %d subjects", ErrInvalidProfile, maxProfileSubjects)
+	case req.StudyHoursPerWeek < 0 || req.StudyHoursPerWeek > maxStudyHoursPerWeek:
+		return llm.ReadinessProfile{}, fmt.Errorf("%w: study_hours_per_week must be between 0 and %d", ErrInvalidProfile, maxStudyHoursPerWeek)
+	}
+
+	profile := llm.ReadinessProfile{
+		Qualifications:    []string{},
+		Subjects:          []llm.SubjectGrade{},
+		StudyHoursPerWeek: req.StudyHoursPerWeek,
+	}
+
+	seen := make(map[string]bool, len(req.Qualifications))
+	for _, qualification := range req.Qualifications {
+		qualification = strings.Join(strings.Fields(qualification), " ")
+		key := strings.ToLower(qualification)
+		if qualification == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		profile.Qualifications = append(profile.Qualifications, qualification)
+	}
+	sort.Slice(profile.Qualifications, func(i, j int) bool {
+		return strings.ToLower(profile.Qualifications[i]) < strings.ToLower(profile.Qualifications[j])
+	})
+
+	subjects := make(map[string]bool, len(req.Subjects))
+	for _, result := range req.Subjects {
+		subject := strings.Join(strings.Fields(result.Subject), " ")
+		grade := strings.ToUpper(strings.TrimSpace(result.Grade))
+		if subject == "" {
+			return llm.ReadinessProfile{}, fmt.Errorf("%w: every subject needs a name", ErrInvalidProfile)
+		}
+		if !slices.Contains(Grades, grade) {
+			return llm.ReadinessProfile{}, fmt.Errorf("%w: grade for %s must be one of %s", ErrInvalidProfile, subject, strings.Join(Grades, ", "))
+		}
+		if subjects[strings.ToLower(subject)] {
+			return llm.ReadinessProfile{}, fmt.Errorf("%w: %s is listed twice", ErrInvalidProfile, subject)
+		}
+		subjects[strings.ToLower(subject)] = true
+		profile.Subjects = append(profile.Subjects, llm.SubjectGrade{Subject: subject, Grade: grade})
+	}
+	sort.Slice(profile.Subjects, func(i, j int) bool {
+		return strings.ToLower(profile.Subjects[i].Subject) < strings.ToLower(profile.Subjects[j].Subject)
+	})
+	return profile, nil
+}
+
+// profileHash identifies a normalized profile without keeping its contents
+func profileHash(profile llm.ReadinessProfile) (string, error) {
+	encoded, err := json.Marshal(profile)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(string(encoded))))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// readinessContext splits a program's requirements into those the student's
+// qualifications meet and those they do not, alongside the rest of what the
+// graph knows about the program
+func readinessContext(details *neo4j.ProgramDetails, qualifications []string) llm.ReadinessContext {
+	program := llm.ReadinessContext{
+		ProgramName:       details.Name,
+		Institute:         details.Institute,
+		MetRequirements:   []string{},
+		UnmetRequirements: []string{},
+	}
+
+	held := make(map[string]bool, len(qualifications))
+	for _, qualification := range qualifications {
+		held[strings.ToLower(qualification)] = true
+	}
+	for _, requirement := range details.Requirements {
+		if held[strings.ToLower(requirement.Name)] {
+			program.MetRequirements = append(program.MetRequirements, requirement.Name)
+		} else {
+			program.UnmetRequirements = append(program.UnmetRequirements, requirement.Name)
+		}
+	}
+	for _, prerequisite := range details.Prerequisites {
+		program.Prerequisites = append(program.Prerequisites, prerequisite.Name)
+	}
+	for _, test := range details.AptitudeTests {
+		program.AptitudeTests = append(program.AptitudeTests, test.Name)
+	}
+	for _, career := range details.CareerPaths {
+		program.Careers = append(program.Careers, career.Title)
+	}
+	return program
+}
+
+// groundAssessment keeps a generated assessment consistent with the graph: a
+// student with unmet requirements is never rated ready, and preparation weeks
+// stay within bounds
+func groundAssessment(assessment llm.ReadinessAssessment, unmet bool) llm.ReadinessAssessment {
+	switch assessment.Level {
+	case llm.ReadinessReady, llm.ReadinessNearlyReady, llm.ReadinessNotReady:
+	default:
+		assessment.Level = llm.ReadinessNearlyReady
+	}
+	if unmet && assessment.Level == llm.ReadinessReady {
+		assessment.Level = llm.ReadinessNotReady
+	}
+
+	if assessment.StrongAreas == nil {
+		assessment.StrongAreas = []string{}
+	}
+	if assessment.WeakAreas == nil {
+		assessment.WeakAreas = []string{}
+	}
+
+	preparation := []llm.SubjectPreparation{}
+	for _, prep := range assessment.Preparation {
+		prep.Subject = strings.TrimSpace(prep.Subject)
+		if prep.Subject == "" || prep.Weeks <= 0 {
+			continue
+		}
+		prep.Weeks = min(prep.Weeks, maxPrepWeeks)
+		preparation = append(preparation, prep)
+	}
+	assessment.Preparation = preparation
+	return assessment
+}
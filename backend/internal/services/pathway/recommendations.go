@@ -0,0 +1,113 @@
+package pathway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+const (
+	// Number of recent views used as the basis for suggestions
+	recentActivityWindow = 10
+
+	// Maximum number of suggestions returned per entity type
+	maxActivitySuggestions = 10
+
+	// Related programs fetched per suggestion returned, leaving room to re-rank
+	// by competitiveness
+	programCandidateFactor = 2
+)
+
+// RecentActivityRecommendations contains suggestions based on a user's browsing history
+type RecentActivityRecommendations struct {
+	RecentViews []mongodb.EntityView  `json:"recent_views"`
+	Programs    []neo4j.RelatedEntity `json:"programs"`
+	Careers     []neo4j.RelatedEntity `json:"careers"`
+}
+
+// RecordView records that a user viewed a program or career.
+// Recording is best-effort and runs asynchronously so it never slows down reads.
+func (s *Service) RecordView(userID, entityType, name string) {
+	if userID == "" || name == "" {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := s.history.RecordView(ctx, userID, entityType, name); err != nil {
+			s.logger.Warn("Failed to record browsing history",
+				zap.String("entity_type", entityType),
+				zap.String("name", name),
+				zap.Error(err))
+		}
+	}()
+}
+
+// GetRecentActivityRecommendations suggests programs and careers adjacent in the
+// graph (same department, shared careers) to what the user viewed recently.
+// Accessible programs rank ahead of equally related competitive ones.
+func (s *Service) GetRecentActivityRecommendations(ctx context.Context, userID string) (*RecentActivityRecommendations, error) {
+	s.logger.Debug("Building recent activity recommendations")
+
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	views, err := s.history.RecentViews(ctx, userID, recentActivityWindow)
+	if err != nil {
+		s.logger.Error("Failed to fetch browsing history", zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch browsing history: %w", err)
+	}
+
+	recommendations := &RecentActivityRecommendations{
+		RecentViews: views,
+		Programs:    []neo4j.RelatedEntity{},
+		Careers:     []neo4j.RelatedEntity{},
+	}
+
+	if len(views) == 0 {
+		return recommendations, nil
+	}
+
+	programs := []string{}
+	careers := []string{}
+	for _, view := range views {
+		switch view.EntityType {
+		case mongodb.EntityTypeProgram:
+			programs = append(programs, view.Name)
+		case mongodb.EntityTypeCareer:
+			careers = append(careers, view.Name)
+		}
+	}
+
+	relatedPrograms, err := s.neo4jClient.GetRelatedPrograms(ctx, programs, careers, maxActivitySuggestions*programCandidateFactor)
+	if err != nil {
+		s.logger.Error("Failed to fetch related programs", zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch related programs: %w", err)
+	}
+	if relatedPrograms != nil {
+		recommendations.Programs = s.rankByCompetitiveness(ctx, relatedPrograms, maxActivitySuggestions)
+	}
+
+	relatedCareers, err := s.neo4jClient.GetRelatedCareers(ctx, programs, careers, maxActivitySuggestions)
+	if err != nil {
+		s.logger.Error("Failed to fetch related careers", zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch related careers: %w", err)
+	}
+	if relatedCareers != nil {
+		recommendations.Careers = relatedCareers
+	}
+
+	s.logger.Info("Successfully built recent activity recommendations",
+		zap.Int("recent_views", len(views)),
+		zap.Int("programs", len(recommendations.Programs)),
+		zap.Int("careers", len(recommendations.Careers)))
+
+	return recommendations, nil
+}
@@ -0,0 +1,98 @@
+package pathway
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mayura-andrew/fastfinder/internal/services/resources"
+)
+
+// ResourceCost is what the resources suggested for a roadmap, or one of its
+// steps, cost to follow. Videos come from YouTube and count as free.
+type ResourceCost struct {
+	Free           int `json:"free"`
+	Paid           int `json:"paid"`
+	UnknownPricing int `json:"unknown_pricing"`
+	// Totals are the known prices of the paid resources summed per currency
+	Totals []resources.Price `json:"totals"`
+	// Complete reports whether Totals is the whole cost: every paid resource
+	// has a price and none is of unknown pricing
+	Complete bool `json:"complete"`
+}
+
+// PriceResources returns a copy of roadmap with every suggested resource tagged
+// with its pricing and the cost of resources of each step and of the roadmap.
+// In free-only mode, resources not known to be free are left out. The roadmap
+// itself, which may be cached, is not modified.
+func PriceResources(roadmap *LearningRoadmapResponse, freeOnly bool) *LearningRoadmapResponse {
+	priced := *roadmap
+	priced.Steps = make([]LearningStepWithVideos, len(roadmap.Steps))
+
+	var all []resources.Resource
+	videos := 0
+	for i, step := range roadmap.Steps {
+		step.Resources = tagResources(step.Resources, freeOnly)
+		step.Cost = costOf(len(step.Videos), step.Resources)
+		priced.Steps[i] = step
+
+		all = append(all, step.Resources...)
+		videos += len(step.Videos)
+	}
+	priced.TotalCost = costOf(videos, all)
+	return &priced
+}
+
+// tagResources returns a copy of found with untagged resources marked as of
+// unknown pricing, holding only free resources in free-only mode
+func tagResources(found []resources.Resource, freeOnly bool) []resources.Resource {
+	if len(found) == 0 {
+		return found
+	}
+	tagged := make([]resources.Resource, 0, len(found))
+	for _, resource := range found {
+		switch resource.Pricing {
+		case resources.PricingFree, resources.PricingPaid:
+		default:
+			resource.Pricing = resources.PricingUnknown
+		}
+		if resource.Pricing != resources.PricingPaid {
+			resource.Price = nil
+		}
+		if freeOnly && resource.Pricing != resources.PricingFree {
+			continue
+		}
+		tagged = append(tagged, resource)
+	}
+	return tagged
+}
+
+// costOf sums the cost of videos and tagged resources
+func costOf(videos int, tagged []resources.Resource) *ResourceCost {
+	cost := &ResourceCost{Free: videos, Totals: []resources.Price{}}
+	totals := make(map[string]float64)
+	unpriced := 0
+	for _, resource := range tagged {
+		switch resource.Pricing {
+		case resources.PricingFree:
+			cost.Free++
+		case resources.PricingPaid:
+			cost.Paid++
+			if resource.Price == nil || resource.Price.Currency == "" {
+				unpriced++
+				continue
+			}
+			totals[resource.Price.Currency] += resource.Price.Amount
+		default:
+			cost.UnknownPricing++
+		}
+	}
+
+	for currency, amount := range totals {
+		cost.Totals = append(cost.Totals, resources.Price{Amount: math.Round(amount*100) / 100, Currency: currency})
+	}
+	sort.Slice(cost.Totals, func(i, j int) bool {
+		return cost.Totals[i].Currency < cost.Totals[j].Currency
+	})
+	cost.Complete = unpriced == 0 && cost.UnknownPricing == 0
+	return cost
+}
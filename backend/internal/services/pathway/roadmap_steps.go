@@ -0,0 +1,71 @@
+package pathway
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidStepRange is returned for a roadmap step range that cannot be parsed
+var ErrInvalidStepRange = errors.New("invalid step range")
+
+// StepRange selects roadmap steps by position, counting from 1, so clients can
+// load the later steps of a long roadmap lazily. To is 0 for every step from
+// From on; the zero StepRange selects all steps.
+type StepRange struct {
+	From int
+	To   int
+}
+
+// ParseStepRange parses a step range written as "2", "1-3" or "4-". An empty
+// string selects all steps.
+func ParseStepRange(value string) (StepRange, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return StepRange{}, nil
+	}
+
+	from, to, isRange := strings.Cut(value, "-")
+	r := StepRange{}
+	var err error
+	if r.From, err = strconv.Atoi(strings.TrimSpace(from)); err != nil || r.From < 1 {
+		return StepRange{}, fmt.Errorf("%w: %q", ErrInvalidStepRange, value)
+	}
+	switch {
+	case !isRange:
+		r.To = r.From
+	case strings.TrimSpace(to) == "":
+		r.To = 0
+	default:
+		if r.To, err = strconv.Atoi(strings.TrimSpace(to)); err != nil || r.To < r.From {
+			return StepRange{}, fmt.Errorf("%w: %q", ErrInvalidStepRange, value)
+		}
+	}
+	return r, nil
+}
+
+// All reports whether the range selects every step
+func (r StepRange) All() bool {
+	return r.From <= 1 && r.To == 0
+}
+
+// Apply returns a copy of roadmap holding only the steps in the range, and
+// whether steps after the range were left out. A range past the last step
+// leaves no steps. The roadmap itself, which may be cached, is not modified.
+func (r StepRange) Apply(roadmap *LearningRoadmapResponse) (*LearningRoadmapResponse, bool) {
+	if r.All() {
+		return roadmap, false
+	}
+
+	total := len(roadmap.Steps)
+	start := min(max(r.From, 1)-1, total)
+	end := total
+	if r.To > 0 {
+		end = min(r.To, total)
+	}
+
+	sliced := *roadmap
+	sliced.Steps = roadmap.Steps[start:end:end]
+	return &sliced, end < total
+}
@@ -0,0 +1,84 @@
+package pathway
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"go.uber.org/zap"
+)
+
+// ErrRoadmapUnavailable is returned when the LLM cannot generate a roadmap and
+// there is no curated template for the program
+var ErrRoadmapUnavailable = errors.New("learning roadmap unavailable")
+
+//go:embed templates/roadmaps.json
+var roadmapTemplatesJSON []byte
+
+// roadmapTemplate is a curated, non-personalized roadmap for one of the most
+// viewed programs. Aliases are other names the program is listed under.
+type roadmapTemplate struct {
+	llm.LearningRoadmap
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// roadmapTemplates indexes the bundled templates by normalized program name
+var roadmapTemplates = sync.OnceValues(func() (map[string]*llm.LearningRoadmap, error) {
+	var templates []roadmapTemplate
+	if err := json.Unmarshal(roadmapTemplatesJSON, &templates); err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*llm.LearningRoadmap, len(templates))
+	for i := range templates {
+		roadmap := &templates[i].LearningRoadmap
+		byName[templateKey(roadmap.ProgramName)] = roadmap
+		for _, alias := range templates[i].Aliases {
+			byName[templateKey(alias)] = roadmap
+		}
+	}
+	return byName, nil
+})
+
+func templateKey(programName string) string {
+	return strings.ToLower(strings.Join(strings.Fields(programName), " "))
+}
+
+// generateRoadmap asks the LLM for a roadmap of the program. Hedged requests
+// race the hedge model against the primary one when one is configured, and the
+// primary model's roadmap is cached once it arrives. While the LLM is not
+// configured, its circuit is open or the caller has no generations left, the
+// program's curated template is returned instead and template is true.
+func (s *Service) generateRoadmap(ctx context.Context, programName string, prerequisites []string, hedged bool) (roadmap *llm.LearningRoadmap, template bool, err error) {
+	if s.llmClient.Available() {
+		if hedged {
+			roadmap, err = s.llmClient.GenerateLearningRoadmapHedged(ctx, programName, prerequisites, func(primary *llm.LearningRoadmap) {
+				s.cacheGeneratedRoadmap(programName, primary)
+			})
+		} else {
+			roadmap, err = s.llmClient.GenerateLearningRoadmap(ctx, programName, prerequisites)
+		}
+		if !errors.Is(err, llm.ErrCircuitOpen) && !errors.Is(err, llm.ErrGenerationLimit) {
+			return roadmap, false, err
+		}
+	}
+
+	templates, err := roadmapTemplates()
+	if err != nil {
+		s.logger.Error("Failed to load roadmap templates", zap.Error(err))
+		return nil, false, ErrRoadmapUnavailable
+	}
+	found, ok := templates[templateKey(programName)]
+	if !ok {
+		return nil, false, ErrRoadmapUnavailable
+	}
+
+	s.logger.Warn("LLM unavailable, serving roadmap template",
+		zap.String("program", programName))
+	copied := *found
+	return &copied, true, nil
+}
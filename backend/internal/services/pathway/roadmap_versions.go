@@ -0,0 +1,224 @@
+package pathway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.uber.org/zap"
+)
+
+// ErrRoadmapVersionNotFound is returned for versions of a roadmap never stored
+var ErrRoadmapVersionNotFound = errors.New("roadmap version not found")
+
+// RoadmapDiff is what changed in a program's roadmap between two versions.
+// Steps are matched by title, so a step moved or renumbered counts as changed.
+type RoadmapDiff struct {
+	ProgramName   string            `json:"program_name"`
+	From          int               `json:"from"`
+	To            int               `json:"to"`
+	ChangedFields []string          `json:"changed_fields"` // of the roadmap itself, e.g. overview
+	StepsAdded    []RoadmapStepRef  `json:"steps_added"`
+	StepsRemoved  []RoadmapStepRef  `json:"steps_removed"`
+	StepsChanged  []RoadmapStepDiff `json:"steps_changed"`
+}
+
+// RoadmapStepRef names a step of one version
+type RoadmapStepRef struct {
+	StepNumber int    `json:"step_number"`
+	Title      string `json:"title"`
+}
+
+// RoadmapStepDiff is a step found in both versions whose content changed
+type RoadmapStepDiff struct {
+	Title         string   `json:"title"`
+	FromStep      int      `json:"from_step"`
+	ToStep        int      `json:"to_step"`
+	ChangedFields []string `json:"changed_fields"`
+}
+
+// storeRoadmapVersion keeps a roadmap as the program's next version and returns
+// its number, or 0 without a version store. Only the roadmap's own content is
+// compared, so fetching different videos for the same steps is not a new version.
+func (s *Service) storeRoadmapVersion(ctx context.Context, programName string, response *LearningRoadmapResponse) (int, error) {
+	if s.versions == nil {
+		return 0, nil
+	}
+
+	content := *response
+	content.Version = 0
+	content.Steps = make([]LearningStepWithVideos, len(response.Steps))
+	for i, step := range response.Steps {
+		step.Videos, step.Resources = nil, nil
+		content.Steps[i] = step
+	}
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		return 0, err
+	}
+	sum := sha256.Sum256(encoded)
+
+	data, err := s.marshalRoadmapForCache(response)
+	if err != nil {
+		return 0, err
+	}
+	return s.versions.Append(ctx, programName, hex.EncodeToString(sum[:]), len(response.Steps), data)
+}
+
+// ListRoadmapVersions returns the stored versions of a program's roadmap, newest first
+func (s *Service) ListRoadmapVersions(ctx context.Context, programName string) ([]mongodb.RoadmapVersion, error) {
+	s.logger.Debug("Listing roadmap versions", zap.String("program", programName))
+
+	versions, err := s.versions.List(ctx, programName)
+	if err != nil {
+		s.logger.Error("Failed to list roadmap versions",
+			zap.String("program", programName),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list roadmap versions: %w", err)
+	}
+	return versions, nil
+}
+
+// LatestRoadmapVersion returns the number of the program's latest roadmap
+// version, or 0 when none is stored
+func (s *Service) LatestRoadmapVersion(ctx context.Context, programName string) (int, error) {
+	return s.versions.Latest(ctx, programName)
+}
+
+// GetRoadmapVersion returns a stored version of a program's roadmap
+func (s *Service) GetRoadmapVersion(ctx context.Context, programName string, version int) (*LearningRoadmapResponse, error) {
+	s.logger.Debug("Fetching roadmap version",
+		zap.String("program", programName),
+		zap.Int("version", version))
+
+	stored, err := s.versions.Get(ctx, programName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roadmap version: %w", err)
+	}
+	if stored == nil {
+		return nil, fmt.Errorf("%w: %s version %d", ErrRoadmapVersionNotFound, programName, version)
+	}
+
+	response, err := s.unmarshalCachedRoadmap(stored.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid roadmap version data: %w", err)
+	}
+	response.Version = stored.Version
+	return response, nil
+}
+
+// DiffRoadmapVersions compares two versions of a program's roadmap
+func (s *Service) DiffRoadmapVersions(ctx context.Context, programName string, from, to int) (*RoadmapDiff, error) {
+	s.logger.Debug("Comparing roadmap versions",
+		zap.String("program", programName),
+		zap.Int("from", from),
+		zap.Int("to", to))
+
+	older, err := s.GetRoadmapVersion(ctx, programName, from)
+	if err != nil {
+		return nil, err
+	}
+	newer, err := s.GetRoadmapVersion(ctx, programName, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &RoadmapDiff{
+		ProgramName:   programName,
+		From:          from,
+		To:            to,
+		ChangedFields: changedRoadmapFields(older, newer),
+		StepsAdded:    []RoadmapStepRef{},
+		StepsRemoved:  []RoadmapStepRef{},
+		StepsChanged:  []RoadmapStepDiff{},
+	}
+
+	olderSteps := make(map[string]LearningStepWithVideos, len(older.Steps))
+	for _, step := range older.Steps {
+		olderSteps[stepKey(step.Title)] = step
+	}
+	matched := make(map[string]bool, len(newer.Steps))
+	for _, step := range newer.Steps {
+		key := stepKey(step.Title)
+		previous, ok := olderSteps[key]
+		if !ok {
+			diff.StepsAdded = append(diff.StepsAdded, RoadmapStepRef{StepNumber: step.StepNumber, Title: step.Title})
+			continue
+		}
+		matched[key] = true
+		if fields := changedStepFields(previous, step); len(fields) > 0 {
+			diff.StepsChanged = append(diff.StepsChanged, RoadmapStepDiff{
+				Title:         step.Title,
+				FromStep:      previous.StepNumber,
+				ToStep:        step.StepNumber,
+				ChangedFields: fields,
+			})
+		}
+	}
+	for _, step := range older.Steps {
+		if !matched[stepKey(step.Title)] {
+			diff.StepsRemoved = append(diff.StepsRemoved, RoadmapStepRef{StepNumber: step.StepNumber, Title: step.Title})
+		}
+	}
+
+	s.logger.Info("Roadmap versions compared",
+		zap.String("program", programName),
+		zap.Int("from", from),
+		zap.Int("to", to),
+		zap.Int("added", len(diff.StepsAdded)),
+		zap.Int("removed", len(diff.StepsRemoved)),
+		zap.Int("changed", len(diff.StepsChanged)))
+	return diff, nil
+}
+
+func stepKey(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// changedRoadmapFields names the fields of the roadmap itself that differ
+func changedRoadmapFields(a, b *LearningRoadmapResponse) []string {
+	fields := []string{}
+	if a.Overview != b.Overview {
+		fields = append(fields, "overview")
+	}
+	if a.TotalDuration != b.TotalDuration {
+		fields = append(fields, "total_duration")
+	}
+	if !slices.Equal(a.Prerequisites, b.Prerequisites) {
+		fields = append(fields, "prerequisites")
+	}
+	if !slices.Equal(a.KeySkills, b.KeySkills) {
+		fields = append(fields, "key_skills")
+	}
+	if a.RecommendedFor != b.RecommendedFor {
+		fields = append(fields, "recommended_for")
+	}
+	return fields
+}
+
+// changedStepFields names the fields of a step that differ, videos aside
+func changedStepFields(a, b LearningStepWithVideos) []string {
+	var fields []string
+	if a.StepNumber != b.StepNumber {
+		fields = append(fields, "step_number")
+	}
+	if a.Description != b.Description {
+		fields = append(fields, "description")
+	}
+	if !slices.Equal(a.Topics, b.Topics) {
+		fields = append(fields, "topics")
+	}
+	if a.Duration != b.Duration {
+		fields = append(fields, "duration")
+	}
+	if a.Difficulty != b.Difficulty {
+		fields = append(fields, "difficulty")
+	}
+	return fields
+}
@@ -0,0 +1,200 @@
+package pathway
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxWorkExperiences is the most jobs one recognition request may declare
+	MaxWorkExperiences = 10
+
+	// maxExperienceYears bounds the years declared for one job
+	maxExperienceYears = 50
+)
+
+// ErrInvalidExperience is returned for declared work experience that cannot be
+// assessed
+var ErrInvalidExperience = errors.New("invalid work experience")
+
+//go:embed rpl/rules.json
+var rplRulesJSON []byte
+
+// RPLLevel is the NVQ level recognized after a number of years in a sector
+type RPLLevel struct {
+	MinYears float64 `json:"min_years"`
+	NVQLevel int     `json:"nvq_level"`
+}
+
+// RPLRule is how recognition of prior learning (RPL) treats work in one sector:
+// the occupations it covers and the NVQ level its years of experience are
+// usually assessed as equivalent to, lowest first
+type RPLRule struct {
+	ID       string     `json:"id"`
+	Sector   string     `json:"sector"`
+	Keywords []string   `json:"occupation_keywords"`
+	Levels   []RPLLevel `json:"levels"`
+}
+
+// rplRules are the bundled RPL rules, and the graph qualifications each NVQ
+// level stands for
+type rplRules struct {
+	Rules []RPLRule `json:"rules"`
+	// Qualifications by NVQ level; a level also stands for those of the levels
+	// below it
+	Qualifications map[string][]string `json:"nvq_qualifications"`
+}
+
+var bundledRPLRules = sync.OnceValues(func() (*rplRules, error) {
+	var rules rplRules
+	if err := json.Unmarshal(rplRulesJSON, &rules); err != nil {
+		return nil, err
+	}
+	for _, rule := range rules.Rules {
+		if !slices.IsSortedFunc(rule.Levels, func(a, b RPLLevel) int { return cmp.Compare(a.MinYears, b.MinYears) }) {
+			return nil, fmt.Errorf("RPL rule %s has levels out of order", rule.ID)
+		}
+	}
+	return &rules, nil
+})
+
+// WorkExperience is a job someone has done and for how many years
+type WorkExperience struct {
+	Occupation string  `json:"occupation"`
+	Years      float64 `json:"years"`
+}
+
+// RecognizedLearning is the NVQ level the experience in one sector is likely to
+// be recognized as, and the qualifications in the graph that level stands for
+type RecognizedLearning struct {
+	Sector         string   `json:"sector"`
+	Occupations    []string `json:"occupations"`
+	Years          float64  `json:"years"`
+	NVQLevel       int      `json:"nvq_level"`
+	Qualifications []string `json:"qualifications"`
+}
+
+// PriorLearning is the outcome of assessing declared work experience
+type PriorLearning struct {
+	Recognized []RecognizedLearning `json:"recognized"`
+	// Unrecognized lists occupations no rule covers, or with too few years
+	Unrecognized []string `json:"unrecognized"`
+	// Qualifications are the declared qualifications and the recognized ones,
+	// as eligibility is checked against
+	Qualifications []string `json:"qualifications"`
+	Disclaimer     string   `json:"disclaimer"`
+}
+
+const rplDisclaimer = "Recognition of prior learning is awarded after a skills assessment at a TVEC accredited RPL centre. These levels are what similar experience is usually assessed as, not a certificate."
+
+// RecognizePriorLearning turns declared work experience into the NVQ levels it
+// is likely to be recognized as, and adds the qualifications those levels stand
+// for to the declared ones. Years in the same sector add up.
+func (s *Service) RecognizePriorLearning(ctx context.Context, experience []WorkExperience, qualifications []string) (*PriorLearning, error) {
+	s.logger.Debug("Recognizing prior learning", zap.Int("experiences", len(experience)))
+
+	if len(experience) == 0 || len(experience) > MaxWorkExperiences {
+		return nil, fmt.Errorf("%w: between 1 and %d jobs are required", ErrInvalidExperience, MaxWorkExperiences)
+	}
+	rules, err := bundledRPLRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load RPL rules: %w", err)
+	}
+
+	result := &PriorLearning{
+		Recognized:   []RecognizedLearning{},
+		Unrecognized: []string{},
+		Disclaimer:   rplDisclaimer,
+	}
+	bySector := make(map[string]*RecognizedLearning)
+	var sectors []*RPLRule
+	for _, job := range experience {
+		occupation := strings.TrimSpace(job.Occupation)
+		if occupation == "" {
+			return nil, fmt.Errorf("%w: every job needs an occupation", ErrInvalidExperience)
+		}
+		if job.Years <= 0 || job.Years > maxExperienceYears {
+			return nil, fmt.Errorf("%w: years for %q must be between 0 and %d", ErrInvalidExperience, occupation, maxExperienceYears)
+		}
+
+		rule := matchRPLRule(rules.Rules, occupation)
+		if rule == nil {
+			result.Unrecognized = append(result.Unrecognized, occupation)
+			continue
+		}
+		recognized, ok := bySector[rule.ID]
+		if !ok {
+			recognized = &RecognizedLearning{Sector: rule.Sector}
+			bySector[rule.ID] = recognized
+			sectors = append(sectors, rule)
+		}
+		recognized.Occupations = append(recognized.Occupations, occupation)
+		recognized.Years += job.Years
+	}
+
+	for _, rule := range sectors {
+		recognized := bySector[rule.ID]
+		for _, level := range rule.Levels {
+			if recognized.Years >= level.MinYears {
+				recognized.NVQLevel = level.NVQLevel
+			}
+		}
+		if recognized.NVQLevel == 0 {
+			result.Unrecognized = append(result.Unrecognized, recognized.Occupations...)
+			continue
+		}
+		recognized.Qualifications = []string{}
+		for level := 1; level <= recognized.NVQLevel; level++ {
+			recognized.Qualifications = append(recognized.Qualifications, rules.Qualifications[strconv.Itoa(level)]...)
+		}
+		result.Recognized = append(result.Recognized, *recognized)
+	}
+
+	result.Qualifications = s.resolveQualifications(ctx, qualifications)
+	for _, recognized := range result.Recognized {
+		for _, q := range recognized.Qualifications {
+			if !slices.Contains(result.Qualifications, q) {
+				result.Qualifications = append(result.Qualifications, q)
+			}
+		}
+	}
+	s.logger.Info("Prior learning recognized",
+		zap.Int("sectors", len(result.Recognized)),
+		zap.Int("unrecognized", len(result.Unrecognized)),
+		zap.Int("qualifications", len(result.Qualifications)))
+	return result, nil
+}
+
+// matchRPLRule returns the rule whose occupation keywords the occupation
+// contains as whole words most often, the first on a tie, or nil when none
+// match
+func matchRPLRule(rules []RPLRule, occupation string) *RPLRule {
+	words := " " + strings.Join(strings.FieldsFunc(strings.ToLower(occupation), func(r rune) bool {
+		return !('a' <= r && r <= 'z') && !('0' <= r && r <= '9')
+	}), " ") + " "
+
+	var best *RPLRule
+	bestHits := 0
+	for i := range rules {
+		hits := 0
+		for _, keyword := range rules[i].Keywords {
+			if strings.Contains(words, " "+keyword+" ") {
+				hits++
+			}
+		}
+		if hits > bestHits {
+			best, bestHits = &rules[i], hits
+		}
+	}
+	return best
+}
@@ -0,0 +1,77 @@
+package pathway
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"go.uber.org/zap"
+)
+
+const (
+	// SalarySourceJobBoards marks salary figures taken from advertised salaries
+	SalarySourceJobBoards = "job_boards"
+
+	// SalarySourceEstimate marks salary figures estimated by the LLM
+	SalarySourceEstimate = "llm_estimate"
+)
+
+// withMarketSalary returns job role details whose salary expectations come from
+// job board data when any has been collected for the role, falling back to the
+// LLM's estimate. The details passed in are never modified, as they may still be
+// in the process of being cached.
+func (s *Service) withMarketSalary(ctx context.Context, roleName string, details *llm.JobRoleDetails) *llm.JobRoleDetails {
+	result := *details
+	if result.SalaryInfo.Source == "" {
+		result.SalaryInfo.Source = SalarySourceEstimate
+	}
+
+	salary, err := s.salaries.Get(ctx, roleName)
+	if err != nil {
+		s.logger.Warn("Failed to fetch job board salaries",
+			zap.String("role", roleName),
+			zap.Error(err))
+		return &result
+	}
+	if salary == nil || salary.SampleSize == 0 {
+		return &result
+	}
+
+	collectedAt := salary.CollectedAt
+	result.SalaryInfo = llm.SalaryInfo{
+		EntryLevel:  formatSalaryRange(salary.Currency, salary.Min, salary.P25),
+		MidLevel:    formatSalaryRange(salary.Currency, salary.P25, salary.P75),
+		SeniorLevel: formatSalaryRange(salary.Currency, salary.P75, salary.Max),
+		Currency:    salary.Currency,
+		Source:      SalarySourceJobBoards,
+		SampleSize:  salary.SampleSize,
+		CollectedAt: &collectedAt,
+	}
+	return &result
+}
+
+// formatSalaryRange renders a monthly range such as "LKR 45,000 - 60,000 per month"
+func formatSalaryRange(currency string, low, high int64) string {
+	if low == high {
+		return fmt.Sprintf("%s %s per month", currency, groupThousands(low))
+	}
+	return fmt.Sprintf("%s %s - %s per month", currency, groupThousands(low), groupThousands(high))
+}
+
+// groupThousands formats a number with comma thousands separators
+func groupThousands(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	if n < 0 {
+		return "-" + groupThousands(-n)
+	}
+
+	var out []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, digits[i])
+	}
+	return string(out)
+}
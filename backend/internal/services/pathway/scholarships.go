@@ -0,0 +1,57 @@
+package pathway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// ListScholarships returns the scholarships in the graph matching a filter:
+// those open to a household's monthly income and district when given
+func (s *Service) ListScholarships(ctx context.Context, filter neo4j.ScholarshipFilter) ([]neo4j.Scholarship, error) {
+	s.logger.Debug("Listing scholarships",
+		zap.String("district", filter.District),
+		zap.Int64("monthly_income", filter.MonthlyIncomeLKR))
+
+	if filter.MonthlyIncomeLKR < 0 {
+		return nil, fmt.Errorf("%w: monthly income cannot be negative", ErrInvalidFilter)
+	}
+
+	scholarships, err := s.neo4jClient.ListScholarships(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list scholarships", zap.Error(err))
+		return nil, fmt.Errorf("failed to list scholarships: %w", err)
+	}
+
+	s.logger.Info("Successfully listed scholarships", zap.Int("count", len(scholarships)))
+	return scholarships, nil
+}
+
+// GetProgramScholarships returns the scholarships funding a program, directly
+// or through the institute offering it
+func (s *Service) GetProgramScholarships(ctx context.Context, programName string) ([]neo4j.Scholarship, error) {
+	s.logger.Debug("Fetching program scholarships", zap.String("program", programName))
+
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, []string{programName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check program: %w", err)
+	}
+	if !existing[programName] {
+		return nil, fmt.Errorf("%w: %q", ErrProgramNotFound, programName)
+	}
+
+	scholarships, err := s.neo4jClient.ProgramScholarships(ctx, programName)
+	if err != nil {
+		s.logger.Error("Failed to fetch program scholarships",
+			zap.String("program", programName),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch program scholarships: %w", err)
+	}
+
+	s.logger.Info("Successfully fetched program scholarships",
+		zap.String("program", programName),
+		zap.Int("count", len(scholarships)))
+	return scholarships, nil
+}
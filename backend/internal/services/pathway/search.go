@@ -0,0 +1,252 @@
+package pathway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/experiment"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// MinSearchLength is the shortest query searched
+	MinSearchLength = 2
+
+	// Defaults and bounds for the number of results per entity type
+	DefaultSearchResults = 5
+	MaxSearchResults     = 25
+
+	// DefaultFuzzySearchResults is the number of results of a fuzzy search
+	// across all types when no limit is given
+	DefaultFuzzySearchResults = 10
+)
+
+// searchWeights score the ways a query can match a name, best first
+type searchWeights struct {
+	exact, prefix, wordPrefix, contains float64
+}
+
+// Search weights by variant of the search ranking experiment
+var (
+	defaultSearchWeights   = searchWeights{exact: 1.0, prefix: 0.9, wordPrefix: 0.8, contains: 0.6}
+	wordMatchSearchWeights = searchWeights{exact: 1.0, prefix: 0.85, wordPrefix: 0.85, contains: 0.5}
+)
+
+// ErrInvalidSearch is returned for a query that is too short to search
+var ErrInvalidSearch = errors.New("invalid search")
+
+// searchKinds are the entity types covered by the global search, in the order
+// their groups are listed when equally relevant
+var searchKinds = []string{
+	neo4j.KindInstitute,
+	neo4j.KindProgram,
+	neo4j.KindCareer,
+	neo4j.KindDepartment,
+	neo4j.KindQualification,
+}
+
+// SearchResult is an entity matching a global search. Link is the API path with
+// the entity's details; qualifications have no page of their own and no link.
+type SearchResult struct {
+	Type      string  `json:"type"`
+	Name      string  `json:"name"`
+	Institute string  `json:"institute,omitempty"`
+	Score     float64 `json:"score"`
+	Link      string  `json:"link,omitempty"`
+}
+
+// SearchGroup holds the results of one entity type, best first
+type SearchGroup struct {
+	Type    string         `json:"type"`
+	Results []SearchResult `json:"results"`
+}
+
+// SearchResults is the outcome of a global search. Groups without results are
+// left out and the rest are ordered by their best result.
+type SearchResults struct {
+	Query  string        `json:"query"`
+	Total  int           `json:"total"`
+	Groups []SearchGroup `json:"groups"`
+}
+
+// Search finds institutes, programs, careers, departments and qualifications
+// whose names contain every word of the query, querying each type in parallel.
+// A query that is a qualification alias searches for what it stands for.
+func (s *Service) Search(ctx context.Context, query string, limit int) (*SearchResults, error) {
+	s.logger.Debug("Searching all entity types", zap.String("query", query), zap.Int("limit", limit))
+
+	query = s.resolveQualification(ctx, query)
+	if len([]rune(query)) < MinSearchLength {
+		return nil, fmt.Errorf("%w: type at least %d characters", ErrInvalidSearch, MinSearchLength)
+	}
+	if limit <= 0 {
+		limit = DefaultSearchResults
+	}
+	if limit > MaxSearchResults {
+		limit = MaxSearchResults
+	}
+
+	lower := strings.ToLower(query)
+	words := strings.Fields(lower)
+
+	weights := defaultSearchWeights
+	if experiment.Variant(ctx, experiment.SearchRanking) == experiment.WordMatch {
+		weights = wordMatchSearchWeights
+	}
+
+	groups := make([]SearchGroup, len(searchKinds))
+	g, gCtx := errgroup.WithContext(ctx)
+	for i, kind := range searchKinds {
+		g.Go(func() error {
+			matches, err := s.neo4jClient.SearchNames(gCtx, kind, words, limit)
+			if err != nil {
+				return err
+			}
+			groups[i] = SearchGroup{Type: kind, Results: rankSearchMatches(kind, lower, words, matches, weights)}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	results := &SearchResults{Query: query, Groups: []SearchGroup{}}
+	for _, group := range groups {
+		if len(group.Results) == 0 {
+			continue
+		}
+		results.Groups = append(results.Groups, group)
+		results.Total += len(group.Results)
+	}
+	// Stable, so equally relevant groups keep the order of searchKinds
+	sort.SliceStable(results.Groups, func(i, j int) bool {
+		return results.Groups[i].Results[0].Score > results.Groups[j].Results[0].Score
+	})
+
+	s.logger.Info("Search completed",
+		zap.String("query", query),
+		zap.Int("groups", len(results.Groups)),
+		zap.Int("results", results.Total))
+	return results, nil
+}
+
+// SearchEntities finds institutes, programs, qualifications and careers (or
+// only the given types) whose names match the query despite typos, ranked
+// across types. Scores are relative to the best match, which scores 1. Aliases
+// are resolved as by Search.
+func (s *Service) SearchEntities(ctx context.Context, query string, types []string, limit int) ([]SearchResult, error) {
+	s.logger.Debug("Fuzzy searching entities",
+		zap.String("query", query),
+		zap.Strings("types", types),
+		zap.Int("limit", limit))
+
+	query = s.resolveQualification(ctx, query)
+	if len([]rune(query)) < MinSearchLength {
+		return nil, fmt.Errorf("%w: type at least %d characters", ErrInvalidSearch, MinSearchLength)
+	}
+	for _, kind := range types {
+		if !slices.Contains(neo4j.SearchableKinds, kind) {
+			return nil, fmt.Errorf("%w: type must be one of %s", ErrInvalidSearch, strings.Join(neo4j.SearchableKinds, ", "))
+		}
+	}
+	if limit <= 0 {
+		limit = DefaultFuzzySearchResults
+	}
+	if limit > MaxSearchResults {
+		limit = MaxSearchResults
+	}
+
+	hits, err := s.neo4jClient.SearchEntities(ctx, query, types, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, SearchResult{
+			Type:      hit.Kind,
+			Name:      hit.Name,
+			Institute: hit.Institute,
+			Score:     hit.Score / hits[0].Score,
+			Link:      entityLink(hit.Kind, hit.Name),
+		})
+	}
+
+	s.logger.Info("Fuzzy search completed",
+		zap.String("query", query),
+		zap.Int("results", len(results)))
+	return results, nil
+}
+
+// rankSearchMatches scores matches of one type against the query, best first and
+// shorter names first among equals
+func rankSearchMatches(kind, lower string, words []string, matches []neo4j.NameMatch, weights searchWeights) []SearchResult {
+	results := make([]SearchResult, 0, len(matches))
+	for _, match := range matches {
+		results = append(results, SearchResult{
+			Type:      kind,
+			Name:      match.Name,
+			Institute: match.Institute,
+			Score:     searchScore(strings.ToLower(match.Name), lower, words, weights),
+			Link:      entityLink(kind, match.Name),
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return len(results[i].Name) < len(results[j].Name)
+	})
+	return results
+}
+
+// searchScore rates how closely a lowercase name matches the query. Every query
+// word is already known to occur somewhere in the name.
+func searchScore(name, lower string, words []string, weights searchWeights) float64 {
+	switch {
+	case name == lower:
+		return weights.exact
+	case strings.HasPrefix(name, lower):
+		return weights.prefix
+	}
+
+	nameWords := strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '(' || r == ')' || r == ',' || r == '/'
+	})
+	for _, word := range words {
+		found := false
+		for _, nameWord := range nameWords {
+			if strings.HasPrefix(nameWord, word) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return weights.contains
+		}
+	}
+	return weights.wordPrefix
+}
+
+// entityLink returns the API path with the details of an entity
+func entityLink(kind, name string) string {
+	escaped := url.PathEscape(name)
+	switch kind {
+	case neo4j.KindInstitute:
+		return "/api/v1/pathway/institutes/" + escaped + "/programs"
+	case neo4j.KindProgram:
+		return "/api/v1/pathway/programs/" + escaped
+	case neo4j.KindCareer:
+		return "/api/v1/pathway/careers/" + escaped + "/pathways"
+	case neo4j.KindDepartment:
+		return "/api/v1/pathway/departments/" + escaped + "/complete"
+	}
+	return ""
+}
@@ -3,44 +3,139 @@ package pathway
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mayura-andrew/fastfinder/internal/core/llm"
 	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
 	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"github.com/mayura-andrew/fastfinder/internal/services/demand"
+	"github.com/mayura-andrew/fastfinder/internal/services/outcomes"
+	"github.com/mayura-andrew/fastfinder/internal/services/resources"
 	"github.com/mayura-andrew/fastfinder/internal/services/scraper"
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrRoadmapNotCached is returned for pins of programs with no cached roadmap
+	ErrRoadmapNotCached = errors.New("no cached roadmap for program")
+
+	// ErrRoadmapPinned is returned when invalidating or refreshing a pinned roadmap
+	ErrRoadmapPinned = errors.New("cached roadmap is pinned")
+)
+
 // Service handles education pathway business logic
 type Service struct {
-	neo4jClient    *neo4j.Client
+	neo4jClient    Graph
 	llmClient      *llm.Client
 	youtubeService *scraper.YouTubeService
-	cache          *mongodb.LearningRoadmapCache
+	providers      []resources.Provider
+	outcomes       *outcomes.Service
+	demand         *demand.Service
+	cache          RoadmapCache
+	versions       *mongodb.RoadmapVersionStore
+	history        *mongodb.BrowsingHistory
+	jobRoleCache   JobRoleCache
+	intakes        *mongodb.ProgramIntakeStore
+	aptitudeTests  *mongodb.AptitudeTestStore
+	livingCosts    *mongodb.LivingCostStore
+	aliases        *mongodb.QualificationAliasStore
+	readiness      *mongodb.ReadinessCache
+	salaries       *mongodb.CareerSalaryStore
+	cutoffs        *mongodb.ZScoreCutoffStore
+	views          *mongodb.PathwayViewStore
+	lists          *listCache
+	snapshots      *listingSnapshots
+	lastChanged    atomic.Int64 // unix nanoseconds of the last graph change seen
 	logger         *zap.Logger
 }
 
-// NewService creates a new pathway service
-func NewService(neo4jClient *neo4j.Client, llmClient *llm.Client, youtubeService *scraper.YouTubeService, mongoClient *mongodb.Client, logger *zap.Logger) *Service {
+// NewService creates a new pathway service. Institute, career and per-institute or
+// per-department program listings are cached in memory for listCacheTTL, and a
+// snapshot of them is kept at snapshotPath for when the graph is unreachable.
+func NewService(neo4jClient Graph, llmClient *llm.Client, youtubeService *scraper.YouTubeService, mongoClient *mongodb.Client, listCacheTTL time.Duration, snapshotPath string, logger *zap.Logger) *Service {
 	// Initialize cache
 	cache := mongodb.NewLearningRoadmapCache(mongoClient, logger)
 
-	return &Service{
+	service := &Service{
 		neo4jClient:    neo4jClient,
 		llmClient:      llmClient,
 		youtubeService: youtubeService,
 		cache:          cache,
+		versions:       mongodb.NewRoadmapVersionStore(mongoClient, logger),
+		history:        mongodb.NewBrowsingHistory(mongoClient, logger),
+		jobRoleCache:   mongodb.NewJobRoleCache(mongoClient, logger),
+		intakes:        mongodb.NewProgramIntakeStore(mongoClient, logger),
+		aptitudeTests:  mongodb.NewAptitudeTestStore(mongoClient, logger),
+		livingCosts:    mongodb.NewLivingCostStore(mongoClient, logger),
+		aliases:        mongodb.NewQualificationAliasStore(mongoClient, logger),
+		readiness:      mongodb.NewReadinessCache(mongoClient, logger),
+		salaries:       mongodb.NewCareerSalaryStore(mongoClient, logger),
+		cutoffs:        mongodb.NewZScoreCutoffStore(mongoClient, logger),
+		views:          mongodb.NewPathwayViewStore(mongoClient, logger),
+		lists:          newListCache(listCacheTTL),
+		snapshots:      newListingSnapshots(snapshotPath, logger),
 		logger:         logger,
 	}
+	// Changes made before startup are unknown, so the graph counts as changed now
+	service.lastChanged.Store(time.Now().UnixNano())
+	return service
+}
+
+// UseCaches replaces the MongoDB roadmap and job role caches, e.g. with SQL ones.
+// Call it before the service handles requests.
+func (s *Service) UseCaches(roadmaps RoadmapCache, jobRoles JobRoleCache) {
+	s.cache = roadmaps
+	s.jobRoleCache = jobRoles
+}
+
+// UseSharedListCache shares institute, career and per-institute or
+// per-department program listings between instances through shared, behind the
+// in-memory list cache. Call it before the service handles requests.
+func (s *Service) UseSharedListCache(shared SharedListCache) {
+	s.lists.shared = shared
+	s.lists.logger = s.logger
+}
+
+// UseResourceProviders sets the providers whose resources enrich roadmap steps
+// alongside YouTube videos. Call it before the service handles requests.
+func (s *Service) UseResourceProviders(providers []resources.Provider) {
+	s.providers = providers
+}
+
+// UseOutcomes sets the service whose graduate outcomes are added to program
+// details. Call it before the service handles requests.
+func (s *Service) UseOutcomes(service *outcomes.Service) {
+	s.outcomes = service
+}
+
+// UseDemand sets the service whose demand indexes are added to career listings.
+// Call it before the service handles requests.
+func (s *Service) UseDemand(service *demand.Service) {
+	s.demand = service
+}
+
+// LastChanged returns when the graph last changed through an admin edit, import
+// or sync seen by this instance, or when the service started
+func (s *Service) LastChanged() time.Time {
+	return time.Unix(0, s.lastChanged.Load())
 }
 
 // GetAllInstitutes retrieves all education institutes
 func (s *Service) GetAllInstitutes(ctx context.Context) ([]neo4j.Institute, error) {
 	s.logger.Debug("Fetching all institutes")
-	institutes, err := s.neo4jClient.GetAllInstitutes(ctx)
+	institutes, err := sharedList(ctx, s.lists, listKeyInstitutes, func(ctx context.Context) ([]neo4j.Institute, error) {
+		return s.neo4jClient.GetAllInstitutes(ctx)
+	})
+	if cached, ok := fromSnapshot(s, ctx, err, func(snapshot *ListingSnapshot) ([]neo4j.Institute, bool) {
+		return snapshot.Institutes, true
+	}); ok {
+		return cached, nil
+	}
 	if err != nil {
 		s.logger.Error("Failed to fetch institutes", zap.Error(err))
 		return nil, fmt.Errorf("failed to fetch institutes: %w", err)
@@ -57,7 +152,18 @@ func (s *Service) GetProgramsByInstitute(ctx context.Context, instituteName stri
 		return nil, fmt.Errorf("institute name is required")
 	}
 
-	programs, err := s.neo4jClient.GetProgramsByInstitute(ctx, instituteName)
+	programs, err := sharedList(ctx, s.lists, listKeyInstitutePrograms+instituteName, func(ctx context.Context) ([]neo4j.ProgramDetails, error) {
+		return s.neo4jClient.GetProgramsByInstitute(ctx, instituteName)
+	})
+	if cached, ok := fromSnapshot(s, ctx, err, func(snapshot *ListingSnapshot) ([]neo4j.ProgramDetails, bool) {
+		programs, ok := snapshot.InstitutePrograms[instituteName]
+		return programs, ok
+	}); ok {
+		programs, err = cached, nil
+	}
+	if err == nil {
+		programs, err = s.narrowPrograms(ctx, programs)
+	}
 	if err != nil {
 		s.logger.Error("Failed to fetch programs", zap.String("institute", instituteName), zap.Error(err))
 		return nil, fmt.Errorf("failed to fetch programs: %w", err)
@@ -66,27 +172,41 @@ func (s *Service) GetProgramsByInstitute(ctx context.Context, instituteName stri
 	s.logger.Info("Successfully fetched programs",
 		zap.String("institute", instituteName),
 		zap.Int("count", len(programs)))
-	return programs, nil
+	return s.withAptitudeTests(ctx, programs), nil
 }
 
-// GetCareerPaths finds education paths based on qualifications
-func (s *Service) GetCareerPaths(ctx context.Context, qualifications []string) ([]neo4j.EducationPath, error) {
+// StreamCareerPaths finds education paths based on qualifications, given by name
+// or alias, passing each path to emit as it is read so large result sets are
+// never held in memory
+func (s *Service) StreamCareerPaths(ctx context.Context, qualifications []string, emit func(neo4j.EducationPath) error) error {
 	s.logger.Debug("Finding career paths", zap.Strings("qualifications", qualifications))
 
+	qualifications = s.resolveQualifications(ctx, qualifications)
 	if len(qualifications) == 0 {
-		return nil, fmt.Errorf("at least one qualification is required")
+		return fmt.Errorf("at least one qualification is required")
 	}
 
-	paths, err := s.neo4jClient.GetCareerPaths(ctx, qualifications)
+	emit, err := s.narrowEmit(ctx, emit)
 	if err != nil {
-		s.logger.Error("Failed to find career paths", zap.Error(err))
-		return nil, fmt.Errorf("failed to find career paths: %w", err)
+		return err
+	}
+
+	count := 0
+	err = s.neo4jClient.StreamCareerPaths(ctx, qualifications, func(path neo4j.EducationPath) error {
+		count++
+		return emit(path)
+	})
+	if err != nil {
+		s.logger.Error("Failed to find career paths",
+			zap.Int("streamed", count),
+			zap.Error(err))
+		return fmt.Errorf("failed to find career paths: %w", err)
 	}
 
 	s.logger.Info("Successfully found career paths",
 		zap.Strings("qualifications", qualifications),
-		zap.Int("count", len(paths)))
-	return paths, nil
+		zap.Int("count", count))
+	return nil
 }
 
 // GetProgramDetails retrieves detailed information about a program
@@ -104,45 +224,66 @@ func (s *Service) GetProgramDetails(ctx context.Context, programName string) (*n
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to fetch program details: %w", err)
 	}
+	s.attachCapacity(ctx, details)
+	s.attachAptitudeTests(ctx, details)
+	s.attachOutcomes(ctx, details)
 
 	s.logger.Info("Successfully fetched program details", zap.String("program", programName))
 	return details, nil
 }
 
-// GetAllCareers retrieves all available careers
+// GetAllCareers retrieves all available careers with their demand indexes
 func (s *Service) GetAllCareers(ctx context.Context) ([]neo4j.Career, error) {
 	s.logger.Debug("Fetching all careers")
 
-	careers, err := s.neo4jClient.GetAllCareers(ctx)
+	careers, err := sharedList(ctx, s.lists, listKeyCareers, func(ctx context.Context) ([]neo4j.Career, error) {
+		return s.neo4jClient.GetAllCareers(ctx)
+	})
+	if cached, ok := fromSnapshot(s, ctx, err, func(snapshot *ListingSnapshot) ([]neo4j.Career, bool) {
+		return snapshot.Careers, true
+	}); ok {
+		return withDemandIndexes(cached, s.demandIndexes(ctx)), nil
+	}
 	if err != nil {
 		s.logger.Error("Failed to fetch careers", zap.Error(err))
 		return nil, fmt.Errorf("failed to fetch careers: %w", err)
 	}
 
 	s.logger.Info("Successfully fetched careers", zap.Int("count", len(careers)))
-	return careers, nil
+	return withDemandIndexes(careers, s.demandIndexes(ctx)), nil
 }
 
-// GetPathwayToCareer finds educational pathways to a specific career
-func (s *Service) GetPathwayToCareer(ctx context.Context, careerTitle string) ([]neo4j.EducationPath, error) {
+// StreamPathwayToCareer finds educational pathways to a specific career, passing
+// each pathway to emit as it is read
+func (s *Service) StreamPathwayToCareer(ctx context.Context, careerTitle string, emit func(neo4j.EducationPath) error) error {
 	s.logger.Debug("Finding pathways to career", zap.String("career", careerTitle))
 
 	if careerTitle == "" {
-		return nil, fmt.Errorf("career title is required")
+		return fmt.Errorf("career title is required")
+	}
+
+	emit, err := s.narrowEmit(ctx, emit)
+	if err != nil {
+		return err
 	}
 
-	paths, err := s.neo4jClient.GetPathwayToCareer(ctx, careerTitle)
+	count := 0
+	err = s.neo4jClient.StreamPathwayToCareer(ctx, careerTitle, func(path neo4j.EducationPath) error {
+		count++
+		return emit(path)
+	})
 	if err != nil {
 		s.logger.Error("Failed to find career pathways",
 			zap.String("career", careerTitle),
+			zap.Int("streamed", count),
 			zap.Error(err))
-		return nil, fmt.Errorf("failed to find career pathways: %w", err)
+		return fmt.Errorf("failed to find career pathways: %w", err)
 	}
 
 	s.logger.Info("Successfully found career pathways",
 		zap.String("career", careerTitle),
-		zap.Int("count", len(paths)))
-	return paths, nil
+		zap.Int("count", count))
+	return nil
 }
 
 // GetCachedLearningRoadmap retrieves a cached learning roadmap WITHOUT calling LLM
@@ -222,8 +363,8 @@ func (s *Service) GetLearningRoadmapFast(ctx context.Context, programName string
 		prerequisites = []string{}
 	}
 
-	// Generate learning roadmap using LLM (this is fast)
-	roadmap, err := s.llmClient.GenerateLearningRoadmap(ctx, programName, prerequisites)
+	// Generate learning roadmap using LLM (this is fast), hedged with a faster model
+	roadmap, template, err := s.generateRoadmap(ctx, programName, prerequisites, true)
 	if err != nil {
 		s.logger.Error("Failed to generate learning roadmap",
 			zap.String("program", programName),
@@ -240,6 +381,7 @@ func (s *Service) GetLearningRoadmapFast(ctx context.Context, programName string
 		KeySkills:      roadmap.KeySkills,
 		RecommendedFor: roadmap.RecommendedFor,
 		Steps:          make([]LearningStepWithVideos, len(roadmap.LearningSteps)),
+		Template:       template,
 	}
 
 	for i, step := range roadmap.LearningSteps {
@@ -256,6 +398,7 @@ func (s *Service) GetLearningRoadmapFast(ctx context.Context, programName string
 
 	s.logger.Info("Successfully generated FAST learning roadmap (no videos)",
 		zap.String("program", programName),
+		zap.Bool("template", template),
 		zap.Int("steps", len(response.Steps)))
 
 	return response, nil
@@ -269,7 +412,18 @@ func (s *Service) GetCompletePathway(ctx context.Context, department string) ([]
 		return nil, fmt.Errorf("department is required")
 	}
 
-	programs, err := s.neo4jClient.GetCompletePathway(ctx, department)
+	programs, err := sharedList(ctx, s.lists, listKeyDepartmentPrograms+department, func(ctx context.Context) ([]neo4j.ProgramDetails, error) {
+		return s.materializedPathway(ctx, department)
+	})
+	if cached, ok := fromSnapshot(s, ctx, err, func(snapshot *ListingSnapshot) ([]neo4j.ProgramDetails, bool) {
+		programs, ok := snapshot.DepartmentPathways[department]
+		return programs, ok
+	}); ok {
+		programs, err = cached, nil
+	}
+	if err == nil {
+		programs, err = s.narrowPrograms(ctx, programs)
+	}
 	if err != nil {
 		s.logger.Error("Failed to fetch complete pathway",
 			zap.String("department", department),
@@ -280,24 +434,38 @@ func (s *Service) GetCompletePathway(ctx context.Context, department string) ([]
 	s.logger.Info("Successfully fetched complete pathway",
 		zap.String("department", department),
 		zap.Int("count", len(programs)))
-	return programs, nil
+	return s.withAptitudeTests(ctx, programs), nil
 }
 
-// GetPathwayByQualification retrieves pathways filtered by department and qualification
-func (s *Service) GetPathwayByQualification(ctx context.Context, department string, qualification string) ([]neo4j.ProgramDetails, error) {
+// GetPathwayByQualification retrieves the programs of a department accessible
+// from a qualification within maxHops prerequisite steps, at most
+// neo4j.MaxPrerequisiteDepth, ordered and numbered by the named ranking
+func (s *Service) GetPathwayByQualification(ctx context.Context, department string, qualification string, maxHops int, rankBy string) ([]neo4j.ProgramDetails, error) {
 	s.logger.Debug("Fetching pathway by qualification",
 		zap.String("department", department),
-		zap.String("qualification", qualification))
+		zap.String("qualification", qualification),
+		zap.Int("max_hops", maxHops),
+		zap.String("rank_by", rankBy))
 
 	if department == "" {
 		return nil, fmt.Errorf("department is required")
 	}
+	if maxHops < 0 || maxHops > neo4j.MaxPrerequisiteDepth {
+		return nil, fmt.Errorf("%w: max hops must be between 0 and %d", ErrInvalidTraversal, neo4j.MaxPrerequisiteDepth)
+	}
+	if !IsProgramRanking(rankBy) {
+		return nil, fmt.Errorf("%w: unknown ranking %q", ErrInvalidTraversal, rankBy)
+	}
 
+	qualification = s.resolveQualification(ctx, qualification)
 	if qualification == "" {
 		return nil, fmt.Errorf("qualification is required")
 	}
 
-	programs, err := s.neo4jClient.GetPathwayByQualification(ctx, department, qualification)
+	programs, err := s.neo4jClient.GetPathwayByQualification(ctx, department, qualification, maxHops)
+	if err == nil {
+		programs, err = s.narrowPrograms(ctx, programs)
+	}
 	if err != nil {
 		s.logger.Error("Failed to fetch pathway by qualification",
 			zap.String("department", department),
@@ -310,6 +478,10 @@ func (s *Service) GetPathwayByQualification(ctx context.Context, department stri
 		zap.String("department", department),
 		zap.String("qualification", qualification),
 		zap.Int("count", len(programs)))
+
+	// Meeting the qualification is not enough for programs that also test applicants
+	programs = s.withAptitudeTests(ctx, programs)
+	s.rankPrograms(ctx, rankBy, programs)
 	return programs, nil
 }
 
@@ -322,6 +494,10 @@ type LearningRoadmapResponse struct {
 	KeySkills      []string                 `json:"key_skills"`
 	RecommendedFor string                   `json:"recommended_for"`
 	Steps          []LearningStepWithVideos `json:"steps"`
+	Template       bool                     `json:"template,omitempty"` // curated fallback, not personalized
+	Version        int                      `json:"version,omitempty"`  // stored version, once cached
+	// TotalCost is the cost of the resources of every step, added when served
+	TotalCost *ResourceCost `json:"total_cost_of_resources,omitempty"`
 }
 
 // LearningStepWithVideos combines a learning step with related videos
@@ -333,6 +509,10 @@ type LearningStepWithVideos struct {
 	Duration    string          `json:"duration"`
 	Difficulty  string          `json:"difficulty"`
 	Videos      []scraper.Video `json:"videos"`
+	// Resources from the registered resource providers, e.g. local e-learning sites
+	Resources []resources.Resource `json:"resources,omitempty"`
+	// Cost of the step's videos and resources, added when served
+	Cost *ResourceCost `json:"cost,omitempty"`
 }
 
 // GetLearningRoadmap generates a personalized learning roadmap for a program
@@ -384,7 +564,7 @@ func (s *Service) GetLearningRoadmap(ctx context.Context, programName string) (*
 	}
 
 	// Step 2: Generate learning roadmap using LLM
-	roadmap, err := s.llmClient.GenerateLearningRoadmap(ctx, programName, prerequisites)
+	roadmap, template, err := s.generateRoadmap(ctx, programName, prerequisites, false)
 	if err != nil {
 		s.logger.Error("Failed to generate learning roadmap",
 			zap.String("program", programName),
@@ -393,6 +573,37 @@ func (s *Service) GetLearningRoadmap(ctx context.Context, programName string) (*
 	}
 
 	// PERFORMANCE OPTIMIZATION 2: Fetch videos concurrently for all topics
+	response := s.roadmapWithVideos(ctx, roadmap, template)
+
+	// Count steps with videos
+	stepsWithVideos := 0
+	totalVideos := 0
+	for _, step := range response.Steps {
+		if len(step.Videos) > 0 {
+			stepsWithVideos++
+			totalVideos += len(step.Videos)
+		}
+	}
+
+	s.logger.Info("Successfully generated learning roadmap with concurrent video fetching",
+		zap.String("program", programName),
+		zap.Bool("template", template),
+		zap.Int("total_steps", len(response.Steps)),
+		zap.Int("steps_with_videos", stepsWithVideos),
+		zap.Int("total_videos", totalVideos))
+
+	// PERFORMANCE OPTIMIZATION 3: Cache the result for future requests (async).
+	// Templates are not cached, so the LLM is asked again once it recovers.
+	if !response.Template {
+		go s.cacheRoadmap(programName, response)
+	}
+
+	return response, nil
+}
+
+// roadmapWithVideos builds the response for a roadmap, fetching videos for the
+// topics of each step concurrently
+func (s *Service) roadmapWithVideos(ctx context.Context, roadmap *llm.LearningRoadmap, template bool) *LearningRoadmapResponse {
 	response := &LearningRoadmapResponse{
 		ProgramName:    roadmap.ProgramName,
 		Overview:       roadmap.Overview,
@@ -401,6 +612,7 @@ func (s *Service) GetLearningRoadmap(ctx context.Context, programName string) (*
 		KeySkills:      roadmap.KeySkills,
 		RecommendedFor: roadmap.RecommendedFor,
 		Steps:          make([]LearningStepWithVideos, len(roadmap.LearningSteps)),
+		Template:       template,
 	}
 
 	// PERFORMANCE OPTIMIZATION: Use goroutines with controlled concurrency
@@ -448,8 +660,16 @@ func (s *Service) GetLearningRoadmap(ctx context.Context, programName string) (*
 			default:
 			}
 
-			// Fetch videos for all topics in this step
+			// Fetch videos for all topics in this step, and resources for the
+			// step from the other providers meanwhile
+			var stepResources []resources.Resource
+			resourcesDone := make(chan struct{})
+			go func() {
+				defer close(resourcesDone)
+				stepResources = s.fetchResources(videoCtx, learningStep.Title)
+			}()
 			videos := s.fetchVideosForTopics(videoCtx, learningStep.Topics)
+			<-resourcesDone
 
 			// Build step with videos
 			stepWithVideos := LearningStepWithVideos{
@@ -460,6 +680,7 @@ func (s *Service) GetLearningRoadmap(ctx context.Context, programName string) (*
 				Duration:    learningStep.Duration,
 				Difficulty:  learningStep.Difficulty,
 				Videos:      videos,
+				Resources:   stepResources,
 			}
 
 			// Thread-safe write to response
@@ -473,26 +694,7 @@ func (s *Service) GetLearningRoadmap(ctx context.Context, programName string) (*
 	// Wait for all goroutines to complete
 	wg.Wait()
 
-	// Count steps with videos
-	stepsWithVideos := 0
-	totalVideos := 0
-	for _, step := range response.Steps {
-		if len(step.Videos) > 0 {
-			stepsWithVideos++
-			totalVideos += len(step.Videos)
-		}
-	}
-
-	s.logger.Info("Successfully generated learning roadmap with concurrent video fetching",
-		zap.String("program", programName),
-		zap.Int("total_steps", len(response.Steps)),
-		zap.Int("steps_with_videos", stepsWithVideos),
-		zap.Int("total_videos", totalVideos))
-
-	// PERFORMANCE OPTIMIZATION 3: Cache the result for future requests (async)
-	go s.cacheRoadmap(programName, response)
-
-	return response, nil
+	return response
 }
 
 // fetchVideosForTopics fetches videos for multiple topics with optimized concurrency
@@ -548,13 +750,73 @@ func (s *Service) fetchVideosForTopics(ctx context.Context, topics []string) []s
 	return allVideos
 }
 
+// resourcesPerProvider is the number of resources each provider adds to a step
+const resourcesPerProvider = 2
+
+// fetchResources asks every resource provider for resources on a topic
+// concurrently; providers that fail or time out are left out
+func (s *Service) fetchResources(ctx context.Context, topic string) []resources.Resource {
+	if len(s.providers) == 0 {
+		return nil
+	}
+
+	found := make([][]resources.Resource, len(s.providers))
+	var wg sync.WaitGroup
+	for i, provider := range s.providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			providerCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+			defer cancel()
+
+			results, err := provider.Search(providerCtx, topic, resourcesPerProvider)
+			if err != nil {
+				s.logger.Warn("Failed to fetch resources for topic",
+					zap.String("provider", provider.Name()),
+					zap.String("topic", topic),
+					zap.Error(err))
+				return
+			}
+			found[i] = results[:min(len(results), resourcesPerProvider)]
+		}()
+	}
+	wg.Wait()
+
+	// In provider order, so responses are stable
+	var all []resources.Resource
+	for _, results := range found {
+		all = append(all, results...)
+	}
+	return all
+}
+
+// cacheGeneratedRoadmap fetches videos for a roadmap generated in the background
+// and caches it as GetLearningRoadmap would, so the full endpoint serves it next
+func (s *Service) cacheGeneratedRoadmap(programName string, roadmap *llm.LearningRoadmap) {
+	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+	defer cancel()
+
+	s.cacheRoadmap(programName, s.roadmapWithVideos(ctx, roadmap, false))
+}
+
 // cacheRoadmap caches a learning roadmap asynchronously
 func (s *Service) cacheRoadmap(programName string, response *LearningRoadmapResponse) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Every version is kept, so users following an older one can stay on it
+	version, err := s.storeRoadmapVersion(ctx, programName, response)
+	if err != nil {
+		s.logger.Error("Failed to store roadmap version",
+			zap.String("program", programName),
+			zap.Error(err))
+	}
+	versioned := *response
+	versioned.Version = version
+
 	// Convert response to map for caching
-	data, err := s.marshalRoadmapForCache(response)
+	data, err := s.marshalRoadmapForCache(&versioned)
 	if err != nil {
 		s.logger.Error("Failed to marshal roadmap for caching",
 			zap.String("program", programName),
@@ -626,14 +888,89 @@ func (s *Service) getPrerequisites(ctx context.Context, programName string) ([]s
 
 // Cache Management Methods
 
-// InvalidateCache removes a specific program's cached roadmap
+// InvalidateCache removes a specific program's cached roadmap, unless it is
+// pinned
 func (s *Service) InvalidateCache(ctx context.Context, programName string) error {
+	if err := s.checkNotPinned(ctx, programName); err != nil {
+		return err
+	}
 	return s.cache.Delete(ctx, programName)
 }
 
+// PinRoadmap pins or unpins a program's cached roadmap. Pinned roadmaps, such
+// as ones a counselor has reviewed, never expire and are not replaced by newly
+// generated ones.
+func (s *Service) PinRoadmap(ctx context.Context, programName string, pinned bool) error {
+	s.logger.Debug("Changing roadmap pin",
+		zap.String("program", programName),
+		zap.Bool("pinned", pinned))
+
+	found, err := s.cache.SetPinned(ctx, programName, pinned)
+	if err != nil {
+		s.logger.Error("Failed to change roadmap pin",
+			zap.String("program", programName),
+			zap.Error(err))
+		return fmt.Errorf("failed to change roadmap pin: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", ErrRoadmapNotCached, programName)
+	}
+
+	s.logger.Info("Roadmap pin changed",
+		zap.String("program", programName),
+		zap.Bool("pinned", pinned))
+	return nil
+}
+
+// checkNotPinned returns ErrRoadmapPinned when the program's cached roadmap is pinned
+func (s *Service) checkNotPinned(ctx context.Context, programName string) error {
+	pinned, err := s.cache.IsPinned(ctx, programName)
+	if err != nil {
+		return err
+	}
+	if pinned {
+		return fmt.Errorf("%w: %s", ErrRoadmapPinned, programName)
+	}
+	return nil
+}
+
+// InvalidateChangedPrograms drops the cached roadmaps of programs changed by an
+// admin edit, import or sync, under both names when a program was renamed, along
+// with all cached listings and materialized pathway views, and notes when the
+// graph last changed. It is subscribed to the graph change log.
+func (s *Service) InvalidateChangedPrograms(ctx context.Context, changes []mongodb.GraphChange) {
+	if len(changes) > 0 {
+		s.lastChanged.Store(time.Now().UnixNano())
+		s.lists.clear()
+		s.dropViews(ctx)
+		s.refreshAccessibility(changes)
+	}
+
+	for _, change := range changes {
+		if change.Kind != neo4j.KindProgram || change.Action == changelog.ActionCreate {
+			continue
+		}
+		for _, name := range []string{change.PreviousName, change.Name} {
+			if name == "" {
+				continue
+			}
+			if err := s.cache.Delete(ctx, name); err != nil {
+				s.logger.Warn("Failed to invalidate roadmap cache after graph change",
+					zap.String("program", name),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
 // GetCacheStats returns cache statistics
 func (s *Service) GetCacheStats(ctx context.Context) (map[string]interface{}, error) {
-	return s.cache.GetStats(ctx)
+	stats, err := s.cache.GetStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats["cached_lists"] = s.lists.size()
+	return stats, nil
 }
 
 // ClearAllCache clears all cached roadmaps (use with caution)
@@ -643,6 +980,14 @@ func (s *Service) ClearAllCache(ctx context.Context) error {
 
 // RefreshCache regenerates and updates a cached roadmap
 func (s *Service) RefreshCache(ctx context.Context, programName string) error {
+	// Keep the cached roadmap rather than replacing it with a template
+	if !s.llmClient.Available() {
+		return ErrRoadmapUnavailable
+	}
+	if err := s.checkNotPinned(ctx, programName); err != nil {
+		return err
+	}
+
 	// Delete existing cache
 	if err := s.cache.Delete(ctx, programName); err != nil {
 		s.logger.Warn("Failed to delete cache before refresh",
@@ -661,6 +1006,27 @@ func (s *Service) GetJobRoleDetails(ctx context.Context, roleName string, progra
 		zap.String("role", roleName),
 		zap.String("context", programContext))
 
+	// Check cache first
+	cachedData, found, err := s.jobRoleCache.Get(ctx, roleName, programContext)
+	if err != nil {
+		s.logger.Warn("Job role cache error, proceeding with generation",
+			zap.String("role", roleName),
+			zap.Error(err))
+	}
+	if found {
+		var cached llm.JobRoleDetails
+		if err := remarshal(cachedData, &cached); err == nil {
+			s.logger.Info("Returning cached job role details",
+				zap.String("role", roleName),
+				zap.String("source", "cache"))
+			return s.withMarketSalary(ctx, roleName, &cached), nil
+		}
+	}
+
+	if s.llmClient == nil {
+		return nil, fmt.Errorf("LLM client is not available")
+	}
+
 	// Generate job role details using LLM
 	jobDetails, err := s.llmClient.GenerateJobRoleDetails(ctx, roleName, programContext)
 	if err != nil {
@@ -673,5 +1039,36 @@ func (s *Service) GetJobRoleDetails(ctx context.Context, roleName string, progra
 	s.logger.Info("Successfully generated job role details",
 		zap.String("role", roleName))
 
-	return jobDetails, nil
+	go s.cacheJobRoleDetails(roleName, programContext, jobDetails)
+
+	return s.withMarketSalary(ctx, roleName, jobDetails), nil
+}
+
+// cacheJobRoleDetails caches generated job role details asynchronously
+func (s *Service) cacheJobRoleDetails(roleName, programContext string, details *llm.JobRoleDetails) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var data map[string]interface{}
+	if err := remarshal(details, &data); err != nil {
+		s.logger.Error("Failed to marshal job role details for caching",
+			zap.String("role", roleName),
+			zap.Error(err))
+		return
+	}
+
+	if err := s.jobRoleCache.Set(ctx, roleName, programContext, data); err != nil {
+		s.logger.Error("Failed to cache job role details",
+			zap.String("role", roleName),
+			zap.Error(err))
+	}
+}
+
+// remarshal converts between structs and maps via JSON
+func remarshal(from interface{}, to interface{}) error {
+	jsonData, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, to)
 }
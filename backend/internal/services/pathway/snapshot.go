@@ -0,0 +1,181 @@
+package pathway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+// ListingSnapshot is a copy of the core listings taken while the graph was
+// reachable, so institutes, programs, careers and department pathways can still
+// be served, flagged as stale, while Neo4j is down. It is kept in memory and on
+// disk, where a restarted instance finds it before its first refresh.
+type ListingSnapshot struct {
+	TakenAt            time.Time                         `json:"taken_at"`
+	Institutes         []neo4j.Institute                 `json:"institutes"`
+	Careers            []neo4j.Career                    `json:"careers"`
+	InstitutePrograms  map[string][]neo4j.ProgramDetails `json:"institute_programs"`
+	DepartmentPathways map[string][]neo4j.ProgramDetails `json:"department_pathways"`
+}
+
+// listingSnapshots holds the latest snapshot and the file it is kept in, if any
+type listingSnapshots struct {
+	path    string
+	current atomic.Pointer[ListingSnapshot]
+}
+
+// newListingSnapshots loads the snapshot kept at path. An empty path keeps
+// snapshots in memory only.
+func newListingSnapshots(path string, logger *zap.Logger) *listingSnapshots {
+	snapshots := &listingSnapshots{path: path}
+	if path == "" {
+		return snapshots
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return snapshots
+	}
+	var snapshot ListingSnapshot
+	if err == nil {
+		err = json.Unmarshal(data, &snapshot)
+	}
+	if err != nil {
+		logger.Warn("Failed to load listing snapshot", zap.String("path", path), zap.Error(err))
+		return snapshots
+	}
+	snapshots.current.Store(&snapshot)
+	return snapshots
+}
+
+// save writes a snapshot to a temporary file and moves it into place, so a crash
+// mid-write leaves the previous snapshot intact
+func (l *listingSnapshots) save(snapshot *ListingSnapshot) error {
+	if l.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode listing snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write listing snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return fmt.Errorf("failed to replace listing snapshot: %w", err)
+	}
+	return nil
+}
+
+// RefreshSnapshot reads the core listings from the graph, bypassing every cache,
+// and keeps them as the snapshot served while the graph is unreachable
+func (s *Service) RefreshSnapshot(ctx context.Context) (*ListingSnapshot, error) {
+	s.logger.Debug("Taking listing snapshot")
+
+	institutes, err := s.neo4jClient.GetAllInstitutes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot institutes: %w", err)
+	}
+	careers, err := s.neo4jClient.GetAllCareers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot careers: %w", err)
+	}
+	departments, err := s.neo4jClient.ListNames(ctx, neo4j.KindDepartment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot departments: %w", err)
+	}
+
+	snapshot := &ListingSnapshot{
+		TakenAt:            time.Now().UTC(),
+		Institutes:         institutes,
+		Careers:            careers,
+		InstitutePrograms:  make(map[string][]neo4j.ProgramDetails, len(institutes)),
+		DepartmentPathways: make(map[string][]neo4j.ProgramDetails, len(departments)),
+	}
+	for _, institute := range institutes {
+		programs, err := s.neo4jClient.GetProgramsByInstitute(ctx, institute.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot programs of %s: %w", institute.Name, err)
+		}
+		snapshot.InstitutePrograms[institute.Name] = programs
+	}
+	for _, department := range departments {
+		programs, err := s.neo4jClient.GetCompletePathway(ctx, department)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot pathway of %s: %w", department, err)
+		}
+		snapshot.DepartmentPathways[department] = programs
+	}
+
+	s.snapshots.current.Store(snapshot)
+	if err := s.snapshots.save(snapshot); err != nil {
+		// The snapshot is still served from memory
+		s.logger.Warn("Failed to persist listing snapshot", zap.Error(err))
+	}
+
+	s.logger.Info("Listing snapshot taken",
+		zap.Int("institutes", len(institutes)),
+		zap.Int("careers", len(careers)),
+		zap.Int("departments", len(departments)))
+	return snapshot, nil
+}
+
+// fromSnapshot answers a read that failed because the graph is unreachable from
+// the listing snapshot, when it holds the data, and flags the request as stale.
+// Other failures are not masked.
+func fromSnapshot[T any](s *Service, ctx context.Context, err error, read func(*ListingSnapshot) (T, bool)) (T, bool) {
+	var zero T
+	snapshot := s.snapshots.current.Load()
+	if snapshot == nil || !neo4j.IsUnavailable(err) {
+		return zero, false
+	}
+	value, ok := read(snapshot)
+	if !ok {
+		return zero, false
+	}
+
+	if flag, ok := ctx.Value(staleFlagKey{}).(*StaleFlag); ok {
+		flag.takenAt.Store(snapshot.TakenAt.UnixNano())
+	}
+	s.logger.Warn("Graph unavailable, serving listing snapshot",
+		zap.Time("taken_at", snapshot.TakenAt),
+		zap.Error(err))
+	return value, true
+}
+
+type staleFlagKey struct{}
+
+// StaleFlag records whether a request was answered from the listing snapshot
+type StaleFlag struct {
+	takenAt atomic.Int64 // unix nanoseconds, 0 while answered from the graph
+}
+
+// WithStaleFlag returns a context whose reads answered from the listing snapshot
+// are recorded in the returned flag
+func WithStaleFlag(ctx context.Context) (context.Context, *StaleFlag) {
+	flag := &StaleFlag{}
+	return context.WithValue(ctx, staleFlagKey{}, flag), flag
+}
+
+// SnapshotTakenAt returns when the snapshot that answered the request was taken,
+// or false if the request was answered from the graph
+func (f *StaleFlag) SnapshotTakenAt() (time.Time, bool) {
+	takenAt := f.takenAt.Load()
+	if takenAt == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, takenAt).UTC(), true
+}
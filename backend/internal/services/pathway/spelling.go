@@ -0,0 +1,80 @@
+package pathway
+
+import (
+	"context"
+
+	"github.com/mayura-andrew/fastfinder/pkg/fuzzy"
+	"go.uber.org/zap"
+)
+
+const (
+	// autoCorrectScore is the similarity at which a name not in the graph is
+	// taken to mean the closest graph name, if no other comes within
+	// correctionMargin of it
+	autoCorrectScore = 0.85
+	correctionMargin = 0.05
+
+	// suggestionScore is the similarity at which graph names are offered as
+	// what a name not in the graph may have meant
+	suggestionScore = 0.5
+	maxSuggestions  = 5
+)
+
+// spellingIndex returns the index of the names of kind in the graph
+func (s *Service) spellingIndex(ctx context.Context, kind string) (*fuzzy.Index, error) {
+	return cachedList(ctx, s.lists, listKeySpellingIndex+kind, func(ctx context.Context) (*fuzzy.Index, error) {
+		names, err := s.neo4jClient.ListNames(ctx, kind)
+		if err != nil {
+			return nil, err
+		}
+		return fuzzy.NewIndex(names), nil
+	})
+}
+
+// CorrectName returns the name of an entity of kind that a name typed by a user
+// most likely means: the name itself when it is in the graph or no graph name
+// is clearly closest, and otherwise the closest graph name. Names are returned
+// as given when the index cannot be built.
+func (s *Service) CorrectName(ctx context.Context, kind, name string) string {
+	index, err := s.spellingIndex(ctx, kind)
+	if err != nil {
+		s.logger.Warn("Failed to build spelling index", zap.String("kind", kind), zap.Error(err))
+		return name
+	}
+	if name == "" || index.Has(name) {
+		return name
+	}
+
+	matches := index.Lookup(name, autoCorrectScore-correctionMargin, 2)
+	if len(matches) == 0 || matches[0].Score < autoCorrectScore {
+		return name
+	}
+	if len(matches) > 1 && matches[1].Score > matches[0].Score-correctionMargin {
+		return name
+	}
+
+	s.logger.Info("Corrected misspelt name",
+		zap.String("kind", kind),
+		zap.String("name", name),
+		zap.String("corrected", matches[0].Name),
+		zap.Float64("score", matches[0].Score))
+	return matches[0].Name
+}
+
+// SuggestNames returns the graph names of kind closest to a name that is not in
+// the graph, best first, for a "did you mean" prompt, or none when it is
+func (s *Service) SuggestNames(ctx context.Context, kind, name string) []string {
+	suggestions := []string{}
+	index, err := s.spellingIndex(ctx, kind)
+	if err != nil {
+		s.logger.Warn("Failed to build spelling index", zap.String("kind", kind), zap.Error(err))
+		return suggestions
+	}
+	if index.Has(name) {
+		return suggestions
+	}
+	for _, match := range index.Lookup(name, suggestionScore, maxSuggestions) {
+		suggestions = append(suggestions, match.Name)
+	}
+	return suggestions
+}
@@ -0,0 +1,69 @@
+package pathway
+
+import (
+	"context"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+)
+
+// Graph is the education graph the service reads. It is implemented by
+// neo4j.Client, and by memstore.Graph in demo mode.
+type Graph interface {
+	GetAllInstitutes(ctx context.Context) ([]neo4j.Institute, error)
+	GetAllCareers(ctx context.Context) ([]neo4j.Career, error)
+	GetProgramsByInstitute(ctx context.Context, instituteName string) ([]neo4j.ProgramDetails, error)
+	ListInstitutes(ctx context.Context, filter neo4j.InstituteFilter, offset, limit int) ([]neo4j.Institute, error)
+	ListCareers(ctx context.Context, filter neo4j.CareerFilter, offset, limit int) ([]neo4j.Career, error)
+	ListProgramsByInstitute(ctx context.Context, instituteName string, filter neo4j.ProgramFilter, offset, limit int) ([]neo4j.ProgramDetails, error)
+	WorkingStudentPrograms(ctx context.Context) ([]string, error)
+	InstitutesInDistrict(ctx context.Context, districtName string) ([]string, error)
+	ListProgramsByDistrict(ctx context.Context, districtName string, filter neo4j.ProgramFilter, offset, limit int) ([]neo4j.ProgramDetails, error)
+	ListScholarships(ctx context.Context, filter neo4j.ScholarshipFilter) ([]neo4j.Scholarship, error)
+	ProgramScholarships(ctx context.Context, programName string) ([]neo4j.Scholarship, error)
+	GetCompletePathway(ctx context.Context, department string) ([]neo4j.ProgramDetails, error)
+	GetPathwayByQualification(ctx context.Context, department string, qualification string, maxHops int) ([]neo4j.ProgramDetails, error)
+	GetProgramDetails(ctx context.Context, programName string) (*neo4j.ProgramDetails, error)
+	StreamCareerPaths(ctx context.Context, qualifications []string, fn func(neo4j.EducationPath) error) error
+	StreamPathwayToCareer(ctx context.Context, careerTitle string, fn func(neo4j.EducationPath) error) error
+	GetCareerEducation(ctx context.Context, careerTitles []string) ([]neo4j.CareerEducation, error)
+	GetCareerTransition(ctx context.Context, from, to string) (*neo4j.CareerTransitionData, error)
+	GetRelatedPrograms(ctx context.Context, programs []string, careers []string, limit int) ([]neo4j.RelatedEntity, error)
+	GetRelatedCareers(ctx context.Context, programs []string, careers []string, limit int) ([]neo4j.RelatedEntity, error)
+	ListNames(ctx context.Context, kind string) ([]string, error)
+	ExistingNames(ctx context.Context, kind string, names []string) (map[string]bool, error)
+	SearchNames(ctx context.Context, kind string, words []string, limit int) ([]neo4j.NameMatch, error)
+	SearchEntities(ctx context.Context, query string, kinds []string, limit int) ([]neo4j.EntityHit, error)
+	GetSubgraph(ctx context.Context, kind, name string, depth, maxNodes int) (*neo4j.Subgraph, error)
+	StreamPathwayGraph(ctx context.Context, department string, node func(neo4j.GraphNode) error, edge func(neo4j.GraphEdge) error) error
+	MarkAccessibilityStale()
+	RebuildAccessibility(ctx context.Context) (int, error)
+}
+
+// SharedListCache shares encoded listings between instances, behind the
+// in-memory list cache. It is implemented by the MongoDB listing cache.
+type SharedListCache interface {
+	Get(ctx context.Context, key string) (data []byte, loadedAt time.Time, ok bool, err error)
+	Set(ctx context.Context, key string, data []byte, loadedAt time.Time, ttl time.Duration) error
+}
+
+// RoadmapCache keeps generated learning roadmaps by program. It is implemented
+// by the MongoDB and SQL stores. Pinned roadmaps never expire, and Set, Delete
+// and Clear leave them in place.
+type RoadmapCache interface {
+	Get(ctx context.Context, programName string) (map[string]interface{}, bool, error)
+	Set(ctx context.Context, programName string, data map[string]interface{}) error
+	Delete(ctx context.Context, programName string) error
+	GetStats(ctx context.Context) (map[string]interface{}, error)
+	Clear(ctx context.Context) error
+	SetPinned(ctx context.Context, programName string, pinned bool) (bool, error)
+	IsPinned(ctx context.Context, programName string) (bool, error)
+}
+
+// JobRoleCache keeps generated job role details by role and program context. It
+// is implemented by the MongoDB and SQL stores.
+type JobRoleCache interface {
+	Get(ctx context.Context, roleName, programContext string) (map[string]interface{}, bool, error)
+	GetAny(ctx context.Context, roleName string) (map[string]interface{}, bool, error)
+	Set(ctx context.Context, roleName, programContext string, data map[string]interface{}) error
+}
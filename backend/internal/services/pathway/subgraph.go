@@ -0,0 +1,91 @@
+package pathway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSubgraphDepth and MaxSubgraphDepth bound how many relationships
+	// away from the center a subgraph reaches
+	DefaultSubgraphDepth = 1
+	MaxSubgraphDepth     = 2
+
+	// maxSubgraphNodes keeps a subgraph small enough to lay out in a browser
+	maxSubgraphNodes = 150
+)
+
+// ErrInvalidSubgraph is returned for a subgraph requested without a center
+var ErrInvalidSubgraph = errors.New("invalid subgraph request")
+
+// GetSubgraph returns the neighbourhood of an entity as nodes and edges for an
+// interactive pathway map. Center is a node ID such as "career:Doctor" or a
+// bare name; depth is clamped to 1 through MaxSubgraphDepth.
+func (s *Service) GetSubgraph(ctx context.Context, center string, depth int) (*neo4j.Subgraph, error) {
+	s.logger.Debug("Fetching subgraph", zap.String("center", center), zap.Int("depth", depth))
+
+	kind, name := neo4j.ParseNodeID(strings.TrimSpace(center))
+	if name == "" {
+		return nil, fmt.Errorf("%w: center is required", ErrInvalidSubgraph)
+	}
+	if depth <= 0 {
+		depth = DefaultSubgraphDepth
+	}
+	depth = min(depth, MaxSubgraphDepth)
+
+	subgraph, err := s.neo4jClient.GetSubgraph(ctx, kind, name, depth, maxSubgraphNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Subgraph fetched",
+		zap.String("center", subgraph.Center),
+		zap.Int("depth", depth),
+		zap.Int("nodes", len(subgraph.Nodes)),
+		zap.Int("edges", len(subgraph.Edges)),
+		zap.Bool("truncated", subgraph.Truncated))
+	return subgraph, nil
+}
+
+// ExportGraph passes the whole pathway graph, or the part of it around a
+// department when one is given, to node and edge as it is read: every node
+// before the first edge
+func (s *Service) ExportGraph(ctx context.Context, department string, node func(neo4j.GraphNode) error, edge func(neo4j.GraphEdge) error) error {
+	s.logger.Debug("Exporting graph", zap.String("department", department))
+
+	if department != "" {
+		existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindDepartment, []string{department})
+		if err != nil {
+			return fmt.Errorf("failed to check department: %w", err)
+		}
+		if !existing[department] {
+			return fmt.Errorf("%w: department %q", neo4j.ErrEntityNotFound, department)
+		}
+	}
+
+	nodes, edges := 0, 0
+	err := s.neo4jClient.StreamPathwayGraph(ctx, department,
+		func(n neo4j.GraphNode) error {
+			nodes++
+			return node(n)
+		},
+		func(e neo4j.GraphEdge) error {
+			edges++
+			return edge(e)
+		})
+	if err != nil {
+		s.logger.Error("Failed to export graph", zap.String("department", department), zap.Error(err))
+		return fmt.Errorf("failed to export graph: %w", err)
+	}
+
+	s.logger.Info("Graph exported",
+		zap.String("department", department),
+		zap.Int("nodes", nodes),
+		zap.Int("edges", edges))
+	return nil
+}
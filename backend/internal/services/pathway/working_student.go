@@ -0,0 +1,76 @@
+package pathway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+)
+
+type workingStudentKey struct{}
+
+// WithWorkingStudent returns a context in which pathway reads only return
+// programs, and paths made of programs, that can be completed while employed
+func WithWorkingStudent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, workingStudentKey{}, true)
+}
+
+// IsWorkingStudent reports whether ctx asks for working student pathways only
+func IsWorkingStudent(ctx context.Context) bool {
+	on, _ := ctx.Value(workingStudentKey{}).(bool)
+	return on
+}
+
+// workingStudentPrograms returns the programs that can be completed while
+// employed, or nil when ctx does not ask for working student pathways
+func (s *Service) workingStudentPrograms(ctx context.Context) (map[string]bool, error) {
+	if !IsWorkingStudent(ctx) {
+		return nil, nil
+	}
+	names, err := cachedList(ctx, s.lists, listKeyWorkingStudentPrograms, func(ctx context.Context) ([]string, error) {
+		return s.neo4jClient.WorkingStudentPrograms(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch working student programs: %w", err)
+	}
+	programs := make(map[string]bool, len(names))
+	for _, name := range names {
+		programs[name] = true
+	}
+	return programs, nil
+}
+
+// forWorkingStudents keeps the programs that can be completed while employed
+// when ctx asks for working student pathways. The programs are copied, as
+// listings may be shared through the list cache.
+func (s *Service) forWorkingStudents(ctx context.Context, programs []neo4j.ProgramDetails) ([]neo4j.ProgramDetails, error) {
+	suitable, err := s.workingStudentPrograms(ctx)
+	if err != nil || suitable == nil {
+		return programs, err
+	}
+	kept := make([]neo4j.ProgramDetails, 0, len(programs))
+	for _, program := range programs {
+		if suitable[program.Name] {
+			kept = append(kept, program)
+		}
+	}
+	return kept, nil
+}
+
+// emitForWorkingStudents wraps emit to skip the paths with a program that
+// cannot be completed while employed, when ctx asks for working student
+// pathways
+func (s *Service) emitForWorkingStudents(ctx context.Context, emit func(neo4j.EducationPath) error) (func(neo4j.EducationPath) error, error) {
+	suitable, err := s.workingStudentPrograms(ctx)
+	if err != nil || suitable == nil {
+		return emit, err
+	}
+	return func(path neo4j.EducationPath) error {
+		for _, program := range path.Programs {
+			if !suitable[program.Name] {
+				return nil
+			}
+		}
+		return emit(path)
+	}, nil
+}
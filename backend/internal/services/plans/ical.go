@@ -0,0 +1,74 @@
+package plans
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// icalEscaper escapes TEXT values as RFC 5545 requires
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// ICalendar renders the schedule as an iCalendar file with one weekly recurring
+// event per roadmap step. Times carry the schedule's IANA time zone ID, which
+// Google, Apple and Outlook calendars resolve without a VTIMEZONE definition.
+func (s *StudySchedule) ICalendar() []byte {
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		writeFolded(&b, fmt.Sprintf(format, args...))
+	}
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//PathwayLK//Study Schedule//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:%s", icalEscaper.Replace("Study plan: "+s.ProgramName))
+	line("X-WR-TIMEZONE:%s", s.TimeZone)
+
+	for _, block := range s.Blocks {
+		end := block.FirstSession.Add(time.Duration(s.SessionMinutes) * time.Minute)
+		line("BEGIN:VEVENT")
+		line("UID:%s-step-%d@pathwaylk", s.PlanID, block.StepNumber)
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART;TZID=%s:%s", s.TimeZone, block.FirstSession.Format("20060102T150405"))
+		line("DTEND;TZID=%s:%s", s.TimeZone, end.Format("20060102T150405"))
+		line("RRULE:%s", s.RRule(&block))
+		line("SUMMARY:%s", icalEscaper.Replace(block.Summary(s.ProgramName)))
+		line("DESCRIPTION:%s", icalEscaper.Replace(block.Details()))
+		if s.ReminderMinutes >= 0 {
+			line("BEGIN:VALARM")
+			line("ACTION:DISPLAY")
+			line("DESCRIPTION:%s", icalEscaper.Replace(block.Title))
+			line("TRIGGER:-PT%dM", s.ReminderMinutes)
+			line("END:VALARM")
+		}
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// RRule is the recurrence rule of a block's weekly sessions
+func (s *StudySchedule) RRule(block *StudyBlock) string {
+	return fmt.Sprintf("FREQ=WEEKLY;BYDAY=%s;COUNT=%d", s.ByDay(), block.Sessions)
+}
+
+// writeFolded writes a content line, folding it at 75 octets without splitting
+// UTF-8 characters
+func writeFolded(b *strings.Builder, content string) {
+	const limit = 75
+	width := 0
+	for _, r := range content {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,251 @@
+package plans
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"go.uber.org/zap"
+)
+
+const (
+	// Defaults for study schedules: three one-hour evening sessions a week with a
+	// reminder half an hour before, in Sri Lankan time
+	defaultStudyDays       = "mon,wed,sat"
+	defaultStudyTime       = "18:00"
+	defaultSessionMinutes  = 60
+	defaultReminderMinutes = 30
+	defaultStudyTimeZone   = "Asia/Colombo"
+
+	// defaultStepWeeks is used for roadmap steps whose duration cannot be read
+	defaultStepWeeks = 2
+
+	dateLayout = "2006-01-02"
+)
+
+// ErrInvalidSchedule is returned for schedule options that cannot be used
+var ErrInvalidSchedule = errors.New("invalid schedule options")
+
+var (
+	weekdayCodes = map[string]time.Weekday{
+		"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+		"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+	}
+
+	// durationPattern reads roadmap step durations such as "2-3 weeks" or "1 month"
+	durationPattern = regexp.MustCompile(`(?i)(\d+)(?:\s*(?:-|–|to)\s*(\d+))?\s*(day|week|month)`)
+)
+
+// ScheduleOptions shape a study schedule. Empty fields take the defaults.
+type ScheduleOptions struct {
+	StartDate       string `form:"start" json:"start"`       // YYYY-MM-DD; today when empty
+	Days            string `form:"days" json:"days"`         // comma-separated weekdays, e.g. mon,wed,sat
+	StartTime       string `form:"time" json:"time"`         // HH:MM, local to the time zone
+	SessionMinutes  int    `form:"minutes" json:"minutes"`   // length of a study session
+	ReminderMinutes int    `form:"reminder" json:"reminder"` // reminder before each session; -1 for none
+	TimeZone        string `form:"tz" json:"tz"`             // IANA time zone
+}
+
+// StudySchedule spreads the steps of a plan's learning roadmap over weekly study
+// sessions
+type StudySchedule struct {
+	PlanID          string       `json:"plan_id"`
+	ProgramName     string       `json:"program_name"`
+	TimeZone        string       `json:"time_zone"`
+	Days            []string     `json:"days"`
+	StartTime       string       `json:"start_time"`
+	SessionMinutes  int          `json:"session_minutes"`
+	ReminderMinutes int          `json:"reminder_minutes"` // -1 when sessions have no reminder
+	StartDate       string       `json:"start_date"`
+	EndDate         string       `json:"end_date"`
+	Blocks          []StudyBlock `json:"blocks"`
+}
+
+// StudyBlock is the run of weekly sessions spent on one roadmap step
+type StudyBlock struct {
+	StepNumber   int       `json:"step_number"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Topics       []string  `json:"topics,omitempty"`
+	StartDate    string    `json:"start_date"`
+	EndDate      string    `json:"end_date"` // last day of the block
+	Weeks        int       `json:"weeks"`
+	Sessions     int       `json:"sessions"`
+	FirstSession time.Time `json:"first_session"`
+}
+
+// Summary is the calendar event title of the block's sessions
+func (b *StudyBlock) Summary(programName string) string {
+	return fmt.Sprintf("Study: %s (%s, step %d)", b.Title, programName, b.StepNumber)
+}
+
+// Details is the calendar event description of the block's sessions
+func (b *StudyBlock) Details() string {
+	details := b.Description
+	if len(b.Topics) > 0 {
+		details += "\n\nTopics: " + strings.Join(b.Topics, ", ")
+	}
+	return details
+}
+
+// ByDay lists the session weekdays as iCalendar BYDAY codes
+func (s *StudySchedule) ByDay() string {
+	codes := make([]string, len(s.Days))
+	for i, day := range s.Days {
+		codes[i] = strings.ToUpper(day[:2])
+	}
+	return strings.Join(codes, ",")
+}
+
+// StudySchedule builds a study schedule from the learning roadmap of a plan owned
+// by the given user, generating the roadmap if it was not generated before
+func (s *Service) StudySchedule(ctx context.Context, userID, planID string, opts ScheduleOptions) (*StudySchedule, error) {
+	plan, err := s.GetPlan(ctx, userID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, start, err := newSchedule(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	roadmap, err := s.pathwayService.GetLearningRoadmap(ctx, plan.ProgramName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roadmap for schedule: %w", err)
+	}
+
+	schedule.PlanID = plan.ID
+	schedule.ProgramName = plan.ProgramName
+	schedule.fill(roadmap.Steps, start)
+
+	s.logger.Info("Study schedule built",
+		zap.String("plan_id", plan.ID),
+		zap.Int("blocks", len(schedule.Blocks)),
+		zap.String("end_date", schedule.EndDate))
+	return schedule, nil
+}
+
+// newSchedule applies defaults to the options and checks them, returning the
+// empty schedule and the local time of the first day's session slot
+func newSchedule(opts ScheduleOptions) (*StudySchedule, time.Time, error) {
+	schedule := &StudySchedule{
+		TimeZone:        cmp.Or(opts.TimeZone, defaultStudyTimeZone),
+		StartTime:       cmp.Or(opts.StartTime, defaultStudyTime),
+		SessionMinutes:  opts.SessionMinutes,
+		ReminderMinutes: opts.ReminderMinutes,
+	}
+	if schedule.SessionMinutes == 0 {
+		schedule.SessionMinutes = defaultSessionMinutes
+	}
+	if schedule.ReminderMinutes == 0 {
+		schedule.ReminderMinutes = defaultReminderMinutes
+	}
+
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: unknown time zone %q", ErrInvalidSchedule, schedule.TimeZone)
+	}
+	if schedule.SessionMinutes < 15 || schedule.SessionMinutes > 8*60 {
+		return nil, time.Time{}, fmt.Errorf("%w: sessions must last 15 to 480 minutes", ErrInvalidSchedule)
+	}
+	if schedule.ReminderMinutes < -1 || schedule.ReminderMinutes > 7*24*60 {
+		return nil, time.Time{}, fmt.Errorf("%w: reminders must be at most a week before, or -1 for none", ErrInvalidSchedule)
+	}
+	clock, err := time.Parse("15:04", schedule.StartTime)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: time must be HH:MM", ErrInvalidSchedule)
+	}
+
+	for _, day := range strings.Split(cmp.Or(opts.Days, defaultStudyDays), ",") {
+		day = strings.ToLower(strings.TrimSpace(day))
+		if len(day) > 3 {
+			day = day[:3]
+		}
+		if _, ok := weekdayCodes[day]; !ok {
+			return nil, time.Time{}, fmt.Errorf("%w: unknown weekday %q", ErrInvalidSchedule, day)
+		}
+		if !slices.Contains(schedule.Days, day) {
+			schedule.Days = append(schedule.Days, day)
+		}
+	}
+
+	day := time.Now().In(location)
+	if opts.StartDate != "" {
+		if day, err = time.ParseInLocation(dateLayout, opts.StartDate, location); err != nil {
+			return nil, time.Time{}, fmt.Errorf("%w: start must be YYYY-MM-DD", ErrInvalidSchedule)
+		}
+	}
+	start := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, location)
+	schedule.StartDate = start.Format(dateLayout)
+	return schedule, start, nil
+}
+
+// fill lays the roadmap steps out one after another, each over whole weeks, so
+// that every block holds the same sessions each week
+func (s *StudySchedule) fill(steps []pathway.LearningStepWithVideos, start time.Time) {
+	blockStart := start
+	for _, step := range steps {
+		weeks := stepWeeks(step.Duration)
+		next := blockStart.AddDate(0, 0, 7*weeks)
+
+		first := blockStart
+		for !s.onStudyDay(first) {
+			first = first.AddDate(0, 0, 1)
+		}
+
+		s.Blocks = append(s.Blocks, StudyBlock{
+			StepNumber:   step.StepNumber,
+			Title:        step.Title,
+			Description:  step.Description,
+			Topics:       step.Topics,
+			StartDate:    blockStart.Format(dateLayout),
+			EndDate:      next.AddDate(0, 0, -1).Format(dateLayout),
+			Weeks:        weeks,
+			Sessions:     weeks * len(s.Days),
+			FirstSession: first,
+		})
+		blockStart = next
+	}
+	s.EndDate = blockStart.AddDate(0, 0, -1).Format(dateLayout)
+}
+
+func (s *StudySchedule) onStudyDay(t time.Time) bool {
+	for _, day := range s.Days {
+		if weekdayCodes[day] == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// stepWeeks reads the upper bound of a step duration in whole weeks
+func stepWeeks(duration string) int {
+	match := durationPattern.FindStringSubmatch(duration)
+	if match == nil {
+		return defaultStepWeeks
+	}
+
+	amount, _ := strconv.Atoi(match[1])
+	if match[2] != "" {
+		amount, _ = strconv.Atoi(match[2])
+	}
+
+	var weeks int
+	switch strings.ToLower(match[3]) {
+	case "day":
+		weeks = (amount + 6) / 7
+	case "week":
+		weeks = amount
+	case "month":
+		weeks = (amount*52 + 11) / 12
+	}
+	return max(1, min(weeks, 52))
+}
@@ -0,0 +1,270 @@
+package plans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrPlanNotFound is returned when a plan does not exist or belongs to another user
+	ErrPlanNotFound = errors.New("plan not found")
+
+	// ErrInvalidShareLink is returned when a share token is unknown, expired or revoked
+	ErrInvalidShareLink = errors.New("share link is invalid or has expired")
+)
+
+// Service handles student plans and the views shared from them
+type Service struct {
+	store          *mongodb.StudentPlanStore
+	pathwayService *pathway.Service
+	logger         *zap.Logger
+}
+
+// NewService creates a new plan service
+func NewService(mongoClient *mongodb.Client, pathwayService *pathway.Service, logger *zap.Logger) *Service {
+	return &Service{
+		store:          mongodb.NewStudentPlanStore(mongoClient, logger),
+		pathwayService: pathwayService,
+		logger:         logger,
+	}
+}
+
+// CreatePlanRequest contains the fields a student provides when choosing a pathway
+type CreatePlanRequest struct {
+	ProgramName string `json:"program_name" binding:"required"`
+	CareerTitle string `json:"career_title"`
+	Notes       string `json:"notes"`
+}
+
+// CreatePlan records the pathway a student has chosen
+func (s *Service) CreatePlan(ctx context.Context, userID string, req CreatePlanRequest) (*mongodb.StudentPlan, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	// Make sure the program actually exists before saving the plan
+	if _, err := s.pathwayService.GetProgramDetails(ctx, req.ProgramName); err != nil {
+		return nil, fmt.Errorf("failed to validate program: %w", err)
+	}
+
+	plan := &mongodb.StudentPlan{
+		UserID:      userID,
+		ProgramName: req.ProgramName,
+		CareerTitle: req.CareerTitle,
+		Notes:       req.Notes,
+	}
+	if err := s.store.CreatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// ListPlans returns all plans of a user
+func (s *Service) ListPlans(ctx context.Context, userID string) ([]mongodb.StudentPlan, error) {
+	return s.store.ListPlans(ctx, userID)
+}
+
+// CareerCounts counts how many of the given users target each career, most
+// common first
+func (s *Service) CareerCounts(ctx context.Context, userIDs []string) ([]mongodb.CareerCount, error) {
+	if len(userIDs) == 0 {
+		return []mongodb.CareerCount{}, nil
+	}
+	return s.store.CountCareersByUsers(ctx, userIDs)
+}
+
+// GetPlan returns a plan owned by the given user
+func (s *Service) GetPlan(ctx context.Context, userID, planID string) (*mongodb.StudentPlan, error) {
+	plan, err := s.store.GetPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil || plan.UserID != userID {
+		return nil, ErrPlanNotFound
+	}
+	return plan, nil
+}
+
+// CreateShareLink issues a guardian share link for a plan owned by the given user
+func (s *Service) CreateShareLink(ctx context.Context, userID, planID string) (*mongodb.ShareLink, error) {
+	plan, err := s.GetPlan(ctx, userID, planID)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.CreateShareLink(ctx, plan, mongodb.ShareScopeGuardian)
+}
+
+// RevokeShareLink revokes a share link owned by the given user
+func (s *Service) RevokeShareLink(ctx context.Context, userID, token string) error {
+	found, err := s.store.RevokeShareLink(ctx, userID, token)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrInvalidShareLink
+	}
+	return nil
+}
+
+// GuardianSummary is a plain-language, read-only overview of a student's plan
+type GuardianSummary struct {
+	ProgramName  string          `json:"program_name"`
+	Institute    string          `json:"institute"`
+	Headline     string          `json:"headline"`
+	Cost         CostSummary     `json:"cost"`
+	Timeline     TimelineSummary `json:"timeline"`
+	Outcomes     OutcomeSummary  `json:"outcomes"`
+	Requirements []string        `json:"requirements"`
+	NextSteps    []string        `json:"next_steps"`
+}
+
+// CostSummary describes what the pathway is expected to cost
+type CostSummary struct {
+	Summary string `json:"summary"`
+}
+
+// TimelineSummary describes how long the pathway takes
+type TimelineSummary struct {
+	TotalDuration string   `json:"total_duration"`
+	Stages        []string `json:"stages"`
+	Summary       string   `json:"summary"`
+}
+
+// OutcomeSummary describes where the pathway can lead
+type OutcomeSummary struct {
+	Careers []string `json:"careers"`
+	Summary string   `json:"summary"`
+}
+
+// GetGuardianSummary builds the guardian view of the plan behind a share token
+func (s *Service) GetGuardianSummary(ctx context.Context, token string) (*GuardianSummary, error) {
+	link, err := s.store.GetShareLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil || link.Scope != mongodb.ShareScopeGuardian {
+		return nil, ErrInvalidShareLink
+	}
+
+	plan, err := s.store.GetPlan(ctx, link.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, ErrInvalidShareLink
+	}
+
+	details, err := s.pathwayService.GetProgramDetails(ctx, plan.ProgramName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load program for guardian summary: %w", err)
+	}
+
+	summary := &GuardianSummary{
+		ProgramName: details.Name,
+		Institute:   details.Institute,
+		Cost: CostSummary{
+			Summary: "Fee information for this program is not available yet. Please contact the institute's admissions office for current fees.",
+		},
+	}
+
+	if info := details.Info; info != nil && info.AnnualFeeLKR != nil {
+		if *info.AnnualFeeLKR == 0 {
+			summary.Cost.Summary = "This program charges no tuition fee. Living and study material costs still apply."
+		} else {
+			summary.Cost.Summary = fmt.Sprintf("The tuition fee is about LKR %d a year. Please confirm the current fee with the institute.", *info.AnnualFeeLKR)
+		}
+	}
+
+	summary.Headline = fmt.Sprintf("Your child is planning to study %s", details.Name)
+	if details.Institute != "" {
+		summary.Headline += fmt.Sprintf(" at %s", details.Institute)
+	}
+	summary.Headline += "."
+
+	// Timeline - prefer the cached roadmap, never trigger an LLM call from a shared view
+	summary.Timeline = s.buildTimeline(ctx, details)
+
+	// Outcomes
+	for _, career := range details.CareerPaths {
+		summary.Outcomes.Careers = append(summary.Outcomes.Careers, career.Title)
+	}
+	if plan.CareerTitle != "" && !containsFold(summary.Outcomes.Careers, plan.CareerTitle) {
+		summary.Outcomes.Careers = append([]string{plan.CareerTitle}, summary.Outcomes.Careers...)
+	}
+	switch {
+	case plan.CareerTitle != "":
+		summary.Outcomes.Summary = fmt.Sprintf("The goal is to work as a %s after completing this program.", plan.CareerTitle)
+	case len(summary.Outcomes.Careers) > 0:
+		summary.Outcomes.Summary = fmt.Sprintf("This program can lead to jobs such as %s.", strings.Join(summary.Outcomes.Careers, ", "))
+	default:
+		summary.Outcomes.Summary = "Career outcomes for this program have not been recorded yet."
+	}
+
+	// Requirements
+	for _, req := range details.Requirements {
+		summary.Requirements = append(summary.Requirements, req.Name)
+	}
+	for _, prereq := range details.Prerequisites {
+		summary.Requirements = append(summary.Requirements, fmt.Sprintf("Completion of %s", prereq.Name))
+	}
+
+	summary.NextSteps = []string{
+		"Talk with your child about why this program interests them.",
+		"Check the entry requirements above against your child's exam results.",
+	}
+	if details.Institute != "" {
+		summary.NextSteps = append(summary.NextSteps,
+			fmt.Sprintf("Contact %s to confirm fees, intake dates and application deadlines.", details.Institute))
+	}
+
+	s.logger.Info("Guardian summary served",
+		zap.String("plan_id", plan.ID),
+		zap.String("program", details.Name))
+
+	return summary, nil
+}
+
+// buildTimeline describes the program duration using the cached roadmap when
+// available. A duration recorded for the program wins over the roadmap's.
+func (s *Service) buildTimeline(ctx context.Context, details *neo4j.ProgramDetails) TimelineSummary {
+	timeline := TimelineSummary{}
+
+	roadmap, err := s.pathwayService.GetCachedLearningRoadmap(ctx, details.Name)
+	if err == nil && roadmap != nil {
+		timeline.TotalDuration = roadmap.TotalDuration
+		for _, step := range roadmap.Steps {
+			stage := step.Title
+			if step.Duration != "" {
+				stage = fmt.Sprintf("%s (%s)", step.Title, step.Duration)
+			}
+			timeline.Stages = append(timeline.Stages, stage)
+		}
+	}
+
+	if details.Info != nil && details.Info.DurationMonths > 0 || timeline.TotalDuration == "" {
+		timeline.TotalDuration = pathway.FormatDurationMonths(pathway.ProgramDurationMonths(details))
+	}
+
+	if timeline.TotalDuration == "" {
+		timeline.Summary = "The length of this program has not been recorded yet. Please ask the institute."
+	} else {
+		timeline.Summary = fmt.Sprintf("This pathway usually takes about %s to complete.", timeline.TotalDuration)
+	}
+	return timeline
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+// Package progress tracks which steps of their learning roadmaps users have
+// completed.
+package progress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrProgressNotFound is returned for roadmaps the user has not started
+	ErrProgressNotFound = errors.New("roadmap progress not found")
+
+	// ErrRoadmapNotAvailable is returned when the program has no generated
+	// roadmap yet, so its steps are unknown
+	ErrRoadmapNotAvailable = errors.New("roadmap has not been generated for this program")
+
+	// ErrInvalidStep is returned for step numbers outside the roadmap
+	ErrInvalidStep = errors.New("invalid roadmap step")
+)
+
+// Progress is a user's progress on one roadmap
+type Progress struct {
+	*mongodb.RoadmapProgress
+	Completed int  `json:"completed"` // completed steps still in the roadmap
+	Percent   int  `json:"percent"`
+	Complete  bool `json:"complete"` // every step completed; a badge can be claimed
+
+	// LatestVersion is the program's newest roadmap version. When it is past
+	// the pinned RoadmapVersion, the user can be offered the newer roadmap.
+	LatestVersion int `json:"latest_version,omitempty"`
+}
+
+// Service records roadmap progress
+type Service struct {
+	store          *mongodb.RoadmapProgressStore
+	pathwayService *pathway.Service
+	logger         *zap.Logger
+}
+
+// NewService creates a new progress service
+func NewService(mongoClient *mongodb.Client, pathwayService *pathway.Service, logger *zap.Logger) *Service {
+	return &Service{
+		store:          mongodb.NewRoadmapProgressStore(mongoClient, logger),
+		pathwayService: pathwayService,
+		logger:         logger,
+	}
+}
+
+// SetStep marks a step of the program's roadmap as completed or not. Steps are
+// numbered from 1 as in the roadmap.
+func (s *Service) SetStep(ctx context.Context, userID, programName string, step int, completed bool) (*Progress, error) {
+	programName = strings.TrimSpace(programName)
+	s.logger.Debug("Updating roadmap progress",
+		zap.String("program", programName),
+		zap.Int("step", step),
+		zap.Bool("completed", completed))
+
+	roadmap, err := s.followedRoadmap(ctx, userID, programName)
+	if err != nil {
+		return nil, err
+	}
+	totalSteps := len(roadmap.Steps)
+	if step < 1 || step > totalSteps {
+		return nil, fmt.Errorf("%w: step must be between 1 and %d", ErrInvalidStep, totalSteps)
+	}
+
+	progress, err := s.store.SetStep(ctx, userID, roadmap.ProgramName, roadmap.Version, totalSteps, step, completed)
+	if err != nil {
+		return nil, err
+	}
+	if progress == nil {
+		return nil, ErrProgressNotFound
+	}
+
+	result := s.withLatestVersion(ctx, summarize(progress))
+	if completed && result.Complete {
+		s.logger.Info("Roadmap completed",
+			zap.String("program", progress.ProgramName),
+			zap.Int("steps", totalSteps))
+	}
+	return result, nil
+}
+
+// followedRoadmap returns the roadmap the user is following: the version their
+// progress is pinned to, or the cached roadmap when they have not started or
+// started before versions were kept. Progress is never a reason to generate one.
+func (s *Service) followedRoadmap(ctx context.Context, userID, programName string) (*pathway.LearningRoadmapResponse, error) {
+	progress, err := s.store.Get(ctx, userID, programName)
+	if err != nil {
+		return nil, err
+	}
+	if progress != nil && progress.RoadmapVersion > 0 {
+		roadmap, err := s.pathwayService.GetRoadmapVersion(ctx, progress.ProgramName, progress.RoadmapVersion)
+		if err == nil {
+			return roadmap, nil
+		}
+		s.logger.Warn("Pinned roadmap version unavailable, using the cached roadmap",
+			zap.String("program", programName),
+			zap.Int("version", progress.RoadmapVersion),
+			zap.Error(err))
+	}
+
+	roadmap, err := s.pathwayService.GetCachedLearningRoadmap(ctx, programName)
+	if err != nil || roadmap == nil {
+		return nil, fmt.Errorf("%w: %s", ErrRoadmapNotAvailable, programName)
+	}
+	return roadmap, nil
+}
+
+// withLatestVersion adds the program's newest roadmap version to progress
+func (s *Service) withLatestVersion(ctx context.Context, progress *Progress) *Progress {
+	latest, err := s.pathwayService.LatestRoadmapVersion(ctx, progress.ProgramName)
+	if err != nil {
+		s.logger.Warn("Failed to fetch latest roadmap version",
+			zap.String("program", progress.ProgramName),
+			zap.Error(err))
+		return progress
+	}
+	progress.LatestVersion = latest
+	return progress
+}
+
+// Get returns a user's progress on a program's roadmap
+func (s *Service) Get(ctx context.Context, userID, programName string) (*Progress, error) {
+	progress, err := s.store.Get(ctx, userID, strings.TrimSpace(programName))
+	if err != nil {
+		return nil, err
+	}
+	if progress == nil {
+		return nil, ErrProgressNotFound
+	}
+	return s.withLatestVersion(ctx, summarize(progress)), nil
+}
+
+// List returns a user's progress on every roadmap they started
+func (s *Service) List(ctx context.Context, userID string) ([]Progress, error) {
+	stored, err := s.store.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]Progress, 0, len(stored))
+	for i := range stored {
+		progress = append(progress, *summarize(&stored[i]))
+	}
+	return progress, nil
+}
+
+// ListByUsers returns the progress of the given users on every roadmap they started
+func (s *Service) ListByUsers(ctx context.Context, userIDs []string) ([]Progress, error) {
+	if len(userIDs) == 0 {
+		return []Progress{}, nil
+	}
+	stored, err := s.store.ListByUsers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]Progress, 0, len(stored))
+	for i := range stored {
+		progress = append(progress, *summarize(&stored[i]))
+	}
+	return progress, nil
+}
+
+// summarize counts the completed steps that are still part of the roadmap
+func summarize(progress *mongodb.RoadmapProgress) *Progress {
+	result := &Progress{RoadmapProgress: progress, Complete: progress.Complete()}
+	for _, step := range progress.CompletedSteps {
+		if step >= 1 && step <= progress.TotalSteps {
+			result.Completed++
+		}
+	}
+	if progress.TotalSteps > 0 {
+		result.Percent = result.Completed * 100 / progress.TotalSteps
+	}
+	return result
+}
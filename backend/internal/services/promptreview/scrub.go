@@ -0,0 +1,24 @@
+package promptreview
+
+import "regexp"
+
+// scrubbers replace personal details students may type into free-text fields,
+// in order: NIC numbers before phone numbers, as new NICs are 12 digits long
+var scrubbers = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), "[email]"},
+	{regexp.MustCompile(`\b(?:\d{9}[VvXx]|(?:19|20)\d{10})\b`), "[nic]"},
+	{regexp.MustCompile(`(?:\+|\b)\d(?:[\s-]?\d){8,13}\b`), "[phone]"},
+}
+
+// Scrub removes email addresses, national identity card numbers and phone
+// numbers from text. Names and addresses are not recognised, so prompts should
+// not carry them.
+func Scrub(text string) string {
+	for _, scrubber := range scrubbers {
+		text = scrubber.pattern.ReplaceAllString(text, scrubber.replacement)
+	}
+	return text
+}
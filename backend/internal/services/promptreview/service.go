@@ -0,0 +1,161 @@
+// Package promptreview captures prompts sent to the LLM and its responses,
+// scrubbed of personal details, so admins can browse and label them while
+// improving the prompts.
+package promptreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/core/llm"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.uber.org/zap"
+)
+
+const (
+	// Defaults and bounds for exchange listings
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+
+	// storeTimeout bounds storing one captured exchange
+	storeTimeout = 5 * time.Second
+)
+
+// Labels a reviewer can give an exchange
+const (
+	LabelGood      = "good"
+	LabelNeedsWork = "needs_work" // usable, but the prompt could do better
+	LabelWrong     = "wrong"      // factually wrong or unusable
+	LabelUnsafe    = "unsafe"     // harmful or inappropriate for students
+)
+
+// Labels lists every label, best first
+var Labels = []string{LabelGood, LabelNeedsWork, LabelWrong, LabelUnsafe}
+
+var (
+	// ErrExchangeNotFound is returned for exchanges that were never captured or
+	// have passed the retention period
+	ErrExchangeNotFound = errors.New("LLM exchange not found")
+
+	// ErrInvalidLabel is returned for labels outside Labels
+	ErrInvalidLabel = errors.New("invalid label")
+
+	// ErrInvalidFilter is returned for listings by an unknown task or label
+	ErrInvalidFilter = errors.New("invalid exchange filter")
+)
+
+// Service captures and labels LLM exchanges
+type Service struct {
+	store      *mongodb.LLMExchangeStore
+	sampleRate float64
+	logger     *zap.Logger
+}
+
+// NewService creates a new prompt review service. Exchanges are only captured
+// once Capture is given to the LLM client, which is up to the caller.
+func NewService(mongoClient *mongodb.Client, cfg config.LLMConfig, logger *zap.Logger) *Service {
+	return &Service{
+		store:      mongodb.NewLLMExchangeStore(mongoClient, logger),
+		sampleRate: min(max(cfg.CaptureSampleRate, 0), 1),
+		logger:     logger,
+	}
+}
+
+// Capture scrubs a sample of exchanges and stores them in the background. It
+// is given to the LLM client with UseCapture.
+func (s *Service) Capture(exchange llm.Exchange) {
+	if s.sampleRate < 1 && rand.Float64() >= s.sampleRate {
+		return
+	}
+
+	stored := &mongodb.LLMExchange{
+		Task:         exchange.Task,
+		Model:        exchange.Model,
+		SystemPrompt: Scrub(exchange.SystemPrompt),
+		UserPrompt:   Scrub(exchange.UserPrompt),
+		Temperature:  exchange.Temperature,
+		Response:     Scrub(exchange.Response),
+		Error:        Scrub(exchange.Error),
+		DurationMS:   exchange.Duration.Milliseconds(),
+		CreatedAt:    exchange.At,
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+		defer cancel()
+
+		if err := s.store.Insert(ctx, stored); err != nil {
+			s.logger.Warn("Failed to capture LLM exchange",
+				zap.String("task", stored.Task),
+				zap.Error(err))
+		}
+	}()
+}
+
+// List returns captured exchanges, newest first. Unlabeled takes precedence
+// over a label.
+func (s *Service) List(ctx context.Context, filter mongodb.LLMExchangeFilter, limit int) ([]mongodb.LLMExchange, error) {
+	if filter.Task != "" && !slices.Contains(llm.Tasks, filter.Task) {
+		return nil, fmt.Errorf("%w: task must be one of %s", ErrInvalidFilter, strings.Join(llm.Tasks, ", "))
+	}
+	if filter.Label != "" && !slices.Contains(Labels, filter.Label) {
+		return nil, fmt.Errorf("%w: label must be one of %s", ErrInvalidFilter, strings.Join(Labels, ", "))
+	}
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+	return s.store.List(ctx, filter, limit)
+}
+
+// Counts returns the number of captured exchanges per task and label
+func (s *Service) Counts(ctx context.Context) (map[string]map[string]int64, error) {
+	return s.store.CountByLabel(ctx)
+}
+
+// Get returns a captured exchange
+func (s *Service) Get(ctx context.Context, id string) (*mongodb.LLMExchange, error) {
+	exchange, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exchange == nil {
+		return nil, ErrExchangeNotFound
+	}
+	return exchange, nil
+}
+
+// Label records a reviewer's verdict on an exchange, replacing any earlier one
+func (s *Service) Label(ctx context.Context, id, label, note, reviewer string) (*mongodb.LLMExchange, error) {
+	s.logger.Debug("Labeling LLM exchange",
+		zap.String("id", id),
+		zap.String("label", label))
+
+	if !slices.Contains(Labels, label) {
+		return nil, fmt.Errorf("%w: label must be one of %s", ErrInvalidLabel, strings.Join(Labels, ", "))
+	}
+
+	exchange, err := s.store.SetLabel(ctx, id, label, strings.TrimSpace(note), reviewer)
+	if err != nil {
+		s.logger.Error("Failed to label LLM exchange",
+			zap.String("id", id),
+			zap.Error(err))
+		return nil, err
+	}
+	if exchange == nil {
+		return nil, ErrExchangeNotFound
+	}
+
+	s.logger.Info("LLM exchange labeled",
+		zap.String("id", id),
+		zap.String("task", exchange.Task),
+		zap.String("label", label))
+	return exchange, nil
+}
@@ -0,0 +1,31 @@
+package resources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"plugin"
+
+	"go.uber.org/zap"
+)
+
+// LoadPlugins opens every Go plugin (*.so) in dir, whose init functions register
+// their providers. Plugins must be built with the same Go version and module
+// versions as the server, and cannot be unloaded.
+func LoadPlugins(dir string, logger *zap.Logger) error {
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("failed to read resource provider plugin directory: %w", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.so"))
+	if err != nil {
+		return fmt.Errorf("failed to list resource provider plugins: %w", err)
+	}
+
+	for _, path := range paths {
+		if _, err := plugin.Open(path); err != nil {
+			return fmt.Errorf("failed to load resource provider plugin %s: %w", filepath.Base(path), err)
+		}
+		logger.Info("Loaded resource provider plugin", zap.String("plugin", filepath.Base(path)))
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Package resources is the registry of learning-resource providers used to enrich
+// roadmap steps beyond YouTube videos: local e-learning platforms, ministry content
+// portals and the like. A provider registers a factory under its name from an
+// init function, in this binary or in a Go plugin, and is enabled by listing that
+// name in RESOURCE_PROVIDERS.
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Kinds of resources
+const (
+	KindCourse   = "course"
+	KindArticle  = "article"
+	KindVideo    = "video"
+	KindExercise = "exercise"
+)
+
+// Pricing of resources. Resources a provider leaves untagged are suggested as
+// of unknown pricing.
+const (
+	PricingFree    = "free"
+	PricingPaid    = "paid"
+	PricingUnknown = "unknown"
+)
+
+// ErrUnknownProvider is returned for provider names nothing registered
+var ErrUnknownProvider = errors.New("unknown resource provider")
+
+// Resource is a learning resource for a roadmap topic
+type Resource struct {
+	Provider    string `json:"provider"`
+	Kind        string `json:"kind"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+	Language    string `json:"language,omitempty"` // e.g. "si", "ta" or "en"
+	Thumbnail   string `json:"thumbnail,omitempty"`
+	Pricing     string `json:"pricing"`         // PricingFree, PricingPaid or PricingUnknown
+	Price       *Price `json:"price,omitempty"` // of a paid resource, where known
+}
+
+// Price is what a paid resource costs
+type Price struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"` // ISO 4217 code, e.g. "LKR"
+}
+
+// Provider finds learning resources for a topic. Search is called concurrently
+// and should honor the context's deadline; returning no resources is not an error.
+type Provider interface {
+	Name() string
+	Search(ctx context.Context, topic string, limit int) ([]Resource, error)
+}
+
+// Factory creates a provider from its settings, the RESOURCE_<NAME>_<KEY>
+// environment variables keyed by lowercase KEY (e.g. RESOURCE_MOODLE_URL is "url")
+type Factory func(settings map[string]string, logger *zap.Logger) (Provider, error)
+
+var (
+	mu        sync.RWMutex
+	factories = make(map[string]Factory)
+)
+
+// Register makes a provider factory available under a name. It is meant to be
+// called from init functions and panics if the name is taken or factory is nil.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if factory == nil {
+		panic("resources: Register factory is nil for " + name)
+	}
+	if _, taken := factories[name]; taken {
+		panic("resources: Register called twice for provider " + name)
+	}
+	factories[name] = factory
+}
+
+// Registered returns the names of the registered providers, sorted
+func Registered() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open creates the named providers with their settings, by provider name. A
+// provider that is unknown or fails to open is left out and its error returned
+// alongside the providers that did open.
+func Open(names []string, settings map[string]map[string]string, logger *zap.Logger) ([]Provider, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var providers []Provider
+	var errs []error
+	for _, name := range names {
+		factory, ok := factories[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownProvider, name))
+			continue
+		}
+		provider, err := factory(settings[name], logger.With(zap.String("resource_provider", name)))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to open resource provider %q: %w", name, err))
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	return providers, errors.Join(errs...)
+}
@@ -0,0 +1,194 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+const (
+	// Defaults and bounds for queue listings
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
+
+var (
+	// ErrItemNotFound is returned when a review item does not exist
+	ErrItemNotFound = errors.New("review item not found")
+
+	// ErrAlreadyResolved is returned when approving or rejecting a resolved item
+	ErrAlreadyResolved = errors.New("review item is already resolved")
+
+	// ErrInvalidResolution is returned by appliers when the reviewer's resolution
+	// cannot be applied (e.g. it names an entity that does not exist)
+	ErrInvalidResolution = errors.New("invalid resolution")
+)
+
+// Applier applies an approved review item to the graph. The resolution carries
+// the reviewer's decision, such as which existing entity an entry maps to.
+type Applier func(ctx context.Context, item *mongodb.ReviewItem, resolution map[string]interface{}) error
+
+// Service manages the admin approval queue shared by all ingestion pipelines.
+// Pipelines enqueue proposals and register an Applier for each kind they produce.
+type Service struct {
+	queue    *mongodb.ReviewQueue
+	logger   *zap.Logger
+	mu       sync.RWMutex
+	appliers map[string]Applier
+}
+
+// NewService creates a new review service
+func NewService(mongoClient *mongodb.Client, logger *zap.Logger) *Service {
+	return &Service{
+		queue:    mongodb.NewReviewQueue(mongoClient, logger),
+		logger:   logger,
+		appliers: make(map[string]Applier),
+	}
+}
+
+// RegisterApplier sets the function that applies approved items of a kind
+func (s *Service) RegisterApplier(kind string, applier Applier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appliers[kind] = applier
+}
+
+// Enqueue adds a proposal to the queue, refreshing an existing pending proposal
+// with the same dedup key. It reports whether a new item was created.
+func (s *Service) Enqueue(ctx context.Context, item *mongodb.ReviewItem) (bool, error) {
+	created, err := s.queue.Enqueue(ctx, item)
+	if err != nil {
+		s.logger.Error("Failed to enqueue review item",
+			zap.String("source", item.Source),
+			zap.String("kind", item.Kind),
+			zap.Error(err))
+		return false, err
+	}
+	return created, nil
+}
+
+// List returns queue items filtered by source and status
+func (s *Service) List(ctx context.Context, source, status string, limit int) ([]mongodb.ReviewItem, error) {
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+	return s.queue.List(ctx, source, status, limit)
+}
+
+// PendingCounts returns the number of pending items per source
+func (s *Service) PendingCounts(ctx context.Context) (map[string]int64, error) {
+	return s.queue.CountPending(ctx)
+}
+
+// Approve applies a pending item and marks it approved
+func (s *Service) Approve(ctx context.Context, id, reviewer string, resolution map[string]interface{}) (*mongodb.ReviewItem, error) {
+	s.logger.Debug("Approving review item", zap.String("id", id))
+
+	item, err := s.pendingItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	applier, ok := s.appliers[item.Kind]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no applier registered for review items of kind %q", item.Kind)
+	}
+
+	// Appliers can attribute the changes they make to the reviewer
+	item.ResolvedBy = reviewer
+	if err := applier(ctx, item, resolution); err != nil {
+		s.logger.Warn("Failed to apply review item",
+			zap.String("id", id),
+			zap.String("kind", item.Kind),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return s.resolve(ctx, item, mongodb.ReviewStatusApproved, reviewer, resolution)
+}
+
+// Reject marks a pending item rejected without applying it
+func (s *Service) Reject(ctx context.Context, id, reviewer, reason string) (*mongodb.ReviewItem, error) {
+	s.logger.Debug("Rejecting review item", zap.String("id", id))
+
+	item, err := s.pendingItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.resolve(ctx, item, mongodb.ReviewStatusRejected, reviewer, map[string]interface{}{"reason": reason})
+}
+
+func (s *Service) pendingItem(ctx context.Context, id string) (*mongodb.ReviewItem, error) {
+	item, err := s.queue.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrItemNotFound
+	}
+	if item.Status != mongodb.ReviewStatusPending {
+		return nil, ErrAlreadyResolved
+	}
+	return item, nil
+}
+
+func (s *Service) resolve(ctx context.Context, item *mongodb.ReviewItem, status, reviewer string, resolution map[string]interface{}) (*mongodb.ReviewItem, error) {
+	resolved, err := s.queue.Resolve(ctx, item.ID, status, reviewer, resolution)
+	if err != nil {
+		return nil, err
+	}
+	if !resolved {
+		return nil, ErrAlreadyResolved
+	}
+
+	s.logger.Info("Review item resolved",
+		zap.String("id", item.ID),
+		zap.String("source", item.Source),
+		zap.String("kind", item.Kind),
+		zap.String("status", status),
+		zap.String("reviewer", reviewer))
+
+	return s.queue.Get(ctx, item.ID)
+}
+
+// EncodePayload converts a typed payload into the generic form stored in the queue
+func EncodePayload(v interface{}) (map[string]interface{}, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode review payload: %w", err)
+	}
+	var payload map[string]interface{}
+	if err := bson.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to encode review payload: %w", err)
+	}
+	return payload, nil
+}
+
+// DecodePayload converts a stored payload back into its typed form
+func DecodePayload(payload map[string]interface{}, v interface{}) error {
+	data, err := bson.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to decode review payload: %w", err)
+	}
+	if err := bson.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode review payload: %w", err)
+	}
+	return nil
+}
+
+// ResolutionString reads a string field from a reviewer's resolution
+func ResolutionString(resolution map[string]interface{}, key string) string {
+	value, _ := resolution[key].(string)
+	return value
+}
@@ -0,0 +1,302 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mayura-andrew/fastfinder/internal/core/district"
+	"github.com/mayura-andrew/fastfinder/pkg/fuzzy"
+	"go.uber.org/zap"
+)
+
+const (
+	// Advertised monthly salaries outside this range (LKR) are treated as parse errors
+	minMonthlySalary = 10_000
+	maxMonthlySalary = 5_000_000
+
+	// Links with longer text are page sections rather than posting titles
+	maxListingTitleLength = 150
+
+	jobBoardUserAgent = "Mozilla/5.0 (compatible; PathwayLK job market survey)"
+)
+
+// salaryPattern matches salary mentions such as "Rs. 75,000 - 90,000", "LKR 1.2 Mn
+// per annum" or "Salary: 60k to 80k". A currency or "salary" marker is required so
+// phone numbers and dates are not mistaken for salaries, and units and periods
+// must be whole words so the start of "Panadura" or "paid" is not read as p.a.
+var salaryPattern = regexp.MustCompile(`(?i)\b(?:rs\.?|lkr|salary\s*:?)\s*([\d,]+(?:\.\d+)?)\s*(?:(k|mn|million)\b)?` +
+	`(?:\s*(?:/=|/-))?(?:\s*(?:-|–|to)\s*(?:rs\.?|lkr)?\s*([\d,]+(?:\.\d+)?)\s*(?:(k|mn|million)\b)?)?` +
+	`(?:\s*(?:/=|/-))?\s*(\b(?:per\s+(?:month|annum|year)|p\.?\s?m|p\.?\s?a|monthly|annually|yearly)\b\.?)?`)
+
+// SalaryRange is an advertised monthly salary range in LKR. Min equals Max for
+// ads that state a single figure.
+type SalaryRange struct {
+	Min int64 `json:"min"`
+	Max int64 `json:"max"`
+}
+
+// ParseSalaries extracts monthly LKR salary ranges from free text. Annual figures
+// are converted to monthly ones.
+func ParseSalaries(text string) []SalaryRange {
+	var ranges []SalaryRange
+	for _, m := range salaryPattern.FindAllStringSubmatch(text, -1) {
+		low := parseAmount(m[1], m[2])
+		high := low
+		if m[3] != "" {
+			unit := m[4]
+			if unit == "" {
+				// "Rs. 1.2 - 1.5 Mn": the unit is written once, after the range
+				unit = m[2]
+			}
+			high = parseAmount(m[3], unit)
+			if m[2] == "" && unit != "" {
+				low = parseAmount(m[1], unit)
+			}
+		}
+
+		period := strings.ToLower(strings.Join(strings.Fields(m[5]), " "))
+		if strings.Contains(period, "annum") || strings.Contains(period, "year") ||
+			strings.HasPrefix(period, "p.a") || strings.HasPrefix(period, "pa") {
+			low, high = low/12, high/12
+		}
+
+		if low > high {
+			low, high = high, low
+		}
+		if low < minMonthlySalary || high > maxMonthlySalary {
+			continue
+		}
+		ranges = append(ranges, SalaryRange{Min: low, Max: high})
+	}
+	return ranges
+}
+
+// parseAmount converts "75,000", "60" with unit "k" or "1.2" with unit "mn" to rupees
+func parseAmount(number, unit string) int64 {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	switch strings.ToLower(unit) {
+	case "k":
+		value *= 1_000
+	case "mn", "million":
+		value *= 1_000_000
+	}
+	return int64(value)
+}
+
+// BoardSalaries are the salaries advertised on one job board for a search
+type BoardSalaries struct {
+	Board    string        `json:"board"`
+	Salaries []SalaryRange `json:"salaries"`
+}
+
+// JobListing is a job posting found on a board
+type JobListing struct {
+	Board    string       `json:"board"`
+	Title    string       `json:"title"`
+	URL      string       `json:"url"`
+	Salary   *SalaryRange `json:"salary,omitempty"`
+	District string       `json:"district,omitempty"` // where the job is, when the posting names a district
+}
+
+// JobBoardScraper searches job boards and collects the postings and salaries
+// advertised in their results
+type JobBoardScraper struct {
+	searchURLs []string
+	delay      time.Duration
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewJobBoardScraper creates a scraper for the given search URL templates, in
+// which {query} is replaced by the search term
+func NewJobBoardScraper(searchURLs []string, timeout, delay time.Duration, logger *zap.Logger) *JobBoardScraper {
+	return &JobBoardScraper{
+		searchURLs: searchURLs,
+		delay:      delay,
+		httpClient: &http.Client{Timeout: timeout},
+		logger:     logger,
+	}
+}
+
+// Configured reports whether any job board search URL is set
+func (s *JobBoardScraper) Configured() bool {
+	return len(s.searchURLs) > 0
+}
+
+// SearchSalaries searches every board for a job title and collects the salaries
+// advertised with matching postings. Boards that fail are logged and skipped; an
+// error is returned only when all of them fail.
+func (s *JobBoardScraper) SearchSalaries(ctx context.Context, title string) ([]BoardSalaries, error) {
+	var results []BoardSalaries
+	err := s.searchBoards(ctx, title, func(board string, searchURL *url.URL, doc *goquery.Document) {
+		result := BoardSalaries{Board: board}
+		for _, listing := range extractListings(doc, board, searchURL, title) {
+			if listing.Salary != nil {
+				result.Salaries = append(result.Salaries, *listing.Salary)
+			}
+		}
+		results = append(results, result)
+	})
+	return results, err
+}
+
+// SearchListings searches every board for a job title and returns the postings
+// whose titles contain every word of it. Boards that fail are logged and skipped;
+// an error is returned only when all of them fail.
+func (s *JobBoardScraper) SearchListings(ctx context.Context, title string) ([]JobListing, error) {
+	var listings []JobListing
+	err := s.searchBoards(ctx, title, func(board string, searchURL *url.URL, doc *goquery.Document) {
+		listings = append(listings, extractListings(doc, board, searchURL, title)...)
+	})
+	return listings, err
+}
+
+// searchBoards runs a search on each board in turn, pausing between boards, and
+// passes every page that loads to visit
+func (s *JobBoardScraper) searchBoards(ctx context.Context, title string, visit func(board string, searchURL *url.URL, doc *goquery.Document)) error {
+	var (
+		loaded int
+		errs   []error
+	)
+
+	for i, template := range s.searchURLs {
+		if i > 0 && s.delay > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(s.delay):
+			}
+		}
+
+		searchURL := strings.ReplaceAll(template, "{query}", url.QueryEscape(title))
+		u, err := url.Parse(searchURL)
+		if err == nil {
+			var doc *goquery.Document
+			if doc, err = s.fetchDocument(ctx, searchURL); err == nil {
+				visit(boardName(u), u, doc)
+				loaded++
+				continue
+			}
+		}
+
+		s.logger.Warn("Job board search failed",
+			zap.String("url", searchURL),
+			zap.Error(err))
+		errs = append(errs, err)
+	}
+
+	if loaded == 0 && len(errs) > 0 {
+		return fmt.Errorf("all job board searches failed: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// fetchDocument downloads and parses a page
+func (s *JobBoardScraper) fetchDocument(ctx context.Context, pageURL string) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", jobBoardUserAgent)
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+	doc.Find("script, style, noscript").Remove()
+	return doc, nil
+}
+
+// extractListings finds links on a results page whose text looks like a posting
+// for the title. The salary and district of a posting are read from the row or
+// block holding its link.
+func extractListings(doc *goquery.Document, board string, searchURL *url.URL, title string) []JobListing {
+	var listings []JobListing
+	seen := make(map[string]bool)
+
+	doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+		text := strings.Join(strings.Fields(a.Text()), " ")
+		if text == "" || len(text) > maxListingTitleLength || !matchesTitle(text, title) {
+			return
+		}
+
+		href, _ := a.Attr("href")
+		link, err := searchURL.Parse(href)
+		if err != nil || (link.Scheme != "http" && link.Scheme != "https") {
+			return
+		}
+		link.Fragment = ""
+		if (link.Host == searchURL.Host && link.Path == searchURL.Path) || seen[link.String()] {
+			// Links back to the search page are pagination or filters
+			return
+		}
+		seen[link.String()] = true
+
+		listing := JobListing{
+			Board: board,
+			Title: text,
+			URL:   link.String(),
+		}
+		container := a.Closest("tr, li, article")
+		if container.Length() == 0 {
+			container = a.Parent()
+		}
+		details := container.Text()
+		if salaries := ParseSalaries(details); len(salaries) > 0 {
+			listing.Salary = &salaries[0]
+		}
+		listing.District, _ = district.Find(details)
+		listings = append(listings, listing)
+	})
+	return listings
+}
+
+// matchesTitle reports whether text contains every word of title, ignoring case,
+// punctuation and stop words
+func matchesTitle(text, title string) bool {
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(fuzzy.Normalize(text)) {
+		words[w] = true
+	}
+
+	titleWords := strings.Fields(fuzzy.Normalize(title))
+	if len(titleWords) == 0 {
+		return false
+	}
+	for _, w := range titleWords {
+		if !words[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// boardName identifies a board by the host of its search URL
+func boardName(searchURL *url.URL) string {
+	if searchURL.Host == "" {
+		return searchURL.String()
+	}
+	return strings.TrimPrefix(searchURL.Host, "www.")
+}
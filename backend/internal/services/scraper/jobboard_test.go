@@ -0,0 +1,38 @@
+package scraper
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseSalaries(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []SalaryRange
+	}{
+		{"range", "Rs. 75,000 - 90,000", []SalaryRange{{75_000, 90_000}}},
+		{"thousands", "Salary: 60k to 80k", []SalaryRange{{60_000, 80_000}}},
+		{"millions per annum", "LKR 1.2 Mn per annum", []SalaryRange{{100_000, 100_000}}},
+		{"unit after the range", "Rs. 1.2 - 1.5 Mn p.a.", []SalaryRange{{100_000, 125_000}}},
+		{"per month", "Rs. 75,000/= p.m.", []SalaryRange{{75_000, 75_000}}},
+		{"pa abbreviated", "Rs. 600,000 pa", []SalaryRange{{50_000, 50_000}}},
+
+		// Words starting like a period or unit are not one
+		{"town after the amount", "Rs. 50,000 Panadura", []SalaryRange{{50_000, 50_000}}},
+		{"paid after the amount", "Rs. 50,000 paid on completion", []SalaryRange{{50_000, 50_000}}},
+		{"pmb after the amount", "Rs. 50,000 pmb allowance", []SalaryRange{{50_000, 50_000}}},
+		{"town starting with k", "Rs. 50,000 Kandy", []SalaryRange{{50_000, 50_000}}},
+
+		{"no currency marker", "Call 0771234567 before 2025", nil},
+		{"marker inside a word", "Mrs. 45,000 Perera", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseSalaries(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("ParseSalaries(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
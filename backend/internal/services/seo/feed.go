@@ -0,0 +1,128 @@
+package seo
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// feedEntries is how many of the most recently added programs the feed lists
+const feedEntries = 50
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Link       atomLink       `xml:"link"`
+	Summary    string         `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+// NewProgramsFeed is an Atom feed of the most recently added programs, dated by
+// when they were first imported, linking to their pages on the frontend
+func (s *Service) NewProgramsFeed(ctx context.Context) ([]byte, error) {
+	if s.cfg.SiteURL == "" {
+		return nil, ErrSEODisabled
+	}
+
+	programs, err := s.graph.RecentlyAddedPrograms(ctx, feedEntries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recently added programs: %w", err)
+	}
+
+	host := s.cfg.SiteURL
+	if parsed, err := url.Parse(s.cfg.SiteURL); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	feed := atomFeed{
+		XMLNS:   "http://www.w3.org/2005/Atom",
+		ID:      s.cfg.SiteURL + "/",
+		Title:   "New programs on " + host,
+		Author:  atomAuthor{Name: host, URI: s.cfg.SiteURL + "/"},
+		Link:    atomLink{Href: s.cfg.SiteURL + "/"},
+		Entries: make([]atomEntry, 0, len(programs)),
+	}
+
+	var updated time.Time
+	for i := range programs {
+		program := &programs[i]
+		if program.Provenance == nil {
+			continue
+		}
+		added, err := time.Parse(time.RFC3339, program.Provenance.AddedAt)
+		if err != nil {
+			s.logger.Warn("Skipping program with an unreadable added_at",
+				zap.String("program", program.Name),
+				zap.String("added_at", program.Provenance.AddedAt))
+			continue
+		}
+		if added.After(updated) {
+			updated = added
+		}
+
+		entry := atomEntry{
+			ID:        s.ProgramURL(program.Name),
+			Title:     program.Name,
+			Published: added.UTC().Format(time.RFC3339),
+			Updated:   added.UTC().Format(time.RFC3339),
+			Link:      atomLink{Rel: "alternate", Href: s.ProgramURL(program.Name)},
+			Summary:   describe(program),
+		}
+		for _, category := range nonEmpty(program.Institute, program.Faculty) {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	// An empty feed is dated by the last graph change, or failing that now
+	if updated.IsZero() {
+		updated = s.pathwayService.LastChanged()
+	}
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	feed.Updated = updated.UTC().Format(time.RFC3339)
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		return nil, fmt.Errorf("failed to encode feed: %w", err)
+	}
+	buf.WriteString("\n")
+
+	s.logger.Debug("New programs feed built", zap.Int("entries", len(feed.Entries)))
+	return buf.Bytes(), nil
+}
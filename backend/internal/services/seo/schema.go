@@ -0,0 +1,122 @@
+package seo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+)
+
+// Program is the schema.org markup of a program page. The program is typed both
+// as a Course, for course rich results, and as an EducationalOccupationalProgram,
+// which carries the prerequisites, length, intake and careers.
+type Program struct {
+	Context              string        `json:"@context"`
+	Type                 []string      `json:"@type"`
+	ID                   string        `json:"@id,omitempty"`
+	URL                  string        `json:"url,omitempty"`
+	Name                 string        `json:"name"`
+	Description          string        `json:"description"`
+	Provider             *Organization `json:"provider,omitempty"`
+	ProgramPrerequisites []string      `json:"programPrerequisites,omitempty"`
+	CoursePrerequisites  []string      `json:"coursePrerequisites,omitempty"`
+	TimeToComplete       string        `json:"timeToComplete,omitempty"` // ISO 8601 duration
+	MaximumEnrollment    int           `json:"maximumEnrollment,omitempty"`
+	OccupationalCategory []string      `json:"occupationalCategory,omitempty"`
+}
+
+// Organization is the institute offering a program
+type Organization struct {
+	Type      string         `json:"@type"`
+	Name      string         `json:"name"`
+	SameAs    string         `json:"sameAs,omitempty"`
+	Email     string         `json:"email,omitempty"`
+	Telephone string         `json:"telephone,omitempty"`
+	Address   *PostalAddress `json:"address,omitempty"`
+}
+
+// PostalAddress is an institute's address as recorded, in one line
+type PostalAddress struct {
+	Type           string `json:"@type"`
+	StreetAddress  string `json:"streetAddress"`
+	AddressCountry string `json:"addressCountry"`
+}
+
+func newProgram(details *neo4j.ProgramDetails, pageURL string) *Program {
+	program := &Program{
+		Context:     "https://schema.org",
+		Type:        []string{"Course", "EducationalOccupationalProgram"},
+		URL:         pageURL,
+		Name:        details.Name,
+		Description: describe(details),
+	}
+	if pageURL != "" {
+		program.ID = pageURL + "#program"
+	}
+
+	if details.Institute != "" {
+		program.Provider = &Organization{Type: "CollegeOrUniversity", Name: details.Institute}
+		if contact := details.InstituteContact; contact != nil {
+			program.Provider.SameAs = contact.Website
+			program.Provider.Email = contact.Email
+			program.Provider.Telephone = contact.Phone
+			if contact.Address != "" {
+				program.Provider.Address = &PostalAddress{Type: "PostalAddress", StreetAddress: contact.Address, AddressCountry: "LK"}
+			}
+		}
+	}
+
+	for _, requirement := range details.Requirements {
+		program.ProgramPrerequisites = append(program.ProgramPrerequisites, requirement.Name)
+	}
+	for _, prerequisite := range details.Prerequisites {
+		program.CoursePrerequisites = append(program.CoursePrerequisites, prerequisite.Name)
+	}
+	if months := pathway.ProgramDurationMonths(details); months > 0 {
+		program.TimeToComplete = fmt.Sprintf("P%dM", months)
+	}
+	if details.Capacity != nil {
+		program.MaximumEnrollment = details.Capacity.AnnualIntake
+	}
+	for _, career := range details.CareerPaths {
+		program.OccupationalCategory = append(program.OccupationalCategory, career.Title)
+	}
+	return program
+}
+
+// describe writes the description search engines show for a program, from what
+// the graph records about it
+func describe(details *neo4j.ProgramDetails) string {
+	var b strings.Builder
+	b.WriteString(details.Name)
+	if details.Institute != "" {
+		b.WriteString(" at " + details.Institute)
+	}
+	if unit := strings.Join(nonEmpty(details.Faculty, details.Department), ", "); unit != "" {
+		b.WriteString(" (" + unit + ")")
+	}
+	b.WriteString(".")
+
+	if len(details.CareerPaths) > 0 {
+		titles := make([]string, 0, 3)
+		for _, career := range details.CareerPaths[:min(3, len(details.CareerPaths))] {
+			titles = append(titles, career.Title)
+		}
+		b.WriteString(" Leads to careers such as " + strings.Join(titles, ", ") + ".")
+	}
+	if duration := pathway.FormatDurationMonths(pathway.ProgramDurationMonths(details)); duration != "" {
+		b.WriteString(" Usually takes about " + duration + ".")
+	}
+	return b.String()
+}
+
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+// Package seo describes the public program and career pages of the frontend to
+// search engines and subscribers: a sitemap listing them, schema.org JSON-LD
+// markup per program that the frontend embeds for rich results, and an Atom feed
+// of newly added programs.
+package seo
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"go.uber.org/zap"
+)
+
+// maxSitemapURLs is the most URLs a single sitemap may list
+const maxSitemapURLs = 50000
+
+var (
+	// ErrSEODisabled is returned while no site URL is configured
+	ErrSEODisabled = errors.New("site URL is not configured")
+
+	// ErrProgramNotFound is returned for programs not in the graph
+	ErrProgramNotFound = errors.New("program not found")
+)
+
+// Graph is the part of the education graph the sitemap and feed are built from
+type Graph interface {
+	ListNames(ctx context.Context, kind string) ([]string, error)
+	ExistingNames(ctx context.Context, kind string, names []string) (map[string]bool, error)
+	RecentlyAddedPrograms(ctx context.Context, limit int) ([]neo4j.ProgramDetails, error)
+}
+
+// Service builds sitemaps and structured data
+type Service struct {
+	graph          Graph
+	pathwayService *pathway.Service
+	cfg            config.SEOConfig
+	logger         *zap.Logger
+}
+
+// NewService creates a new SEO service
+func NewService(graph Graph, pathwayService *pathway.Service, cfg config.SEOConfig, logger *zap.Logger) *Service {
+	return &Service{
+		graph:          graph,
+		pathwayService: pathwayService,
+		cfg:            cfg,
+		logger:         logger,
+	}
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// Sitemap lists the home page and every program and career page. Pages are dated
+// by the last graph change, when the API has seen one.
+func (s *Service) Sitemap(ctx context.Context) ([]byte, error) {
+	if s.cfg.SiteURL == "" {
+		return nil, ErrSEODisabled
+	}
+
+	programs, err := s.graph.ListNames(ctx, neo4j.KindProgram)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+	careers, err := s.graph.ListNames(ctx, neo4j.KindCareer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list careers: %w", err)
+	}
+
+	lastMod := ""
+	if changed := s.pathwayService.LastChanged(); !changed.IsZero() {
+		lastMod = changed.UTC().Format(time.DateOnly)
+	}
+
+	set := urlSet{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	set.URLs = append(set.URLs, sitemapURL{Loc: s.cfg.SiteURL + "/", ChangeFreq: "weekly", Priority: "1.0"})
+	for _, name := range programs {
+		set.URLs = append(set.URLs, sitemapURL{Loc: s.ProgramURL(name), LastMod: lastMod, ChangeFreq: "weekly", Priority: "0.8"})
+	}
+	for _, title := range careers {
+		set.URLs = append(set.URLs, sitemapURL{Loc: s.CareerURL(title), LastMod: lastMod, ChangeFreq: "monthly", Priority: "0.6"})
+	}
+	if len(set.URLs) > maxSitemapURLs {
+		s.logger.Warn("Sitemap truncated", zap.Int("urls", len(set.URLs)), zap.Int("limit", maxSitemapURLs))
+		set.URLs = set.URLs[:maxSitemapURLs]
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(set); err != nil {
+		return nil, fmt.Errorf("failed to encode sitemap: %w", err)
+	}
+	buf.WriteString("\n")
+
+	s.logger.Info("Sitemap built",
+		zap.Int("programs", len(programs)),
+		zap.Int("careers", len(careers)))
+	return buf.Bytes(), nil
+}
+
+// Enabled reports whether a site URL is configured; without one the frontend's
+// pages have no links
+func (s *Service) Enabled() bool {
+	return s.cfg.SiteURL != ""
+}
+
+// ProgramURL is the frontend page of a program
+func (s *Service) ProgramURL(name string) string {
+	return s.cfg.SiteURL + strings.ReplaceAll(s.cfg.ProgramPath, "{name}", url.PathEscape(name))
+}
+
+// CareerURL is the frontend page of a career
+func (s *Service) CareerURL(title string) string {
+	return s.cfg.SiteURL + strings.ReplaceAll(s.cfg.CareerPath, "{title}", url.PathEscape(title))
+}
+
+// ProgramStructuredData returns the schema.org markup of a program's page
+func (s *Service) ProgramStructuredData(ctx context.Context, name string) (*Program, error) {
+	existing, err := s.graph.ExistingNames(ctx, neo4j.KindProgram, []string{name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check program: %w", err)
+	}
+	if !existing[name] {
+		return nil, fmt.Errorf("%w: %q", ErrProgramNotFound, name)
+	}
+
+	details, err := s.pathwayService.GetProgramDetails(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pageURL := ""
+	if s.cfg.SiteURL != "" {
+		pageURL = s.ProgramURL(details.Name)
+	}
+	return newProgram(details, pageURL), nil
+}
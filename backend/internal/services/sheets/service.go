@@ -0,0 +1,622 @@
+package sheets
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"github.com/mayura-andrew/fastfinder/internal/services/importer"
+	"go.uber.org/zap"
+)
+
+// SourceGoogleSheets is the provenance source of programs synced from sheets
+const SourceGoogleSheets = "google_sheets"
+
+// Import fields a sheet column can be mapped to
+const (
+	FieldInstitute     = "institute"
+	FieldFaculty       = "faculty"
+	FieldDepartment    = "department"
+	FieldProgram       = "program"
+	FieldRequirements  = "requirements"
+	FieldPrerequisites = "prerequisites"
+	FieldCareers       = "careers"
+)
+
+// fields lists the import fields in column order
+var fields = []string{
+	FieldInstitute, FieldFaculty, FieldDepartment, FieldProgram,
+	FieldRequirements, FieldPrerequisites, FieldCareers,
+}
+
+var (
+	// ErrMappingNotFound is returned when syncing a mapping that does not exist
+	ErrMappingNotFound = errors.New("sheet mapping not found")
+
+	// ErrInvalidMapping is returned for mappings that cannot be used
+	ErrInvalidMapping = errors.New("invalid sheet mapping")
+
+	// ErrSheetUnavailable is returned when the sheet cannot be read
+	ErrSheetUnavailable = errors.New("sheet could not be read")
+)
+
+var (
+	mappingNamePattern   = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+	spreadsheetIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{20,100}$`)
+)
+
+// ProgramChange describes how an applied row changes an existing program
+type ProgramChange struct {
+	Program              string   `json:"program"`
+	Action               string   `json:"action"`
+	MovedFrom            string   `json:"moved_from,omitempty"`
+	AddedRequirements    []string `json:"added_requirements,omitempty"`
+	RemovedRequirements  []string `json:"removed_requirements,omitempty"`
+	AddedPrerequisites   []string `json:"added_prerequisites,omitempty"`
+	RemovedPrerequisites []string `json:"removed_prerequisites,omitempty"`
+	AddedCareers         []string `json:"added_careers,omitempty"`
+	RemovedCareers       []string `json:"removed_careers,omitempty"`
+}
+
+// SyncReport is the outcome of syncing a sheet
+type SyncReport struct {
+	Mapping   string           `json:"mapping"`
+	Rows      int              `json:"rows"`
+	Import    *importer.Report `json:"import"`
+	Changes   []ProgramChange  `json:"changes"`
+	Unchanged int              `json:"unchanged"`
+}
+
+// Service pulls counselor-maintained Google Sheets and upserts their programs
+// through the import pipeline
+type Service struct {
+	mappings    *mongodb.SheetMappingStore
+	importer    *importer.Service
+	changes     *changelog.Service
+	neo4jClient *neo4j.Client
+	cfg         config.SheetsConfig
+	httpClient  *http.Client
+	logger      *zap.Logger
+}
+
+// NewService creates a new sheets service
+func NewService(neo4jClient *neo4j.Client, mongoClient *mongodb.Client, importService *importer.Service, changelogService *changelog.Service, cfg config.SheetsConfig, logger *zap.Logger) *Service {
+	return &Service{
+		mappings:    mongodb.NewSheetMappingStore(mongoClient, logger),
+		importer:    importService,
+		changes:     changelogService,
+		neo4jClient: neo4jClient,
+		cfg:         cfg,
+		httpClient:  &http.Client{Timeout: cfg.Timeout},
+		logger:      logger,
+	}
+}
+
+// SaveMapping validates and stores a mapping. Fields without a column default to
+// a header with the field's own name.
+func (s *Service) SaveMapping(ctx context.Context, mapping *mongodb.SheetMapping) error {
+	s.logger.Debug("Saving sheet mapping", zap.String("name", mapping.Name))
+
+	mapping.Name = strings.ToLower(strings.TrimSpace(mapping.Name))
+	if !mappingNamePattern.MatchString(mapping.Name) {
+		return fmt.Errorf("%w: name must be lowercase letters, digits, '-' or '_'", ErrInvalidMapping)
+	}
+	if !spreadsheetIDPattern.MatchString(mapping.SpreadsheetID) {
+		return fmt.Errorf("%w: spreadsheet_id is not a Google Sheets ID", ErrInvalidMapping)
+	}
+	if mapping.HeaderRow <= 0 {
+		mapping.HeaderRow = 1
+	}
+	if mapping.ListSeparator == "" {
+		mapping.ListSeparator = ";"
+	}
+
+	columns := make(map[string]string, len(fields))
+	for field, header := range mapping.Columns {
+		if !isField(field) {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidMapping, field)
+		}
+		columns[field] = strings.TrimSpace(header)
+	}
+	for _, field := range fields {
+		if columns[field] == "" {
+			columns[field] = field
+		}
+	}
+	mapping.Columns = columns
+
+	for field := range mapping.Defaults {
+		if !isField(field) || field == FieldProgram {
+			return fmt.Errorf("%w: field %q cannot have a default", ErrInvalidMapping, field)
+		}
+	}
+
+	if err := s.mappings.Save(ctx, mapping); err != nil {
+		return err
+	}
+
+	s.logger.Info("Sheet mapping saved",
+		zap.String("name", mapping.Name),
+		zap.String("spreadsheet_id", mapping.SpreadsheetID))
+	return nil
+}
+
+// ListMappings returns all stored mappings
+func (s *Service) ListMappings(ctx context.Context) ([]mongodb.SheetMapping, error) {
+	return s.mappings.List(ctx)
+}
+
+// Sync pulls the sheet behind a mapping and applies the difference to the graph:
+// programs added or changed in the sheet are upserted, and programs synced from
+// it before but no longer listed are removed. Unchanged rows are not rewritten.
+// With dryRun the changes are computed without writing. Probable duplicates of
+// existing names block the sync unless allowDuplicates is set. Applied changes
+// are emitted to the change log.
+func (s *Service) Sync(ctx context.Context, name string, dryRun, allowDuplicates bool) (*SyncReport, error) {
+	s.logger.Debug("Syncing sheet",
+		zap.String("mapping", name),
+		zap.Bool("dry_run", dryRun),
+		zap.Bool("allow_duplicates", allowDuplicates))
+
+	mapping, err := s.mappings.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if mapping == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMappingNotFound, name)
+	}
+
+	values, err := s.fetch(ctx, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := mapRows(mapping, values)
+	if err != nil {
+		return nil, err
+	}
+
+	// Diff against the graph before anything is written
+	changes, unchanged, err := s.diff(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	provenance := neo4j.NewProvenance(SourceGoogleSheets, sheetURL(mapping))
+	removed, err := s.removed(ctx, rows, provenance.SourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only rows that change the graph go through the import
+	report, err := s.importDelta(ctx, rows, changes, dryRun, allowDuplicates, provenance)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Applied {
+		applied := removed[:0]
+		for _, program := range removed {
+			if err := s.neo4jClient.DeleteEntity(ctx, neo4j.KindProgram, program); err != nil && !errors.Is(err, neo4j.ErrEntityNotFound) {
+				// The rest of the sync is applied already, so report the program
+				// as still present rather than failing
+				s.logger.Warn("Failed to remove program no longer in sheet",
+					zap.String("program", program),
+					zap.Error(err))
+				continue
+			}
+			applied = append(applied, program)
+		}
+		removed = applied
+	}
+	for _, program := range removed {
+		changes = append(changes, ProgramChange{Program: program, Action: changelog.ActionDelete})
+	}
+
+	if report.Applied {
+		if err := s.changes.Emit(ctx, changeLog(changes, provenance)); err != nil {
+			s.logger.Warn("Failed to record sheet changes", zap.Error(err))
+		}
+		if err := s.mappings.MarkSynced(ctx, mapping.Name, time.Now()); err != nil {
+			s.logger.Warn("Failed to record sheet sync", zap.Error(err))
+		}
+	}
+
+	s.logger.Info("Sheet synced",
+		zap.String("mapping", name),
+		zap.Bool("dry_run", dryRun),
+		zap.Bool("applied", report.Applied),
+		zap.Int("rows", len(rows)),
+		zap.Int("changes", len(changes)),
+		zap.Int("removed", len(removed)))
+
+	return &SyncReport{
+		Mapping:   mapping.Name,
+		Rows:      len(rows),
+		Import:    report,
+		Changes:   changes,
+		Unchanged: unchanged,
+	}, nil
+}
+
+// SyncAll applies every mapping in turn. A mapping that fails to sync is logged
+// and skipped; an error is returned only when all of them fail.
+func (s *Service) SyncAll(ctx context.Context) error {
+	mappings, err := s.mappings.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, mapping := range mappings {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		report, err := s.Sync(ctx, mapping.Name, false, false)
+		if err == nil && !report.Import.Applied {
+			err = fmt.Errorf("%d invalid rows", report.Import.Summary.Invalid)
+		}
+		if err != nil {
+			s.logger.Warn("Scheduled sheet sync failed",
+				zap.String("mapping", mapping.Name),
+				zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", mapping.Name, err))
+		}
+	}
+
+	if len(mappings) > 0 && len(errs) == len(mappings) {
+		return fmt.Errorf("all sheet syncs failed: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// importDelta imports the rows of created and updated programs. Row numbers in
+// the returned report refer to the sheet's rows. When nothing changed, the sync
+// counts as applied without touching the graph.
+func (s *Service) importDelta(ctx context.Context, rows []importer.Row, changes []ProgramChange, dryRun, allowDuplicates bool, provenance neo4j.Provenance) (*importer.Report, error) {
+	changed := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		changed[change.Program] = true
+	}
+
+	var (
+		delta   []importer.Row
+		rowNums []int
+	)
+	for i, row := range rows {
+		if changed[row.Program] {
+			delta = append(delta, row)
+			rowNums = append(rowNums, i+1)
+		}
+	}
+
+	if len(delta) == 0 {
+		return &importer.Report{DryRun: dryRun, Applied: !dryRun, Rows: []importer.RowReport{}}, nil
+	}
+
+	report, err := s.importer.ImportPrograms(ctx, delta, dryRun, allowDuplicates, provenance)
+	if err != nil {
+		return nil, err
+	}
+	for i := range report.Rows {
+		report.Rows[i].Row = rowNums[i]
+	}
+	return report, nil
+}
+
+// removed returns the programs previously synced from the sheet that are no
+// longer in it. Programs edited since by another source are left alone.
+func (s *Service) removed(ctx context.Context, rows []importer.Row, sourceURL string) ([]string, error) {
+	synced, err := s.neo4jClient.NamesBySource(ctx, neo4j.KindProgram, SourceGoogleSheets, sourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	inSheet := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		inSheet[row.Program] = true
+	}
+
+	var removed []string
+	for _, program := range synced {
+		if !inSheet[program] {
+			removed = append(removed, program)
+		}
+	}
+	return removed, nil
+}
+
+// changeLog converts the changes of a sync into change log entries
+func changeLog(changes []ProgramChange, provenance neo4j.Provenance) []mongodb.GraphChange {
+	entries := make([]mongodb.GraphChange, 0, len(changes))
+	for _, change := range changes {
+		var details []string
+		if change.MovedFrom != "" {
+			details = append(details, "moved from "+change.MovedFrom)
+		}
+		describe := func(label string, values []string) {
+			if len(values) > 0 {
+				details = append(details, label+": "+strings.Join(values, ", "))
+			}
+		}
+		describe("added requirements", change.AddedRequirements)
+		describe("removed requirements", change.RemovedRequirements)
+		describe("added prerequisites", change.AddedPrerequisites)
+		describe("removed prerequisites", change.RemovedPrerequisites)
+		describe("added careers", change.AddedCareers)
+		describe("removed careers", change.RemovedCareers)
+
+		entries = append(entries, mongodb.GraphChange{
+			Kind:      neo4j.KindProgram,
+			Name:      change.Program,
+			Action:    change.Action,
+			Source:    provenance.Source,
+			SourceURL: provenance.SourceURL,
+			Details:   details,
+		})
+	}
+	return entries
+}
+
+// fetch reads the sheet's cell values, through the Sheets API when a key is
+// configured and the CSV export otherwise
+func (s *Service) fetch(ctx context.Context, mapping *mongodb.SheetMapping) ([][]string, error) {
+	if s.cfg.APIKey != "" {
+		return s.fetchValues(ctx, mapping)
+	}
+	return s.fetchCSV(ctx, mapping)
+}
+
+func (s *Service) fetchValues(ctx context.Context, mapping *mongodb.SheetMapping) ([][]string, error) {
+	sheetRange := mapping.Sheet
+	if sheetRange == "" {
+		sheetRange = "A:Z"
+	}
+	endpoint := fmt.Sprintf("https://sheets.googleapis.com/v4/spreadsheets/%s/values/%s",
+		mapping.SpreadsheetID, url.PathEscape(sheetRange))
+
+	body, err := s.get(ctx, endpoint, s.cfg.APIKey)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result struct {
+		Values [][]interface{} `json:"values"`
+	}
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: invalid Sheets API response: %v", ErrSheetUnavailable, err)
+	}
+
+	values := make([][]string, 0, len(result.Values))
+	for _, row := range result.Values {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, fmt.Sprint(cell))
+		}
+		values = append(values, cells)
+	}
+	return values, nil
+}
+
+func (s *Service) fetchCSV(ctx context.Context, mapping *mongodb.SheetMapping) ([][]string, error) {
+	endpoint := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/export?format=csv", mapping.SpreadsheetID)
+	if mapping.GID != "" {
+		endpoint += "&gid=" + url.QueryEscape(mapping.GID)
+	}
+
+	body, err := s.get(ctx, endpoint, "")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	values, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid CSV export (is the sheet shared by link?): %v", ErrSheetUnavailable, err)
+	}
+	return values, nil
+}
+
+// get reads endpoint, sending apiKey in a header when set. The key is kept out
+// of the URL because failed requests report their URL, and errors are logged
+// and shown to admins.
+func (s *Service) get(ctx context.Context, endpoint, apiKey string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sheet request: %w", err)
+	}
+	if apiKey != "" {
+		req.Header.Set("X-Goog-Api-Key", apiKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrSheetUnavailable, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: Google returned status %d", ErrSheetUnavailable, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// sheetURL returns the browser URL of a mapping's sheet
+func sheetURL(mapping *mongodb.SheetMapping) string {
+	u := "https://docs.google.com/spreadsheets/d/" + mapping.SpreadsheetID
+	if mapping.GID != "" {
+		u += "/edit#gid=" + url.QueryEscape(mapping.GID)
+	}
+	return u
+}
+
+// mapRows converts sheet values into import rows using the mapping's columns and
+// defaults. Rows without a program name are skipped.
+func mapRows(mapping *mongodb.SheetMapping, values [][]string) ([]importer.Row, error) {
+	if len(values) < mapping.HeaderRow {
+		return nil, fmt.Errorf("%w: sheet has no header row %d", ErrInvalidMapping, mapping.HeaderRow)
+	}
+
+	index := map[string]int{}
+	for i, header := range values[mapping.HeaderRow-1] {
+		index[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+
+	columns := map[string]int{}
+	for field, header := range mapping.Columns {
+		if i, ok := index[strings.ToLower(header)]; ok {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns[FieldProgram]; !ok {
+		return nil, fmt.Errorf("%w: sheet has no %q column", ErrInvalidMapping, mapping.Columns[FieldProgram])
+	}
+
+	var rows []importer.Row
+	for _, record := range values[mapping.HeaderRow:] {
+		cell := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				if value := strings.TrimSpace(record[i]); value != "" {
+					return value
+				}
+			}
+			return strings.TrimSpace(mapping.Defaults[field])
+		}
+		list := func(field string) string {
+			value := cell(field)
+			if mapping.ListSeparator != ";" {
+				value = strings.ReplaceAll(value, mapping.ListSeparator, ";")
+			}
+			return value
+		}
+
+		if cell(FieldProgram) == "" {
+			continue
+		}
+		rows = append(rows, importer.Row{
+			Institute:     cell(FieldInstitute),
+			Faculty:       cell(FieldFaculty),
+			Department:    cell(FieldDepartment),
+			Program:       cell(FieldProgram),
+			Requirements:  list(FieldRequirements),
+			Prerequisites: list(FieldPrerequisites),
+			Careers:       list(FieldCareers),
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, importer.ErrEmptyImport
+	}
+	return rows, nil
+}
+
+// diff compares rows with the programs already in the graph
+func (s *Service) diff(ctx context.Context, rows []importer.Row) ([]ProgramChange, int, error) {
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Program)
+	}
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindProgram, names)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	changes := []ProgramChange{}
+	unchanged := 0
+	for _, row := range rows {
+		if !existing[row.Program] {
+			changes = append(changes, ProgramChange{Program: row.Program, Action: importer.ActionCreate})
+			continue
+		}
+
+		current, err := s.neo4jClient.GetProgramDetails(ctx, row.Program)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		change := ProgramChange{Program: row.Program, Action: importer.ActionUpdate}
+		if row.Department != current.Department || (row.Department == "" && row.Institute != current.Institute) {
+			change.MovedFrom = strings.Trim(current.Institute+" / "+current.Department, " /")
+		}
+
+		var reqs, prereqs, careers []string
+		for _, q := range current.Requirements {
+			reqs = append(reqs, q.Name)
+		}
+		for _, p := range current.Prerequisites {
+			prereqs = append(prereqs, p.Name)
+		}
+		for _, c := range current.CareerPaths {
+			careers = append(careers, c.Title)
+		}
+		change.AddedRequirements, change.RemovedRequirements = compare(reqs, splitList(row.Requirements))
+		change.AddedPrerequisites, change.RemovedPrerequisites = compare(prereqs, splitList(row.Prerequisites))
+		change.AddedCareers, change.RemovedCareers = compare(careers, splitList(row.Careers))
+
+		if change.MovedFrom == "" && len(change.AddedRequirements)+len(change.RemovedRequirements)+
+			len(change.AddedPrerequisites)+len(change.RemovedPrerequisites)+
+			len(change.AddedCareers)+len(change.RemovedCareers) == 0 {
+			unchanged++
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes, unchanged, nil
+}
+
+// compare returns the values added to and removed from current
+func compare(current, next []string) (added, removed []string) {
+	seen := map[string]bool{}
+	for _, v := range current {
+		seen[v] = true
+	}
+	kept := map[string]bool{}
+	for _, v := range next {
+		kept[v] = true
+		if !seen[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range current {
+		if !kept[v] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func isField(field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
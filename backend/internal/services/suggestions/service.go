@@ -0,0 +1,385 @@
+package suggestions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/admin"
+	"github.com/mayura-andrew/fastfinder/internal/services/moderation"
+	"github.com/mayura-andrew/fastfinder/internal/services/review"
+	"github.com/mayura-andrew/fastfinder/pkg/fuzzy"
+	"go.uber.org/zap"
+)
+
+const (
+	// SourceCommunity identifies user suggestions in the review queue
+	SourceCommunity = "community"
+
+	// KindCommunityEdit is the review item kind of a suggested correction
+	KindCommunityEdit = "community_edit"
+
+	// Suggestion actions. Single-valued fields are set; relationship lists are
+	// added to or removed from one entry at a time.
+	ActionSet    = "set"
+	ActionAdd    = "add"
+	ActionRemove = "remove"
+
+	// Bounds on free text accepted from users
+	maxValueLength   = 200
+	maxCommentLength = 1000
+
+	// Existing entities scoring at least this similarity to a suggested value are
+	// offered to the reviewer
+	suggestionScore = 0.6
+	maxSuggestions  = 5
+)
+
+var (
+	// ErrInvalidSuggestion is returned when a suggestion is malformed
+	ErrInvalidSuggestion = errors.New("invalid suggestion")
+
+	// ErrTargetNotFound is returned when a suggestion refers to an entity that does not exist
+	ErrTargetNotFound = errors.New("suggested entity not found")
+)
+
+// fieldKinds maps each editable field to the kind of entity its value names
+var fieldKinds = map[string]string{
+	"institute":     neo4j.KindInstitute,
+	"faculty":       neo4j.KindFaculty,
+	"department":    neo4j.KindDepartment,
+	"requirements":  neo4j.KindQualification,
+	"prerequisites": neo4j.KindProgram,
+	"careers":       neo4j.KindCareer,
+}
+
+// listFields are the fields holding relationship lists rather than a single value
+var listFields = map[string]bool{
+	"requirements":  true,
+	"prerequisites": true,
+	"careers":       true,
+}
+
+// editableFields lists the fields users may suggest changes to, per entity kind.
+// Every kind can be renamed.
+var editableFields = map[string][]string{
+	neo4j.KindProgram:    {"institute", "department", "requirements", "prerequisites", "careers"},
+	neo4j.KindFaculty:    {"institute"},
+	neo4j.KindDepartment: {"faculty"},
+}
+
+// Suggestion is a user's proposed correction to a single field of a graph entity
+type Suggestion struct {
+	Entity      string `json:"entity" bson:"entity"`
+	Name        string `json:"name" bson:"name"`
+	Field       string `json:"field" bson:"field"`
+	Action      string `json:"action" bson:"action"`
+	Value       string `json:"value" bson:"value"`
+	Comment     string `json:"comment,omitempty" bson:"comment,omitempty"`
+	SubmittedBy string `json:"submitted_by,omitempty" bson:"submitted_by,omitempty"`
+}
+
+// Submission is the outcome of submitting a suggestion
+type Submission struct {
+	Summary string `json:"summary"`
+	// Created is false when an identical suggestion was already waiting for review
+	Created bool `json:"created"`
+}
+
+// Service accepts crowd-sourced corrections and applies them once an admin approves
+type Service struct {
+	neo4jClient  *neo4j.Client
+	adminService *admin.Service
+	review       *review.Service
+	moderation   *moderation.Service
+	logger       *zap.Logger
+}
+
+// NewService creates a new suggestion service and registers its review applier
+func NewService(neo4jClient *neo4j.Client, adminService *admin.Service, reviewService *review.Service, logger *zap.Logger) *Service {
+	s := &Service{
+		neo4jClient:  neo4jClient,
+		adminService: adminService,
+		review:       reviewService,
+		logger:       logger,
+	}
+
+	reviewService.RegisterApplier(KindCommunityEdit, s.applyCommunityEdit)
+
+	return s
+}
+
+// UseModeration screens the value and comment of suggestions before they are
+// queued, quarantining flagged ones
+func (s *Service) UseModeration(moderationService *moderation.Service) {
+	s.moderation = moderationService
+}
+
+// Submit validates a suggestion and queues it for moderation. Values that do not
+// name an existing entity are queued with the closest matches so the reviewer can
+// pick the intended one.
+func (s *Service) Submit(ctx context.Context, suggestion Suggestion) (*Submission, error) {
+	s.logger.Debug("Submitting suggestion",
+		zap.String("entity", suggestion.Entity),
+		zap.String("field", suggestion.Field))
+
+	suggestion = normalize(suggestion)
+	if err := validate(suggestion); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.neo4jClient.ExistingNames(ctx, suggestion.Entity, []string{suggestion.Name})
+	if err != nil {
+		return nil, err
+	}
+	if !existing[suggestion.Name] {
+		return nil, fmt.Errorf("%w: %s %q", ErrTargetNotFound, suggestion.Entity, suggestion.Name)
+	}
+
+	var matches []mongodb.ReviewSuggestion
+	if valueKind, ok := fieldKinds[suggestion.Field]; ok && suggestion.Action != ActionRemove {
+		matches, err = s.closestNames(ctx, valueKind, suggestion.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	payload, err := review.EncodePayload(suggestion)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &mongodb.ReviewItem{
+		Source:      SourceCommunity,
+		Kind:        KindCommunityEdit,
+		Summary:     summary(suggestion),
+		DedupKey:    dedupKey(suggestion),
+		Payload:     payload,
+		Suggestions: matches,
+	}
+	var created, quarantined bool
+	if s.moderation != nil {
+		created, quarantined, err = s.moderation.Enqueue(ctx, item, suggestion.Value, suggestion.Comment)
+	} else {
+		created, err = s.review.Enqueue(ctx, item)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Suggestion queued for review",
+		zap.String("entity", suggestion.Entity),
+		zap.String("name", suggestion.Name),
+		zap.String("field", suggestion.Field),
+		zap.Bool("created", created),
+		zap.Bool("quarantined", quarantined))
+
+	return &Submission{Summary: item.Summary, Created: created}, nil
+}
+
+// closestNames returns existing entities resembling value, or nothing when value
+// names an existing entity exactly
+func (s *Service) closestNames(ctx context.Context, kind, value string) ([]mongodb.ReviewSuggestion, error) {
+	names, err := s.neo4jClient.ListNames(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if name == value {
+			return nil, nil
+		}
+	}
+
+	var matches []mongodb.ReviewSuggestion
+	for _, m := range fuzzy.Rank(value, names, suggestionScore, maxSuggestions) {
+		matches = append(matches, mongodb.ReviewSuggestion{Name: m.Name, Score: m.Score})
+	}
+	return matches, nil
+}
+
+// applyCommunityEdit applies an approved suggestion through the admin service so
+// the usual reference checks and cache invalidation apply. The reviewer may
+// correct the suggested value with a "value" resolution.
+func (s *Service) applyCommunityEdit(ctx context.Context, item *mongodb.ReviewItem, resolution map[string]interface{}) error {
+	var suggestion Suggestion
+	if err := review.DecodePayload(item.Payload, &suggestion); err != nil {
+		return err
+	}
+	if value := review.ResolutionString(resolution, "value"); value != "" {
+		suggestion.Value = strings.Join(strings.Fields(value), " ")
+	}
+
+	entity, err := s.editedEntity(ctx, suggestion)
+	if err != nil {
+		return err
+	}
+
+	if err := s.adminService.UpdateEntity(ctx, suggestion.Entity, suggestion.Name, entity, item.ResolvedBy); err != nil {
+		if errors.Is(err, neo4j.ErrEntityNotFound) || errors.Is(err, neo4j.ErrEntityExists) ||
+			errors.Is(err, neo4j.ErrReferenceNotFound) || errors.Is(err, neo4j.ErrInvalidEntity) {
+			return fmt.Errorf("%w: %v", review.ErrInvalidResolution, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// editedEntity builds the admin update that applies a suggestion. List edits are
+// made against the program's current relationships.
+func (s *Service) editedEntity(ctx context.Context, suggestion Suggestion) (neo4j.GraphEntity, error) {
+	var entity neo4j.GraphEntity
+
+	switch suggestion.Field {
+	case "name":
+		entity.Name = suggestion.Value
+		return entity, nil
+	case "institute":
+		entity.Institute = suggestion.Value
+		return entity, nil
+	case "faculty":
+		entity.Faculty = suggestion.Value
+		return entity, nil
+	case "department":
+		entity.Department = suggestion.Value
+		return entity, nil
+	}
+
+	details, err := s.neo4jClient.GetProgramDetails(ctx, suggestion.Name)
+	if err != nil {
+		return entity, fmt.Errorf("%w: %v", review.ErrInvalidResolution, err)
+	}
+
+	var current []string
+	switch suggestion.Field {
+	case "requirements":
+		for _, q := range details.Requirements {
+			current = append(current, q.Name)
+		}
+	case "prerequisites":
+		for _, p := range details.Prerequisites {
+			current = append(current, p.Name)
+		}
+	case "careers":
+		for _, c := range details.CareerPaths {
+			current = append(current, c.Title)
+		}
+	}
+
+	updated, err := editList(current, suggestion.Action, suggestion.Value)
+	if err != nil {
+		return entity, err
+	}
+
+	switch suggestion.Field {
+	case "requirements":
+		entity.Requirements = updated
+	case "prerequisites":
+		entity.Prerequisites = updated
+	case "careers":
+		entity.Careers = updated
+	}
+	return entity, nil
+}
+
+// editList adds or removes value, returning a non-nil list so an emptied list
+// clears the relationships
+func editList(current []string, action, value string) ([]string, error) {
+	updated := make([]string, 0, len(current)+1)
+	found := false
+	for _, name := range current {
+		if name == value {
+			found = true
+			if action == ActionRemove {
+				continue
+			}
+		}
+		updated = append(updated, name)
+	}
+
+	switch {
+	case action == ActionRemove && !found:
+		return nil, fmt.Errorf("%w: %q is no longer linked", review.ErrInvalidResolution, value)
+	case action == ActionAdd && !found:
+		updated = append(updated, value)
+	}
+	return updated, nil
+}
+
+// normalize collapses whitespace in names and trims the comment. Single-valued
+// fields default to the set action.
+func normalize(suggestion Suggestion) Suggestion {
+	suggestion.Entity = strings.ToLower(strings.TrimSpace(suggestion.Entity))
+	suggestion.Field = strings.ToLower(strings.TrimSpace(suggestion.Field))
+	suggestion.Action = strings.ToLower(strings.TrimSpace(suggestion.Action))
+	if suggestion.Action == "" && !listFields[suggestion.Field] {
+		suggestion.Action = ActionSet
+	}
+	suggestion.Name = strings.Join(strings.Fields(suggestion.Name), " ")
+	suggestion.Value = strings.Join(strings.Fields(suggestion.Value), " ")
+	suggestion.Comment = strings.TrimSpace(suggestion.Comment)
+	return suggestion
+}
+
+// validate checks that a suggestion names an editable field and an action that
+// fits it
+func validate(suggestion Suggestion) error {
+	if !neo4j.IsEntityKind(suggestion.Entity) {
+		return fmt.Errorf("%w: unknown entity %q", ErrInvalidSuggestion, suggestion.Entity)
+	}
+	if suggestion.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSuggestion)
+	}
+	if suggestion.Field != "name" && !containsField(editableFields[suggestion.Entity], suggestion.Field) {
+		return fmt.Errorf("%w: field %q cannot be changed on a %s", ErrInvalidSuggestion, suggestion.Field, suggestion.Entity)
+	}
+
+	if listFields[suggestion.Field] {
+		if suggestion.Action != ActionAdd && suggestion.Action != ActionRemove {
+			return fmt.Errorf("%w: %s accepts the actions %q and %q", ErrInvalidSuggestion, suggestion.Field, ActionAdd, ActionRemove)
+		}
+	} else if suggestion.Action != ActionSet {
+		return fmt.Errorf("%w: %s accepts the action %q", ErrInvalidSuggestion, suggestion.Field, ActionSet)
+	}
+
+	if suggestion.Value == "" {
+		return fmt.Errorf("%w: value is required", ErrInvalidSuggestion)
+	}
+	if len(suggestion.Value) > maxValueLength {
+		return fmt.Errorf("%w: value exceeds %d characters", ErrInvalidSuggestion, maxValueLength)
+	}
+	if len(suggestion.Comment) > maxCommentLength {
+		return fmt.Errorf("%w: comment exceeds %d characters", ErrInvalidSuggestion, maxCommentLength)
+	}
+	return nil
+}
+
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// summary describes a suggestion for the review queue listing
+func summary(suggestion Suggestion) string {
+	switch suggestion.Action {
+	case ActionAdd:
+		return fmt.Sprintf("Add %q to %s of %s %q", suggestion.Value, suggestion.Field, suggestion.Entity, suggestion.Name)
+	case ActionRemove:
+		return fmt.Sprintf("Remove %q from %s of %s %q", suggestion.Value, suggestion.Field, suggestion.Entity, suggestion.Name)
+	default:
+		return fmt.Sprintf("Set %s of %s %q to %q", suggestion.Field, suggestion.Entity, suggestion.Name, suggestion.Value)
+	}
+}
+
+// dedupKey folds repeated submissions of the same correction into one item
+func dedupKey(suggestion Suggestion) string {
+	return strings.ToLower(strings.Join([]string{
+		SourceCommunity, suggestion.Entity, suggestion.Name, suggestion.Field, suggestion.Action, suggestion.Value,
+	}, "|"))
+}
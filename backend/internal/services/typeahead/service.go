@@ -0,0 +1,283 @@
+package typeahead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/changelog"
+	"github.com/mayura-andrew/fastfinder/pkg/fuzzy"
+	"go.uber.org/zap"
+)
+
+const (
+	// MinQueryLength is the shortest query matched, as single letters match
+	// most of the index
+	MinQueryLength = 2
+
+	// Defaults and bounds for the number of matches returned
+	DefaultMatches = 8
+	MaxMatches     = 20
+
+	// Scores of the ways a query can match a name, best first
+	scorePrefix     = 1.0
+	scoreWordPrefix = 0.9
+	scoreContains   = 0.75
+	scoreTypo       = 0.6
+
+	// minTypoLength is the shortest query word that may contain a typo
+	minTypoLength = 4
+)
+
+// ErrInvalidQuery is returned for a query that is too short or has an unknown kind
+var ErrInvalidQuery = errors.New("invalid suggest query")
+
+// kinds are the entity kinds kept in the index
+var kinds = []string{neo4j.KindInstitute, neo4j.KindProgram, neo4j.KindCareer}
+
+// Match is an indexed name matching a query. Institute is set for programs.
+type Match struct {
+	Kind      string  `json:"kind"`
+	Name      string  `json:"name"`
+	Institute string  `json:"institute,omitempty"`
+	Score     float64 `json:"score"`
+}
+
+// entry is an indexed name with its normalized forms computed once per refresh
+type entry struct {
+	kind       string
+	name       string
+	institute  string
+	lower      string
+	normalized string
+	words      []string
+}
+
+// Graph is the education graph the index is built from. It is implemented by
+// neo4j.Client, and by memstore.Graph in demo mode.
+type Graph interface {
+	ListNames(ctx context.Context, kind string) ([]string, error)
+	PathwayProfiles(ctx context.Context, kind string, names []string) ([]neo4j.PathwayProfile, error)
+}
+
+// Service answers typeahead queries from an in-memory index of institute,
+// program and career names, so search boxes do not wait on the graph
+type Service struct {
+	neo4jClient Graph
+	logger      *zap.Logger
+
+	mu          sync.RWMutex
+	entries     []entry
+	refreshedAt time.Time
+
+	// refreshing guards against overlapping refreshes triggered by changes
+	refreshing sync.Mutex
+}
+
+// NewService creates a new typeahead service. The index is empty until the
+// first Refresh.
+func NewService(neo4jClient Graph, logger *zap.Logger) *Service {
+	return &Service{
+		neo4jClient: neo4jClient,
+		logger:      logger,
+	}
+}
+
+// Refresh rebuilds the index from the graph, returning the number of names
+// indexed. Queries keep using the previous index until the new one is ready.
+func (s *Service) Refresh(ctx context.Context) (int, error) {
+	s.logger.Debug("Refreshing typeahead index")
+
+	s.refreshing.Lock()
+	defer s.refreshing.Unlock()
+
+	var entries []entry
+	for _, kind := range kinds {
+		if kind == neo4j.KindProgram {
+			profiles, err := s.neo4jClient.PathwayProfiles(ctx, kind, nil)
+			if err != nil {
+				return 0, err
+			}
+			for _, profile := range profiles {
+				entries = append(entries, newEntry(kind, profile.Name, profile.Institute))
+			}
+			continue
+		}
+
+		names, err := s.neo4jClient.ListNames(ctx, kind)
+		if err != nil {
+			return 0, err
+		}
+		for _, name := range names {
+			entries = append(entries, newEntry(kind, name, ""))
+		}
+	}
+
+	s.mu.Lock()
+	s.entries = entries
+	s.refreshedAt = time.Now().UTC()
+	s.mu.Unlock()
+
+	s.logger.Info("Typeahead index refreshed", zap.Int("names", len(entries)))
+	return len(entries), nil
+}
+
+// RefreshOnChange is a change log listener that rebuilds the index in the
+// background, so new and renamed entities can be found without waiting for the
+// next scheduled refresh
+func (s *Service) RefreshOnChange(_ context.Context, changes []mongodb.GraphChange) {
+	indexed := slices.ContainsFunc(changes, func(change mongodb.GraphChange) bool {
+		return slices.Contains(kinds, change.Kind) || change.Kind == changelog.KindGraph
+	})
+	if !indexed {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if _, err := s.Refresh(ctx); err != nil {
+			s.logger.Warn("Failed to refresh typeahead index after graph changes", zap.Error(err))
+		}
+	}()
+}
+
+// RefreshedAt returns when the index was last rebuilt, or the zero time if it
+// has not been built yet
+func (s *Service) RefreshedAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.refreshedAt
+}
+
+// Suggest returns the indexed names best matching a partial query, optionally of
+// one kind. Names starting with the query rank first, then names with words
+// starting with the query words, names containing the query and finally names
+// matching despite a typo; ties go to the shorter name.
+func (s *Service) Suggest(query, kind string, limit int) ([]Match, error) {
+	lower := strings.ToLower(strings.TrimSpace(query))
+	if len([]rune(lower)) < MinQueryLength {
+		return nil, fmt.Errorf("%w: type at least %d characters", ErrInvalidQuery, MinQueryLength)
+	}
+	if kind != "" && !slices.Contains(kinds, kind) {
+		return nil, fmt.Errorf("%w: type must be one of %s", ErrInvalidQuery, strings.Join(kinds, ", "))
+	}
+	if limit <= 0 {
+		limit = DefaultMatches
+	}
+	if limit > MaxMatches {
+		limit = MaxMatches
+	}
+
+	// A query of stop words alone normalizes to nothing, which every name starts with
+	normalized := fuzzy.Normalize(query)
+	if normalized == "" {
+		normalized = lower
+	}
+	queryWords := strings.Fields(normalized)
+
+	s.mu.RLock()
+	matches := []Match{}
+	for _, e := range s.entries {
+		if kind != "" && e.kind != kind {
+			continue
+		}
+		score := e.score(lower, normalized, queryWords)
+		if score == 0 {
+			continue
+		}
+		matches = append(matches, Match{
+			Kind:      e.kind,
+			Name:      e.name,
+			Institute: e.institute,
+			Score:     score,
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if len(matches[i].Name) != len(matches[j].Name) {
+			return len(matches[i].Name) < len(matches[j].Name)
+		}
+		return matches[i].Name < matches[j].Name
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+func newEntry(kind, name, institute string) entry {
+	normalized := fuzzy.Normalize(name)
+	return entry{
+		kind:       kind,
+		name:       name,
+		institute:  institute,
+		lower:      strings.ToLower(name),
+		normalized: normalized,
+		words:      strings.Fields(normalized),
+	}
+}
+
+// score rates how well the entry matches a query, 0 meaning no match
+func (e entry) score(lower, normalized string, queryWords []string) float64 {
+	switch {
+	case strings.HasPrefix(e.lower, lower) || strings.HasPrefix(e.normalized, normalized):
+		return scorePrefix
+	case e.everyWord(strings.HasPrefix, queryWords):
+		return scoreWordPrefix
+	case strings.Contains(e.lower, lower) || strings.Contains(e.normalized, normalized):
+		return scoreContains
+	case e.everyWord(typoPrefix, queryWords):
+		return scoreTypo
+	}
+	return 0
+}
+
+// everyWord reports whether each query word matches some word of the name
+func (e entry) everyWord(match func(word, queryWord string) bool, queryWords []string) bool {
+	for _, queryWord := range queryWords {
+		found := false
+		for _, word := range e.words {
+			if match(word, queryWord) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// typoPrefix reports whether word starts with queryWord give or take one edit,
+// for query words long enough that a single edit is likely a typo
+func typoPrefix(word, queryWord string) bool {
+	query := []rune(queryWord)
+	if len(query) < minTypoLength {
+		return false
+	}
+	runes := []rune(word)
+	// Compare against prefixes one rune either side of the query length, so a
+	// dropped or doubled letter still matches
+	for n := len(query) - 1; n <= len(query)+1; n++ {
+		if n <= 0 || n > len(runes) {
+			continue
+		}
+		if fuzzy.Levenshtein(string(runes[:n]), queryWord) <= 1 {
+			return true
+		}
+	}
+	return false
+}
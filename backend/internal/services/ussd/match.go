@@ -0,0 +1,337 @@
+package ussd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/sms"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/discovery"
+	"go.uber.org/zap"
+)
+
+const (
+	// MatchCount is how many programs a match session texts to the caller
+	MatchCount = 3
+
+	// MaxSMSLength keeps a match message to three SMS segments
+	MaxSMSLength = 459
+
+	// matchTimeout bounds finding and texting a caller's matches, which happens
+	// after their session has closed
+	matchTimeout = 30 * time.Second
+
+	// sessionTTL is how long a session is remembered as answered, so a gateway
+	// retrying the final request does not text the caller twice
+	sessionTTL = 10 * time.Minute
+
+	// maxTextsPerNumber bounds the match texts a number receives per textWindow,
+	// so the session cannot be used to flood a phone or run up SMS costs
+	maxTextsPerNumber = 3
+	textWindow        = 24 * time.Hour
+
+	// localPrefix is the country code of the only numbers matches are texted to
+	localPrefix = "+94"
+)
+
+// Qualifications the graph's programs require, held by each stream
+const (
+	qualificationOLPass      = "G.C.E. (O/L) Examination Pass"
+	qualificationOLNotPassed = "G.C.E. (O/L) Examination Not Passed"
+	qualificationALPass      = "G.C.E. (A/L) Examination Pass"
+)
+
+// Stream is what a caller has studied, as the qualifications it gives them and
+// words of program names suited to it
+type Stream struct {
+	Label          string
+	Qualifications []string
+	Keywords       []string
+}
+
+// Interest is a field a caller would like to work in, described for semantic
+// matching and as words of program and career names in it
+type Interest struct {
+	Label       string
+	Description string
+	Keywords    []string
+}
+
+// Streams are offered in this order
+var Streams = []Stream{
+	{"A/L Science", []string{qualificationOLPass, qualificationALPass}, []string{"science", "engineering", "technology"}},
+	{"A/L Commerce", []string{qualificationOLPass, qualificationALPass}, []string{"commerce", "business", "management", "accounting"}},
+	{"A/L Arts", []string{qualificationOLPass, qualificationALPass}, []string{"arts", "language", "social", "design"}},
+	{"A/L Technology", []string{qualificationOLPass, qualificationALPass}, []string{"technology", "engineering", "industrial"}},
+	{"O/L only", []string{qualificationOLPass}, nil},
+	{"Did not pass O/L", []string{qualificationOLNotPassed}, nil},
+}
+
+// Interests are offered in this order
+var Interests = []Interest{
+	{"Engineering", "building and designing machines, structures and electronics", []string{"engineer", "mechanical", "civil", "electrical", "electronic", "mechatronic"}},
+	{"IT and computing", "software, computers, programming and networks", []string{"software", "computer", "ict", "information", "network", "data"}},
+	{"Agriculture", "farming, food, plantations and the environment", []string{"agricultur", "farm", "food", "plantation", "environment"}},
+	{"Business", "business, accounting, management and finance", []string{"business", "management", "accounting", "finance", "commerce", "marketing"}},
+	{"Health", "medicine, nursing, health and caring for people", []string{"health", "medic", "nurs", "pharmac", "care"}},
+	{"Arts and design", "art, design, fashion, textiles and media", []string{"design", "art", "fashion", "textile", "apparel", "media"}},
+}
+
+// Session is a gateway request of a match session
+type Session struct {
+	ID    string
+	Phone string
+	Input string // the caller's choices so far, joined by Separator
+}
+
+// Match is a program a caller is eligible for that suits their interest
+type Match struct {
+	Program   string
+	Institute string
+}
+
+// RespondMatch runs a session asking the caller's stream and interest, then
+// texts them the programs they are eligible for that suit it best. It returns
+// the screen and whether it ends the session. The programs are found and texted
+// after the session closes, as gateways wait only a few seconds for a screen.
+func (s *Service) RespondMatch(session Session) (string, bool) {
+	s.logger.Debug("Answering USSD match session", zap.String("input", session.Input))
+
+	// Gateways always identify the session; without it retries cannot be told apart
+	if session.ID == "" {
+		return "Sorry, this session could not be identified. Please dial again.", true
+	}
+
+	var chosen []int
+	valid := true
+	if session.Input != "" {
+		for _, choice := range strings.Split(session.Input, Separator) {
+			choice = strings.TrimSpace(choice)
+			if choice == choiceBack && len(chosen) > 0 {
+				chosen, valid = chosen[:len(chosen)-1], true
+				continue
+			}
+			options := len(Streams)
+			if len(chosen) == 1 {
+				options = len(Interests)
+			}
+			var index int
+			if _, err := fmt.Sscanf(choice, "%d", &index); err != nil || index < 1 || index > options {
+				valid = false
+				continue
+			}
+			chosen, valid = append(chosen, index-1), true
+			if len(chosen) == 2 {
+				break
+			}
+		}
+	}
+
+	var lines []string
+	nav := ""
+	switch len(chosen) {
+	case 0:
+		lines = append(lines, "Programs for you", "What did you study?")
+		for i, stream := range Streams {
+			lines = append(lines, fmt.Sprintf("%d. %s", i+1, stream.Label))
+		}
+	case 1:
+		lines = append(lines, "What interests you?")
+		for i, interest := range Interests {
+			lines = append(lines, fmt.Sprintf("%d. %s", i+1, interest.Label))
+		}
+		nav = "\n" + choiceBack + ". Back"
+	default:
+		phone, err := sms.NormalizeNumber(session.Phone)
+		if err != nil || !strings.HasPrefix(phone, localPrefix) {
+			return "Sorry, results can only be sent to a Sri Lankan mobile number.", true
+		}
+		if s.firstAnswer(session.ID) {
+			if !s.admitText(phone) {
+				return "Sorry, this number has had its results for today. Please try again tomorrow.", true
+			}
+			stream, interest := Streams[chosen[0]], Interests[chosen[1]]
+			go s.sendMatches(phone, stream, interest)
+		}
+		return "Thank you. Your top programs will arrive by SMS shortly.", true
+	}
+
+	body := strings.Join(lines, "\n")
+	if !valid {
+		body = "Invalid choice.\n" + body
+	}
+	return fit(body, len(nav)) + nav, false
+}
+
+// firstAnswer records a session as answered, reporting whether it was not
+// already. Sessions are remembered per instance, which is enough as gateways
+// retry against the same address within seconds.
+func (s *Service) firstAnswer(sessionID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, at := range s.answered {
+		if now.Sub(at) > sessionTTL {
+			delete(s.answered, id)
+		}
+	}
+	if _, ok := s.answered[sessionID]; ok {
+		return false
+	}
+	s.answered[sessionID] = now
+	return true
+}
+
+// admitText records a match text to phone, reporting whether the number is
+// still under maxTextsPerNumber in the current window. Like answered sessions,
+// texts are counted per instance.
+func (s *Service) admitText(phone string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for number, times := range s.texted {
+		recent := slices.DeleteFunc(times, func(at time.Time) bool { return now.Sub(at) > textWindow })
+		if len(recent) == 0 {
+			delete(s.texted, number)
+		} else {
+			s.texted[number] = recent
+		}
+	}
+	if len(s.texted[phone]) >= maxTextsPerNumber {
+		return false
+	}
+	s.texted[phone] = append(s.texted[phone], now)
+	return true
+}
+
+// sendMatches finds the caller's matches and texts them
+func (s *Service) sendMatches(phone string, stream Stream, interest Interest) {
+	ctx, cancel := context.WithTimeout(context.Background(), matchTimeout)
+	defer cancel()
+
+	matches, err := s.FindMatches(ctx, stream, interest)
+	if err != nil {
+		s.logger.Error("Failed to find USSD matches",
+			zap.String("stream", stream.Label),
+			zap.String("interest", interest.Label),
+			zap.Error(err))
+		return
+	}
+	if err := s.sms.Send(ctx, phone, matchMessage(stream, interest, matches)); err != nil {
+		s.logger.Error("Failed to text USSD matches", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("USSD matches sent",
+		zap.String("stream", stream.Label),
+		zap.String("interest", interest.Label),
+		zap.Int("matches", len(matches)))
+}
+
+// FindMatches returns up to MatchCount programs open to a stream that suit an
+// interest. Semantic matches come first when discovery is available; programs
+// whose names and careers share the most words with the interest and the
+// stream make up the rest.
+func (s *Service) FindMatches(ctx context.Context, stream Stream, interest Interest) ([]Match, error) {
+	eligible := map[string]neo4j.EducationPath{}
+	var order []string
+	err := s.pathway.StreamCareerPaths(ctx, stream.Qualifications, func(path neo4j.EducationPath) error {
+		name := path.Programs[0].Name
+		if _, ok := eligible[name]; !ok {
+			eligible[name] = path
+			order = append(order, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []Match{}
+	add := func(name string) {
+		path, ok := eligible[name]
+		if !ok || len(matches) == MatchCount || slices.ContainsFunc(matches, func(m Match) bool { return m.Program == name }) {
+			return
+		}
+		matches = append(matches, Match{Program: name, Institute: path.Institute})
+	}
+
+	suggestions, err := s.discovery.Match(ctx, stream.Label+" student interested in "+interest.Description, discovery.MaxSuggestions)
+	if err != nil && !errors.Is(err, discovery.ErrUnavailable) {
+		s.logger.Warn("Semantic matching failed, matching by keywords", zap.Error(err))
+	}
+	for _, suggestion := range suggestions {
+		if suggestion.Kind == neo4j.KindProgram {
+			add(suggestion.Name)
+		}
+		for _, program := range suggestion.Programs {
+			add(program)
+		}
+	}
+
+	scores := map[string]int{}
+	for _, name := range order {
+		path := eligible[name]
+		text := strings.ToLower(strings.Join([]string{name, path.Faculty, path.Department}, " "))
+		careers := make([]string, 0, len(path.Careers))
+		for _, career := range path.Careers {
+			careers = append(careers, career.Title)
+		}
+		careerText := strings.ToLower(strings.Join(careers, " "))
+		scores[name] = 2*countKeywords(text, interest.Keywords) + countKeywords(careerText, interest.Keywords)
+		if scores[name] > 0 {
+			scores[name] += countKeywords(text, stream.Keywords)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b string) int { return scores[b] - scores[a] })
+	for _, name := range order {
+		if scores[name] > 0 {
+			add(name)
+		}
+	}
+	return matches, nil
+}
+
+// matchMessage writes a caller's matches as a text message
+func matchMessage(stream Stream, interest Interest, matches []Match) string {
+	if len(matches) == 0 {
+		return fmt.Sprintf("No programs for %s students matched %s. Dial again to try another interest.", stream.Label, interest.Label)
+	}
+
+	lines := []string{fmt.Sprintf("Programs for %s students interested in %s:", stream.Label, interest.Label)}
+	for i, match := range matches {
+		line := fmt.Sprintf("%d. %s", i+1, match.Program)
+		if match.Institute != "" {
+			line += ", " + match.Institute
+		}
+		lines = append(lines, line)
+	}
+	footer := "\nCheck entry requirements with the institute before applying."
+
+	text := asciiPunctuation.Replace(strings.Join(lines, "\n"))
+	if runes := []rune(text); len(runes) > MaxSMSLength-len(footer) {
+		text = strings.TrimSpace(string(runes[:MaxSMSLength-len(footer)-3])) + "..."
+	}
+	return text + footer
+}
+
+// countKeywords counts the keywords starting a word of text
+func countKeywords(text string, keywords []string) int {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	count := 0
+	for _, keyword := range keywords {
+		if slices.ContainsFunc(words, func(word string) bool { return strings.HasPrefix(word, keyword) }) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,328 @@
+// Package ussd answers pathway questions as short numbered text menus, for USSD
+// and IVR gateways serving students on basic phones, and texts callers the
+// programs matching their stream and interests. Gateways send everything the
+// caller has entered so far, so a session needs no state on the server.
+package ussd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/sms"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/discovery"
+	"github.com/mayura-andrew/fastfinder/internal/services/pathway"
+	"github.com/mayura-andrew/fastfinder/internal/services/typeahead"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxScreenLength is the most characters a USSD screen shows
+	MaxScreenLength = 182
+
+	// PageSize is the number of options listed per screen
+	PageSize = 4
+
+	// Separator joins the caller's choices in a gateway's input
+	Separator = "*"
+
+	// maxOptionLength bounds an option's name so a page fits one screen
+	maxOptionLength = 28
+
+	// maxDetails is how many requirements and careers a program screen lists
+	maxDetails = 3
+
+	choiceBack = "0"
+	choiceMore = "9"
+)
+
+// asciiPunctuation replaces punctuation outside the GSM alphabet, which basic
+// phones show as boxes
+var asciiPunctuation = strings.NewReplacer("–", "-", "—", "-", "‘", "'", "’", "'", "“", `"`, "”", `"`, "…", "...")
+
+// abbreviations shorten the words most program and institute names share, so
+// names stay distinct when cut to a line
+var abbreviations = strings.NewReplacer(
+	"Bachelor of Science", "BSc",
+	"Bachelor of", "B.",
+	"Honours", "Hons",
+	" in ", " ",
+	"Engineering", "Eng",
+	"Technology", "Tech",
+	"Information", "Info",
+	"Management", "Mgmt",
+	"Advanced", "Adv",
+	"Certificate", "Cert",
+	"Diploma", "Dip",
+	"Programme", "Prog",
+	"National", "Natl",
+	"University", "Univ",
+	"Institute", "Inst",
+	"Training", "Trg",
+)
+
+type screen int
+
+const (
+	screenMain screen = iota
+	screenSearch
+	screenResults
+	screenInstitutes
+	screenPrograms
+	screenProgram
+)
+
+// frame is a screen the caller has reached, with the search query, institute
+// or program it is about and the page of options shown
+type frame struct {
+	screen screen
+	arg    string
+	page   int
+}
+
+// Service answers gateway sessions
+type Service struct {
+	pathway   *pathway.Service
+	typeahead *typeahead.Service
+	discovery *discovery.Service
+	sms       *sms.Sender
+	logger    *zap.Logger
+
+	mu       sync.Mutex
+	answered map[string]time.Time   // match sessions by ID, when they were answered
+	texted   map[string][]time.Time // numbers, when they were texted matches
+}
+
+// NewService creates a new USSD service
+func NewService(pathwayService *pathway.Service, typeaheadService *typeahead.Service, discoveryService *discovery.Service, sender *sms.Sender, logger *zap.Logger) *Service {
+	return &Service{
+		pathway:   pathwayService,
+		typeahead: typeaheadService,
+		discovery: discoveryService,
+		sms:       sender,
+		logger:    logger,
+		answered:  make(map[string]time.Time),
+		texted:    make(map[string][]time.Time),
+	}
+}
+
+// Respond replays a session's input, the caller's choices joined by Separator,
+// and returns the screen it leads to. Choices that match no option leave the
+// caller where they were; when the last one does, the screen says so.
+func (s *Service) Respond(ctx context.Context, input string) (string, error) {
+	// Called on every keypress, so only logged at debug level
+	s.logger.Debug("Answering USSD session", zap.String("input", input))
+
+	stack := []frame{{screen: screenMain}}
+	valid := true
+	if input != "" {
+		for _, choice := range strings.Split(input, Separator) {
+			var err error
+			stack, valid, err = s.advance(ctx, stack, strings.TrimSpace(choice))
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	body, nav, err := s.render(ctx, stack[len(stack)-1])
+	if err != nil {
+		return "", err
+	}
+	if !valid {
+		body = "Invalid choice.\n" + body
+	}
+	return fit(body, len(nav)) + nav, nil
+}
+
+// advance applies one choice to the screens reached so far
+func (s *Service) advance(ctx context.Context, stack []frame, choice string) ([]frame, bool, error) {
+	top := stack[len(stack)-1]
+	if choice == choiceBack && top.screen != screenMain {
+		return stack[:len(stack)-1], true, nil
+	}
+
+	switch top.screen {
+	case screenMain:
+		switch choice {
+		case "1":
+			return append(stack, frame{screen: screenSearch}), true, nil
+		case "2":
+			return append(stack, frame{screen: screenInstitutes}), true, nil
+		}
+		return stack, false, nil
+
+	case screenSearch:
+		if len([]rune(choice)) < typeahead.MinQueryLength {
+			return stack, false, nil
+		}
+		return append(stack, frame{screen: screenResults, arg: choice}), true, nil
+
+	case screenResults, screenInstitutes, screenPrograms:
+		options, err := s.options(ctx, top)
+		if err != nil {
+			return nil, false, err
+		}
+		if choice == choiceMore && (top.page+1)*PageSize < len(options) {
+			top.page++
+			stack[len(stack)-1] = top
+			return stack, true, nil
+		}
+		var index int
+		if _, err := fmt.Sscanf(choice, "%d", &index); err != nil || index < 1 || index > PageSize {
+			return stack, false, nil
+		}
+		index += top.page*PageSize - 1
+		if index >= len(options) {
+			return stack, false, nil
+		}
+		next := screenProgram
+		if top.screen == screenInstitutes {
+			next = screenPrograms
+		}
+		return append(stack, frame{screen: next, arg: options[index]}), true, nil
+	}
+	return stack, false, nil
+}
+
+// options are the names a listing screen offers, across all its pages
+func (s *Service) options(ctx context.Context, f frame) ([]string, error) {
+	names := []string{}
+	switch f.screen {
+	case screenResults:
+		matches, err := s.typeahead.Suggest(f.arg, neo4j.KindProgram, typeahead.MaxMatches)
+		if errors.Is(err, typeahead.ErrInvalidQuery) {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			names = append(names, match.Name)
+		}
+	case screenInstitutes:
+		institutes, err := s.pathway.GetAllInstitutes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, institute := range institutes {
+			names = append(names, institute.Name)
+		}
+	case screenPrograms:
+		programs, err := s.pathway.GetProgramsByInstitute(ctx, f.arg)
+		if err != nil {
+			return nil, err
+		}
+		for _, program := range programs {
+			names = append(names, program.Name)
+		}
+	}
+	return names, nil
+}
+
+// render writes a screen as plain text, returning its content and the
+// navigation lines that follow it, which are never cut to fit the screen
+func (s *Service) render(ctx context.Context, f frame) (string, string, error) {
+	back := "\n" + choiceBack + ". Back"
+	switch f.screen {
+	case screenMain:
+		return "Study pathways\n1. Find a program\n2. Browse institutes", "", nil
+	case screenSearch:
+		return "Type part of a program name", back, nil
+	case screenProgram:
+		body, err := s.renderProgram(ctx, f.arg)
+		return body, back, err
+	}
+
+	options, err := s.options(ctx, f)
+	if err != nil {
+		return "", "", err
+	}
+	var title string
+	switch f.screen {
+	case screenResults:
+		title = "Results: " + shorten(f.arg)
+	case screenInstitutes:
+		title = "Institutes"
+	case screenPrograms:
+		title = shorten(f.arg)
+	}
+	if len(options) == 0 {
+		return title + "\nNone found.", back, nil
+	}
+	if pages := (len(options) + PageSize - 1) / PageSize; pages > 1 {
+		title += fmt.Sprintf(" %d/%d", f.page+1, pages)
+	}
+
+	lines := []string{title}
+	start := f.page * PageSize
+	end := min(start+PageSize, len(options))
+	for i, option := range options[start:end] {
+		lines = append(lines, fmt.Sprintf("%d. %s", i+1, shorten(option)))
+	}
+	if end < len(options) {
+		back = "\n" + choiceMore + ". More" + back
+	}
+	return strings.Join(lines, "\n"), back, nil
+}
+
+// renderProgram writes what a program asks for and leads to, as much as fits
+func (s *Service) renderProgram(ctx context.Context, name string) (string, error) {
+	details, err := s.pathway.GetProgramDetails(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{details.Name}
+	if details.Institute != "" {
+		lines = append(lines, "At "+details.Institute)
+	}
+	var requirements []string
+	for _, requirement := range details.Requirements {
+		if len(requirements) == maxDetails {
+			break
+		}
+		requirements = append(requirements, requirement.Name)
+	}
+	if len(requirements) > 0 {
+		lines = append(lines, "Needs: "+strings.Join(requirements, "; "))
+	}
+	if details.RequiresAptitudeTest {
+		lines = append(lines, "Aptitude test required")
+	}
+	var careers []string
+	for _, career := range details.CareerPaths {
+		if len(careers) == maxDetails {
+			break
+		}
+		careers = append(careers, career.Title)
+	}
+	if len(careers) > 0 {
+		lines = append(lines, "Careers: "+strings.Join(careers, ", "))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// fit cuts text to one screen, leaving room for reserved characters
+func fit(text string, reserved int) string {
+	limit := MaxScreenLength - reserved
+	text = asciiPunctuation.Replace(text)
+	if runes := []rune(text); len(runes) > limit {
+		return strings.TrimSpace(string(runes[:limit-3])) + "..."
+	}
+	return text
+}
+
+// shorten abbreviates and cuts a name to fit a line of a listing
+func shorten(name string) string {
+	name = abbreviations.Replace(name)
+	if runes := []rune(name); len(runes) > maxOptionLength {
+		return strings.TrimSpace(string(runes[:maxOptionLength-3])) + "..."
+	}
+	return name
+}
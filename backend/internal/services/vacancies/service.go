@@ -0,0 +1,176 @@
+package vacancies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"github.com/mayura-andrew/fastfinder/internal/data/neo4j"
+	"github.com/mayura-andrew/fastfinder/internal/services/scraper"
+	"go.uber.org/zap"
+)
+
+const (
+	// Defaults and bounds for the number of listings returned per career
+	DefaultListingLimit = 10
+	MaxListingLimit     = 50
+
+	// recentWindow is how far back a posting counts as newly advertised
+	recentWindow = 30 * 24 * time.Hour
+)
+
+var (
+	// ErrNotConfigured is returned when no job board search URL is set
+	ErrNotConfigured = errors.New("job board search URLs are not configured")
+
+	// ErrCareerNotFound is returned for vacancies of a career not in the graph
+	ErrCareerNotFound = errors.New("career not found")
+)
+
+// CareerVacancies is the job market evidence for a career: how many postings are
+// open, how many appeared recently, and the most recent of them
+type CareerVacancies struct {
+	Career       string            `json:"career"`
+	OpenCount    int64             `json:"open_count"`
+	NewLast30    int64             `json:"new_last_30_days"`
+	Listings     []mongodb.Vacancy `json:"listings"`
+	LastSyncedAt *time.Time        `json:"last_synced_at,omitempty"`
+}
+
+// SyncReport summarises a vacancy sync run
+type SyncReport struct {
+	Careers  int      `json:"careers"`
+	Listings int      `json:"listings"`
+	Failed   []string `json:"failed,omitempty"`
+}
+
+// Service pulls job postings matching career titles from job boards
+type Service struct {
+	neo4jClient *neo4j.Client
+	store       *mongodb.VacancyStore
+	scraper     *scraper.JobBoardScraper
+	openWindow  time.Duration
+	logger      *zap.Logger
+}
+
+// NewService creates a new vacancy service
+func NewService(neo4jClient *neo4j.Client, mongoClient *mongodb.Client, cfg config.JobBoardConfig, logger *zap.Logger) *Service {
+	return &Service{
+		neo4jClient: neo4jClient,
+		store:       mongodb.NewVacancyStore(mongoClient, cfg.VacancyRetention, logger),
+		scraper:     scraper.NewJobBoardScraper(cfg.SearchURLs, cfg.Timeout, cfg.RequestDelay, logger),
+		openWindow:  cfg.VacancyOpenWindow,
+		logger:      logger,
+	}
+}
+
+// Sync searches the job boards for every career in the graph and records the
+// postings found
+func (s *Service) Sync(ctx context.Context) (*SyncReport, error) {
+	if !s.scraper.Configured() {
+		return nil, ErrNotConfigured
+	}
+
+	titles, err := s.neo4jClient.ListNames(ctx, neo4j.KindCareer)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Syncing vacancies from job boards", zap.Int("careers", len(titles)))
+
+	report := &SyncReport{Careers: len(titles)}
+	for _, title := range titles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		listings, err := s.scraper.SearchListings(ctx, title)
+		if err != nil {
+			report.Failed = append(report.Failed, title)
+			continue
+		}
+
+		vacancies := make([]mongodb.Vacancy, 0, len(listings))
+		for _, listing := range listings {
+			vacancies = append(vacancies, mongodb.Vacancy{
+				CareerTitle: title,
+				Title:       listing.Title,
+				URL:         listing.URL,
+				Board:       listing.Board,
+				District:    listing.District,
+			})
+		}
+		if err := s.store.Record(ctx, vacancies); err != nil {
+			return nil, err
+		}
+		report.Listings += len(vacancies)
+	}
+
+	if len(titles) > 0 && len(report.Failed) == len(titles) {
+		return nil, errors.New("job boards could not be reached for any career")
+	}
+
+	s.logger.Info("Vacancy sync completed",
+		zap.Int("careers", report.Careers),
+		zap.Int("listings", report.Listings),
+		zap.Int("failed", len(report.Failed)))
+	return report, nil
+}
+
+// ForCareer returns the open postings of a career, newest first
+func (s *Service) ForCareer(ctx context.Context, careerTitle string, limit int) (*CareerVacancies, error) {
+	s.logger.Debug("Fetching vacancies", zap.String("career", careerTitle))
+
+	if limit <= 0 {
+		limit = DefaultListingLimit
+	}
+	if limit > MaxListingLimit {
+		limit = MaxListingLimit
+	}
+
+	existing, err := s.neo4jClient.ExistingNames(ctx, neo4j.KindCareer, []string{careerTitle})
+	if err != nil {
+		return nil, err
+	}
+	if !existing[careerTitle] {
+		return nil, fmt.Errorf("%w: %q", ErrCareerNotFound, careerTitle)
+	}
+
+	now := time.Now()
+	openSince := now.Add(-s.openWindow)
+
+	openCount, newCount, err := s.store.CountOpen(ctx, careerTitle, openSince, now.Add(-recentWindow))
+	if err != nil {
+		return nil, err
+	}
+	listings, err := s.store.ListOpen(ctx, careerTitle, openSince, limit)
+	if err != nil {
+		return nil, err
+	}
+	lastSynced, err := s.store.LastSeen(ctx, careerTitle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CareerVacancies{
+		Career:       careerTitle,
+		OpenCount:    openCount,
+		NewLast30:    newCount,
+		Listings:     listings,
+		LastSyncedAt: lastSynced,
+	}, nil
+}
+
+// OpenByCareer returns the number of open postings of each career with any
+func (s *Service) OpenByCareer(ctx context.Context) (map[string]int64, error) {
+	return s.store.CountOpenByCareer(ctx, time.Now().Add(-s.openWindow))
+}
+
+// OpenByDistrict returns the number of open postings in each district named by
+// the postings
+func (s *Service) OpenByDistrict(ctx context.Context) (map[string]int64, error) {
+	return s.store.CountOpenByDistrict(ctx, time.Now().Add(-s.openWindow))
+}
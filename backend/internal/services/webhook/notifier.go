@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mayura-andrew/fastfinder/internal/core/config"
+	"github.com/mayura-andrew/fastfinder/internal/data/mongodb"
+	"go.uber.org/zap"
+)
+
+const (
+	// EventGraphChanged is the event name of graph change notifications
+	EventGraphChanged = "graph.changed"
+
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body,
+	// keyed with the shared secret
+	SignatureHeader = "X-Signature-256"
+
+	// retryDelay is the wait before the first retry; it doubles on every attempt
+	retryDelay = time.Second
+)
+
+// Event is the JSON body posted to webhooks
+type Event struct {
+	Event   string                `json:"event"`
+	Changes []mongodb.GraphChange `json:"changes"`
+	SentAt  time.Time             `json:"sent_at"`
+}
+
+// Notifier posts graph changes to the configured webhooks so downstream
+// consumers can refresh their copies without polling the change log
+type Notifier struct {
+	urls        []string
+	secret      string
+	maxAttempts int
+	httpClient  *http.Client
+	logger      *zap.Logger
+}
+
+// NewNotifier creates a notifier for the configured webhook URLs
+func NewNotifier(cfg config.WebhookConfig, logger *zap.Logger) *Notifier {
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Notifier{
+		urls:        cfg.URLs,
+		secret:      cfg.Secret,
+		maxAttempts: maxAttempts,
+		httpClient:  &http.Client{Timeout: cfg.Timeout},
+		logger:      logger,
+	}
+}
+
+// Notify is a change log listener. Webhooks are called in the background, as
+// the changes are already applied and the request that made them should not
+// wait on slow or failing consumers.
+func (n *Notifier) Notify(ctx context.Context, changes []mongodb.GraphChange) {
+	body, err := json.Marshal(Event{
+		Event:   EventGraphChanged,
+		Changes: changes,
+		SentAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		n.logger.Error("Failed to encode webhook event", zap.Error(err))
+		return
+	}
+
+	for _, url := range n.urls {
+		go n.deliver(url, body, len(changes))
+	}
+}
+
+// deliver posts an event to one webhook, retrying failed deliveries with
+// exponential backoff. Client errors other than timeouts and rate limiting are
+// not retried.
+func (n *Notifier) deliver(url string, body []byte, changes int) {
+	delay := retryDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := n.post(url, body)
+		if err == nil {
+			n.logger.Debug("Webhook delivered",
+				zap.String("url", url),
+				zap.Int("changes", changes),
+				zap.Int("attempt", attempt))
+			return
+		}
+		if !retry || attempt >= n.maxAttempts {
+			n.logger.Warn("Webhook delivery failed",
+				zap.String("url", url),
+				zap.Int("changes", changes),
+				zap.Int("attempts", attempt),
+				zap.Error(err))
+			return
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// post sends one delivery attempt, reporting whether a failure is worth retrying
+func (n *Notifier) post(url string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(n.secret, body))
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+}
+
+// Sign returns the hex HMAC-SHA256 of body keyed with secret, as sent in
+// SignatureHeader, so consumers can verify that a payload came from us
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,180 @@
+// Package fuzzy provides approximate string matching for mapping free-text names
+// (from handbooks, registries and user input) onto existing graph entities.
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Match is a candidate with its similarity to the query, between 0 and 1
+type Match struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+// stopWords are dropped before comparing names, as handbooks and registries are
+// inconsistent about including them
+var stopWords = map[string]bool{
+	"of": true, "in": true, "and": true, "the": true, "&": true, "for": true,
+}
+
+// abbreviations are expanded before comparing names, so "Univ. of Moratuwa" and
+// "University of Moratuwa" normalize to the same words
+var abbreviations = map[string]string{
+	"univ":  "university",
+	"uni":   "university",
+	"dept":  "department",
+	"fac":   "faculty",
+	"inst":  "institute",
+	"coll":  "college",
+	"engg":  "engineering",
+	"sci":   "science",
+	"tech":  "technology",
+	"mgt":   "management",
+	"mgmt":  "management",
+	"natl":  "national",
+	"intl":  "international",
+	"assoc": "associate",
+}
+
+// Normalize lowercases a name, replaces punctuation with spaces, expands common
+// abbreviations and drops stop words
+func Normalize(s string) string {
+	mapped := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		default:
+			return ' '
+		}
+	}, s)
+
+	words := strings.Fields(mapped)
+	kept := words[:0]
+	for _, w := range words {
+		if full, ok := abbreviations[w]; ok {
+			w = full
+		}
+		if !stopWords[w] {
+			kept = append(kept, w)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// Similarity scores two names between 0 (unrelated) and 1 (equal after
+// normalization). It takes the best of edit-distance and trigram similarity, so
+// it tolerates both typos and reordered or abbreviated words.
+func Similarity(a, b string) float64 {
+	na, nb := Normalize(a), Normalize(b)
+	if na == "" || nb == "" {
+		return 0
+	}
+	if na == nb {
+		return 1
+	}
+
+	lev := levenshteinSimilarity(na, nb)
+	tri := trigramSimilarity(na, nb)
+	if tri > lev {
+		return tri
+	}
+	return lev
+}
+
+// Rank returns the candidates scoring at least minScore, best first
+func Rank(query string, candidates []string, minScore float64, limit int) []Match {
+	var matches []Match
+	for _, candidate := range candidates {
+		if score := Similarity(query, candidate); score >= minScore {
+			matches = append(matches, Match{Name: candidate, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Best returns the closest candidate and its score, or an empty match when there
+// are no candidates
+func Best(query string, candidates []string) Match {
+	var best Match
+	for _, candidate := range candidates {
+		if score := Similarity(query, candidate); score > best.Score {
+			best = Match{Name: candidate, Score: score}
+		}
+	}
+	return best
+}
+
+// Levenshtein returns the edit distance between two strings, counted in runes
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func levenshteinSimilarity(a, b string) float64 {
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(Levenshtein(a, b))/float64(longest)
+}
+
+// trigramSimilarity is the Jaccard similarity of the padded character trigrams
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(s) {
+		r := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			set[string(r[i:i+3])] = true
+		}
+	}
+	return set
+}
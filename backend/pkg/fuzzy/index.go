@@ -0,0 +1,54 @@
+package fuzzy
+
+import "slices"
+
+// Index holds a set of names for finding those closest to a query without
+// scoring every name: only names sharing a trigram with the query are compared
+type Index struct {
+	names    []string
+	exact    map[string]bool
+	postings map[string][]int // positions in names by normalized trigram
+}
+
+// NewIndex indexes names for lookups
+func NewIndex(names []string) *Index {
+	ix := &Index{
+		names:    names,
+		exact:    make(map[string]bool, len(names)),
+		postings: make(map[string][]int),
+	}
+	for i, name := range names {
+		ix.exact[name] = true
+		for t := range trigrams(Normalize(name)) {
+			ix.postings[t] = append(ix.postings[t], i)
+		}
+	}
+	return ix
+}
+
+// Has reports whether name is indexed exactly as given
+func (ix *Index) Has(name string) bool {
+	return ix.exact[name]
+}
+
+// Lookup returns the indexed names scoring at least minScore against query,
+// best first and in index order on a tie, as Rank does
+func (ix *Index) Lookup(query string, minScore float64, limit int) []Match {
+	seen := make(map[int]bool)
+	var positions []int
+	for t := range trigrams(Normalize(query)) {
+		for _, i := range ix.postings[t] {
+			if !seen[i] {
+				seen[i] = true
+				positions = append(positions, i)
+			}
+		}
+	}
+	slices.Sort(positions)
+
+	candidates := make([]string, len(positions))
+	for j, i := range positions {
+		candidates[j] = ix.names[i]
+	}
+	return Rank(query, candidates, minScore, limit)
+}